@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"syscall"
 
 	"github.com/spf13/cobra"
@@ -25,6 +26,7 @@ import (
 	"github.com/tyemirov/gix/internal/audit"
 	"github.com/tyemirov/gix/internal/branches"
 	syncflowcmd "github.com/tyemirov/gix/internal/branches/syncflow"
+	"github.com/tyemirov/gix/internal/commitsign"
 	"github.com/tyemirov/gix/internal/githubauth"
 	"github.com/tyemirov/gix/internal/llmclient"
 	"github.com/tyemirov/gix/internal/migrate"
@@ -92,11 +94,16 @@ type Application struct {
 	webFlagValue                      bool
 	webBindFlagValue                  string
 	webPortFlagValue                  string
+	webTLSFlagValue                   bool
+	webWorkspaceFlagValue             string
 	versionResolver                   func(context.Context) string
 	versionExitEnabled                bool
 	exitFunction                      func(int)
 	webRunner                         webRunner
 	llmClientFactory                  llmclient.ClientFactory
+	webInspectionCacheOnce            sync.Once
+	webInspectionCache                *audit.InspectionCache
+	webAuditSnapshots                 webAuditSnapshotStore
 }
 
 // NewApplication assembles a fully wired CLI application instance.
@@ -162,6 +169,8 @@ func NewApplication() *Application {
 	cobraCommand.PersistentFlags().BoolVar(&application.webFlagValue, webFlagNameConstant, false, webFlagUsageConstant)
 	cobraCommand.PersistentFlags().StringVar(&application.webBindFlagValue, webBindFlagNameConstant, "", webBindFlagUsageConstant)
 	cobraCommand.PersistentFlags().StringVar(&application.webPortFlagValue, webPortFlagNameConstant, "", webPortFlagUsageConstant)
+	cobraCommand.PersistentFlags().BoolVar(&application.webTLSFlagValue, webTLSFlagNameConstant, false, webTLSFlagUsageConstant)
+	cobraCommand.PersistentFlags().StringVar(&application.webWorkspaceFlagValue, webWorkspaceFlagNameConstant, "", webWorkspaceFlagUsageConstant)
 	application.rootFlagValues = flagutils.BindRootFlags(
 		cobraCommand,
 		flagutils.RootFlagValues{},
@@ -303,6 +312,13 @@ func (application *Application) initializeConfiguration(command *cobra.Command)
 	if llmConfigurationError := application.configuration.LLM.validateConnections(); llmConfigurationError != nil {
 		return fmt.Errorf("invalid llm configuration: %w", llmConfigurationError)
 	}
+	commitSigning, signingConfigurationError := application.configuration.Signing.commitSigning()
+	if signingConfigurationError != nil {
+		return fmt.Errorf("invalid signing configuration: %w", signingConfigurationError)
+	}
+	if _, webConfigurationError := application.configuration.Web.normalizedWorkspaces(); webConfigurationError != nil {
+		return fmt.Errorf("invalid web configuration: %w", webConfigurationError)
+	}
 	operationConfigurations, configurationBuildError := newOperationConfigurations(application.configuration.Operations)
 	if configurationBuildError != nil {
 		return configurationBuildError
@@ -357,6 +373,7 @@ func (application *Application) initializeConfiguration(command *cobra.Command)
 		updatedContext = application.commandContextAccessor.WithExecutionFlags(updatedContext, executionFlags)
 		updatedContext = application.commandContextAccessor.WithLogLevel(updatedContext, application.configuration.Common.LogLevel)
 		updatedContext = githubauth.WithCredential(updatedContext, application.configuration.GitHub.Credential)
+		updatedContext = commitsign.WithConfiguration(updatedContext, commitSigning)
 
 		updatedContext = application.commandContextAccessor.WithBranchContext(updatedContext, utils.BranchContext{RequireClean: true})
 
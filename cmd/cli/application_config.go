@@ -7,7 +7,10 @@ import (
 
 	mapstructure "github.com/go-viper/mapstructure/v2"
 
+	"github.com/tyemirov/gix/internal/commitsign"
 	"github.com/tyemirov/gix/internal/llmclient"
+	pathutils "github.com/tyemirov/gix/internal/utils/path"
+	"github.com/tyemirov/gix/internal/web"
 	workflowpkg "github.com/tyemirov/gix/internal/workflow"
 )
 
@@ -52,8 +55,10 @@ type ApplicationConfiguration struct {
 	Common     ApplicationCommonConfiguration      `yaml:"common"`
 	GitHub     ApplicationGitHubConfiguration      `yaml:"github"`
 	LLM        ApplicationLLMConfiguration         `yaml:"llm"`
+	Signing    ApplicationSigningConfiguration     `yaml:"signing"`
 	Operations []ApplicationOperationConfiguration `yaml:"operations"`
 	Workflow   []ApplicationWorkflowStep           `yaml:"workflow"`
+	Web        ApplicationWebConfiguration         `yaml:"web"`
 }
 
 // ApplicationWorkflowStep wraps one typed workflow step from the shared configuration file.
@@ -74,6 +79,50 @@ type ApplicationCommonConfiguration struct {
 	RequireClean bool   `yaml:"require_clean"`
 }
 
+// ApplicationSigningConfiguration stores how Git signs the commits gix creates.
+type ApplicationSigningConfiguration struct {
+	Mode              string `yaml:"mode"`
+	Key               string `yaml:"key"`
+	RequireSignatures bool   `yaml:"require_signatures"`
+}
+
+func (configuration ApplicationSigningConfiguration) commitSigning() (commitsign.Configuration, error) {
+	mode, modeError := commitsign.ParseMode(configuration.Mode)
+	if modeError != nil {
+		return commitsign.Configuration{}, modeError
+	}
+	key := strings.TrimSpace(configuration.Key)
+	if mode == commitsign.ModeSSH {
+		key = pathutils.NewHomeExpander().Expand(key)
+	}
+	signing := commitsign.Configuration{Mode: mode, Key: key, RequireSignatures: configuration.RequireSignatures}
+	if validationError := signing.Validate(); validationError != nil {
+		return commitsign.Configuration{}, validationError
+	}
+	return signing, nil
+}
+
+// ApplicationWebConfiguration stores the named workspaces the web interface opens.
+type ApplicationWebConfiguration struct {
+	Workspaces []web.Workspace `yaml:"workspaces"`
+}
+
+// normalizedWorkspaces validates every saved workspace and rejects duplicate names.
+func (configuration ApplicationWebConfiguration) normalizedWorkspaces() ([]web.Workspace, error) {
+	workspaces := make([]web.Workspace, 0, len(configuration.Workspaces))
+	for _, configuredWorkspace := range configuration.Workspaces {
+		workspace, normalizeError := web.NormalizeWorkspace(configuredWorkspace)
+		if normalizeError != nil {
+			return nil, normalizeError
+		}
+		if _, findError := web.FindWorkspace(workspaces, workspace.Name); findError == nil {
+			return nil, fmt.Errorf(webWorkspaceDuplicateTemplateConstant, workspace.Name)
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, nil
+}
+
 // ApplicationLLMConfiguration stores language-model defaults shared across LLM-backed commands.
 type ApplicationLLMConfiguration struct {
 	OpenAI              llmclient.OpenAIConnectionProfile   `yaml:"openai"`
@@ -81,12 +130,18 @@ type ApplicationLLMConfiguration struct {
 	Effort              string                              `yaml:"effort"`
 	MaxCompletionTokens int                                 `yaml:"max_completion_tokens"`
 	TimeoutSeconds      int                                 `yaml:"timeout_seconds"`
+	Transport           string                              `yaml:"transport"`
+	CassetteDirectory   string                              `yaml:"cassette_directory"`
 }
 
 func (configuration ApplicationLLMConfiguration) connectionProfiles() llmclient.ConnectionProfiles {
 	profiles := llmclient.ConnectionProfiles{
 		OpenAI:   configuration.OpenAI,
 		LLMProxy: configuration.LLMProxy,
+		Cassette: llmclient.CassetteConfig{
+			Mode:      llmclient.CassetteMode(strings.ToLower(strings.TrimSpace(configuration.Transport))),
+			Directory: pathutils.NewHomeExpander().Expand(strings.TrimSpace(configuration.CassetteDirectory)),
+		},
 	}
 	if profiles.OpenAI.MaxCompletionTokens == 0 {
 		profiles.OpenAI.MaxCompletionTokens = configuration.MaxCompletionTokens
@@ -101,6 +156,9 @@ func (configuration ApplicationLLMConfiguration) validateConnections() error {
 	if configuration.MaxCompletionTokens <= 0 {
 		return errors.New("llm max_completion_tokens is required and must be positive")
 	}
+	if _, transportError := llmclient.ParseCassetteMode(configuration.Transport); transportError != nil {
+		return transportError
+	}
 	return configuration.connectionProfiles().Validate()
 }
 
@@ -159,14 +159,32 @@ const (
 	webBindFlagUsageConstant                                      = "Bind the gix web interface to HOST instead of 127.0.0.1. Requires --web."
 	webPortFlagNameConstant                                       = "port"
 	webPortFlagUsageConstant                                      = "Set the gix web interface port instead of 8080. Requires --web."
+	webTLSFlagNameConstant                                        = "tls"
+	webTLSFlagUsageConstant                                       = "Serve the gix web interface over HTTPS with a self-signed certificate generated at launch. Requires --web."
 	webDefaultPortConstant                                        = "8080"
 	webListenHostConstant                                         = "127.0.0.1"
+	webLocalhostNameConstant                                      = "localhost"
+	webIPv6LoopbackConstant                                       = "::1"
+	webWildcardIPv4BindConstant                                   = "0.0.0.0"
+	webWildcardIPv6BindConstant                                   = "::"
+	webHTTPSchemeConstant                                         = "http"
+	webHTTPSSchemeConstant                                        = "https"
+	webLaunchPathConstant                                         = "/"
+	webSessionTokenQueryParameterConstant                         = "token"
 	webLaunchMessageTemplateConstant                              = "gix web interface available at %s\n"
+	webCertificateFingerprintTemplateConstant                     = "TLS certificate SHA-256 fingerprint: %s\n"
+	webStateDirectoryNameConstant                                 = "web"
+	webActorEnvironmentVariableConstant                           = "USER"
 	webBindRequiredConstant                                       = "web bind address is required"
 	webNetworkFlagsRequireWebConstant                             = "web network flags require --web"
 	webPositionalArgumentsRequirePortFlagConstant                 = "web mode does not accept positional arguments; use --port <port>"
 	webPortInvalidTemplateConstant                                = "invalid web port %q"
 	webPortRangeTemplateConstant                                  = "web port must be between 1 and 65535, got %d"
+	webWorkspaceFlagNameConstant                                  = "workspace"
+	webWorkspaceFlagUsageConstant                                 = "Open the gix web interface on a workspace saved under web.workspaces in the configuration. Requires --web."
+	webWorkspaceFlagRequiresWebConstant                           = "--workspace requires --web"
+	webWorkspaceRootsConflictConstant                             = "--workspace cannot be combined with --roots"
+	webWorkspaceDuplicateTemplateConstant                         = "workspace %q is defined more than once"
 	operationDecodeErrorMessageConstant                           = "unable to decode operation defaults"
 	operationNameLogFieldConstant                                 = "command"
 	duplicateOperationConfigurationTemplateConstant               = "duplicate configuration for command %q"
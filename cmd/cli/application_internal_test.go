@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/tyemirov/gix/internal/commitsign"
 	"github.com/tyemirov/gix/internal/llmclient"
 	flagutils "github.com/tyemirov/gix/internal/utils/flags"
 )
@@ -183,6 +184,19 @@ func TestApplicationLLMConfigurationRejectsNegativeGlobalCompletionTokens(t *tes
 	require.EqualError(t, validationError, "llm max_completion_tokens is required and must be positive")
 }
 
+func TestApplicationLLMConfigurationReplayTransportRequiresOnlyCassetteDirectory(t *testing.T) {
+	configuration := applicationTestLLMConfiguration()
+	configuration.Transport = "replay"
+	configuration.LLMProxy.Credential = ""
+	configuration.OpenAI.Credential = ""
+
+	require.EqualError(t, configuration.validateConnections(), "llm cassette_directory is required for replay transport")
+
+	configuration.CassetteDirectory = t.TempDir()
+	require.NoError(t, configuration.validateConnections())
+	require.Equal(t, llmclient.CassetteModeReplay, configuration.connectionProfiles().Cassette.Mode)
+}
+
 func TestInitializeConfigurationRejectsPublicLLMTransport(t *testing.T) {
 	configurationPath := filepath.Join(t.TempDir(), "config.yml")
 	configurationContent := `common:
@@ -244,6 +258,24 @@ operations: []
 	require.EqualError(t, initializationError, "invalid llm configuration: llm llm_proxy provider is required")
 }
 
+func TestApplicationSigningConfiguration(t *testing.T) {
+	t.Setenv("HOME", "/home/signer")
+
+	signing, signingError := ApplicationSigningConfiguration{Mode: " SSH ", Key: "~/.ssh/id_ed25519.pub", RequireSignatures: true}.commitSigning()
+	require.NoError(t, signingError)
+	require.Equal(t, commitsign.Configuration{Mode: commitsign.ModeSSH, Key: "/home/signer/.ssh/id_ed25519.pub", RequireSignatures: true}, signing)
+
+	signing, signingError = ApplicationSigningConfiguration{}.commitSigning()
+	require.NoError(t, signingError)
+	require.False(t, signing.RequiresPreflight())
+
+	_, signingError = ApplicationSigningConfiguration{Mode: "ssh"}.commitSigning()
+	require.EqualError(t, signingError, "signing.key is required when signing.mode is ssh")
+
+	_, signingError = ApplicationSigningConfiguration{Mode: "x509"}.commitSigning()
+	require.EqualError(t, signingError, `unsupported signing mode "x509"; use ssh, gpg, or none`)
+}
+
 func TestApplicationOperationLLMProxyProviderOverrideClearsConfiguredModel(t *testing.T) {
 	application := newApplicationConfigurationTestHarness(t, ApplicationConfiguration{
 		LLM: applicationTestLLMConfiguration(),
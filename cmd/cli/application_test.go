@@ -559,6 +559,7 @@ func TestCanonicalConfigurationTemplateProvidesCompleteCommandConfigurations(tes
 	require.Equal(testInstance, "${LLM_PROXY_SECRET_KEY}", embeddedConfiguration.LLM.LLMProxy.Credential)
 	require.Zero(testInstance, embeddedConfiguration.LLM.LLMProxy.MaxCompletionTokens)
 	require.Equal(testInstance, 4_800, embeddedConfiguration.LLM.MaxCompletionTokens)
+	require.Equal(testInstance, cli.ApplicationSigningConfiguration{Mode: "none"}, embeddedConfiguration.Signing)
 
 	operationIndex := buildEmbeddedOperationIndex(testInstance)
 
@@ -3,6 +3,7 @@ package cli
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"hash/fnv"
@@ -10,6 +11,7 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"os/user"
 	"path/filepath"
 	"regexp"
 	"slices"
@@ -83,6 +85,16 @@ const (
 	webAuditChangeProtocolMissingConstant       = "convert_protocol requires source_protocol and target_protocol"
 	webAuditChangeSyncStrategyTemplateConstant  = "unsupported sync strategy %q"
 	webAuditChangeKindTemplateConstant          = "unsupported audit change kind %q"
+	webAuditInspectionDepthTemplateConstant     = "unsupported audit depth %q; expected minimal, full, or github"
+	webAuditExportFormatTemplateConstant        = "unsupported audit export format %q; expected csv, html, or json"
+	webAuditExportPathTemplateConstant          = "audit export path %q is not part of the exported audit"
+	webAuditExportSnapshotMissingConstant       = "the audit being exported is no longer available; run the audit again"
+	webAuditExportCSVFileNameConstant           = "gix-audit.csv"
+	webAuditExportCSVContentTypeConstant        = "text/csv"
+	webAuditExportHTMLFileNameConstant          = "gix-audit.html"
+	webAuditExportHTMLContentTypeConstant       = "text/html"
+	webAuditExportJSONFileNameConstant          = "gix-audit.json"
+	webAuditExportJSONContentTypeConstant       = "application/json"
 	webAuditChangeChangelogBranchRejected       = "update_changelog requires the current branch to match the default branch"
 	webAuditChangeChangelogTaggedRejected       = "update_changelog requires HEAD to be untagged"
 	webAuditChangeCommitMessageTemplateConstant = "Generated commit message:\n%s\n"
@@ -101,6 +113,9 @@ const (
 	webAuditChangeStatusSucceededConstant       = "succeeded"
 	webAuditChangeStatusSkippedConstant         = "skipped"
 	webAuditChangeStatusFailedConstant          = "failed"
+	webAuditChangeStatusCanceledConstant        = "canceled"
+	webAuditChangeStatusHandoffConstant         = "handoff"
+	webAuditChangeCanceledMessageConstant       = "canceled by the operator"
 )
 
 var webReleaseVersionPattern = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)(?:-rc(\d+))?$`)
@@ -137,11 +152,13 @@ type webGitRepositoryManager = shared.GitRepositoryManager
 type webGitHubMetadataResolver = shared.GitHubMetadataResolver
 
 type webLaunchConfiguration struct {
-	bind string
-	port int
+	bind      string
+	port      int
+	tls       bool
+	workspace string
 }
 
-func newWebLaunchConfiguration(rawPortValue string, rawBindValue string, bindProvided bool) (webLaunchConfiguration, error) {
+func newWebLaunchConfiguration(rawPortValue string, rawBindValue string, bindProvided bool, tlsEnabled bool, workspaceName string) (webLaunchConfiguration, error) {
 	portValue, portError := parseWebLaunchPort(rawPortValue)
 	if portError != nil {
 		return webLaunchConfiguration{}, portError
@@ -153,8 +170,10 @@ func newWebLaunchConfiguration(rawPortValue string, rawBindValue string, bindPro
 	}
 
 	return webLaunchConfiguration{
-		bind: bindValue,
-		port: portValue,
+		bind:      bindValue,
+		port:      portValue,
+		tls:       tlsEnabled,
+		workspace: strings.TrimSpace(workspaceName),
 	}, nil
 }
 
@@ -192,13 +211,32 @@ func (configuration webLaunchConfiguration) listenAddress() string {
 	return net.JoinHostPort(configuration.bind, strconv.Itoa(configuration.port))
 }
 
-func (configuration webLaunchConfiguration) launchURL() string {
+func (configuration webLaunchConfiguration) launchURL(sessionToken string) string {
+	scheme := webHTTPSchemeConstant
+	if configuration.tls {
+		scheme = webHTTPSSchemeConstant
+	}
 	return (&url.URL{
-		Scheme: "http",
-		Host:   net.JoinHostPort(configuration.bind, strconv.Itoa(configuration.port)),
+		Scheme:   scheme,
+		Host:     net.JoinHostPort(configuration.bind, strconv.Itoa(configuration.port)),
+		Path:     webLaunchPathConstant,
+		RawQuery: url.Values{webSessionTokenQueryParameterConstant: []string{sessionToken}}.Encode(),
 	}).String()
 }
 
+// certificateHosts lists the names a self-signed certificate should cover: the bind host
+// (unless it is a wildcard), the loopback names, and the machine host name for jump hosts.
+func (configuration webLaunchConfiguration) certificateHosts() []string {
+	hosts := []string{webLocalhostNameConstant, webListenHostConstant, webIPv6LoopbackConstant}
+	if configuration.bind != webWildcardIPv4BindConstant && configuration.bind != webWildcardIPv6BindConstant {
+		hosts = append(hosts, configuration.bind)
+	}
+	if hostname, hostnameError := os.Hostname(); hostnameError == nil {
+		hosts = append(hosts, hostname)
+	}
+	return hosts
+}
+
 func (application *Application) handleWebLaunch(command *cobra.Command, arguments []string) (bool, error) {
 	launchConfiguration, requested, configurationError := application.resolveWebLaunchConfiguration(command, arguments)
 	if configurationError != nil {
@@ -207,6 +245,10 @@ func (application *Application) handleWebLaunch(command *cobra.Command, argument
 	if !requested {
 		return false, nil
 	}
+	launchRoots, launchRootsError := application.resolveWebLaunchRoots(command, launchConfiguration.workspace)
+	if launchRootsError != nil {
+		return true, launchRootsError
+	}
 
 	outputWriter := io.Writer(nil)
 	executionContext := context.Background()
@@ -217,25 +259,56 @@ func (application *Application) handleWebLaunch(command *cobra.Command, argument
 	if executionContext == nil {
 		executionContext = context.Background()
 	}
+	sessionToken, tokenError := web.NewSessionToken()
+	if tokenError != nil {
+		return true, tokenError
+	}
+	var certificate *tls.Certificate
+	if launchConfiguration.tls {
+		selfSignedCertificate, certificateError := web.NewSelfSignedCertificate(launchConfiguration.certificateHosts(), time.Now())
+		if certificateError != nil {
+			return true, certificateError
+		}
+		certificate = &selfSignedCertificate
+	}
 	if outputWriter != nil {
-		if _, writeError := fmt.Fprintf(outputWriter, webLaunchMessageTemplateConstant, launchConfiguration.launchURL()); writeError != nil {
+		if _, writeError := fmt.Fprintf(outputWriter, webLaunchMessageTemplateConstant, launchConfiguration.launchURL(sessionToken)); writeError != nil {
 			return true, writeError
 		}
+		if certificate != nil {
+			if _, writeError := fmt.Fprintf(outputWriter, webCertificateFingerprintTemplateConstant, web.CertificateFingerprint(*certificate)); writeError != nil {
+				return true, writeError
+			}
+		}
 	}
 
-	launchRoots, launchRootsError := resolveWebLaunchRoots(command)
-	if launchRootsError != nil {
-		return true, launchRootsError
+	actionStore, actionStoreError := application.webActionStore()
+	if actionStoreError != nil {
+		return true, actionStoreError
 	}
 
 	repositoryCatalog := application.repositoryCatalog(executionContext, launchRoots)
+	repositoryCatalog.Workspace = launchConfiguration.workspace
 
 	return true, application.webRunner(executionContext, web.ServerOptions{
 		Address:           launchConfiguration.listenAddress(),
 		Repositories:      repositoryCatalog,
 		BrowseDirectories: application.newWebDirectoryBrowser(),
 		InspectAudit:      application.newWebAuditInspector(),
+		ExportAudit:       application.newWebAuditExporter(),
 		ApplyAuditChanges: application.newWebAuditChangeExecutor(),
+		LoadRepository:    application.newWebRepositoryDetailLoader(),
+		LoadFileDiff:      application.newWebRepositoryFileDiffLoader(),
+		LoadWorkflows:     application.newWebWorkflowCatalogLoader(),
+		PlanWorkflow:      application.newWebWorkflowPlanner(),
+		PreviewSync:       application.newWebSyncPreviewer(),
+		LoadPullRequests:  application.newWebPullRequestDashboardLoader(),
+		LoadPackages:      application.newWebPackageDashboardLoader(),
+		Workspaces:        application.webWorkspaceStore(),
+		LoadWorkspace:     application.newWebWorkspaceRepositoryLoader(),
+		Actions:           actionStore,
+		SessionToken:      sessionToken,
+		TLSCertificate:    certificate,
 	})
 }
 
@@ -273,31 +346,72 @@ func (application *Application) resolveWebLaunchConfiguration(command *cobra.Com
 		return webLaunchConfiguration{}, false, portFlagError
 	}
 
-	if !webEnabled && !bindChanged && !portChanged {
+	tlsEnabled, tlsChanged, tlsFlagError := flagutils.BoolFlag(command, webTLSFlagNameConstant)
+	switch {
+	case tlsFlagError == nil:
+	case errors.Is(tlsFlagError, flagutils.ErrFlagNotDefined):
+		tlsEnabled = false
+		tlsChanged = false
+	default:
+		return webLaunchConfiguration{}, false, tlsFlagError
+	}
+
+	rawWorkspaceValue, workspaceChanged, workspaceFlagError := flagutils.StringFlag(command, webWorkspaceFlagNameConstant)
+	switch {
+	case workspaceFlagError == nil:
+	case errors.Is(workspaceFlagError, flagutils.ErrFlagNotDefined):
+		rawWorkspaceValue = ""
+		workspaceChanged = false
+	default:
+		return webLaunchConfiguration{}, false, workspaceFlagError
+	}
+
+	if !webEnabled && !bindChanged && !portChanged && !tlsChanged && !workspaceChanged {
 		return webLaunchConfiguration{}, false, nil
 	}
 
-	if !webEnabled && (bindChanged || portChanged) {
+	if !webEnabled && (bindChanged || portChanged || tlsChanged) {
 		return webLaunchConfiguration{}, true, errors.New(webNetworkFlagsRequireWebConstant)
 	}
 
+	if !webEnabled && workspaceChanged {
+		return webLaunchConfiguration{}, true, errors.New(webWorkspaceFlagRequiresWebConstant)
+	}
+
 	if webEnabled && len(arguments) > 0 {
 		return webLaunchConfiguration{}, true, errors.New(webPositionalArgumentsRequirePortFlagConstant)
 	}
 
-	launchConfiguration, configurationError := newWebLaunchConfiguration(rawPortValue, rawBindValue, bindChanged)
+	launchConfiguration, configurationError := newWebLaunchConfiguration(rawPortValue, rawBindValue, bindChanged, tlsEnabled, rawWorkspaceValue)
 	if configurationError != nil {
 		return webLaunchConfiguration{}, true, configurationError
 	}
 	return launchConfiguration, true, nil
 }
 
-func resolveWebLaunchRoots(command *cobra.Command) ([]string, error) {
+// resolveWebLaunchRoots returns the --roots values, or the roots of the workspace named by --workspace.
+func (application *Application) resolveWebLaunchRoots(command *cobra.Command, workspaceName string) ([]string, error) {
 	if command == nil {
 		return nil, nil
 	}
 
-	return rootutils.FlagValues(command)
+	flagRoots, flagError := rootutils.FlagValues(command)
+	if flagError != nil || len(workspaceName) == 0 {
+		return flagRoots, flagError
+	}
+	if len(flagRoots) > 0 {
+		return nil, errors.New(webWorkspaceRootsConflictConstant)
+	}
+
+	workspaces, workspacesError := application.configuration.Web.normalizedWorkspaces()
+	if workspacesError != nil {
+		return nil, workspacesError
+	}
+	workspace, findError := web.FindWorkspace(workspaces, workspaceName)
+	if findError != nil {
+		return nil, findError
+	}
+	return rootutils.SanitizeConfigured(workspace.Roots), nil
 }
 
 func (application *Application) launchWebInterface(executionContext context.Context, options web.ServerOptions) error {
@@ -382,43 +496,140 @@ func (application *Application) newWebDirectoryBrowser() web.DirectoryBrowser {
 
 func (application *Application) newWebAuditInspector() web.AuditInspector {
 	return func(executionContext context.Context, request web.AuditInspectionRequest) web.AuditInspectionResponse {
-		discoverer, gitExecutor, repositoryManager, githubResolver, dependencyError := application.webAuditDependencies()
-		if dependencyError != nil {
-			return web.AuditInspectionResponse{Error: dependencyError.Error()}
+		inspectionDepth, depthError := webAuditInspectionDepth(request.Depth)
+		if depthError != nil {
+			return web.AuditInspectionResponse{Roots: append([]string(nil), request.Roots...), Error: depthError.Error()}
 		}
 
-		auditService := audit.NewService(
-			discoverer,
-			repositoryManager,
-			gitExecutor,
-			githubResolver,
-			io.Discard,
-			io.Discard,
-		)
-
-		inspections, inspectionError := auditService.DiscoverInspections(
-			executionContext,
-			append([]string(nil), request.Roots...),
-			request.IncludeAll,
-			false,
-			audit.InspectionDepthFull,
-		)
+		inspections, inspectionError := application.inspectWebAudit(executionContext, request.Roots, request.IncludeAll, request.Refresh, inspectionDepth)
 		if inspectionError != nil {
 			return web.AuditInspectionResponse{Roots: append([]string(nil), request.Roots...), Error: inspectionError.Error()}
 		}
 
+		snapshotID, snapshotError := application.webAuditSnapshots.record(webAuditSnapshot{
+			inspections:  inspections,
+			gitHubHealth: inspectionDepth == audit.InspectionDepthGitHub,
+		})
+		if snapshotError != nil {
+			return web.AuditInspectionResponse{Roots: append([]string(nil), request.Roots...), Error: snapshotError.Error()}
+		}
+
 		rows := make([]web.AuditInspectionRow, 0, len(inspections))
 		for inspectionIndex := range inspections {
 			rows = append(rows, mapAuditInspectionRow(inspections[inspectionIndex]))
 		}
 
 		return web.AuditInspectionResponse{
-			Roots: append([]string(nil), request.Roots...),
-			Rows:  rows,
+			Roots:      append([]string(nil), request.Roots...),
+			Rows:       rows,
+			SnapshotID: snapshotID,
 		}
 	}
 }
 
+// newWebAuditExporter renders the audit rows the browser shows with the `gix audit` report renderers.
+// It writes the inspection snapshot the audit panel rendered, never a new inspection, and only the
+// requested paths, in the order the table listed them.
+func (application *Application) newWebAuditExporter() web.AuditExporter {
+	return func(_ context.Context, request web.AuditExportRequest) web.AuditExport {
+		exportFormat := strings.TrimSpace(request.Format)
+		fileName, contentType, formatSupported := webAuditExportFile(exportFormat)
+		if !formatSupported {
+			return web.AuditExport{Format: exportFormat, Error: fmt.Sprintf(webAuditExportFormatTemplateConstant, request.Format)}
+		}
+		snapshot, snapshotFound := application.webAuditSnapshots.lookup(strings.TrimSpace(request.SnapshotID))
+		if !snapshotFound {
+			return web.AuditExport{Format: exportFormat, Error: webAuditExportSnapshotMissingConstant}
+		}
+
+		inspectionsByPath := make(map[string]audit.RepositoryInspection, len(snapshot.inspections))
+		for _, inspection := range snapshot.inspections {
+			inspectionsByPath[inspection.Path] = inspection
+		}
+		selectedInspections := make([]audit.RepositoryInspection, 0, len(request.Paths))
+		for _, path := range request.Paths {
+			inspection, found := inspectionsByPath[path]
+			if !found {
+				return web.AuditExport{Format: exportFormat, Error: fmt.Sprintf(webAuditExportPathTemplateConstant, path)}
+			}
+			selectedInspections = append(selectedInspections, inspection)
+		}
+
+		content := &strings.Builder{}
+		writeError := audit.WriteReport(content, audit.ReportOptions{
+			Format:       audit.ReportFormat(exportFormat),
+			GitHubHealth: snapshot.gitHubHealth,
+			Columns:      request.Columns,
+		}, selectedInspections)
+		if writeError != nil {
+			return web.AuditExport{Format: exportFormat, Error: writeError.Error()}
+		}
+
+		return web.AuditExport{
+			Format:      exportFormat,
+			FileName:    fileName,
+			ContentType: contentType,
+			Content:     content.String(),
+		}
+	}
+}
+
+// inspectWebAudit runs the audit inspection behind the audit panel.
+func (application *Application) inspectWebAudit(executionContext context.Context, roots []string, includeAll bool, refresh bool, inspectionDepth audit.InspectionDepth) ([]audit.RepositoryInspection, error) {
+	discoverer, gitExecutor, repositoryManager, githubResolver, dependencyError := application.webAuditDependencies()
+	if dependencyError != nil {
+		return nil, dependencyError
+	}
+
+	auditService := audit.NewService(
+		discoverer,
+		repositoryManager,
+		gitExecutor,
+		githubResolver,
+		io.Discard,
+		io.Discard,
+	)
+	inspectionCache := application.webAuditInspectionCache()
+	if refresh {
+		inspectionCache = inspectionCache.WithRefresh()
+	}
+	auditService.SetInspectionCache(inspectionCache)
+
+	return auditService.DiscoverInspections(
+		executionContext,
+		append([]string(nil), roots...),
+		includeAll,
+		false,
+		inspectionDepth,
+	)
+}
+
+// webAuditExportFile names the download of an audit export format; ok is false for unsupported formats.
+func webAuditExportFile(exportFormat string) (string, string, bool) {
+	switch audit.ReportFormat(exportFormat) {
+	case audit.ReportFormatCSV:
+		return webAuditExportCSVFileNameConstant, webAuditExportCSVContentTypeConstant, true
+	case audit.ReportFormatHTML:
+		return webAuditExportHTMLFileNameConstant, webAuditExportHTMLContentTypeConstant, true
+	case audit.ReportFormatJSON:
+		return webAuditExportJSONFileNameConstant, webAuditExportJSONContentTypeConstant, true
+	default:
+		return "", "", false
+	}
+}
+
+// webAuditInspectionDepth maps the depth a workspace or the audit panel requested; empty means full.
+func webAuditInspectionDepth(rawDepth string) (audit.InspectionDepth, error) {
+	switch depth := audit.InspectionDepth(strings.TrimSpace(rawDepth)); depth {
+	case "":
+		return audit.InspectionDepthFull, nil
+	case audit.InspectionDepthMinimal, audit.InspectionDepthFull, audit.InspectionDepthGitHub:
+		return depth, nil
+	default:
+		return "", fmt.Errorf(webAuditInspectionDepthTemplateConstant, rawDepth)
+	}
+}
+
 func (application *Application) newWebAuditChangeExecutor() web.AuditChangeExecutor {
 	return func(executionContext context.Context, request web.AuditChangeApplyRequest) web.AuditChangeApplyResponse {
 		if len(request.Changes) == 0 {
@@ -440,6 +651,13 @@ func (application *Application) newWebAuditChangeExecutor() web.AuditChangeExecu
 }
 
 func (application *Application) applyWebAuditChange(executionContext context.Context, change web.AuditQueuedChange) web.AuditChangeApplyResult {
+	changeContext, progress := web.StartAuditChange(executionContext, change.ID)
+	result := application.runWebAuditChange(changeContext, change, progress)
+	progress.Finish(result)
+	return result
+}
+
+func (application *Application) runWebAuditChange(executionContext context.Context, change web.AuditQueuedChange, progress web.AuditChangeProgress) web.AuditChangeApplyResult {
 	normalizedPath, pathError := normalizeWebAuditChangePath(change.Path)
 	result := web.AuditChangeApplyResult{
 		ID:   change.ID,
@@ -452,12 +670,21 @@ func (application *Application) applyWebAuditChange(executionContext context.Con
 		return result
 	}
 
+	if executionContext.Err() != nil {
+		result.Status = webAuditChangeStatusCanceledConstant
+		result.Error = webAuditChangeCanceledMessageConstant
+		return result
+	}
+
 	outputBuffer := &bytes.Buffer{}
 	errorBuffer := &bytes.Buffer{}
+	outputWriter := progress.Output(web.AuditChangeOutputStdout, outputBuffer)
+	errorWriter := progress.Output(web.AuditChangeOutputStderr, errorBuffer)
 
 	applyError := error(nil)
 	executionOutcome := workflow.ExecutionOutcome{}
 	message := ""
+	var syncEvents *webSyncEventRecorder
 
 	switch change.Kind {
 	case web.AuditChangeKindDeleteFolder:
@@ -473,9 +700,9 @@ func (application *Application) applyWebAuditChange(executionContext context.Con
 			applyError = deleteError
 			break
 		}
-		_, _ = fmt.Fprintf(outputBuffer, "DELETED: %s\n", normalizedPath)
+		_, _ = fmt.Fprintf(outputWriter, "DELETED: %s\n", normalizedPath)
 	case web.AuditChangeKindUpdateChangelog, web.AuditChangeKindCommitChanges:
-		dependencies, dependencyError := application.webTaskRunnerDependencies(outputBuffer, errorBuffer)
+		dependencies, dependencyError := application.webTaskRunnerDependencies(outputWriter, errorWriter)
 		if dependencyError != nil {
 			applyError = dependencyError
 			break
@@ -488,18 +715,27 @@ func (application *Application) applyWebAuditChange(executionContext context.Con
 				dependencies.GitExecutor,
 				dependencies.FileSystem,
 				normalizedPath,
-				outputBuffer,
+				outputWriter,
 			)
 		case web.AuditChangeKindCommitChanges:
 			applyError = application.applyWebAuditCommitChanges(
 				executionContext,
 				dependencies.GitExecutor,
 				normalizedPath,
-				outputBuffer,
+				outputWriter,
 			)
 		}
+	case web.AuditChangeKindClosePullRequest, web.AuditChangeKindRetargetPullRequest, web.AuditChangeKindDeleteMergedBranch:
+		applyError = application.applyWebPullRequestChange(executionContext, normalizedPath, change, outputWriter)
+	case web.AuditChangeKindPackageRetention:
+		applyError = application.applyWebPackageRetentionChange(executionContext, normalizedPath, change, outputWriter)
+	case web.AuditChangeKindStrictSync:
+		syncEvents = newWebSyncEventRecorder(progress)
+		executionOutcome, applyError = application.executeWebStrictSync(executionContext, normalizedPath, change, syncEvents, outputWriter, errorWriter)
+	case web.AuditChangeKindWorkflow:
+		executionOutcome, applyError = application.executeWebWorkflowChange(executionContext, normalizedPath, change, outputWriter, errorWriter)
 	default:
-		dependencies, dependencyError := application.webTaskRunnerDependencies(outputBuffer, errorBuffer)
+		dependencies, dependencyError := application.webTaskRunnerDependencies(outputWriter, errorWriter)
 		if dependencyError != nil {
 			applyError = dependencyError
 			break
@@ -511,6 +747,20 @@ func (application *Application) applyWebAuditChange(executionContext context.Con
 	result.Stdout = outputBuffer.String()
 	result.Stderr = errorBuffer.String()
 	result.Status = webAuditChangeResultStatus(executionOutcome, applyError)
+	if applyError != nil && executionContext.Err() != nil {
+		result.Status = webAuditChangeStatusCanceledConstant
+		result.Error = webAuditChangeCanceledMessageConstant
+		return result
+	}
+	if syncEvents != nil {
+		result.Events = syncEvents.recorded()
+		if handoff, handedOff := syncEvents.handoff(executionContext, normalizedPath); handedOff {
+			result.Status = webAuditChangeStatusHandoffConstant
+			result.Message = handoff.Message
+			result.Handoff = &handoff
+			return result
+		}
+	}
 	if result.Status == webAuditChangeStatusFailedConstant {
 		result.Status = webAuditChangeStatusFailedConstant
 		result.Error = applyError.Error()
@@ -522,6 +772,9 @@ func (application *Application) applyWebAuditChange(executionContext context.Con
 	} else {
 		message = webAuditChangeMessage(change.Kind)
 	}
+	if len(message) > 0 && change.Kind == web.AuditChangeKindWorkflow {
+		message = fmt.Sprintf(webWorkflowChangeMessageTemplateConstant, message, strings.TrimSpace(change.WorkflowID))
+	}
 	if len(message) > 0 {
 		result.Message = message
 	}
@@ -835,6 +1088,14 @@ func mapAuditInspectionRow(inspection audit.RepositoryInspection) web.AuditInspe
 		WorktreeDirty:          string(row.WorktreeDirty),
 		DirtyFiles:             row.DirtyFiles,
 		DirtyFileEntries:       parseWebAuditDirtyFileEntries(inspection.WorktreeDirtyFiles),
+		UpstreamAhead:          row.UpstreamAhead,
+		UpstreamBehind:         row.UpstreamBehind,
+		DefaultAhead:           row.DefaultAhead,
+		DefaultBehind:          row.DefaultBehind,
+		NoUpstreamBranches:     row.NoUpstreamBranches,
+		UpstreamGoneBranches:   row.UpstreamGoneBranches,
+		MergedBranches:         row.MergedBranches,
+		BranchListing:          row.BranchListing,
 	}
 }
 
@@ -1407,12 +1668,24 @@ func webAuditChangeMessage(kind web.AuditChangeKind) string {
 		return "Remote protocol updated"
 	case web.AuditChangeKindSyncWithRemote:
 		return "Repository synchronized with remote"
+	case web.AuditChangeKindStrictSync:
+		return "Strict sync completed"
 	case web.AuditChangeKindUpdateChangelog:
 		return "Changelog updated"
 	case web.AuditChangeKindCommitChanges:
 		return "Changes committed"
+	case web.AuditChangeKindRetargetPullRequest:
+		return "Pull request retargeted"
+	case web.AuditChangeKindClosePullRequest:
+		return "Pull request closed"
+	case web.AuditChangeKindDeleteMergedBranch:
+		return "Merged branch deleted"
+	case web.AuditChangeKindPackageRetention:
+		return "Package retention applied"
 	case web.AuditChangeKindDeleteFolder:
 		return "Folder deleted"
+	case web.AuditChangeKindWorkflow:
+		return "Workflow completed"
 	default:
 		return ""
 	}
@@ -1428,12 +1701,24 @@ func webAuditChangeSkippedMessage(kind web.AuditChangeKind) string {
 		return "Remote protocol update skipped"
 	case web.AuditChangeKindSyncWithRemote:
 		return "Repository synchronization skipped"
+	case web.AuditChangeKindStrictSync:
+		return "Strict sync skipped"
 	case web.AuditChangeKindUpdateChangelog:
 		return "Changelog update skipped"
 	case web.AuditChangeKindCommitChanges:
 		return "Commit skipped"
+	case web.AuditChangeKindRetargetPullRequest:
+		return "Pull request retarget skipped"
+	case web.AuditChangeKindClosePullRequest:
+		return "Pull request close skipped"
+	case web.AuditChangeKindDeleteMergedBranch:
+		return "Merged branch deletion skipped"
+	case web.AuditChangeKindPackageRetention:
+		return "Package retention skipped"
 	case web.AuditChangeKindDeleteFolder:
 		return "Folder deletion skipped"
+	case web.AuditChangeKindWorkflow:
+		return "Workflow skipped"
 	default:
 		return ""
 	}
@@ -1475,10 +1760,20 @@ func webAuditChangePriority(kind web.AuditChangeKind) int {
 		return 20
 	case web.AuditChangeKindSyncWithRemote:
 		return 30
+	case web.AuditChangeKindStrictSync:
+		return 31
 	case web.AuditChangeKindUpdateChangelog:
 		return 35
 	case web.AuditChangeKindCommitChanges:
 		return 36
+	case web.AuditChangeKindRetargetPullRequest:
+		return 40
+	case web.AuditChangeKindClosePullRequest:
+		return 42
+	case web.AuditChangeKindDeleteMergedBranch:
+		return 44
+	case web.AuditChangeKindPackageRetention:
+		return 46
 	case web.AuditChangeKindDeleteFolder:
 		return 50
 	case web.AuditChangeKindRenameFolder:
@@ -1560,6 +1855,14 @@ func auditInspectionReportRow(inspection audit.RepositoryInspection) audit.Audit
 		OriginMatchesCanonical: originMatches,
 		WorktreeDirty:          worktreeDirty,
 		DirtyFiles:             dirtyFiles,
+		UpstreamAhead:          inspection.UpstreamDivergence.AheadValue(),
+		UpstreamBehind:         inspection.UpstreamDivergence.BehindValue(),
+		DefaultAhead:           inspection.DefaultDivergence.AheadValue(),
+		DefaultBehind:          inspection.DefaultDivergence.BehindValue(),
+		NoUpstreamBranches:     inspection.Branches.NoUpstreamValue(),
+		UpstreamGoneBranches:   inspection.Branches.UpstreamGoneValue(),
+		MergedBranches:         inspection.Branches.MergedValue(),
+		BranchListing:          inspection.Branches.Listing(),
 	}
 }
 
@@ -1605,8 +1908,40 @@ func (application *Application) webGitDependencies() (execshellGitExecutor, webG
 	return gitExecutor, repositoryManager, nil
 }
 
+// webAuditInspectionCache shares one inspection cache across web requests so re-inspection after an apply
+// only revisits the repositories the apply changed. It is nil when the user cache directory is unavailable.
+func (application *Application) webAuditInspectionCache() *audit.InspectionCache {
+	application.webInspectionCacheOnce.Do(func() {
+		cachePath, cachePathError := audit.DefaultInspectionCachePath()
+		if cachePathError != nil {
+			return
+		}
+		application.webInspectionCache = audit.NewInspectionCache(cachePath, audit.InspectionCacheOptions{})
+	})
+	return application.webInspectionCache
+}
+
+// webActionStore keeps the web queue and action history next to the user configuration in $HOME/.gix/web.
+func (application *Application) webActionStore() (*web.ActionStore, error) {
+	userConfigurationPath, pathError := application.userConfigurationFilePath()
+	if pathError != nil {
+		return nil, pathError
+	}
+	return web.NewActionStore(web.ActionStoreOptions{
+		Directory: filepath.Join(filepath.Dir(userConfigurationPath), webStateDirectoryNameConstant),
+		Actor:     webActionActor(),
+	})
+}
+
+func webActionActor() string {
+	if currentUser, userError := user.Current(); userError == nil && len(strings.TrimSpace(currentUser.Username)) > 0 {
+		return currentUser.Username
+	}
+	return os.Getenv(webActorEnvironmentVariableConstant)
+}
+
 func (application *Application) webTaskRunnerDependencies(outputWriter io.Writer, errorWriter io.Writer) (taskrunner.DependenciesResult, error) {
-	return taskrunner.BuildDependencies(
+	dependencyResult, dependencyError := taskrunner.BuildDependencies(
 		taskrunner.DependenciesConfig{
 			LoggerProvider: func() *zap.Logger {
 				return application.logger
@@ -1619,6 +1954,11 @@ func (application *Application) webTaskRunnerDependencies(outputWriter io.Writer
 			DisablePrompter: true,
 		},
 	)
+	if dependencyError != nil {
+		return taskrunner.DependenciesResult{}, dependencyError
+	}
+	dependencyResult.Workflow.InspectionCache = application.webAuditInspectionCache()
+	return dependencyResult, nil
 }
 
 func (application *Application) webAuditDependencies() (shared.RepositoryDiscoverer, execshellGitExecutor, webGitRepositoryManager, webGitHubMetadataResolver, error) {
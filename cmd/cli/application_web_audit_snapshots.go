@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+
+	"github.com/tyemirov/gix/internal/audit"
+)
+
+const (
+	webAuditSnapshotLimitConstant        = 16
+	webAuditSnapshotIDByteLengthConstant = 16
+)
+
+// webAuditSnapshot is the inspection one audit panel response rendered.
+type webAuditSnapshot struct {
+	inspections  []audit.RepositoryInspection
+	gitHubHealth bool
+}
+
+// webAuditSnapshotStore keeps the most recent audit inspections so exports write the rows the browser shows
+// instead of inspecting the roots again; older snapshots are evicted first.
+type webAuditSnapshotStore struct {
+	mutex     sync.Mutex
+	snapshots map[string]webAuditSnapshot
+	order     []string
+}
+
+func (store *webAuditSnapshotStore) record(snapshot webAuditSnapshot) (string, error) {
+	identifierBytes := make([]byte, webAuditSnapshotIDByteLengthConstant)
+	if _, readError := rand.Read(identifierBytes); readError != nil {
+		return "", readError
+	}
+	identifier := hex.EncodeToString(identifierBytes)
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if store.snapshots == nil {
+		store.snapshots = make(map[string]webAuditSnapshot)
+	}
+	store.snapshots[identifier] = snapshot
+	store.order = append(store.order, identifier)
+	for len(store.order) > webAuditSnapshotLimitConstant {
+		delete(store.snapshots, store.order[0])
+		store.order = store.order[1:]
+	}
+	return identifier, nil
+}
+
+func (store *webAuditSnapshotStore) lookup(identifier string) (webAuditSnapshot, bool) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	snapshot, found := store.snapshots[identifier]
+	return snapshot, found
+}
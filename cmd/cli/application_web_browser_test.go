@@ -24,6 +24,7 @@ import (
 const (
 	testBrowserEnvironmentVariableConstant = "GIX_TEST_BROWSER"
 	testServerAddressConstant              = "127.0.0.1:8080"
+	testSessionTokenConstant               = "test-session-token"
 	browserRunTimeoutConstant              = 30 * time.Second
 	browserReadyTimeoutConstant            = 10 * time.Second
 	browserReadyPollIntervalConstant       = 100 * time.Millisecond
@@ -87,7 +88,7 @@ func TestWebInterfaceBrowserShowsAuditWorkspaceOnly(t *testing.T) {
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -172,7 +173,7 @@ func TestWebInterfaceBrowserInspectsAuditRootsAndDisplaysTable(t *testing.T) {
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -256,7 +257,7 @@ func TestWebInterfaceBrowserDirtyFilesPreviewCanExpand(t *testing.T) {
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -331,7 +332,7 @@ func TestWebInterfaceBrowserDirtyRowsShowCommitAndChangelogActions(t *testing.T)
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -364,7 +365,7 @@ func TestWebInterfaceBrowserDirtyMasterRepoWithoutOriginShowsChangelogAction(t *
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryPath)
@@ -420,7 +421,7 @@ func TestWebInterfaceBrowserDirtyRowsHideChangelogActionOffDefaultBranch(t *test
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -452,7 +453,7 @@ func TestWebInterfaceBrowserCurrentRepoStartupShowsParentLeaf(t *testing.T) {
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(repoTreeSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryPath)
@@ -474,7 +475,7 @@ func TestWebInterfaceBrowserKeepsConfiguredRootsScopedToLaunchRoots(t *testing.T
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(repoTreeSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, launchRootPath)
@@ -515,7 +516,7 @@ func TestWebInterfaceBrowserFindingsRowsRenderCorrectiveAndOperatorActions(t *te
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -591,7 +592,7 @@ func TestWebInterfaceBrowserCleanRowsHideSyncAction(t *testing.T) {
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -677,7 +678,7 @@ func TestWebInterfaceBrowserProtocolActionsQueueAndApply(t *testing.T) {
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -775,7 +776,7 @@ func TestWebInterfaceBrowserNonGitRowsCanQueueDeleteActions(t *testing.T) {
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -839,7 +840,7 @@ func TestWebInterfaceBrowserQueueOptionsOnlyExistInQueuedItems(t *testing.T) {
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -964,7 +965,7 @@ func TestWebInterfaceBrowserApplyRemovesSucceededActionsAndKeepsFailuresQueued(t
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -1045,7 +1046,7 @@ func TestWebInterfaceBrowserReauditClearsQueuedActions(t *testing.T) {
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -1119,7 +1120,7 @@ func TestWebInterfaceBrowserSupportsMultiRootAuditsFromCheckedRepos(t *testing.T
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(repoTreeSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -1188,7 +1189,7 @@ func TestWebInterfaceBrowserSingleCheckedRepoOverridesSelectedFolderScope(t *tes
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(repoTreeSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -1267,7 +1268,7 @@ func TestWebInterfaceBrowserFiltersAuditRowsByColumnValue(t *testing.T) {
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(auditRunButtonSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -1298,7 +1299,7 @@ func TestWebInterfaceBrowserRendersRepositoryTreeAndPreservesCheckedScopeAcrossF
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(repoTreeSelectorConstant, chromedp.ByQuery),
 	))
 	waitForAuditWorkspaceReady(t, browserContext, repositoryCatalog.ExplorerRoot)
@@ -1343,7 +1344,7 @@ func TestWebInterfaceBrowserDisplaysRepositoryTreeInLeftSidebar(t *testing.T) {
 
 	browserContext := newBrowserTestContext(t)
 	require.NoError(t, chromedp.Run(browserContext,
-		chromedp.Navigate(httpServer.URL),
+		chromedp.Navigate(browserLaunchURL(httpServer)),
 		chromedp.WaitVisible(repoSidebarSelectorConstant, chromedp.ByQuery),
 		chromedp.WaitVisible(repoTreeSelectorConstant, chromedp.ByQuery),
 	))
@@ -1444,7 +1445,19 @@ func newBrowserTestServerWithOptions(
 			Repositories:      repositoryCatalog,
 			BrowseDirectories: application.newWebDirectoryBrowser(),
 			InspectAudit:      auditInspector,
+			ExportAudit:       application.newWebAuditExporter(),
 			ApplyAuditChanges: auditChangeExecutor,
+			LoadRepository:    application.newWebRepositoryDetailLoader(),
+			LoadFileDiff:      application.newWebRepositoryFileDiffLoader(),
+			LoadWorkflows:     application.newWebWorkflowCatalogLoader(),
+			PlanWorkflow:      application.newWebWorkflowPlanner(),
+			PreviewSync:       application.newWebSyncPreviewer(),
+			LoadPullRequests:  application.newWebPullRequestDashboardLoader(),
+			LoadPackages:      application.newWebPackageDashboardLoader(),
+			Workspaces:        newTestWebWorkspaceStore(testingInstance),
+			LoadWorkspace:     application.newWebWorkspaceRepositoryLoader(),
+			Actions:           newTestWebActionStore(testingInstance),
+			SessionToken:      testSessionTokenConstant,
 		})
 		require.NoError(testingInstance, serverError)
 
@@ -1455,6 +1468,10 @@ func newBrowserTestServerWithOptions(
 	return httpServer, repositoryCatalog
 }
 
+func browserLaunchURL(httpServer *httptest.Server) string {
+	return httpServer.URL + "/?token=" + testSessionTokenConstant
+}
+
 func newBrowserTestContext(testingInstance *testing.T) context.Context {
 	testingInstance.Helper()
 
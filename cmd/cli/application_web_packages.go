@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/tyemirov/gix/internal/ghcr"
+	"github.com/tyemirov/gix/internal/packages"
+	"github.com/tyemirov/gix/internal/web"
+)
+
+const (
+	webPackageBaseURLRequiredErrorConstant    = "packages delete requires base_url in the configuration"
+	webPackageCredentialRequiredErrorConstant = "packages delete requires credential in the configuration"
+	webPackageDeleteRejectedErrorConstant     = "package_retention requires confirm_delete"
+	webPackagePlanChangedTemplateConstant     = "the retention plan for %s changed since it was previewed (now deletes %v); reload the packages and queue it again"
+	webPackageDeletedTemplateConstant         = "DELETED VERSIONS: %s/%s kept %d of %d, deleted %d\n"
+)
+
+// webPackageSettings holds the packages delete configuration the web package views share with
+// `gix packages delete`.
+type webPackageSettings struct {
+	service         *ghcr.PackageVersionService
+	credential      string
+	packageOverride string
+	resolver        packages.RepositoryMetadataResolver
+}
+
+func (application *Application) webPackageSettings() (webPackageSettings, error) {
+	configuration := application.packagesConfiguration().Sanitize()
+	baseURL := strings.TrimSpace(configuration.Delete.BaseURL)
+	if len(baseURL) == 0 {
+		return webPackageSettings{}, errors.New(webPackageBaseURLRequiredErrorConstant)
+	}
+	credential := strings.TrimSpace(configuration.Delete.Credential)
+	if len(credential) == 0 {
+		return webPackageSettings{}, errors.New(webPackageCredentialRequiredErrorConstant)
+	}
+
+	_, _, repositoryManager, githubResolver, dependencyError := application.webAuditDependencies()
+	if dependencyError != nil {
+		return webPackageSettings{}, dependencyError
+	}
+	service, serviceError := ghcr.NewPackageVersionService(application.logger, nil, ghcr.ServiceConfiguration{BaseURL: baseURL})
+	if serviceError != nil {
+		return webPackageSettings{}, serviceError
+	}
+
+	return webPackageSettings{
+		service:         service,
+		credential:      credential,
+		packageOverride: strings.TrimSpace(configuration.Delete.PackageName),
+		resolver: &packages.DefaultRepositoryMetadataResolver{
+			RepositoryManager: repositoryManager,
+			GitHubResolver:    githubResolver,
+		},
+	}, nil
+}
+
+// packageRequest resolves the container package of the repository the same way `gix packages delete`
+// does: the configured package name, or else the repository name, under the owner of its origin.
+func (settings webPackageSettings) packageRequest(executionContext context.Context, repositoryPath string, packageName string) (ghcr.PackageRequest, error) {
+	metadata, metadataError := settings.resolver.ResolveMetadata(executionContext, repositoryPath)
+	if metadataError != nil {
+		return ghcr.PackageRequest{}, metadataError
+	}
+	resolvedPackageName := strings.TrimSpace(packageName)
+	if len(resolvedPackageName) == 0 {
+		resolvedPackageName = settings.packageOverride
+	}
+	if len(resolvedPackageName) == 0 {
+		resolvedPackageName = metadata.DefaultPackageName
+	}
+
+	return ghcr.PackageRequest{
+		Owner:       metadata.Owner,
+		PackageName: resolvedPackageName,
+		OwnerType:   metadata.OwnerType,
+		Token:       settings.credential,
+	}, nil
+}
+
+// newWebPackageDashboardLoader lists every version of the container package of each repository under
+// the requested roots.
+func (application *Application) newWebPackageDashboardLoader() web.PackageDashboardLoader {
+	return func(executionContext context.Context, request web.PackageDashboardRequest) web.PackageDashboard {
+		roots := make([]string, 0, len(request.Roots))
+		for _, root := range request.Roots {
+			if normalizedRoot := canonicalWebPath(root); len(normalizedRoot) > 0 {
+				roots = append(roots, normalizedRoot)
+			}
+		}
+		dashboard := web.PackageDashboard{Roots: roots, Packages: []web.PackageDescriptor{}}
+		if len(roots) == 0 {
+			dashboard.Error = webPullRequestRootsRequiredErrorConstant
+			return dashboard
+		}
+		settings, settingsError := application.webPackageSettings()
+		if settingsError != nil {
+			dashboard.Error = settingsError.Error()
+			return dashboard
+		}
+
+		repositoryPaths, discoverError := discoverWebRepositoryPaths(roots)
+		if discoverError != nil {
+			dashboard.Error = discoverError.Error()
+			return dashboard
+		}
+		for _, repositoryPath := range repositoryPaths {
+			dashboard.Packages = append(dashboard.Packages, loadWebPackage(executionContext, settings, repositoryPath))
+		}
+		return dashboard
+	}
+}
+
+func loadWebPackage(executionContext context.Context, settings webPackageSettings, repositoryPath string) web.PackageDescriptor {
+	descriptor := web.PackageDescriptor{Path: repositoryPath, Versions: []web.PackageVersionDescriptor{}}
+	packageRequest, requestError := settings.packageRequest(executionContext, repositoryPath, "")
+	if requestError != nil {
+		descriptor.Error = requestError.Error()
+		return descriptor
+	}
+	descriptor.Owner = packageRequest.Owner
+	descriptor.OwnerType = string(packageRequest.OwnerType)
+	descriptor.Package = packageRequest.PackageName
+
+	versions, listError := settings.service.ListVersions(executionContext, packageRequest)
+	if listError != nil {
+		descriptor.Error = listError.Error()
+		return descriptor
+	}
+	for _, version := range versions {
+		tags := version.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		descriptor.Versions = append(descriptor.Versions, web.PackageVersionDescriptor{
+			ID:        version.ID,
+			Name:      version.Name,
+			Tags:      tags,
+			CreatedAt: version.CreatedAt,
+			Size:      version.Size,
+		})
+	}
+	return descriptor
+}
+
+// applyWebPackageRetentionChange deletes the package versions outside the queued keep count and pins.
+// The plan is recomputed first and the change refuses to delete anything when it no longer matches
+// the versions the operator previewed.
+func (application *Application) applyWebPackageRetentionChange(executionContext context.Context, repositoryPath string, change web.AuditQueuedChange, outputWriter io.Writer) error {
+	if !change.ConfirmDelete {
+		return errors.New(webPackageDeleteRejectedErrorConstant)
+	}
+	keepCount, keepCountError := ghcr.NewKeepCount(change.Keep)
+	if keepCountError != nil {
+		return keepCountError
+	}
+	settings, settingsError := application.webPackageSettings()
+	if settingsError != nil {
+		return settingsError
+	}
+	packageRequest, requestError := settings.packageRequest(executionContext, repositoryPath, change.Package)
+	if requestError != nil {
+		return requestError
+	}
+
+	versions, listError := settings.service.ListVersions(executionContext, packageRequest)
+	if listError != nil {
+		return listError
+	}
+	plannedVersionIDs := make([]int64, 0)
+	for _, version := range ghcr.PlanRetention(versions, keepCount, change.PinnedVersions) {
+		plannedVersionIDs = append(plannedVersionIDs, version.ID)
+	}
+	if !slices.Equal(slices.Sorted(slices.Values(plannedVersionIDs)), slices.Sorted(slices.Values(change.DeleteVersions))) {
+		return fmt.Errorf(webPackagePlanChangedTemplateConstant, packageRequest.PackageName, plannedVersionIDs)
+	}
+
+	result, retentionError := settings.service.ApplyRetention(executionContext, ghcr.RetentionRequest{
+		Owner:            packageRequest.Owner,
+		PackageName:      packageRequest.PackageName,
+		OwnerType:        packageRequest.OwnerType,
+		Token:            packageRequest.Token,
+		Keep:             keepCount,
+		PinnedVersionIDs: change.PinnedVersions,
+	})
+	if retentionError != nil {
+		return retentionError
+	}
+	_, _ = fmt.Fprintf(outputWriter, webPackageDeletedTemplateConstant, packageRequest.Owner, packageRequest.PackageName, result.RetainedVersions, result.TotalVersions, result.DeletedVersions)
+	return nil
+}
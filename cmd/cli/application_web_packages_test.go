@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tyemirov/gix/internal/githubauth"
+	"github.com/tyemirov/gix/internal/web"
+)
+
+const (
+	webPackageRepoViewStubScriptConstant = `#!/bin/sh
+echo '{"nameWithOwner":"octo/example","isInOrganization":true}'
+`
+	webPackageVersionsPathConstant = "/orgs/octo/packages/container/example/versions"
+	webPackageVersionsPageConstant = `[` +
+		`{"id":303,"name":"sha256:303","created_at":"2026-03-03T00:00:00Z","metadata":{"container":{"tags":["v3","latest"]}}},` +
+		`{"id":101,"name":"sha256:101","created_at":"2026-01-01T00:00:00Z","size":2048,"metadata":{"container":{"tags":[]}}},` +
+		`{"id":202,"name":"sha256:202","created_at":"2026-02-02T00:00:00Z","metadata":{"container":{"tags":["v2"]}}}]`
+)
+
+// newWebPackageRegistryStub serves the GitHub packages API for octo/example with one page of versions
+// and records every deleted version path.
+func newWebPackageRegistryStub(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var deletedPathsMutex sync.Mutex
+	deletedPaths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		switch {
+		case request.Method == http.MethodGet && request.URL.Path == webPackageVersionsPathConstant:
+			responseWriter.Header().Set("Content-Type", "application/json")
+			if request.URL.Query().Get("page") == "1" {
+				_, _ = responseWriter.Write([]byte(webPackageVersionsPageConstant))
+				return
+			}
+			_, _ = responseWriter.Write([]byte(`[]`))
+		case request.Method == http.MethodDelete && strings.HasPrefix(request.URL.Path, webPackageVersionsPathConstant+"/"):
+			deletedPathsMutex.Lock()
+			deletedPaths = append(deletedPaths, strings.TrimPrefix(request.URL.Path, webPackageVersionsPathConstant+"/"))
+			deletedPathsMutex.Unlock()
+			responseWriter.WriteHeader(http.StatusNoContent)
+		default:
+			responseWriter.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		deletedPathsMutex.Lock()
+		defer deletedPathsMutex.Unlock()
+		return append([]string(nil), deletedPaths...)
+	}
+}
+
+func newWebPackageTestApplication(t *testing.T, baseURL string) *Application {
+	t.Helper()
+
+	stubDirectory := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(stubDirectory, "gh"), []byte(webPackageRepoViewStubScriptConstant), 0o755))
+	t.Setenv("PATH", stubDirectory+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	operations, buildError := newOperationConfigurations([]ApplicationOperationConfiguration{{
+		Command: []string{"packages", "delete"},
+		Options: map[string]any{"base_url": baseURL, "credential": "test-registry-token"},
+	}})
+	require.NoError(t, buildError)
+
+	application := NewApplication()
+	application.logger = zap.NewNop()
+	application.operationConfigurations = operations
+	return application
+}
+
+func TestWebPackageDashboardListsVersionsNewestFirst(t *testing.T) {
+	registry, _ := newWebPackageRegistryStub(t)
+	workspacePath := t.TempDir()
+	repositoryPath := createTestRepository(t, filepath.Join(workspacePath, "example"))
+	runGitCommand(t, repositoryPath, "remote", "add", "origin", "https://github.com/octo/example.git")
+
+	application := newWebPackageTestApplication(t, registry.URL)
+	executionContext := githubauth.WithCredential(context.Background(), "test-github-key")
+	dashboard := application.newWebPackageDashboardLoader()(executionContext, web.PackageDashboardRequest{Roots: []string{workspacePath}})
+	require.Empty(t, dashboard.Error)
+	require.Len(t, dashboard.Packages, 1)
+
+	descriptor := dashboard.Packages[0]
+	require.Empty(t, descriptor.Error)
+	require.Equal(t, "octo", descriptor.Owner)
+	require.Equal(t, "org", descriptor.OwnerType)
+	require.Equal(t, "example", descriptor.Package)
+	require.Len(t, descriptor.Versions, 3)
+	require.Equal(t, []int64{303, 202, 101}, []int64{descriptor.Versions[0].ID, descriptor.Versions[1].ID, descriptor.Versions[2].ID})
+	require.Equal(t, []string{"v3", "latest"}, descriptor.Versions[0].Tags)
+	require.Nil(t, descriptor.Versions[0].Size)
+	require.NotNil(t, descriptor.Versions[2].Size)
+	require.Equal(t, int64(2048), *descriptor.Versions[2].Size)
+
+	unconfigured := NewApplication().newWebPackageDashboardLoader()(executionContext, web.PackageDashboardRequest{Roots: []string{workspacePath}})
+	require.Equal(t, webPackageBaseURLRequiredErrorConstant, unconfigured.Error)
+}
+
+func TestWebPackageRetentionChangeRequiresThePreviewedPlan(t *testing.T) {
+	registry, deletedPaths := newWebPackageRegistryStub(t)
+	workspacePath := t.TempDir()
+	repositoryPath := createTestRepository(t, filepath.Join(workspacePath, "example"))
+	runGitCommand(t, repositoryPath, "remote", "add", "origin", "https://github.com/octo/example.git")
+
+	application := newWebPackageTestApplication(t, registry.URL)
+	executionContext := githubauth.WithCredential(context.Background(), "test-github-key")
+	output := &bytes.Buffer{}
+
+	unconfirmedError := application.applyWebPackageRetentionChange(executionContext, repositoryPath, web.AuditQueuedChange{
+		Kind:           web.AuditChangeKindPackageRetention,
+		Keep:           1,
+		DeleteVersions: []int64{101, 202},
+	}, output)
+	require.EqualError(t, unconfirmedError, webPackageDeleteRejectedErrorConstant)
+
+	staleError := application.applyWebPackageRetentionChange(executionContext, repositoryPath, web.AuditQueuedChange{
+		Kind:           web.AuditChangeKindPackageRetention,
+		Keep:           1,
+		DeleteVersions: []int64{101},
+		ConfirmDelete:  true,
+	}, output)
+	require.ErrorContains(t, staleError, "changed since it was previewed")
+	require.Empty(t, deletedPaths())
+
+	require.NoError(t, application.applyWebPackageRetentionChange(executionContext, repositoryPath, web.AuditQueuedChange{
+		Kind:           web.AuditChangeKindPackageRetention,
+		Keep:           1,
+		PinnedVersions: []int64{101},
+		DeleteVersions: []int64{202},
+		ConfirmDelete:  true,
+	}, output))
+	require.Equal(t, []string{"202"}, deletedPaths())
+	require.Equal(t, fmt.Sprintf(webPackageDeletedTemplateConstant, "octo", "example", 2, 3, 1), output.String())
+}
@@ -0,0 +1,403 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/tyemirov/gix/internal/branches"
+	"github.com/tyemirov/gix/internal/githubcli"
+	"github.com/tyemirov/gix/internal/gitrepo"
+	reposdeps "github.com/tyemirov/gix/internal/repos/dependencies"
+	"github.com/tyemirov/gix/internal/repos/identity"
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/web"
+)
+
+const (
+	webPullRequestMergedLimitConstant           = 30
+	webPullRequestLocalBranchFormatConstant     = "--format=%(HEAD)%1f%(refname:short)"
+	webPullRequestCurrentBranchMarkerConstant   = "*"
+	webPullRequestRootsRequiredErrorConstant    = "at least one root is required"
+	webPullRequestRemoteMissingTemplateConstant = "no GitHub repository found for the %s remote"
+	webPullRequestTargetRequiredErrorConstant   = "pull request changes require repository and pull_request"
+	webPullRequestBaseRequiredErrorConstant     = "retarget_pull_request requires base_branch"
+	webPullRequestBranchRequiredErrorConstant   = "delete_merged_branch requires branch"
+	webPullRequestDeleteRejectedErrorConstant   = "delete_merged_branch requires confirm_delete"
+	webPullRequestNotMergedTemplateConstant     = "pull request %s#%d from %s is not merged"
+	webPullRequestForkHeadTemplateConstant      = "the head branch of %s#%d lives in %s; delete it there"
+	webPullRequestClosedTemplateConstant        = "CLOSED: %s#%d\n"
+	webPullRequestRetargetedTemplateConstant    = "RETARGETED: %s#%d -> %s\n"
+	webPullRequestBranchDeletedTemplateConstant = "DELETED BRANCH: %s\n"
+)
+
+// newWebPullRequestDashboardLoader lists the open pull requests of every repository under the requested
+// roots, grouped into stacks, plus the merged pull requests whose head branch from the same repository
+// is still present locally.
+func (application *Application) newWebPullRequestDashboardLoader() web.PullRequestDashboardLoader {
+	gitExecutor, repositoryManager, dependencyError := application.webGitDependencies()
+
+	return func(executionContext context.Context, request web.PullRequestDashboardRequest) web.PullRequestDashboard {
+		roots := make([]string, 0, len(request.Roots))
+		for _, root := range request.Roots {
+			if normalizedRoot := canonicalWebPath(root); len(normalizedRoot) > 0 {
+				roots = append(roots, normalizedRoot)
+			}
+		}
+		dashboard := web.PullRequestDashboard{Roots: roots, Repositories: []web.PullRequestRepository{}}
+		if len(roots) == 0 {
+			dashboard.Error = webPullRequestRootsRequiredErrorConstant
+			return dashboard
+		}
+		if dependencyError != nil {
+			dashboard.Error = dependencyError.Error()
+			return dashboard
+		}
+		githubClient, clientError := githubcli.NewClient(gitExecutor)
+		if clientError != nil {
+			dashboard.Error = clientError.Error()
+			return dashboard
+		}
+
+		repositoryPaths, discoverError := discoverWebRepositoryPaths(roots)
+		if discoverError != nil {
+			dashboard.Error = discoverError.Error()
+			return dashboard
+		}
+		for _, repositoryPath := range repositoryPaths {
+			dashboard.Repositories = append(dashboard.Repositories,
+				loadWebPullRequestRepository(executionContext, gitExecutor, repositoryManager, githubClient, repositoryPath))
+		}
+		return dashboard
+	}
+}
+
+// discoverWebRepositoryPaths lists the repositories under the roots once each, sorted by canonical path.
+func discoverWebRepositoryPaths(roots []string) ([]string, error) {
+	repositoryPaths, discoverError := reposdeps.ResolveRepositoryDiscoverer(nil).DiscoverRepositories(roots)
+	if discoverError != nil {
+		return nil, discoverError
+	}
+	normalizedPaths := make([]string, 0, len(repositoryPaths))
+	for _, repositoryPath := range repositoryPaths {
+		normalizedPaths = append(normalizedPaths, canonicalWebPath(repositoryPath))
+	}
+	sort.Strings(normalizedPaths)
+	return slices.Compact(normalizedPaths), nil
+}
+
+func loadWebPullRequestRepository(
+	executionContext context.Context,
+	gitExecutor execshellGitExecutor,
+	repositoryManager webGitRepositoryManager,
+	githubClient *githubcli.Client,
+	repositoryPath string,
+) web.PullRequestRepository {
+	repository := web.PullRequestRepository{
+		Path:   repositoryPath,
+		Name:   filepath.Base(repositoryPath),
+		Stacks: []web.PullRequestStack{},
+		Merged: []web.PullRequestDescriptor{},
+	}
+
+	// The GitHub repository comes from the origin URL alone, so listing a fleet costs no metadata lookups.
+	remoteIdentity, identityError := identity.ResolveRemoteIdentity(
+		executionContext,
+		identity.RemoteResolutionDependencies{RepositoryManager: repositoryManager, GitExecutor: gitExecutor},
+		identity.RemoteResolutionOptions{RepositoryPath: repositoryPath, RemoteName: shared.OriginRemoteNameConstant},
+	)
+	if identityError != nil {
+		repository.Error = identityError.Error()
+		return repository
+	}
+	if remoteIdentity.OwnerRepository == nil {
+		repository.Error = fmt.Sprintf(webPullRequestRemoteMissingTemplateConstant, shared.OriginRemoteNameConstant)
+		return repository
+	}
+	repository.Repository = remoteIdentity.OwnerRepository.String()
+
+	localBranchOutput, localBranchError := runWebRepositoryGit(executionContext, gitExecutor, repositoryPath,
+		gitForEachRefSubcommandConstant, webPullRequestLocalBranchFormatConstant, webRepositoryLocalReferencePrefixConstant)
+	if localBranchError != nil {
+		repository.Error = localBranchError.Error()
+		return repository
+	}
+	localBranches := parseWebPullRequestLocalBranches(localBranchOutput)
+	for branchName, current := range localBranches {
+		if current {
+			repository.CurrentBranch = branchName
+		}
+	}
+
+	openPullRequests, openError := githubClient.ListPullRequests(executionContext, repository.Repository, githubcli.PullRequestListOptions{
+		State: githubcli.PullRequestStateOpen,
+	})
+	if openError != nil {
+		repository.Error = openError.Error()
+		return repository
+	}
+	mergedPullRequests, mergedError := githubClient.ListPullRequests(executionContext, repository.Repository, githubcli.PullRequestListOptions{
+		State:       githubcli.PullRequestStateMerged,
+		ResultLimit: webPullRequestMergedLimitConstant,
+	})
+	if mergedError != nil {
+		repository.Error = mergedError.Error()
+		return repository
+	}
+
+	openDescriptors := make([]web.PullRequestDescriptor, 0, len(openPullRequests))
+	openHeads := map[string]struct{}{}
+	for _, pullRequest := range openPullRequests {
+		openDescriptors = append(openDescriptors, describeWebPullRequest(executionContext, gitExecutor, repositoryPath, repository.Repository, localBranches, pullRequest, web.PullRequestStateOpen))
+		openHeads[pullRequest.HeadRefName] = struct{}{}
+	}
+	repository.Stacks = groupWebPullRequestStacks(openDescriptors)
+
+	for _, pullRequest := range mergedPullRequests {
+		if _, present := localBranches[pullRequest.HeadRefName]; !present || webPullRequestCrossRepository(repository.Repository, pullRequest.HeadRepositoryNameWithOwner) {
+			continue
+		}
+		// A branch reused by a newer open pull request is not a leftover.
+		if _, reopened := openHeads[pullRequest.HeadRefName]; reopened {
+			continue
+		}
+		repository.Merged = append(repository.Merged, describeWebPullRequest(executionContext, gitExecutor, repositoryPath, repository.Repository, localBranches, pullRequest, web.PullRequestStateMerged))
+	}
+
+	return repository
+}
+
+func describeWebPullRequest(
+	executionContext context.Context,
+	gitExecutor execshellGitExecutor,
+	repositoryPath string,
+	repositoryName string,
+	localBranches map[string]bool,
+	pullRequest githubcli.PullRequest,
+	state string,
+) web.PullRequestDescriptor {
+	descriptor := web.PullRequestDescriptor{
+		Number:          pullRequest.Number,
+		Title:           pullRequest.Title,
+		State:           state,
+		HeadBranch:      pullRequest.HeadRefName,
+		BaseBranch:      pullRequest.BaseRefName,
+		HeadCommit:      pullRequest.HeadRefOID,
+		HeadRepository:  pullRequest.HeadRepositoryNameWithOwner,
+		CrossRepository: webPullRequestCrossRepository(repositoryName, pullRequest.HeadRepositoryNameWithOwner),
+		SyncState:       web.PullRequestSyncStateMissing,
+	}
+
+	current, present := localBranches[pullRequest.HeadRefName]
+	if !present {
+		return descriptor
+	}
+	descriptor.LocalBranch = true
+	descriptor.Current = current
+	if reviewBase, recorded, reviewBaseError := gitrepo.BranchReviewBase(executionContext, gitExecutor, repositoryPath, pullRequest.HeadRefName); reviewBaseError == nil && recorded {
+		descriptor.ReviewBase = reviewBase
+	}
+
+	descriptor.SyncState = web.PullRequestSyncStateUnknown
+	if len(pullRequest.HeadRefOID) == 0 {
+		return descriptor
+	}
+	// The divergence fails when the head commit was never fetched; the state then stays unknown.
+	ahead, behind, counted := countWebRepositoryDivergence(executionContext, gitExecutor, repositoryPath, pullRequest.HeadRefOID, pullRequest.HeadRefName)
+	if !counted {
+		return descriptor
+	}
+	descriptor.Ahead, descriptor.Behind = ahead, behind
+	switch {
+	case ahead > 0 && behind > 0:
+		descriptor.SyncState = web.PullRequestSyncStateDiverged
+	case ahead > 0:
+		descriptor.SyncState = web.PullRequestSyncStateAhead
+	case behind > 0:
+		descriptor.SyncState = web.PullRequestSyncStateBehind
+	default:
+		descriptor.SyncState = web.PullRequestSyncStateInSync
+	}
+	return descriptor
+}
+
+func webPullRequestCrossRepository(repositoryName string, headRepository string) bool {
+	return len(headRepository) > 0 && !strings.EqualFold(repositoryName, headRepository)
+}
+
+// parseWebPullRequestLocalBranches maps each local branch to whether it is checked out.
+func parseWebPullRequestLocalBranches(rawOutput string) map[string]bool {
+	localBranches := map[string]bool{}
+	for _, line := range strings.Split(rawOutput, "\n") {
+		marker, branchName, found := strings.Cut(line, webRepositoryFieldSeparatorConstant)
+		branchName = strings.TrimSpace(branchName)
+		if !found || len(branchName) == 0 {
+			continue
+		}
+		localBranches[branchName] = strings.TrimSpace(marker) == webPullRequestCurrentBranchMarkerConstant
+	}
+	return localBranches
+}
+
+// groupWebPullRequestStacks chains open pull requests the way gix sync stacks them: a pull request's
+// parent is its recorded review base, or its GitHub base when none is recorded, whenever that branch
+// is the head of another open pull request from the same repository. Each stack lists its pull
+// requests parent first, with siblings ordered by number.
+func groupWebPullRequestStacks(pullRequests []web.PullRequestDescriptor) []web.PullRequestStack {
+	headIndexes := map[string]int{}
+	for pullRequestIndex, pullRequest := range pullRequests {
+		if _, duplicate := headIndexes[pullRequest.HeadBranch]; !duplicate && !pullRequest.CrossRepository {
+			headIndexes[pullRequest.HeadBranch] = pullRequestIndex
+		}
+	}
+
+	children := map[int][]int{}
+	rootIndexes := make([]int, 0, len(pullRequests))
+	for pullRequestIndex, pullRequest := range pullRequests {
+		parentBranch := pullRequest.ReviewBase
+		if len(parentBranch) == 0 {
+			parentBranch = pullRequest.BaseBranch
+		}
+		parentIndex, stacked := headIndexes[parentBranch]
+		if !stacked || parentIndex == pullRequestIndex {
+			rootIndexes = append(rootIndexes, pullRequestIndex)
+			continue
+		}
+		children[parentIndex] = append(children[parentIndex], pullRequestIndex)
+	}
+
+	byNumber := func(left int, right int) int {
+		return pullRequests[left].Number - pullRequests[right].Number
+	}
+	slices.SortFunc(rootIndexes, byNumber)
+	for parentIndex := range children {
+		slices.SortFunc(children[parentIndex], byNumber)
+	}
+
+	visited := make([]bool, len(pullRequests))
+	var appendChain func(stack *web.PullRequestStack, pullRequestIndex int, depth int)
+	appendChain = func(stack *web.PullRequestStack, pullRequestIndex int, depth int) {
+		if visited[pullRequestIndex] {
+			return
+		}
+		visited[pullRequestIndex] = true
+		descriptor := pullRequests[pullRequestIndex]
+		descriptor.Depth = depth
+		stack.PullRequests = append(stack.PullRequests, descriptor)
+		for _, childIndex := range children[pullRequestIndex] {
+			appendChain(stack, childIndex, depth+1)
+		}
+	}
+
+	stacks := make([]web.PullRequestStack, 0, len(rootIndexes))
+	appendStack := func(rootIndex int) {
+		stack := web.PullRequestStack{Base: pullRequests[rootIndex].BaseBranch}
+		appendChain(&stack, rootIndex, 0)
+		stacks = append(stacks, stack)
+	}
+	for _, rootIndex := range rootIndexes {
+		appendStack(rootIndex)
+	}
+	// Recorded parents that point at each other leave a cycle with no root; list it from its lowest number.
+	remainingIndexes := make([]int, 0)
+	for pullRequestIndex := range pullRequests {
+		if !visited[pullRequestIndex] {
+			remainingIndexes = append(remainingIndexes, pullRequestIndex)
+		}
+	}
+	slices.SortFunc(remainingIndexes, byNumber)
+	for _, pullRequestIndex := range remainingIndexes {
+		if !visited[pullRequestIndex] {
+			appendStack(pullRequestIndex)
+		}
+	}
+	return stacks
+}
+
+// applyWebPullRequestChange closes or retargets a pull request, or deletes the head branch of a merged one.
+func (application *Application) applyWebPullRequestChange(executionContext context.Context, repositoryPath string, change web.AuditQueuedChange, outputWriter io.Writer) error {
+	repositoryName := strings.TrimSpace(change.Repository)
+	if len(repositoryName) == 0 || change.PullRequest <= 0 {
+		return errors.New(webPullRequestTargetRequiredErrorConstant)
+	}
+	gitExecutor, _, dependencyError := application.webGitDependencies()
+	if dependencyError != nil {
+		return dependencyError
+	}
+	githubClient, clientError := githubcli.NewClient(gitExecutor)
+	if clientError != nil {
+		return clientError
+	}
+	headBranch := strings.TrimSpace(change.Branch)
+
+	switch change.Kind {
+	case web.AuditChangeKindClosePullRequest:
+		if closeError := githubClient.ClosePullRequest(executionContext, repositoryName, change.PullRequest); closeError != nil {
+			return closeError
+		}
+		_, _ = fmt.Fprintf(outputWriter, webPullRequestClosedTemplateConstant, repositoryName, change.PullRequest)
+	case web.AuditChangeKindRetargetPullRequest:
+		baseBranch := strings.TrimSpace(change.BaseBranch)
+		if len(baseBranch) == 0 {
+			return errors.New(webPullRequestBaseRequiredErrorConstant)
+		}
+		if updateError := githubClient.UpdatePullRequestBase(executionContext, repositoryName, change.PullRequest, baseBranch); updateError != nil {
+			return updateError
+		}
+		_, _ = fmt.Fprintf(outputWriter, webPullRequestRetargetedTemplateConstant, repositoryName, change.PullRequest, baseBranch)
+		// Keep a recorded stack parent in step with the new base so the next sync does not retarget it back.
+		if len(headBranch) == 0 {
+			return nil
+		}
+		reviewBase, recorded, reviewBaseError := gitrepo.BranchReviewBase(executionContext, gitExecutor, repositoryPath, headBranch)
+		if reviewBaseError != nil {
+			return reviewBaseError
+		}
+		if recorded && reviewBase != baseBranch {
+			return gitrepo.RecordBranchReviewBase(executionContext, gitExecutor, repositoryPath, headBranch, baseBranch)
+		}
+	case web.AuditChangeKindDeleteMergedBranch:
+		if !change.ConfirmDelete {
+			return errors.New(webPullRequestDeleteRejectedErrorConstant)
+		}
+		if len(headBranch) == 0 {
+			return errors.New(webPullRequestBranchRequiredErrorConstant)
+		}
+		// Re-check on GitHub at apply time: the queue may be older than the merge state it was built from.
+		mergedPullRequests, listError := githubClient.ListPullRequests(executionContext, repositoryName, githubcli.PullRequestListOptions{
+			State:      githubcli.PullRequestStateMerged,
+			HeadBranch: headBranch,
+		})
+		if listError != nil {
+			return listError
+		}
+		mergedIndex := slices.IndexFunc(mergedPullRequests, func(pullRequest githubcli.PullRequest) bool {
+			return pullRequest.Number == change.PullRequest && pullRequest.HeadRefName == headBranch
+		})
+		if mergedIndex < 0 {
+			return fmt.Errorf(webPullRequestNotMergedTemplateConstant, repositoryName, change.PullRequest, headBranch)
+		}
+		if headRepository := mergedPullRequests[mergedIndex].HeadRepositoryNameWithOwner; webPullRequestCrossRepository(repositoryName, headRepository) {
+			return fmt.Errorf(webPullRequestForkHeadTemplateConstant, repositoryName, change.PullRequest, headRepository)
+		}
+
+		branchService, serviceError := branches.NewService(application.logger, gitExecutor, nil)
+		if serviceError != nil {
+			return serviceError
+		}
+		if deleteError := branchService.DeleteBranch(executionContext, branches.BranchDeletionOptions{
+			RemoteName:       shared.OriginRemoteNameConstant,
+			BranchName:       headBranch,
+			WorkingDirectory: repositoryPath,
+		}); deleteError != nil {
+			return deleteError
+		}
+		_, _ = fmt.Fprintf(outputWriter, webPullRequestBranchDeletedTemplateConstant, headBranch)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/web"
+)
+
+const webPullRequestStubScriptTemplate = `#!/bin/sh
+echo "$@" >> %q
+case "$*" in
+  *"--state open"*) cat <<'JSON'
+%s
+JSON
+  ;;
+  *"--state merged"*) cat <<'JSON'
+%s
+JSON
+  ;;
+esac
+`
+
+// installWebPullRequestStub puts a gh script on PATH that answers open and merged pull request lists
+// and records every invocation in the returned log file.
+func installWebPullRequestStub(t *testing.T, openPayload string, mergedPayload string) string {
+	t.Helper()
+
+	stubDirectory := t.TempDir()
+	logPath := filepath.Join(stubDirectory, "gh.log")
+	script := []byte(fmt.Sprintf(webPullRequestStubScriptTemplate, logPath, openPayload, mergedPayload))
+	require.NoError(t, os.WriteFile(filepath.Join(stubDirectory, "gh"), script, 0o755))
+	t.Setenv("PATH", stubDirectory+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return logPath
+}
+
+func TestWebPullRequestDashboardGroupsStacksAndReportsLocalState(t *testing.T) {
+	workspacePath := t.TempDir()
+	repositoryPath := createTestRepository(t, filepath.Join(workspacePath, "example"))
+	runGitCommand(t, repositoryPath, "remote", "add", "origin", "https://github.com/octo/example.git")
+	createTestBranch(t, repositoryPath, "feature/parent")
+	parentHead := strings.TrimSpace(runGitCommandOutput(t, repositoryPath, "rev-parse", "HEAD"))
+	createTestBranch(t, repositoryPath, "feature/child")
+	runGitCommand(t, repositoryPath, "config", "branch.feature/child.gix-review-base", "feature/parent")
+	createTestBranch(t, repositoryPath, "feature/done")
+	runGitCommand(t, repositoryPath, "checkout", "feature/child")
+
+	installWebPullRequestStub(t,
+		`[{"number":3,"title":"Other","headRefName":"feature/other","headRefOid":"","headRepository":{"nameWithOwner":"octo/example"},"baseRefName":"master"},`+
+			`{"number":2,"title":"Child","headRefName":"feature/child","headRefOid":"`+parentHead+`","headRepository":{"nameWithOwner":"octo/example"},"baseRefName":"master"},`+
+			`{"number":1,"title":"Parent","headRefName":"feature/parent","headRefOid":"`+parentHead+`","headRepository":{"nameWithOwner":"octo/example"},"baseRefName":"master"}]`,
+		`[{"number":4,"title":"Done","headRefName":"feature/done","headRefOid":"","headRepository":{"nameWithOwner":"octo/example"},"baseRefName":"master"},`+
+			`{"number":5,"title":"Gone","headRefName":"feature/gone","headRefOid":"","headRepository":{"nameWithOwner":"octo/example"},"baseRefName":"master"}]`,
+	)
+
+	dashboard := NewApplication().newWebPullRequestDashboardLoader()(context.Background(), web.PullRequestDashboardRequest{Roots: []string{workspacePath}})
+	require.Empty(t, dashboard.Error)
+	require.Len(t, dashboard.Repositories, 1)
+
+	repository := dashboard.Repositories[0]
+	require.Empty(t, repository.Error)
+	require.Equal(t, "octo/example", repository.Repository)
+	require.Equal(t, "feature/child", repository.CurrentBranch)
+	require.Len(t, repository.Stacks, 2)
+
+	parentStack := repository.Stacks[0]
+	require.Equal(t, "master", parentStack.Base)
+	require.Len(t, parentStack.PullRequests, 2)
+	require.Equal(t, 1, parentStack.PullRequests[0].Number)
+	require.Equal(t, web.PullRequestSyncStateInSync, parentStack.PullRequests[0].SyncState)
+	require.Equal(t, 2, parentStack.PullRequests[1].Number)
+	require.Equal(t, 1, parentStack.PullRequests[1].Depth)
+	require.Equal(t, "feature/parent", parentStack.PullRequests[1].ReviewBase)
+	require.True(t, parentStack.PullRequests[1].Current)
+
+	otherStack := repository.Stacks[1]
+	require.Equal(t, 3, otherStack.PullRequests[0].Number)
+	require.False(t, otherStack.PullRequests[0].LocalBranch)
+	require.Equal(t, web.PullRequestSyncStateMissing, otherStack.PullRequests[0].SyncState)
+
+	require.Len(t, repository.Merged, 1)
+	require.Equal(t, 4, repository.Merged[0].Number)
+	require.Equal(t, web.PullRequestStateMerged, repository.Merged[0].State)
+
+	missingRoots := NewApplication().newWebPullRequestDashboardLoader()(context.Background(), web.PullRequestDashboardRequest{Roots: []string{" "}})
+	require.Equal(t, webPullRequestRootsRequiredErrorConstant, missingRoots.Error)
+}
+
+func TestGroupWebPullRequestStacksBreaksRecordedCycles(t *testing.T) {
+	stacks := groupWebPullRequestStacks([]web.PullRequestDescriptor{
+		{Number: 8, HeadBranch: "feature/b", BaseBranch: "master", ReviewBase: "feature/a"},
+		{Number: 7, HeadBranch: "feature/a", BaseBranch: "master", ReviewBase: "feature/b"},
+		{Number: 9, HeadBranch: "feature/fork", BaseBranch: "feature/a", CrossRepository: true},
+	})
+
+	require.Len(t, stacks, 1)
+	require.Equal(t, []int{7, 8, 9}, []int{stacks[0].PullRequests[0].Number, stacks[0].PullRequests[1].Number, stacks[0].PullRequests[2].Number})
+	require.Equal(t, []int{0, 1, 1}, []int{stacks[0].PullRequests[0].Depth, stacks[0].PullRequests[1].Depth, stacks[0].PullRequests[2].Depth})
+}
+
+func TestWebPullRequestChangesCloseRetargetAndDeleteMergedBranches(t *testing.T) {
+	workspacePath := t.TempDir()
+	repositoryPath := createTestRepository(t, filepath.Join(workspacePath, "example"))
+	remotePath := filepath.Join(workspacePath, "origin.git")
+	runGitCommand(t, "", "clone", "--bare", repositoryPath, remotePath)
+	runGitCommand(t, repositoryPath, "remote", "add", "origin", "https://github.com/octo/example.git")
+	runGitCommand(t, repositoryPath, "remote", "set-url", "--push", "origin", remotePath)
+	createTestBranch(t, repositoryPath, "feature/child")
+	runGitCommand(t, repositoryPath, "config", "branch.feature/child.gix-review-base", "feature/parent")
+	createTestBranch(t, repositoryPath, "feature/done")
+	runGitCommand(t, repositoryPath, "push", "origin", "feature/done")
+	runGitCommand(t, repositoryPath, "checkout", "master")
+
+	logPath := installWebPullRequestStub(t, `[]`,
+		`[{"number":4,"title":"Done","headRefName":"feature/done","headRefOid":"","headRepository":{"nameWithOwner":"octo/example"},"baseRefName":"master"}]`)
+
+	application := NewApplication()
+	output := &bytes.Buffer{}
+	require.NoError(t, application.applyWebPullRequestChange(context.Background(), repositoryPath, web.AuditQueuedChange{
+		Kind:        web.AuditChangeKindClosePullRequest,
+		Repository:  "octo/example",
+		PullRequest: 3,
+	}, output))
+	require.NoError(t, application.applyWebPullRequestChange(context.Background(), repositoryPath, web.AuditQueuedChange{
+		Kind:        web.AuditChangeKindRetargetPullRequest,
+		Repository:  "octo/example",
+		PullRequest: 2,
+		Branch:      "feature/child",
+		BaseBranch:  "master",
+	}, output))
+	require.Equal(t, "master", strings.TrimSpace(runGitCommandOutput(t, repositoryPath, "config", "branch.feature/child.gix-review-base")))
+
+	rejectedError := application.applyWebPullRequestChange(context.Background(), repositoryPath, web.AuditQueuedChange{
+		Kind:        web.AuditChangeKindDeleteMergedBranch,
+		Repository:  "octo/example",
+		PullRequest: 4,
+		Branch:      "feature/done",
+	}, output)
+	require.EqualError(t, rejectedError, webPullRequestDeleteRejectedErrorConstant)
+
+	notMergedError := application.applyWebPullRequestChange(context.Background(), repositoryPath, web.AuditQueuedChange{
+		Kind:          web.AuditChangeKindDeleteMergedBranch,
+		Repository:    "octo/example",
+		PullRequest:   6,
+		Branch:        "feature/done",
+		ConfirmDelete: true,
+	}, output)
+	require.EqualError(t, notMergedError, "pull request octo/example#6 from feature/done is not merged")
+
+	require.NoError(t, application.applyWebPullRequestChange(context.Background(), repositoryPath, web.AuditQueuedChange{
+		Kind:          web.AuditChangeKindDeleteMergedBranch,
+		Repository:    "octo/example",
+		PullRequest:   4,
+		Branch:        "feature/done",
+		ConfirmDelete: true,
+	}, output))
+	require.Empty(t, strings.TrimSpace(runGitCommandOutput(t, repositoryPath, "branch", "--list", "feature/done")))
+	require.Empty(t, strings.TrimSpace(runGitCommandOutput(t, remotePath, "branch", "--list", "feature/done")))
+
+	require.Contains(t, output.String(), "CLOSED: octo/example#3")
+	require.Contains(t, output.String(), "RETARGETED: octo/example#2 -> master")
+	require.Contains(t, output.String(), "DELETED BRANCH: feature/done")
+
+	ghLog, readError := os.ReadFile(logPath)
+	require.NoError(t, readError)
+	require.Contains(t, string(ghLog), "pr close 3 --repo octo/example")
+	require.Contains(t, string(ghLog), "pr edit 2 --repo octo/example --base master")
+}
@@ -0,0 +1,337 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/web"
+)
+
+const (
+	webRepositoryGraphCommitLimitConstant         = 50
+	webRepositoryFieldSeparatorConstant           = "\x1f"
+	webRepositoryBranchFormatArgumentConstant     = "--format=%(HEAD)%1f%(refname)%1f%(refname:short)%1f%(upstream:short)%1f%(upstream:track)"
+	webRepositoryLocalReferencePrefixConstant     = "refs/heads/"
+	webRepositoryRemoteReferencePrefixConstant    = "refs/remotes/"
+	webRepositorySymbolicHeadSuffixConstant       = "/HEAD"
+	webRepositoryUpstreamGoneConstant             = "[gone]"
+	webRepositoryGitLogSubcommandConstant         = "log"
+	webRepositoryGitGraphArgumentConstant         = "--graph"
+	webRepositoryGitBranchesArgumentConstant      = "--branches"
+	webRepositoryGitRemotesArgumentConstant       = "--remotes"
+	webRepositoryGitTagsArgumentConstant          = "--tags"
+	webRepositoryGitDateArgumentConstant          = "--date=iso-strict"
+	webRepositoryGitLogFormatArgumentConstant     = "--format=%x1f%H%x1f%h%x1f%P%x1f%an%x1f%ad%x1f%D%x1f%s"
+	webRepositoryGitStatusSubcommandConstant      = "status"
+	webRepositoryGitPorcelainArgumentConstant     = "--porcelain"
+	webRepositoryGitStashSubcommandConstant       = "stash"
+	webRepositoryGitStashListArgumentConstant     = "list"
+	webRepositoryGitStashFormatArgumentConstant   = "--format=%gd%x1f%ci%x1f%s"
+	webRepositoryGitRevListSubcommandConstant     = "rev-list"
+	webRepositoryGitLeftRightArgumentConstant     = "--left-right"
+	webRepositoryGitCountArgumentConstant         = "--count"
+	webRepositoryGitDiffSubcommandConstant        = "diff"
+	webRepositoryGitNoIndexArgumentConstant       = "--no-index"
+	webRepositoryGitPathSeparatorArgumentConstant = "--"
+	webRepositoryNullDevicePathConstant           = "/dev/null"
+	webRepositoryUntrackedStatusConstant          = "??"
+	webRepositoryRenameSeparatorConstant          = " -> "
+	webRepositoryDiffChangesExitCodeConstant      = 1
+	webRepositoryDivergenceRangeTemplateConstant  = "%s...%s"
+	webRepositoryFileNotDirtyTemplateConstant     = "%s has no working tree changes"
+)
+
+var webRepositoryUpstreamTrackPattern = regexp.MustCompile(`(ahead|behind) (\d+)`)
+
+func (application *Application) newWebRepositoryDetailLoader() web.RepositoryDetailLoader {
+	gitExecutor, _, dependencyError := application.webGitDependencies()
+
+	return func(executionContext context.Context, repositoryPath string) web.RepositoryDetail {
+		normalizedPath := canonicalWebPath(repositoryPath)
+		if len(normalizedPath) == 0 {
+			return web.RepositoryDetail{Error: webRepositoryPathRequiredErrorConstant}
+		}
+		if dependencyError != nil {
+			return web.RepositoryDetail{Path: normalizedPath, Error: dependencyError.Error()}
+		}
+
+		return loadWebRepositoryDetail(executionContext, gitExecutor, normalizedPath)
+	}
+}
+
+func (application *Application) newWebRepositoryFileDiffLoader() web.RepositoryFileDiffLoader {
+	gitExecutor, _, dependencyError := application.webGitDependencies()
+
+	return func(executionContext context.Context, request web.RepositoryFileDiffRequest) web.RepositoryFileDiff {
+		normalizedPath := canonicalWebPath(request.Path)
+		if len(normalizedPath) == 0 {
+			return web.RepositoryFileDiff{File: request.File, Error: webRepositoryPathRequiredErrorConstant}
+		}
+		if dependencyError != nil {
+			return web.RepositoryFileDiff{Path: normalizedPath, File: request.File, Error: dependencyError.Error()}
+		}
+
+		return loadWebRepositoryFileDiff(executionContext, gitExecutor, normalizedPath, strings.TrimSpace(request.File))
+	}
+}
+
+func loadWebRepositoryDetail(executionContext context.Context, gitExecutor execshellGitExecutor, repositoryPath string) web.RepositoryDetail {
+	detail := web.RepositoryDetail{
+		Path:       repositoryPath,
+		Name:       filepath.Base(repositoryPath),
+		Branches:   []web.BranchDescriptor{},
+		Graph:      []web.RepositoryGraphLine{},
+		DirtyFiles: []web.AuditDirtyFileEntry{},
+		Stashes:    []web.RepositoryStash{},
+	}
+
+	if _, topLevelError := runWebRepositoryGit(executionContext, gitExecutor, repositoryPath, gitRevParseSubcommandConstant, gitShowTopLevelArgumentConstant); topLevelError != nil {
+		detail.Error = topLevelError.Error()
+		return detail
+	}
+
+	branchOutput, branchError := runWebRepositoryGit(executionContext, gitExecutor, repositoryPath,
+		gitForEachRefSubcommandConstant, webRepositoryBranchFormatArgumentConstant, webRepositoryLocalReferencePrefixConstant, webRepositoryRemoteReferencePrefixConstant)
+	if branchError != nil {
+		detail.Error = branchError.Error()
+		return detail
+	}
+	detail.Branches = parseWebRepositoryBranches(branchOutput)
+	for _, branch := range detail.Branches {
+		if branch.Current {
+			detail.CurrentBranch = branch.Name
+		}
+	}
+
+	// Repositories without an origin HEAD simply have no default-branch divergence.
+	if defaultBranch, defaultBranchError := resolveRepositoryDefaultBranch(executionContext, gitExecutor, repositoryPath); defaultBranchError == nil && len(defaultBranch) > 0 {
+		detail.DefaultBranch = defaultBranch
+		defaultReference := gitOriginPrefixConstant + defaultBranch
+		for branchIndex := range detail.Branches {
+			ahead, behind, counted := countWebRepositoryDivergence(executionContext, gitExecutor, repositoryPath, defaultReference, detail.Branches[branchIndex].Name)
+			if counted {
+				detail.Branches[branchIndex].DefaultAhead = &ahead
+				detail.Branches[branchIndex].DefaultBehind = &behind
+			}
+		}
+	}
+
+	// The graph walks branches, remotes, and tags but not refs/stash, whose commits belong in the stash list.
+	// A repository without commits has no history yet; the graph stays empty.
+	if graphOutput, graphError := runWebRepositoryGit(executionContext, gitExecutor, repositoryPath,
+		webRepositoryGitLogSubcommandConstant, webRepositoryGitGraphArgumentConstant,
+		webRepositoryGitBranchesArgumentConstant, webRepositoryGitRemotesArgumentConstant, webRepositoryGitTagsArgumentConstant,
+		fmt.Sprintf("-n%d", webRepositoryGraphCommitLimitConstant), webRepositoryGitDateArgumentConstant, webRepositoryGitLogFormatArgumentConstant); graphError == nil {
+		detail.Graph = parseWebRepositoryGraph(graphOutput)
+	}
+
+	statusOutput, statusError := runWebRepositoryGit(executionContext, gitExecutor, repositoryPath, webRepositoryGitStatusSubcommandConstant, webRepositoryGitPorcelainArgumentConstant)
+	if statusError != nil {
+		detail.Error = statusError.Error()
+		return detail
+	}
+	detail.DirtyFiles = parseWebAuditDirtyFileEntries(strings.Split(statusOutput, "\n"))
+
+	stashOutput, stashError := runWebRepositoryGit(executionContext, gitExecutor, repositoryPath,
+		webRepositoryGitStashSubcommandConstant, webRepositoryGitStashListArgumentConstant, webRepositoryGitStashFormatArgumentConstant)
+	if stashError != nil {
+		detail.Error = stashError.Error()
+		return detail
+	}
+	detail.Stashes = parseWebRepositoryStashes(stashOutput)
+
+	return detail
+}
+
+// loadWebRepositoryFileDiff only diffs files the working tree reports as dirty, so the endpoint
+// cannot be used to read arbitrary files through `git diff --no-index`.
+func loadWebRepositoryFileDiff(executionContext context.Context, gitExecutor execshellGitExecutor, repositoryPath string, file string) web.RepositoryFileDiff {
+	fileDiff := web.RepositoryFileDiff{Path: repositoryPath, File: file}
+
+	statusOutput, statusError := runWebRepositoryGit(executionContext, gitExecutor, repositoryPath, webRepositoryGitStatusSubcommandConstant, webRepositoryGitPorcelainArgumentConstant)
+	if statusError != nil {
+		fileDiff.Error = statusError.Error()
+		return fileDiff
+	}
+
+	var dirtyEntry web.AuditDirtyFileEntry
+	dirtyEntryFound := false
+	for _, entry := range parseWebAuditDirtyFileEntries(strings.Split(statusOutput, "\n")) {
+		if entry.File == file {
+			dirtyEntry = entry
+			dirtyEntryFound = true
+			break
+		}
+	}
+	if !dirtyEntryFound {
+		fileDiff.Error = fmt.Sprintf(webRepositoryFileNotDirtyTemplateConstant, file)
+		return fileDiff
+	}
+	fileDiff.Status = dirtyEntry.Status
+
+	targetFile := file
+	if _, renamedFile, renamed := strings.Cut(file, webRepositoryRenameSeparatorConstant); renamed {
+		targetFile = renamedFile
+	}
+
+	arguments := []string{webRepositoryGitDiffSubcommandConstant, gitHeadReferenceConstant, webRepositoryGitPathSeparatorArgumentConstant, targetFile}
+	if dirtyEntry.Status == webRepositoryUntrackedStatusConstant {
+		arguments = []string{webRepositoryGitDiffSubcommandConstant, webRepositoryGitNoIndexArgumentConstant, webRepositoryGitPathSeparatorArgumentConstant, webRepositoryNullDevicePathConstant, targetFile}
+	}
+	diffOutput, diffError := runWebRepositoryGit(executionContext, gitExecutor, repositoryPath, arguments...)
+	if diffError != nil {
+		// `git diff --no-index` exits 1 whenever the files differ, which is always the case here.
+		var commandFailure execshell.CommandFailedError
+		if !errors.As(diffError, &commandFailure) || commandFailure.Result.ExitCode != webRepositoryDiffChangesExitCodeConstant {
+			fileDiff.Error = diffError.Error()
+			return fileDiff
+		}
+		diffOutput = commandFailure.Result.StandardOutput
+	}
+	fileDiff.Diff = diffOutput
+
+	return fileDiff
+}
+
+func runWebRepositoryGit(executionContext context.Context, gitExecutor execshellGitExecutor, repositoryPath string, arguments ...string) (string, error) {
+	result, executionError := gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        arguments,
+		WorkingDirectory: repositoryPath,
+	})
+	if executionError != nil {
+		return "", executionError
+	}
+	return result.StandardOutput, nil
+}
+
+func countWebRepositoryDivergence(executionContext context.Context, gitExecutor execshellGitExecutor, repositoryPath string, baseReference string, branchName string) (int, int, bool) {
+	countOutput, countError := runWebRepositoryGit(executionContext, gitExecutor, repositoryPath,
+		webRepositoryGitRevListSubcommandConstant, webRepositoryGitLeftRightArgumentConstant, webRepositoryGitCountArgumentConstant,
+		fmt.Sprintf(webRepositoryDivergenceRangeTemplateConstant, baseReference, branchName))
+	if countError != nil {
+		return 0, 0, false
+	}
+
+	counts := strings.Fields(countOutput)
+	if len(counts) != 2 {
+		return 0, 0, false
+	}
+	behind, behindError := strconv.Atoi(counts[0])
+	ahead, aheadError := strconv.Atoi(counts[1])
+	if behindError != nil || aheadError != nil {
+		return 0, 0, false
+	}
+	return ahead, behind, true
+}
+
+func parseWebRepositoryBranches(rawOutput string) []web.BranchDescriptor {
+	branches := make([]web.BranchDescriptor, 0)
+	for _, line := range strings.Split(rawOutput, "\n") {
+		fields := strings.Split(line, webRepositoryFieldSeparatorConstant)
+		if len(fields) != 5 {
+			continue
+		}
+
+		referenceName := strings.TrimSpace(fields[1])
+		branchName := strings.TrimSpace(fields[2])
+		if len(branchName) == 0 || strings.HasSuffix(referenceName, webRepositorySymbolicHeadSuffixConstant) {
+			continue
+		}
+
+		branch := web.BranchDescriptor{
+			Name:     branchName,
+			Current:  strings.TrimSpace(fields[0]) == "*",
+			Upstream: strings.TrimSpace(fields[3]),
+			Remote:   strings.HasPrefix(referenceName, webRepositoryRemoteReferencePrefixConstant),
+		}
+
+		track := strings.TrimSpace(fields[4])
+		switch {
+		case track == webRepositoryUpstreamGoneConstant:
+			branch.UpstreamGone = true
+		case len(branch.Upstream) > 0:
+			ahead, behind := 0, 0
+			for _, match := range webRepositoryUpstreamTrackPattern.FindAllStringSubmatch(track, -1) {
+				count, _ := strconv.Atoi(match[2])
+				if match[1] == "ahead" {
+					ahead = count
+				} else {
+					behind = count
+				}
+			}
+			branch.Ahead = &ahead
+			branch.Behind = &behind
+		}
+
+		branches = append(branches, branch)
+	}
+
+	sort.SliceStable(branches, func(leftIndex int, rightIndex int) bool {
+		leftBranch := branches[leftIndex]
+		rightBranch := branches[rightIndex]
+		if leftBranch.Remote != rightBranch.Remote {
+			return !leftBranch.Remote
+		}
+		if leftBranch.Current != rightBranch.Current {
+			return leftBranch.Current
+		}
+		return leftBranch.Name < rightBranch.Name
+	})
+
+	return branches
+}
+
+func parseWebRepositoryGraph(rawOutput string) []web.RepositoryGraphLine {
+	graphLines := make([]web.RepositoryGraphLine, 0)
+	for _, line := range strings.Split(strings.TrimRight(rawOutput, "\n"), "\n") {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		fields := strings.Split(line, webRepositoryFieldSeparatorConstant)
+		if len(fields) != 8 {
+			graphLines = append(graphLines, web.RepositoryGraphLine{Graph: strings.TrimRight(line, " ")})
+			continue
+		}
+
+		commit := &web.RepositoryCommit{
+			Hash:      fields[1],
+			ShortHash: fields[2],
+			Parents:   strings.Fields(fields[3]),
+			Author:    fields[4],
+			Date:      fields[5],
+			Subject:   fields[7],
+		}
+		for _, reference := range strings.Split(fields[6], ",") {
+			trimmedReference := strings.TrimSpace(reference)
+			if len(trimmedReference) > 0 {
+				commit.Refs = append(commit.Refs, trimmedReference)
+			}
+		}
+		graphLines = append(graphLines, web.RepositoryGraphLine{Graph: strings.TrimRight(fields[0], " "), Commit: commit})
+	}
+	return graphLines
+}
+
+func parseWebRepositoryStashes(rawOutput string) []web.RepositoryStash {
+	stashes := make([]web.RepositoryStash, 0)
+	for _, line := range strings.Split(rawOutput, "\n") {
+		fields := strings.Split(line, webRepositoryFieldSeparatorConstant)
+		if len(fields) != 3 {
+			continue
+		}
+		stashes = append(stashes, web.RepositoryStash{
+			Reference: strings.TrimSpace(fields[0]),
+			Date:      strings.TrimSpace(fields[1]),
+			Subject:   strings.TrimSpace(fields[2]),
+		})
+	}
+	return stashes
+}
@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"sync"
+
+	syncflowcmd "github.com/tyemirov/gix/internal/branches/syncflow"
+	"github.com/tyemirov/gix/internal/githubcli"
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/web"
+	"github.com/tyemirov/gix/internal/workflow"
+)
+
+const (
+	webSyncRecoverCommandTemplateConstant   = applicationNameConstant + " sync recover --roots %s"
+	webSyncEventLineTemplateConstant        = "%s %s\n"
+	webSyncHandoffReasonDetailConstant      = "reason"
+	webSyncHandoffTargetDetailConstant      = "target_branch"
+	webSyncHandoffRecordDetailConstant      = "handoff_record"
+	webSyncMergeHandoffInspectStepConstant  = "inspect git status in %s; gix did not push the merge"
+	webSyncMergeHandoffResolveStepConstant  = "resolve the conflicted paths and commit the merge, or abort it with git merge --abort"
+	webSyncMergeHandoffRerunStepConstant    = "rerun the sync once the worktree is clean"
+	webSyncHandoffRecordUnreadableConstant  = "read the handoff record with %s: %s"
+	webSyncRecoverCommandShellCharsConstant = " \t\n'\"\\$`;&|<>()*?[]{}~#!"
+	webSyncShellSingleQuoteConstant         = "'"
+	webSyncShellEscapedSingleQuoteConstant  = `'\''`
+)
+
+// newWebSyncPreviewer resolves a strict sync preview with the same sync configuration the sync command uses.
+func (application *Application) newWebSyncPreviewer() web.SyncPreviewer {
+	gitExecutor, repositoryManager, dependencyError := application.webGitDependencies()
+
+	return func(executionContext context.Context, request web.SyncPreviewRequest) web.SyncPreview {
+		normalizedPath := canonicalWebPath(request.Path)
+		if len(normalizedPath) == 0 {
+			return web.SyncPreview{Error: webRepositoryPathRequiredErrorConstant}
+		}
+		if dependencyError != nil {
+			return web.SyncPreview{Path: normalizedPath, Error: dependencyError.Error()}
+		}
+
+		configuration := application.branchSyncConfiguration()
+		syncRequest, requestError := webStrictSyncRequest(configuration, normalizedPath, request.Branch, request.SyncStrategy)
+		if requestError != nil {
+			return web.SyncPreview{Path: normalizedPath, Error: requestError.Error()}
+		}
+
+		var pullRequests syncflowcmd.StrictSyncPullRequestLister
+		if githubClient, clientError := githubcli.NewClient(gitExecutor); clientError == nil {
+			pullRequests = githubClient
+		}
+		preview, previewError := syncflowcmd.PreviewStrictSync(executionContext, gitExecutor, repositoryManager, pullRequests, configuration, syncRequest)
+		if previewError != nil {
+			return web.SyncPreview{Path: normalizedPath, Error: previewError.Error()}
+		}
+		return mapWebSyncPreview(normalizedPath, preview)
+	}
+}
+
+// executeWebStrictSync runs one strict_sync change exactly as gix sync would, reporting every sync event to recorder.
+func (application *Application) executeWebStrictSync(
+	executionContext context.Context,
+	repositoryPath string,
+	change web.AuditQueuedChange,
+	recorder *webSyncEventRecorder,
+	outputWriter io.Writer,
+	errorWriter io.Writer,
+) (workflow.ExecutionOutcome, error) {
+	configuration := application.branchSyncConfiguration()
+	syncRequest, requestError := webStrictSyncRequest(configuration, repositoryPath, change.Branch, change.SyncStrategy)
+	if requestError != nil {
+		return workflow.ExecutionOutcome{}, requestError
+	}
+	taskDefinition, definitionError := syncflowcmd.StrictSyncTaskDefinition(configuration, syncRequest)
+	if definitionError != nil {
+		return workflow.ExecutionOutcome{}, definitionError
+	}
+
+	dependencies, dependencyError := application.webTaskRunnerDependencies(outputWriter, errorWriter)
+	if dependencyError != nil {
+		return workflow.ExecutionOutcome{}, dependencyError
+	}
+	dependencies.Workflow.SuppressOperationFailureOutput = true
+	dependencies.Workflow.ReporterOptions = append(dependencies.Workflow.ReporterOptions, shared.WithEventFormatter(recorder))
+	recorder.gitExecutor = dependencies.GitExecutor
+	if dependencies.Workflow.GitHubClient != nil {
+		recorder.pullRequests = dependencies.Workflow.GitHubClient
+	}
+
+	return executeWebAuditTasks(executionContext, dependencies.Workflow, repositoryPath, []workflow.TaskDefinition{taskDefinition})
+}
+
+// webStrictSyncRequest applies a web sync strategy over the configured sync defaults; an empty strategy keeps them.
+func webStrictSyncRequest(configuration syncflowcmd.CommandConfiguration, repositoryPath string, branchName string, syncStrategy string) (syncflowcmd.StrictSyncRequest, error) {
+	request := syncflowcmd.StrictSyncRequest{
+		RepositoryPath: repositoryPath,
+		BranchName:     strings.TrimSpace(branchName),
+		StashChanges:   configuration.StashChanges,
+		CommitChanges:  configuration.CommitChanges,
+		RequireClean:   configuration.RequireClean,
+	}
+
+	switch strings.TrimSpace(syncStrategy) {
+	case "":
+	case web.AuditChangeSyncStrategyRequireClean:
+		request.StashChanges, request.CommitChanges, request.RequireClean = false, false, true
+	case web.AuditChangeSyncStrategyStashChanges:
+		request.StashChanges, request.CommitChanges, request.RequireClean = true, false, false
+	case web.AuditChangeSyncStrategyCommitChanges:
+		request.StashChanges, request.CommitChanges, request.RequireClean = false, true, false
+	default:
+		return syncflowcmd.StrictSyncRequest{}, fmt.Errorf(webAuditChangeSyncStrategyTemplateConstant, syncStrategy)
+	}
+	return request, nil
+}
+
+func mapWebSyncPreview(repositoryPath string, preview syncflowcmd.StrictSyncPreview) web.SyncPreview {
+	mapped := web.SyncPreview{
+		Path:             repositoryPath,
+		CurrentBranch:    preview.CurrentBranch,
+		TargetBranch:     preview.TargetBranch,
+		TargetSource:     preview.TargetSource,
+		PushRemote:       preview.PushRemote,
+		BaseRemote:       preview.BaseRemote,
+		DefaultBranch:    preview.DefaultBranch,
+		ReviewBase:       preview.ReviewBase,
+		ReviewBaseSource: preview.ReviewBaseSource,
+		GeneratedBranch:  preview.GeneratedBranch,
+		DirtyAction:      preview.DirtyAction,
+		Blocker:          preview.Blocker,
+	}
+	for _, cluster := range preview.Clusters {
+		mapped.Clusters = append(mapped.Clusters, web.SyncCluster{
+			Root:           cluster.Root,
+			TrackedPaths:   cluster.TrackedPaths,
+			UntrackedPaths: cluster.UntrackedPaths,
+		})
+	}
+	if preview.Handoff != nil {
+		handoff := mapWebSyncSwitchHandoff(repositoryPath, *preview.Handoff)
+		mapped.Handoff = &handoff
+	}
+	return mapped
+}
+
+func mapWebSyncSwitchHandoff(repositoryPath string, handoff syncflowcmd.StrictSyncHandoff) web.SyncHandoff {
+	return web.SyncHandoff{
+		Code:           shared.EventCodeSyncSwitchHandoff,
+		Kind:           handoff.Kind,
+		Reason:         handoff.Reason,
+		TargetBranch:   handoff.TargetBranch,
+		RecordPath:     handoff.RecordPath,
+		RecoverySteps:  handoff.RecoverySteps,
+		RecoverCommand: webSyncRecoverCommand(repositoryPath),
+	}
+}
+
+func webSyncRecoverCommand(repositoryPath string) string {
+	if strings.ContainsAny(repositoryPath, webSyncRecoverCommandShellCharsConstant) {
+		return fmt.Sprintf(webSyncRecoverCommandTemplateConstant, webSyncShellQuote(repositoryPath))
+	}
+	return fmt.Sprintf(webSyncRecoverCommandTemplateConstant, repositoryPath)
+}
+
+// webSyncShellQuote single-quotes value for POSIX shells, which expand nothing inside single quotes.
+func webSyncShellQuote(value string) string {
+	escaped := strings.ReplaceAll(value, webSyncShellSingleQuoteConstant, webSyncShellEscapedSingleQuoteConstant)
+	return webSyncShellSingleQuoteConstant + escaped + webSyncShellSingleQuoteConstant
+}
+
+// webSyncEventRecorder replaces the reporter's event formatting for a strict_sync change: it writes each event
+// as a CODE message line, streams it to the web client, and keeps it for the change result.
+type webSyncEventRecorder struct {
+	progress     web.AuditChangeProgress
+	gitExecutor  shared.GitExecutor
+	pullRequests syncflowcmd.StrictSyncPullRequestLister
+
+	mutex  sync.Mutex
+	events []web.SyncEvent
+}
+
+func newWebSyncEventRecorder(progress web.AuditChangeProgress) *webSyncEventRecorder {
+	return &webSyncEventRecorder{progress: progress}
+}
+
+func (recorder *webSyncEventRecorder) HandleEvent(event shared.Event, writer io.Writer) {
+	syncEvent := web.SyncEvent{
+		Level:   string(event.Level),
+		Code:    event.Code,
+		Message: event.Message,
+		Details: maps.Clone(event.Details),
+	}
+
+	recorder.mutex.Lock()
+	recorder.events = append(recorder.events, syncEvent)
+	recorder.mutex.Unlock()
+
+	if writer != nil {
+		_, _ = fmt.Fprintf(writer, webSyncEventLineTemplateConstant, event.Code, event.Message)
+	}
+	recorder.progress.Event(syncEvent)
+}
+
+func (recorder *webSyncEventRecorder) recorded() []web.SyncEvent {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return append([]web.SyncEvent(nil), recorder.events...)
+}
+
+// handoff describes the last SYNC_SWITCH_HANDOFF or AI_MERGE_HANDOFF the sync reported, with the steps that
+// remain; the handoff record supplies the steps for a switch handoff, since recover reads the same record.
+func (recorder *webSyncEventRecorder) handoff(executionContext context.Context, repositoryPath string) (web.SyncHandoff, bool) {
+	events := recorder.recorded()
+	for eventIndex := len(events) - 1; eventIndex >= 0; eventIndex-- {
+		event := events[eventIndex]
+		switch event.Code {
+		case shared.EventCodeSyncSwitchHandoff:
+			handoff := web.SyncHandoff{
+				Code:           event.Code,
+				Message:        event.Message,
+				Reason:         event.Details[webSyncHandoffReasonDetailConstant],
+				TargetBranch:   event.Details[webSyncHandoffTargetDetailConstant],
+				RecordPath:     event.Details[webSyncHandoffRecordDetailConstant],
+				RecoverCommand: webSyncRecoverCommand(repositoryPath),
+			}
+			if recorder.gitExecutor == nil {
+				return handoff, true
+			}
+			record, recordError := syncflowcmd.ReadStrictSyncHandoff(executionContext, recorder.gitExecutor, recorder.pullRequests, repositoryPath)
+			if recordError != nil {
+				handoff.RecoverySteps = []string{fmt.Sprintf(webSyncHandoffRecordUnreadableConstant, handoff.RecoverCommand, recordError.Error())}
+				return handoff, true
+			}
+			if record != nil {
+				handoff.Kind = record.Kind
+				handoff.RecordPath = record.RecordPath
+				handoff.RecoverySteps = record.RecoverySteps
+			}
+			return handoff, true
+		case shared.EventCodeAIMergeHandoff:
+			return web.SyncHandoff{
+				Code:         event.Code,
+				Message:      event.Message,
+				Reason:       event.Details[webSyncHandoffReasonDetailConstant],
+				TargetBranch: event.Details[webSyncHandoffTargetDetailConstant],
+				RecoverySteps: []string{
+					fmt.Sprintf(webSyncMergeHandoffInspectStepConstant, repositoryPath),
+					webSyncMergeHandoffResolveStepConstant,
+					webSyncMergeHandoffRerunStepConstant,
+				},
+			}, true
+		}
+	}
+	return web.SyncHandoff{}, false
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	syncflowcmd "github.com/tyemirov/gix/internal/branches/syncflow"
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/web"
+)
+
+func TestWebStrictSyncRequestAppliesStrategyOverConfiguration(t *testing.T) {
+	configuration := syncflowcmd.DefaultCommandConfiguration()
+	configuration.RequireClean = true
+
+	defaultRequest, defaultError := webStrictSyncRequest(configuration, "/tmp/example", " feature/demo ", "")
+	require.NoError(t, defaultError)
+	require.Equal(t, syncflowcmd.StrictSyncRequest{RepositoryPath: "/tmp/example", BranchName: "feature/demo", RequireClean: true}, defaultRequest)
+
+	stashRequest, stashError := webStrictSyncRequest(configuration, "/tmp/example", "", web.AuditChangeSyncStrategyStashChanges)
+	require.NoError(t, stashError)
+	require.True(t, stashRequest.StashChanges)
+	require.False(t, stashRequest.RequireClean)
+
+	commitRequest, commitError := webStrictSyncRequest(configuration, "/tmp/example", "", web.AuditChangeSyncStrategyCommitChanges)
+	require.NoError(t, commitError)
+	require.True(t, commitRequest.CommitChanges)
+	require.False(t, commitRequest.StashChanges)
+
+	_, invalidError := webStrictSyncRequest(configuration, "/tmp/example", "", "force")
+	require.EqualError(t, invalidError, `unsupported sync strategy "force"`)
+}
+
+func TestWebSyncPreviewerDescribesTargetAndClusters(t *testing.T) {
+	workspacePath := t.TempDir()
+	repositoryPath := createTestRepository(t, filepath.Join(workspacePath, "example"))
+	remotePath := filepath.Join(workspacePath, "origin.git")
+	runGitCommand(t, "", "clone", "--bare", repositoryPath, remotePath)
+	runGitCommand(t, repositoryPath, "remote", "add", "origin", remotePath)
+	runGitCommand(t, repositoryPath, "fetch", "origin")
+	createTestBranch(t, repositoryPath, "feature/demo")
+	require.NoError(t, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("changed\n"), 0o644))
+
+	application := NewApplication()
+	preview := application.newWebSyncPreviewer()(context.Background(), web.SyncPreviewRequest{
+		Path:         repositoryPath,
+		SyncStrategy: web.AuditChangeSyncStrategyCommitChanges,
+	})
+	require.Empty(t, preview.Error)
+	require.Equal(t, "feature/demo", preview.TargetBranch)
+	require.Equal(t, syncflowcmd.StrictSyncTargetCurrent, preview.TargetSource)
+	require.Equal(t, "master", preview.ReviewBase)
+	require.Equal(t, syncflowcmd.StrictSyncDirtyActionCommit, preview.DirtyAction)
+	require.Len(t, preview.Clusters, 1)
+	require.Equal(t, "README.md", preview.Clusters[0].Root)
+	require.Nil(t, preview.Handoff)
+
+	invalid := application.newWebSyncPreviewer()(context.Background(), web.SyncPreviewRequest{Path: repositoryPath, SyncStrategy: "force"})
+	require.Contains(t, invalid.Error, "unsupported sync strategy")
+}
+
+func TestWebSyncEventRecorderReportsMergeHandoff(t *testing.T) {
+	recorder := newWebSyncEventRecorder(web.AuditChangeProgress{})
+	recorder.HandleEvent(shared.Event{Level: shared.EventLevelInfo, Code: "SYNC_START", Message: "syncing"}, io.Discard)
+	_, handedOff := recorder.handoff(context.Background(), "/tmp/example")
+	require.False(t, handedOff)
+
+	recorder.HandleEvent(shared.Event{
+		Level:   shared.EventLevelError,
+		Code:    shared.EventCodeAIMergeHandoff,
+		Message: "merge resolution failed and rollback failed",
+		Details: map[string]string{"target_branch": "feature/demo", "reason": "tests failed"},
+	}, io.Discard)
+
+	handoff, handedOff := recorder.handoff(context.Background(), "/tmp/example")
+	require.True(t, handedOff)
+	require.Equal(t, shared.EventCodeAIMergeHandoff, handoff.Code)
+	require.Equal(t, "feature/demo", handoff.TargetBranch)
+	require.Equal(t, "tests failed", handoff.Reason)
+	require.Len(t, handoff.RecoverySteps, 3)
+	require.Contains(t, handoff.RecoverySteps[0], "/tmp/example")
+	require.Len(t, recorder.recorded(), 2)
+}
+
+func TestWebSyncRecoverCommandQuotesShellSensitivePaths(t *testing.T) {
+	testCases := []struct {
+		name           string
+		repositoryPath string
+		expected       string
+	}{
+		{name: "plain path", repositoryPath: "/tmp/example", expected: "gix sync recover --roots /tmp/example"},
+		{name: "space", repositoryPath: "/tmp/my repo", expected: `gix sync recover --roots '/tmp/my repo'`},
+		{name: "dollar", repositoryPath: "/tmp/$HOME", expected: `gix sync recover --roots '/tmp/$HOME'`},
+		{name: "command substitution", repositoryPath: "/tmp/$(touch x)`id`", expected: "gix sync recover --roots '/tmp/$(touch x)`id`'"},
+		{name: "single quote", repositoryPath: "/tmp/it's", expected: `gix sync recover --roots '/tmp/it'\''s'`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			command := webSyncRecoverCommand(testCase.repositoryPath)
+			require.Equal(t, testCase.expected, command)
+
+			quotedPath := strings.TrimPrefix(command, "gix sync recover --roots ")
+			echoed, echoErr := exec.Command("sh", "-c", "printf %s "+quotedPath).Output()
+			require.NoError(t, echoErr)
+			require.Equal(t, testCase.repositoryPath, string(echoed))
+		})
+	}
+}
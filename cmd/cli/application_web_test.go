@@ -5,12 +5,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/http/cookiejar"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -58,11 +62,21 @@ func TestExecuteWithOptionsLaunchesWebRunnerWithDefaultPort(t *testing.T) {
 	configureApplicationWithTestConfig(t, application)
 
 	capturedAddress := ""
+	capturedSessionToken := ""
 	application.webRunner = func(executionContext context.Context, options web.ServerOptions) error {
 		capturedAddress = options.Address
+		capturedSessionToken = options.SessionToken
+		require.Nil(t, options.TLSCertificate)
 		require.NotNil(t, options.BrowseDirectories)
 		require.NotNil(t, options.InspectAudit)
+		require.NotNil(t, options.ExportAudit)
 		require.NotNil(t, options.ApplyAuditChanges)
+		require.NotNil(t, options.PreviewSync)
+		require.NotNil(t, options.LoadPullRequests)
+		require.NotNil(t, options.LoadPackages)
+		require.NotNil(t, options.Workspaces)
+		require.NotNil(t, options.LoadWorkspace)
+		require.NotNil(t, options.Actions)
 		require.NotNil(t, executionContext)
 		resolvedToken, tokenAvailable := githubauth.ResolveToken(executionContext, nil)
 		require.True(t, tokenAvailable)
@@ -80,7 +94,8 @@ func TestExecuteWithOptionsLaunchesWebRunnerWithDefaultPort(t *testing.T) {
 
 	require.NoError(t, executionError)
 	require.Equal(t, "127.0.0.1:8080", capturedAddress)
-	require.Contains(t, standardOutput.String(), "http://127.0.0.1:8080")
+	require.NotEmpty(t, capturedSessionToken)
+	require.Contains(t, standardOutput.String(), "http://127.0.0.1:8080/?token="+capturedSessionToken)
 }
 
 func TestExecuteWithOptionsLaunchesWebRunnerWithExplicitPort(t *testing.T) {
@@ -136,7 +151,35 @@ func TestExecuteWithOptionsLaunchesWebRunnerWithBindAndPortFlags(t *testing.T) {
 
 	require.NoError(t, executionError)
 	require.Equal(t, "0.0.0.0:8081", capturedAddress)
-	require.Contains(t, standardOutput.String(), "http://0.0.0.0:8081")
+	require.Contains(t, standardOutput.String(), "http://0.0.0.0:8081/?token=")
+}
+
+func TestExecuteWithOptionsLaunchesWebRunnerWithTLS(t *testing.T) {
+	application := NewApplication()
+	configureApplicationWithTestConfig(t, application)
+
+	var capturedOptions web.ServerOptions
+	application.webRunner = func(_ context.Context, options web.ServerOptions) error {
+		capturedOptions = options
+		return nil
+	}
+
+	var standardOutput bytes.Buffer
+	executionError := application.ExecuteWithOptions(ExecutionOptions{
+		Arguments:      []string{"--web", "--bind", "10.0.0.5", "--tls"},
+		Context:        context.Background(),
+		StandardOutput: &standardOutput,
+		ExitOnVersion:  false,
+	})
+
+	require.NoError(t, executionError)
+	require.NotNil(t, capturedOptions.TLSCertificate)
+	require.Contains(t, capturedOptions.TLSCertificate.Leaf.DNSNames, "localhost")
+	require.True(t, slices.ContainsFunc(capturedOptions.TLSCertificate.Leaf.IPAddresses, func(address net.IP) bool {
+		return address.Equal(net.ParseIP("10.0.0.5"))
+	}))
+	require.Contains(t, standardOutput.String(), "https://10.0.0.5:8080/?token="+capturedOptions.SessionToken)
+	require.Contains(t, standardOutput.String(), "TLS certificate SHA-256 fingerprint: "+web.CertificateFingerprint(*capturedOptions.TLSCertificate))
 }
 
 func TestParseWebAuditDirtyFileEntries(t *testing.T) {
@@ -238,6 +281,14 @@ func TestExecuteWithOptionsRejectsWebNetworkFlagsWithoutWeb(t *testing.T) {
 	})
 
 	require.EqualError(t, executionError, webNetworkFlagsRequireWebConstant)
+
+	tlsExecutionError := application.ExecuteWithOptions(ExecutionOptions{
+		Arguments:     []string{"--tls"},
+		Context:       context.Background(),
+		ExitOnVersion: false,
+	})
+
+	require.EqualError(t, tlsExecutionError, webNetworkFlagsRequireWebConstant)
 }
 
 func TestCommandCatalogMarksInactionableCommands(t *testing.T) {
@@ -494,9 +545,101 @@ func TestLoadRepositoryBranchesReturnsLocalBranches(t *testing.T) {
 	require.Equal(t, "master", branchCatalog.Branches[1].Name)
 }
 
+func TestWebRepositoryDetailLoaderDescribesBranchesHistoryAndWorktree(t *testing.T) {
+	workspacePath := t.TempDir()
+	repositoryPath := createTestRepository(t, filepath.Join(workspacePath, "example"))
+	remotePath := filepath.Join(workspacePath, "origin.git")
+	runGitCommand(t, "", "clone", "--bare", repositoryPath, remotePath)
+	runGitCommand(t, repositoryPath, "remote", "add", "origin", remotePath)
+	runGitCommand(t, repositoryPath, "fetch", "origin")
+	runGitCommand(t, repositoryPath, "remote", "set-head", "origin", "master")
+	runGitCommand(t, repositoryPath, "branch", "--set-upstream-to=origin/master", "master")
+	runGitCommand(t, repositoryPath, "commit", "--allow-empty", "-m", "local only")
+	runGitCommand(t, repositoryPath, "branch", "--no-track", "feature/demo", "origin/master")
+
+	require.NoError(t, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("stashed\n"), 0o644))
+	runGitCommand(t, repositoryPath, "stash", "push", "-m", "parked work")
+	require.NoError(t, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("changed\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repositoryPath, "notes.txt"), []byte("fresh\n"), 0o644))
+
+	application := NewApplication()
+	detail := application.newWebRepositoryDetailLoader()(context.Background(), repositoryPath)
+	require.Empty(t, detail.Error)
+	require.Equal(t, "example", detail.Name)
+	require.Equal(t, "master", detail.CurrentBranch)
+	require.Equal(t, "master", detail.DefaultBranch)
+
+	require.Len(t, detail.Branches, 3)
+	require.Equal(t, "master", detail.Branches[0].Name)
+	require.Equal(t, "origin/master", detail.Branches[0].Upstream)
+	require.Equal(t, 1, *detail.Branches[0].Ahead)
+	require.Equal(t, 0, *detail.Branches[0].Behind)
+	require.Equal(t, 1, *detail.Branches[0].DefaultAhead)
+	require.Equal(t, "feature/demo", detail.Branches[1].Name)
+	require.Nil(t, detail.Branches[1].Ahead)
+	require.Equal(t, "origin/master", detail.Branches[2].Name)
+	require.True(t, detail.Branches[2].Remote)
+	require.Equal(t, 0, *detail.Branches[2].DefaultAhead)
+
+	require.NotEmpty(t, detail.Graph)
+	require.NotNil(t, detail.Graph[0].Commit)
+	require.Equal(t, "local only", detail.Graph[0].Commit.Subject)
+	require.Contains(t, detail.Graph[0].Commit.Refs, "HEAD -> master")
+
+	require.Equal(t, []web.AuditDirtyFileEntry{{Status: "M", File: "README.md"}, {Status: "??", File: "notes.txt"}}, detail.DirtyFiles)
+	require.Len(t, detail.Stashes, 1)
+	require.Equal(t, "stash@{0}", detail.Stashes[0].Reference)
+	require.Contains(t, detail.Stashes[0].Subject, "parked work")
+
+	loadFileDiff := application.newWebRepositoryFileDiffLoader()
+	trackedDiff := loadFileDiff(context.Background(), web.RepositoryFileDiffRequest{Path: repositoryPath, File: "README.md"})
+	require.Empty(t, trackedDiff.Error)
+	require.Equal(t, "M", trackedDiff.Status)
+	require.Contains(t, trackedDiff.Diff, "-initial")
+	require.Contains(t, trackedDiff.Diff, "+changed")
+
+	untrackedDiff := loadFileDiff(context.Background(), web.RepositoryFileDiffRequest{Path: repositoryPath, File: "notes.txt"})
+	require.Empty(t, untrackedDiff.Error)
+	require.Contains(t, untrackedDiff.Diff, "+fresh")
+
+	cleanFileDiff := loadFileDiff(context.Background(), web.RepositoryFileDiffRequest{Path: repositoryPath, File: "../outside.txt"})
+	require.Equal(t, "../outside.txt has no working tree changes", cleanFileDiff.Error)
+	require.Empty(t, cleanFileDiff.Diff)
+}
+
+func newTestWebActionStore(t *testing.T) *web.ActionStore {
+	t.Helper()
+
+	actionStore, actionStoreError := web.NewActionStore(web.ActionStoreOptions{Directory: t.TempDir(), Actor: "tester"})
+	require.NoError(t, actionStoreError)
+	return actionStore
+}
+
+func newTestWebWorkspaceStore(t *testing.T) web.WorkspaceStore {
+	t.Helper()
+
+	application := NewApplication()
+	configureApplicationWithTestConfig(t, application)
+	require.NoError(t, application.InitializeForCommand(applicationNameConstant))
+	return application.webWorkspaceStore()
+}
+
+func TestWebActionStoreLivesUnderUserConfigurationDirectory(t *testing.T) {
+	homeDirectory := t.TempDir()
+	t.Setenv("HOME", homeDirectory)
+
+	actionStore, actionStoreError := NewApplication().webActionStore()
+	require.NoError(t, actionStoreError)
+	_, saveError := actionStore.SaveQueue([]web.AuditQueuedChange{{ID: "audit-change-1", Kind: web.AuditChangeKindSyncWithRemote, Path: "/tmp/alpha"}})
+	require.NoError(t, saveError)
+
+	require.FileExists(t, filepath.Join(homeDirectory, userConfigurationDirectoryNameConstant, webStateDirectoryNameConstant, "queue.json"))
+}
+
 func TestWebServerServesAuditWorkspaceAndRemovesLegacyEndpoints(t *testing.T) {
 	server, serverError := web.NewServer(web.ServerOptions{
-		Address: "127.0.0.1:8080",
+		Address:      "127.0.0.1:8080",
+		SessionToken: testSessionTokenConstant,
 		Repositories: web.RepositoryCatalog{
 			LaunchPath:           "/tmp/example",
 			ExplorerRoot:         "/tmp/example",
@@ -556,6 +699,9 @@ func TestWebServerServesAuditWorkspaceAndRemovesLegacyEndpoints(t *testing.T) {
 				},
 			}
 		},
+		ExportAudit: func(_ context.Context, request web.AuditExportRequest) web.AuditExport {
+			return web.AuditExport{Format: request.Format, FileName: "gix-audit." + request.Format, Content: strings.Join(request.Paths, "\n")}
+		},
 		ApplyAuditChanges: func(_ context.Context, request web.AuditChangeApplyRequest) web.AuditChangeApplyResponse {
 			return web.AuditChangeApplyResponse{
 				Results: []web.AuditChangeApplyResult{
@@ -569,13 +715,48 @@ func TestWebServerServesAuditWorkspaceAndRemovesLegacyEndpoints(t *testing.T) {
 				},
 			}
 		},
+		LoadRepository: func(_ context.Context, repositoryPath string) web.RepositoryDetail {
+			return web.RepositoryDetail{Path: repositoryPath, Name: "example", CurrentBranch: "feature/demo"}
+		},
+		LoadFileDiff: func(_ context.Context, request web.RepositoryFileDiffRequest) web.RepositoryFileDiff {
+			return web.RepositoryFileDiff{Path: request.Path, File: request.File}
+		},
+		LoadWorkflows: func(context.Context) web.WorkflowCatalog {
+			return web.WorkflowCatalog{Workflows: []web.WorkflowDescriptor{{ID: "preset:license", Source: web.WorkflowSourcePreset, Label: "license"}}}
+		},
+		PlanWorkflow: func(_ context.Context, request web.WorkflowRunRequest) web.WorkflowPlan {
+			return web.WorkflowPlan{WorkflowID: request.WorkflowID, RepositoryPaths: request.RepositoryPaths}
+		},
+		PreviewSync: func(_ context.Context, request web.SyncPreviewRequest) web.SyncPreview {
+			return web.SyncPreview{Path: request.Path, CurrentBranch: "feature/demo", TargetBranch: "feature/demo", ReviewBase: "master"}
+		},
+		LoadPullRequests: func(_ context.Context, request web.PullRequestDashboardRequest) web.PullRequestDashboard {
+			return web.PullRequestDashboard{Roots: request.Roots, Repositories: []web.PullRequestRepository{{
+				Path:       "/tmp/example",
+				Name:       "example",
+				Repository: "octo/example",
+				Stacks:     []web.PullRequestStack{{Base: "master", PullRequests: []web.PullRequestDescriptor{{Number: 7, HeadBranch: "feature/demo", BaseBranch: "master"}}}},
+			}}}
+		},
+		LoadPackages: func(_ context.Context, request web.PackageDashboardRequest) web.PackageDashboard {
+			return web.PackageDashboard{Roots: request.Roots, Packages: []web.PackageDescriptor{}}
+		},
+		Workspaces: newTestWebWorkspaceStore(t),
+		LoadWorkspace: func(_ context.Context, roots []string) web.RepositoryCatalog {
+			return web.RepositoryCatalog{LaunchRoots: roots}
+		},
+		Actions: newTestWebActionStore(t),
 	})
 	require.NoError(t, serverError)
 
 	httpServer := httptest.NewServer(server.Handler())
 	defer httpServer.Close()
 
-	indexResponse, indexError := http.Get(httpServer.URL)
+	cookieJar, cookieJarError := cookiejar.New(nil)
+	require.NoError(t, cookieJarError)
+	client := &http.Client{Jar: cookieJar}
+
+	indexResponse, indexError := client.Get(browserLaunchURL(httpServer))
 	require.NoError(t, indexError)
 	defer indexResponse.Body.Close()
 	require.Equal(t, http.StatusOK, indexResponse.StatusCode)
@@ -589,6 +770,14 @@ func TestWebServerServesAuditWorkspaceAndRemovesLegacyEndpoints(t *testing.T) {
 	require.Contains(t, indexDocument.String(), "Run audit")
 	require.Contains(t, indexDocument.String(), "Queued Actions")
 	require.Contains(t, indexDocument.String(), "Apply Results")
+	require.Contains(t, indexDocument.String(), "id=\"repository-detail-panel\"")
+	require.Contains(t, indexDocument.String(), "id=\"workflow-panel\"")
+	require.Contains(t, indexDocument.String(), "id=\"action-history-panel\"")
+	require.Contains(t, indexDocument.String(), "id=\"sync-panel\"")
+	require.Contains(t, indexDocument.String(), "id=\"pull-request-panel\"")
+	require.Contains(t, indexDocument.String(), "id=\"package-panel\"")
+	require.Contains(t, indexDocument.String(), "id=\"workspace-select\"")
+	require.Contains(t, indexDocument.String(), "id=\"audit-depth\"")
 	require.NotContains(t, indexDocument.String(), "Workflow Actions")
 	require.NotContains(t, indexDocument.String(), "Queue workflow action")
 	require.NotContains(t, indexDocument.String(), "id=\"command-groups\"")
@@ -596,7 +785,7 @@ func TestWebServerServesAuditWorkspaceAndRemovesLegacyEndpoints(t *testing.T) {
 	require.NotContains(t, indexDocument.String(), "id=\"run-command\"")
 
 	readEmbeddedAsset := func(assetPath string) string {
-		assetResponse, assetError := http.Get(httpServer.URL + assetPath)
+		assetResponse, assetError := client.Get(httpServer.URL + assetPath)
 		require.NoError(t, assetError)
 		defer assetResponse.Body.Close()
 		require.Equal(t, http.StatusOK, assetResponse.StatusCode)
@@ -613,10 +802,41 @@ func TestWebServerServesAuditWorkspaceAndRemovesLegacyEndpoints(t *testing.T) {
 	mainScript := readEmbeddedAsset("/assets/main.js")
 	require.Contains(t, mainScript, "from \"./repo_tree.js\"")
 	require.Contains(t, mainScript, "from \"./audit.js\"")
+	require.Contains(t, mainScript, "from \"./repository_detail.js\"")
+	require.Contains(t, mainScript, "from \"./workflows.js\"")
+	require.Contains(t, mainScript, "from \"./history.js\"")
+	require.Contains(t, mainScript, "from \"./sync.js\"")
+	require.Contains(t, mainScript, "from \"./pull_requests.js\"")
+	require.Contains(t, mainScript, "from \"./packages.js\"")
+	require.Contains(t, mainScript, "from \"./workspaces.js\"")
+
+	workspaceScript := readEmbeddedAsset("/assets/workspaces.js")
+	require.Contains(t, workspaceScript, "workspacesEndpoint")
+
+	pullRequestScript := readEmbeddedAsset("/assets/pull_requests.js")
+	require.Contains(t, pullRequestScript, "pullRequestsEndpoint")
+
+	packageScript := readEmbeddedAsset("/assets/packages.js")
+	require.Contains(t, packageScript, "packagesEndpoint")
+
+	syncScript := readEmbeddedAsset("/assets/sync.js")
+	require.Contains(t, syncScript, "syncPreviewEndpoint")
+	require.Contains(t, syncScript, "auditApplyStreamEndpoint")
+
+	historyScript := readEmbeddedAsset("/assets/history.js")
+	require.Contains(t, historyScript, "auditHistoryEndpoint")
+
+	workflowScript := readEmbeddedAsset("/assets/workflows.js")
+	require.Contains(t, workflowScript, "workflowPlanEndpoint")
+
+	repositoryDetailScript := readEmbeddedAsset("/assets/repository_detail.js")
+	require.Contains(t, repositoryDetailScript, "repositoryDetailEndpoint")
 
 	auditScript := readEmbeddedAsset("/assets/audit.js")
 	require.Contains(t, auditScript, "from \"./shared.js\"")
 	require.Contains(t, auditScript, "from \"./repo_tree.js\"")
+	require.Contains(t, auditScript, "auditQueueEndpoint")
+	require.Contains(t, auditScript, "auditExportEndpoint")
 
 	repositoryTreeScript := readEmbeddedAsset("/assets/repo_tree.js")
 	require.Contains(t, repositoryTreeScript, "from \"https://cdn.jsdelivr.net/npm/wunderbaum@0/+esm\"")
@@ -625,7 +845,7 @@ func TestWebServerServesAuditWorkspaceAndRemovesLegacyEndpoints(t *testing.T) {
 	sharedScript := readEmbeddedAsset("/assets/shared.js")
 	require.Contains(t, sharedScript, "export const state = {")
 
-	repositoriesResponse, repositoriesError := http.Get(httpServer.URL + "/api/repos")
+	repositoriesResponse, repositoriesError := client.Get(httpServer.URL + "/api/repos")
 	require.NoError(t, repositoriesError)
 	defer repositoriesResponse.Body.Close()
 	require.Equal(t, http.StatusOK, repositoriesResponse.StatusCode)
@@ -638,7 +858,58 @@ func TestWebServerServesAuditWorkspaceAndRemovesLegacyEndpoints(t *testing.T) {
 	require.Equal(t, "repo-001", repositories.Repositories[0].ID)
 	require.Equal(t, "feature/demo", repositories.Repositories[0].CurrentBranch)
 
-	foldersResponse, foldersError := http.Get(httpServer.URL + "/api/folders?path=" + url.QueryEscape("/tmp"))
+	repositoryDetailResponse, repositoryDetailError := client.Get(httpServer.URL + "/api/repository?path=" + url.QueryEscape("/tmp/example"))
+	require.NoError(t, repositoryDetailError)
+	defer repositoryDetailResponse.Body.Close()
+	require.Equal(t, http.StatusOK, repositoryDetailResponse.StatusCode)
+
+	var repositoryDetail web.RepositoryDetail
+	require.NoError(t, json.NewDecoder(repositoryDetailResponse.Body).Decode(&repositoryDetail))
+	require.Equal(t, "/tmp/example", repositoryDetail.Path)
+	require.Equal(t, "feature/demo", repositoryDetail.CurrentBranch)
+
+	workflowsResponse, workflowsError := client.Get(httpServer.URL + "/api/workflows")
+	require.NoError(t, workflowsError)
+	defer workflowsResponse.Body.Close()
+	require.Equal(t, http.StatusOK, workflowsResponse.StatusCode)
+
+	var workflowCatalog web.WorkflowCatalog
+	require.NoError(t, json.NewDecoder(workflowsResponse.Body).Decode(&workflowCatalog))
+	require.Len(t, workflowCatalog.Workflows, 1)
+	require.Equal(t, "preset:license", workflowCatalog.Workflows[0].ID)
+
+	workflowPlanBody := strings.NewReader(`{"workflow_id":"preset:license","repository_paths":["/tmp/example"]}`)
+	workflowPlanResponse, workflowPlanError := client.Post(httpServer.URL+"/api/workflows/plan", "application/json", workflowPlanBody)
+	require.NoError(t, workflowPlanError)
+	defer workflowPlanResponse.Body.Close()
+	require.Equal(t, http.StatusOK, workflowPlanResponse.StatusCode)
+
+	var workflowPlan web.WorkflowPlan
+	require.NoError(t, json.NewDecoder(workflowPlanResponse.Body).Decode(&workflowPlan))
+	require.Equal(t, []string{"/tmp/example"}, workflowPlan.RepositoryPaths)
+
+	syncPreviewBody := strings.NewReader(`{"path":"/tmp/example","sync_strategy":"stash_changes"}`)
+	syncPreviewResponse, syncPreviewError := client.Post(httpServer.URL+"/api/sync/preview", "application/json", syncPreviewBody)
+	require.NoError(t, syncPreviewError)
+	defer syncPreviewResponse.Body.Close()
+	require.Equal(t, http.StatusOK, syncPreviewResponse.StatusCode)
+
+	var syncPreview web.SyncPreview
+	require.NoError(t, json.NewDecoder(syncPreviewResponse.Body).Decode(&syncPreview))
+	require.Equal(t, "master", syncPreview.ReviewBase)
+
+	pullRequestsBody := strings.NewReader(`{"roots":["/tmp/example"]}`)
+	pullRequestsResponse, pullRequestsError := client.Post(httpServer.URL+"/api/pull-requests", "application/json", pullRequestsBody)
+	require.NoError(t, pullRequestsError)
+	defer pullRequestsResponse.Body.Close()
+	require.Equal(t, http.StatusOK, pullRequestsResponse.StatusCode)
+
+	var pullRequestDashboard web.PullRequestDashboard
+	require.NoError(t, json.NewDecoder(pullRequestsResponse.Body).Decode(&pullRequestDashboard))
+	require.Len(t, pullRequestDashboard.Repositories, 1)
+	require.Equal(t, 7, pullRequestDashboard.Repositories[0].Stacks[0].PullRequests[0].Number)
+
+	foldersResponse, foldersError := client.Get(httpServer.URL + "/api/folders?path=" + url.QueryEscape("/tmp"))
 	require.NoError(t, foldersError)
 	defer foldersResponse.Body.Close()
 	require.Equal(t, http.StatusOK, foldersResponse.StatusCode)
@@ -650,7 +921,7 @@ func TestWebServerServesAuditWorkspaceAndRemovesLegacyEndpoints(t *testing.T) {
 	require.Equal(t, "/tmp/example", folders.Folders[0].Path)
 
 	auditBody := strings.NewReader(`{"roots":["/tmp/custom"],"include_all":true}`)
-	auditResponse, auditError := http.Post(httpServer.URL+"/api/audit/inspect", "application/json", auditBody)
+	auditResponse, auditError := client.Post(httpServer.URL+"/api/audit/inspect", "application/json", auditBody)
 	require.NoError(t, auditError)
 	defer auditResponse.Body.Close()
 	require.Equal(t, http.StatusOK, auditResponse.StatusCode)
@@ -662,7 +933,7 @@ func TestWebServerServesAuditWorkspaceAndRemovesLegacyEndpoints(t *testing.T) {
 	require.Equal(t, "/tmp/custom/example", auditInspection.Rows[0].Path)
 
 	applyBody := strings.NewReader(`{"changes":[{"id":"chg-001","kind":"rename_folder","path":"/tmp/custom/example","require_clean":true}]}`)
-	applyResponse, applyError := http.Post(httpServer.URL+"/api/audit/apply", "application/json", applyBody)
+	applyResponse, applyError := client.Post(httpServer.URL+"/api/audit/apply", "application/json", applyBody)
 	require.NoError(t, applyError)
 	defer applyResponse.Body.Close()
 	require.Equal(t, http.StatusOK, applyResponse.StatusCode)
@@ -681,7 +952,7 @@ func TestWebServerServesAuditWorkspaceAndRemovesLegacyEndpoints(t *testing.T) {
 		"/api/runs",
 	}
 	for _, endpoint := range legacyEndpoints {
-		response, requestError := http.Get(httpServer.URL + endpoint)
+		response, requestError := client.Get(httpServer.URL + endpoint)
 		require.NoError(t, requestError)
 		response.Body.Close()
 		require.Equal(t, http.StatusNotFound, response.StatusCode, endpoint)
@@ -707,6 +978,31 @@ func TestWebAuditChangeExecutorRejectsRelativePaths(t *testing.T) {
 	require.Contains(t, response.Results[0].Error, "absolute")
 }
 
+func TestWebAuditChangeExecutorReportsCanceledChanges(t *testing.T) {
+	folderPath := filepath.Join(t.TempDir(), "doomed")
+	require.NoError(t, os.MkdirAll(folderPath, 0o755))
+
+	canceledContext, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	application := NewApplication()
+	response := application.newWebAuditChangeExecutor()(canceledContext, web.AuditChangeApplyRequest{
+		Changes: []web.AuditQueuedChange{
+			{
+				ID:            "chg-001",
+				Kind:          web.AuditChangeKindDeleteFolder,
+				Path:          folderPath,
+				ConfirmDelete: true,
+			},
+		},
+	})
+
+	require.Len(t, response.Results, 1)
+	require.Equal(t, "canceled", response.Results[0].Status)
+	require.Equal(t, webAuditChangeCanceledMessageConstant, response.Results[0].Error)
+	require.DirExists(t, folderPath)
+}
+
 func TestWebAuditChangeExecutorCommitChangesCommitsDirtyWorktree(t *testing.T) {
 	repositoryPath := createTestRepository(t, filepath.Join(t.TempDir(), "workspace", "example"))
 	runGitCommand(t, repositoryPath, "config", "user.name", "gix-test")
@@ -988,3 +1284,94 @@ func canonicalPath(testingInstance *testing.T, path string) string {
 	require.NoError(testingInstance, resolveError)
 	return resolvedPath
 }
+
+func TestWebAuditExporterRendersRequestedRowsAndColumns(t *testing.T) {
+	workspacePath := t.TempDir()
+	alphaPath := createTestRepository(t, filepath.Join(workspacePath, "alpha"))
+	betaPath := createTestRepository(t, filepath.Join(workspacePath, "beta"))
+	application := NewApplication()
+	inspection := application.newWebAuditInspector()(context.Background(), web.AuditInspectionRequest{Roots: []string{workspacePath}, Depth: "minimal"})
+	require.Empty(t, inspection.Error)
+	require.NotEmpty(t, inspection.SnapshotID)
+	exporter := application.newWebAuditExporter()
+
+	// The export writes the rendered snapshot, so a remote added after the audit does not appear in it.
+	runGitCommand(t, alphaPath, "remote", "add", "origin", "https://github.com/example/alpha.git")
+
+	testCases := []struct {
+		name            string
+		request         web.AuditExportRequest
+		expectedExport  web.AuditExport
+		expectedContent []string
+	}{
+		{
+			name: "csv_in_table_order",
+			request: web.AuditExportRequest{
+				SnapshotID: inspection.SnapshotID,
+				Format:     "csv",
+				Paths:      []string{betaPath, alphaPath},
+				Columns:    []string{"origin_remote_status", "folder_name"},
+			},
+			expectedExport: web.AuditExport{
+				Format:      "csv",
+				FileName:    webAuditExportCSVFileNameConstant,
+				ContentType: webAuditExportCSVContentTypeConstant,
+				Content:     "origin_remote_status,folder_name\nmissing,beta\nmissing,alpha\n",
+			},
+		},
+		{
+			name:            "json_keeps_full_schema",
+			request:         web.AuditExportRequest{SnapshotID: inspection.SnapshotID, Format: "json", Paths: []string{alphaPath}, Columns: []string{"folder_name"}},
+			expectedContent: []string{`"folder_name": "alpha"`, `"origin_remote_status": "missing"`},
+		},
+		{
+			name:           "unsupported_format",
+			request:        web.AuditExportRequest{SnapshotID: inspection.SnapshotID, Format: "ndjson", Paths: []string{alphaPath}},
+			expectedExport: web.AuditExport{Format: "ndjson", Error: `unsupported audit export format "ndjson"; expected csv, html, or json`},
+		},
+		{
+			name:           "missing_path",
+			request:        web.AuditExportRequest{SnapshotID: inspection.SnapshotID, Format: "csv", Paths: []string{filepath.Join(workspacePath, "gamma")}},
+			expectedExport: web.AuditExport{Format: "csv", Error: fmt.Sprintf(webAuditExportPathTemplateConstant, filepath.Join(workspacePath, "gamma"))},
+		},
+		{
+			name:           "unknown_snapshot",
+			request:        web.AuditExportRequest{SnapshotID: "expired", Format: "csv", Paths: []string{alphaPath}},
+			expectedExport: web.AuditExport{Format: "csv", Error: webAuditExportSnapshotMissingConstant},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			export := exporter(context.Background(), testCase.request)
+			if len(testCase.expectedContent) > 0 {
+				require.Empty(t, export.Error)
+				for _, expectedFragment := range testCase.expectedContent {
+					require.Contains(t, export.Content, expectedFragment)
+				}
+				return
+			}
+			require.Equal(t, testCase.expectedExport, export)
+		})
+	}
+}
+
+func TestWebAuditSnapshotStoreEvictsOldestSnapshot(t *testing.T) {
+	var store webAuditSnapshotStore
+	firstID, firstError := store.record(webAuditSnapshot{gitHubHealth: true})
+	require.NoError(t, firstError)
+	for snapshotIndex := 0; snapshotIndex < webAuditSnapshotLimitConstant-1; snapshotIndex++ {
+		_, recordError := store.record(webAuditSnapshot{})
+		require.NoError(t, recordError)
+	}
+	firstSnapshot, firstFound := store.lookup(firstID)
+	require.True(t, firstFound)
+	require.True(t, firstSnapshot.gitHubHealth)
+
+	lastID, lastError := store.record(webAuditSnapshot{})
+	require.NoError(t, lastError)
+	_, firstFound = store.lookup(firstID)
+	require.False(t, firstFound)
+	_, lastFound := store.lookup(lastID)
+	require.True(t, lastFound)
+}
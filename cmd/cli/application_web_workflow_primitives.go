@@ -90,6 +90,7 @@ const (
 	webWorkflowPrimitiveProtocolHTTPSConstant = string(shared.RemoteProtocolHTTPS)
 	webWorkflowPrimitiveAuditDepthFullConst   = string(audit.InspectionDepthFull)
 	webWorkflowPrimitiveAuditDepthMinConst    = string(audit.InspectionDepthMinimal)
+	webWorkflowPrimitiveAuditDepthGitHubConst = string(audit.InspectionDepthGitHub)
 )
 
 type webWorkflowPrimitiveDefinition struct {
@@ -523,12 +524,13 @@ func (application *Application) webWorkflowPrimitiveDefinitions() []webWorkflowP
 					selectWorkflowPrimitiveParameter(
 						webWorkflowPrimitiveParameterDepthConstant,
 						"Inspection depth",
-						"Choose between full and minimal audit inspection.",
+						"Choose between full, minimal, and GitHub health audit inspection.",
 						true,
 						webWorkflowPrimitiveAuditDepthFullConst,
 						[]web.WorkflowPrimitiveParameterOption{
 							{Value: webWorkflowPrimitiveAuditDepthFullConst, Label: "Full"},
 							{Value: webWorkflowPrimitiveAuditDepthMinConst, Label: "Minimal"},
+							{Value: webWorkflowPrimitiveAuditDepthGitHubConst, Label: "Full + GitHub health"},
 						},
 					),
 					textWorkflowPrimitiveParameter(
@@ -557,8 +559,9 @@ func (application *Application) webWorkflowPrimitiveDefinitions() []webWorkflowP
 					true,
 					webWorkflowPrimitiveAuditDepthFullConst,
 					map[string]struct{}{
-						webWorkflowPrimitiveAuditDepthFullConst: {},
-						webWorkflowPrimitiveAuditDepthMinConst:  {},
+						webWorkflowPrimitiveAuditDepthFullConst:   {},
+						webWorkflowPrimitiveAuditDepthMinConst:    {},
+						webWorkflowPrimitiveAuditDepthGitHubConst: {},
 					},
 				)
 				if depthError != nil {
@@ -0,0 +1,379 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	workflowcmd "github.com/tyemirov/gix/cmd/cli/workflow"
+	"github.com/tyemirov/gix/internal/web"
+	"github.com/tyemirov/gix/internal/workflow"
+	"github.com/tyemirov/gix/pkg/taskrunner"
+)
+
+const (
+	webWorkflowPrimitiveIDPrefixConstant = "primitive:"
+	webWorkflowPresetIDPrefixConstant    = "preset:"
+	webWorkflowFileIDPrefixConstant      = "file:"
+
+	webWorkflowUnknownTemplateConstant           = "unknown workflow %q"
+	webWorkflowFileUnavailableTemplateConstant   = "workflow file %q is not in the workflows directory"
+	webWorkflowRepositoriesRequiredConstant      = "select at least one repository to run the workflow against"
+	webWorkflowRepositoryPathRequiredConstant    = "workflow repository path is required"
+	webWorkflowRepositoryPathAbsoluteRequiredMsg = "workflow repository path must be absolute"
+	webWorkflowDirectoryReadErrorTemplate        = "unable to read workflows directory %q: %v"
+	webWorkflowParameterTypeTemplateConstant     = "workflow variable %q must be a string, number, or boolean"
+	webWorkflowPresetParameterDescription        = "Workflow variable read by this preset."
+	webWorkflowFileParameterDescription          = "Workflow variable read by this workflow file."
+	webWorkflowFileDescriptionTemplateConstant   = "Steps: %s"
+	webWorkflowTasksApplyCommandConstant         = "tasks apply"
+	webWorkflowChangeMessageTemplateConstant     = "%s: %s"
+)
+
+var webWorkflowFileExtensions = map[string]struct{}{
+	".yaml": {},
+	".yml":  {},
+	".json": {},
+}
+
+type webWorkflowSelection struct {
+	descriptor    web.WorkflowDescriptor
+	configuration workflow.Configuration
+	variables     map[string]string
+}
+
+func (application *Application) newWebWorkflowCatalogLoader() web.WorkflowCatalogLoader {
+	return func(context.Context) web.WorkflowCatalog {
+		return application.webWorkflowCatalog()
+	}
+}
+
+func (application *Application) newWebWorkflowPlanner() web.WorkflowPlanner {
+	return func(_ context.Context, request web.WorkflowRunRequest) web.WorkflowPlan {
+		plan := web.WorkflowPlan{WorkflowID: request.WorkflowID}
+		selection, repositoryPaths, workflowPlan, planError := application.planWebWorkflow(request)
+		plan.Label = selection.descriptor.Label
+		plan.RepositoryPaths = repositoryPaths
+		if planError != nil {
+			plan.Error = planError.Error()
+			return plan
+		}
+
+		plan.Variables = workflowPlan.Variables
+		steps, describeError := describeWebWorkflowPlanSteps(workflowPlan)
+		if describeError != nil {
+			plan.Error = describeError.Error()
+			return plan
+		}
+		plan.Steps = steps
+		return plan
+	}
+}
+
+// executeWebWorkflowChange runs a workflow change against its one repository. The browser queues one change per
+// planned repository, so each run streams, cancels, and records its history entry on its own.
+func (application *Application) executeWebWorkflowChange(executionContext context.Context, repositoryPath string, change web.AuditQueuedChange, outputWriter io.Writer, errorWriter io.Writer) (workflow.ExecutionOutcome, error) {
+	_, repositoryPaths, workflowPlan, planError := application.planWebWorkflow(web.WorkflowRunRequest{
+		WorkflowID:      change.WorkflowID,
+		Parameters:      change.Parameters,
+		RepositoryPaths: []string{repositoryPath},
+	})
+	if planError != nil {
+		return workflow.ExecutionOutcome{}, planError
+	}
+
+	dependencies, dependencyError := application.webTaskRunnerDependencies(outputWriter, errorWriter)
+	if dependencyError != nil {
+		return workflow.ExecutionOutcome{}, dependencyError
+	}
+
+	workflowWorkers := application.workflowCommandConfiguration().Sanitize().WorkflowWorkers
+	executor := workflowcmd.ResolveOperationExecutor(nil, workflowPlan.Nodes, dependencies.Workflow)
+	executionOutcome, executionError := executor.Execute(executionContext, repositoryPaths, workflowPlan.RuntimeOptions(true, workflowWorkers))
+	if summary := taskrunner.RenderSummaryLine(executionOutcome.ReporterSummaryData, repositoryPaths); len(strings.TrimSpace(summary)) > 0 {
+		fmt.Fprintln(errorWriter, summary)
+	}
+	return executionOutcome, executionError
+}
+
+func (application *Application) webWorkflowCatalog() web.WorkflowCatalog {
+	configuration := application.workflowCommandConfiguration().Sanitize()
+	catalog := web.WorkflowCatalog{Directory: configuration.WorkflowsDirectory}
+
+	for _, definition := range application.webWorkflowPrimitiveDefinitions() {
+		catalog.Workflows = append(catalog.Workflows, web.WorkflowDescriptor{
+			ID:          webWorkflowPrimitiveIDPrefixConstant + definition.descriptor.ID,
+			Source:      web.WorkflowSourcePrimitive,
+			Label:       definition.descriptor.Label,
+			Description: definition.descriptor.Description,
+			Parameters:  definition.descriptor.Parameters,
+		})
+	}
+
+	presetCatalog := workflowcmd.NewEmbeddedPresetCatalog()
+	for _, preset := range presetCatalog.List() {
+		descriptor := web.WorkflowDescriptor{
+			ID:          webWorkflowPresetIDPrefixConstant + preset.Name,
+			Source:      web.WorkflowSourcePreset,
+			Label:       preset.Name,
+			Description: preset.Description,
+		}
+		presetConfiguration, _, loadError := presetCatalog.Load(preset.Name)
+		if loadError != nil {
+			descriptor.Error = loadError.Error()
+		} else {
+			descriptor.Parameters = webWorkflowVariableParameters(presetConfiguration, configuration.Variables, webWorkflowPresetParameterDescription)
+		}
+		catalog.Workflows = append(catalog.Workflows, descriptor)
+	}
+
+	fileNames, directoryError := webWorkflowFileNames(configuration.WorkflowsDirectory)
+	if directoryError != nil {
+		catalog.Error = directoryError.Error()
+		return catalog
+	}
+	for _, fileName := range fileNames {
+		descriptor := web.WorkflowDescriptor{
+			ID:     webWorkflowFileIDPrefixConstant + fileName,
+			Source: web.WorkflowSourceFile,
+			Label:  fileName,
+			Path:   filepath.Join(configuration.WorkflowsDirectory, fileName),
+		}
+		fileConfiguration, loadError := workflow.LoadConfiguration(descriptor.Path)
+		if loadError != nil {
+			descriptor.Error = loadError.Error()
+		} else {
+			descriptor.Description = fmt.Sprintf(webWorkflowFileDescriptionTemplateConstant, strings.Join(webWorkflowStepLabels(fileConfiguration), ", "))
+			descriptor.Parameters = webWorkflowVariableParameters(fileConfiguration, configuration.Variables, webWorkflowFileParameterDescription)
+		}
+		catalog.Workflows = append(catalog.Workflows, descriptor)
+	}
+
+	return catalog
+}
+
+func (application *Application) planWebWorkflow(request web.WorkflowRunRequest) (webWorkflowSelection, []string, workflowcmd.Plan, error) {
+	selection, selectionError := application.selectWebWorkflow(request)
+	if selectionError != nil {
+		return selection, nil, workflowcmd.Plan{}, selectionError
+	}
+
+	repositoryPaths, pathsError := normalizeWebWorkflowRepositoryPaths(request.RepositoryPaths)
+	if pathsError != nil {
+		return selection, repositoryPaths, workflowcmd.Plan{}, pathsError
+	}
+
+	configuration := application.workflowCommandConfiguration().Sanitize()
+	workflowPlan, planError := workflowcmd.NewPlan(selection.configuration, selection.variables, workflowcmd.PlanOptions{
+		RequireClean:       configuration.RequireClean,
+		ConnectionProfiles: configuration.ConnectionProfiles,
+	})
+	return selection, repositoryPaths, workflowPlan, planError
+}
+
+func (application *Application) selectWebWorkflow(request web.WorkflowRunRequest) (webWorkflowSelection, error) {
+	workflowID := strings.TrimSpace(request.WorkflowID)
+	configuration := application.workflowCommandConfiguration().Sanitize()
+
+	switch {
+	case strings.HasPrefix(workflowID, webWorkflowPrimitiveIDPrefixConstant):
+		primitiveID := strings.TrimPrefix(workflowID, webWorkflowPrimitiveIDPrefixConstant)
+		for _, definition := range application.webWorkflowPrimitiveDefinitions() {
+			if definition.descriptor.ID != primitiveID {
+				continue
+			}
+			options, optionsError := definition.buildOptions(request.Parameters)
+			selection := webWorkflowSelection{descriptor: web.WorkflowDescriptor{ID: workflowID, Source: web.WorkflowSourcePrimitive, Label: definition.descriptor.Label}}
+			if optionsError != nil {
+				return selection, optionsError
+			}
+			selection.configuration = webWorkflowPrimitiveConfiguration(definition.descriptor, options)
+			return selection, nil
+		}
+	case strings.HasPrefix(workflowID, webWorkflowPresetIDPrefixConstant):
+		presetName := strings.TrimPrefix(workflowID, webWorkflowPresetIDPrefixConstant)
+		selection := webWorkflowSelection{descriptor: web.WorkflowDescriptor{ID: workflowID, Source: web.WorkflowSourcePreset, Label: presetName}}
+		presetConfiguration, found, loadError := workflowcmd.NewEmbeddedPresetCatalog().Load(presetName)
+		if loadError != nil {
+			return selection, loadError
+		}
+		if found {
+			selection.configuration = presetConfiguration
+			variables, variablesError := webWorkflowVariables(configuration.Variables, request.Parameters)
+			selection.variables = variables
+			return selection, variablesError
+		}
+	case strings.HasPrefix(workflowID, webWorkflowFileIDPrefixConstant):
+		fileName := strings.TrimPrefix(workflowID, webWorkflowFileIDPrefixConstant)
+		selection := webWorkflowSelection{descriptor: web.WorkflowDescriptor{ID: workflowID, Source: web.WorkflowSourceFile, Label: fileName}}
+		fileNames, directoryError := webWorkflowFileNames(configuration.WorkflowsDirectory)
+		if directoryError != nil {
+			return selection, directoryError
+		}
+		if !slices.Contains(fileNames, fileName) {
+			return selection, fmt.Errorf(webWorkflowFileUnavailableTemplateConstant, fileName)
+		}
+		selection.descriptor.Path = filepath.Join(configuration.WorkflowsDirectory, fileName)
+		fileConfiguration, loadError := workflow.LoadConfiguration(selection.descriptor.Path)
+		if loadError != nil {
+			return selection, loadError
+		}
+		selection.configuration = fileConfiguration
+		variables, variablesError := webWorkflowVariables(configuration.Variables, request.Parameters)
+		selection.variables = variables
+		return selection, variablesError
+	}
+
+	return webWorkflowSelection{descriptor: web.WorkflowDescriptor{ID: workflowID}}, fmt.Errorf(webWorkflowUnknownTemplateConstant, workflowID)
+}
+
+// webWorkflowPrimitiveConfiguration wraps one primitive action in a single tasks apply step so primitives
+// plan and run through the same workflow executor as presets and workflow files.
+func webWorkflowPrimitiveConfiguration(descriptor web.WorkflowPrimitiveDescriptor, options map[string]any) workflow.Configuration {
+	return workflow.Configuration{
+		Steps: []workflow.StepConfiguration{
+			{
+				Name:    descriptor.ID,
+				Command: strings.Fields(webWorkflowTasksApplyCommandConstant),
+				Options: map[string]any{
+					"tasks": []any{
+						map[string]any{
+							"name": descriptor.Label,
+							"actions": []any{
+								map[string]any{
+									"type":    descriptor.ID,
+									"options": options,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func webWorkflowVariableParameters(configuration workflow.Configuration, configuredVariables map[string]string, description string) []web.WorkflowPrimitiveParameterDescriptor {
+	variableNames := workflowcmd.Variables(configuration)
+	parameters := make([]web.WorkflowPrimitiveParameterDescriptor, 0, len(variableNames))
+	for _, variableName := range variableNames {
+		defaultValue := configuredVariables[variableName]
+		if strings.HasSuffix(variableName, "_content") || variableName == webWorkflowPrimitiveParameterPathsConstant {
+			parameters = append(parameters, textareaWorkflowPrimitiveParameter(variableName, variableName, description, false, defaultValue, ""))
+			continue
+		}
+		parameters = append(parameters, textWorkflowPrimitiveParameter(variableName, variableName, description, false, defaultValue, ""))
+	}
+	return parameters
+}
+
+func webWorkflowVariables(configuredVariables map[string]string, parameters map[string]any) (map[string]string, error) {
+	overrides := make(map[string]string, len(parameters))
+	for key, rawValue := range parameters {
+		var value string
+		switch typedValue := rawValue.(type) {
+		case nil:
+			continue
+		case string:
+			value = typedValue
+		case bool:
+			value = strconv.FormatBool(typedValue)
+		case float64:
+			value = strconv.FormatFloat(typedValue, 'f', -1, 64)
+		default:
+			return nil, fmt.Errorf(webWorkflowParameterTypeTemplateConstant, key)
+		}
+		if len(strings.TrimSpace(value)) == 0 {
+			continue
+		}
+		overrides[key] = value
+	}
+	return workflowcmd.MergeVariables(configuredVariables, overrides)
+}
+
+func webWorkflowFileNames(directory string) ([]string, error) {
+	if len(directory) == 0 {
+		return nil, nil
+	}
+	entries, readError := os.ReadDir(directory)
+	if readError != nil {
+		return nil, fmt.Errorf(webWorkflowDirectoryReadErrorTemplate, directory, readError)
+	}
+
+	fileNames := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if _, supported := webWorkflowFileExtensions[strings.ToLower(filepath.Ext(entry.Name()))]; !supported {
+			continue
+		}
+		fileNames = append(fileNames, entry.Name())
+	}
+	sort.Strings(fileNames)
+	return fileNames, nil
+}
+
+func normalizeWebWorkflowRepositoryPaths(rawPaths []string) ([]string, error) {
+	repositoryPaths := make([]string, 0, len(rawPaths))
+	seenPaths := make(map[string]struct{}, len(rawPaths))
+	for _, rawPath := range rawPaths {
+		normalizedPath, pathError := normalizeWebAbsolutePath(rawPath, webWorkflowRepositoryPathRequiredConstant, webWorkflowRepositoryPathAbsoluteRequiredMsg)
+		if pathError != nil {
+			return repositoryPaths, pathError
+		}
+		if _, seen := seenPaths[normalizedPath]; seen {
+			continue
+		}
+		seenPaths[normalizedPath] = struct{}{}
+		repositoryPaths = append(repositoryPaths, normalizedPath)
+	}
+	if len(repositoryPaths) == 0 {
+		return nil, errors.New(webWorkflowRepositoriesRequiredConstant)
+	}
+	return repositoryPaths, nil
+}
+
+func describeWebWorkflowPlanSteps(workflowPlan workflowcmd.Plan) ([]web.WorkflowPlanStep, error) {
+	steps := make([]web.WorkflowPlanStep, 0, len(workflowPlan.Nodes))
+	for stepIndex, node := range workflowPlan.Nodes {
+		step := web.WorkflowPlanStep{
+			Name:  node.Name,
+			After: append([]string(nil), node.Dependencies...),
+		}
+		if stepIndex < len(workflowPlan.Configuration.Steps) {
+			stepConfiguration := workflowPlan.Configuration.Steps[stepIndex]
+			step.Command = strings.Join(stepConfiguration.Command, " ")
+			if len(stepConfiguration.Options) > 0 {
+				renderedOptions, renderError := yaml.Marshal(stepConfiguration.Options)
+				if renderError != nil {
+					return nil, renderError
+				}
+				step.Options = string(renderedOptions)
+			}
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+func webWorkflowStepLabels(configuration workflow.Configuration) []string {
+	labels := make([]string, 0, len(configuration.Steps))
+	for _, step := range configuration.Steps {
+		label := strings.TrimSpace(step.Name)
+		if len(label) == 0 {
+			label = strings.Join(step.Command, " ")
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tyemirov/gix/internal/web"
+)
+
+const webWorkflowTestFileConstant = `workflow:
+  - step:
+      name: seed-notes
+      command: ["tasks", "apply"]
+      with:
+        tasks:
+          - name: Seed notes
+            files:
+              - path: NOTES.md
+                content: '{{ .Environment.notes_text }}'
+                mode: overwrite
+`
+
+func newWebWorkflowTestApplication(t *testing.T, workflowsDirectory string) *Application {
+	t.Helper()
+
+	operations, buildError := newOperationConfigurations([]ApplicationOperationConfiguration{
+		{
+			Command: []string{"workflow"},
+			Options: map[string]any{
+				"workflows_directory": workflowsDirectory,
+				"variables":           map[string]any{"notes_text": "configured notes"},
+			},
+		},
+	})
+	require.NoError(t, buildError)
+
+	return &Application{
+		logger:                  zap.NewNop(),
+		operationConfigurations: operations,
+	}
+}
+
+func TestWebWorkflowCatalogListsPrimitivesPresetsAndFiles(t *testing.T) {
+	workflowsDirectory := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDirectory, "seed-notes.yaml"), []byte(webWorkflowTestFileConstant), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDirectory, "broken.yml"), []byte("workflow: [\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDirectory, "README.txt"), []byte("ignored\n"), 0o644))
+
+	catalog := newWebWorkflowTestApplication(t, workflowsDirectory).newWebWorkflowCatalogLoader()(context.Background())
+	require.Empty(t, catalog.Error)
+	require.Equal(t, workflowsDirectory, catalog.Directory)
+
+	descriptors := make(map[string]web.WorkflowDescriptor, len(catalog.Workflows))
+	for _, descriptor := range catalog.Workflows {
+		descriptors[descriptor.ID] = descriptor
+	}
+
+	require.Equal(t, web.WorkflowSourcePrimitive, descriptors["primitive:"+webWorkflowPrimitiveCanonicalRemoteConstant].Source)
+
+	namespacePreset := descriptors["preset:namespace"]
+	require.Equal(t, web.WorkflowSourcePreset, namespacePreset.Source)
+	require.NotEmpty(t, namespacePreset.Description)
+	require.Contains(t, webWorkflowParameterKeys(namespacePreset.Parameters), "namespace_old")
+
+	workflowFile := descriptors["file:seed-notes.yaml"]
+	require.Equal(t, web.WorkflowSourceFile, workflowFile.Source)
+	require.Equal(t, "Steps: seed-notes", workflowFile.Description)
+	require.Len(t, workflowFile.Parameters, 1)
+	require.Equal(t, "notes_text", workflowFile.Parameters[0].Key)
+	require.Equal(t, "configured notes", workflowFile.Parameters[0].DefaultValue)
+
+	require.NotEmpty(t, descriptors["file:broken.yml"].Error)
+	require.NotContains(t, descriptors, "file:README.txt")
+}
+
+func TestWebWorkflowPlannerPreviewsResolvedSteps(t *testing.T) {
+	workflowsDirectory := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDirectory, "seed-notes.yaml"), []byte(webWorkflowTestFileConstant), 0o644))
+	application := newWebWorkflowTestApplication(t, workflowsDirectory)
+	planner := application.newWebWorkflowPlanner()
+
+	plan := planner(context.Background(), web.WorkflowRunRequest{
+		WorkflowID:      "preset:remote-update-to-canonical",
+		Parameters:      map[string]any{"owner": "canonical-owner"},
+		RepositoryPaths: []string{"/tmp/alpha", "/tmp/alpha/"},
+	})
+	require.Empty(t, plan.Error)
+	require.Equal(t, []string{"/tmp/alpha"}, plan.RepositoryPaths)
+	require.Len(t, plan.Steps, 1)
+	require.Equal(t, "remote-update-to-canonical", plan.Steps[0].Name)
+	require.Equal(t, "remote update-to-canonical", plan.Steps[0].Command)
+	require.Contains(t, plan.Steps[0].Options, "owner: canonical-owner")
+
+	primitivePlan := planner(context.Background(), web.WorkflowRunRequest{
+		WorkflowID:      "primitive:" + webWorkflowPrimitiveProtocolConversionConstant,
+		Parameters:      map[string]any{"to": webWorkflowPrimitiveProtocolHTTPSConstant},
+		RepositoryPaths: []string{"/tmp/alpha"},
+	})
+	require.Empty(t, primitivePlan.Error)
+	require.Equal(t, "tasks apply", primitivePlan.Steps[0].Command)
+	require.Contains(t, primitivePlan.Steps[0].Options, webWorkflowPrimitiveProtocolConversionConstant)
+
+	missingRepositories := planner(context.Background(), web.WorkflowRunRequest{WorkflowID: "file:seed-notes.yaml"})
+	require.Equal(t, webWorkflowRepositoriesRequiredConstant, missingRepositories.Error)
+
+	escapedFile := planner(context.Background(), web.WorkflowRunRequest{WorkflowID: "file:../seed-notes.yaml", RepositoryPaths: []string{"/tmp/alpha"}})
+	require.Contains(t, escapedFile.Error, "is not in the workflows directory")
+
+	unknownWorkflow := planner(context.Background(), web.WorkflowRunRequest{WorkflowID: "preset:missing", RepositoryPaths: []string{"/tmp/alpha"}})
+	require.Contains(t, unknownWorkflow.Error, "unknown workflow")
+}
+
+func TestWebWorkflowChangeExecutesWorkflowFileAgainstItsRepository(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "gix-test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "gix-test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	workspacePath := t.TempDir()
+	repositoryPath := createTestRepository(t, filepath.Join(workspacePath, "example"))
+	remotePath := filepath.Join(workspacePath, "origin.git")
+	runGitCommand(t, "", "clone", "--bare", repositoryPath, remotePath)
+	runGitCommand(t, repositoryPath, "remote", "add", "origin", remotePath)
+	workflowsDirectory := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDirectory, "seed-notes.yaml"), []byte(webWorkflowTestFileConstant), 0o644))
+
+	executor := newWebWorkflowTestApplication(t, workflowsDirectory).newWebAuditChangeExecutor()
+	workflowChange := web.AuditQueuedChange{
+		ID:         "workflow-change-1",
+		Kind:       web.AuditChangeKindWorkflow,
+		Path:       repositoryPath,
+		WorkflowID: "file:seed-notes.yaml",
+		Parameters: map[string]any{"notes_text": "from the browser"},
+	}
+
+	canceledContext, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled := executor(canceledContext, web.AuditChangeApplyRequest{Changes: []web.AuditQueuedChange{workflowChange}})
+	require.Len(t, canceled.Results, 1)
+	require.Equal(t, webAuditChangeStatusCanceledConstant, canceled.Results[0].Status)
+
+	response := executor(context.Background(), web.AuditChangeApplyRequest{Changes: []web.AuditQueuedChange{workflowChange}})
+	require.Len(t, response.Results, 1)
+	result := response.Results[0]
+	require.Empty(t, result.Error, result.Stderr)
+	require.Equal(t, webAuditChangeStatusSucceededConstant, result.Status, result.Stdout+result.Stderr)
+	require.Equal(t, web.AuditChangeKindWorkflow, result.Kind)
+	require.Equal(t, repositoryPath, result.Path)
+	require.Equal(t, "Workflow completed: file:seed-notes.yaml", result.Message)
+
+	require.Contains(t, result.Stderr, "STEP_SEED_NOTES_APPLIED=1")
+
+	notesCommit := strings.TrimSpace(runGitCommandOutput(t, repositoryPath, "rev-list", "--all", "-1", "--", "NOTES.md"))
+	require.NotEmpty(t, notesCommit)
+	require.Equal(t, "from the browser", runGitCommandOutput(t, repositoryPath, "show", notesCommit+":NOTES.md"))
+}
+
+func webWorkflowParameterKeys(parameters []web.WorkflowPrimitiveParameterDescriptor) []string {
+	keys := make([]string, 0, len(parameters))
+	for _, parameter := range parameters {
+		keys = append(keys, parameter.Key)
+	}
+	return keys
+}
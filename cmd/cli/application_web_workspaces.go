@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/tyemirov/gix/internal/utils"
+	rootutils "github.com/tyemirov/gix/internal/utils/roots"
+	"github.com/tyemirov/gix/internal/web"
+)
+
+const (
+	webWorkspaceConfigurationKeyConstant           = "web"
+	webWorkspaceListConfigurationKeyConstant       = "workspaces"
+	webWorkspaceNameConfigurationKeyConstant       = "name"
+	webWorkspaceYAMLIndentConstant                 = 2
+	webWorkspaceYAMLNullTagConstant                = "!!null"
+	webWorkspaceYAMLMappingTagConstant             = "!!map"
+	webWorkspaceYAMLSequenceTagConstant            = "!!seq"
+	webWorkspaceYAMLStringTagConstant              = "!!str"
+	webWorkspaceConfigurationRequiredErrorConstant = "workspaces are saved in the configuration file, but no configuration file is loaded"
+	webWorkspaceConfigurationDocumentErrorTemplate = "configuration %s must hold one YAML mapping"
+	webWorkspaceConfigurationSectionErrorTemplate  = "configuration %s: web must be a mapping"
+	webWorkspaceConfigurationListErrorTemplate     = "configuration %s: web.workspaces must be a list"
+	webWorkspaceConfigurationReadErrorTemplate     = "read workspaces from %s: %w"
+	webWorkspaceConfigurationWriteErrorTemplate    = "save workspaces to %s: %w"
+	webWorkspaceNamedErrorTemplate                 = "%w: %s"
+)
+
+// webWorkspaceStore saves workspaces under web.workspaces in the configuration file gix loaded.
+// Edits change only the affected list entry and keep comments, placeholders, and other keys, but
+// the whole document is re-encoded with two-space indentation, so its layout may change.
+type webWorkspaceStore struct {
+	configurationPath string
+	loader            *utils.ConfigurationLoader
+	mutex             sync.Mutex
+}
+
+func (application *Application) webWorkspaceStore() *webWorkspaceStore {
+	return &webWorkspaceStore{
+		configurationPath: application.configurationMetadata.ConfigFileUsed,
+		loader:            application.configurationLoader,
+	}
+}
+
+func (application *Application) newWebWorkspaceRepositoryLoader() web.WorkspaceRepositoryLoader {
+	return func(executionContext context.Context, roots []string) web.RepositoryCatalog {
+		return application.repositoryCatalog(executionContext, rootutils.SanitizeConfigured(roots))
+	}
+}
+
+// ListWorkspaces reloads the configuration file so hand edits show up without restarting gix.
+func (store *webWorkspaceStore) ListWorkspaces() ([]web.Workspace, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.loadWorkspaces()
+}
+
+func (store *webWorkspaceStore) CreateWorkspace(workspace web.Workspace) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	workspaces, loadError := store.loadWorkspaces()
+	if loadError != nil {
+		return loadError
+	}
+	if _, findError := web.FindWorkspace(workspaces, workspace.Name); findError == nil {
+		return fmt.Errorf(webWorkspaceNamedErrorTemplate, web.ErrWorkspaceExists, workspace.Name)
+	}
+	return store.editWorkspaceList(func(list *yaml.Node) error {
+		entry, encodeError := encodeWebWorkspace(workspace)
+		if encodeError != nil {
+			return encodeError
+		}
+		list.Content = append(list.Content, entry)
+		return nil
+	})
+}
+
+func (store *webWorkspaceStore) UpdateWorkspace(name string, workspace web.Workspace) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	workspaces, loadError := store.loadWorkspaces()
+	if loadError != nil {
+		return loadError
+	}
+	if _, findError := web.FindWorkspace(workspaces, name); findError != nil {
+		return findError
+	}
+	if workspace.Name != name {
+		if _, findError := web.FindWorkspace(workspaces, workspace.Name); findError == nil {
+			return fmt.Errorf(webWorkspaceNamedErrorTemplate, web.ErrWorkspaceExists, workspace.Name)
+		}
+	}
+	return store.editWorkspaceList(func(list *yaml.Node) error {
+		entryIndex, findError := findWebWorkspaceEntry(list, name)
+		if findError != nil {
+			return findError
+		}
+		entry, encodeError := encodeWebWorkspace(workspace)
+		if encodeError != nil {
+			return encodeError
+		}
+		list.Content[entryIndex] = entry
+		return nil
+	})
+}
+
+func (store *webWorkspaceStore) DeleteWorkspace(name string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.editWorkspaceList(func(list *yaml.Node) error {
+		entryIndex, findError := findWebWorkspaceEntry(list, name)
+		if findError != nil {
+			return findError
+		}
+		list.Content = append(list.Content[:entryIndex], list.Content[entryIndex+1:]...)
+		return nil
+	})
+}
+
+func (store *webWorkspaceStore) loadWorkspaces() ([]web.Workspace, error) {
+	if len(store.configurationPath) == 0 {
+		return nil, errors.New(webWorkspaceConfigurationRequiredErrorConstant)
+	}
+	var configuration ApplicationConfiguration
+	if _, loadError := store.loader.LoadConfiguration(store.configurationPath, &configuration); loadError != nil {
+		return nil, fmt.Errorf(webWorkspaceConfigurationReadErrorTemplate, store.configurationPath, loadError)
+	}
+	return configuration.Web.normalizedWorkspaces()
+}
+
+// editWorkspaceList parses the configuration file, lets edit change the web.workspaces sequence
+// (creating it when absent), and writes the file back in place.
+func (store *webWorkspaceStore) editWorkspaceList(edit func(*yaml.Node) error) error {
+	if len(store.configurationPath) == 0 {
+		return errors.New(webWorkspaceConfigurationRequiredErrorConstant)
+	}
+	contents, readError := os.ReadFile(store.configurationPath)
+	if readError != nil {
+		return fmt.Errorf(webWorkspaceConfigurationReadErrorTemplate, store.configurationPath, readError)
+	}
+
+	var document yaml.Node
+	if decodeError := yaml.Unmarshal(contents, &document); decodeError != nil {
+		return fmt.Errorf(webWorkspaceConfigurationReadErrorTemplate, store.configurationPath, decodeError)
+	}
+	if document.Kind == 0 {
+		document = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: webWorkspaceYAMLMappingTagConstant}}}
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 || document.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf(webWorkspaceConfigurationDocumentErrorTemplate, store.configurationPath)
+	}
+
+	webSection, sectionFound := ensureWebWorkspaceMappingValue(document.Content[0], webWorkspaceConfigurationKeyConstant, yaml.MappingNode)
+	if !sectionFound {
+		return fmt.Errorf(webWorkspaceConfigurationSectionErrorTemplate, store.configurationPath)
+	}
+	list, listFound := ensureWebWorkspaceMappingValue(webSection, webWorkspaceListConfigurationKeyConstant, yaml.SequenceNode)
+	if !listFound {
+		return fmt.Errorf(webWorkspaceConfigurationListErrorTemplate, store.configurationPath)
+	}
+	if editError := edit(list); editError != nil {
+		return editError
+	}
+	// Block style keeps a list that started as `workspaces: []` readable once it has entries.
+	list.Style = 0
+
+	var encoded bytes.Buffer
+	encoder := yaml.NewEncoder(&encoded)
+	encoder.SetIndent(webWorkspaceYAMLIndentConstant)
+	if encodeError := encoder.Encode(&document); encodeError != nil {
+		return fmt.Errorf(webWorkspaceConfigurationWriteErrorTemplate, store.configurationPath, encodeError)
+	}
+	if closeError := encoder.Close(); closeError != nil {
+		return fmt.Errorf(webWorkspaceConfigurationWriteErrorTemplate, store.configurationPath, closeError)
+	}
+	if writeError := writeWebWorkspaceConfiguration(store.configurationPath, encoded.Bytes()); writeError != nil {
+		return fmt.Errorf(webWorkspaceConfigurationWriteErrorTemplate, store.configurationPath, writeError)
+	}
+	return nil
+}
+
+// ensureWebWorkspaceMappingValue returns the value stored under key in mapping, adding an empty
+// node of kind when the key is missing or null. It reports false when the value has another kind.
+func ensureWebWorkspaceMappingValue(mapping *yaml.Node, key string, kind yaml.Kind) (*yaml.Node, bool) {
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		if mapping.Content[index].Value != key {
+			continue
+		}
+		value := mapping.Content[index+1]
+		if value.Kind == yaml.ScalarNode && value.Tag == webWorkspaceYAMLNullTagConstant {
+			*value = *newWebWorkspaceNode(kind)
+		}
+		return value, value.Kind == kind
+	}
+	value := newWebWorkspaceNode(kind)
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: webWorkspaceYAMLStringTagConstant, Value: key}, value)
+	return value, true
+}
+
+func newWebWorkspaceNode(kind yaml.Kind) *yaml.Node {
+	if kind == yaml.SequenceNode {
+		return &yaml.Node{Kind: yaml.SequenceNode, Tag: webWorkspaceYAMLSequenceTagConstant}
+	}
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: webWorkspaceYAMLMappingTagConstant}
+}
+
+func findWebWorkspaceEntry(list *yaml.Node, name string) (int, error) {
+	for entryIndex, entry := range list.Content {
+		if entry.Kind != yaml.MappingNode {
+			continue
+		}
+		for index := 0; index+1 < len(entry.Content); index += 2 {
+			if entry.Content[index].Value == webWorkspaceNameConfigurationKeyConstant && entry.Content[index+1].Value == name {
+				return entryIndex, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf(webWorkspaceNamedErrorTemplate, web.ErrWorkspaceNotFound, name)
+}
+
+func encodeWebWorkspace(workspace web.Workspace) (*yaml.Node, error) {
+	var entry yaml.Node
+	if encodeError := entry.Encode(workspace); encodeError != nil {
+		return nil, encodeError
+	}
+	return &entry, nil
+}
+
+// writeWebWorkspaceConfiguration replaces the file through a sibling temporary file so a failed
+// write never leaves a truncated configuration behind; the original permissions are kept. A symlinked
+// configuration is resolved first so the link keeps pointing at the rewritten file.
+func writeWebWorkspaceConfiguration(path string, contents []byte) error {
+	targetPath := path
+	if resolvedPath, resolveError := filepath.EvalSymlinks(path); resolveError == nil {
+		targetPath = resolvedPath
+	}
+	fileMode := os.FileMode(configurationFilePermissionConstant)
+	if fileInfo, statError := os.Stat(targetPath); statError == nil {
+		fileMode = fileInfo.Mode().Perm()
+	}
+	return utils.WriteFileAtomically(targetPath, contents, fileMode)
+}
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/utils"
+	"github.com/tyemirov/gix/internal/web"
+)
+
+const webWorkspaceTestSectionTemplate = `
+web:
+  # Edited from the browser.
+  workspaces:
+    - name: fleet
+      roots:
+        - %s
+      filters:
+        repository: alpha
+      audit:
+        depth: minimal
+        format: csv
+`
+
+// configureApplicationWithWorkspaceConfig writes the default test configuration plus one workspace
+// named fleet rooted at rootPath, and returns the configuration path.
+func configureApplicationWithWorkspaceConfig(t *testing.T, application *Application, rootPath string) string {
+	t.Helper()
+	configurationPath := filepath.Join(t.TempDir(), "config.yml")
+	configurationContent := append(embeddedDefaultTestConfiguration(), []byte(fmt.Sprintf(webWorkspaceTestSectionTemplate, rootPath))...)
+	require.NoError(t, os.WriteFile(configurationPath, configurationContent, 0o600))
+	application.configurationFilePath = configurationPath
+	return configurationPath
+}
+
+func TestExecuteWithOptionsLaunchesWebRunnerWithSavedWorkspace(t *testing.T) {
+	rootPath := t.TempDir()
+	targetRootPath := filepath.Join(rootPath, "fleet")
+	repositoryPath := createTestRepository(t, filepath.Join(targetRootPath, "alpha"))
+	createTestRepository(t, filepath.Join(rootPath, "outside"))
+
+	capturedCatalog := web.RepositoryCatalog{}
+	var capturedOptions web.ServerOptions
+	withWorkingDirectory(t, rootPath, func() {
+		application := NewApplication()
+		configureApplicationWithWorkspaceConfig(t, application, targetRootPath)
+		application.webRunner = func(_ context.Context, options web.ServerOptions) error {
+			capturedCatalog = options.Repositories
+			capturedOptions = options
+			return nil
+		}
+
+		executionError := application.ExecuteWithOptions(ExecutionOptions{
+			Arguments:     []string{"--web", "--workspace", "fleet"},
+			Context:       context.Background(),
+			ExitOnVersion: false,
+		})
+		require.NoError(t, executionError)
+	})
+
+	require.Equal(t, "fleet", capturedCatalog.Workspace)
+	require.Equal(t, webLaunchModeConfiguredRootsConstant, capturedCatalog.LaunchMode)
+	require.Len(t, capturedCatalog.Repositories, 1)
+	require.Equal(t, canonicalPath(t, repositoryPath), canonicalPath(t, capturedCatalog.Repositories[0].Path))
+
+	workspaces, listError := capturedOptions.Workspaces.ListWorkspaces()
+	require.NoError(t, listError)
+	require.Equal(t, []web.Workspace{{
+		Name:    "fleet",
+		Roots:   []string{targetRootPath},
+		Filters: web.WorkspaceFilters{Repository: "alpha"},
+		Audit:   web.WorkspaceAudit{Depth: web.WorkspaceAuditDepthMinimal, Format: web.WorkspaceAuditFormatCSV},
+	}}, workspaces)
+
+	workspaceCatalog := capturedOptions.LoadWorkspace(context.Background(), []string{targetRootPath})
+	require.Len(t, workspaceCatalog.Repositories, 1)
+}
+
+func TestExecuteWithOptionsRejectsInvalidWorkspaceLaunches(t *testing.T) {
+	rootPath := t.TempDir()
+
+	testCases := []struct {
+		name          string
+		arguments     []string
+		expectedError string
+	}{
+		{name: "without web", arguments: []string{"--workspace", "fleet"}, expectedError: webWorkspaceFlagRequiresWebConstant},
+		{name: "with roots", arguments: []string{"--web", "--workspace", "fleet", "--roots", rootPath}, expectedError: webWorkspaceRootsConflictConstant},
+		{name: "unknown", arguments: []string{"--web", "--workspace", "missing"}, expectedError: "workspace not found: missing"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			application := NewApplication()
+			configureApplicationWithWorkspaceConfig(t, application, rootPath)
+			application.webRunner = func(context.Context, web.ServerOptions) error {
+				t.Fatal("web runner must not start")
+				return nil
+			}
+
+			executionError := application.ExecuteWithOptions(ExecutionOptions{
+				Arguments:     testCase.arguments,
+				Context:       context.Background(),
+				ExitOnVersion: false,
+			})
+			require.EqualError(t, executionError, testCase.expectedError)
+		})
+	}
+}
+
+func TestInitializeConfigurationRejectsInvalidWorkspaces(t *testing.T) {
+	application := NewApplication()
+	configurationPath := filepath.Join(t.TempDir(), "config.yml")
+	configurationContent := append(embeddedDefaultTestConfiguration(), []byte(`
+web:
+  workspaces:
+    - name: fleet
+      roots: [/tmp/fleet]
+    - name: fleet
+      roots: [/tmp/other]
+`)...)
+	require.NoError(t, os.WriteFile(configurationPath, configurationContent, 0o600))
+	application.configurationFilePath = configurationPath
+
+	initializationError := application.InitializeForCommand("gix")
+	require.EqualError(t, initializationError, `invalid web configuration: workspace "fleet" is defined more than once`)
+}
+
+func TestWebWorkspaceStoreEditsOnlyTheWorkspaceList(t *testing.T) {
+	application := NewApplication()
+	configurationPath := configureApplicationWithWorkspaceConfig(t, application, "~/Development/fleet")
+	require.NoError(t, application.InitializeForCommand("gix"))
+	store := application.webWorkspaceStore()
+
+	require.NoError(t, store.CreateWorkspace(web.Workspace{
+		Name:  "services",
+		Roots: []string{"/srv/services"},
+		Audit: web.WorkspaceAudit{Depth: web.WorkspaceAuditDepthGitHub, Format: web.WorkspaceAuditFormatTable},
+	}))
+	require.ErrorIs(t, store.CreateWorkspace(web.Workspace{Name: "services", Roots: []string{"/srv"}}), web.ErrWorkspaceExists)
+	require.ErrorIs(t, store.UpdateWorkspace("services", web.Workspace{Name: "fleet", Roots: []string{"/srv"}}), web.ErrWorkspaceExists)
+	require.ErrorIs(t, store.UpdateWorkspace("missing", web.Workspace{Name: "missing", Roots: []string{"/srv"}}), web.ErrWorkspaceNotFound)
+
+	require.NoError(t, store.UpdateWorkspace("services", web.Workspace{
+		Name:    "backend",
+		Roots:   []string{"/srv/backend"},
+		Filters: web.WorkspaceFilters{Columns: map[string]string{"in_sync": "no"}},
+		Audit:   web.WorkspaceAudit{Depth: web.WorkspaceAuditDepthFull, Format: web.WorkspaceAuditFormatJSON},
+	}))
+
+	workspaces, listError := store.ListWorkspaces()
+	require.NoError(t, listError)
+	require.Len(t, workspaces, 2)
+	require.Equal(t, "fleet", workspaces[0].Name)
+	require.Equal(t, []string{"~/Development/fleet"}, workspaces[0].Roots)
+	require.Equal(t, web.Workspace{
+		Name:    "backend",
+		Roots:   []string{"/srv/backend"},
+		Filters: web.WorkspaceFilters{Columns: map[string]string{"in_sync": "no"}},
+		Audit:   web.WorkspaceAudit{Depth: web.WorkspaceAuditDepthFull, Format: web.WorkspaceAuditFormatJSON},
+	}, workspaces[1])
+
+	require.NoError(t, store.DeleteWorkspace("fleet"))
+	require.ErrorIs(t, store.DeleteWorkspace("fleet"), web.ErrWorkspaceNotFound)
+
+	contents, readError := os.ReadFile(configurationPath)
+	require.NoError(t, readError)
+	require.Contains(t, string(contents), "# Edited from the browser.")
+	require.Contains(t, string(contents), `credential: "test-github-key"`)
+	require.NotContains(t, string(contents), "fleet")
+
+	var configuration ApplicationConfiguration
+	_, loadError := utils.NewConfigurationLoader().LoadConfiguration(configurationPath, &configuration)
+	require.NoError(t, loadError)
+	require.Len(t, configuration.Web.Workspaces, 1)
+	require.Equal(t, "backend", configuration.Web.Workspaces[0].Name)
+}
+
+func TestWebWorkspaceStoreAddsWebSectionWhenMissing(t *testing.T) {
+	application := NewApplication()
+	configureApplicationWithTestConfig(t, application)
+	require.NoError(t, application.InitializeForCommand("gix"))
+	store := application.webWorkspaceStore()
+
+	require.NoError(t, store.CreateWorkspace(web.Workspace{Name: "fleet", Roots: []string{"/srv/fleet"}, Audit: web.WorkspaceAudit{Depth: "full", Format: "table"}}))
+
+	workspaces, listError := store.ListWorkspaces()
+	require.NoError(t, listError)
+	require.Equal(t, []string{"fleet"}, []string{workspaces[0].Name})
+}
+
+func TestWriteWebWorkspaceConfigurationKeepsSymlinkedConfiguration(t *testing.T) {
+	targetPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(targetPath, []byte("web: {}\n"), 0o640))
+	linkPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.Symlink(targetPath, linkPath))
+
+	require.NoError(t, writeWebWorkspaceConfiguration(linkPath, []byte("web:\n  workspaces: []\n")))
+
+	linkInfo, linkError := os.Lstat(linkPath)
+	require.NoError(t, linkError)
+	require.Equal(t, os.ModeSymlink, linkInfo.Mode()&os.ModeSymlink)
+	contents, readError := os.ReadFile(targetPath)
+	require.NoError(t, readError)
+	require.Equal(t, "web:\n  workspaces: []\n", string(contents))
+	targetInfo, statError := os.Stat(targetPath)
+	require.NoError(t, statError)
+	require.Equal(t, os.FileMode(0o640), targetInfo.Mode().Perm())
+}
+
+func TestWebAuditInspectionDepthRejectsUnknownDepths(t *testing.T) {
+	depth, depthError := webAuditInspectionDepth("")
+	require.NoError(t, depthError)
+	require.Equal(t, "full", string(depth))
+
+	response := NewApplication().newWebAuditInspector()(context.Background(), web.AuditInspectionRequest{Roots: []string{t.TempDir()}, Depth: "deep"})
+	require.Equal(t, `unsupported audit depth "deep"; expected minimal, full, or github`, response.Error)
+}
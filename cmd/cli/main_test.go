@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain points the user cache directory at a temporary directory so web audit tests never write
+// the user's audit inspection cache.
+func TestMain(m *testing.M) {
+	cacheDirectory, cacheDirectoryError := os.MkdirTemp("", "gix-cli-cache-")
+	if cacheDirectoryError != nil {
+		panic(cacheDirectoryError)
+	}
+	_ = os.Setenv("XDG_CACHE_HOME", cacheDirectory)
+	exitCode := m.Run()
+	_ = os.RemoveAll(cacheDirectory)
+	os.Exit(exitCode)
+}
@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"strings"
+
 	"github.com/tyemirov/gix/internal/llmclient"
 	pathutils "github.com/tyemirov/gix/internal/utils/path"
 	workflowpkg "github.com/tyemirov/gix/internal/workflow"
@@ -8,6 +10,8 @@ import (
 
 var workflowConfigurationRepositoryPathSanitizer = pathutils.NewRepositoryPathSanitizerWithConfiguration(nil, pathutils.RepositoryPathSanitizerConfiguration{PruneNestedPaths: true})
 
+var workflowConfigurationHomeExpander = pathutils.NewHomeExpander()
+
 // CommandConfiguration captures configuration values for workflow.
 type CommandConfiguration struct {
 	Roots              []string                     `mapstructure:"roots"`
@@ -15,6 +19,7 @@ type CommandConfiguration struct {
 	RequireClean       bool                         `mapstructure:"require_clean"`
 	WorkflowWorkers    int                          `mapstructure:"workflow_workers"`
 	Variables          map[string]string            `mapstructure:"variables"`
+	WorkflowsDirectory string                       `mapstructure:"workflows_directory"`
 	ConnectionProfiles llmclient.ConnectionProfiles `mapstructure:"-"`
 	ConfiguredWorkflow *workflowpkg.Configuration   `mapstructure:"-"`
 }
@@ -32,6 +37,7 @@ func DefaultCommandConfiguration() CommandConfiguration {
 func (configuration CommandConfiguration) Sanitize() CommandConfiguration {
 	sanitized := configuration
 	sanitized.Roots = workflowConfigurationRepositoryPathSanitizer.Sanitize(configuration.Roots)
+	sanitized.WorkflowsDirectory = workflowConfigurationHomeExpander.Expand(strings.TrimSpace(configuration.WorkflowsDirectory))
 	if sanitized.WorkflowWorkers < 1 {
 		sanitized.WorkflowWorkers = 1
 	}
@@ -0,0 +1,166 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/tyemirov/gix/internal/licenses"
+	"github.com/tyemirov/gix/internal/llmclient"
+	workflowpkg "github.com/tyemirov/gix/internal/workflow"
+)
+
+var environmentVariableReferencePattern = regexp.MustCompile(`\.Environment\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// PlanOptions carries the defaults applied to every operation of a workflow plan.
+type PlanOptions struct {
+	RequireClean       bool
+	ConnectionProfiles llmclient.ConnectionProfiles
+}
+
+// Plan is a workflow configuration with variables applied and operations built, ready to execute.
+type Plan struct {
+	Configuration workflowpkg.Configuration
+	Nodes         []*workflowpkg.OperationNode
+	Variables     map[string]string
+	requirements  runtimeRequirements
+}
+
+// NewPlan applies variable overrides to a copy of the configuration and builds its operations.
+func NewPlan(configuration workflowpkg.Configuration, variables map[string]string, options PlanOptions) (Plan, error) {
+	plannedConfiguration := cloneConfiguredWorkflow(configuration)
+	if overrideError := applyVariableOverrides(&plannedConfiguration, variables); overrideError != nil {
+		return Plan{}, overrideError
+	}
+
+	nodes, operationsError := workflowpkg.BuildOperations(plannedConfiguration)
+	if operationsError != nil {
+		return Plan{}, fmt.Errorf(buildOperationsErrorTemplateConstant, operationsError)
+	}
+
+	workflowpkg.ApplyDefaults(nodes, workflowpkg.OperationDefaults{RequireClean: options.RequireClean})
+	workflowpkg.ApplyLLMConnectionProfiles(nodes, options.ConnectionProfiles)
+
+	return Plan{
+		Configuration: plannedConfiguration,
+		Nodes:         nodes,
+		Variables:     variables,
+		requirements:  deriveRuntimeRequirements(nodes),
+	}, nil
+}
+
+// RuntimeOptions returns the runtime options the planned operations require.
+func (plan Plan) RuntimeOptions(assumeYes bool, workflowWorkers int) workflowpkg.RuntimeOptions {
+	return workflowpkg.RuntimeOptions{
+		AssumeYes:                            assumeYes,
+		IncludeNestedRepositories:            plan.requirements.includeNestedRepositories,
+		ProcessRepositoriesByDescendingDepth: plan.requirements.processRepositoriesByDescendingDepth,
+		CaptureInitialWorktreeStatus:         plan.requirements.captureInitialWorktreeStatus,
+		WorkflowParallelism:                  workflowWorkers,
+		Variables:                            plan.Variables,
+	}
+}
+
+// MergeVariables layers overrides on top of configured workflow variables, validating every name like --var does.
+func MergeVariables(configured map[string]string, overrides map[string]string) (map[string]string, error) {
+	merged := make(map[string]string, len(configured)+len(overrides))
+	for key, value := range configured {
+		normalizedKey, normalizeError := normalizeVariableName(key)
+		if normalizeError != nil {
+			return nil, fmt.Errorf("invalid workflow variable %q in configuration: %w", key, normalizeError)
+		}
+		merged[normalizedKey] = value
+	}
+	for key, value := range overrides {
+		normalizedKey, normalizeError := normalizeVariableName(key)
+		if normalizeError != nil {
+			return nil, fmt.Errorf("invalid workflow variable %q: %w", key, normalizeError)
+		}
+		merged[normalizedKey] = value
+	}
+
+	if len(merged) == 0 {
+		return nil, nil
+	}
+	return merged, nil
+}
+
+// Variables lists the workflow variables a configuration reads, in sorted order. It covers
+// {{ .Environment.<name> }} references in step options and the variables the workflow command
+// maps onto known step options.
+func Variables(configuration workflowpkg.Configuration) []string {
+	discovered := make(map[string]struct{})
+	for _, step := range configuration.Steps {
+		collectEnvironmentVariableReferences(step.Options, discovered)
+
+		switch workflowpkg.CommandPathKey(step.Command) {
+		case "remote update-to-canonical":
+			discovered["owner"] = struct{}{}
+		case "remote update-protocol":
+			discovered["from"] = struct{}{}
+			discovered["to"] = struct{}{}
+		case tasksApplyCommandKeyConstant:
+			if stepIncludesActionType(step.Options, workflowpkg.TaskActionHistoryPurgeType) {
+				for _, name := range []string{"paths", "remote", "push", "restore", "push_missing"} {
+					discovered[name] = struct{}{}
+				}
+			}
+		}
+	}
+	if _, readsLicenseContent := discovered[licenses.VariableContent]; readsLicenseContent {
+		discovered[licenses.VariableTemplate] = struct{}{}
+	}
+
+	names := make([]string, 0, len(discovered))
+	for name := range discovered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func collectEnvironmentVariableReferences(value any, discovered map[string]struct{}) {
+	switch typedValue := value.(type) {
+	case string:
+		for _, match := range environmentVariableReferencePattern.FindAllStringSubmatch(typedValue, -1) {
+			discovered[match[1]] = struct{}{}
+		}
+	case map[string]any:
+		for _, nestedValue := range typedValue {
+			collectEnvironmentVariableReferences(nestedValue, discovered)
+		}
+	case []any:
+		for _, nestedValue := range typedValue {
+			collectEnvironmentVariableReferences(nestedValue, discovered)
+		}
+	case []string:
+		for _, nestedValue := range typedValue {
+			collectEnvironmentVariableReferences(nestedValue, discovered)
+		}
+	case map[string]string:
+		for _, nestedValue := range typedValue {
+			collectEnvironmentVariableReferences(nestedValue, discovered)
+		}
+	}
+}
+
+func stepIncludesActionType(value any, actionType string) bool {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		if declaredType, isString := typedValue["type"].(string); isString && declaredType == actionType {
+			return true
+		}
+		for _, nestedValue := range typedValue {
+			if stepIncludesActionType(nestedValue, actionType) {
+				return true
+			}
+		}
+	case []any:
+		for _, nestedValue := range typedValue {
+			if stepIncludesActionType(nestedValue, actionType) {
+				return true
+			}
+		}
+	}
+	return false
+}
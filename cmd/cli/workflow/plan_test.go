@@ -0,0 +1,73 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	workflowpkg "github.com/tyemirov/gix/internal/workflow"
+)
+
+func TestVariablesDiscoversPresetParameters(t *testing.T) {
+	catalog := NewEmbeddedPresetCatalog()
+	testCases := []struct {
+		preset    string
+		variables []string
+	}{
+		{preset: "namespace", variables: []string{"namespace_branch_prefix", "namespace_commit_message", "namespace_new", "namespace_old", "namespace_push", "namespace_remote"}},
+		{preset: "remote-update-to-canonical", variables: []string{"owner"}},
+		{preset: "remote-update-protocol", variables: []string{"from", "to"}},
+		{preset: "history-remove", variables: []string{"paths", "push", "push_missing", "remote", "restore"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.preset, func(t *testing.T) {
+			configuration, found, loadError := catalog.Load(testCase.preset)
+			require.NoError(t, loadError)
+			require.True(t, found)
+			require.Equal(t, testCase.variables, Variables(configuration))
+		})
+	}
+}
+
+func TestVariablesOffersLicenseTemplateWhenContentIsReferenced(t *testing.T) {
+	configuration, found, loadError := NewEmbeddedPresetCatalog().Load("license")
+	require.NoError(t, loadError)
+	require.True(t, found)
+
+	variables := Variables(configuration)
+	require.Contains(t, variables, "license_content")
+	require.Contains(t, variables, "license_template")
+	require.Contains(t, variables, "license_branch")
+}
+
+func TestNewPlanAppliesVariablesWithoutMutatingConfiguration(t *testing.T) {
+	configuration, found, loadError := NewEmbeddedPresetCatalog().Load("remote-update-to-canonical")
+	require.NoError(t, loadError)
+	require.True(t, found)
+
+	plan, planError := NewPlan(configuration, map[string]string{"owner": "canonical"}, PlanOptions{RequireClean: true})
+	require.NoError(t, planError)
+	require.Len(t, plan.Nodes, 1)
+	require.Equal(t, "canonical", plan.Configuration.Steps[0].Options["owner"])
+	require.Equal(t, "", configuration.Steps[0].Options["owner"])
+
+	runtimeOptions := plan.RuntimeOptions(true, 3)
+	require.True(t, runtimeOptions.AssumeYes)
+	require.Equal(t, 3, runtimeOptions.WorkflowParallelism)
+	require.Equal(t, map[string]string{"owner": "canonical"}, runtimeOptions.Variables)
+}
+
+func TestNewPlanRejectsUnknownCommands(t *testing.T) {
+	_, planError := NewPlan(workflowpkg.Configuration{Steps: []workflowpkg.StepConfiguration{{Command: []string{"unknown"}}}}, nil, PlanOptions{})
+	require.Error(t, planError)
+}
+
+func TestMergeVariablesLayersOverridesOnConfiguredValues(t *testing.T) {
+	merged, mergeError := MergeVariables(map[string]string{"owner": "configured", "remote": "origin"}, map[string]string{"owner": "override"})
+	require.NoError(t, mergeError)
+	require.Equal(t, map[string]string{"owner": "override", "remote": "origin"}, merged)
+
+	_, invalidError := MergeVariables(nil, map[string]string{"not valid": "x"})
+	require.Error(t, invalidError)
+}
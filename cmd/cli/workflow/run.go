@@ -142,15 +142,6 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		return variableError
 	}
 
-	if overrideError := applyVariableOverrides(&workflowConfiguration, variableAssignments); overrideError != nil {
-		return overrideError
-	}
-
-	nodes, operationsError := workflow.BuildOperations(workflowConfiguration)
-	if operationsError != nil {
-		return fmt.Errorf(buildOperationsErrorTemplateConstant, operationsError)
-	}
-
 	requireCleanDefault := commandConfiguration.RequireClean
 	if command != nil {
 		requireCleanFlagValue, requireCleanFlagChanged, requireCleanFlagError := flagutils.BoolFlag(command, requireCleanFlagNameConstant)
@@ -162,9 +153,13 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		}
 	}
 
-	workflow.ApplyDefaults(nodes, workflow.OperationDefaults{RequireClean: requireCleanDefault})
-	workflow.ApplyLLMConnectionProfiles(nodes, commandConfiguration.ConnectionProfiles)
-	runtimeRequirements := deriveRuntimeRequirements(nodes)
+	plan, planError := NewPlan(workflowConfiguration, variableAssignments, PlanOptions{
+		RequireClean:       requireCleanDefault,
+		ConnectionProfiles: commandConfiguration.ConnectionProfiles,
+	})
+	if planError != nil {
+		return planError
+	}
 
 	dependencyOptions := taskrunner.DependenciesOptions{Command: command}
 	if command != nil {
@@ -210,16 +205,9 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		}
 	}
 
-	runtimeOptions := workflow.RuntimeOptions{
-		AssumeYes:                            assumeYes,
-		IncludeNestedRepositories:            runtimeRequirements.includeNestedRepositories,
-		ProcessRepositoriesByDescendingDepth: runtimeRequirements.processRepositoriesByDescendingDepth,
-		CaptureInitialWorktreeStatus:         runtimeRequirements.captureInitialWorktreeStatus,
-		WorkflowParallelism:                  workflowWorkers,
-		Variables:                            variableAssignments,
-	}
+	runtimeOptions := plan.RuntimeOptions(assumeYes, workflowWorkers)
 
-	executor := ResolveOperationExecutor(builder.OperationExecutorFactory, nodes, workflowDependencies)
+	executor := ResolveOperationExecutor(builder.OperationExecutorFactory, plan.Nodes, workflowDependencies)
 	outcome, runErr := executor.Execute(command.Context(), roots, runtimeOptions)
 	summary := taskrunner.RenderSummaryLine(outcome.ReporterSummaryData, roots)
 	if len(strings.TrimSpace(summary)) > 0 {
@@ -0,0 +1,137 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/repos/shared"
+)
+
+const (
+	gitRevListSubcommandConstant    = "rev-list"
+	gitLeftRightFlagConstant        = "--left-right"
+	gitCountFlagConstant            = "--count"
+	gitForEachRefSubcommandConstant = "for-each-ref"
+	gitMergedFlagConstant           = "--merged"
+	gitLocalBranchesPrefixConstant  = "refs/heads/"
+	branchInventoryFormatConstant   = "--format=%(refname:short)%09%(upstream)%09%(upstream:track)"
+	mergedBranchesFormatConstant    = "--format=%(refname:short)"
+	upstreamGoneTrackConstant       = "[gone]"
+	symmetricDifferenceSeparator    = "..."
+)
+
+// measureDivergence counts commits reachable only from HEAD (ahead) and only from reference (behind).
+// Counts use the remote-tracking refs already present locally; the audit does not fetch for them.
+func (service *Service) measureDivergence(executionContext context.Context, repositoryPath string, reference string) CommitDivergence {
+	if len(strings.TrimSpace(reference)) == 0 {
+		return CommitDivergence{}
+	}
+
+	executionResult, executionError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        divergenceArguments(reference),
+		WorkingDirectory: repositoryPath,
+	})
+	if executionError != nil {
+		return CommitDivergence{}
+	}
+
+	fields := strings.Fields(executionResult.StandardOutput)
+	if len(fields) != 2 {
+		return CommitDivergence{}
+	}
+	ahead, aheadError := strconv.Atoi(fields[0])
+	behind, behindError := strconv.Atoi(fields[1])
+	if aheadError != nil || behindError != nil {
+		return CommitDivergence{}
+	}
+	return CommitDivergence{Ahead: ahead, Behind: behind, Available: true}
+}
+
+// collectBranchInventory classifies local branches that have no upstream, whose upstream is gone,
+// or that are already merged into the remote default branch.
+func (service *Service) collectBranchInventory(executionContext context.Context, repositoryPath string, remoteDefaultBranch string) BranchInventory {
+	executionResult, executionError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitForEachRefSubcommandConstant, branchInventoryFormatConstant, gitLocalBranchesPrefixConstant},
+		WorkingDirectory: repositoryPath,
+	})
+	if executionError != nil {
+		return BranchInventory{}
+	}
+
+	inventory := BranchInventory{Available: true}
+	for _, line := range strings.Split(executionResult.StandardOutput, "\n") {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		fields := strings.Split(line, gitReferenceSeparator)
+		branchName := strings.TrimSpace(fields[0])
+		upstream := ""
+		if len(fields) > 1 {
+			upstream = strings.TrimSpace(fields[1])
+		}
+		track := ""
+		if len(fields) > 2 {
+			track = strings.TrimSpace(fields[2])
+		}
+		switch {
+		case len(upstream) == 0:
+			inventory.NoUpstream = append(inventory.NoUpstream, branchName)
+		case track == upstreamGoneTrackConstant:
+			inventory.UpstreamGone = append(inventory.UpstreamGone, branchName)
+		}
+	}
+
+	inventory.Merged, inventory.MergedAvailable = service.collectMergedBranches(executionContext, repositoryPath, remoteDefaultBranch)
+	sort.Strings(inventory.NoUpstream)
+	sort.Strings(inventory.UpstreamGone)
+	return inventory
+}
+
+func (service *Service) collectMergedBranches(executionContext context.Context, repositoryPath string, remoteDefaultBranch string) ([]string, bool) {
+	trimmedDefault := strings.TrimSpace(remoteDefaultBranch)
+	if len(trimmedDefault) == 0 {
+		return nil, false
+	}
+
+	executionResult, executionError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments: []string{
+			gitForEachRefSubcommandConstant,
+			mergedBranchesFormatConstant,
+			gitMergedFlagConstant,
+			remoteTrackingReference(trimmedDefault),
+			gitLocalBranchesPrefixConstant,
+		},
+		WorkingDirectory: repositoryPath,
+	})
+	if executionError != nil {
+		return nil, false
+	}
+
+	var merged []string
+	for _, line := range strings.Split(executionResult.StandardOutput, "\n") {
+		branchName := strings.TrimSpace(line)
+		if len(branchName) == 0 || branchName == trimmedDefault {
+			continue
+		}
+		merged = append(merged, branchName)
+	}
+	sort.Strings(merged)
+	return merged, true
+}
+
+func divergenceArguments(reference string) []string {
+	return []string{
+		gitRevListSubcommandConstant,
+		gitLeftRightFlagConstant,
+		gitCountFlagConstant,
+		gitHeadReferenceConstant + symmetricDifferenceSeparator + reference,
+	}
+}
+
+func remoteTrackingReference(branch string) string {
+	return fmt.Sprintf("refs/remotes/%s/%s", shared.OriginRemoteNameConstant, branch)
+}
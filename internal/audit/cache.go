@@ -0,0 +1,360 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/githubcli"
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/utils"
+)
+
+// InspectionCacheSchemaVersion identifies the on-disk cache layout; files with another schema are ignored.
+const InspectionCacheSchemaVersion = "gix.audit-cache/v1"
+
+// DefaultMetadataCacheTTL bounds how long GitHub repository metadata is reused without asking GitHub again.
+const DefaultMetadataCacheTTL = 24 * time.Hour
+
+const (
+	inspectionCacheDirectoryName     = "gix"
+	inspectionCacheFileName          = "audit-inspections.json"
+	inspectionCacheFilePermissions   = 0o600
+	inspectionCacheDirPermissions    = 0o755
+	inspectionCacheSaveErrorTemplate = "save audit cache %s: %w"
+	gitAbsoluteGitDirFlagConstant    = "--absolute-git-dir"
+	gitCommonDirFlagConstant         = "--git-common-dir"
+	gitVerifyFlagConstant            = "--verify"
+	gitIndexFileName                 = "index"
+	gitPackedRefsFileName            = "packed-refs"
+	gitRefsDirectoryName             = "refs"
+	gitConfigFileName                = "config"
+	gitHeadFileName                  = "HEAD"
+	fingerprintFieldSeparator        = "\x00"
+)
+
+// InspectionCacheOptions configures an InspectionCache.
+type InspectionCacheOptions struct {
+	// MetadataTTL defaults to DefaultMetadataCacheTTL when zero.
+	MetadataTTL time.Duration
+	Clock       func() time.Time
+}
+
+// InspectionCache persists repository inspections keyed by path and a repository fingerprint,
+// plus GitHub metadata keyed by owner/repo with its own expiry.
+type InspectionCache struct {
+	store *inspectionCacheStore
+	// refreshedAfter hides entries recorded before a refresh started; the zero value hides nothing.
+	refreshedAfter time.Time
+}
+
+type inspectionCacheStore struct {
+	path        string
+	metadataTTL time.Duration
+	clock       func() time.Time
+
+	mutex    sync.Mutex
+	loaded   bool
+	modified bool
+	document inspectionCacheDocument
+}
+
+type inspectionCacheDocument struct {
+	Schema       string                          `json:"schema"`
+	Repositories map[string]inspectionCacheEntry `json:"repositories"`
+	Metadata     map[string]metadataCacheEntry   `json:"metadata"`
+}
+
+type inspectionCacheEntry struct {
+	Fingerprint string          `json:"fingerprint"`
+	Depth       InspectionDepth `json:"depth"`
+	InspectedAt time.Time       `json:"inspected_at"`
+	// SeenAt records the last cache hit; entries neither inspected nor seen within the TTL are pruned on save.
+	SeenAt     time.Time            `json:"seen_at,omitempty"`
+	Inspection RepositoryInspection `json:"inspection"`
+}
+
+type metadataCacheEntry struct {
+	NameWithOwner    string    `json:"name_with_owner"`
+	DefaultBranch    string    `json:"default_branch"`
+	Description      string    `json:"description,omitempty"`
+	IsInOrganization bool      `json:"is_in_organization,omitempty"`
+	ResolvedAt       time.Time `json:"resolved_at"`
+}
+
+// DefaultInspectionCachePath returns the cache file under the user cache directory.
+func DefaultInspectionCachePath() (string, error) {
+	cacheDirectory, cacheDirectoryError := os.UserCacheDir()
+	if cacheDirectoryError != nil {
+		return "", cacheDirectoryError
+	}
+	return filepath.Join(cacheDirectory, inspectionCacheDirectoryName, inspectionCacheFileName), nil
+}
+
+// NewInspectionCache constructs a cache backed by path. The file is read lazily and written by Save.
+func NewInspectionCache(path string, options InspectionCacheOptions) *InspectionCache {
+	metadataTTL := options.MetadataTTL
+	if metadataTTL <= 0 {
+		metadataTTL = DefaultMetadataCacheTTL
+	}
+	clock := options.Clock
+	if clock == nil {
+		clock = time.Now
+	}
+	return &InspectionCache{store: &inspectionCacheStore{
+		path:        path,
+		metadataTTL: metadataTTL,
+		clock:       clock,
+	}}
+}
+
+// WithRefresh returns a view of the same cache that ignores entries recorded before the call while still
+// recording fresh results, so repeated inspections within one refreshed run query git and GitHub once.
+func (cache *InspectionCache) WithRefresh() *InspectionCache {
+	if cache == nil {
+		return nil
+	}
+	return &InspectionCache{store: cache.store, refreshedAfter: cache.store.clock()}
+}
+
+// Save writes the cache when entries changed since it was loaded.
+func (cache *InspectionCache) Save() error {
+	if cache == nil {
+		return nil
+	}
+	return cache.store.save()
+}
+
+func (cache *inspectionCacheStore) save() error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if !cache.modified {
+		return nil
+	}
+	cache.pruneLocked()
+	contents, encodeError := json.Marshal(cache.document)
+	if encodeError != nil {
+		return fmt.Errorf(inspectionCacheSaveErrorTemplate, cache.path, encodeError)
+	}
+	if directoryError := os.MkdirAll(filepath.Dir(cache.path), inspectionCacheDirPermissions); directoryError != nil {
+		return fmt.Errorf(inspectionCacheSaveErrorTemplate, cache.path, directoryError)
+	}
+	if writeError := utils.WriteFileAtomically(cache.path, contents, inspectionCacheFilePermissions); writeError != nil {
+		return fmt.Errorf(inspectionCacheSaveErrorTemplate, cache.path, writeError)
+	}
+	cache.modified = false
+	return nil
+}
+
+// pruneLocked drops entries for repositories that no longer exist and entries not used within the TTL,
+// so the file does not keep growing with every directory ever audited.
+func (cache *inspectionCacheStore) pruneLocked() {
+	for repositoryPath, entry := range cache.document.Repositories {
+		lastUsedAt := entry.InspectedAt
+		if entry.SeenAt.After(lastUsedAt) {
+			lastUsedAt = entry.SeenAt
+		}
+		if _, statError := os.Stat(repositoryPath); statError != nil || cache.expired(lastUsedAt) {
+			delete(cache.document.Repositories, repositoryPath)
+		}
+	}
+	for ownerRepository, entry := range cache.document.Metadata {
+		if cache.expired(entry.ResolvedAt) {
+			delete(cache.document.Metadata, ownerRepository)
+		}
+	}
+}
+
+// ensureLoadedLocked reads the cache file once; missing, unreadable, or foreign files start an empty cache
+// that Save later replaces.
+func (cache *inspectionCacheStore) ensureLoadedLocked() {
+	if cache.loaded {
+		return
+	}
+	cache.loaded = true
+	cache.document = inspectionCacheDocument{
+		Schema:       InspectionCacheSchemaVersion,
+		Repositories: map[string]inspectionCacheEntry{},
+		Metadata:     map[string]metadataCacheEntry{},
+	}
+
+	contents, readError := os.ReadFile(cache.path)
+	if readError != nil {
+		return
+	}
+	var stored inspectionCacheDocument
+	if decodeError := json.Unmarshal(contents, &stored); decodeError != nil || stored.Schema != InspectionCacheSchemaVersion {
+		return
+	}
+	for path, entry := range stored.Repositories {
+		cache.document.Repositories[path] = entry
+	}
+	for ownerRepository, entry := range stored.Metadata {
+		cache.document.Metadata[ownerRepository] = entry
+	}
+}
+
+func (cache *InspectionCache) lookupInspection(repositoryPath string, fingerprint string, depth InspectionDepth) (RepositoryInspection, bool) {
+	store := cache.store
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.ensureLoadedLocked()
+	entry, exists := store.document.Repositories[repositoryPath]
+	if !exists || entry.Fingerprint != fingerprint || entry.InspectedAt.Before(cache.refreshedAfter) {
+		return RepositoryInspection{}, false
+	}
+	if !entry.Depth.includes(depth) {
+		return RepositoryInspection{}, false
+	}
+	if entry.Inspection.OriginRemoteStatus == OriginRemoteStatusConfigured && store.expired(entry.InspectedAt) {
+		return RepositoryInspection{}, false
+	}
+	entry.SeenAt = store.clock()
+	store.document.Repositories[repositoryPath] = entry
+	store.modified = true
+	return entry.Inspection, true
+}
+
+func (cache *InspectionCache) storeInspection(repositoryPath string, fingerprint string, depth InspectionDepth, inspection RepositoryInspection) {
+	store := cache.store
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.ensureLoadedLocked()
+	store.document.Repositories[repositoryPath] = inspectionCacheEntry{
+		Fingerprint: fingerprint,
+		Depth:       depth,
+		InspectedAt: store.clock(),
+		Inspection:  inspection,
+	}
+	store.modified = true
+}
+
+func (cache *InspectionCache) lookupMetadata(ownerRepository string) (githubcli.RepositoryMetadata, bool) {
+	store := cache.store
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.ensureLoadedLocked()
+	entry, exists := store.document.Metadata[strings.ToLower(ownerRepository)]
+	if !exists || store.expired(entry.ResolvedAt) || entry.ResolvedAt.Before(cache.refreshedAfter) {
+		return githubcli.RepositoryMetadata{}, false
+	}
+	return githubcli.RepositoryMetadata{
+		NameWithOwner:    entry.NameWithOwner,
+		DefaultBranch:    entry.DefaultBranch,
+		Description:      entry.Description,
+		IsInOrganization: entry.IsInOrganization,
+	}, true
+}
+
+func (cache *InspectionCache) storeMetadata(ownerRepository string, metadata githubcli.RepositoryMetadata) {
+	store := cache.store
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.ensureLoadedLocked()
+	store.document.Metadata[strings.ToLower(ownerRepository)] = metadataCacheEntry{
+		NameWithOwner:    metadata.NameWithOwner,
+		DefaultBranch:    metadata.DefaultBranch,
+		Description:      metadata.Description,
+		IsInOrganization: metadata.IsInOrganization,
+		ResolvedAt:       store.clock(),
+	}
+	store.modified = true
+}
+
+func (cache *inspectionCacheStore) expired(recordedAt time.Time) bool {
+	return cache.clock().Sub(recordedAt) > cache.metadataTTL
+}
+
+// repositoryFingerprint hashes the HEAD commit, the HEAD, index, config, packed-refs, and loose-ref
+// modification times, and the origin URL. Worktree edits are not part of it; callers re-read status.
+func (service *Service) repositoryFingerprint(executionContext context.Context, repositoryPath string) (string, bool) {
+	directoriesResult, directoriesError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitRevParseSubcommandConstant, gitAbsoluteGitDirFlagConstant, gitCommonDirFlagConstant},
+		WorkingDirectory: repositoryPath,
+	})
+	if directoriesError != nil {
+		return "", false
+	}
+	directories := strings.Fields(directoriesResult.StandardOutput)
+	if len(directories) != 2 {
+		return "", false
+	}
+	gitDirectory := directories[0]
+	commonDirectory := directories[1]
+	if !filepath.IsAbs(commonDirectory) {
+		commonDirectory = filepath.Join(repositoryPath, commonDirectory)
+	}
+
+	headRevision := ""
+	headResult, headError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitRevParseSubcommandConstant, gitVerifyFlagConstant, gitQuietFlagConstant, gitHeadReferenceConstant},
+		WorkingDirectory: repositoryPath,
+	})
+	if headError == nil {
+		headRevision = strings.TrimSpace(headResult.StandardOutput)
+	}
+
+	originURL := ""
+	if service.gitManager != nil {
+		if remoteURL, remoteError := service.gitManager.GetRemoteURL(executionContext, repositoryPath, shared.OriginRemoteNameConstant); remoteError == nil {
+			originURL = strings.TrimSpace(remoteURL)
+		}
+	}
+
+	fields := []string{
+		repositoryPath,
+		headRevision,
+		originURL,
+		fileStamp(filepath.Join(gitDirectory, gitHeadFileName)),
+		fileStamp(filepath.Join(gitDirectory, gitIndexFileName)),
+		fileStamp(filepath.Join(commonDirectory, gitConfigFileName)),
+		fileStamp(filepath.Join(commonDirectory, gitPackedRefsFileName)),
+		treeStamp(filepath.Join(commonDirectory, gitRefsDirectoryName)),
+	}
+	digest := sha256.Sum256([]byte(strings.Join(fields, fingerprintFieldSeparator)))
+	return hex.EncodeToString(digest[:]), true
+}
+
+func fileStamp(path string) string {
+	info, statError := os.Stat(path)
+	if statError != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", info.ModTime().UnixNano(), info.Size())
+}
+
+// treeStamp summarizes a directory tree by entry count and latest modification time,
+// which changes whenever git writes, renames, or deletes a loose ref.
+func treeStamp(root string) string {
+	entries := 0
+	var latest int64
+	_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, walkError error) error {
+		if walkError != nil {
+			return nil
+		}
+		info, infoError := entry.Info()
+		if infoError != nil {
+			return nil
+		}
+		entries++
+		if modified := info.ModTime().UnixNano(); modified > latest {
+			latest = modified
+		}
+		return nil
+	})
+	return fmt.Sprintf("%d:%d", entries, latest)
+}
@@ -0,0 +1,296 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/audit"
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/githubcli"
+)
+
+type countingGitManager struct {
+	stubGitManager
+	branchLookups *int
+}
+
+func (manager countingGitManager) GetCurrentBranch(ctx context.Context, repositoryPath string) (string, error) {
+	*manager.branchLookups++
+	return manager.stubGitManager.GetCurrentBranch(ctx, repositoryPath)
+}
+
+type countingGitHubResolver struct {
+	metadata githubcli.RepositoryMetadata
+	calls    *int
+}
+
+func (resolver countingGitHubResolver) ResolveRepoMetadata(ctx context.Context, repository string) (githubcli.RepositoryMetadata, error) {
+	*resolver.calls++
+	return resolver.metadata, nil
+}
+
+// remoteAdvancingGitExecutor serves origin/main from a remote-tracking ref that each fetch moves to remoteRevision,
+// rewriting the loose ref file the way git does.
+type remoteAdvancingGitExecutor struct {
+	stubGitExecutor
+	gitDirectory    string
+	remoteRevision  *string
+	trackedRevision *string
+	fetches         *int
+}
+
+func (executor remoteAdvancingGitExecutor) ExecuteGit(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	switch strings.Join(details.Arguments, " ") {
+	case "fetch -q --no-tags --no-recurse-submodules origin main":
+		*executor.fetches++
+		if *executor.trackedRevision != *executor.remoteRevision {
+			*executor.trackedRevision = *executor.remoteRevision
+			if writeError := writeCacheTestRemoteReference(executor.gitDirectory, *executor.trackedRevision, time.Now().Add(time.Duration(*executor.fetches)*time.Minute)); writeError != nil {
+				return execshell.ExecutionResult{}, writeError
+			}
+		}
+		return execshell.ExecutionResult{}, nil
+	case "rev-parse refs/remotes/origin/main":
+		return execshell.ExecutionResult{StandardOutput: *executor.trackedRevision + "\n"}, nil
+	}
+	return executor.stubGitExecutor.ExecuteGit(executionContext, details)
+}
+
+func writeCacheTestRemoteReference(gitDirectory string, revision string, modifiedAt time.Time) error {
+	referencePath := filepath.Join(gitDirectory, "refs", "remotes", "origin", "main")
+	if directoryError := os.MkdirAll(filepath.Dir(referencePath), 0o755); directoryError != nil {
+		return directoryError
+	}
+	if writeError := os.WriteFile(referencePath, []byte(revision+"\n"), 0o644); writeError != nil {
+		return writeError
+	}
+	return os.Chtimes(referencePath, modifiedAt, modifiedAt)
+}
+
+func newCacheTestGitDirectory(testInstance *testing.T) string {
+	testInstance.Helper()
+	gitDirectory := testInstance.TempDir()
+	require.NoError(testInstance, os.WriteFile(filepath.Join(gitDirectory, "HEAD"), []byte("ref: refs/heads/main\n"), 0o644))
+	require.NoError(testInstance, os.WriteFile(filepath.Join(gitDirectory, "index"), []byte("index"), 0o644))
+	require.NoError(testInstance, os.MkdirAll(filepath.Join(gitDirectory, "refs", "heads"), 0o755))
+	require.NoError(testInstance, os.WriteFile(filepath.Join(gitDirectory, "refs", "heads", "main"), []byte("abc\n"), 0o644))
+	return gitDirectory
+}
+
+func TestInspectionCacheSkipsUnchangedRepositories(testInstance *testing.T) {
+	repositoryPath := testInstance.TempDir()
+	gitDirectory := newCacheTestGitDirectory(testInstance)
+
+	executor := stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+		"rev-parse --is-inside-work-tree":               {StandardOutput: "true"},
+		"rev-parse --absolute-git-dir --git-common-dir": {StandardOutput: gitDirectory + "\n" + gitDirectory + "\n"},
+		"rev-parse --verify -q HEAD":                    {StandardOutput: "abc\n"},
+	}}
+	branchLookups := 0
+	metadataCalls := 0
+	resolver := countingGitHubResolver{
+		metadata: githubcli.RepositoryMetadata{NameWithOwner: "canonical/example", DefaultBranch: "main"},
+		calls:    &metadataCalls,
+	}
+
+	cachePath := filepath.Join(testInstance.TempDir(), "gix", "audit-inspections.json")
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	inspect := func(cache *audit.InspectionCache, worktreeEntries []string) audit.RepositoryInspection {
+		manager := countingGitManager{
+			stubGitManager: stubGitManager{
+				cleanWorktree:   len(worktreeEntries) == 0,
+				worktreeEntries: worktreeEntries,
+				branchName:      "main",
+				remoteURL:       "https://github.com/origin/example.git",
+			},
+			branchLookups: &branchLookups,
+		}
+		service := audit.NewService(stubDiscoverer{repositories: []string{repositoryPath}}, manager, executor, resolver, io.Discard, io.Discard)
+		service.SetInspectionCache(cache)
+		inspections, inspectionError := service.DiscoverInspections(context.Background(), []string{repositoryPath}, false, false, audit.InspectionDepthFull)
+		require.NoError(testInstance, inspectionError)
+		require.Len(testInstance, inspections, 1)
+		return inspections[0]
+	}
+	newCache := func() *audit.InspectionCache {
+		return audit.NewInspectionCache(cachePath, audit.InspectionCacheOptions{MetadataTTL: time.Hour, Clock: clock})
+	}
+
+	first := inspect(newCache(), nil)
+	require.Equal(testInstance, "canonical/example", first.CanonicalOwnerRepo)
+	require.Equal(testInstance, 1, branchLookups)
+	require.Equal(testInstance, 1, metadataCalls)
+	require.FileExists(testInstance, cachePath)
+
+	cached := inspect(newCache(), []string{"M main.go"})
+	require.Equal(testInstance, "canonical/example", cached.CanonicalOwnerRepo)
+	require.Equal(testInstance, []string{"M main.go"}, cached.WorktreeDirtyFiles)
+	require.Equal(testInstance, 1, branchLookups)
+	require.Equal(testInstance, 1, metadataCalls)
+
+	require.NoError(testInstance, os.WriteFile(filepath.Join(gitDirectory, "index"), []byte("index updated"), 0o644))
+	inspect(newCache(), nil)
+	require.Equal(testInstance, 2, branchLookups)
+	require.Equal(testInstance, 1, metadataCalls)
+
+	now = now.Add(time.Minute)
+	inspect(newCache().WithRefresh(), nil)
+	require.Equal(testInstance, 3, branchLookups)
+	require.Equal(testInstance, 2, metadataCalls)
+
+	inspect(newCache(), nil)
+	require.Equal(testInstance, 3, branchLookups)
+
+	now = now.Add(2 * time.Hour)
+	inspect(newCache(), nil)
+	require.Equal(testInstance, 4, branchLookups)
+	require.Equal(testInstance, 3, metadataCalls)
+}
+
+func TestInspectionCacheIgnoresForeignFiles(testInstance *testing.T) {
+	cachePath := filepath.Join(testInstance.TempDir(), "audit-inspections.json")
+	require.NoError(testInstance, os.WriteFile(cachePath, []byte(`{"schema":"gix.audit-cache/v0"}`), 0o600))
+
+	cache := audit.NewInspectionCache(cachePath, audit.InspectionCacheOptions{})
+	require.NoError(testInstance, cache.Save())
+
+	contents, readError := os.ReadFile(cachePath)
+	require.NoError(testInstance, readError)
+	require.JSONEq(testInstance, `{"schema":"gix.audit-cache/v0"}`, string(contents))
+}
+
+func TestInspectionCacheRecomputesInSyncWhenTheRemoteAdvances(testInstance *testing.T) {
+	repositoryPath := testInstance.TempDir()
+	gitDirectory := newCacheTestGitDirectory(testInstance)
+	remoteRevision := "abc"
+	trackedRevision := "abc"
+	require.NoError(testInstance, writeCacheTestRemoteReference(gitDirectory, trackedRevision, time.Now()))
+
+	fetches := 0
+	executor := remoteAdvancingGitExecutor{
+		stubGitExecutor: stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+			"rev-parse --is-inside-work-tree":               {StandardOutput: "true"},
+			"rev-parse --absolute-git-dir --git-common-dir": {StandardOutput: gitDirectory + "\n" + gitDirectory + "\n"},
+			"rev-parse --verify -q HEAD":                    {StandardOutput: "abc\n"},
+			"rev-parse HEAD":                                {StandardOutput: "abc\n"},
+		}},
+		gitDirectory:    gitDirectory,
+		remoteRevision:  &remoteRevision,
+		trackedRevision: &trackedRevision,
+		fetches:         &fetches,
+	}
+	branchLookups := 0
+	metadataCalls := 0
+	resolver := countingGitHubResolver{
+		metadata: githubcli.RepositoryMetadata{NameWithOwner: "origin/example", DefaultBranch: "main"},
+		calls:    &metadataCalls,
+	}
+	cachePath := filepath.Join(testInstance.TempDir(), "audit-inspections.json")
+
+	inspect := func() audit.RepositoryInspection {
+		manager := countingGitManager{
+			stubGitManager: stubGitManager{cleanWorktree: true, branchName: "main", remoteURL: "git@github.com:origin/example.git"},
+			branchLookups:  &branchLookups,
+		}
+		service := audit.NewService(stubDiscoverer{repositories: []string{repositoryPath}}, manager, executor, resolver, io.Discard, io.Discard)
+		service.SetInspectionCache(audit.NewInspectionCache(cachePath, audit.InspectionCacheOptions{}))
+		inspections, inspectionError := service.DiscoverInspections(context.Background(), []string{repositoryPath}, false, false, audit.InspectionDepthFull)
+		require.NoError(testInstance, inspectionError)
+		require.Len(testInstance, inspections, 1)
+		return inspections[0]
+	}
+
+	require.Equal(testInstance, audit.TernaryValueYes, inspect().InSyncStatus)
+	require.Equal(testInstance, 1, branchLookups)
+	require.Equal(testInstance, 1, fetches)
+
+	require.Equal(testInstance, audit.TernaryValueYes, inspect().InSyncStatus)
+	require.Equal(testInstance, 1, branchLookups)
+	require.Equal(testInstance, 2, fetches)
+
+	remoteRevision = "def"
+	require.Equal(testInstance, audit.TernaryValueNo, inspect().InSyncStatus)
+	require.Equal(testInstance, 2, branchLookups)
+
+	require.Equal(testInstance, audit.TernaryValueNo, inspect().InSyncStatus)
+	require.Equal(testInstance, 2, branchLookups)
+	require.Equal(testInstance, 1, metadataCalls)
+}
+
+func TestInspectionCachePrunesMissingAndUnusedEntriesOnSave(testInstance *testing.T) {
+	repositoryPath := testInstance.TempDir()
+	gitDirectory := newCacheTestGitDirectory(testInstance)
+	unusedPath := testInstance.TempDir()
+	recentlySeenPath := testInstance.TempDir()
+	missingPath := filepath.Join(testInstance.TempDir(), "removed")
+
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	cachePath := filepath.Join(testInstance.TempDir(), "audit-inspections.json")
+	storedDocument := map[string]any{
+		"schema": audit.InspectionCacheSchemaVersion,
+		"repositories": map[string]any{
+			missingPath:      map[string]any{"fingerprint": "missing", "inspected_at": now},
+			unusedPath:       map[string]any{"fingerprint": "unused", "inspected_at": now.Add(-2 * time.Hour)},
+			recentlySeenPath: map[string]any{"fingerprint": "seen", "inspected_at": now.Add(-2 * time.Hour), "seen_at": now.Add(-30 * time.Minute)},
+		},
+		"metadata": map[string]any{
+			"stale/example": map[string]any{"name_with_owner": "stale/example", "resolved_at": now.Add(-2 * time.Hour)},
+			"fresh/example": map[string]any{"name_with_owner": "fresh/example", "resolved_at": now.Add(-10 * time.Minute)},
+		},
+	}
+	storedContents, encodeError := json.Marshal(storedDocument)
+	require.NoError(testInstance, encodeError)
+	require.NoError(testInstance, os.WriteFile(cachePath, storedContents, 0o600))
+
+	executor := stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+		"rev-parse --is-inside-work-tree":               {StandardOutput: "true"},
+		"rev-parse --absolute-git-dir --git-common-dir": {StandardOutput: gitDirectory + "\n" + gitDirectory + "\n"},
+		"rev-parse --verify -q HEAD":                    {StandardOutput: "abc\n"},
+	}}
+	metadataCalls := 0
+	resolver := countingGitHubResolver{
+		metadata: githubcli.RepositoryMetadata{NameWithOwner: "canonical/example", DefaultBranch: "main"},
+		calls:    &metadataCalls,
+	}
+	manager := stubGitManager{cleanWorktree: true, branchName: "main", remoteURL: "https://github.com/origin/example.git"}
+	service := audit.NewService(stubDiscoverer{repositories: []string{repositoryPath}}, manager, executor, resolver, io.Discard, io.Discard)
+	service.SetInspectionCache(audit.NewInspectionCache(cachePath, audit.InspectionCacheOptions{
+		MetadataTTL: time.Hour,
+		Clock:       func() time.Time { return now },
+	}))
+	_, inspectionError := service.DiscoverInspections(context.Background(), []string{repositoryPath}, false, false, audit.InspectionDepthFull)
+	require.NoError(testInstance, inspectionError)
+
+	savedContents, readError := os.ReadFile(cachePath)
+	require.NoError(testInstance, readError)
+	var savedDocument struct {
+		Repositories map[string]json.RawMessage `json:"repositories"`
+		Metadata     map[string]json.RawMessage `json:"metadata"`
+	}
+	require.NoError(testInstance, json.Unmarshal(savedContents, &savedDocument))
+
+	expectedRepositories := []string{repositoryPath, recentlySeenPath}
+	sort.Strings(expectedRepositories)
+	require.Equal(testInstance, expectedRepositories, sortedCacheKeys(savedDocument.Repositories))
+	require.Equal(testInstance, []string{"fresh/example", "origin/example"}, sortedCacheKeys(savedDocument.Metadata))
+}
+
+func sortedCacheKeys(entries map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
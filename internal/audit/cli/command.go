@@ -23,8 +23,24 @@ const (
 	flagIncludeAllNameConstant       = "all"
 	flagIncludeAllDescription        = "Include directories without Git repositories in the audit output"
 	flagFormatNameConstant           = "format"
-	flagFormatDescriptionConstant    = "Audit report format: table, csv, or html"
+	flagFormatDescriptionConstant    = "Audit report format: table, csv, html, json, or ndjson"
+	flagBranchesNameConstant         = "branches"
+	flagBranchesDescription          = "List the local branches behind the no-upstream, upstream-gone, and merged counts"
+	flagPolicyNameConstant           = "policy"
+	flagPolicyDescription            = "Evaluate the rules in a YAML policy file instead of printing the report; violations exit non-zero"
+	flagFixNameConstant              = "fix"
+	flagFixDescription               = "Apply the rename, canonical-remote, and protocol remediations for policy violations"
+	flagRefreshNameConstant          = "refresh"
+	flagRefreshDescription           = "Re-inspect every repository and re-query GitHub metadata instead of reusing the inspection cache"
+	flagSaveNameConstant             = "save"
+	flagSaveDescription              = "Also save the inspections as a JSON snapshot for gix audit diff"
+	flagGitHubNameConstant           = "github"
+	flagGitHubDescription            = "Also query GitHub for archived/disabled state, visibility, default branch protection, Pages, and open PRs"
 	taskNameGenerateAuditReport      = "Generate audit report"
+	taskNameEvaluateAuditPolicy      = "Evaluate audit policy"
+	fixWithoutPolicyErrorMessage     = "--fix requires --policy"
+	saveWithPolicyErrorMessage       = "--save cannot be combined with --policy"
+	githubWithPolicyErrorMessage     = "--github cannot be combined with --policy"
 	missingRootsErrorMessageConstant = "no repository roots provided; specify --roots or configure defaults"
 )
 
@@ -33,6 +49,12 @@ type commandOptions struct {
 	includeAllFolders bool
 	repositoryRoots   []string
 	reportFormat      audit.ReportFormat
+	branchListing     bool
+	policyPath        string
+	fix               bool
+	refresh           bool
+	snapshotPath      string
+	githubHealth      bool
 }
 
 // LoggerProvider yields a zap logger for command execution.
@@ -48,6 +70,9 @@ type CommandBuilder struct {
 	HumanReadableLoggingProvider func() bool
 	ConfigurationProvider        func() audit.CommandConfiguration
 	TaskRunnerFactory            func(workflow.Dependencies) TaskRunnerExecutor
+	// InspectionCachePathProvider locates the inspection cache; the cache is disabled when it fails.
+	// Defaults to audit.DefaultInspectionCachePath.
+	InspectionCachePathProvider func() (string, error)
 }
 
 // Build constructs the audit command.
@@ -63,6 +88,13 @@ func (builder *CommandBuilder) Build() (*cobra.Command, error) {
 	command.Flags().StringSlice(flagRootNameConstant, nil, flagRootDescriptionConstant)
 	flagutils.AddToggleFlag(command.Flags(), nil, flagIncludeAllNameConstant, "", false, flagIncludeAllDescription)
 	command.Flags().String(flagFormatNameConstant, string(audit.DefaultReportFormat()), flagFormatDescriptionConstant)
+	flagutils.AddToggleFlag(command.Flags(), nil, flagBranchesNameConstant, "", false, flagBranchesDescription)
+	command.Flags().String(flagPolicyNameConstant, "", flagPolicyDescription)
+	flagutils.AddToggleFlag(command.Flags(), nil, flagFixNameConstant, "", false, flagFixDescription)
+	flagutils.AddToggleFlag(command.Flags(), nil, flagRefreshNameConstant, "", false, flagRefreshDescription)
+	command.Flags().String(flagSaveNameConstant, "", flagSaveDescription)
+	flagutils.AddToggleFlag(command.Flags(), nil, flagGitHubNameConstant, "", false, flagGitHubDescription)
+	command.AddCommand(builder.buildDiffCommand())
 
 	return command, nil
 }
@@ -92,7 +124,7 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 			Command:         command,
 			Output:          command.OutOrStdout(),
 			Errors:          command.ErrOrStderr(),
-			DisablePrompter: true,
+			DisablePrompter: !options.fix,
 		},
 	)
 	if dependencyError != nil {
@@ -100,23 +132,47 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 	}
 
 	dependencyResult.Workflow.DisableWorkflowLogging = true
+	if len(options.policyPath) > 0 {
+		dependencyResult.Workflow.SuppressOperationFailureOutput = true
+	}
+	dependencyResult.Workflow.InspectionCache = builder.resolveInspectionCache(options.refresh)
 
 	taskRunner := resolveTaskRunner(builder.TaskRunnerFactory, dependencyResult.Workflow)
 
-	actionOptions := workflow.AuditReportActionOptions{
-		IncludeAll: options.includeAllFolders,
-		Debug:      options.debugOutput,
-		Depth:      audit.InspectionDepthFull,
-		Format:     options.reportFormat,
-	}.Options()
+	inspectionDepth := audit.InspectionDepthFull
+	if options.githubHealth {
+		inspectionDepth = audit.InspectionDepthGitHub
+	}
 
 	taskDefinition := workflow.TaskDefinition{
 		Name:        taskNameGenerateAuditReport,
 		EnsureClean: false,
 		Actions: []workflow.TaskActionDefinition{
-			{Type: "audit.report", Options: actionOptions},
+			{
+				Type: workflow.TaskActionAuditReportType,
+				Options: workflow.AuditReportActionOptions{
+					IncludeAll:    options.includeAllFolders,
+					Debug:         options.debugOutput,
+					Depth:         inspectionDepth,
+					Format:        options.reportFormat,
+					BranchListing: options.branchListing,
+					SnapshotPath:  options.snapshotPath,
+				}.Options(),
+			},
 		},
 	}
+	if len(options.policyPath) > 0 {
+		taskDefinition = workflow.TaskDefinition{
+			Name:        taskNameEvaluateAuditPolicy,
+			EnsureClean: false,
+			Actions: []workflow.TaskActionDefinition{
+				{
+					Type:    workflow.TaskActionAuditPolicyType,
+					Options: workflow.AuditPolicyActionOptions{PolicyPath: options.policyPath, Fix: options.fix}.Options(),
+				},
+			},
+		}
+	}
 
 	runtimeOptions := workflow.RuntimeOptions{AssumeYes: assumeYes}
 
@@ -167,6 +223,80 @@ func (builder *CommandBuilder) parseOptions(command *cobra.Command) (commandOpti
 		reportFormat = parsedFormat
 	}
 
+	branchListing := false
+	if command != nil {
+		branchListingValue, branchListingChanged, branchListingError := flagutils.BoolFlag(command, flagBranchesNameConstant)
+		if branchListingError != nil && !errors.Is(branchListingError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, branchListingError
+		}
+		if branchListingChanged {
+			branchListing = branchListingValue
+		}
+	}
+
+	policyPath := ""
+	fix := false
+	if command != nil {
+		policyValue, policyError := command.Flags().GetString(flagPolicyNameConstant)
+		if policyError != nil {
+			return commandOptions{}, policyError
+		}
+		policyPath = strings.TrimSpace(policyValue)
+		if len(policyPath) > 0 {
+			if _, loadError := audit.LoadPolicy(policyPath); loadError != nil {
+				return commandOptions{}, loadError
+			}
+		}
+
+		fixValue, fixChanged, fixError := flagutils.BoolFlag(command, flagFixNameConstant)
+		if fixError != nil && !errors.Is(fixError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, fixError
+		}
+		if fixChanged {
+			fix = fixValue
+		}
+		if fix && len(policyPath) == 0 {
+			return commandOptions{}, errors.New(fixWithoutPolicyErrorMessage)
+		}
+	}
+
+	refresh := false
+	if command != nil {
+		refreshValue, refreshChanged, refreshError := flagutils.BoolFlag(command, flagRefreshNameConstant)
+		if refreshError != nil && !errors.Is(refreshError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, refreshError
+		}
+		if refreshChanged {
+			refresh = refreshValue
+		}
+	}
+
+	snapshotPath := ""
+	if command != nil {
+		saveValue, saveError := command.Flags().GetString(flagSaveNameConstant)
+		if saveError != nil {
+			return commandOptions{}, saveError
+		}
+		snapshotPath = strings.TrimSpace(saveValue)
+		if len(snapshotPath) > 0 && len(policyPath) > 0 {
+			return commandOptions{}, errors.New(saveWithPolicyErrorMessage)
+		}
+	}
+
+	githubHealth := false
+	if command != nil {
+		githubValue, githubChanged, githubError := flagutils.BoolFlag(command, flagGitHubNameConstant)
+		if githubError != nil && !errors.Is(githubError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, githubError
+		}
+		if githubChanged {
+			githubHealth = githubValue
+		}
+		if githubHealth && len(policyPath) > 0 {
+			return commandOptions{}, errors.New(githubWithPolicyErrorMessage)
+		}
+	}
+
 	if len(repositoryRoots) == 0 {
 		if command != nil {
 			_ = command.Help()
@@ -179,9 +309,31 @@ func (builder *CommandBuilder) parseOptions(command *cobra.Command) (commandOpti
 		includeAllFolders: includeAll,
 		debugOutput:       debugMode,
 		reportFormat:      reportFormat,
+		branchListing:     branchListing,
+		policyPath:        policyPath,
+		fix:               fix,
+		refresh:           refresh,
+		snapshotPath:      snapshotPath,
+		githubHealth:      githubHealth,
 	}, nil
 }
 
+func (builder *CommandBuilder) resolveInspectionCache(refresh bool) *audit.InspectionCache {
+	pathProvider := builder.InspectionCachePathProvider
+	if pathProvider == nil {
+		pathProvider = audit.DefaultInspectionCachePath
+	}
+	cachePath, cachePathError := pathProvider()
+	if cachePathError != nil || len(cachePath) == 0 {
+		return nil
+	}
+	cache := audit.NewInspectionCache(cachePath, audit.InspectionCacheOptions{})
+	if refresh {
+		return cache.WithRefresh()
+	}
+	return cache
+}
+
 func (builder *CommandBuilder) resolveConfiguration() audit.CommandConfiguration {
 	if builder.ConfigurationProvider == nil {
 		return audit.DefaultCommandConfiguration()
@@ -2,6 +2,8 @@ package cli_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -20,10 +22,22 @@ const (
 	rootFlagArgumentConstant       = "--" + flagutils.DefaultRootFlagName
 	includeAllFlagArgumentConstant = "--all"
 	formatFlagArgumentConstant     = "--format"
+	branchesFlagArgumentConstant   = "--branches"
+	policyFlagArgumentConstant     = "--policy"
+	fixFlagArgumentConstant        = "--fix"
+	refreshFlagArgumentConstant    = "--refresh"
+	saveFlagArgumentConstant       = "--save"
+	githubFlagArgumentConstant     = "--github"
 )
 
 var boundRootFlagValues []*flagutils.RootFlagValues
 
+// temporaryInspectionCachePath keeps command tests from writing the user's inspection cache.
+func temporaryInspectionCachePath(t *testing.T) func() (string, error) {
+	cachePath := filepath.Join(t.TempDir(), "audit-inspections.json")
+	return func() (string, error) { return cachePath, nil }
+}
+
 func TestCommandBuildsAuditTaskFromConfiguration(t *testing.T) {
 	t.Helper()
 
@@ -34,10 +48,11 @@ func TestCommandBuildsAuditTaskFromConfiguration(t *testing.T) {
 	runner := &recordingTaskRunner{}
 
 	builder := cli.CommandBuilder{
-		LoggerProvider: func() *zap.Logger { return zap.NewNop() },
-		Discoverer:     discoverer,
-		GitExecutor:    executor,
-		GitManager:     manager,
+		InspectionCachePathProvider: temporaryInspectionCachePath(t),
+		LoggerProvider:              func() *zap.Logger { return zap.NewNop() },
+		Discoverer:                  discoverer,
+		GitExecutor:                 executor,
+		GitManager:                  manager,
 		ConfigurationProvider: func() audit.CommandConfiguration {
 			return audit.CommandConfiguration{Roots: []string{root}}
 		},
@@ -61,6 +76,8 @@ func TestCommandBuildsAuditTaskFromConfiguration(t *testing.T) {
 	require.Equal(t, false, action.Options["include_all"])
 	require.Equal(t, false, action.Options["debug"])
 	require.Equal(t, "table", action.Options["format"])
+	require.Equal(t, "full", action.Options["depth"])
+	require.NotContains(t, action.Options, "branch_listing")
 }
 
 func TestCommandFlagsOverrideConfiguration(t *testing.T) {
@@ -75,10 +92,11 @@ func TestCommandFlagsOverrideConfiguration(t *testing.T) {
 	runner := &recordingTaskRunner{}
 
 	builder := cli.CommandBuilder{
-		LoggerProvider: func() *zap.Logger { return zap.NewNop() },
-		Discoverer:     discoverer,
-		GitExecutor:    executor,
-		GitManager:     manager,
+		InspectionCachePathProvider: temporaryInspectionCachePath(t),
+		LoggerProvider:              func() *zap.Logger { return zap.NewNop() },
+		Discoverer:                  discoverer,
+		GitExecutor:                 executor,
+		GitManager:                  manager,
 		ConfigurationProvider: func() audit.CommandConfiguration {
 			return audit.CommandConfiguration{Roots: []string{configuredRoot}}
 		},
@@ -94,6 +112,9 @@ func TestCommandFlagsOverrideConfiguration(t *testing.T) {
 		rootFlagArgumentConstant, flagRoot,
 		includeAllFlagArgumentConstant,
 		formatFlagArgumentConstant, "csv",
+		branchesFlagArgumentConstant,
+		saveFlagArgumentConstant, "snapshots/fleet.json",
+		githubFlagArgumentConstant,
 	})
 
 	executionError := command.Execute()
@@ -104,6 +125,43 @@ func TestCommandFlagsOverrideConfiguration(t *testing.T) {
 	require.Equal(t, "audit.report", action.Type)
 	require.Equal(t, true, action.Options["include_all"])
 	require.Equal(t, "csv", action.Options["format"])
+	require.Equal(t, true, action.Options["branch_listing"])
+	require.Equal(t, "snapshots/fleet.json", action.Options["save"])
+	require.Equal(t, "github", action.Options["depth"])
+}
+
+func TestDiffCommandComparesSnapshots(t *testing.T) {
+	directory := t.TempDir()
+	previousPath := filepath.Join(directory, "previous.json")
+	currentPath := filepath.Join(directory, "current.json")
+	require.NoError(t, os.WriteFile(previousPath, []byte(`{"schema":"gix.audit-report/v1","repositories":[{"path":"/repos/legacy","final_owner_repo":"owner/example","remote_default_branch":"main"}]}`), 0o644))
+	require.NoError(t, os.WriteFile(currentPath, []byte(`{"schema":"gix.audit-report/v1","repositories":[{"path":"/repos/example","final_owner_repo":"owner/example","remote_default_branch":"trunk"}]}`), 0o644))
+
+	runner := &recordingTaskRunner{}
+	builder := cli.CommandBuilder{
+		InspectionCachePathProvider: temporaryInspectionCachePath(t),
+		LoggerProvider:              func() *zap.Logger { return zap.NewNop() },
+		TaskRunnerFactory:           func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+	}
+	command, buildError := builder.Build()
+	require.NoError(t, buildError)
+	bindRootAndExecutionFlags(command)
+
+	output := &strings.Builder{}
+	command.SetContext(context.Background())
+	command.SetOut(output)
+	command.SetErr(&strings.Builder{})
+	command.SetArgs([]string{"diff", previousPath, currentPath, formatFlagArgumentConstant, "json"})
+
+	require.NoError(t, command.Execute())
+	require.Empty(t, runner.definitions)
+	require.JSONEq(t, `{"schema":"gix.audit-diff/v1","changes":[
+		{"kind":"renamed","path":"/repos/example","previous":"/repos/legacy","current":"/repos/example"},
+		{"kind":"default_branch_changed","path":"/repos/example","previous":"main","current":"trunk"}
+	]}`, output.String())
+
+	command.SetArgs([]string{"diff", previousPath})
+	require.ErrorContains(t, command.Execute(), "accepts 2 arg(s)")
 }
 
 func TestCommandRejectsUnsupportedReportFormat(t *testing.T) {
@@ -111,7 +169,8 @@ func TestCommandRejectsUnsupportedReportFormat(t *testing.T) {
 
 	root := "/tmp/audit-root"
 	builder := cli.CommandBuilder{
-		LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+		InspectionCachePathProvider: temporaryInspectionCachePath(t),
+		LoggerProvider:              func() *zap.Logger { return zap.NewNop() },
 		ConfigurationProvider: func() audit.CommandConfiguration {
 			return audit.CommandConfiguration{Roots: []string{root}}
 		},
@@ -122,19 +181,218 @@ func TestCommandRejectsUnsupportedReportFormat(t *testing.T) {
 	bindRootAndExecutionFlags(command)
 
 	command.SetContext(context.Background())
-	command.SetArgs([]string{formatFlagArgumentConstant, "json"})
+	command.SetArgs([]string{formatFlagArgumentConstant, "xml"})
 
 	executionError := command.Execute()
 	require.Error(t, executionError)
-	require.Contains(t, executionError.Error(), "unsupported audit report format \"json\"")
+	require.Contains(t, executionError.Error(), "unsupported audit report format \"xml\"")
+}
+
+func TestCommandBuildsAuditPolicyTask(t *testing.T) {
+	root := "/tmp/audit-root"
+	policyPath := filepath.Join(t.TempDir(), "policy.yml")
+	require.NoError(t, os.WriteFile(policyPath, []byte("rules:\n  - type: remote_protocol\n    protocol: ssh\n"), 0o644))
+
+	testCases := []struct {
+		name          string
+		arguments     []string
+		expectedFix   bool
+		expectedError string
+	}{
+		{
+			name:        "evaluate",
+			arguments:   []string{policyFlagArgumentConstant, policyPath},
+			expectedFix: false,
+		},
+		{
+			name:        "fix",
+			arguments:   []string{policyFlagArgumentConstant, policyPath, fixFlagArgumentConstant},
+			expectedFix: true,
+		},
+		{
+			name:          "fix_without_policy",
+			arguments:     []string{fixFlagArgumentConstant},
+			expectedError: "--fix requires --policy",
+		},
+		{
+			name:          "save_with_policy",
+			arguments:     []string{policyFlagArgumentConstant, policyPath, saveFlagArgumentConstant, "snapshot.json"},
+			expectedError: "--save cannot be combined with --policy",
+		},
+		{
+			name:          "github_with_policy",
+			arguments:     []string{policyFlagArgumentConstant, policyPath, githubFlagArgumentConstant},
+			expectedError: "--github cannot be combined with --policy",
+		},
+		{
+			name:          "missing_policy_file",
+			arguments:     []string{policyFlagArgumentConstant, filepath.Join(t.TempDir(), "absent.yml")},
+			expectedError: "read audit policy",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := cli.CommandBuilder{
+				InspectionCachePathProvider: temporaryInspectionCachePath(t),
+				LoggerProvider:              func() *zap.Logger { return zap.NewNop() },
+				Discoverer:                  &fakeRepositoryDiscoverer{repositories: []string{root}},
+				GitExecutor:                 &stubGitExecutor{},
+				GitManager:                  stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return audit.CommandConfiguration{Roots: []string{root}}
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(t, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+			command.SetOut(&strings.Builder{})
+			command.SetErr(&strings.Builder{})
+
+			executionError := command.Execute()
+			if len(testCase.expectedError) > 0 {
+				require.Error(t, executionError)
+				require.Contains(t, executionError.Error(), testCase.expectedError)
+				require.Empty(t, runner.definitions)
+				return
+			}
+			require.NoError(t, executionError)
+			require.Len(t, runner.definitions, 1)
+			require.Len(t, runner.definitions[0].Actions, 1)
+			action := runner.definitions[0].Actions[0]
+			require.Equal(t, "audit.policy", action.Type)
+			require.Equal(t, policyPath, action.Options["policy"])
+			require.Equal(t, testCase.expectedFix, action.Options["fix"])
+		})
+	}
+}
+
+func TestCommandSuppressesOperationFailureOutputOnlyForPolicy(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "policy.yml")
+	require.NoError(t, os.WriteFile(policyPath, []byte("rules:\n  - type: remote_protocol\n    protocol: ssh\n"), 0o644))
+
+	testCases := []struct {
+		name             string
+		arguments        []string
+		expectedSuppress bool
+	}{
+		{
+			name: "report",
+		},
+		{
+			name:             "policy",
+			arguments:        []string{policyFlagArgumentConstant, policyPath},
+			expectedSuppress: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			runner := &recordingTaskRunner{}
+			var capturedDependencies workflow.Dependencies
+			builder := cli.CommandBuilder{
+				InspectionCachePathProvider: temporaryInspectionCachePath(t),
+				LoggerProvider:              func() *zap.Logger { return zap.NewNop() },
+				Discoverer:                  &fakeRepositoryDiscoverer{repositories: []string{"/tmp/audit-root"}},
+				GitExecutor:                 &stubGitExecutor{},
+				GitManager:                  stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return audit.CommandConfiguration{Roots: []string{"/tmp/audit-root"}}
+				},
+				TaskRunnerFactory: func(dependencies workflow.Dependencies) cli.TaskRunnerExecutor {
+					capturedDependencies = dependencies
+					return runner
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(t, buildError)
+			bindRootAndExecutionFlags(command)
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+			command.SetOut(&strings.Builder{})
+			command.SetErr(&strings.Builder{})
+
+			require.NoError(t, command.Execute())
+			require.Len(t, runner.definitions, 1)
+			require.Equal(t, testCase.expectedSuppress, capturedDependencies.SuppressOperationFailureOutput)
+		})
+	}
+}
+
+func TestCommandConfiguresInspectionCache(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pathProvider  func() (string, error)
+		arguments     []string
+		expectedCache bool
+	}{
+		{
+			name:          "cache_under_provided_path",
+			pathProvider:  func() (string, error) { return filepath.Join(t.TempDir(), "audit-inspections.json"), nil },
+			expectedCache: true,
+		},
+		{
+			name:          "refresh_keeps_cache_for_fresh_results",
+			pathProvider:  func() (string, error) { return filepath.Join(t.TempDir(), "audit-inspections.json"), nil },
+			arguments:     []string{refreshFlagArgumentConstant},
+			expectedCache: true,
+		},
+		{
+			name:         "cache_disabled_without_cache_directory",
+			pathProvider: func() (string, error) { return "", os.ErrNotExist },
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			runner := &recordingTaskRunner{}
+			var capturedDependencies workflow.Dependencies
+			builder := cli.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{repositories: []string{"/tmp/audit-root"}},
+				GitExecutor:    &stubGitExecutor{},
+				GitManager:     stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return audit.CommandConfiguration{Roots: []string{"/tmp/audit-root"}}
+				},
+				TaskRunnerFactory: func(dependencies workflow.Dependencies) cli.TaskRunnerExecutor {
+					capturedDependencies = dependencies
+					return runner
+				},
+				InspectionCachePathProvider: testCase.pathProvider,
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(t, buildError)
+			bindRootAndExecutionFlags(command)
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			require.NoError(t, command.Execute())
+			require.Len(t, runner.definitions, 1)
+			if testCase.expectedCache {
+				require.NotNil(t, capturedDependencies.InspectionCache)
+			} else {
+				require.Nil(t, capturedDependencies.InspectionCache)
+			}
+		})
+	}
 }
 
 func TestCommandDisplaysHelpWhenRootsMissing(t *testing.T) {
 	t.Helper()
 
 	builder := cli.CommandBuilder{
-		LoggerProvider:        func() *zap.Logger { return zap.NewNop() },
-		ConfigurationProvider: func() audit.CommandConfiguration { return audit.CommandConfiguration{} },
+		InspectionCachePathProvider: temporaryInspectionCachePath(t),
+		LoggerProvider:              func() *zap.Logger { return zap.NewNop() },
+		ConfigurationProvider:       func() audit.CommandConfiguration { return audit.CommandConfiguration{} },
 	}
 
 	command, buildError := builder.Build()
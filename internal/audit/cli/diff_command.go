@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tyemirov/gix/internal/audit"
+)
+
+const (
+	diffCommandUseConstant              = "diff <old-snapshot> <new-snapshot>"
+	diffCommandShortDescriptionConstant = "Compare two saved audit snapshots"
+	diffCommandLongDescriptionConstant  = "Reports repositories added, removed, or renamed between two snapshots saved with gix audit --save, along with default-branch, remote, and protocol changes and repositories that became dirty or out of sync."
+	diffFormatDescriptionConstant       = "Audit diff format: table, html, or json"
+	diffArgumentCountConstant           = 2
+)
+
+func (builder *CommandBuilder) buildDiffCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   diffCommandUseConstant,
+		Short: diffCommandShortDescriptionConstant,
+		Long:  diffCommandLongDescriptionConstant,
+		Args:  cobra.ExactArgs(diffArgumentCountConstant),
+		RunE:  builder.runDiff,
+	}
+	command.Flags().String(flagFormatNameConstant, string(audit.DefaultReportFormat()), diffFormatDescriptionConstant)
+	return command
+}
+
+func (builder *CommandBuilder) runDiff(command *cobra.Command, arguments []string) error {
+	formatValue, formatError := command.Flags().GetString(flagFormatNameConstant)
+	if formatError != nil {
+		return formatError
+	}
+	reportFormat, parseFormatError := audit.ParseReportFormat(formatValue)
+	if parseFormatError != nil {
+		return parseFormatError
+	}
+
+	previous, previousError := audit.LoadSnapshot(arguments[0])
+	if previousError != nil {
+		return previousError
+	}
+	current, currentError := audit.LoadSnapshot(arguments[1])
+	if currentError != nil {
+		return currentError
+	}
+
+	return audit.WriteDiffReport(command.OutOrStdout(), reportFormat, audit.DiffSnapshots(previous, current))
+}
@@ -12,6 +12,7 @@ const (
 	normalizeRepositoryPathErrorMessageConstant = "failed to normalize repository path"
 	debugDiscoveredTemplate                     = "DEBUG: discovered %d candidate repos under: %s\n"
 	debugCheckingTemplate                       = "DEBUG: checking %s\n"
+	debugCacheSaveFailedTemplate                = "DEBUG: %v\n"
 	csvHeaderFinalRepository                    = "final_github_repo"
 	csvHeaderFolderName                         = "folder_name"
 	csvHeaderOriginRemoteStatus                 = "origin_remote_status"
@@ -23,6 +24,25 @@ const (
 	csvHeaderOriginCanonical                    = "origin_matches_canonical"
 	csvHeaderWorktreeDirty                      = "worktree_dirty"
 	csvHeaderDirtyFiles                         = "dirty_files"
+	csvHeaderUpstreamAhead                      = "upstream_ahead"
+	csvHeaderUpstreamBehind                     = "upstream_behind"
+	csvHeaderDefaultAhead                       = "default_ahead"
+	csvHeaderDefaultBehind                      = "default_behind"
+	csvHeaderNoUpstreamBranches                 = "no_upstream_branches"
+	csvHeaderUpstreamGoneBranches               = "upstream_gone_branches"
+	csvHeaderMergedBranches                     = "merged_branches"
+	csvHeaderBranchListing                      = "branch_listing"
+	csvHeaderArchived                           = "github_archived"
+	csvHeaderDisabled                           = "github_disabled"
+	csvHeaderVisibility                         = "github_visibility"
+	csvHeaderDefaultBranchProtected             = "default_branch_protected"
+	csvHeaderPages                              = "github_pages"
+	csvHeaderOpenPullRequests                   = "open_pull_requests"
+	csvHeaderLocalDefaultBranch                 = "local_default_branch"
+	csvHeaderDefaultBranchMatches               = "default_branch_matches_github"
+	branchListingMergedLabel                    = "merged"
+	branchListingNoUpstreamLabel                = "no upstream"
+	branchListingUpstreamGoneLabel              = "upstream gone"
 	gitIsInsideWorkTreeFlagConstant             = "--is-inside-work-tree"
 	gitTrueOutputConstant                       = "true"
 	notGitHubRemoteMessageConstant              = "not a github remote"
@@ -1,6 +1,11 @@
 package audit
 
-import "github.com/tyemirov/gix/internal/repos/shared"
+import (
+	"context"
+
+	"github.com/tyemirov/gix/internal/githubcli"
+	"github.com/tyemirov/gix/internal/repos/shared"
+)
 
 // RepositoryDiscoverer finds git repositories rooted under the provided paths.
 type RepositoryDiscoverer = shared.RepositoryDiscoverer
@@ -19,3 +24,12 @@ type ConfirmationPrompter = shared.ConfirmationPrompter
 
 // FileSystem provides filesystem operations required by the audit workflows.
 type FileSystem = shared.FileSystem
+
+// GitHubHealthInspector queries the GitHub-side repository state reported at the github inspection depth.
+// githubcli.Client satisfies it; metadata resolvers that do not are audited without GitHub health.
+type GitHubHealthInspector interface {
+	GetRepositoryStatus(executionContext context.Context, repository string) (githubcli.RepositoryStatus, error)
+	CheckBranchProtection(executionContext context.Context, repository string, branchName string) (bool, error)
+	GetPagesConfig(executionContext context.Context, repository string) (githubcli.PagesStatus, error)
+	ListPullRequests(executionContext context.Context, repository string, options githubcli.PullRequestListOptions) ([]githubcli.PullRequest, error)
+}
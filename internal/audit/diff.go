@@ -0,0 +1,168 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DiffSchemaVersion identifies the JSON audit diff payload layout.
+const DiffSchemaVersion = "gix.audit-diff/v1"
+
+// DiffChangeKind classifies one difference between two audit snapshots.
+type DiffChangeKind string
+
+// Supported audit diff changes.
+const (
+	DiffChangeAdded           DiffChangeKind = "added"
+	DiffChangeRemoved         DiffChangeKind = "removed"
+	DiffChangeRenamed         DiffChangeKind = "renamed"
+	DiffChangeDefaultBranch   DiffChangeKind = "default_branch_changed"
+	DiffChangeRemote          DiffChangeKind = "remote_changed"
+	DiffChangeProtocol        DiffChangeKind = "protocol_changed"
+	DiffChangeBecameDirty     DiffChangeKind = "became_dirty"
+	DiffChangeBecameOutOfSync DiffChangeKind = "became_out_of_sync"
+)
+
+const (
+	snapshotReadErrorTemplate   = "read audit snapshot %s: %w"
+	snapshotDecodeErrorTemplate = "decode audit snapshot %s: %w"
+	snapshotSchemaErrorTemplate = "audit snapshot %s has schema %q; expected %q (save snapshots with gix audit --save)"
+	diffCleanWorktreeValue      = "clean"
+	diffDirtyFilesSeparator     = "; "
+)
+
+// SnapshotDiff lists the changes between two audit snapshots ordered by repository path.
+type SnapshotDiff struct {
+	Schema  string       `json:"schema"`
+	Changes []DiffChange `json:"changes"`
+}
+
+// DiffChange reports one change; Path is the repository path in the newer snapshot except for removals.
+type DiffChange struct {
+	Kind     DiffChangeKind `json:"kind"`
+	Path     string         `json:"path"`
+	Previous string         `json:"previous"`
+	Current  string         `json:"current"`
+}
+
+// LoadSnapshot reads an audit snapshot written by --save or --format json.
+func LoadSnapshot(path string) (JSONReport, error) {
+	contents, readError := os.ReadFile(path)
+	if readError != nil {
+		return JSONReport{}, fmt.Errorf(snapshotReadErrorTemplate, path, readError)
+	}
+	var snapshot JSONReport
+	if decodeError := json.Unmarshal(contents, &snapshot); decodeError != nil {
+		return JSONReport{}, fmt.Errorf(snapshotDecodeErrorTemplate, path, decodeError)
+	}
+	if snapshot.Schema != ReportSchemaVersion {
+		return JSONReport{}, fmt.Errorf(snapshotSchemaErrorTemplate, path, snapshot.Schema, ReportSchemaVersion)
+	}
+	return snapshot, nil
+}
+
+// DiffSnapshots compares two snapshots. A repository that disappeared from one path and appeared at another
+// with the same GitHub repository (or origin URL when no repository resolved) is reported as renamed.
+func DiffSnapshots(previous JSONReport, current JSONReport) SnapshotDiff {
+	previousByPath := make(map[string]InspectionRecord, len(previous.Repositories))
+	for _, record := range previous.Repositories {
+		previousByPath[record.Path] = record
+	}
+	currentByPath := make(map[string]InspectionRecord, len(current.Repositories))
+	for _, record := range current.Repositories {
+		currentByPath[record.Path] = record
+	}
+
+	removedByIdentity := map[string][]InspectionRecord{}
+	for _, record := range sortedRecords(previous.Repositories) {
+		if _, stillPresent := currentByPath[record.Path]; stillPresent {
+			continue
+		}
+		identity := snapshotIdentity(record)
+		removedByIdentity[identity] = append(removedByIdentity[identity], record)
+	}
+
+	changes := []DiffChange{}
+	renamedFrom := map[string]struct{}{}
+	for _, record := range sortedRecords(current.Repositories) {
+		if earlier, existed := previousByPath[record.Path]; existed {
+			changes = append(changes, recordChanges(earlier, record)...)
+			continue
+		}
+		identity := snapshotIdentity(record)
+		if candidates := removedByIdentity[identity]; len(identity) > 0 && len(candidates) > 0 {
+			earlier := candidates[0]
+			removedByIdentity[identity] = candidates[1:]
+			renamedFrom[earlier.Path] = struct{}{}
+			changes = append(changes, DiffChange{Kind: DiffChangeRenamed, Path: record.Path, Previous: earlier.Path, Current: record.Path})
+			changes = append(changes, recordChanges(earlier, record)...)
+			continue
+		}
+		changes = append(changes, DiffChange{Kind: DiffChangeAdded, Path: record.Path, Current: record.FinalOwnerRepo})
+	}
+	for _, record := range sortedRecords(previous.Repositories) {
+		if _, stillPresent := currentByPath[record.Path]; stillPresent {
+			continue
+		}
+		if _, renamed := renamedFrom[record.Path]; renamed {
+			continue
+		}
+		changes = append(changes, DiffChange{Kind: DiffChangeRemoved, Path: record.Path, Previous: record.FinalOwnerRepo})
+	}
+
+	sort.SliceStable(changes, func(first int, second int) bool {
+		return changes[first].Path < changes[second].Path
+	})
+	return SnapshotDiff{Schema: DiffSchemaVersion, Changes: changes}
+}
+
+func recordChanges(previous InspectionRecord, current InspectionRecord) []DiffChange {
+	var changes []DiffChange
+	report := func(kind DiffChangeKind, before string, after string) {
+		changes = append(changes, DiffChange{Kind: kind, Path: current.Path, Previous: before, Current: after})
+	}
+
+	if previous.RemoteDefaultBranch != current.RemoteDefaultBranch {
+		report(DiffChangeDefaultBranch, previous.RemoteDefaultBranch, current.RemoteDefaultBranch)
+	}
+	if previousRemote, currentRemote := snapshotRemote(previous), snapshotRemote(current); previousRemote != currentRemote {
+		report(DiffChangeRemote, previousRemote, currentRemote)
+	}
+	bothConfigured := previous.OriginRemoteStatus == OriginRemoteStatusConfigured && current.OriginRemoteStatus == OriginRemoteStatusConfigured
+	if bothConfigured && previous.RemoteProtocol != current.RemoteProtocol {
+		report(DiffChangeProtocol, string(previous.RemoteProtocol), string(current.RemoteProtocol))
+	}
+	if len(previous.WorktreeDirtyFiles) == 0 && len(current.WorktreeDirtyFiles) > 0 {
+		report(DiffChangeBecameDirty, diffCleanWorktreeValue, strings.Join(current.WorktreeDirtyFiles, diffDirtyFilesSeparator))
+	}
+	if previous.InSync != TernaryValueNo && current.InSync == TernaryValueNo {
+		report(DiffChangeBecameOutOfSync, string(previous.InSync), string(current.InSync))
+	}
+	return changes
+}
+
+// snapshotIdentity names the remote repository behind a record; folders without a remote have no identity.
+func snapshotIdentity(record InspectionRecord) string {
+	if identity := strings.TrimSpace(record.FinalOwnerRepo); len(identity) > 0 {
+		return strings.ToLower(identity)
+	}
+	return strings.TrimSpace(record.OriginURL)
+}
+
+func snapshotRemote(record InspectionRecord) string {
+	if record.OriginRemoteStatus == OriginRemoteStatusConfigured && len(record.OriginOwnerRepo) > 0 {
+		return record.OriginOwnerRepo
+	}
+	return string(record.OriginRemoteStatus)
+}
+
+func sortedRecords(records []InspectionRecord) []InspectionRecord {
+	sorted := append([]InspectionRecord(nil), records...)
+	sort.SliceStable(sorted, func(first int, second int) bool {
+		return sorted[first].Path < sorted[second].Path
+	})
+	return sorted
+}
@@ -0,0 +1,106 @@
+package audit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/audit"
+)
+
+func TestDiffSnapshotsReportsFleetChanges(testInstance *testing.T) {
+	previous := audit.JSONReport{Schema: audit.ReportSchemaVersion, Repositories: []audit.InspectionRecord{
+		snapshotRecord("/repos/alpha", "owner/alpha", audit.RemoteProtocolHTTPS, "main"),
+		snapshotRecord("/repos/legacy", "owner/beta", audit.RemoteProtocolSSH, "main"),
+		snapshotRecord("/repos/gamma", "owner/gamma", audit.RemoteProtocolSSH, "main"),
+		snapshotRecord("/repos/retired", "owner/retired", audit.RemoteProtocolSSH, "main"),
+	}}
+
+	alpha := snapshotRecord("/repos/alpha", "owner/alpha", audit.RemoteProtocolSSH, "trunk")
+	alpha.WorktreeDirtyFiles = []string{"M main.go", "?? notes.md"}
+	alpha.InSync = audit.TernaryValueNo
+	beta := snapshotRecord("/repos/beta", "owner/beta", audit.RemoteProtocolSSH, "main")
+	gamma := snapshotRecord("/repos/gamma", "fork/gamma", audit.RemoteProtocolSSH, "main")
+	delta := snapshotRecord("/repos/delta", "owner/delta", audit.RemoteProtocolSSH, "main")
+	current := audit.JSONReport{Schema: audit.ReportSchemaVersion, Repositories: []audit.InspectionRecord{delta, gamma, beta, alpha}}
+
+	diff := audit.DiffSnapshots(previous, current)
+	require.Equal(testInstance, audit.DiffSchemaVersion, diff.Schema)
+	require.Equal(testInstance, []audit.DiffChange{
+		{Kind: audit.DiffChangeDefaultBranch, Path: "/repos/alpha", Previous: "main", Current: "trunk"},
+		{Kind: audit.DiffChangeProtocol, Path: "/repos/alpha", Previous: "https", Current: "ssh"},
+		{Kind: audit.DiffChangeBecameDirty, Path: "/repos/alpha", Previous: "clean", Current: "M main.go; ?? notes.md"},
+		{Kind: audit.DiffChangeBecameOutOfSync, Path: "/repos/alpha", Previous: "yes", Current: "no"},
+		{Kind: audit.DiffChangeRenamed, Path: "/repos/beta", Previous: "/repos/legacy", Current: "/repos/beta"},
+		{Kind: audit.DiffChangeAdded, Path: "/repos/delta", Current: "owner/delta"},
+		{Kind: audit.DiffChangeRemote, Path: "/repos/gamma", Previous: "owner/gamma", Current: "fork/gamma"},
+		{Kind: audit.DiffChangeRemoved, Path: "/repos/retired", Previous: "owner/retired"},
+	}, diff.Changes)
+
+	require.Empty(testInstance, audit.DiffSnapshots(current, current).Changes)
+}
+
+func TestWriteDiffReportFormats(testInstance *testing.T) {
+	diff := audit.SnapshotDiff{Schema: audit.DiffSchemaVersion, Changes: []audit.DiffChange{
+		{Kind: audit.DiffChangeRenamed, Path: "/repos/beta", Previous: "/repos/legacy", Current: "/repos/beta"},
+	}}
+
+	var table bytes.Buffer
+	require.NoError(testInstance, audit.WriteDiffReport(&table, audit.ReportFormatTable, diff))
+	require.Contains(testInstance, table.String(), "| Change ")
+	require.Contains(testInstance, table.String(), "| renamed ")
+	require.Contains(testInstance, table.String(), "/repos/legacy")
+
+	var document bytes.Buffer
+	require.NoError(testInstance, audit.WriteDiffReport(&document, audit.ReportFormatHTML, diff))
+	require.Contains(testInstance, document.String(), "<title>gix audit diff</title>")
+	require.Contains(testInstance, document.String(), "<td>renamed</td>")
+
+	var encoded bytes.Buffer
+	require.NoError(testInstance, audit.WriteDiffReport(&encoded, audit.ReportFormatJSON, diff))
+	var decoded audit.SnapshotDiff
+	require.NoError(testInstance, json.Unmarshal(encoded.Bytes(), &decoded))
+	require.Equal(testInstance, diff, decoded)
+
+	var empty bytes.Buffer
+	require.NoError(testInstance, audit.WriteDiffReport(&empty, audit.ReportFormatTable, audit.SnapshotDiff{Schema: audit.DiffSchemaVersion}))
+	require.Equal(testInstance, "No changes between snapshots.\n", empty.String())
+
+	require.ErrorContains(testInstance, audit.WriteDiffReport(&bytes.Buffer{}, audit.ReportFormatCSV, diff), "unsupported audit diff format \"csv\"")
+}
+
+func TestLoadSnapshotRequiresReportSchema(testInstance *testing.T) {
+	directory := testInstance.TempDir()
+	snapshotPath := filepath.Join(directory, "snapshot.json")
+	require.NoError(testInstance, os.WriteFile(snapshotPath, []byte(`{"schema":"gix.audit-report/v1","repositories":[{"path":"/repos/alpha"}]}`), 0o644))
+
+	snapshot, loadError := audit.LoadSnapshot(snapshotPath)
+	require.NoError(testInstance, loadError)
+	require.Len(testInstance, snapshot.Repositories, 1)
+
+	foreignPath := filepath.Join(directory, "foreign.json")
+	require.NoError(testInstance, os.WriteFile(foreignPath, []byte(`{"schema":"gix.audit-diff/v1"}`), 0o644))
+	_, foreignError := audit.LoadSnapshot(foreignPath)
+	require.ErrorContains(testInstance, foreignError, "has schema \"gix.audit-diff/v1\"")
+}
+
+func snapshotRecord(path string, ownerRepository string, protocol audit.RemoteProtocolType, defaultBranch string) audit.InspectionRecord {
+	return audit.InspectionRecord{
+		Path:                path,
+		FolderName:          filepath.Base(path),
+		IsGitRepository:     true,
+		OriginURL:           "git@github.com:" + ownerRepository + ".git",
+		OriginOwnerRepo:     ownerRepository,
+		CanonicalOwnerRepo:  ownerRepository,
+		FinalOwnerRepo:      ownerRepository,
+		OriginRemoteStatus:  audit.OriginRemoteStatusConfigured,
+		RemoteProtocol:      protocol,
+		RemoteDefaultBranch: defaultBranch,
+		InSync:              audit.TernaryValueYes,
+		WorktreeDirtyFiles:  []string{},
+	}
+}
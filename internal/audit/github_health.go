@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tyemirov/gix/internal/githubcli"
+)
+
+const (
+	githubPagesDisabledValue       = "disabled"
+	githubPagesLegacyValueTemplate = "legacy %s %s"
+	githubOpenPullRequestLimit     = 1000
+)
+
+// inspectGitHubHealth queries GitHub for archive state, visibility, default branch protection, Pages, and open
+// pull requests, and compares the GitHub default branch with the local origin/HEAD. It requires a client that
+// implements GitHubHealthInspector; otherwise the health is reported as unavailable.
+func (service *Service) inspectGitHubHealth(executionContext context.Context, repositoryPath string, ownerRepository string) GitHubHealth {
+	inspector, supported := service.githubClient.(GitHubHealthInspector)
+	if !supported || len(strings.TrimSpace(ownerRepository)) == 0 {
+		return GitHubHealth{}
+	}
+
+	health := GitHubHealth{
+		Available:              true,
+		Archived:               TernaryValueNotApplicable,
+		Disabled:               TernaryValueNotApplicable,
+		Visibility:             string(TernaryValueNotApplicable),
+		DefaultBranchProtected: TernaryValueNotApplicable,
+		Pages:                  string(TernaryValueNotApplicable),
+		LocalDefaultBranch:     service.resolveRemoteHeadBranch(executionContext, repositoryPath),
+		DefaultBranchMatches:   TernaryValueNotApplicable,
+	}
+
+	if status, statusError := inspector.GetRepositoryStatus(executionContext, ownerRepository); statusError == nil {
+		health.Archived = ternaryFromBool(status.Archived)
+		health.Disabled = ternaryFromBool(status.Disabled)
+		if len(status.Visibility) > 0 {
+			health.Visibility = status.Visibility
+		}
+		health.DefaultBranch = status.DefaultBranch
+	}
+
+	if len(health.DefaultBranch) > 0 {
+		if protected, protectionError := inspector.CheckBranchProtection(executionContext, ownerRepository, health.DefaultBranch); protectionError == nil {
+			health.DefaultBranchProtected = ternaryFromBool(protected)
+		}
+		if len(health.LocalDefaultBranch) > 0 {
+			health.DefaultBranchMatches = ternaryFromBool(health.LocalDefaultBranch == health.DefaultBranch)
+		}
+	}
+
+	if pages, pagesError := inspector.GetPagesConfig(executionContext, ownerRepository); pagesError == nil {
+		health.Pages = pagesValue(pages)
+	}
+
+	pullRequests, pullRequestsError := inspector.ListPullRequests(executionContext, ownerRepository, githubcli.PullRequestListOptions{
+		State:       githubcli.PullRequestStateOpen,
+		ResultLimit: githubOpenPullRequestLimit,
+	})
+	if pullRequestsError == nil {
+		health.OpenPullRequests = len(pullRequests)
+		health.OpenPullRequestsAvailable = true
+	}
+
+	return health
+}
+
+func pagesValue(pages githubcli.PagesStatus) string {
+	if !pages.Enabled {
+		return githubPagesDisabledValue
+	}
+	if pages.BuildType == githubcli.PagesBuildTypeLegacy {
+		return fmt.Sprintf(githubPagesLegacyValueTemplate, pages.SourceBranch, pages.SourcePath)
+	}
+	return string(pages.BuildType)
+}
+
+func ternaryFromBool(value bool) TernaryValue {
+	if value {
+		return TernaryValueYes
+	}
+	return TernaryValueNo
+}
+
+func unavailableGitHubHealth() GitHubHealth {
+	placeholder := string(TernaryValueNotApplicable)
+	return GitHubHealth{
+		Archived:               TernaryValueNotApplicable,
+		Disabled:               TernaryValueNotApplicable,
+		Visibility:             placeholder,
+		DefaultBranchProtected: TernaryValueNotApplicable,
+		Pages:                  placeholder,
+		LocalDefaultBranch:     placeholder,
+		DefaultBranchMatches:   TernaryValueNotApplicable,
+	}
+}
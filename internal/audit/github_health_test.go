@@ -0,0 +1,160 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/audit"
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/githubcli"
+)
+
+type stubGitHubHealthClient struct {
+	stubGitHubResolver
+	status        githubcli.RepositoryStatus
+	protected     bool
+	pages         githubcli.PagesStatus
+	pullRequests  []githubcli.PullRequest
+	pullListError error
+	queried       *[]string
+}
+
+func (client stubGitHubHealthClient) GetRepositoryStatus(ctx context.Context, repository string) (githubcli.RepositoryStatus, error) {
+	*client.queried = append(*client.queried, "status "+repository)
+	return client.status, nil
+}
+
+func (client stubGitHubHealthClient) CheckBranchProtection(ctx context.Context, repository string, branchName string) (bool, error) {
+	*client.queried = append(*client.queried, "protection "+repository+" "+branchName)
+	return client.protected, nil
+}
+
+func (client stubGitHubHealthClient) GetPagesConfig(ctx context.Context, repository string) (githubcli.PagesStatus, error) {
+	*client.queried = append(*client.queried, "pages "+repository)
+	return client.pages, nil
+}
+
+func (client stubGitHubHealthClient) ListPullRequests(ctx context.Context, repository string, options githubcli.PullRequestListOptions) ([]githubcli.PullRequest, error) {
+	*client.queried = append(*client.queried, "pulls "+repository+" "+string(options.State))
+	return client.pullRequests, client.pullListError
+}
+
+func TestServiceRunReportsGitHubHealth(testInstance *testing.T) {
+	newService := func(output *bytes.Buffer, client audit.GitHubMetadataResolver) *audit.Service {
+		return audit.NewService(
+			stubDiscoverer{repositories: []string{"/tmp/example"}},
+			stubGitManager{cleanWorktree: true, branchName: "main", remoteURL: "https://github.com/origin/example.git"},
+			stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+				"rev-parse --is-inside-work-tree":       {StandardOutput: "true"},
+				"symbolic-ref refs/remotes/origin/HEAD": {StandardOutput: "refs/remotes/origin/master\n"},
+			}},
+			client,
+			output,
+			&bytes.Buffer{},
+		)
+	}
+	resolver := stubGitHubResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "canonical/example", DefaultBranch: "main"}}
+
+	testCases := []struct {
+		name            string
+		depth           audit.InspectionDepth
+		pullListError   error
+		expectedHeaders []string
+		expectedValues  []string
+		expectedQueries []string
+	}{
+		{
+			name:            "github_depth",
+			depth:           audit.InspectionDepthGitHub,
+			expectedHeaders: []string{"github_archived", "github_disabled", "github_visibility", "default_branch_protected", "github_pages", "open_pull_requests", "local_default_branch", "default_branch_matches_github"},
+			expectedValues:  []string{"yes", "no", "private", "yes", "legacy gh-pages /docs", "2", "master", "no"},
+			expectedQueries: []string{"status canonical/example", "protection canonical/example main", "pages canonical/example", "pulls canonical/example open"},
+		},
+		{
+			name:            "pull_request_listing_fails",
+			depth:           audit.InspectionDepthGitHub,
+			pullListError:   errors.New("rate limited"),
+			expectedHeaders: []string{"github_archived", "github_disabled", "github_visibility", "default_branch_protected", "github_pages", "open_pull_requests", "local_default_branch", "default_branch_matches_github"},
+			expectedValues:  []string{"yes", "no", "private", "yes", "legacy gh-pages /docs", "n/a", "master", "no"},
+			expectedQueries: []string{"status canonical/example", "protection canonical/example main", "pages canonical/example", "pulls canonical/example open"},
+		},
+		{
+			name:            "full_depth_skips_github",
+			depth:           audit.InspectionDepthFull,
+			expectedHeaders: []string{"no_upstream_branches", "upstream_gone_branches", "merged_branches"},
+			expectedValues:  []string{"n/a", "n/a", "n/a"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			var queried []string
+			client := stubGitHubHealthClient{
+				stubGitHubResolver: resolver,
+				status:             githubcli.RepositoryStatus{Archived: true, Visibility: "private", DefaultBranch: "main"},
+				protected:          true,
+				pages:              githubcli.PagesStatus{Enabled: true, BuildType: githubcli.PagesBuildTypeLegacy, SourceBranch: "gh-pages", SourcePath: "/docs"},
+				pullRequests:       []githubcli.PullRequest{{Number: 1}, {Number: 2}},
+				pullListError:      testCase.pullListError,
+				queried:            &queried,
+			}
+
+			output := &bytes.Buffer{}
+			runError := newService(output, client).Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{"/tmp/example"},
+				InspectionDepth: testCase.depth,
+				ReportFormat:    audit.ReportFormatCSV,
+			})
+			require.NoError(testInstance, runError)
+
+			records, parseError := csv.NewReader(output).ReadAll()
+			require.NoError(testInstance, parseError)
+			require.Len(testInstance, records, 2)
+			tailStart := len(records[0]) - len(testCase.expectedHeaders)
+			require.Equal(testInstance, testCase.expectedHeaders, records[0][tailStart:])
+			require.Equal(testInstance, testCase.expectedValues, records[1][tailStart:])
+			require.Equal(testInstance, testCase.expectedQueries, queried)
+		})
+	}
+
+	testInstance.Run("json_record", func(testInstance *testing.T) {
+		var queried []string
+		client := stubGitHubHealthClient{
+			stubGitHubResolver: resolver,
+			status:             githubcli.RepositoryStatus{Visibility: "public", DefaultBranch: "main"},
+			queried:            &queried,
+		}
+		inspections, inspectionError := newService(&bytes.Buffer{}, client).DiscoverInspections(context.Background(), []string{"/tmp/example"}, false, false, audit.InspectionDepthGitHub)
+		require.NoError(testInstance, inspectionError)
+
+		var encoded bytes.Buffer
+		require.NoError(testInstance, audit.WriteReport(&encoded, audit.ReportOptions{Format: audit.ReportFormatJSON}, inspections))
+		var report struct {
+			Repositories []struct {
+				GitHub json.RawMessage `json:"github"`
+			} `json:"repositories"`
+		}
+		require.NoError(testInstance, json.Unmarshal(encoded.Bytes(), &report))
+		require.Len(testInstance, report.Repositories, 1)
+		require.JSONEq(testInstance, `{"archived":"no","disabled":"no","visibility":"public","default_branch":"main","default_branch_protected":"no","pages":"disabled","open_pull_requests":0,"local_default_branch":"master","default_branch_matches":"no"}`, string(report.Repositories[0].GitHub))
+	})
+
+	testInstance.Run("resolver_without_health_support", func(testInstance *testing.T) {
+		output := &bytes.Buffer{}
+		runError := newService(output, resolver).Run(context.Background(), audit.CommandOptions{
+			Roots:           []string{"/tmp/example"},
+			InspectionDepth: audit.InspectionDepthGitHub,
+			ReportFormat:    audit.ReportFormatCSV,
+		})
+		require.NoError(testInstance, runError)
+		records, parseError := csv.NewReader(output).ReadAll()
+		require.NoError(testInstance, parseError)
+		require.Equal(testInstance, []string{"n/a", "n/a", "n/a", "n/a", "n/a", "n/a", "n/a", "n/a"}, records[1][len(records[1])-8:])
+	})
+}
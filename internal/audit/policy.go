@@ -0,0 +1,183 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyRuleType identifies a declarative audit policy check.
+type PolicyRuleType string
+
+// Supported audit policy rules.
+const (
+	PolicyRuleRemoteProtocol         PolicyRuleType = "remote_protocol"
+	PolicyRuleFolderMatchesCanonical PolicyRuleType = "folder_matches_canonical"
+	PolicyRuleOriginMatchesCanonical PolicyRuleType = "origin_matches_canonical"
+	PolicyRuleCleanDefaultBranch     PolicyRuleType = "clean_default_branch"
+	PolicyRuleRequiredFiles          PolicyRuleType = "required_files"
+)
+
+// PolicyRemediation names the reconciliation action that resolves a violation.
+type PolicyRemediation string
+
+// Supported policy remediations; an empty remediation requires a manual fix.
+const (
+	PolicyRemediationNone            PolicyRemediation = ""
+	PolicyRemediationRenameFolder    PolicyRemediation = "rename_folder"
+	PolicyRemediationCanonicalRemote PolicyRemediation = "canonical_remote"
+	PolicyRemediationConvertProtocol PolicyRemediation = "convert_protocol"
+)
+
+const (
+	policyReadErrorTemplate             = "read audit policy %s: %w"
+	policyDecodeErrorTemplate           = "decode audit policy %s: %w"
+	policyRuleErrorTemplate             = "audit policy %s: rule %d: %w"
+	policyEmptyErrorTemplate            = "audit policy %s defines no rules"
+	policyUnknownRuleErrorTemplate      = "unsupported rule type %q"
+	policyProtocolRequiredMessage       = "remote_protocol requires protocol: git, ssh, or https"
+	policyFilesRequiredMessage          = "required_files requires at least one file"
+	policyProtocolViolationTemplate     = "remote protocol is %s; policy requires %s"
+	policyFolderViolationTemplate       = "folder name %s does not match canonical name %s"
+	policyOriginViolationTemplate       = "origin %s does not match canonical %s"
+	policyCleanDefaultViolationTemplate = "default branch %s has %d uncommitted change(s)"
+	policyRequiredFileViolationTemplate = "required file %s is missing"
+)
+
+// Policy is a set of rules evaluated against every inspected repository.
+type Policy struct {
+	Rules []PolicyRule `yaml:"rules"`
+}
+
+// PolicyRule configures a single check. Protocol applies to remote_protocol and Files to required_files.
+type PolicyRule struct {
+	Type     PolicyRuleType     `yaml:"type"`
+	Protocol RemoteProtocolType `yaml:"protocol,omitempty"`
+	Files    []string           `yaml:"files,omitempty"`
+}
+
+// PolicyViolation reports one failed rule for one repository.
+type PolicyViolation struct {
+	RepositoryPath string
+	Rule           PolicyRule
+	Message        string
+	Remediation    PolicyRemediation
+}
+
+// LoadPolicy reads and validates a YAML policy file.
+func LoadPolicy(path string) (Policy, error) {
+	contents, readError := os.ReadFile(path)
+	if readError != nil {
+		return Policy{}, fmt.Errorf(policyReadErrorTemplate, path, readError)
+	}
+
+	var policy Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(contents))
+	decoder.KnownFields(true)
+	if decodeError := decoder.Decode(&policy); decodeError != nil && !errors.Is(decodeError, io.EOF) {
+		return Policy{}, fmt.Errorf(policyDecodeErrorTemplate, path, decodeError)
+	}
+	if len(policy.Rules) == 0 {
+		return Policy{}, fmt.Errorf(policyEmptyErrorTemplate, path)
+	}
+
+	for ruleIndex := range policy.Rules {
+		sanitized, ruleError := policy.Rules[ruleIndex].sanitize()
+		if ruleError != nil {
+			return Policy{}, fmt.Errorf(policyRuleErrorTemplate, path, ruleIndex+1, ruleError)
+		}
+		policy.Rules[ruleIndex] = sanitized
+	}
+	return policy, nil
+}
+
+func (rule PolicyRule) sanitize() (PolicyRule, error) {
+	sanitized := PolicyRule{Type: PolicyRuleType(strings.ToLower(strings.TrimSpace(string(rule.Type))))}
+	switch sanitized.Type {
+	case PolicyRuleRemoteProtocol:
+		protocol := RemoteProtocolType(strings.ToLower(strings.TrimSpace(string(rule.Protocol))))
+		switch protocol {
+		case RemoteProtocolGit, RemoteProtocolSSH, RemoteProtocolHTTPS:
+			sanitized.Protocol = protocol
+		default:
+			return PolicyRule{}, errors.New(policyProtocolRequiredMessage)
+		}
+	case PolicyRuleRequiredFiles:
+		for _, file := range rule.Files {
+			if trimmed := strings.TrimSpace(file); len(trimmed) > 0 {
+				sanitized.Files = append(sanitized.Files, trimmed)
+			}
+		}
+		if len(sanitized.Files) == 0 {
+			return PolicyRule{}, errors.New(policyFilesRequiredMessage)
+		}
+	case PolicyRuleFolderMatchesCanonical, PolicyRuleOriginMatchesCanonical, PolicyRuleCleanDefaultBranch:
+	default:
+		return PolicyRule{}, fmt.Errorf(policyUnknownRuleErrorTemplate, rule.Type)
+	}
+	return sanitized, nil
+}
+
+// Evaluate returns the violations for one inspection in rule order.
+// Rules that cannot be judged, such as protocol checks without an origin, are skipped.
+func (policy Policy) Evaluate(inspection RepositoryInspection, fileSystem FileSystem) []PolicyViolation {
+	if !inspection.IsGitRepository {
+		return nil
+	}
+
+	var violations []PolicyViolation
+	report := func(rule PolicyRule, remediation PolicyRemediation, message string) {
+		violations = append(violations, PolicyViolation{
+			RepositoryPath: inspection.Path,
+			Rule:           rule,
+			Message:        message,
+			Remediation:    remediation,
+		})
+	}
+
+	for _, rule := range policy.Rules {
+		switch rule.Type {
+		case PolicyRuleRemoteProtocol:
+			if inspection.OriginRemoteStatus != OriginRemoteStatusConfigured || inspection.RemoteProtocol == rule.Protocol {
+				continue
+			}
+			remediation := PolicyRemediationConvertProtocol
+			if inspection.RemoteProtocol == RemoteProtocolOther {
+				remediation = PolicyRemediationNone
+			}
+			report(rule, remediation, fmt.Sprintf(policyProtocolViolationTemplate, inspection.RemoteProtocol, rule.Protocol))
+		case PolicyRuleFolderMatchesCanonical:
+			folderBaseName := filepath.Base(inspection.FolderName)
+			if len(inspection.DesiredFolderName) == 0 || folderBaseName == inspection.DesiredFolderName {
+				continue
+			}
+			report(rule, PolicyRemediationRenameFolder, fmt.Sprintf(policyFolderViolationTemplate, folderBaseName, inspection.DesiredFolderName))
+		case PolicyRuleOriginMatchesCanonical:
+			if inspection.OriginMatchesCanonical != TernaryValueNo {
+				continue
+			}
+			report(rule, PolicyRemediationCanonicalRemote, fmt.Sprintf(policyOriginViolationTemplate, inspection.OriginOwnerRepo, inspection.CanonicalOwnerRepo))
+		case PolicyRuleCleanDefaultBranch:
+			if len(inspection.RemoteDefaultBranch) == 0 || inspection.LocalBranch != inspection.RemoteDefaultBranch || len(inspection.WorktreeDirtyFiles) == 0 {
+				continue
+			}
+			report(rule, PolicyRemediationNone, fmt.Sprintf(policyCleanDefaultViolationTemplate, inspection.LocalBranch, len(inspection.WorktreeDirtyFiles)))
+		case PolicyRuleRequiredFiles:
+			if fileSystem == nil {
+				continue
+			}
+			for _, file := range rule.Files {
+				if _, statError := fileSystem.Stat(filepath.Join(inspection.Path, file)); statError != nil {
+					report(rule, PolicyRemediationNone, fmt.Sprintf(policyRequiredFileViolationTemplate, file))
+				}
+			}
+		}
+	}
+	return violations
+}
@@ -0,0 +1,139 @@
+package audit_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/audit"
+)
+
+func TestLoadPolicyValidatesRules(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		contents      string
+		expectedRules []audit.PolicyRule
+		expectedError string
+	}{
+		{
+			name:     "normalizes_rules",
+			contents: "rules:\n  - type: Remote_Protocol\n    protocol: SSH\n  - type: required_files\n    files: [' LICENSE ', '']\n  - type: clean_default_branch\n",
+			expectedRules: []audit.PolicyRule{
+				{Type: audit.PolicyRuleRemoteProtocol, Protocol: audit.RemoteProtocolSSH},
+				{Type: audit.PolicyRuleRequiredFiles, Files: []string{"LICENSE"}},
+				{Type: audit.PolicyRuleCleanDefaultBranch},
+			},
+		},
+		{name: "empty", contents: "", expectedError: "defines no rules"},
+		{name: "unknown_field", contents: "rules: []\nextra: true\n", expectedError: "field extra not found"},
+		{name: "unknown_rule", contents: "rules:\n  - type: signed_commits\n", expectedError: "rule 1: unsupported rule type \"signed_commits\""},
+		{name: "protocol_missing", contents: "rules:\n  - type: remote_protocol\n", expectedError: "remote_protocol requires protocol"},
+		{name: "files_missing", contents: "rules:\n  - type: required_files\n", expectedError: "required_files requires at least one file"},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			policyPath := filepath.Join(testInstance.TempDir(), "policy.yml")
+			require.NoError(testInstance, os.WriteFile(policyPath, []byte(testCase.contents), 0o644))
+
+			policy, loadError := audit.LoadPolicy(policyPath)
+			if len(testCase.expectedError) > 0 {
+				require.ErrorContains(testInstance, loadError, testCase.expectedError)
+				return
+			}
+			require.NoError(testInstance, loadError)
+			require.Equal(testInstance, testCase.expectedRules, policy.Rules)
+		})
+	}
+}
+
+func TestPolicyEvaluateReportsViolationsWithRemediations(testInstance *testing.T) {
+	policy := audit.Policy{Rules: []audit.PolicyRule{
+		{Type: audit.PolicyRuleRemoteProtocol, Protocol: audit.RemoteProtocolSSH},
+		{Type: audit.PolicyRuleFolderMatchesCanonical},
+		{Type: audit.PolicyRuleOriginMatchesCanonical},
+		{Type: audit.PolicyRuleCleanDefaultBranch},
+		{Type: audit.PolicyRuleRequiredFiles, Files: []string{"LICENSE", "README.md"}},
+	}}
+	fileSystem := policyFileSystem{existing: map[string]bool{"/repos/legacy/README.md": true}}
+
+	violating := audit.RepositoryInspection{
+		Path:                   "/repos/legacy",
+		FolderName:             "legacy",
+		DesiredFolderName:      "example",
+		OriginOwnerRepo:        "origin/example",
+		CanonicalOwnerRepo:     "canonical/example",
+		OriginRemoteStatus:     audit.OriginRemoteStatusConfigured,
+		RemoteProtocol:         audit.RemoteProtocolHTTPS,
+		RemoteDefaultBranch:    "main",
+		LocalBranch:            "main",
+		OriginMatchesCanonical: audit.TernaryValueNo,
+		IsGitRepository:        true,
+		WorktreeDirtyFiles:     []string{" M main.go"},
+	}
+	violations := policy.Evaluate(violating, fileSystem)
+	require.Len(testInstance, violations, 5)
+
+	expected := []struct {
+		rule        audit.PolicyRuleType
+		message     string
+		remediation audit.PolicyRemediation
+	}{
+		{audit.PolicyRuleRemoteProtocol, "remote protocol is https; policy requires ssh", audit.PolicyRemediationConvertProtocol},
+		{audit.PolicyRuleFolderMatchesCanonical, "folder name legacy does not match canonical name example", audit.PolicyRemediationRenameFolder},
+		{audit.PolicyRuleOriginMatchesCanonical, "origin origin/example does not match canonical canonical/example", audit.PolicyRemediationCanonicalRemote},
+		{audit.PolicyRuleCleanDefaultBranch, "default branch main has 1 uncommitted change(s)", audit.PolicyRemediationNone},
+		{audit.PolicyRuleRequiredFiles, "required file LICENSE is missing", audit.PolicyRemediationNone},
+	}
+	for index, expectation := range expected {
+		require.Equal(testInstance, "/repos/legacy", violations[index].RepositoryPath)
+		require.Equal(testInstance, expectation.rule, violations[index].Rule.Type)
+		require.Equal(testInstance, expectation.message, violations[index].Message)
+		require.Equal(testInstance, expectation.remediation, violations[index].Remediation)
+	}
+
+	compliant := violating
+	compliant.FolderName = "example"
+	compliant.RemoteProtocol = audit.RemoteProtocolSSH
+	compliant.OriginMatchesCanonical = audit.TernaryValueYes
+	compliant.LocalBranch = "feature"
+	require.Len(testInstance, policy.Evaluate(compliant, fileSystem), 1)
+
+	nestedCompliant := compliant
+	nestedCompliant.FolderName = "org/example"
+	require.Len(testInstance, policy.Evaluate(nestedCompliant, fileSystem), 1)
+
+	nestedViolating := compliant
+	nestedViolating.FolderName = "org/legacy"
+	nestedViolations := policy.Evaluate(nestedViolating, fileSystem)
+	require.Len(testInstance, nestedViolations, 2)
+	require.Equal(testInstance, audit.PolicyRuleFolderMatchesCanonical, nestedViolations[0].Rule.Type)
+	require.Equal(testInstance, "folder name legacy does not match canonical name example", nestedViolations[0].Message)
+
+	withoutOrigin := compliant
+	withoutOrigin.OriginRemoteStatus = audit.OriginRemoteStatusMissing
+	withoutOrigin.RemoteProtocol = audit.RemoteProtocolOther
+	require.Len(testInstance, policy.Evaluate(withoutOrigin, fileSystem), 1)
+
+	require.Empty(testInstance, policy.Evaluate(audit.RepositoryInspection{Path: "/repos/notes"}, fileSystem))
+}
+
+type policyFileSystem struct {
+	existing map[string]bool
+}
+
+func (fileSystem policyFileSystem) Stat(path string) (fs.FileInfo, error) {
+	if fileSystem.existing[path] {
+		return nil, nil
+	}
+	return nil, fs.ErrNotExist
+}
+
+func (policyFileSystem) Rename(string, string) error                 { return nil }
+func (policyFileSystem) Abs(path string) (string, error)             { return path, nil }
+func (policyFileSystem) MkdirAll(string, fs.FileMode) error          { return nil }
+func (policyFileSystem) ReadFile(string) ([]byte, error)             { return nil, fs.ErrNotExist }
+func (policyFileSystem) WriteFile(string, []byte, fs.FileMode) error { return nil }
@@ -2,10 +2,12 @@ package audit
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"html"
 	"io"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -23,6 +25,8 @@ const (
 	auditTableEllipsisConstant             = "…"
 	auditTableMinimumCellWidthConstant     = 12
 	auditTableNoRowsMessageConstant        = "No repositories found."
+	auditDiffTitleConstant                 = "gix audit diff"
+	auditDiffNoChangesMessageConstant      = "No changes between snapshots."
 )
 
 var auditTableValueReplacer = strings.NewReplacer(
@@ -38,28 +42,46 @@ type auditTableWriterUnwrapper interface {
 }
 
 // WriteReport serializes repository inspections in the requested report format.
-func WriteReport(writer io.Writer, reportFormat ReportFormat, inspections []RepositoryInspection) error {
-	normalizedFormat, formatError := normalizeReportFormat(reportFormat)
+func WriteReport(writer io.Writer, options ReportOptions, inspections []RepositoryInspection) error {
+	normalizedFormat, formatError := normalizeReportFormat(options.Format)
 	if formatError != nil {
 		return formatError
 	}
 
-	rows := make([]AuditReportRow, len(inspections))
+	switch normalizedFormat {
+	case ReportFormatJSON:
+		if writeError := writeJSONReport(writer, inspections); writeError != nil {
+			return fmt.Errorf("write JSON audit report: %w", writeError)
+		}
+		return nil
+	case ReportFormatNDJSON:
+		if writeError := writeNDJSONReport(writer, inspections); writeError != nil {
+			return fmt.Errorf("write NDJSON audit report: %w", writeError)
+		}
+		return nil
+	}
+
+	columnIndexes, columnError := auditReportColumnIndexes(options)
+	if columnError != nil {
+		return columnError
+	}
+	records := make([][]string, len(inspections))
 	for inspectionIndex := range inspections {
-		rows[inspectionIndex] = inspectionReportRow(inspections[inspectionIndex])
+		records[inspectionIndex] = selectAuditReportColumns(auditReportRecord(inspectionReportRow(inspections[inspectionIndex]), options), columnIndexes)
 	}
+	displayHeaders := selectAuditReportColumns(auditReportDisplayHeaders(options), columnIndexes)
 
 	switch normalizedFormat {
 	case ReportFormatTable:
-		if writeError := writeTableReport(writer, rows); writeError != nil {
+		if writeError := writeResponsiveTable(writer, displayHeaders, records); writeError != nil {
 			return fmt.Errorf("write table audit report: %w", writeError)
 		}
 	case ReportFormatCSV:
-		if writeError := writeCSVReport(writer, rows); writeError != nil {
+		if writeError := writeCSVReport(writer, selectAuditReportColumns(auditReportCSVHeaders(options), columnIndexes), records); writeError != nil {
 			return fmt.Errorf("write CSV audit report: %w", writeError)
 		}
 	case ReportFormatHTML:
-		if writeError := writeHTMLReport(writer, rows); writeError != nil {
+		if writeError := writeHTMLTable(writer, auditReportTitleConstant, displayHeaders, records); writeError != nil {
 			return fmt.Errorf("write HTML audit report: %w", writeError)
 		}
 	}
@@ -67,14 +89,55 @@ func WriteReport(writer io.Writer, reportFormat ReportFormat, inspections []Repo
 	return nil
 }
 
-func writeCSVReport(writer io.Writer, rows []AuditReportRow) error {
+// WriteDiffReport serializes a snapshot diff as a table, HTML document, or JSON document.
+func WriteDiffReport(writer io.Writer, format ReportFormat, diff SnapshotDiff) error {
+	normalizedFormat, formatError := normalizeReportFormat(format)
+	if formatError != nil {
+		return formatError
+	}
+
+	records := make([][]string, 0, len(diff.Changes))
+	for _, change := range diff.Changes {
+		records = append(records, []string{string(change.Kind), change.Path, change.Previous, change.Current})
+	}
+	headers := []string{"Change", "Repository", "Previous", "Current"}
+
+	switch normalizedFormat {
+	case ReportFormatTable:
+		if len(records) == 0 {
+			_, writeError := fmt.Fprintln(writer, auditDiffNoChangesMessageConstant)
+			return writeError
+		}
+		if writeError := writeResponsiveTable(writer, headers, records); writeError != nil {
+			return fmt.Errorf("write table audit diff: %w", writeError)
+		}
+	case ReportFormatHTML:
+		if writeError := writeHTMLTable(writer, auditDiffTitleConstant, headers, records); writeError != nil {
+			return fmt.Errorf("write HTML audit diff: %w", writeError)
+		}
+	case ReportFormatJSON:
+		if diff.Changes == nil {
+			diff.Changes = []DiffChange{}
+		}
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		if writeError := encoder.Encode(diff); writeError != nil {
+			return fmt.Errorf("write JSON audit diff: %w", writeError)
+		}
+	default:
+		return fmt.Errorf("unsupported audit diff format %q; expected table, html, or json", normalizedFormat)
+	}
+	return nil
+}
+
+func writeCSVReport(writer io.Writer, headers []string, records [][]string) error {
 	csvWriter := csv.NewWriter(writer)
-	if writeError := csvWriter.Write(auditReportCSVHeaders()); writeError != nil {
+	if writeError := csvWriter.Write(headers); writeError != nil {
 		return writeError
 	}
 
-	for rowIndex := range rows {
-		if writeError := csvWriter.Write(rows[rowIndex].CSVRecord()); writeError != nil {
+	for recordIndex := range records {
+		if writeError := csvWriter.Write(records[recordIndex]); writeError != nil {
 			return writeError
 		}
 	}
@@ -83,11 +146,11 @@ func writeCSVReport(writer io.Writer, rows []AuditReportRow) error {
 	return csvWriter.Error()
 }
 
-func writeTableReport(writer io.Writer, rows []AuditReportRow) error {
-	header := auditReportDisplayHeaders()
-	values := make([][]string, 0, len(rows))
-	for rowIndex := range rows {
-		values = append(values, normalizeTableRecord(rows[rowIndex].CSVRecord()))
+// writeResponsiveTable renders a grid that fits the terminal, falling back to a field/value layout.
+func writeResponsiveTable(writer io.Writer, header []string, records [][]string) error {
+	values := make([][]string, 0, len(records))
+	for recordIndex := range records {
+		values = append(values, normalizeTableRecord(records[recordIndex]))
 	}
 
 	widths := auditTableColumnWidths(header, values)
@@ -184,22 +247,22 @@ func writeAuditTableMessage(writer io.Writer, message string, terminalWidth int)
 	return nil
 }
 
-func writeHTMLReport(writer io.Writer, rows []AuditReportRow) error {
+func writeHTMLTable(writer io.Writer, title string, headers []string, records [][]string) error {
 	var document strings.Builder
 	document.WriteString(auditHTMLDocumentTitlePrefixConstant)
-	document.WriteString(auditReportTitleConstant)
+	document.WriteString(html.EscapeString(title))
 	document.WriteString(auditHTMLDocumentTitleSuffixConstant)
-	document.WriteString(auditReportTitleConstant)
+	document.WriteString(html.EscapeString(title))
 	document.WriteString(auditHTMLDocumentHeadingSuffixConstant)
-	for _, header := range auditReportDisplayHeaders() {
+	for _, header := range headers {
 		document.WriteString("\n<th>")
 		document.WriteString(html.EscapeString(header))
 		document.WriteString("</th>")
 	}
 	document.WriteString("\n</tr>\n</thead>\n<tbody>")
-	for rowIndex := range rows {
+	for recordIndex := range records {
 		document.WriteString("\n<tr>")
-		for _, value := range rows[rowIndex].CSVRecord() {
+		for _, value := range records[recordIndex] {
 			document.WriteString("\n<td>")
 			document.WriteString(html.EscapeString(value))
 			document.WriteString("</td>")
@@ -211,8 +274,48 @@ func writeHTMLReport(writer io.Writer, rows []AuditReportRow) error {
 	return writeError
 }
 
-func auditReportCSVHeaders() []string {
-	return []string{
+// auditReportColumnIndexes resolves ReportOptions.Columns to positions in the full report record;
+// nil selects every column.
+func auditReportColumnIndexes(options ReportOptions) ([]int, error) {
+	if len(options.Columns) == 0 {
+		return nil, nil
+	}
+	headers := auditReportCSVHeaders(options)
+	columnIndexes := make([]int, 0, len(options.Columns))
+	for _, column := range options.Columns {
+		columnIndex := slices.Index(headers, strings.TrimSpace(column))
+		if columnIndex < 0 {
+			return nil, fmt.Errorf("unsupported audit report column %q; expected one of %s", column, strings.Join(headers, ", "))
+		}
+		columnIndexes = append(columnIndexes, columnIndex)
+	}
+	return columnIndexes, nil
+}
+
+func selectAuditReportColumns(values []string, columnIndexes []int) []string {
+	if columnIndexes == nil {
+		return values
+	}
+	selected := make([]string, 0, len(columnIndexes))
+	for _, columnIndex := range columnIndexes {
+		selected = append(selected, values[columnIndex])
+	}
+	return selected
+}
+
+func auditReportRecord(row AuditReportRow, options ReportOptions) []string {
+	record := row.CSVRecord()
+	if options.BranchListing {
+		record = append(record, row.BranchListing)
+	}
+	if options.GitHubHealth {
+		record = append(record, row.GitHubHealthRecord()...)
+	}
+	return record
+}
+
+func auditReportCSVHeaders(options ReportOptions) []string {
+	headers := []string{
 		csvHeaderFolderName,
 		csvHeaderFinalRepository,
 		csvHeaderOriginRemoteStatus,
@@ -224,11 +327,34 @@ func auditReportCSVHeaders() []string {
 		csvHeaderOriginCanonical,
 		csvHeaderWorktreeDirty,
 		csvHeaderDirtyFiles,
-	}
+		csvHeaderUpstreamAhead,
+		csvHeaderUpstreamBehind,
+		csvHeaderDefaultAhead,
+		csvHeaderDefaultBehind,
+		csvHeaderNoUpstreamBranches,
+		csvHeaderUpstreamGoneBranches,
+		csvHeaderMergedBranches,
+	}
+	if options.BranchListing {
+		headers = append(headers, csvHeaderBranchListing)
+	}
+	if options.GitHubHealth {
+		headers = append(headers,
+			csvHeaderArchived,
+			csvHeaderDisabled,
+			csvHeaderVisibility,
+			csvHeaderDefaultBranchProtected,
+			csvHeaderPages,
+			csvHeaderOpenPullRequests,
+			csvHeaderLocalDefaultBranch,
+			csvHeaderDefaultBranchMatches,
+		)
+	}
+	return headers
 }
 
-func auditReportDisplayHeaders() []string {
-	return []string{
+func auditReportDisplayHeaders(options ReportOptions) []string {
+	headers := []string{
 		"Folder",
 		"Final Repository",
 		"Origin",
@@ -240,7 +366,30 @@ func auditReportDisplayHeaders() []string {
 		"Origin Canonical",
 		"Worktree Dirty",
 		"Dirty Files",
-	}
+		"Upstream Ahead",
+		"Upstream Behind",
+		"Default Ahead",
+		"Default Behind",
+		"No Upstream",
+		"Upstream Gone",
+		"Merged",
+	}
+	if options.BranchListing {
+		headers = append(headers, "Branches")
+	}
+	if options.GitHubHealth {
+		headers = append(headers,
+			"Archived",
+			"Disabled",
+			"Visibility",
+			"Default Protected",
+			"Pages",
+			"Open PRs",
+			"Local Default",
+			"Default Matches GitHub",
+		)
+	}
+	return headers
 }
 
 func normalizeTableRecord(record []string) []string {
@@ -0,0 +1,166 @@
+package audit
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ReportSchemaVersion identifies the JSON and NDJSON audit payload layout.
+// Fields are only added within a schema version; renames and removals bump it.
+const ReportSchemaVersion = "gix.audit-report/v1"
+
+// JSONReport is the document written by the json audit report format.
+type JSONReport struct {
+	Schema       string             `json:"schema"`
+	Repositories []InspectionRecord `json:"repositories"`
+}
+
+// InspectionRecord is the machine-readable form of one RepositoryInspection.
+// The ndjson format writes one record per line, each carrying the schema identifier.
+type InspectionRecord struct {
+	Schema                 string                 `json:"schema,omitempty"`
+	Path                   string                 `json:"path"`
+	FolderName             string                 `json:"folder_name"`
+	IsGitRepository        bool                   `json:"is_git_repository"`
+	OriginURL              string                 `json:"origin_url"`
+	OriginOwnerRepo        string                 `json:"origin_owner_repo"`
+	CanonicalOwnerRepo     string                 `json:"canonical_owner_repo"`
+	FinalOwnerRepo         string                 `json:"final_owner_repo"`
+	DesiredFolderName      string                 `json:"desired_folder_name"`
+	OriginRemoteStatus     OriginRemoteStatus     `json:"origin_remote_status"`
+	RemoteProtocol         RemoteProtocolType     `json:"remote_protocol"`
+	RemoteDefaultBranch    string                 `json:"remote_default_branch"`
+	LocalBranch            string                 `json:"local_branch"`
+	HeadTagged             bool                   `json:"head_tagged"`
+	InSync                 TernaryValue           `json:"in_sync"`
+	OriginMatchesCanonical TernaryValue           `json:"origin_matches_canonical"`
+	WorktreeDirtyFiles     []string               `json:"worktree_dirty_files"`
+	Upstream               *DivergenceRecord      `json:"upstream"`
+	DefaultBranch          *DivergenceRecord      `json:"default_branch"`
+	Branches               *BranchInventoryRecord `json:"branches"`
+	GitHub                 *GitHubHealthRecord    `json:"github,omitempty"`
+}
+
+// DivergenceRecord reports commit counts; a null record means the reference did not resolve.
+type DivergenceRecord struct {
+	Ahead  int `json:"ahead"`
+	Behind int `json:"behind"`
+}
+
+// BranchInventoryRecord lists stale local branches; a null merged list means the default branch did not resolve.
+type BranchInventoryRecord struct {
+	NoUpstream   []string `json:"no_upstream"`
+	UpstreamGone []string `json:"upstream_gone"`
+	Merged       []string `json:"merged"`
+}
+
+// GitHubHealthRecord reports GitHub-side health; it is omitted unless the github inspection depth ran.
+// A null open_pull_requests means the pull request listing failed.
+type GitHubHealthRecord struct {
+	Archived               TernaryValue `json:"archived"`
+	Disabled               TernaryValue `json:"disabled"`
+	Visibility             string       `json:"visibility"`
+	DefaultBranch          string       `json:"default_branch"`
+	DefaultBranchProtected TernaryValue `json:"default_branch_protected"`
+	Pages                  string       `json:"pages"`
+	OpenPullRequests       *int         `json:"open_pull_requests"`
+	LocalDefaultBranch     string       `json:"local_default_branch"`
+	DefaultBranchMatches   TernaryValue `json:"default_branch_matches"`
+}
+
+// NewInspectionRecord converts an inspection into its schema form.
+func NewInspectionRecord(inspection RepositoryInspection) InspectionRecord {
+	return InspectionRecord{
+		Path:                   inspection.Path,
+		FolderName:             inspection.FolderName,
+		IsGitRepository:        inspection.IsGitRepository,
+		OriginURL:              inspection.OriginURL,
+		OriginOwnerRepo:        inspection.OriginOwnerRepo,
+		CanonicalOwnerRepo:     inspection.CanonicalOwnerRepo,
+		FinalOwnerRepo:         inspection.FinalOwnerRepo,
+		DesiredFolderName:      inspection.DesiredFolderName,
+		OriginRemoteStatus:     inspection.OriginRemoteStatus,
+		RemoteProtocol:         inspection.RemoteProtocol,
+		RemoteDefaultBranch:    inspection.RemoteDefaultBranch,
+		LocalBranch:            inspection.LocalBranch,
+		HeadTagged:             inspection.HeadTagged,
+		InSync:                 inspection.InSyncStatus,
+		OriginMatchesCanonical: inspection.OriginMatchesCanonical,
+		WorktreeDirtyFiles:     nonNilStrings(inspection.WorktreeDirtyFiles),
+		Upstream:               newDivergenceRecord(inspection.UpstreamDivergence),
+		DefaultBranch:          newDivergenceRecord(inspection.DefaultDivergence),
+		Branches:               newBranchInventoryRecord(inspection.Branches),
+		GitHub:                 newGitHubHealthRecord(inspection.GitHub),
+	}
+}
+
+func newDivergenceRecord(divergence CommitDivergence) *DivergenceRecord {
+	if !divergence.Available {
+		return nil
+	}
+	return &DivergenceRecord{Ahead: divergence.Ahead, Behind: divergence.Behind}
+}
+
+func newBranchInventoryRecord(inventory BranchInventory) *BranchInventoryRecord {
+	if !inventory.Available {
+		return nil
+	}
+	record := &BranchInventoryRecord{
+		NoUpstream:   nonNilStrings(inventory.NoUpstream),
+		UpstreamGone: nonNilStrings(inventory.UpstreamGone),
+	}
+	if inventory.MergedAvailable {
+		record.Merged = nonNilStrings(inventory.Merged)
+	}
+	return record
+}
+
+func newGitHubHealthRecord(health GitHubHealth) *GitHubHealthRecord {
+	if !health.Available {
+		return nil
+	}
+	record := &GitHubHealthRecord{
+		Archived:               health.Archived,
+		Disabled:               health.Disabled,
+		Visibility:             health.Visibility,
+		DefaultBranch:          health.DefaultBranch,
+		DefaultBranchProtected: health.DefaultBranchProtected,
+		Pages:                  health.Pages,
+		LocalDefaultBranch:     health.LocalDefaultBranch,
+		DefaultBranchMatches:   health.DefaultBranchMatches,
+	}
+	if health.OpenPullRequestsAvailable {
+		openPullRequests := health.OpenPullRequests
+		record.OpenPullRequests = &openPullRequests
+	}
+	return record
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return append([]string{}, values...)
+}
+
+func writeJSONReport(writer io.Writer, inspections []RepositoryInspection) error {
+	report := JSONReport{Schema: ReportSchemaVersion, Repositories: make([]InspectionRecord, 0, len(inspections))}
+	for inspectionIndex := range inspections {
+		report.Repositories = append(report.Repositories, NewInspectionRecord(inspections[inspectionIndex]))
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func writeNDJSONReport(writer io.Writer, inspections []RepositoryInspection) error {
+	encoder := json.NewEncoder(writer)
+	for inspectionIndex := range inspections {
+		record := NewInspectionRecord(inspections[inspectionIndex])
+		record.Schema = ReportSchemaVersion
+		if encodeError := encoder.Encode(record); encodeError != nil {
+			return encodeError
+		}
+	}
+	return nil
+}
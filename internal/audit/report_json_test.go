@@ -0,0 +1,100 @@
+package audit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/audit"
+)
+
+func TestWriteReportJSONFormats(testInstance *testing.T) {
+	inspections := []audit.RepositoryInspection{
+		{
+			Path:                   "/tmp/example",
+			FolderName:             "example",
+			OriginURL:              "git@github.com:origin/example.git",
+			OriginOwnerRepo:        "origin/example",
+			CanonicalOwnerRepo:     "canonical/example",
+			FinalOwnerRepo:         "canonical/example",
+			DesiredFolderName:      "example",
+			OriginRemoteStatus:     audit.OriginRemoteStatusConfigured,
+			RemoteProtocol:         audit.RemoteProtocolSSH,
+			RemoteDefaultBranch:    "main",
+			LocalBranch:            "feature",
+			HeadTagged:             true,
+			InSyncStatus:           audit.TernaryValueNotApplicable,
+			OriginMatchesCanonical: audit.TernaryValueNo,
+			IsGitRepository:        true,
+			WorktreeDirtyFiles:     []string{"M main.go", "?? notes, draft.md"},
+			UpstreamDivergence:     audit.CommitDivergence{Ahead: 1, Behind: 2, Available: true},
+			Branches:               audit.BranchInventory{Available: true, UpstreamGone: []string{"old"}},
+		},
+		{
+			Path:               "/tmp/notes",
+			FolderName:         "notes",
+			OriginRemoteStatus: audit.OriginRemoteStatusNotApplicable,
+		},
+	}
+	expectedFirst := map[string]any{
+		"path":                     "/tmp/example",
+		"folder_name":              "example",
+		"is_git_repository":        true,
+		"origin_url":               "git@github.com:origin/example.git",
+		"origin_owner_repo":        "origin/example",
+		"canonical_owner_repo":     "canonical/example",
+		"final_owner_repo":         "canonical/example",
+		"desired_folder_name":      "example",
+		"origin_remote_status":     "configured",
+		"remote_protocol":          "ssh",
+		"remote_default_branch":    "main",
+		"local_branch":             "feature",
+		"head_tagged":              true,
+		"in_sync":                  "n/a",
+		"origin_matches_canonical": "no",
+		"worktree_dirty_files":     []any{"M main.go", "?? notes, draft.md"},
+		"upstream":                 map[string]any{"ahead": float64(1), "behind": float64(2)},
+		"default_branch":           nil,
+		"branches":                 map[string]any{"no_upstream": []any{}, "upstream_gone": []any{"old"}, "merged": nil},
+	}
+
+	testInstance.Run("json", func(testInstance *testing.T) {
+		output := &bytes.Buffer{}
+		require.NoError(testInstance, audit.WriteReport(output, audit.ReportOptions{Format: audit.ReportFormatJSON}, inspections))
+
+		var document struct {
+			Schema       string           `json:"schema"`
+			Repositories []map[string]any `json:"repositories"`
+		}
+		require.NoError(testInstance, json.Unmarshal(output.Bytes(), &document))
+		require.Equal(testInstance, audit.ReportSchemaVersion, document.Schema)
+		require.Len(testInstance, document.Repositories, 2)
+		require.Equal(testInstance, expectedFirst, document.Repositories[0])
+		require.Equal(testInstance, false, document.Repositories[1]["is_git_repository"])
+		require.Equal(testInstance, "n/a", document.Repositories[1]["origin_remote_status"])
+		require.Equal(testInstance, []any{}, document.Repositories[1]["worktree_dirty_files"])
+	})
+
+	testInstance.Run("ndjson", func(testInstance *testing.T) {
+		output := &bytes.Buffer{}
+		require.NoError(testInstance, audit.WriteReport(output, audit.ReportOptions{Format: audit.ReportFormatNDJSON}, inspections))
+
+		lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+		require.Len(testInstance, lines, 2)
+		var first map[string]any
+		require.NoError(testInstance, json.Unmarshal([]byte(lines[0]), &first))
+		require.Equal(testInstance, audit.ReportSchemaVersion, first["schema"])
+		delete(first, "schema")
+		require.Equal(testInstance, expectedFirst, first)
+		require.Contains(testInstance, lines[1], `"schema":"gix.audit-report/v1"`)
+	})
+
+	testInstance.Run("ndjson_without_rows", func(testInstance *testing.T) {
+		output := &bytes.Buffer{}
+		require.NoError(testInstance, audit.WriteReport(output, audit.ReportOptions{Format: audit.ReportFormatNDJSON}, nil))
+		require.Empty(testInstance, output.String())
+	})
+}
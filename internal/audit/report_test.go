@@ -0,0 +1,70 @@
+package audit_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/audit"
+)
+
+func TestWriteReportSelectsColumns(testInstance *testing.T) {
+	inspections := []audit.RepositoryInspection{
+		{
+			Path:                   "/tmp/example",
+			FolderName:             "example",
+			CanonicalOwnerRepo:     "canonical/example",
+			DesiredFolderName:      "example",
+			OriginRemoteStatus:     audit.OriginRemoteStatusConfigured,
+			RemoteProtocol:         audit.RemoteProtocolSSH,
+			RemoteDefaultBranch:    "main",
+			LocalBranch:            "feature",
+			InSyncStatus:           audit.TernaryValueNotApplicable,
+			OriginMatchesCanonical: audit.TernaryValueYes,
+			IsGitRepository:        true,
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		options          audit.ReportOptions
+		expectedOutput   string
+		expectedContains []string
+		expectedError    string
+	}{
+		{
+			name:           "csv_in_requested_order",
+			options:        audit.ReportOptions{Format: audit.ReportFormatCSV, Columns: []string{"local_branch", "folder_name"}},
+			expectedOutput: "local_branch,folder_name\nfeature,example\n",
+		},
+		{
+			name:             "html_uses_display_headers",
+			options:          audit.ReportOptions{Format: audit.ReportFormatHTML, Columns: []string{"final_github_repo", "remote_protocol"}},
+			expectedContains: []string{"<th>Final Repository</th>\n<th>Protocol</th>\n</tr>", "<td>canonical/example</td>\n<td>ssh</td>\n</tr>"},
+		},
+		{
+			name:          "unknown_column",
+			options:       audit.ReportOptions{Format: audit.ReportFormatCSV, Columns: []string{"github_pages"}},
+			expectedError: `unsupported audit report column "github_pages"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			output := &bytes.Buffer{}
+			writeError := audit.WriteReport(output, testCase.options, inspections)
+			if len(testCase.expectedError) > 0 {
+				require.ErrorContains(testInstance, writeError, testCase.expectedError)
+				return
+			}
+			require.NoError(testInstance, writeError)
+			if len(testCase.expectedOutput) > 0 {
+				require.Equal(testInstance, testCase.expectedOutput, output.String())
+			}
+			for _, expectedFragment := range testCase.expectedContains {
+				require.Contains(testInstance, output.String(), expectedFragment)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/githubcli"
 	"github.com/tyemirov/gix/internal/repos/shared"
 	"github.com/tyemirov/gix/internal/repos/worktree"
 )
@@ -26,6 +27,7 @@ type Service struct {
 	githubClient GitHubMetadataResolver
 	outputWriter io.Writer
 	errorWriter  io.Writer
+	cache        *InspectionCache
 }
 
 // NewService constructs a Service using the provided dependencies.
@@ -40,6 +42,12 @@ func NewService(discoverer RepositoryDiscoverer, gitManager GitRepositoryManager
 	}
 }
 
+// SetInspectionCache makes DiscoverInspections reuse inspections of unchanged repositories
+// and GitHub metadata younger than the cache TTL. A nil cache inspects every repository.
+func (service *Service) SetInspectionCache(cache *InspectionCache) {
+	service.cache = cache
+}
+
 // Run executes the service according to the provided options.
 func (service *Service) Run(executionContext context.Context, options CommandOptions) error {
 	roots := options.Roots
@@ -52,7 +60,12 @@ func (service *Service) Run(executionContext context.Context, options CommandOpt
 		return inspectionError
 	}
 
-	return WriteReport(service.outputWriter, options.ReportFormat, inspections)
+	reportOptions := ReportOptions{
+		Format:        options.ReportFormat,
+		BranchListing: options.BranchListing,
+		GitHubHealth:  normalizeInspectionDepth(options.InspectionDepth) == InspectionDepthGitHub,
+	}
+	return WriteReport(service.outputWriter, reportOptions, inspections)
 }
 
 // DiscoverInspections collects repository inspections for the provided roots.
@@ -118,7 +131,7 @@ func (service *Service) DiscoverInspections(executionContext context.Context, ro
 			continue
 		}
 
-		inspection, inspectError := service.inspectRepository(executionContext, repositoryPath, normalizedDepth)
+		inspection, inspectError := service.inspectRepositoryWithCache(executionContext, repositoryPath, normalizedDepth)
 		if inspectError != nil {
 			continue
 		}
@@ -127,9 +140,48 @@ func (service *Service) DiscoverInspections(executionContext context.Context, ro
 		inspections = append(inspections, inspection)
 	}
 
+	if saveError := service.cache.Save(); saveError != nil && debug {
+		fmt.Fprintf(service.errorWriter, debugCacheSaveFailedTemplate, saveError)
+	}
+
 	return inspections, nil
 }
 
+// inspectRepositoryWithCache reuses a cached inspection when the repository fingerprint is unchanged.
+// Worktree status is always re-read because editing tracked files does not change the fingerprint; it runs
+// before fingerprinting, and fresh inspections are fingerprinted afterwards, so index refreshes and fetched
+// refs written by the inspection itself do not invalidate the entry on the next run.
+func (service *Service) inspectRepositoryWithCache(executionContext context.Context, repositoryPath string, inspectionDepth InspectionDepth) (RepositoryInspection, error) {
+	if service.cache == nil {
+		return service.inspectRepository(executionContext, repositoryPath, inspectionDepth)
+	}
+
+	worktreeDirtyFiles := service.collectWorktreeStatus(executionContext, repositoryPath)
+	if fingerprint, fingerprintAvailable := service.repositoryFingerprint(executionContext, repositoryPath); fingerprintAvailable {
+		if cached, hit := service.cache.lookupInspection(repositoryPath, fingerprint, inspectionDepth); hit {
+			cached.WorktreeDirtyFiles = worktreeDirtyFiles
+			if !inspectionDepth.includes(InspectionDepthFull) {
+				return cached, nil
+			}
+			// InSync compares against a freshly fetched remote, so it is never served from the cache.
+			cached.InSyncStatus = service.computeInSync(executionContext, repositoryPath, cached.RemoteDefaultBranch, cached.LocalBranch, cached.RemoteProtocol)
+			if refetchedFingerprint, refetchedAvailable := service.repositoryFingerprint(executionContext, repositoryPath); refetchedAvailable && refetchedFingerprint == fingerprint {
+				return cached, nil
+			}
+			// The fetch moved remote-tracking refs, so divergence and branch inventory must be re-measured.
+		}
+	}
+
+	inspection, inspectError := service.inspectRepository(executionContext, repositoryPath, inspectionDepth)
+	if inspectError != nil {
+		return RepositoryInspection{}, inspectError
+	}
+	if fingerprint, fingerprintAvailable := service.repositoryFingerprint(executionContext, repositoryPath); fingerprintAvailable {
+		service.cache.storeInspection(repositoryPath, fingerprint, inspectionDepth, inspection)
+	}
+	return inspection, nil
+}
+
 func deduplicatePaths(paths []string) []string {
 	seen := make(map[string]struct{})
 	var unique []string
@@ -166,8 +218,8 @@ func normalizeRepositoryPaths(paths []string) ([]string, error) {
 
 func normalizeInspectionDepth(depth InspectionDepth) InspectionDepth {
 	switch depth {
-	case InspectionDepthMinimal:
-		return InspectionDepthMinimal
+	case InspectionDepthMinimal, InspectionDepthGitHub:
+		return depth
 	default:
 		return InspectionDepthFull
 	}
@@ -195,12 +247,16 @@ func (service *Service) inspectRepository(executionContext context.Context, repo
 	if originError != nil || len(strings.TrimSpace(originURL)) == 0 {
 		localBranch := ""
 		headTagged := false
-		if inspectionDepth == InspectionDepthFull {
+		var upstreamDivergence CommitDivergence
+		var branchInventory BranchInventory
+		if inspectionDepth.includes(InspectionDepthFull) {
 			branchName, localBranchError := service.gitManager.GetCurrentBranch(executionContext, repositoryPath)
 			if localBranchError == nil {
 				localBranch = sanitizeBranchName(branchName)
 			}
 			headTagged = service.currentHeadTagged(executionContext, repositoryPath)
+			upstreamDivergence = service.measureDivergence(executionContext, repositoryPath, upstreamReferenceCommandArgument)
+			branchInventory = service.collectBranchInventory(executionContext, repositoryPath, "")
 		}
 
 		return RepositoryInspection{
@@ -220,6 +276,8 @@ func (service *Service) inspectRepository(executionContext context.Context, repo
 			OriginMatchesCanonical: TernaryValueNotApplicable,
 			IsGitRepository:        true,
 			WorktreeDirtyFiles:     worktreeStatus,
+			UpstreamDivergence:     upstreamDivergence,
+			Branches:               branchInventory,
 		}, nil
 	}
 
@@ -236,12 +294,9 @@ func (service *Service) inspectRepository(executionContext context.Context, repo
 
 	canonicalOwnerRepo := ""
 	remoteDefaultBranch := ""
-	if service.githubClient != nil {
-		metadata, metadataError := service.githubClient.ResolveRepoMetadata(executionContext, originOwnerRepo)
-		if metadataError == nil {
-			canonicalOwnerRepo = strings.TrimSpace(metadata.NameWithOwner)
-			remoteDefaultBranch = strings.TrimSpace(metadata.DefaultBranch)
-		}
+	if metadata, metadataAvailable := service.resolveRepositoryMetadata(executionContext, originOwnerRepo); metadataAvailable {
+		canonicalOwnerRepo = strings.TrimSpace(metadata.NameWithOwner)
+		remoteDefaultBranch = strings.TrimSpace(metadata.DefaultBranch)
 	}
 
 	if len(remoteDefaultBranch) == 0 {
@@ -251,7 +306,10 @@ func (service *Service) inspectRepository(executionContext context.Context, repo
 	localBranch := ""
 	headTagged := false
 	inSyncStatus := TernaryValueNotApplicable
-	if inspectionDepth == InspectionDepthFull {
+	var upstreamDivergence CommitDivergence
+	var defaultDivergence CommitDivergence
+	var branchInventory BranchInventory
+	if inspectionDepth.includes(InspectionDepthFull) {
 		branchName, localBranchError := service.gitManager.GetCurrentBranch(executionContext, repositoryPath)
 		if localBranchError == nil {
 			sanitizedBranch := sanitizeBranchName(branchName)
@@ -259,6 +317,11 @@ func (service *Service) inspectRepository(executionContext context.Context, repo
 			inSyncStatus = service.computeInSync(executionContext, repositoryPath, remoteDefaultBranch, sanitizedBranch, remoteProtocol)
 		}
 		headTagged = service.currentHeadTagged(executionContext, repositoryPath)
+		upstreamDivergence = service.measureDivergence(executionContext, repositoryPath, upstreamReferenceCommandArgument)
+		if len(remoteDefaultBranch) > 0 {
+			defaultDivergence = service.measureDivergence(executionContext, repositoryPath, remoteTrackingReference(remoteDefaultBranch))
+		}
+		branchInventory = service.collectBranchInventory(executionContext, repositoryPath, remoteDefaultBranch)
 	}
 
 	finalOwnerRepo := originOwnerRepo
@@ -283,10 +346,36 @@ func (service *Service) inspectRepository(executionContext context.Context, repo
 		OriginMatchesCanonical: matchesCanonical(originOwnerRepo, canonicalOwnerRepo),
 		IsGitRepository:        true,
 		WorktreeDirtyFiles:     worktreeStatus,
+		UpstreamDivergence:     upstreamDivergence,
+		DefaultDivergence:      defaultDivergence,
+		Branches:               branchInventory,
+	}
+	if inspectionDepth == InspectionDepthGitHub {
+		inspection.GitHub = service.inspectGitHubHealth(executionContext, repositoryPath, finalOwnerRepo)
 	}
 	return inspection, nil
 }
 
+func (service *Service) resolveRepositoryMetadata(executionContext context.Context, ownerRepository string) (githubcli.RepositoryMetadata, bool) {
+	if service.githubClient == nil {
+		return githubcli.RepositoryMetadata{}, false
+	}
+	cacheable := service.cache != nil && len(strings.TrimSpace(ownerRepository)) > 0
+	if cacheable {
+		if metadata, hit := service.cache.lookupMetadata(ownerRepository); hit {
+			return metadata, true
+		}
+	}
+	metadata, metadataError := service.githubClient.ResolveRepoMetadata(executionContext, ownerRepository)
+	if metadataError != nil {
+		return githubcli.RepositoryMetadata{}, false
+	}
+	if cacheable {
+		service.cache.storeMetadata(ownerRepository, metadata)
+	}
+	return metadata, true
+}
+
 func matchesCanonical(origin string, canonical string) TernaryValue {
 	if len(strings.TrimSpace(origin)) == 0 || len(strings.TrimSpace(canonical)) == 0 {
 		return TernaryValueNotApplicable
@@ -341,6 +430,12 @@ func inspectionReportRow(inspection RepositoryInspection) AuditReportRow {
 			worktreeDirty = TernaryValueNo
 		}
 	}
+	githubHealth := inspection.GitHub
+	if !githubHealth.Available {
+		githubHealth = unavailableGitHubHealth()
+	} else if len(githubHealth.LocalDefaultBranch) == 0 {
+		githubHealth.LocalDefaultBranch = string(TernaryValueNotApplicable)
+	}
 	return AuditReportRow{
 		FolderName:             inspection.FolderName,
 		FinalRepository:        finalRepo,
@@ -353,6 +448,22 @@ func inspectionReportRow(inspection RepositoryInspection) AuditReportRow {
 		OriginMatchesCanonical: originMatches,
 		WorktreeDirty:          worktreeDirty,
 		DirtyFiles:             dirtyFilesValue,
+		UpstreamAhead:          inspection.UpstreamDivergence.AheadValue(),
+		UpstreamBehind:         inspection.UpstreamDivergence.BehindValue(),
+		DefaultAhead:           inspection.DefaultDivergence.AheadValue(),
+		DefaultBehind:          inspection.DefaultDivergence.BehindValue(),
+		NoUpstreamBranches:     inspection.Branches.NoUpstreamValue(),
+		UpstreamGoneBranches:   inspection.Branches.UpstreamGoneValue(),
+		MergedBranches:         inspection.Branches.MergedValue(),
+		BranchListing:          inspection.Branches.Listing(),
+		Archived:               githubHealth.Archived,
+		Disabled:               githubHealth.Disabled,
+		Visibility:             githubHealth.Visibility,
+		DefaultBranchProtected: githubHealth.DefaultBranchProtected,
+		Pages:                  githubHealth.Pages,
+		OpenPullRequests:       githubHealth.OpenPullRequestsValue(),
+		LocalDefaultBranch:     githubHealth.LocalDefaultBranch,
+		DefaultBranchMatches:   githubHealth.DefaultBranchMatches,
 	}
 }
 
@@ -474,18 +585,8 @@ func isPathWithinRepository(path string, repositories map[string]struct{}) bool
 }
 
 func (service *Service) resolveDefaultBranchFromGit(executionContext context.Context, repositoryPath string) string {
-	remoteHeadReference := execshell.CommandDetails{
-		Arguments:        remoteHeadSymbolicRefArguments(),
-		WorkingDirectory: repositoryPath,
-	}
-
-	if executionResult, executionError := service.gitExecutor.ExecuteGit(executionContext, remoteHeadReference); executionError == nil {
-		remoteReference := strings.TrimSpace(executionResult.StandardOutput)
-		remoteReference = strings.TrimPrefix(remoteReference, fmt.Sprintf("refs/remotes/%s/", shared.OriginRemoteNameConstant))
-		remoteReference = strings.TrimPrefix(remoteReference, refsHeadsPrefixConstant)
-		if len(remoteReference) > 0 {
-			return remoteReference
-		}
+	if remoteHeadBranch := service.resolveRemoteHeadBranch(executionContext, repositoryPath); len(remoteHeadBranch) > 0 {
+		return remoteHeadBranch
 	}
 
 	localHeadReference := execshell.CommandDetails{
@@ -504,6 +605,22 @@ func (service *Service) resolveDefaultBranchFromGit(executionContext context.Con
 	return ""
 }
 
+// resolveRemoteHeadBranch returns the branch origin/HEAD points at, or an empty string when it is not set.
+func (service *Service) resolveRemoteHeadBranch(executionContext context.Context, repositoryPath string) string {
+	remoteHeadReference := execshell.CommandDetails{
+		Arguments:        remoteHeadSymbolicRefArguments(),
+		WorkingDirectory: repositoryPath,
+	}
+
+	executionResult, executionError := service.gitExecutor.ExecuteGit(executionContext, remoteHeadReference)
+	if executionError != nil {
+		return ""
+	}
+	remoteReference := strings.TrimSpace(executionResult.StandardOutput)
+	remoteReference = strings.TrimPrefix(remoteReference, fmt.Sprintf("refs/remotes/%s/", shared.OriginRemoteNameConstant))
+	return strings.TrimPrefix(remoteReference, refsHeadsPrefixConstant)
+}
+
 func (service *Service) currentHeadTagged(executionContext context.Context, repositoryPath string) bool {
 	tagDetails := execshell.CommandDetails{
 		Arguments:        []string{gitTagSubcommandConstant, gitPointsAtFlagConstant, gitHeadReferenceConstant},
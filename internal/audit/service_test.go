@@ -18,7 +18,7 @@ import (
 
 const (
 	currentDirectoryRelativePathConstant = "."
-	auditServiceCSVHeader                = "folder_name,final_github_repo,origin_remote_status,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,worktree_dirty,dirty_files\n"
+	auditServiceCSVHeader                = "folder_name,final_github_repo,origin_remote_status,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,worktree_dirty,dirty_files,upstream_ahead,upstream_behind,default_ahead,default_behind,no_upstream_branches,upstream_gone_branches,merged_branches\n"
 )
 
 type stubDiscoverer struct {
@@ -151,7 +151,7 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 					DefaultBranch: "main",
 				},
 			},
-			expectedOutput: auditServiceCSVHeader + "example,canonical/example,configured,yes,main,main,n/a,https,no,no,\n",
+			expectedOutput: auditServiceCSVHeader + "example,canonical/example,configured,yes,main,main,n/a,https,no,no,,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n",
 			expectedError:  "",
 		},
 		{
@@ -179,7 +179,7 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 					DefaultBranch: "main",
 				},
 			},
-			expectedOutput: auditServiceCSVHeader + "example,canonical/example,configured,yes,main,main,n/a,https,no,yes,M main.go; ?? README.md\n",
+			expectedOutput: auditServiceCSVHeader + "example,canonical/example,configured,yes,main,main,n/a,https,no,yes,M main.go; ?? README.md,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n",
 			expectedError:  "",
 		},
 		{
@@ -204,7 +204,7 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 					DefaultBranch: "main",
 				},
 			},
-			expectedOutput:       auditServiceCSVHeader + "example,canonical/example,configured,yes,main,,n/a,https,no,no,\n",
+			expectedOutput:       auditServiceCSVHeader + "example,canonical/example,configured,yes,main,,n/a,https,no,no,,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n",
 			expectedError:        "",
 			panicOnUnexpectedGit: true,
 		},
@@ -230,7 +230,7 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 					DefaultBranch: "main",
 				},
 			},
-			expectedOutput: auditServiceCSVHeader + "example,canonical/example,configured,yes,main,main,n/a,https,no,no,\n",
+			expectedOutput: auditServiceCSVHeader + "example,canonical/example,configured,yes,main,main,n/a,https,no,no,,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n",
 			expectedError:  "DEBUG: discovered 1 candidate repos under: /tmp/example\nDEBUG: checking /tmp/example\n",
 		},
 		{
@@ -249,7 +249,7 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 				branchName:    "main",
 				remoteURL:     "https://github.com/origin/example.git",
 			},
-			expectedOutput: auditServiceCSVHeader + "example,origin/example,configured,yes,main,,n/a,https,n/a,no,\n",
+			expectedOutput: auditServiceCSVHeader + "example,origin/example,configured,yes,main,,n/a,https,n/a,no,,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n",
 			expectedError:  "",
 		},
 		{
@@ -268,7 +268,7 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 				remoteURL:           "",
 				panicOnBranchLookup: true,
 			},
-			expectedOutput: auditServiceCSVHeader + "example,n/a,missing,yes,,,n/a,n/a,n/a,no,\n",
+			expectedOutput: auditServiceCSVHeader + "example,n/a,missing,yes,,,n/a,n/a,n/a,no,,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n",
 			expectedError:  "",
 		},
 		{
@@ -294,7 +294,7 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 					DefaultBranch: "main",
 				},
 			},
-			expectedOutput: auditServiceCSVHeader + "example,canonical/example,configured,yes,main,,n/a,https,no,no,\n",
+			expectedOutput: auditServiceCSVHeader + "example,canonical/example,configured,yes,main,,n/a,https,no,no,,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n",
 			expectedError:  "",
 		},
 	}
@@ -322,6 +322,66 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 	}
 }
 
+func TestServiceRunReportsBranchDivergenceAndInventory(testInstance *testing.T) {
+	const expectedRow = "example,canonical/example,configured,yes,main,feature/work,n/a,https,no,no,,1,2,3,0,1,1,1"
+
+	testCases := []struct {
+		name           string
+		branchListing  bool
+		expectedOutput string
+	}{
+		{
+			name:           "counts_only",
+			expectedOutput: auditServiceCSVHeader + expectedRow + "\n",
+		},
+		{
+			name:           "expanded_branch_listing",
+			branchListing:  true,
+			expectedOutput: strings.TrimSuffix(auditServiceCSVHeader, "\n") + ",branch_listing\n" + expectedRow + ",merged: done; no upstream: feature/local; upstream gone: feature/old\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			outputBuffer := &bytes.Buffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{"/tmp/example"}},
+				stubGitManager{
+					cleanWorktree: true,
+					branchName:    "feature/work",
+					remoteURL:     "https://github.com/origin/example.git",
+				},
+				stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+					"rev-parse --is-inside-work-tree":                                                      {StandardOutput: "true"},
+					"rev-list --left-right --count HEAD...@{u}":                                            {StandardOutput: "1\t2\n"},
+					"rev-list --left-right --count HEAD...refs/remotes/origin/main":                        {StandardOutput: "3\t0\n"},
+					"for-each-ref --format=%(refname:short) --merged refs/remotes/origin/main refs/heads/": {StandardOutput: "done\nmain\n"},
+					"for-each-ref --format=%(refname:short)%09%(upstream)%09%(upstream:track) refs/heads/": {
+						StandardOutput: "done\trefs/remotes/origin/done\t\nfeature/local\t\t\nfeature/old\trefs/remotes/origin/feature/old\t[gone]\nmain\trefs/remotes/origin/main\t\n",
+					},
+				}},
+				stubGitHubResolver{
+					metadata: githubcli.RepositoryMetadata{
+						NameWithOwner: "canonical/example",
+						DefaultBranch: "main",
+					},
+				},
+				outputBuffer,
+				&bytes.Buffer{},
+			)
+
+			runError := service.Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{"/tmp/example"},
+				InspectionDepth: audit.InspectionDepthFull,
+				ReportFormat:    audit.ReportFormatCSV,
+				BranchListing:   testCase.branchListing,
+			})
+			require.NoError(testInstance, runError)
+			require.Equal(testInstance, testCase.expectedOutput, outputBuffer.String())
+		})
+	}
+}
+
 func TestServiceRunNormalizesRepositoryPaths(testInstance *testing.T) {
 	testInstance.Helper()
 
@@ -374,7 +434,7 @@ func TestServiceRunNormalizesRepositoryPaths(testInstance *testing.T) {
 	}
 
 	expectedCSVOutput := fmt.Sprintf(
-		auditServiceCSVHeader+"%s,canonical/example,configured,%s,main,,n/a,https,no,no,\n",
+		auditServiceCSVHeader+"%s,canonical/example,configured,%s,main,,n/a,https,no,no,,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n",
 		repositoryFolderName,
 		expectedNameMatches,
 	)
@@ -434,8 +494,8 @@ func TestServiceRunIncludesAllFolders(testInstance *testing.T) {
 
 	expectedOutput := fmt.Sprintf(
 		auditServiceCSVHeader+
-			"%s,canonical/example,configured,no,main,,n/a,https,no,no,\n"+
-			"%s,n/a,n/a,n/a,n/a,n/a,n/a,n/a,n/a,n/a,\n",
+			"%s,canonical/example,configured,no,main,,n/a,https,no,no,,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n"+
+			"%s,n/a,n/a,n/a,n/a,n/a,n/a,n/a,n/a,n/a,,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n",
 		gitRepositoryFolderName,
 		nonRepositoryFolderName,
 	)
@@ -481,7 +541,7 @@ func TestServiceRunUsesRelativeFolderNames(testInstance *testing.T) {
 	require.NoError(testInstance, runError)
 
 	expectedOutput := fmt.Sprintf(
-		auditServiceCSVHeader+"%s,canonical/git-project,configured,yes,main,,n/a,https,no,no,\n",
+		auditServiceCSVHeader+"%s,canonical/git-project,configured,yes,main,,n/a,https,no,no,,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n",
 		filepath.ToSlash(relativeFolderPath),
 	)
 	require.Equal(testInstance, expectedOutput, outputBuffer.String())
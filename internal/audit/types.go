@@ -2,6 +2,8 @@ package audit
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/tyemirov/gix/internal/repos/shared"
 )
@@ -44,16 +46,36 @@ type InspectionDepth string
 const (
 	InspectionDepthFull    InspectionDepth = "full"
 	InspectionDepthMinimal InspectionDepth = "minimal"
+	// InspectionDepthGitHub gathers full local state plus GitHub-side repository health.
+	InspectionDepthGitHub InspectionDepth = "github"
 )
 
+// includes reports whether an inspection gathered at depth also satisfies a request for other.
+func (depth InspectionDepth) includes(other InspectionDepth) bool {
+	return inspectionDepthRank(depth) >= inspectionDepthRank(other)
+}
+
+func inspectionDepthRank(depth InspectionDepth) int {
+	switch depth {
+	case InspectionDepthMinimal:
+		return 0
+	case InspectionDepthGitHub:
+		return 2
+	default:
+		return 1
+	}
+}
+
 // ReportFormat identifies the serialization used for an audit report.
 type ReportFormat string
 
 // Supported audit report formats.
 const (
-	ReportFormatTable ReportFormat = "table"
-	ReportFormatCSV   ReportFormat = "csv"
-	ReportFormatHTML  ReportFormat = "html"
+	ReportFormatTable  ReportFormat = "table"
+	ReportFormatCSV    ReportFormat = "csv"
+	ReportFormatHTML   ReportFormat = "html"
+	ReportFormatJSON   ReportFormat = "json"
+	ReportFormatNDJSON ReportFormat = "ndjson"
 )
 
 // DefaultReportFormat returns the terminal-oriented audit report format.
@@ -65,10 +87,10 @@ func DefaultReportFormat() ReportFormat {
 func ParseReportFormat(value string) (ReportFormat, error) {
 	reportFormat := ReportFormat(value)
 	switch reportFormat {
-	case ReportFormatTable, ReportFormatCSV, ReportFormatHTML:
+	case ReportFormatTable, ReportFormatCSV, ReportFormatHTML, ReportFormatJSON, ReportFormatNDJSON:
 		return reportFormat, nil
 	default:
-		return "", fmt.Errorf("unsupported audit report format %q; expected table, csv, or html", value)
+		return "", fmt.Errorf("unsupported audit report format %q; expected table, csv, html, json, or ndjson", value)
 	}
 }
 
@@ -86,6 +108,111 @@ type CommandOptions struct {
 	InspectionDepth   InspectionDepth
 	IncludeAllFolders bool
 	ReportFormat      ReportFormat
+	BranchListing     bool
+}
+
+// ReportOptions selects the audit report serialization and its optional columns.
+type ReportOptions struct {
+	Format        ReportFormat
+	BranchListing bool
+	// GitHubHealth appends the GitHub health columns gathered at the github inspection depth.
+	GitHubHealth bool
+	// Columns limits the table, CSV, and HTML reports to these CSV header names, in this order.
+	// Empty keeps every column; the JSON formats always carry the full schema.
+	Columns []string
+}
+
+// CommitDivergence counts commits that separate HEAD from a comparison reference.
+type CommitDivergence struct {
+	Ahead     int
+	Behind    int
+	Available bool
+}
+
+// AheadValue returns the ahead count for reports, or n/a when it could not be measured.
+func (divergence CommitDivergence) AheadValue() string {
+	return countValue(divergence.Ahead, divergence.Available)
+}
+
+// BehindValue returns the behind count for reports, or n/a when it could not be measured.
+func (divergence CommitDivergence) BehindValue() string {
+	return countValue(divergence.Behind, divergence.Available)
+}
+
+// BranchInventory lists local branches that are candidates for cleanup.
+// Merged branches are only known when the remote default branch resolves locally.
+type BranchInventory struct {
+	Available       bool
+	NoUpstream      []string
+	UpstreamGone    []string
+	MergedAvailable bool
+	Merged          []string
+}
+
+// NoUpstreamValue returns the number of local branches without an upstream, or n/a.
+func (inventory BranchInventory) NoUpstreamValue() string {
+	return countValue(len(inventory.NoUpstream), inventory.Available)
+}
+
+// UpstreamGoneValue returns the number of local branches whose upstream was deleted, or n/a.
+func (inventory BranchInventory) UpstreamGoneValue() string {
+	return countValue(len(inventory.UpstreamGone), inventory.Available)
+}
+
+// MergedValue returns the number of local branches merged into the default branch, or n/a.
+func (inventory BranchInventory) MergedValue() string {
+	return countValue(len(inventory.Merged), inventory.Available && inventory.MergedAvailable)
+}
+
+// Listing returns the per-branch inventory grouped by state, for example "merged: a, b; upstream gone: c".
+func (inventory BranchInventory) Listing() string {
+	if !inventory.Available {
+		return string(TernaryValueNotApplicable)
+	}
+	groups := []struct {
+		label    string
+		branches []string
+	}{
+		{label: branchListingMergedLabel, branches: inventory.Merged},
+		{label: branchListingNoUpstreamLabel, branches: inventory.NoUpstream},
+		{label: branchListingUpstreamGoneLabel, branches: inventory.UpstreamGone},
+	}
+	segments := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if len(group.branches) == 0 {
+			continue
+		}
+		segments = append(segments, group.label+": "+strings.Join(group.branches, ", "))
+	}
+	return strings.Join(segments, "; ")
+}
+
+// GitHubHealth captures GitHub-side repository state gathered at the github inspection depth.
+// Each check is queried independently; checks that could not be answered report n/a.
+type GitHubHealth struct {
+	Available                 bool
+	Archived                  TernaryValue
+	Disabled                  TernaryValue
+	Visibility                string
+	DefaultBranch             string
+	DefaultBranchProtected    TernaryValue
+	Pages                     string
+	OpenPullRequests          int
+	OpenPullRequestsAvailable bool
+	LocalDefaultBranch        string
+	DefaultBranchMatches      TernaryValue
+}
+
+// OpenPullRequestsValue returns the open pull request count for reports, or n/a.
+func (health GitHubHealth) OpenPullRequestsValue() string {
+	return countValue(health.OpenPullRequests, health.OpenPullRequestsAvailable)
+}
+
+func countValue(count int, available bool) string {
+	if !available {
+		return string(TernaryValueNotApplicable)
+	}
+	return strconv.Itoa(count)
 }
 
 // RepositoryInspection captures gathered repository state.
@@ -106,6 +233,10 @@ type RepositoryInspection struct {
 	OriginMatchesCanonical TernaryValue
 	IsGitRepository        bool
 	WorktreeDirtyFiles     []string
+	UpstreamDivergence     CommitDivergence
+	DefaultDivergence      CommitDivergence
+	Branches               BranchInventory
+	GitHub                 GitHubHealth
 }
 
 // AuditReportRow models a single CSV audit result.
@@ -121,9 +252,26 @@ type AuditReportRow struct {
 	OriginMatchesCanonical TernaryValue
 	WorktreeDirty          TernaryValue
 	DirtyFiles             string
+	UpstreamAhead          string
+	UpstreamBehind         string
+	DefaultAhead           string
+	DefaultBehind          string
+	NoUpstreamBranches     string
+	UpstreamGoneBranches   string
+	MergedBranches         string
+	BranchListing          string
+	Archived               TernaryValue
+	Disabled               TernaryValue
+	Visibility             string
+	DefaultBranchProtected TernaryValue
+	Pages                  string
+	OpenPullRequests       string
+	LocalDefaultBranch     string
+	DefaultBranchMatches   TernaryValue
 }
 
 // CSVRecord returns the row formatted for CSV encoding.
+// The per-branch listing and GitHub health columns are optional and are appended by the report writer when requested.
 func (row AuditReportRow) CSVRecord() []string {
 	return []string{
 		row.FolderName,
@@ -137,5 +285,26 @@ func (row AuditReportRow) CSVRecord() []string {
 		string(row.OriginMatchesCanonical),
 		string(row.WorktreeDirty),
 		row.DirtyFiles,
+		row.UpstreamAhead,
+		row.UpstreamBehind,
+		row.DefaultAhead,
+		row.DefaultBehind,
+		row.NoUpstreamBranches,
+		row.UpstreamGoneBranches,
+		row.MergedBranches,
+	}
+}
+
+// GitHubHealthRecord returns the GitHub health columns in report order.
+func (row AuditReportRow) GitHubHealthRecord() []string {
+	return []string{
+		string(row.Archived),
+		string(row.Disabled),
+		row.Visibility,
+		string(row.DefaultBranchProtected),
+		row.Pages,
+		row.OpenPullRequests,
+		row.LocalDefaultBranch,
+		string(row.DefaultBranchMatches),
 	}
 }
@@ -52,6 +52,9 @@ const (
 	remoteBranchParsingErrorTemplateConstant     = "unable to parse remote branch list: %w"
 	pullRequestDecodingErrorTemplateConstant     = "unable to decode pull request response: %w"
 	remoteNameRequiredMessageConstant            = "remote name must be provided"
+	branchNameRequiredMessageConstant            = "branch name must be provided"
+	branchDeletionFailedTemplateConstant         = "unable to delete branch %s: %s"
+	branchDeletionFailureSeparatorConstant       = "; "
 	limitPositiveRequirementMessageConstant      = "pull request limit must be greater than zero"
 	executorNotConfiguredMessageConstant         = "command executor not configured"
 	branchDeletionPromptTemplateConstant         = "Delete pull request branch '%s' from remote '%s' and the local repository? [a/N/y] "
@@ -92,6 +95,13 @@ type CleanupSummary struct {
 	Failures         []CleanupFailure
 }
 
+// BranchDeletionOptions select one branch to delete from the remote and the local repository.
+type BranchDeletionOptions struct {
+	RemoteName       string
+	BranchName       string
+	WorkingDirectory string
+}
+
 // Service orchestrates removal of remote and local branches tied to closed pull requests.
 type Service struct {
 	logger   *zap.Logger
@@ -101,6 +111,7 @@ type Service struct {
 
 var (
 	errRemoteNameRequired    = errors.New(remoteNameRequiredMessageConstant)
+	errBranchNameRequired    = errors.New(branchNameRequiredMessageConstant)
 	errLimitMustBePositive   = errors.New(limitPositiveRequirementMessageConstant)
 	errExecutorNotConfigured = errors.New(executorNotConfiguredMessageConstant)
 )
@@ -144,6 +155,35 @@ func (service *Service) Cleanup(executionContext context.Context, options Cleanu
 	return summary, nil
 }
 
+// DeleteBranch removes one branch from the remote and the local repository without prompting.
+// A side where the branch is already gone counts as deleted.
+func (service *Service) DeleteBranch(executionContext context.Context, options BranchDeletionOptions) error {
+	trimmedRemoteName := strings.TrimSpace(options.RemoteName)
+	if len(trimmedRemoteName) == 0 {
+		return errRemoteNameRequired
+	}
+	trimmedBranchName := strings.TrimSpace(options.BranchName)
+	if len(trimmedBranchName) == 0 {
+		return errBranchNameRequired
+	}
+
+	outcome := service.deleteRemoteAndLocalBranch(executionContext, trimmedRemoteName, trimmedBranchName, nil, CleanupOptions{
+		RemoteName:       trimmedRemoteName,
+		WorkingDirectory: options.WorkingDirectory,
+	})
+	if outcome.failure == nil {
+		return nil
+	}
+
+	failureMessages := make([]string, 0, 2)
+	for _, failureMessage := range []string{outcome.failure.RemoteDeletionError, outcome.failure.LocalDeletionError} {
+		if len(failureMessage) > 0 {
+			failureMessages = append(failureMessages, failureMessage)
+		}
+	}
+	return fmt.Errorf(branchDeletionFailedTemplateConstant, trimmedBranchName, strings.Join(failureMessages, branchDeletionFailureSeparatorConstant))
+}
+
 func (service *Service) fetchRemoteBranches(executionContext context.Context, remoteName string, workingDirectory string) (map[string]struct{}, error) {
 	service.logger.Info(logMessageListingRemoteBranchesConstant,
 		zap.String(logFieldRemoteNameConstant, remoteName),
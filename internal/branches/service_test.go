@@ -621,6 +621,46 @@ func TestServiceCleanupCapturesFailureDetails(testInstance *testing.T) {
 	require.Empty(testInstance, cleanupSummary.Failures[0].PromptError)
 }
 
+func TestServiceDeleteBranchSkipsGoneSidesAndReportsFailures(testInstance *testing.T) {
+	branchName := "feature/merged"
+	fakeExecutorInstance := &fakeCommandExecutor{}
+
+	remoteMissingError := execshell.CommandFailedError{
+		Result: execshell.ExecutionResult{StandardError: "error: unable to delete 'feature/merged': remote ref does not exist\n", ExitCode: 1},
+	}
+	registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, branchName}, execshell.ExecutionResult{}, remoteMissingError)
+	registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, branchName}, execshell.ExecutionResult{ExitCode: 0}, nil)
+
+	service, serviceError := branches.NewService(zap.NewNop(), fakeExecutorInstance, nil)
+	require.NoError(testInstance, serviceError)
+
+	deleteError := service.DeleteBranch(context.Background(), branches.BranchDeletionOptions{
+		RemoteName:       testRemoteNameConstant,
+		BranchName:       " " + branchName + " ",
+		WorkingDirectory: testWorkingDirectoryConstant,
+	})
+	require.NoError(testInstance, deleteError)
+	require.Len(testInstance, fakeExecutorInstance.executedCommands, 2)
+	require.Equal(testInstance, testWorkingDirectoryConstant, fakeExecutorInstance.executedCommands[1].workingDirectory)
+
+	localFailedError := execshell.CommandFailedError{
+		Result: execshell.ExecutionResult{StandardError: "error: cannot delete branch 'feature/merged' used by worktree\n", ExitCode: 1},
+	}
+	registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, branchName}, execshell.ExecutionResult{ExitCode: 0}, nil)
+	registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, branchName}, execshell.ExecutionResult{}, localFailedError)
+
+	failedDeleteError := service.DeleteBranch(context.Background(), branches.BranchDeletionOptions{
+		RemoteName:       testRemoteNameConstant,
+		BranchName:       branchName,
+		WorkingDirectory: testWorkingDirectoryConstant,
+	})
+	require.ErrorContains(testInstance, failedDeleteError, "unable to delete branch feature/merged")
+	require.ErrorContains(testInstance, failedDeleteError, localFailedError.Error())
+
+	require.EqualError(testInstance, service.DeleteBranch(context.Background(), branches.BranchDeletionOptions{RemoteName: testRemoteNameConstant}), "branch name must be provided")
+	require.EqualError(testInstance, service.DeleteBranch(context.Background(), branches.BranchDeletionOptions{BranchName: branchName}), remoteNameErrorMessageConstant)
+}
+
 func TestNewServiceRequiresExecutor(testInstance *testing.T) {
 	service, serviceError := branches.NewService(zap.NewNop(), nil, nil)
 	require.Error(testInstance, serviceError)
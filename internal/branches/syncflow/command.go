@@ -30,7 +30,14 @@ const (
 		"Before fetch or content, index, ref, or checkout mutation, strict sync validates live worktree ownership, repairs only missing canonical Git links, then resolves exact per-worktree administrative paths and rejects operator-owned merge, revert, cherry-pick, rebase, apply-mailbox, bisect, sequencer, or unmerged-index state; ordinary refs with administrative names do not count. " +
 		"The strict-sync transaction snapshots the caller and target sibling checkout, commit, index, tracked files, untracked files, stashes, and topology, then journals only refs and worktrees it mutates. A failure before publication restores that owned state without rewinding unrelated refs; an up-to-date push remains rollback-capable. An actual remote ref update or pull-request creation marks publication, after which failure preserves the published recovery state and reports a handoff. Invocation-owned stashes are restored with their index and validated before SYNCED is reported. " +
 		"When a strict-sync merge conflicts, sync reconstructs untouched bytes locally and directly accepts only cases with no two-sided semantic choice: identical sides, a change on only one side, and marker-free current-stage decisions. Every marker-bearing region changed by both sides requires semantic LLM audit. Concurrent insertions and compatible token edits start from lossless locally derived candidates. Conflicting replacements start from the local alternative plus each compatible incoming edit. Sync sends every derived candidate directly to semantic audit. Candidate generation remains only when local token analysis cannot derive a valid candidate. Each candidate or audit request can exhaust the configured provider order. A provider round with no response stops semantic repair and starts rollback. A locally valid audit correction completes immediately. When exact replacement-intent proof is unavailable for a structurally valid correction, sync retains that exact correction for repair in the next semantic audit. An approval cannot accept that candidate. Only a later locally valid correction completes it. Responses that fail hard validation supply feedback for the next bounded attempt. Rollback occurs only after every safe candidate is exhausted, or after provider failure, cancellation, or an unrecoverable local failure, and always stops before push. " +
-		"Sync never rebases or force-pushes. When sync creates a pull request, the body is generated from the branch diff unless --body or sync.pull_request.body supplies explicit text; title defaults to the branch unless --title or sync.pull_request.title supplies it."
+		"Sync never rebases or force-pushes. When sync creates a pull request, the body is generated from the branch diff unless --body or sync.pull_request.body supplies explicit text; title defaults to the branch unless --title or sync.pull_request.title supplies it. " +
+		"A sync that touched conflicts writes a merge-resolution report under .git/gix/; --conflict-report prints it as Markdown and --conflict-report-pr appends it to a sync-created pull request body. " +
+		"--review-conflicts shows BASE, OURS, THEIRS, and the resolved candidate for every conflict region and lets you accept it, edit it in $EDITOR, pick OURS or THEIRS, or abort the sync with a full rollback. " +
+		"When an upstream remote exists (or --upstream names one), sync runs a fork workflow: it records the upstream and origin roles under remote.<name>.gix-role, merges base branches from the upstream remote, pushes work branches to origin, and opens cross-repository pull requests against the upstream repository with head owner:branch. " +
+		"Commands under sync.verify in the repository .gix.yml, or in user configuration, run after the base merge and before each push; a failure rolls the transaction back without pushing. " +
+		"Pull requests sync opens, including stack parents, take --label, --assignee, --reviewer, --milestone, --draft, and --auto-merge, layered over sync.pull_request defaults and its branch_prefix rules. " +
+		"Sync records the remote commit of each synced branch in branch.<name>.gix-synced-remote; when a later remote no longer contains it and local-only commits exist, sync stops with SYNC_FORCE_PUSH unless --on-force-push adopt-remote or keep-local is set. " +
+		"Every SYNC_SWITCH_HANDOFF writes a handoff record under .git/gix/sync-handoff.json; gix sync recover shows it and offers to finish the merge, reapply preserved stashes, and open the missing pull request."
 	missingBranchMessageConstant            = "unable to determine branch; provide a branch argument or configure a default branch"
 	syncCreatedSuffixConstant               = " (created)"
 	stashFlagNameConstant                   = "stash"
@@ -43,6 +50,28 @@ const (
 	pullRequestTitleFlagDescriptionConstant = "Set the title for a sync-created pull request"
 	pullRequestBodyFlagNameConstant         = taskOptionPullRequestBody
 	pullRequestBodyFlagDescriptionConstant  = "Set the body for a sync-created pull request"
+	conflictReportFlagNameConstant          = "conflict-report"
+	conflictReportFlagDescriptionConstant   = "Print the merge-conflict resolution report as Markdown when sync resolves conflicts"
+	conflictReportPRFlagNameConstant        = "conflict-report-pr"
+	conflictReportPRFlagDescriptionConstant = "Append the merge-conflict resolution report to a sync-created pull request body"
+	upstreamFlagNameConstant                = "upstream"
+	upstreamFlagDescriptionConstant         = "Merge base branches from this upstream remote and open cross-repository pull requests from the push remote (fork workflow)"
+	reviewConflictsFlagNameConstant         = "review-conflicts"
+	reviewConflictsFlagDescriptionConstant  = "Review every resolved conflict region interactively before sync commits the merge"
+	labelFlagNameConstant                   = "label"
+	labelFlagDescriptionConstant            = "Add a label to sync-created pull requests (repeatable)"
+	assigneeFlagNameConstant                = "assignee"
+	assigneeFlagDescriptionConstant         = "Assign a user to sync-created pull requests (repeatable)"
+	reviewerFlagNameConstant                = "reviewer"
+	reviewerFlagDescriptionConstant         = "Request review from a user or org/team on sync-created pull requests (repeatable)"
+	milestoneFlagNameConstant               = "milestone"
+	milestoneFlagDescriptionConstant        = "Set the milestone for sync-created pull requests"
+	draftFlagNameConstant                   = "draft"
+	draftFlagDescriptionConstant            = "Open sync-created pull requests as drafts"
+	autoMergeFlagNameConstant               = "auto-merge"
+	autoMergeFlagDescriptionConstant        = "Enable auto-merge on sync-created pull requests: squash, merge, rebase, or off"
+	onForcePushFlagNameConstant             = "on-force-push"
+	onForcePushFlagDescriptionConstant      = "Handle a remote branch force-pushed since the last sync: adopt-remote (back up local-only commits) or keep-local (publish them with a pull request)"
 	conflictingRecoveryFlagsMessageConstant = "use at most one of --stash or --commit"
 	remoteTargetExtraArgsMessage            = "remote sync target does not accept repository root arguments"
 	remoteTargetDirtyDirectoryMessage       = "remote sync target requires an empty directory when cloning"
@@ -84,6 +113,18 @@ func (builder *CommandBuilder) Build() (*cobra.Command, error) {
 	flagutils.AddToggleFlag(command.Flags(), nil, requireCleanFlagNameConstant, "", false, requireCleanFlagDescriptionConstant)
 	command.Flags().String(pullRequestTitleFlagNameConstant, "", pullRequestTitleFlagDescriptionConstant)
 	command.Flags().String(pullRequestBodyFlagNameConstant, "", pullRequestBodyFlagDescriptionConstant)
+	flagutils.AddToggleFlag(command.Flags(), nil, conflictReportFlagNameConstant, "", false, conflictReportFlagDescriptionConstant)
+	flagutils.AddToggleFlag(command.Flags(), nil, conflictReportPRFlagNameConstant, "", false, conflictReportPRFlagDescriptionConstant)
+	flagutils.AddToggleFlag(command.Flags(), nil, reviewConflictsFlagNameConstant, "", false, reviewConflictsFlagDescriptionConstant)
+	command.Flags().String(upstreamFlagNameConstant, "", upstreamFlagDescriptionConstant)
+	command.Flags().StringSlice(labelFlagNameConstant, nil, labelFlagDescriptionConstant)
+	command.Flags().StringSlice(assigneeFlagNameConstant, nil, assigneeFlagDescriptionConstant)
+	command.Flags().StringSlice(reviewerFlagNameConstant, nil, reviewerFlagDescriptionConstant)
+	command.Flags().String(milestoneFlagNameConstant, "", milestoneFlagDescriptionConstant)
+	flagutils.AddToggleFlag(command.Flags(), nil, draftFlagNameConstant, "", false, draftFlagDescriptionConstant)
+	command.Flags().String(autoMergeFlagNameConstant, "", autoMergeFlagDescriptionConstant)
+	command.Flags().String(onForcePushFlagNameConstant, "", onForcePushFlagDescriptionConstant)
+	command.AddCommand(builder.buildRecoverCommand())
 
 	return command, nil
 }
@@ -101,6 +142,12 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 	requireClean := configuration.RequireClean
 	pullRequestTitle := strings.TrimSpace(configuration.PullRequest.Title)
 	pullRequestBody := strings.TrimSpace(configuration.PullRequest.Body)
+	printConflictReport := configuration.ConflictReport.Print
+	pullRequestConflictReport := configuration.ConflictReport.PullRequest
+	reviewConflicts := configuration.ReviewConflicts
+	upstreamRemote := strings.TrimSpace(configuration.UpstreamRemote)
+	onForcePush := configuration.OnForcePush
+	var pullRequestOverrides strictSyncPullRequestSettings
 
 	if command != nil {
 		if flagValue, err := command.Flags().GetBool(stashFlagNameConstant); err == nil && command.Flags().Changed(stashFlagNameConstant) {
@@ -118,6 +165,46 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		if flagValue, err := command.Flags().GetString(pullRequestBodyFlagNameConstant); err == nil && command.Flags().Changed(pullRequestBodyFlagNameConstant) {
 			pullRequestBody = strings.TrimSpace(flagValue)
 		}
+		if flagValue, err := command.Flags().GetBool(conflictReportFlagNameConstant); err == nil && command.Flags().Changed(conflictReportFlagNameConstant) {
+			printConflictReport = flagValue
+		}
+		if flagValue, err := command.Flags().GetBool(conflictReportPRFlagNameConstant); err == nil && command.Flags().Changed(conflictReportPRFlagNameConstant) {
+			pullRequestConflictReport = flagValue
+		}
+		if flagValue, err := command.Flags().GetBool(reviewConflictsFlagNameConstant); err == nil && command.Flags().Changed(reviewConflictsFlagNameConstant) {
+			reviewConflicts = flagValue
+		}
+		if flagValue, err := command.Flags().GetString(upstreamFlagNameConstant); err == nil && command.Flags().Changed(upstreamFlagNameConstant) {
+			upstreamRemote = strings.TrimSpace(flagValue)
+		}
+		if flagValue, err := command.Flags().GetStringSlice(labelFlagNameConstant); err == nil && command.Flags().Changed(labelFlagNameConstant) {
+			pullRequestOverrides.Labels = sanitizeStrictSyncPullRequestValues(flagValue)
+		}
+		if flagValue, err := command.Flags().GetStringSlice(assigneeFlagNameConstant); err == nil && command.Flags().Changed(assigneeFlagNameConstant) {
+			pullRequestOverrides.Assignees = sanitizeStrictSyncPullRequestValues(flagValue)
+		}
+		if flagValue, err := command.Flags().GetStringSlice(reviewerFlagNameConstant); err == nil && command.Flags().Changed(reviewerFlagNameConstant) {
+			pullRequestOverrides.Reviewers = sanitizeStrictSyncPullRequestValues(flagValue)
+		}
+		if flagValue, err := command.Flags().GetString(milestoneFlagNameConstant); err == nil && command.Flags().Changed(milestoneFlagNameConstant) {
+			pullRequestOverrides.Milestone = strings.TrimSpace(flagValue)
+		}
+		if flagValue, err := command.Flags().GetBool(draftFlagNameConstant); err == nil && command.Flags().Changed(draftFlagNameConstant) {
+			pullRequestOverrides.Draft = &flagValue
+		}
+		if flagValue, err := command.Flags().GetString(autoMergeFlagNameConstant); err == nil && command.Flags().Changed(autoMergeFlagNameConstant) {
+			pullRequestOverrides.AutoMerge = strings.ToLower(strings.TrimSpace(flagValue))
+		}
+		if flagValue, err := command.Flags().GetString(onForcePushFlagNameConstant); err == nil && command.Flags().Changed(onForcePushFlagNameConstant) {
+			onForcePush = strings.ToLower(strings.TrimSpace(flagValue))
+		}
+	}
+	if forcePushModeErr := validateStrictSyncForcePushMode(onForcePush); forcePushModeErr != nil {
+		return forcePushModeErr
+	}
+	pullRequestPolicy, pullRequestPolicyErr := newStrictSyncPullRequestPolicy(configuration.PullRequest, pullRequestOverrides)
+	if pullRequestPolicyErr != nil {
+		return pullRequestPolicyErr
 	}
 
 	if stashRequested && commitRequested {
@@ -196,6 +283,25 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 	if len(pullRequestBody) > 0 {
 		actionOptions[taskOptionPullRequestBody] = pullRequestBody
 	}
+	if printConflictReport {
+		actionOptions[taskOptionConflictReport] = true
+	}
+	if pullRequestConflictReport {
+		actionOptions[taskOptionConflictReportInBody] = true
+	}
+	if len(upstreamRemote) > 0 {
+		actionOptions[taskOptionUpstreamRemote] = upstreamRemote
+	}
+	actionOptions[taskOptionPullRequestPolicy] = pullRequestPolicy
+	if len(onForcePush) > 0 {
+		actionOptions[taskOptionForcePushMode] = onForcePush
+	}
+	if len(configuration.Verify) > 0 {
+		actionOptions[taskOptionVerifyCommands] = append([]string(nil), configuration.Verify...)
+	}
+	if reviewConflicts {
+		actionOptions[taskOptionConflictReviewer] = newTerminalConflictReviewer(command.InOrStdin(), command.OutOrStdout(), runMergeConflictReviewEditor)
+	}
 	actionOptions[taskOptionWorktreeCommitMessage] = worktreeAdoptionCommitMessageOptionsFromConfiguration(configuration.CommitMessage)
 
 	taskBranchLabel := strings.TrimSpace(explicitBranch)
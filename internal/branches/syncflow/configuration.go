@@ -22,21 +22,50 @@ type CommitMessageConfiguration struct {
 
 // PullRequestConfiguration captures optional PR metadata overrides for sync-created pull requests.
 type PullRequestConfiguration struct {
-	Title string `mapstructure:"title"`
-	Body  string `mapstructure:"body"`
+	Title     string                         `mapstructure:"title"`
+	Body      string                         `mapstructure:"body"`
+	Labels    []string                       `mapstructure:"labels"`
+	Assignees []string                       `mapstructure:"assignees"`
+	Reviewers []string                       `mapstructure:"reviewers"`
+	Milestone string                         `mapstructure:"milestone"`
+	Draft     bool                           `mapstructure:"draft"`
+	AutoMerge string                         `mapstructure:"auto_merge"`
+	Rules     []PullRequestRuleConfiguration `mapstructure:"rules"`
+}
+
+// PullRequestRuleConfiguration adds PR metadata for sync-created pull requests whose branch starts with BranchPrefix.
+type PullRequestRuleConfiguration struct {
+	BranchPrefix string   `mapstructure:"branch_prefix"`
+	Labels       []string `mapstructure:"labels"`
+	Assignees    []string `mapstructure:"assignees"`
+	Reviewers    []string `mapstructure:"reviewers"`
+	Milestone    string   `mapstructure:"milestone"`
+	Draft        *bool    `mapstructure:"draft"`
+	AutoMerge    string   `mapstructure:"auto_merge"`
+}
+
+// ConflictReportConfiguration controls where the merge-conflict resolution report is surfaced beyond its Git-directory artifact.
+type ConflictReportConfiguration struct {
+	Print       bool `mapstructure:"print"`
+	PullRequest bool `mapstructure:"pull_request"`
 }
 
 // CommandConfiguration captures configuration values for the sync command.
 type CommandConfiguration struct {
-	RepositoryRoots []string                   `mapstructure:"roots"`
-	DefaultBranch   string                     `mapstructure:"branch"`
-	RemoteName      string                     `mapstructure:"remote"`
-	CreateIfMissing bool                       `mapstructure:"create_if_missing"`
-	RequireClean    bool                       `mapstructure:"require_clean"`
-	StashChanges    bool                       `mapstructure:"stash"`
-	CommitChanges   bool                       `mapstructure:"commit"`
-	CommitMessage   CommitMessageConfiguration `mapstructure:"commit_message"`
-	PullRequest     PullRequestConfiguration   `mapstructure:"pull_request"`
+	RepositoryRoots []string                    `mapstructure:"roots"`
+	DefaultBranch   string                      `mapstructure:"branch"`
+	RemoteName      string                      `mapstructure:"remote"`
+	UpstreamRemote  string                      `mapstructure:"upstream"`
+	CreateIfMissing bool                        `mapstructure:"create_if_missing"`
+	RequireClean    bool                        `mapstructure:"require_clean"`
+	StashChanges    bool                        `mapstructure:"stash"`
+	CommitChanges   bool                        `mapstructure:"commit"`
+	CommitMessage   CommitMessageConfiguration  `mapstructure:"commit_message"`
+	PullRequest     PullRequestConfiguration    `mapstructure:"pull_request"`
+	ConflictReport  ConflictReportConfiguration `mapstructure:"conflict_report"`
+	ReviewConflicts bool                        `mapstructure:"review_conflicts"`
+	Verify          []string                    `mapstructure:"verify"`
+	OnForcePush     string                      `mapstructure:"on_force_push"`
 }
 
 // DefaultCommandConfiguration returns the baseline configuration for sync.
@@ -60,6 +89,8 @@ func (configuration CommandConfiguration) Sanitize() CommandConfiguration {
 	sanitized.RemoteName = strings.TrimSpace(configuration.RemoteName)
 	sanitized.CommitMessage = configuration.CommitMessage.Sanitize()
 	sanitized.PullRequest = configuration.PullRequest.Sanitize()
+	sanitized.Verify = sanitizeStrictSyncVerifyCommands(configuration.Verify)
+	sanitized.OnForcePush = strings.ToLower(strings.TrimSpace(configuration.OnForcePush))
 	return sanitized
 }
 
@@ -68,6 +99,27 @@ func (configuration PullRequestConfiguration) Sanitize() PullRequestConfiguratio
 	sanitized := configuration
 	sanitized.Title = strings.TrimSpace(configuration.Title)
 	sanitized.Body = strings.TrimSpace(configuration.Body)
+	sanitized.Labels = sanitizeStrictSyncPullRequestValues(configuration.Labels)
+	sanitized.Assignees = sanitizeStrictSyncPullRequestValues(configuration.Assignees)
+	sanitized.Reviewers = sanitizeStrictSyncPullRequestValues(configuration.Reviewers)
+	sanitized.Milestone = strings.TrimSpace(configuration.Milestone)
+	sanitized.AutoMerge = strings.ToLower(strings.TrimSpace(configuration.AutoMerge))
+	sanitized.Rules = nil
+	for _, rule := range configuration.Rules {
+		sanitized.Rules = append(sanitized.Rules, rule.Sanitize())
+	}
+	return sanitized
+}
+
+// Sanitize normalizes a branch-prefix pull request rule.
+func (rule PullRequestRuleConfiguration) Sanitize() PullRequestRuleConfiguration {
+	sanitized := rule
+	sanitized.BranchPrefix = strings.TrimSpace(rule.BranchPrefix)
+	sanitized.Labels = sanitizeStrictSyncPullRequestValues(rule.Labels)
+	sanitized.Assignees = sanitizeStrictSyncPullRequestValues(rule.Assignees)
+	sanitized.Reviewers = sanitizeStrictSyncPullRequestValues(rule.Reviewers)
+	sanitized.Milestone = strings.TrimSpace(rule.Milestone)
+	sanitized.AutoMerge = strings.ToLower(strings.TrimSpace(rule.AutoMerge))
 	return sanitized
 }
 
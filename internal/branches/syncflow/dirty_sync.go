@@ -527,7 +527,7 @@ func prepareStrictSyncBranchForDirtyWork(ctx context.Context, environment *workf
 		if branchName == baseBranch {
 			return switchToLocalOrRemoteBranchWithAdoption(ctx, environment, repository, remoteName, branchName, commitMessages)
 		}
-		repositoryIdentifier := strictSyncRepositoryIdentifier(repository)
+		repositoryIdentifier := strictSyncRepositoryIdentifier(ctx, repository)
 		if repositoryIdentifier == "" {
 			return errors.New(strictSyncMissingRepositoryMessage)
 		}
@@ -555,7 +555,7 @@ func prepareStrictSyncBranchForDirtyWork(ctx context.Context, environment *workf
 		return switchToLocalOrRemoteBranchWithAdoption(ctx, environment, repository, remoteName, branchName, commitMessages)
 	}
 
-	baseReference := fmt.Sprintf("%s/%s", remoteName, baseBranch)
+	baseReference := fmt.Sprintf("%s/%s", strictSyncBaseRemote(ctx, remoteName), baseBranch)
 	baseExists, baseExistsErr := remoteReferenceExists(ctx, environment.GitExecutor, repository.Path, baseReference)
 	if baseExistsErr != nil {
 		return baseExistsErr
@@ -667,7 +667,7 @@ func selectGeneratedSyncBranchName(ctx context.Context, environment *workflow.En
 	if initialBranchErr != nil {
 		return "", initialBranchErr
 	}
-	repositoryIdentifier := strictSyncRepositoryIdentifier(repository)
+	repositoryIdentifier := strictSyncRepositoryIdentifier(ctx, repository)
 	for candidateIndex := 0; candidateIndex < strictSyncGeneratedBranchLimit; candidateIndex++ {
 		candidateBranchName := generatedSyncBranchCandidateName(initialBranchName, candidateIndex)
 		remoteReference := fmt.Sprintf("%s/%s", remoteName, candidateBranchName)
@@ -0,0 +1,252 @@
+package syncflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/githubcli"
+	"github.com/tyemirov/gix/internal/gitrepo"
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/workflow"
+)
+
+const (
+	gitConfigSubcommandConstant         = "config"
+	gitConfigGetFlagConstant            = "--get"
+	gitRemoteURLKeyTemplate             = "remote.%s.url"
+	strictSyncForkRemoteMissingTemplate = "upstream remote %q is not configured"
+	strictSyncForkSameRemoteTemplate    = "upstream remote %q must differ from push remote %q"
+	strictSyncForkRemoteURLTemplate     = "resolve %s remote %q repository: %w"
+	strictSyncForkMessageTemplate       = "fork workflow: merging from %s (%s), pushing to %s (%s)"
+	strictSyncForkDetectSkipTemplate    = "remote %q is not a GitHub fork pair (%v); syncing %s alone"
+	strictSyncForkRoleRecordTemplate    = "sync succeeded but the fork remote roles were not recorded: %v"
+	strictSyncForkStackTemplate         = "branch %q records the stacked review base %q, but a fork workflow cannot target fork branches; run git config --unset %s to review it against the upstream default branch"
+	strictSyncForkNotGitHubTemplate     = "remote URL %q is not hosted on %s"
+	strictSyncForkGitHubHostConstant    = "github.com"
+)
+
+// strictSyncFork describes a fork workflow: base branches come from the upstream remote while work branches are pushed to the origin remote.
+type strictSyncFork struct {
+	UpstreamRemote     string
+	OriginRemote       string
+	UpstreamRepository string
+	OriginRepository   string
+	HeadOwner          string
+}
+
+type strictSyncRemoteSelection struct {
+	PushRemote string
+	Fork       *strictSyncFork
+	// DetectionSkipped explains why a present upstream remote did not start a fork workflow.
+	DetectionSkipped string
+	// pendingRoles maps remote names to the roles recordStrictSyncRemoteRoles writes once the sync succeeds.
+	pendingRoles map[string]string
+}
+
+// resolveStrictSyncRemotes picks the push remote and, when an upstream remote is requested, recorded, or present, the fork roles.
+// A merely present upstream remote whose URLs do not name GitHub repositories keeps single-remote sync; nothing is written
+// to the repository configuration here.
+func resolveStrictSyncRemotes(ctx context.Context, executor shared.GitExecutor, repositoryPath string, remoteName string, upstreamRemote string) (strictSyncRemoteSelection, error) {
+	roles, rolesErr := gitrepo.RemoteRoles(ctx, executor, repositoryPath)
+	if rolesErr != nil {
+		return strictSyncRemoteSelection{}, rolesErr
+	}
+	pushRemote := strings.TrimSpace(remoteName)
+	if pushRemote == "" {
+		pushRemote = strings.TrimSpace(roles[gitrepo.RemoteRoleOrigin])
+	}
+	if pushRemote == "" {
+		pushRemote = defaultRemoteNameConstant
+	}
+
+	explicitUpstream := strings.TrimSpace(upstreamRemote)
+	upstream := explicitUpstream
+	if upstream == "" {
+		upstream = strings.TrimSpace(roles[gitrepo.RemoteRoleUpstream])
+	}
+	if upstream == "" {
+		upstream = gitrepo.RemoteRoleUpstream
+	}
+	if upstream == pushRemote {
+		if explicitUpstream != "" {
+			return strictSyncRemoteSelection{}, fmt.Errorf(strictSyncForkSameRemoteTemplate, upstream, pushRemote)
+		}
+		return strictSyncRemoteSelection{PushRemote: pushRemote}, nil
+	}
+
+	upstreamURL, upstreamExists, upstreamErr := strictSyncRemoteURL(ctx, executor, repositoryPath, upstream)
+	if upstreamErr != nil {
+		return strictSyncRemoteSelection{}, upstreamErr
+	}
+	if !upstreamExists {
+		if explicitUpstream != "" || roles[gitrepo.RemoteRoleUpstream] != "" {
+			return strictSyncRemoteSelection{}, fmt.Errorf(strictSyncForkRemoteMissingTemplate, upstream)
+		}
+		return strictSyncRemoteSelection{PushRemote: pushRemote}, nil
+	}
+	originURL, originExists, originErr := strictSyncRemoteURL(ctx, executor, repositoryPath, pushRemote)
+	if originErr != nil {
+		return strictSyncRemoteSelection{}, originErr
+	}
+	if !originExists {
+		return strictSyncRemoteSelection{}, fmt.Errorf(strictSyncForkRemoteMissingTemplate, pushRemote)
+	}
+	autoDetected := explicitUpstream == "" && roles[gitrepo.RemoteRoleUpstream] == ""
+	upstreamRepository, upstreamParseErr := parseStrictSyncGitHubRemote(upstreamURL)
+	if upstreamParseErr != nil {
+		if autoDetected {
+			return strictSyncRemoteSelection{PushRemote: pushRemote, DetectionSkipped: fmt.Sprintf(strictSyncForkDetectSkipTemplate, upstream, upstreamParseErr, pushRemote)}, nil
+		}
+		return strictSyncRemoteSelection{}, fmt.Errorf(strictSyncForkRemoteURLTemplate, gitrepo.RemoteRoleUpstream, upstream, upstreamParseErr)
+	}
+	originRepository, originParseErr := parseStrictSyncGitHubRemote(originURL)
+	if originParseErr != nil {
+		if autoDetected {
+			return strictSyncRemoteSelection{PushRemote: pushRemote, DetectionSkipped: fmt.Sprintf(strictSyncForkDetectSkipTemplate, upstream, originParseErr, pushRemote)}, nil
+		}
+		return strictSyncRemoteSelection{}, fmt.Errorf(strictSyncForkRemoteURLTemplate, gitrepo.RemoteRoleOrigin, pushRemote, originParseErr)
+	}
+
+	pendingRoles := map[string]string{}
+	if roles[gitrepo.RemoteRoleUpstream] != upstream {
+		pendingRoles[upstream] = gitrepo.RemoteRoleUpstream
+	}
+	if roles[gitrepo.RemoteRoleOrigin] != pushRemote {
+		pendingRoles[pushRemote] = gitrepo.RemoteRoleOrigin
+	}
+	return strictSyncRemoteSelection{
+		PushRemote:   pushRemote,
+		pendingRoles: pendingRoles,
+		Fork: &strictSyncFork{
+			UpstreamRemote:     upstream,
+			OriginRemote:       pushRemote,
+			UpstreamRepository: upstreamRepository.Owner + "/" + upstreamRepository.Repository,
+			OriginRepository:   originRepository.Owner + "/" + originRepository.Repository,
+			HeadOwner:          originRepository.Owner,
+		},
+	}, nil
+}
+
+// parseStrictSyncGitHubRemote parses a fork remote URL; fork workflows open pull requests on GitHub, so other hosts are rejected.
+func parseStrictSyncGitHubRemote(remoteURL string) (gitrepo.RemoteURL, error) {
+	parsedRemote, parseErr := gitrepo.ParseRemoteURL(remoteURL)
+	if parseErr != nil {
+		return gitrepo.RemoteURL{}, parseErr
+	}
+	if !strings.EqualFold(parsedRemote.Host, strictSyncForkGitHubHostConstant) {
+		return gitrepo.RemoteURL{}, fmt.Errorf(strictSyncForkNotGitHubTemplate, remoteURL, strictSyncForkGitHubHostConstant)
+	}
+	return parsedRemote, nil
+}
+
+// recordStrictSyncRemoteRoles writes the fork roles the selection resolved, upstream first, so the next sync finds renamed remotes.
+func recordStrictSyncRemoteRoles(ctx context.Context, executor shared.GitExecutor, repositoryPath string, selection strictSyncRemoteSelection) error {
+	for _, role := range []string{gitrepo.RemoteRoleUpstream, gitrepo.RemoteRoleOrigin} {
+		for remoteName, pendingRole := range selection.pendingRoles {
+			if pendingRole != role {
+				continue
+			}
+			if recordErr := gitrepo.RecordRemoteRole(ctx, executor, repositoryPath, remoteName, role); recordErr != nil {
+				return recordErr
+			}
+		}
+	}
+	return nil
+}
+
+// refuseStrictSyncForkStack fails a fork sync of a branch that records a stacked review base. Cross-repository pull
+// requests can only target upstream branches, so syncing it would silently review the branch against the default branch.
+func refuseStrictSyncForkStack(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, branchName string) error {
+	reviewBase, reviewBaseErr := strictSyncStackReviewBase(ctx, environment.GitExecutor, repository.Path, branchName)
+	if reviewBaseErr != nil {
+		return reviewBaseErr
+	}
+	if reviewBase == "" {
+		return nil
+	}
+	message := fmt.Sprintf(strictSyncForkStackTemplate, branchName, reviewBase, gitrepo.BranchReviewBaseKey(branchName))
+	environment.ReportRepositoryEvent(repository, shared.EventLevelError, shared.EventCodeSyncFork, message, map[string]string{
+		"branch":      branchName,
+		"review_base": reviewBase,
+	})
+	return errors.New(message)
+}
+
+// strictSyncRemoteURL reads the configured URL rather than get-url so insteadOf rewrites do not hide the GitHub repository.
+func strictSyncRemoteURL(ctx context.Context, executor shared.GitExecutor, repositoryPath string, remoteName string) (string, bool, error) {
+	result, remoteErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments:        []string{gitConfigSubcommandConstant, gitConfigGetFlagConstant, fmt.Sprintf(gitRemoteURLKeyTemplate, remoteName)},
+		WorkingDirectory: repositoryPath,
+	})
+	if remoteErr == nil {
+		remoteURL := strings.TrimSpace(result.StandardOutput)
+		return remoteURL, remoteURL != "", nil
+	}
+	var commandFailure execshell.CommandFailedError
+	if errors.As(remoteErr, &commandFailure) && commandFailure.Result.ExitCode == 1 {
+		return "", false, nil
+	}
+	return "", false, remoteErr
+}
+
+func reportStrictSyncFork(environment *workflow.Environment, repository *workflow.RepositoryState, fork *strictSyncFork) {
+	if environment == nil || fork == nil {
+		return
+	}
+	environment.ReportRepositoryEvent(
+		repository,
+		shared.EventLevelInfo,
+		shared.EventCodeSyncFork,
+		fmt.Sprintf(strictSyncForkMessageTemplate, fork.UpstreamRemote, fork.UpstreamRepository, fork.OriginRemote, fork.OriginRepository),
+		map[string]string{
+			"upstream_remote": fork.UpstreamRemote,
+			"upstream":        fork.UpstreamRepository,
+			"origin_remote":   fork.OriginRemote,
+			"origin":          fork.OriginRepository,
+		},
+	)
+}
+
+func strictSyncForkFromContext(ctx context.Context) *strictSyncFork {
+	transaction, ok := strictSyncTransactionFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return transaction.fork
+}
+
+// strictSyncBaseRemote returns the remote that holds base branches: upstream in a fork workflow, otherwise remoteName.
+func strictSyncBaseRemote(ctx context.Context, remoteName string) string {
+	if fork := strictSyncForkFromContext(ctx); fork != nil {
+		return fork.UpstreamRemote
+	}
+	return remoteName
+}
+
+// strictSyncPullRequestHead qualifies the head branch with the fork owner for cross-repository pull requests.
+func strictSyncPullRequestHead(ctx context.Context, branchName string) string {
+	if fork := strictSyncForkFromContext(ctx); fork != nil && fork.HeadOwner != "" {
+		return fork.HeadOwner + ":" + branchName
+	}
+	return branchName
+}
+
+// strictSyncForkPullRequests keeps the pull requests whose head lives in the fork. The upstream
+// repository lists pull requests from every fork, and `gh --head` matches the branch name alone, so a
+// same-named branch in another contributor's fork must not be adopted.
+func strictSyncForkPullRequests(ctx context.Context, pullRequests []githubcli.PullRequest) []githubcli.PullRequest {
+	fork := strictSyncForkFromContext(ctx)
+	if fork == nil {
+		return pullRequests
+	}
+	forkPullRequests := make([]githubcli.PullRequest, 0, len(pullRequests))
+	for _, pullRequest := range pullRequests {
+		if strings.EqualFold(strings.TrimSpace(pullRequest.HeadRepositoryNameWithOwner), fork.OriginRepository) {
+			forkPullRequests = append(forkPullRequests, pullRequest)
+		}
+	}
+	return forkPullRequests
+}
@@ -0,0 +1,278 @@
+package syncflow
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/githubcli"
+	"github.com/tyemirov/gix/internal/gitrepo"
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/workflow"
+)
+
+type forkSyncConfigExecutor struct {
+	values map[string]string
+}
+
+func (executor *forkSyncConfigExecutor) ExecuteGit(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	arguments := details.Arguments
+	missing := execshell.CommandFailedError{Result: execshell.ExecutionResult{ExitCode: 1}}
+	if len(arguments) == 0 || arguments[0] != "config" {
+		return execshell.ExecutionResult{}, nil
+	}
+	switch {
+	case len(arguments) == 3 && arguments[1] == "--get":
+		value, exists := executor.values[arguments[2]]
+		if !exists {
+			return execshell.ExecutionResult{}, missing
+		}
+		return execshell.ExecutionResult{StandardOutput: value + "\n"}, nil
+	case len(arguments) == 5 && arguments[3] == "--get":
+		value, exists := executor.values[arguments[4]]
+		if !exists {
+			return execshell.ExecutionResult{}, missing
+		}
+		return execshell.ExecutionResult{StandardOutput: value + "\n"}, nil
+	case len(arguments) == 5 && arguments[3] == "--get-regexp":
+		keys := make([]string, 0, len(executor.values))
+		for key := range executor.values {
+			if strings.HasPrefix(key, "remote.") && strings.HasSuffix(key, ".gix-role") {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			return execshell.ExecutionResult{}, missing
+		}
+		sort.Strings(keys)
+		lines := make([]string, 0, len(keys))
+		for _, key := range keys {
+			lines = append(lines, key+" "+executor.values[key])
+		}
+		return execshell.ExecutionResult{StandardOutput: strings.Join(lines, "\n") + "\n"}, nil
+	case len(arguments) == 5 && arguments[3] == "--unset-all":
+		delete(executor.values, arguments[4])
+		return execshell.ExecutionResult{}, nil
+	case len(arguments) == 5:
+		executor.values[arguments[3]] = arguments[4]
+		return execshell.ExecutionResult{}, nil
+	}
+	return execshell.ExecutionResult{}, nil
+}
+
+func (executor *forkSyncConfigExecutor) ExecuteGitHubCLI(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	return execshell.ExecutionResult{}, nil
+}
+
+func TestResolveStrictSyncRemotes(t *testing.T) {
+	testCases := []struct {
+		name           string
+		values         map[string]string
+		remoteName     string
+		upstreamRemote string
+		expectedPush   string
+		expectedFork   *strictSyncFork
+		expectedSkip   string
+		expectedRoles  map[string]string
+		expectedError  string
+	}{
+		{
+			name:         "single remote keeps same-repository sync",
+			values:       map[string]string{"remote.origin.url": "git@github.com:owner/project.git"},
+			expectedPush: "origin",
+		},
+		{
+			name: "upstream remote is detected and both roles are recorded",
+			values: map[string]string{
+				"remote.origin.url":   "git@github.com:contributor/project.git",
+				"remote.upstream.url": "https://github.com/owner/project.git",
+			},
+			expectedPush: "origin",
+			expectedFork: &strictSyncFork{
+				UpstreamRemote:     "upstream",
+				OriginRemote:       "origin",
+				UpstreamRepository: "owner/project",
+				OriginRepository:   "contributor/project",
+				HeadOwner:          "contributor",
+			},
+			expectedRoles: map[string]string{"upstream": "upstream", "origin": "origin"},
+		},
+		{
+			name: "recorded roles choose renamed remotes",
+			values: map[string]string{
+				"remote.mine.url":                  "git@github.com:contributor/project.git",
+				"remote.canonical.url":             "git@github.com:owner/project.git",
+				"remote.mine.gix-role":             "origin",
+				gitrepo.RemoteRoleKey("canonical"): "upstream",
+			},
+			expectedPush: "mine",
+			expectedFork: &strictSyncFork{
+				UpstreamRemote:     "canonical",
+				OriginRemote:       "mine",
+				UpstreamRepository: "owner/project",
+				OriginRepository:   "contributor/project",
+				HeadOwner:          "contributor",
+			},
+			expectedRoles: map[string]string{"upstream": "canonical", "origin": "mine"},
+		},
+		{
+			name: "explicit upstream moves the recorded role",
+			values: map[string]string{
+				"remote.origin.url":        "git@github.com:contributor/project.git",
+				"remote.upstream.url":      "git@github.com:owner/project.git",
+				"remote.canonical.url":     "git@github.com:owner/canonical.git",
+				"remote.upstream.gix-role": "upstream",
+			},
+			upstreamRemote: "canonical",
+			expectedPush:   "origin",
+			expectedFork: &strictSyncFork{
+				UpstreamRemote:     "canonical",
+				OriginRemote:       "origin",
+				UpstreamRepository: "owner/canonical",
+				OriginRepository:   "contributor/project",
+				HeadOwner:          "contributor",
+			},
+			expectedRoles: map[string]string{"upstream": "canonical", "origin": "origin"},
+		},
+		{
+			name: "detected upstream outside GitHub keeps single-remote sync",
+			values: map[string]string{
+				"remote.origin.url":   "git@github.com:contributor/project.git",
+				"remote.upstream.url": "https://gitlab.example.com/owner/project.git",
+			},
+			expectedPush: "origin",
+			expectedSkip: `remote "upstream" is not a GitHub fork pair`,
+		},
+		{
+			name: "detected upstream with a local origin keeps single-remote sync",
+			values: map[string]string{
+				"remote.origin.url":   "/srv/mirrors/project.git",
+				"remote.upstream.url": "git@github.com:owner/project.git",
+			},
+			expectedPush: "origin",
+			expectedSkip: `remote "upstream" is not a GitHub fork pair`,
+		},
+		{
+			name: "explicit upstream outside GitHub fails",
+			values: map[string]string{
+				"remote.origin.url":   "git@github.com:contributor/project.git",
+				"remote.upstream.url": "https://gitlab.example.com/owner/project.git",
+			},
+			upstreamRemote: "upstream",
+			expectedError:  `resolve upstream remote "upstream" repository`,
+		},
+		{
+			name:           "explicit upstream must exist",
+			values:         map[string]string{"remote.origin.url": "git@github.com:contributor/project.git"},
+			upstreamRemote: "upstream",
+			expectedError:  `upstream remote "upstream" is not configured`,
+		},
+		{
+			name:           "explicit upstream must differ from push remote",
+			values:         map[string]string{"remote.origin.url": "git@github.com:contributor/project.git"},
+			upstreamRemote: "origin",
+			expectedError:  `upstream remote "origin" must differ from push remote "origin"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			executor := &forkSyncConfigExecutor{values: testCase.values}
+
+			selection, selectionErr := resolveStrictSyncRemotes(context.Background(), executor, "/repo", testCase.remoteName, testCase.upstreamRemote)
+
+			if testCase.expectedError != "" {
+				require.ErrorContains(t, selectionErr, testCase.expectedError)
+				return
+			}
+			require.NoError(t, selectionErr)
+			require.Equal(t, testCase.expectedPush, selection.PushRemote)
+			require.Equal(t, testCase.expectedFork, selection.Fork)
+			if testCase.expectedSkip == "" {
+				require.Empty(t, selection.DetectionSkipped)
+			} else {
+				require.Contains(t, selection.DetectionSkipped, testCase.expectedSkip)
+			}
+			recordedBeforeSync := map[string]string{}
+			for key, value := range testCase.values {
+				recordedBeforeSync[key] = value
+			}
+			require.Equal(t, recordedBeforeSync, executor.values, "roles must wait for the sync to succeed")
+
+			require.NoError(t, recordStrictSyncRemoteRoles(context.Background(), executor, "/repo", selection))
+			roles, rolesErr := gitrepo.RemoteRoles(context.Background(), executor, "/repo")
+			require.NoError(t, rolesErr)
+			if testCase.expectedRoles == nil {
+				require.Empty(t, roles)
+				return
+			}
+			require.Equal(t, testCase.expectedRoles, roles)
+		})
+	}
+}
+
+func TestStrictSyncForkContextRedirectsBaseRemoteAndPullRequestHead(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, "origin", strictSyncBaseRemote(ctx, "origin"))
+	require.Equal(t, "feature/fork", strictSyncPullRequestHead(ctx, "feature/fork"))
+
+	forkContext := withStrictSyncTransaction(ctx, &strictSyncTransaction{fork: &strictSyncFork{
+		UpstreamRemote:     "upstream",
+		OriginRemote:       "origin",
+		UpstreamRepository: "owner/project",
+		HeadOwner:          "contributor",
+	}})
+	require.Equal(t, "upstream", strictSyncBaseRemote(forkContext, "origin"))
+	require.Equal(t, "contributor:feature/fork", strictSyncPullRequestHead(forkContext, "feature/fork"))
+	require.Equal(t, "owner/project", strictSyncRepositoryIdentifier(forkContext, nil))
+}
+
+func TestOpenPullRequestForBranchMatchesForkHeadRepository(t *testing.T) {
+	githubClient, githubClientErr := githubcli.NewClient(&strictSyncGitHubExecutor{output: `[` +
+		`{"number":11,"title":"Other fork","headRefName":"feature/fork","headRepository":{"nameWithOwner":"someone/project"},"baseRefName":"main"},` +
+		`{"number":12,"title":"Our fork","headRefName":"feature/fork","headRepository":{"nameWithOwner":"Contributor/Project"},"baseRefName":"main"}]`})
+	require.NoError(t, githubClientErr)
+	environment := &workflow.Environment{GitHubClient: githubClient}
+
+	unscoped, unscopedErr := openPullRequestForBranch(context.Background(), environment, "owner/project", "feature/fork")
+	require.NoError(t, unscopedErr)
+	require.Equal(t, 11, unscoped.Number)
+
+	forkContext := withStrictSyncTransaction(context.Background(), &strictSyncTransaction{fork: &strictSyncFork{
+		UpstreamRemote:     "upstream",
+		OriginRemote:       "origin",
+		UpstreamRepository: "owner/project",
+		OriginRepository:   "contributor/project",
+		HeadOwner:          "contributor",
+	}})
+	forkPullRequest, forkErr := openPullRequestForBranch(forkContext, environment, "owner/project", "feature/fork")
+	require.NoError(t, forkErr)
+	require.NotNil(t, forkPullRequest)
+	require.Equal(t, 12, forkPullRequest.Number)
+
+	otherForkContext := withStrictSyncTransaction(context.Background(), &strictSyncTransaction{fork: &strictSyncFork{OriginRepository: "stranger/project"}})
+	missingPullRequest, missingErr := openPullRequestForBranch(otherForkContext, environment, "owner/project", "feature/fork")
+	require.NoError(t, missingErr)
+	require.Nil(t, missingPullRequest)
+}
+
+func TestRefuseStrictSyncForkStackReportsRecordedReviewBase(t *testing.T) {
+	executor := &forkSyncConfigExecutor{values: map[string]string{gitrepo.BranchReviewBaseKey("feature/child"): "feature/parent"}}
+	reporter := &recordingReporter{}
+	environment := &workflow.Environment{GitExecutor: executor, Reporter: reporter}
+	repository := &workflow.RepositoryState{Path: "/repo"}
+
+	require.NoError(t, refuseStrictSyncForkStack(context.Background(), environment, repository, "feature/other"))
+	require.Empty(t, reporter.events)
+
+	stackErr := refuseStrictSyncForkStack(context.Background(), environment, repository, "feature/child")
+	require.ErrorContains(t, stackErr, `branch "feature/child" records the stacked review base "feature/parent"`)
+	require.Len(t, reporter.events, 1)
+	require.Equal(t, shared.EventCodeSyncFork, reporter.events[0].Code)
+	require.Equal(t, shared.EventLevelError, reporter.events[0].Level)
+	require.Equal(t, "feature/parent", reporter.events[0].Details["review_base"])
+}
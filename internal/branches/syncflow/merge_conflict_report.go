@@ -0,0 +1,341 @@
+package syncflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/workflow"
+)
+
+const (
+	mergeConflictReportSchemaVersion        = "gix.merge-resolution/v1"
+	mergeConflictReportDirectoryName        = "gix"
+	mergeConflictReportFileTemplate         = "merge-resolution-%s.json"
+	mergeConflictReportTimestampLayout      = "20060102T150405.000000000Z"
+	mergeConflictReportDirectoryPermissions = 0o755
+	mergeConflictReportFilePermissions      = 0o644
+	mergeConflictReportDiffContextLines     = 3
+	mergeConflictReportWrittenMessage       = "merge conflict resolution report written to %s"
+	mergeConflictReportWriteFailureTemplate = "merge conflict resolution report was not written: %s"
+	mergeConflictReportWriteTemplate        = "write merge conflict resolution report %s: %w"
+	mergeConflictReportSemanticStrategy     = "semantic candidate"
+
+	mergeConflictReportOutcomeResolved   = "resolved"
+	mergeConflictReportOutcomeFailed     = "failed"
+	mergeConflictReportOutcomeRolledBack = "rolled_back"
+	mergeConflictReportOutcomeHandoff    = "handoff"
+
+	mergeConflictReportDecisionDeterministic = "deterministic"
+	mergeConflictReportDecisionApproved      = "approved"
+	mergeConflictReportDecisionCorrected     = "corrected"
+	mergeConflictReportDecisionUnresolved    = "unresolved"
+)
+
+// mergeConflictReport is the structured artifact written after a strict sync touched conflicts.
+type mergeConflictReport struct {
+	Schema       string                     `json:"schema"`
+	GeneratedAt  string                     `json:"generated_at"`
+	Repository   string                     `json:"repository"`
+	TargetBranch string                     `json:"target_branch"`
+	Merges       []mergeConflictReportMerge `json:"merges"`
+}
+
+type mergeConflictReportMerge struct {
+	SourceReference string                    `json:"source_reference"`
+	TargetBranch    string                    `json:"target_branch"`
+	Outcome         string                    `json:"outcome"`
+	Files           []mergeConflictReportFile `json:"files"`
+}
+
+type mergeConflictReportFile struct {
+	Path     string                      `json:"path"`
+	Strategy string                      `json:"strategy,omitempty"`
+	Deleted  bool                        `json:"deleted,omitempty"`
+	Regions  []mergeConflictReportRegion `json:"regions,omitempty"`
+}
+
+type mergeConflictReportRegion struct {
+	Region     int    `json:"region"`
+	Regions    int    `json:"regions"`
+	Strategy   string `json:"strategy"`
+	Attempts   int    `json:"attempts"`
+	Decision   string `json:"decision"`
+	Operator   string `json:"operator,omitempty"`
+	Candidate  string `json:"candidate"`
+	OursDiff   string `json:"ours_diff"`
+	TheirsDiff string `json:"theirs_diff"`
+}
+
+// mergeConflictReportRecorder collects resolver decisions for every conflicted merge in one strict sync.
+type mergeConflictReportRecorder struct {
+	merges []mergeConflictReportMerge
+}
+
+func (recorder *mergeConflictReportRecorder) beginMerge(options mergeConflictResolutionOptions) {
+	if recorder == nil {
+		return
+	}
+	recorder.merges = append(recorder.merges, mergeConflictReportMerge{
+		SourceReference: strings.TrimSpace(options.SourceReference),
+		TargetBranch:    strings.TrimSpace(options.TargetBranch),
+		Outcome:         mergeConflictReportOutcomeFailed,
+	})
+}
+
+func (recorder *mergeConflictReportRecorder) finishMerge(outcome string) {
+	if recorder == nil || len(recorder.merges) == 0 {
+		return
+	}
+	recorder.merges[len(recorder.merges)-1].Outcome = outcome
+}
+
+func (recorder *mergeConflictReportRecorder) recordMarkerFreeFile(path string, strategy string, deleted bool) {
+	file := recorder.file(path)
+	if file == nil {
+		return
+	}
+	file.Strategy = strategy
+	file.Deleted = deleted
+}
+
+func (recorder *mergeConflictReportRecorder) noteRegionStrategy(path string, regionIndex int, regionCount int, strategy string) {
+	region := recorder.region(path, regionIndex, regionCount)
+	if region == nil {
+		return
+	}
+	region.Strategy = strategy
+}
+
+func (recorder *mergeConflictReportRecorder) recordRegion(path string, regionIndex int, regionCount int, conflictRegion mergeConflictRegion, attempts int, decision string, candidate string) {
+	region := recorder.region(path, regionIndex, regionCount)
+	if region == nil {
+		return
+	}
+	if region.Strategy == "" {
+		region.Strategy = mergeConflictReportSemanticStrategy
+	}
+	region.Attempts = attempts
+	region.Decision = decision
+	region.Candidate = candidate
+	region.OursDiff = ""
+	region.TheirsDiff = ""
+	if decision != mergeConflictReportDecisionUnresolved {
+		region.OursDiff = mergeConflictReportDiff("OURS", conflictRegion.Ours, candidate)
+		region.TheirsDiff = mergeConflictReportDiff("THEIRS", conflictRegion.Theirs, candidate)
+	}
+}
+
+func (recorder *mergeConflictReportRecorder) recordOperatorChoice(path string, regionIndex int, regionCount int, conflictRegion mergeConflictRegion, choice string, content string) {
+	region := recorder.region(path, regionIndex, regionCount)
+	if region == nil {
+		return
+	}
+	region.Operator = choice
+	region.Candidate = content
+	region.OursDiff = mergeConflictReportDiff("OURS", conflictRegion.Ours, content)
+	region.TheirsDiff = mergeConflictReportDiff("THEIRS", conflictRegion.Theirs, content)
+}
+
+func (recorder *mergeConflictReportRecorder) regionStrategy(path string, regionIndex int) string {
+	if recorder == nil || len(recorder.merges) == 0 {
+		return mergeConflictReportSemanticStrategy
+	}
+	merge := recorder.merges[len(recorder.merges)-1]
+	for fileIndex := range merge.Files {
+		if merge.Files[fileIndex].Path != path {
+			continue
+		}
+		for _, region := range merge.Files[fileIndex].Regions {
+			if region.Region == regionIndex+1 && region.Strategy != "" {
+				return region.Strategy
+			}
+		}
+	}
+	return mergeConflictReportSemanticStrategy
+}
+
+func (recorder *mergeConflictReportRecorder) file(path string) *mergeConflictReportFile {
+	if recorder == nil || len(recorder.merges) == 0 {
+		return nil
+	}
+	merge := &recorder.merges[len(recorder.merges)-1]
+	for fileIndex := range merge.Files {
+		if merge.Files[fileIndex].Path == path {
+			return &merge.Files[fileIndex]
+		}
+	}
+	merge.Files = append(merge.Files, mergeConflictReportFile{Path: path})
+	return &merge.Files[len(merge.Files)-1]
+}
+
+func (recorder *mergeConflictReportRecorder) region(path string, regionIndex int, regionCount int) *mergeConflictReportRegion {
+	file := recorder.file(path)
+	if file == nil {
+		return nil
+	}
+	for existingIndex := range file.Regions {
+		if file.Regions[existingIndex].Region == regionIndex+1 {
+			return &file.Regions[existingIndex]
+		}
+	}
+	file.Regions = append(file.Regions, mergeConflictReportRegion{Region: regionIndex + 1, Regions: regionCount})
+	return &file.Regions[len(file.Regions)-1]
+}
+
+func (recorder *mergeConflictReportRecorder) empty() bool {
+	return recorder == nil || len(recorder.merges) == 0
+}
+
+func (recorder *mergeConflictReportRecorder) report(repositoryPath string, targetBranch string, generatedAt time.Time) mergeConflictReport {
+	merges := []mergeConflictReportMerge{}
+	if recorder != nil {
+		merges = append(merges, recorder.merges...)
+	}
+	return mergeConflictReport{
+		Schema:       mergeConflictReportSchemaVersion,
+		GeneratedAt:  generatedAt.UTC().Format(time.RFC3339),
+		Repository:   repositoryPath,
+		TargetBranch: targetBranch,
+		Merges:       merges,
+	}
+}
+
+func mergeConflictReportRecorderFromContext(ctx context.Context) *mergeConflictReportRecorder {
+	transaction, ok := strictSyncTransactionFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if transaction.conflictReport == nil {
+		transaction.conflictReport = &mergeConflictReportRecorder{}
+	}
+	return transaction.conflictReport
+}
+
+func mergeConflictReportDiff(label string, side string, candidate string) string {
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(mergeConflictReportText(side)),
+		B:        difflib.SplitLines(mergeConflictReportText(candidate)),
+		FromFile: label,
+		ToFile:   "candidate",
+		Context:  mergeConflictReportDiffContextLines,
+	})
+	return diff
+}
+
+func mergeConflictReportText(value string) string {
+	if value == "" || strings.HasSuffix(value, "\n") {
+		return value
+	}
+	return value + "\n"
+}
+
+// Markdown renders the report for terminal output and pull-request bodies.
+func (report mergeConflictReport) Markdown() string {
+	var builder strings.Builder
+	builder.WriteString("## Merge conflict resolution\n")
+	for mergeIndex := range report.Merges {
+		merge := report.Merges[mergeIndex]
+		fmt.Fprintf(&builder, "\n### `%s` into `%s`: %s\n", merge.SourceReference, merge.TargetBranch, merge.Outcome)
+		for fileIndex := range merge.Files {
+			file := merge.Files[fileIndex]
+			if len(file.Regions) == 0 {
+				fmt.Fprintf(&builder, "\n- `%s`: %s\n", file.Path, file.Strategy)
+				continue
+			}
+			for regionIndex := range file.Regions {
+				region := file.Regions[regionIndex]
+				fmt.Fprintf(&builder, "\n#### `%s` region %d/%d\n\n", file.Path, region.Region, region.Regions)
+				fmt.Fprintf(&builder, "- Strategy: %s\n", region.Strategy)
+				fmt.Fprintf(&builder, "- Attempts: %d\n", region.Attempts)
+				fmt.Fprintf(&builder, "- Decision: %s\n", region.Decision)
+				if region.Operator != "" {
+					fmt.Fprintf(&builder, "- Operator: %s\n", region.Operator)
+				}
+				writeMergeConflictReportDiff(&builder, region.OursDiff)
+				writeMergeConflictReportDiff(&builder, region.TheirsDiff)
+			}
+		}
+	}
+	return builder.String()
+}
+
+func writeMergeConflictReportDiff(builder *strings.Builder, diff string) {
+	if strings.TrimSpace(diff) == "" {
+		return
+	}
+	builder.WriteString("\n```diff\n")
+	builder.WriteString(mergeConflictReportText(diff))
+	builder.WriteString("```\n")
+}
+
+func writeMergeConflictReport(ctx context.Context, executor shared.GitExecutor, repositoryPath string, report mergeConflictReport, generatedAt time.Time) (string, error) {
+	commonDirectory, commonDirectoryErr := resolveStrictSyncCommonDirectory(ctx, executor, repositoryPath)
+	if commonDirectoryErr != nil {
+		return "", fmt.Errorf(mergeConflictReportWriteTemplate, repositoryPath, commonDirectoryErr)
+	}
+	reportDirectory := filepath.Join(commonDirectory, mergeConflictReportDirectoryName)
+	reportPath := filepath.Join(reportDirectory, fmt.Sprintf(mergeConflictReportFileTemplate, generatedAt.UTC().Format(mergeConflictReportTimestampLayout)))
+	contents, encodeErr := json.MarshalIndent(report, "", "  ")
+	if encodeErr != nil {
+		return "", fmt.Errorf(mergeConflictReportWriteTemplate, reportPath, encodeErr)
+	}
+	if directoryErr := os.MkdirAll(reportDirectory, mergeConflictReportDirectoryPermissions); directoryErr != nil {
+		return "", fmt.Errorf(mergeConflictReportWriteTemplate, reportPath, directoryErr)
+	}
+	if writeErr := os.WriteFile(reportPath, append(contents, '\n'), mergeConflictReportFilePermissions); writeErr != nil {
+		return "", fmt.Errorf(mergeConflictReportWriteTemplate, reportPath, writeErr)
+	}
+	return reportPath, nil
+}
+
+// publishMergeConflictReport writes the transaction's conflict report and optionally prints it as Markdown.
+// A write failure is reported as a warning because the sync outcome is already decided.
+func publishMergeConflictReport(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, transaction *strictSyncTransaction, printMarkdown bool) {
+	if transaction == nil || transaction.conflictReport.empty() {
+		return
+	}
+	generatedAt := time.Now()
+	report := transaction.conflictReport.report(repository.Path, transaction.targetBranch, generatedAt)
+	reportPath, writeErr := writeMergeConflictReport(ctx, environment.GitExecutor, repository.Path, report, generatedAt)
+	if writeErr != nil {
+		environment.ReportRepositoryEvent(
+			repository,
+			shared.EventLevelWarn,
+			shared.EventCodeAIMergeReport,
+			fmt.Sprintf(mergeConflictReportWriteFailureTemplate, strings.TrimSpace(writeErr.Error())),
+			map[string]string{"reason": writeErr.Error()},
+		)
+	} else {
+		environment.ReportRepositoryEvent(
+			repository,
+			shared.EventLevelInfo,
+			shared.EventCodeAIMergeReport,
+			fmt.Sprintf(mergeConflictReportWrittenMessage, reportPath),
+			map[string]string{
+				"path":   reportPath,
+				"merges": strconv.Itoa(len(report.Merges)),
+			},
+		)
+	}
+	if printMarkdown && environment.Output != nil {
+		fmt.Fprint(environment.Output, report.Markdown())
+	}
+}
+
+// mergeConflictReportPullRequestSection returns the Markdown report collected so far for a sync-created pull request body.
+func mergeConflictReportPullRequestSection(ctx context.Context, repositoryPath string) string {
+	transaction, ok := strictSyncTransactionFromContext(ctx)
+	if !ok || transaction.conflictReport.empty() {
+		return ""
+	}
+	return transaction.conflictReport.report(repositoryPath, transaction.targetBranch, time.Now()).Markdown()
+}
@@ -0,0 +1,156 @@
+package syncflow
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/utils/llm"
+)
+
+type mergeConflictReportCommonDirectoryExecutor struct {
+	commonDirectory string
+}
+
+func (executor mergeConflictReportCommonDirectoryExecutor) ExecuteGit(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	if strings.Join(details.Arguments, " ") == "rev-parse --git-common-dir" {
+		return execshell.ExecutionResult{StandardOutput: executor.commonDirectory + "\n"}, nil
+	}
+	return execshell.ExecutionResult{}, nil
+}
+
+func (executor mergeConflictReportCommonDirectoryExecutor) ExecuteGitHubCLI(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	return execshell.ExecutionResult{}, nil
+}
+
+func TestMergeConflictReportRecordsDeterministicAndAuditedRegions(t *testing.T) {
+	content := "stable prefix\n" +
+		"<<<<<<< HEAD\n" +
+		"ours rewrite\n" +
+		"||||||| parent\n" +
+		"base line\n" +
+		"=======\n" +
+		"base line\n" +
+		">>>>>>> origin/master\n" +
+		"stable middle\n" +
+		"<<<<<<< HEAD\n" +
+		"local insertion\n" +
+		"||||||| parent\n" +
+		"=======\n" +
+		"incoming insertion\n" +
+		">>>>>>> origin/master\n" +
+		"stable suffix\n"
+	client := &strictSyncChatClient{responses: []string{mergeConflictResolutionReviewApproved}}
+	recorder := &mergeConflictReportRecorder{}
+	service := mergeConflictResolutionService{
+		repositoryPath: "/repo",
+		commitMessages: worktreeAdoptionCommitMessageOptions{Client: client},
+		recorder:       recorder,
+	}
+	options := mergeConflictResolutionOptions{SourceReference: "origin/master", TargetBranch: "feature/report"}
+	recorder.beginMerge(options)
+
+	resolution, resolutionErr := service.resolveConflictFile(
+		context.Background(),
+		func() (llm.ChatClient, error) { return client, nil },
+		options,
+		mergeConflictFile{Path: "notes.txt", WorktreeContent: content},
+		time.Second,
+	)
+	recorder.finishMerge(mergeConflictReportOutcomeResolved)
+
+	require.NoError(t, resolutionErr)
+	require.Equal(t, "stable prefix\nours rewrite\nstable middle\nlocal insertion\nincoming insertion\nstable suffix\n", resolution.Content)
+	require.Len(t, client.requests, 1)
+
+	report := recorder.report("/repo", "feature/report", time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC))
+	require.Equal(t, mergeConflictReportSchemaVersion, report.Schema)
+	require.Len(t, report.Merges, 1)
+	require.Equal(t, mergeConflictReportOutcomeResolved, report.Merges[0].Outcome)
+	require.Len(t, report.Merges[0].Files, 1)
+	regions := report.Merges[0].Files[0].Regions
+	require.Len(t, regions, 2)
+
+	require.Equal(t, 1, regions[0].Region)
+	require.Equal(t, mergeConflictReportDecisionDeterministic, regions[0].Decision)
+	require.Zero(t, regions[0].Attempts)
+	require.Empty(t, regions[0].OursDiff)
+	require.Contains(t, regions[0].TheirsDiff, "-base line\n+ours rewrite\n")
+
+	require.Equal(t, 2, regions[1].Region)
+	require.Equal(t, mergeConflictReportDecisionApproved, regions[1].Decision)
+	require.Equal(t, 1, regions[1].Attempts)
+	require.NotEmpty(t, regions[1].Strategy)
+	require.NotEqual(t, mergeConflictReportSemanticStrategy, regions[1].Strategy)
+	require.Contains(t, regions[1].OursDiff, "+incoming insertion\n")
+	require.Contains(t, regions[1].TheirsDiff, "+local insertion\n")
+
+	markdown := report.Markdown()
+	require.Contains(t, markdown, "### `origin/master` into `feature/report`: resolved")
+	require.Contains(t, markdown, "#### `notes.txt` region 2/2")
+	require.Contains(t, markdown, "- Decision: approved")
+	require.Contains(t, markdown, "```diff\n--- OURS\n+++ candidate\n")
+}
+
+func TestMergeConflictReportRecordsUnresolvedRegionAfterProviderFailure(t *testing.T) {
+	client := &strictSyncChatClient{response: "   "}
+	recorder := &mergeConflictReportRecorder{}
+	service := mergeConflictResolutionService{
+		repositoryPath: "/repo",
+		commitMessages: worktreeAdoptionCommitMessageOptions{Client: client},
+		recorder:       recorder,
+	}
+	options := mergeConflictResolutionOptions{SourceReference: "origin/master", TargetBranch: "master"}
+	recorder.beginMerge(options)
+
+	_, resolutionErr := service.resolveSemanticConflictRegion(
+		context.Background(),
+		client,
+		options,
+		mergeConflictFile{Path: "policy.txt"},
+		mergeConflictRegion{Base: "base\n", BasePresent: true, Ours: "ours\n", Theirs: "theirs\n"},
+		0,
+		1,
+		time.Second,
+		"",
+		false,
+	)
+
+	require.Error(t, resolutionErr)
+	region := recorder.merges[0].Files[0].Regions[0]
+	require.Equal(t, mergeConflictReportSemanticStrategy, region.Strategy)
+	require.Equal(t, mergeConflictReportDecisionUnresolved, region.Decision)
+	require.Equal(t, 1, region.Attempts)
+	require.Empty(t, region.OursDiff)
+	require.Equal(t, mergeConflictReportOutcomeFailed, recorder.merges[0].Outcome)
+}
+
+func TestWriteMergeConflictReportStoresJSONUnderGitCommonDirectory(t *testing.T) {
+	repositoryPath := t.TempDir()
+	executor := mergeConflictReportCommonDirectoryExecutor{commonDirectory: ".git"}
+	generatedAt := time.Date(2026, time.October, 18, 12, 30, 0, 5, time.UTC)
+	recorder := &mergeConflictReportRecorder{}
+	recorder.beginMerge(mergeConflictResolutionOptions{SourceReference: "origin/master", TargetBranch: "feature/report"})
+	recorder.recordMarkerFreeFile("removed.txt", "current-stage deletion preservation", true)
+	recorder.finishMerge(mergeConflictReportOutcomeRolledBack)
+
+	reportPath, writeErr := writeMergeConflictReport(context.Background(), executor, repositoryPath, recorder.report(repositoryPath, "feature/report", generatedAt), generatedAt)
+
+	require.NoError(t, writeErr)
+	require.Equal(t, filepath.Join(repositoryPath, ".git", "gix", "merge-resolution-20261018T123000.000000005Z.json"), reportPath)
+	contents, readErr := os.ReadFile(reportPath)
+	require.NoError(t, readErr)
+	var report mergeConflictReport
+	require.NoError(t, json.Unmarshal(contents, &report))
+	require.Equal(t, "2026-10-18T12:30:00Z", report.GeneratedAt)
+	require.Equal(t, mergeConflictReportOutcomeRolledBack, report.Merges[0].Outcome)
+	require.True(t, report.Merges[0].Files[0].Deleted)
+	require.Contains(t, report.Markdown(), "- `removed.txt`: current-stage deletion preservation")
+}
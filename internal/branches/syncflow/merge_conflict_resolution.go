@@ -107,6 +107,8 @@ type mergeConflictResolutionService struct {
 	repositoryPath string
 	commitMessages worktreeAdoptionCommitMessageOptions
 	reporter       mergeConflictResolutionReporter
+	recorder       *mergeConflictReportRecorder
+	reviewer       mergeConflictRegionReviewer
 }
 
 type mergeConflictResolutionReporter func(level shared.EventLevel, code string, message string, details map[string]string)
@@ -169,6 +171,8 @@ func resolveMergeConflictOrError(ctx context.Context, environment *workflow.Envi
 		reporter: func(level shared.EventLevel, code string, message string, details map[string]string) {
 			environment.ReportRepositoryEvent(repository, level, code, message, details)
 		},
+		recorder: mergeConflictReportRecorderFromContext(ctx),
+		reviewer: mergeConflictRegionReviewerFromContext(ctx),
 	}
 	conflictObserved, resolveErr := service.Resolve(ctx, mergeConflictResolutionOptions{
 		SourceReference: sourceReference,
@@ -188,6 +192,9 @@ func resolveMergeConflictOrError(ctx context.Context, environment *workflow.Envi
 			rollbackErr := service.rollbackFailedMerge(ctx, resolveErr, sourceReference, targetBranch)
 			if rollbackErr != nil {
 				resolveErr = errors.Join(resolveErr, rollbackErr)
+				service.recorder.finishMerge(mergeConflictReportOutcomeHandoff)
+			} else {
+				service.recorder.finishMerge(mergeConflictReportOutcomeRolledBack)
 			}
 		}
 		return fmt.Errorf("%s: %w", conflictMessage, errors.Join(fmt.Errorf(mergeConflictResolutionFailureTemplate, resolveErr), mergeErr))
@@ -226,6 +233,7 @@ func (service mergeConflictResolutionService) Resolve(ctx context.Context, optio
 		return false, nil
 	}
 	service.reportConflictDetected(paths, options, timeout)
+	service.recorder.beginMerge(options)
 	incomingParentCommit, incomingParentErr := service.resolveIncomingParentCommit(ctx, options)
 	if incomingParentErr != nil {
 		return true, service.normalizeResolutionError(ctx, incomingParentErr)
@@ -290,6 +298,7 @@ func (service mergeConflictResolutionService) Resolve(ctx context.Context, optio
 			return true, service.normalizeResolutionError(ctx, fmt.Errorf(mergeConflictResolutionCommitTemplate, commitErr))
 		}
 	}
+	service.recorder.finishMerge(mergeConflictReportOutcomeResolved)
 	service.report(shared.EventLevelInfo, shared.EventCodeAIMergeResolution, "merge conflict resolution completed", map[string]string{
 		"paths": strings.Join(paths, ", "),
 	})
@@ -634,7 +643,8 @@ func (service mergeConflictResolutionService) resolveConflictFile(ctx context.Co
 		initialCandidateAvailable := false
 		if deterministicResolution, resolved := deterministicMergeConflictRegionResolution(region); resolved {
 			if !deterministicResolution.RequiresSemanticAudit {
-				resolvedRegions[regionIndex] = deterministicResolution.Content
+				service.recorder.noteRegionStrategy(conflictFile.Path, regionIndex, len(document.ConflictRegions), deterministicResolution.Strategy)
+				service.recorder.recordRegion(conflictFile.Path, regionIndex, len(document.ConflictRegions), region, 0, mergeConflictReportDecisionDeterministic, deterministicResolution.Content)
 				service.report(
 					shared.EventLevelInfo,
 					shared.EventCodeAIMergeResolution,
@@ -652,6 +662,11 @@ func (service mergeConflictResolutionService) resolveConflictFile(ctx context.Co
 						"strategy": deterministicResolution.Strategy,
 					},
 				)
+				reviewedRegion, reviewErr := service.reviewRegion(ctx, conflictFile, region, regionIndex, len(document.ConflictRegions), deterministicResolution.Content)
+				if reviewErr != nil {
+					return mergeConflictFileResolution{}, reviewErr
+				}
+				resolvedRegions[regionIndex] = reviewedRegion
 				continue
 			}
 			if validationErr := validateMergeConflictRegionResponse(
@@ -662,6 +677,7 @@ func (service mergeConflictResolutionService) resolveConflictFile(ctx context.Co
 			); validationErr == nil {
 				initialCandidate = deterministicResolution.Content
 				initialCandidateAvailable = true
+				service.recorder.noteRegionStrategy(conflictFile.Path, regionIndex, len(document.ConflictRegions), deterministicResolution.Strategy)
 				service.report(
 					shared.EventLevelInfo,
 					shared.EventCodeAIMergeResolution,
@@ -701,7 +717,11 @@ func (service mergeConflictResolutionService) resolveConflictFile(ctx context.Co
 		if resolutionErr != nil {
 			return mergeConflictFileResolution{}, resolutionErr
 		}
-		resolvedRegions[regionIndex] = resolvedRegion
+		reviewedRegion, reviewErr := service.reviewRegion(ctx, conflictFile, region, regionIndex, len(document.ConflictRegions), resolvedRegion)
+		if reviewErr != nil {
+			return mergeConflictFileResolution{}, reviewErr
+		}
+		resolvedRegions[regionIndex] = reviewedRegion
 	}
 
 	resolution := mergeConflictFileResolution{Content: document.resolve(resolvedRegions)}
@@ -726,6 +746,7 @@ func (service mergeConflictResolutionService) resolveMarkerFreeConflictFile(conf
 		resolution = mergeConflictFileResolution{Delete: true}
 		strategy = "current-stage deletion preservation"
 	}
+	service.recorder.recordMarkerFreeFile(conflictFile.Path, strategy, resolution.Delete)
 	service.report(
 		shared.EventLevelInfo,
 		shared.EventCodeAIMergeResolution,
@@ -809,6 +830,7 @@ func (service mergeConflictResolutionService) resolveSemanticConflictRegion(ctx
 				strategy,
 				providerRoundErr,
 			)
+			service.recorder.recordRegion(conflictFile.Path, regionIndex, regionCount, region, attempt, mergeConflictReportDecisionUnresolved, "")
 			return "", providerRoundErr
 		}
 
@@ -845,6 +867,7 @@ func (service mergeConflictResolutionService) resolveSemanticConflictRegion(ctx
 				continue
 			}
 			service.reportSemanticAuditApproved(conflictFile.Path, regionIndex, regionCount, attempt)
+			service.recorder.recordRegion(conflictFile.Path, regionIndex, regionCount, region, attempt, mergeConflictReportDecisionApproved, candidate)
 			return candidate, nil
 		}
 
@@ -887,6 +910,7 @@ func (service mergeConflictResolutionService) resolveSemanticConflictRegion(ctx
 
 		if reviewing {
 			service.reportSemanticAuditApproved(conflictFile.Path, regionIndex, regionCount, attempt)
+			service.recorder.recordRegion(conflictFile.Path, regionIndex, regionCount, region, attempt, mergeConflictReportDecisionCorrected, resolvedRegion)
 			return resolvedRegion, nil
 		}
 		candidate = resolvedRegion
@@ -919,6 +943,7 @@ func (service mergeConflictResolutionService) resolveSemanticConflictRegion(ctx
 		)
 	}
 
+	service.recorder.recordRegion(conflictFile.Path, regionIndex, regionCount, region, mergeConflictResolutionMaxSemanticAttempts, mergeConflictReportDecisionUnresolved, "")
 	return "", fmt.Errorf(
 		mergeConflictResolutionExhaustedTemplate,
 		conflictFile.Path,
@@ -0,0 +1,230 @@
+package syncflow
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/tyemirov/gix/internal/repos/shared"
+)
+
+const (
+	mergeConflictReviewEditorEnvironmentKey = "EDITOR"
+	mergeConflictReviewFallbackEditor       = "vi"
+	mergeConflictReviewTemporaryPattern     = "gix-conflict-region-*"
+	mergeConflictReviewHeaderTemplate       = "\n=== %s conflict region %d/%d (%s) ===\n"
+	mergeConflictReviewSectionTemplate      = "--- %s\n%s"
+	mergeConflictReviewPrompt               = "Accept candidate, edit in $EDITOR, pick OURS, pick THEIRS, or abort sync? [a/e/o/t/q] "
+	mergeConflictReviewInvalidChoice        = "Unrecognized choice %q; enter a, e, o, t, or q.\n"
+	mergeConflictReviewMarkerRejection      = "The candidate still contains conflict markers; edit it again or pick a side.\n"
+	mergeConflictReviewEditorFailure        = "edit %s conflict region %d in %s: %w"
+	mergeConflictReviewPromptFailure        = "read conflict review choice: %w"
+	mergeConflictReviewChosenTemplate       = "operator %s %s conflict region %d/%d"
+	mergeConflictReviewEmptySection         = "(empty)\n"
+
+	mergeConflictReviewChoiceAccepted = "accepted"
+	mergeConflictReviewChoiceEdited   = "edited"
+	mergeConflictReviewChoiceOurs     = "picked OURS"
+	mergeConflictReviewChoiceTheirs   = "picked THEIRS"
+)
+
+var errMergeConflictReviewAborted = errors.New("operator aborted conflict review; the merge was not committed")
+
+// mergeConflictRegionReview is the three-way state an operator decides for one conflict region.
+type mergeConflictRegionReview struct {
+	Path        string
+	RegionIndex int
+	RegionCount int
+	Region      mergeConflictRegion
+	Candidate   string
+	Strategy    string
+}
+
+// mergeConflictRegionReviewDecision is the content an operator chose for one conflict region.
+type mergeConflictRegionReviewDecision struct {
+	Choice  string
+	Content string
+}
+
+// mergeConflictRegionReviewer lets an operator replace the resolver's candidate before the region is staged.
+type mergeConflictRegionReviewer interface {
+	ReviewRegion(ctx context.Context, review mergeConflictRegionReview) (mergeConflictRegionReviewDecision, error)
+}
+
+type mergeConflictRegionEditor func(ctx context.Context, path string) error
+
+// terminalConflictReviewer renders a three-way view and reads one choice per line.
+type terminalConflictReviewer struct {
+	reader *bufio.Reader
+	writer io.Writer
+	editor mergeConflictRegionEditor
+}
+
+func newTerminalConflictReviewer(input io.Reader, output io.Writer, editor mergeConflictRegionEditor) *terminalConflictReviewer {
+	if output == nil {
+		output = io.Discard
+	}
+	return &terminalConflictReviewer{reader: bufio.NewReader(input), writer: output, editor: editor}
+}
+
+func (reviewer *terminalConflictReviewer) ReviewRegion(ctx context.Context, review mergeConflictRegionReview) (mergeConflictRegionReviewDecision, error) {
+	candidate := review.Candidate
+	choice := mergeConflictReviewChoiceAccepted
+	for {
+		if contextErr := ctx.Err(); contextErr != nil {
+			return mergeConflictRegionReviewDecision{}, contextErr
+		}
+		reviewer.render(review, candidate)
+		fmt.Fprint(reviewer.writer, mergeConflictReviewPrompt)
+		response, readErr := reviewer.reader.ReadString('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return mergeConflictRegionReviewDecision{}, fmt.Errorf(mergeConflictReviewPromptFailure, readErr)
+		}
+		normalizedResponse := strings.ToLower(strings.TrimSpace(response))
+		if normalizedResponse == "" && errors.Is(readErr, io.EOF) {
+			return mergeConflictRegionReviewDecision{}, errMergeConflictReviewAborted
+		}
+		switch normalizedResponse {
+		case "a", "accept":
+			if containsConflictMarker(candidate) {
+				fmt.Fprint(reviewer.writer, mergeConflictReviewMarkerRejection)
+				continue
+			}
+			return mergeConflictRegionReviewDecision{Choice: choice, Content: candidate}, nil
+		case "e", "edit":
+			editedCandidate, editErr := reviewer.edit(ctx, review, candidate)
+			if editErr != nil {
+				return mergeConflictRegionReviewDecision{}, editErr
+			}
+			candidate = editedCandidate
+			choice = mergeConflictReviewChoiceEdited
+		case "o", "ours":
+			return mergeConflictRegionReviewDecision{Choice: mergeConflictReviewChoiceOurs, Content: review.Region.Ours}, nil
+		case "t", "theirs":
+			return mergeConflictRegionReviewDecision{Choice: mergeConflictReviewChoiceTheirs, Content: review.Region.Theirs}, nil
+		case "q", "quit", "abort":
+			return mergeConflictRegionReviewDecision{}, errMergeConflictReviewAborted
+		default:
+			fmt.Fprintf(reviewer.writer, mergeConflictReviewInvalidChoice, normalizedResponse)
+		}
+	}
+}
+
+func (reviewer *terminalConflictReviewer) render(review mergeConflictRegionReview, candidate string) {
+	fmt.Fprintf(reviewer.writer, mergeConflictReviewHeaderTemplate, review.Path, review.RegionIndex+1, review.RegionCount, review.Strategy)
+	base := review.Region.Base
+	if !review.Region.BasePresent {
+		base = ""
+	}
+	for _, section := range []struct {
+		label   string
+		content string
+	}{
+		{label: "BASE", content: base},
+		{label: "OURS", content: review.Region.Ours},
+		{label: "THEIRS", content: review.Region.Theirs},
+		{label: "CANDIDATE", content: candidate},
+	} {
+		fmt.Fprintf(reviewer.writer, mergeConflictReviewSectionTemplate, section.label, mergeConflictReviewSection(section.content))
+	}
+}
+
+func (reviewer *terminalConflictReviewer) edit(ctx context.Context, review mergeConflictRegionReview, candidate string) (string, error) {
+	temporaryFile, createErr := os.CreateTemp("", mergeConflictReviewTemporaryPattern)
+	if createErr != nil {
+		return "", fmt.Errorf(mergeConflictReviewEditorFailure, review.Path, review.RegionIndex+1, "a temporary file", createErr)
+	}
+	temporaryPath := temporaryFile.Name()
+	defer os.Remove(temporaryPath)
+	_, writeErr := temporaryFile.WriteString(mergeConflictReportText(candidate))
+	closeErr := temporaryFile.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		return "", fmt.Errorf(mergeConflictReviewEditorFailure, review.Path, review.RegionIndex+1, temporaryPath, writeErr)
+	}
+	if editErr := reviewer.editor(ctx, temporaryPath); editErr != nil {
+		return "", fmt.Errorf(mergeConflictReviewEditorFailure, review.Path, review.RegionIndex+1, temporaryPath, editErr)
+	}
+	editedContent, readErr := os.ReadFile(temporaryPath)
+	if readErr != nil {
+		return "", fmt.Errorf(mergeConflictReviewEditorFailure, review.Path, review.RegionIndex+1, temporaryPath, readErr)
+	}
+	return string(editedContent), nil
+}
+
+func runMergeConflictReviewEditor(ctx context.Context, path string) error {
+	editorCommand := strings.Fields(os.Getenv(mergeConflictReviewEditorEnvironmentKey))
+	if len(editorCommand) == 0 {
+		editorCommand = []string{mergeConflictReviewFallbackEditor}
+	}
+	editor := exec.CommandContext(ctx, editorCommand[0], append(editorCommand[1:], path)...)
+	editor.Stdin = os.Stdin
+	editor.Stdout = os.Stdout
+	editor.Stderr = os.Stderr
+	return editor.Run()
+}
+
+func mergeConflictReviewSection(content string) string {
+	if content == "" {
+		return mergeConflictReviewEmptySection
+	}
+	return mergeConflictReportText(content)
+}
+
+func mergeConflictRegionReviewerFromParameters(parameters map[string]any) (mergeConflictRegionReviewer, error) {
+	rawReviewer, exists := parameters[taskOptionConflictReviewer]
+	if !exists || rawReviewer == nil {
+		return nil, nil
+	}
+	reviewer, ok := rawReviewer.(mergeConflictRegionReviewer)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a conflict reviewer", taskOptionConflictReviewer)
+	}
+	return reviewer, nil
+}
+
+func mergeConflictRegionReviewerFromContext(ctx context.Context) mergeConflictRegionReviewer {
+	transaction, ok := strictSyncTransactionFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return transaction.conflictReviewer
+}
+
+// reviewRegion hands the resolved candidate to the operator when interactive review is enabled.
+func (service mergeConflictResolutionService) reviewRegion(ctx context.Context, conflictFile mergeConflictFile, region mergeConflictRegion, regionIndex int, regionCount int, candidate string) (string, error) {
+	if service.reviewer == nil {
+		return candidate, nil
+	}
+	decision, reviewErr := service.reviewer.ReviewRegion(ctx, mergeConflictRegionReview{
+		Path:        conflictFile.Path,
+		RegionIndex: regionIndex,
+		RegionCount: regionCount,
+		Region:      region,
+		Candidate:   candidate,
+		Strategy:    service.recorder.regionStrategy(conflictFile.Path, regionIndex),
+	})
+	if reviewErr != nil {
+		return "", reviewErr
+	}
+	service.recorder.recordOperatorChoice(conflictFile.Path, regionIndex, regionCount, region, decision.Choice, decision.Content)
+	service.report(
+		shared.EventLevelInfo,
+		shared.EventCodeAIMergeValidation,
+		fmt.Sprintf(mergeConflictReviewChosenTemplate, decision.Choice, conflictFile.Path, regionIndex+1, regionCount),
+		map[string]string{
+			"path":   conflictFile.Path,
+			"region": strconv.Itoa(regionIndex + 1),
+			"choice": decision.Choice,
+		},
+	)
+	return decision.Content, nil
+}
@@ -0,0 +1,163 @@
+package syncflow
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/utils/llm"
+)
+
+type scriptedConflictReviewer struct {
+	decisions []mergeConflictRegionReviewDecision
+	err       error
+	reviews   []mergeConflictRegionReview
+}
+
+func (reviewer *scriptedConflictReviewer) ReviewRegion(_ context.Context, review mergeConflictRegionReview) (mergeConflictRegionReviewDecision, error) {
+	reviewer.reviews = append(reviewer.reviews, review)
+	if reviewer.err != nil {
+		return mergeConflictRegionReviewDecision{}, reviewer.err
+	}
+	decision := reviewer.decisions[0]
+	reviewer.decisions = reviewer.decisions[1:]
+	return decision, nil
+}
+
+func TestTerminalConflictReviewerChoices(t *testing.T) {
+	review := mergeConflictRegionReview{
+		Path:        "notes.txt",
+		RegionIndex: 0,
+		RegionCount: 1,
+		Region:      mergeConflictRegion{Base: "base\n", BasePresent: true, Ours: "ours\n", Theirs: "theirs\n"},
+		Candidate:   "candidate\n",
+		Strategy:    mergeConflictReportSemanticStrategy,
+	}
+	testCases := []struct {
+		name     string
+		input    string
+		decision mergeConflictRegionReviewDecision
+		aborted  bool
+	}{
+		{name: "accept", input: "a\n", decision: mergeConflictRegionReviewDecision{Choice: mergeConflictReviewChoiceAccepted, Content: "candidate\n"}},
+		{name: "ours after invalid choice", input: "x\no\n", decision: mergeConflictRegionReviewDecision{Choice: mergeConflictReviewChoiceOurs, Content: "ours\n"}},
+		{name: "theirs", input: "t\n", decision: mergeConflictRegionReviewDecision{Choice: mergeConflictReviewChoiceTheirs, Content: "theirs\n"}},
+		{name: "edit then accept", input: "e\na\n", decision: mergeConflictRegionReviewDecision{Choice: mergeConflictReviewChoiceEdited, Content: "edited\n"}},
+		{name: "quit", input: "q\n", aborted: true},
+		{name: "end of input", input: "", aborted: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var output bytes.Buffer
+			reviewer := newTerminalConflictReviewer(strings.NewReader(testCase.input), &output, func(_ context.Context, path string) error {
+				return os.WriteFile(path, []byte("edited\n"), 0o600)
+			})
+
+			decision, reviewErr := reviewer.ReviewRegion(context.Background(), review)
+
+			if testCase.aborted {
+				require.ErrorIs(t, reviewErr, errMergeConflictReviewAborted)
+				return
+			}
+			require.NoError(t, reviewErr)
+			require.Equal(t, testCase.decision, decision)
+			require.Contains(t, output.String(), "=== notes.txt conflict region 1/1")
+			require.Contains(t, output.String(), "--- BASE\nbase\n--- OURS\nours\n--- THEIRS\ntheirs\n--- CANDIDATE\n")
+		})
+	}
+}
+
+func TestTerminalConflictReviewerRejectsEditedConflictMarkers(t *testing.T) {
+	var output bytes.Buffer
+	reviewer := newTerminalConflictReviewer(strings.NewReader("e\na\nt\n"), &output, func(_ context.Context, path string) error {
+		return os.WriteFile(path, []byte("<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> origin/master\n"), 0o600)
+	})
+
+	decision, reviewErr := reviewer.ReviewRegion(context.Background(), mergeConflictRegionReview{
+		Path:        "notes.txt",
+		RegionCount: 1,
+		Region:      mergeConflictRegion{Ours: "ours\n", Theirs: "theirs\n"},
+		Candidate:   "candidate\n",
+	})
+
+	require.NoError(t, reviewErr)
+	require.Equal(t, mergeConflictReviewChoiceTheirs, decision.Choice)
+	require.Contains(t, output.String(), mergeConflictReviewMarkerRejection)
+}
+
+func TestMergeConflictReviewReplacesCandidatesAndRecordsOperatorChoice(t *testing.T) {
+	content := "stable prefix\n" +
+		"<<<<<<< HEAD\n" +
+		"ours rewrite\n" +
+		"||||||| parent\n" +
+		"base line\n" +
+		"=======\n" +
+		"base line\n" +
+		">>>>>>> origin/master\n" +
+		"stable middle\n" +
+		"<<<<<<< HEAD\n" +
+		"local insertion\n" +
+		"||||||| parent\n" +
+		"=======\n" +
+		"incoming insertion\n" +
+		">>>>>>> origin/master\n" +
+		"stable suffix\n"
+	client := &strictSyncChatClient{responses: []string{mergeConflictResolutionReviewApproved}}
+	recorder := &mergeConflictReportRecorder{}
+	reviewer := &scriptedConflictReviewer{decisions: []mergeConflictRegionReviewDecision{
+		{Choice: mergeConflictReviewChoiceAccepted, Content: "ours rewrite\n"},
+		{Choice: mergeConflictReviewChoiceTheirs, Content: "incoming insertion\n"},
+	}}
+	service := mergeConflictResolutionService{
+		repositoryPath: "/repo",
+		commitMessages: worktreeAdoptionCommitMessageOptions{Client: client},
+		recorder:       recorder,
+		reviewer:       reviewer,
+	}
+	options := mergeConflictResolutionOptions{SourceReference: "origin/master", TargetBranch: "feature/review"}
+	recorder.beginMerge(options)
+
+	resolution, resolutionErr := service.resolveConflictFile(
+		context.Background(),
+		func() (llm.ChatClient, error) { return client, nil },
+		options,
+		mergeConflictFile{Path: "notes.txt", WorktreeContent: content},
+		time.Second,
+	)
+
+	require.NoError(t, resolutionErr)
+	require.Equal(t, "stable prefix\nours rewrite\nstable middle\nincoming insertion\nstable suffix\n", resolution.Content)
+	require.Len(t, reviewer.reviews, 2)
+	require.Equal(t, "ours rewrite\n", reviewer.reviews[0].Candidate)
+	require.Equal(t, "local insertion\nincoming insertion\n", reviewer.reviews[1].Candidate)
+	require.NotEqual(t, mergeConflictReportSemanticStrategy, reviewer.reviews[1].Strategy)
+
+	regions := recorder.merges[0].Files[0].Regions
+	require.Equal(t, mergeConflictReviewChoiceAccepted, regions[0].Operator)
+	require.Equal(t, mergeConflictReportDecisionApproved, regions[1].Decision)
+	require.Equal(t, mergeConflictReviewChoiceTheirs, regions[1].Operator)
+	require.Equal(t, "incoming insertion\n", regions[1].Candidate)
+	require.Empty(t, regions[1].TheirsDiff)
+	require.Contains(t, recorder.report("/repo", "feature/review", time.Now()).Markdown(), "- Operator: picked THEIRS")
+}
+
+func TestMergeConflictReviewAbortStopsResolution(t *testing.T) {
+	reviewer := &scriptedConflictReviewer{err: errMergeConflictReviewAborted}
+	service := mergeConflictResolutionService{repositoryPath: "/repo", reviewer: reviewer}
+
+	_, resolutionErr := service.resolveConflictFile(
+		context.Background(),
+		func() (llm.ChatClient, error) { return nil, nil },
+		mergeConflictResolutionOptions{SourceReference: "origin/master", TargetBranch: "master"},
+		mergeConflictFile{Path: "notes.txt", WorktreeContent: "<<<<<<< HEAD\nsame\n=======\nsame\n>>>>>>> origin/master\n"},
+		time.Second,
+	)
+
+	require.ErrorIs(t, resolutionErr, errMergeConflictReviewAborted)
+	require.Len(t, reviewer.reviews, 1)
+}
@@ -61,8 +61,9 @@ type strictSyncPullRequestDescriptionOptions struct {
 }
 
 type strictSyncPullRequestMetadata struct {
-	Title string
-	Body  string
+	Title                 string
+	Body                  string
+	IncludeConflictReport bool
 }
 
 type strictSyncPullRequestMetadataOptions struct {
@@ -95,9 +96,14 @@ func strictSyncPullRequestMetadataFromParameters(parameters map[string]any) (str
 	if bodyErr != nil {
 		return strictSyncPullRequestMetadata{}, bodyErr
 	}
+	includeConflictReport, includeConflictReportErr := boolOptionDefault(parameters, taskOptionConflictReportInBody, false)
+	if includeConflictReportErr != nil {
+		return strictSyncPullRequestMetadata{}, includeConflictReportErr
+	}
 	return strictSyncPullRequestMetadata{
-		Title: title,
-		Body:  body,
+		Title:                 title,
+		Body:                  body,
+		IncludeConflictReport: includeConflictReport,
 	}, nil
 }
 
@@ -152,7 +158,7 @@ func generateStrictSyncPullRequestBody(ctx context.Context, executor shared.GitE
 }
 
 func collectStrictSyncPullRequestDescriptionContext(ctx context.Context, executor shared.GitExecutor, options strictSyncPullRequestDescriptionOptions) (strictSyncPullRequestDescriptionContext, error) {
-	baseReference := fmt.Sprintf("%s/%s", options.RemoteName, options.BaseBranch)
+	baseReference := fmt.Sprintf("%s/%s", strictSyncBaseRemote(ctx, options.RemoteName), options.BaseBranch)
 	comparisonRange := fmt.Sprintf("%s...%s", baseReference, options.BranchName)
 	commitRange := fmt.Sprintf("%s..%s", baseReference, options.BranchName)
 
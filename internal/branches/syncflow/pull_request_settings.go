@@ -0,0 +1,196 @@
+package syncflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyemirov/gix/internal/githubcli"
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/workflow"
+)
+
+const (
+	strictSyncAutoMergeOff                    = "off"
+	strictSyncAutoMergeUnsupportedTemplate    = "unsupported auto-merge method %q; use squash, merge, rebase, or off"
+	strictSyncPullRequestRulePrefixMessage    = "pull_request.rules entries require branch_prefix"
+	strictSyncPullRequestRuleTemplate         = "pull_request.rules[%d]: %w"
+	strictSyncPullRequestPolicyOptionTemplate = "%s option must be pull request settings"
+	strictSyncAutoMergeEnabledTemplate        = "enabled %s auto-merge for %s"
+	strictSyncAutoMergeFailedTemplate         = "opened pull request for %s but could not enable %s auto-merge"
+)
+
+// strictSyncPullRequestSettings is the reviewer-facing metadata sync applies to a pull request it opens.
+type strictSyncPullRequestSettings struct {
+	Labels    []string
+	Assignees []string
+	Reviewers []string
+	Milestone string
+	Draft     *bool
+	AutoMerge string
+}
+
+type strictSyncPullRequestRule struct {
+	BranchPrefix string
+	Settings     strictSyncPullRequestSettings
+}
+
+// strictSyncPullRequestPolicy layers configured defaults, every matching branch-prefix rule in order, and command-line overrides.
+// Lists accumulate across layers; milestone, draft, and auto-merge take the last layer that sets them.
+type strictSyncPullRequestPolicy struct {
+	Defaults  strictSyncPullRequestSettings
+	Rules     []strictSyncPullRequestRule
+	Overrides strictSyncPullRequestSettings
+}
+
+func newStrictSyncPullRequestPolicy(configuration PullRequestConfiguration, overrides strictSyncPullRequestSettings) (strictSyncPullRequestPolicy, error) {
+	policy := strictSyncPullRequestPolicy{
+		Defaults: strictSyncPullRequestSettings{
+			Labels:    sanitizeStrictSyncPullRequestValues(configuration.Labels),
+			Assignees: sanitizeStrictSyncPullRequestValues(configuration.Assignees),
+			Reviewers: sanitizeStrictSyncPullRequestValues(configuration.Reviewers),
+			Milestone: strings.TrimSpace(configuration.Milestone),
+			AutoMerge: strings.ToLower(strings.TrimSpace(configuration.AutoMerge)),
+		},
+		Overrides: overrides,
+	}
+	if configuration.Draft {
+		policy.Defaults.Draft = &configuration.Draft
+	}
+	if validateErr := validateStrictSyncAutoMerge(policy.Defaults.AutoMerge); validateErr != nil {
+		return strictSyncPullRequestPolicy{}, validateErr
+	}
+	if validateErr := validateStrictSyncAutoMerge(policy.Overrides.AutoMerge); validateErr != nil {
+		return strictSyncPullRequestPolicy{}, validateErr
+	}
+	for ruleIndex, ruleConfiguration := range configuration.Rules {
+		rule := strictSyncPullRequestRule{
+			BranchPrefix: strings.TrimSpace(ruleConfiguration.BranchPrefix),
+			Settings: strictSyncPullRequestSettings{
+				Labels:    sanitizeStrictSyncPullRequestValues(ruleConfiguration.Labels),
+				Assignees: sanitizeStrictSyncPullRequestValues(ruleConfiguration.Assignees),
+				Reviewers: sanitizeStrictSyncPullRequestValues(ruleConfiguration.Reviewers),
+				Milestone: strings.TrimSpace(ruleConfiguration.Milestone),
+				Draft:     ruleConfiguration.Draft,
+				AutoMerge: strings.ToLower(strings.TrimSpace(ruleConfiguration.AutoMerge)),
+			},
+		}
+		if rule.BranchPrefix == "" {
+			return strictSyncPullRequestPolicy{}, fmt.Errorf(strictSyncPullRequestRuleTemplate, ruleIndex, errors.New(strictSyncPullRequestRulePrefixMessage))
+		}
+		if validateErr := validateStrictSyncAutoMerge(rule.Settings.AutoMerge); validateErr != nil {
+			return strictSyncPullRequestPolicy{}, fmt.Errorf(strictSyncPullRequestRuleTemplate, ruleIndex, validateErr)
+		}
+		policy.Rules = append(policy.Rules, rule)
+	}
+	return policy, nil
+}
+
+func (policy strictSyncPullRequestPolicy) settingsFor(branchName string) strictSyncPullRequestSettings {
+	settings := policy.Defaults
+	for _, rule := range policy.Rules {
+		if strings.HasPrefix(branchName, rule.BranchPrefix) {
+			settings = settings.merge(rule.Settings)
+		}
+	}
+	return settings.merge(policy.Overrides)
+}
+
+func (settings strictSyncPullRequestSettings) merge(layer strictSyncPullRequestSettings) strictSyncPullRequestSettings {
+	merged := strictSyncPullRequestSettings{
+		Labels:    sanitizeStrictSyncPullRequestValues(append(append([]string(nil), settings.Labels...), layer.Labels...)),
+		Assignees: sanitizeStrictSyncPullRequestValues(append(append([]string(nil), settings.Assignees...), layer.Assignees...)),
+		Reviewers: sanitizeStrictSyncPullRequestValues(append(append([]string(nil), settings.Reviewers...), layer.Reviewers...)),
+		Milestone: settings.Milestone,
+		Draft:     settings.Draft,
+		AutoMerge: settings.AutoMerge,
+	}
+	if layer.Milestone != "" {
+		merged.Milestone = layer.Milestone
+	}
+	if layer.Draft != nil {
+		merged.Draft = layer.Draft
+	}
+	if layer.AutoMerge != "" {
+		merged.AutoMerge = layer.AutoMerge
+	}
+	return merged
+}
+
+func (settings strictSyncPullRequestSettings) draft() bool {
+	return settings.Draft != nil && *settings.Draft
+}
+
+// autoMergeMethod returns the merge method to enable, or an empty method when auto-merge is unset or turned off.
+func (settings strictSyncPullRequestSettings) autoMergeMethod() githubcli.PullRequestMergeMethod {
+	if settings.AutoMerge == strictSyncAutoMergeOff {
+		return ""
+	}
+	return githubcli.PullRequestMergeMethod(settings.AutoMerge)
+}
+
+func validateStrictSyncAutoMerge(value string) error {
+	switch githubcli.PullRequestMergeMethod(value) {
+	case "", strictSyncAutoMergeOff, githubcli.PullRequestMergeMethodSquash, githubcli.PullRequestMergeMethodMerge, githubcli.PullRequestMergeMethodRebase:
+		return nil
+	default:
+		return fmt.Errorf(strictSyncAutoMergeUnsupportedTemplate, value)
+	}
+}
+
+// sanitizeStrictSyncPullRequestValues trims values and drops blanks and repeats while keeping first-seen order.
+func sanitizeStrictSyncPullRequestValues(values []string) []string {
+	var sanitized []string
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		trimmed := strings.TrimSpace(value)
+		if trimmed == "" {
+			continue
+		}
+		if _, duplicate := seen[trimmed]; duplicate {
+			continue
+		}
+		seen[trimmed] = struct{}{}
+		sanitized = append(sanitized, trimmed)
+	}
+	return sanitized
+}
+
+func strictSyncPullRequestPolicyFromParameters(parameters map[string]any) (strictSyncPullRequestPolicy, error) {
+	rawPolicy, exists := parameters[taskOptionPullRequestPolicy]
+	if !exists || rawPolicy == nil {
+		return strictSyncPullRequestPolicy{}, nil
+	}
+	policy, ok := rawPolicy.(strictSyncPullRequestPolicy)
+	if !ok {
+		return strictSyncPullRequestPolicy{}, fmt.Errorf(strictSyncPullRequestPolicyOptionTemplate, taskOptionPullRequestPolicy)
+	}
+	return policy, nil
+}
+
+// strictSyncPullRequestSettingsForBranch resolves the invocation's pull request policy for a branch sync is about to open a pull request for.
+func strictSyncPullRequestSettingsForBranch(ctx context.Context, branchName string) strictSyncPullRequestSettings {
+	transaction, ok := strictSyncTransactionFromContext(ctx)
+	if !ok {
+		return strictSyncPullRequestSettings{}
+	}
+	return transaction.pullRequestPolicy.settingsFor(branchName)
+}
+
+// enableStrictSyncAutoMerge runs after the pull request exists, so a refusal (for example auto-merge disabled on the
+// repository) is reported as a warning rather than turning a published sync into a handoff.
+func enableStrictSyncAutoMerge(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, options strictPullRequestCreateOptions) {
+	method := options.Settings.autoMergeMethod()
+	if method == "" || environment.GitHubClient == nil {
+		return
+	}
+	head := strictSyncPullRequestHead(ctx, options.BranchName)
+	details := map[string]string{"branch": options.BranchName, "repository": options.RepositoryIdentifier, "method": string(method)}
+	if enableErr := environment.GitHubClient.EnablePullRequestAutoMerge(ctx, options.RepositoryIdentifier, head, method); enableErr != nil {
+		details["error"] = enableErr.Error()
+		environment.ReportRepositoryEvent(repository, shared.EventLevelWarn, shared.EventCodeSyncAutoMerge, fmt.Sprintf(strictSyncAutoMergeFailedTemplate, head, method), details)
+		return
+	}
+	environment.ReportRepositoryEvent(repository, shared.EventLevelInfo, shared.EventCodeSyncAutoMerge, fmt.Sprintf(strictSyncAutoMergeEnabledTemplate, method, head), details)
+}
@@ -0,0 +1,86 @@
+package syncflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/githubcli"
+)
+
+func TestStrictSyncPullRequestPolicyLayersRulesAndOverrides(t *testing.T) {
+	draftOff := false
+	draftOn := true
+	configuration := PullRequestConfiguration{
+		Labels:    []string{"sync"},
+		Reviewers: []string{"owner/core"},
+		Milestone: "backlog",
+		Draft:     true,
+		AutoMerge: "merge",
+		Rules: []PullRequestRuleConfiguration{
+			{BranchPrefix: "feature/", Labels: []string{"feature", "sync"}, Draft: &draftOff, AutoMerge: "squash"},
+			{BranchPrefix: "feature/ui-", Reviewers: []string{"owner/design"}, Milestone: "v2"},
+			{BranchPrefix: "hotfix/", Labels: []string{"urgent"}, AutoMerge: "off"},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		branchName string
+		overrides  strictSyncPullRequestSettings
+		expected   strictSyncPullRequestSettings
+		autoMerge  githubcli.PullRequestMergeMethod
+		draft      bool
+	}{
+		{
+			name:       "defaults only",
+			branchName: "chore/deps",
+			expected:   strictSyncPullRequestSettings{Labels: []string{"sync"}, Reviewers: []string{"owner/core"}, Milestone: "backlog", Draft: &draftOn, AutoMerge: "merge"},
+			autoMerge:  githubcli.PullRequestMergeMethodMerge,
+			draft:      true,
+		},
+		{
+			name:       "every matching rule applies in order",
+			branchName: "feature/ui-theme",
+			expected:   strictSyncPullRequestSettings{Labels: []string{"sync", "feature"}, Reviewers: []string{"owner/core", "owner/design"}, Milestone: "v2", Draft: &draftOff, AutoMerge: "squash"},
+			autoMerge:  githubcli.PullRequestMergeMethodSquash,
+		},
+		{
+			name:       "rule turns auto-merge off",
+			branchName: "hotfix/crash",
+			expected:   strictSyncPullRequestSettings{Labels: []string{"sync", "urgent"}, Reviewers: []string{"owner/core"}, Milestone: "backlog", Draft: &draftOn, AutoMerge: "off"},
+			draft:      true,
+		},
+		{
+			name:       "flags extend lists and override scalars",
+			branchName: "feature/api",
+			overrides:  strictSyncPullRequestSettings{Labels: []string{"reviewed"}, Assignees: []string{"octocat"}, Milestone: "v3", Draft: &draftOn, AutoMerge: "rebase"},
+			expected:   strictSyncPullRequestSettings{Labels: []string{"sync", "feature", "reviewed"}, Assignees: []string{"octocat"}, Reviewers: []string{"owner/core"}, Milestone: "v3", Draft: &draftOn, AutoMerge: "rebase"},
+			autoMerge:  githubcli.PullRequestMergeMethodRebase,
+			draft:      true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, policyErr := newStrictSyncPullRequestPolicy(configuration.Sanitize(), testCase.overrides)
+			require.NoError(t, policyErr)
+
+			settings := policy.settingsFor(testCase.branchName)
+
+			require.Equal(t, testCase.expected, settings)
+			require.Equal(t, testCase.autoMerge, settings.autoMergeMethod())
+			require.Equal(t, testCase.draft, settings.draft())
+		})
+	}
+}
+
+func TestStrictSyncPullRequestPolicyRejectsInvalidSettings(t *testing.T) {
+	_, autoMergeErr := newStrictSyncPullRequestPolicy(PullRequestConfiguration{}, strictSyncPullRequestSettings{AutoMerge: "fast-forward"})
+	require.EqualError(t, autoMergeErr, `unsupported auto-merge method "fast-forward"; use squash, merge, rebase, or off`)
+
+	_, prefixErr := newStrictSyncPullRequestPolicy(PullRequestConfiguration{Rules: []PullRequestRuleConfiguration{{Labels: []string{"sync"}}}}, strictSyncPullRequestSettings{})
+	require.EqualError(t, prefixErr, "pull_request.rules[0]: pull_request.rules entries require branch_prefix")
+
+	_, ruleErr := newStrictSyncPullRequestPolicy(PullRequestConfiguration{Rules: []PullRequestRuleConfiguration{{BranchPrefix: "feature/", AutoMerge: "yes"}}}, strictSyncPullRequestSettings{})
+	require.EqualError(t, ruleErr, `pull_request.rules[0]: unsupported auto-merge method "yes"; use squash, merge, rebase, or off`)
+}
@@ -67,7 +67,7 @@ func planStrictSyncStack(ctx context.Context, environment *workflow.Environment,
 	}
 	inspectMergedStateBeforeCommit := options.Dirty && options.ChildBranch != options.DefaultBranch
 	if (remoteExists || localExists) && (storedParentBranch != "" || inspectMergedStateBeforeCommit) {
-		repositoryIdentifier := strictSyncRepositoryIdentifier(repository)
+		repositoryIdentifier := strictSyncRepositoryIdentifier(ctx, repository)
 		if repositoryIdentifier != "" && environment.GitHubClient != nil {
 			openPullRequest, openPullRequestErr := openPullRequestForBranch(ctx, environment, repositoryIdentifier, options.ChildBranch)
 			if openPullRequestErr != nil {
@@ -163,7 +163,7 @@ func ensureStrictSyncStackParentChain(ctx context.Context, environment *workflow
 	}
 	visitedBranches[options.Plan.ParentBranch] = struct{}{}
 
-	repositoryIdentifier := strictSyncRepositoryIdentifier(repository)
+	repositoryIdentifier := strictSyncRepositoryIdentifier(ctx, repository)
 	if repositoryIdentifier == "" {
 		return errors.New(strictSyncMissingRepositoryMessage)
 	}
@@ -0,0 +1,315 @@
+package syncflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/utils"
+)
+
+const (
+	strictSyncHandoffSchema            = "gix.sync-handoff/v1"
+	strictSyncHandoffFileName          = "sync-handoff.json"
+	strictSyncHandoffKindPublished     = "published"
+	strictSyncHandoffKindOwnership     = "ownership"
+	strictSyncHandoffKindRollback      = "rollback"
+	strictSyncHandoffWriteTemplate     = "write sync handoff record %s: %w"
+	strictSyncHandoffReadTemplate      = "read sync handoff record %s: %w"
+	strictSyncHandoffSchemaTemplate    = "sync handoff record %s has unsupported schema %q"
+	strictSyncHandoffPushTargetPrefix  = "To "
+	strictSyncHandoffPushRefSeparator  = ":"
+	strictSyncHandoffRecordDetailKey   = "handoff_record"
+	strictSyncHandoffWriteFailureEvent = "strict sync could not persist the handoff record for gix sync recover: %s"
+	strictSyncHandoffRemoveTemplate    = "remove sync handoff record %s: %w"
+	strictSyncHandoffSupersedeEvent    = "strict sync could not clear the superseded handoff record: %s"
+)
+
+// strictSyncHandoffRecord is the persisted recovery state that gix sync recover reads after a SYNC_SWITCH_HANDOFF.
+type strictSyncHandoffRecord struct {
+	Schema              string                         `json:"schema"`
+	Repository          string                         `json:"repository"`
+	TargetBranch        string                         `json:"target_branch"`
+	Kind                string                         `json:"kind"`
+	Reason              string                         `json:"reason"`
+	RecordedAt          time.Time                      `json:"recorded_at"`
+	StartingWorktree    strictSyncHandoffWorktree      `json:"starting_worktree"`
+	Snapshots           []strictSyncHandoffStash       `json:"snapshots,omitempty"`
+	OwnedStashes        []strictSyncHandoffStash       `json:"owned_stashes,omitempty"`
+	BranchMutations     []strictSyncHandoffReference   `json:"branch_mutations,omitempty"`
+	PublishedReferences []strictSyncHandoffPublication `json:"published_references,omitempty"`
+	PullRequest         *strictSyncHandoffPullRequest  `json:"pull_request,omitempty"`
+}
+
+type strictSyncHandoffWorktree struct {
+	Path       string `json:"path"`
+	BranchName string `json:"branch,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+}
+
+type strictSyncHandoffStash struct {
+	Path       string `json:"path"`
+	BranchName string `json:"branch,omitempty"`
+	CommitID   string `json:"stash"`
+}
+
+type strictSyncHandoffReference struct {
+	Reference      string `json:"reference"`
+	InitialCommit  string `json:"initial_commit,omitempty"`
+	ExpectedCommit string `json:"expected_commit,omitempty"`
+}
+
+type strictSyncHandoffPublication struct {
+	Remote    string `json:"remote"`
+	Reference string `json:"reference"`
+	Summary   string `json:"summary,omitempty"`
+}
+
+// strictSyncHandoffPullRequest is the pull request sync intended to open after its push.
+type strictSyncHandoffPullRequest struct {
+	Repository string   `json:"repository"`
+	BaseBranch string   `json:"base"`
+	BranchName string   `json:"branch"`
+	Head       string   `json:"head"`
+	Title      string   `json:"title"`
+	Body       string   `json:"body"`
+	Labels     []string `json:"labels,omitempty"`
+	Assignees  []string `json:"assignees,omitempty"`
+	Reviewers  []string `json:"reviewers,omitempty"`
+	Milestone  string   `json:"milestone,omitempty"`
+	Draft      bool     `json:"draft,omitempty"`
+	AutoMerge  string   `json:"auto_merge,omitempty"`
+}
+
+func (transaction *strictSyncTransaction) handoffRecord(kind string, reason string, recordedAt time.Time) strictSyncHandoffRecord {
+	record := strictSyncHandoffRecord{
+		Schema:       strictSyncHandoffSchema,
+		Repository:   transaction.repository.Path,
+		TargetBranch: transaction.targetBranch,
+		Kind:         kind,
+		Reason:       reason,
+		RecordedAt:   recordedAt.UTC(),
+		StartingWorktree: strictSyncHandoffWorktree{
+			Path:       transaction.startingWorktree.Path,
+			BranchName: transaction.startingWorktree.BranchName,
+			Commit:     transaction.startingWorktree.Commit,
+		},
+		PublishedReferences: append([]strictSyncHandoffPublication(nil), transaction.publishedReferences...),
+		PullRequest:         transaction.pendingPullRequest,
+	}
+	for snapshotIndex := range transaction.touchedWorktrees {
+		snapshot := transaction.touchedWorktrees[snapshotIndex]
+		if snapshot.Backup == nil || snapshot.BackupDropped {
+			continue
+		}
+		record.Snapshots = append(record.Snapshots, strictSyncHandoffStash{
+			Path:       snapshot.Backup.Path,
+			BranchName: snapshot.Worktree.BranchName,
+			CommitID:   snapshot.Backup.CommitID,
+		})
+	}
+	for stashIndex := range transaction.ownedStashes {
+		record.OwnedStashes = append(record.OwnedStashes, strictSyncHandoffStash{
+			Path:     transaction.ownedStashes[stashIndex].Path,
+			CommitID: transaction.ownedStashes[stashIndex].CommitID,
+		})
+	}
+	for _, referenceName := range sortedStrictSyncMutationReferenceNames(transaction.branchMutations) {
+		mutation := transaction.branchMutations[referenceName]
+		record.BranchMutations = append(record.BranchMutations, strictSyncHandoffReference{
+			Reference:      referenceName,
+			InitialCommit:  mutation.InitialCommit,
+			ExpectedCommit: mutation.ExpectedCommit,
+		})
+	}
+	return record
+}
+
+// persistHandoff writes the handoff record and returns event details pointing at it.
+// A write failure is reported as a warning because the handoff itself is already decided.
+func (transaction *strictSyncTransaction) persistHandoff(ctx context.Context, kind string, reason string) map[string]string {
+	details := map[string]string{
+		"target_branch": transaction.targetBranch,
+		"reason":        reason,
+	}
+	record := transaction.handoffRecord(kind, reason, time.Now())
+	recordPath, writeErr := writeStrictSyncHandoffRecord(ctx, transaction.environment.GitExecutor, transaction.repository.Path, record)
+	if writeErr != nil {
+		transaction.environment.ReportRepositoryEvent(
+			transaction.repository,
+			shared.EventLevelWarn,
+			shared.EventCodeSyncSwitchHandoff,
+			fmt.Sprintf(strictSyncHandoffWriteFailureEvent, strings.TrimSpace(writeErr.Error())),
+			map[string]string{"reason": writeErr.Error()},
+		)
+		return details
+	}
+	details[strictSyncHandoffRecordDetailKey] = recordPath
+	return details
+}
+
+func strictSyncHandoffRecordPath(ctx context.Context, executor shared.GitExecutor, repositoryPath string) (string, error) {
+	commonDirectory, commonDirectoryErr := resolveStrictSyncCommonDirectory(ctx, executor, repositoryPath)
+	if commonDirectoryErr != nil {
+		return "", commonDirectoryErr
+	}
+	return filepath.Join(commonDirectory, mergeConflictReportDirectoryName, strictSyncHandoffFileName), nil
+}
+
+func writeStrictSyncHandoffRecord(ctx context.Context, executor shared.GitExecutor, repositoryPath string, record strictSyncHandoffRecord) (string, error) {
+	recordPath, pathErr := strictSyncHandoffRecordPath(ctx, executor, repositoryPath)
+	if pathErr != nil {
+		return "", fmt.Errorf(strictSyncHandoffWriteTemplate, repositoryPath, pathErr)
+	}
+	contents, encodeErr := json.MarshalIndent(record, "", "  ")
+	if encodeErr != nil {
+		return "", fmt.Errorf(strictSyncHandoffWriteTemplate, recordPath, encodeErr)
+	}
+	if directoryErr := os.MkdirAll(filepath.Dir(recordPath), mergeConflictReportDirectoryPermissions); directoryErr != nil {
+		return "", fmt.Errorf(strictSyncHandoffWriteTemplate, recordPath, directoryErr)
+	}
+	if writeErr := utils.WriteFileAtomically(recordPath, append(contents, '\n'), mergeConflictReportFilePermissions); writeErr != nil {
+		return "", fmt.Errorf(strictSyncHandoffWriteTemplate, recordPath, writeErr)
+	}
+	return recordPath, nil
+}
+
+// removeStrictSyncHandoffRecord deletes the record at recordPath; a record that is already gone is not an error.
+func removeStrictSyncHandoffRecord(recordPath string) error {
+	if removeErr := os.Remove(recordPath); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+		return fmt.Errorf(strictSyncHandoffRemoveTemplate, recordPath, removeErr)
+	}
+	return nil
+}
+
+// supersedeHandoff removes a pending handoff record for the branch this sync just completed. The successful sync
+// already finished any merge and opened any missing pull request, so the record is only kept while one of its
+// preserved stashes is still in the stash list and gix sync recover has something left to reapply.
+func (transaction *strictSyncTransaction) supersedeHandoff(ctx context.Context) {
+	supersedeErr := transaction.removeSupersededHandoff(ctx)
+	if supersedeErr == nil {
+		return
+	}
+	transaction.environment.ReportRepositoryEvent(
+		transaction.repository,
+		shared.EventLevelWarn,
+		shared.EventCodeSyncSwitchHandoff,
+		fmt.Sprintf(strictSyncHandoffSupersedeEvent, strings.TrimSpace(supersedeErr.Error())),
+		map[string]string{"reason": supersedeErr.Error()},
+	)
+}
+
+func (transaction *strictSyncTransaction) removeSupersededHandoff(ctx context.Context) error {
+	executor := transaction.environment.GitExecutor
+	record, recordPath, found, readErr := readStrictSyncHandoffRecord(ctx, executor, transaction.repository.Path)
+	if readErr != nil || !found || record.TargetBranch != transaction.targetBranch {
+		return readErr
+	}
+	recovery := strictSyncRecovery{executor: executor}
+	stashes := append(append([]strictSyncHandoffStash(nil), record.OwnedStashes...), record.Snapshots...)
+	for _, stash := range stashes {
+		present, presentErr := recovery.stashPresent(ctx, stash)
+		if presentErr != nil || present {
+			return presentErr
+		}
+	}
+	return removeStrictSyncHandoffRecord(recordPath)
+}
+
+// readStrictSyncHandoffRecord loads the persisted handoff record; found is false when no handoff is pending.
+func readStrictSyncHandoffRecord(ctx context.Context, executor shared.GitExecutor, repositoryPath string) (record strictSyncHandoffRecord, recordPath string, found bool, err error) {
+	recordPath, pathErr := strictSyncHandoffRecordPath(ctx, executor, repositoryPath)
+	if pathErr != nil {
+		return strictSyncHandoffRecord{}, "", false, fmt.Errorf(strictSyncHandoffReadTemplate, repositoryPath, pathErr)
+	}
+	contents, readErr := os.ReadFile(recordPath)
+	if errors.Is(readErr, fs.ErrNotExist) {
+		return strictSyncHandoffRecord{}, recordPath, false, nil
+	}
+	if readErr != nil {
+		return strictSyncHandoffRecord{}, recordPath, false, fmt.Errorf(strictSyncHandoffReadTemplate, recordPath, readErr)
+	}
+	if decodeErr := json.Unmarshal(contents, &record); decodeErr != nil {
+		return strictSyncHandoffRecord{}, recordPath, false, fmt.Errorf(strictSyncHandoffReadTemplate, recordPath, decodeErr)
+	}
+	if record.Schema != strictSyncHandoffSchema {
+		return strictSyncHandoffRecord{}, recordPath, false, fmt.Errorf(strictSyncHandoffSchemaTemplate, recordPath, record.Schema)
+	}
+	return record, recordPath, true, nil
+}
+
+// strictSyncPushedReferences lists the remote refs a porcelain push actually updated.
+func strictSyncPushedReferences(output string) []strictSyncHandoffPublication {
+	remote := ""
+	publications := make([]strictSyncHandoffPublication, 0)
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, strictSyncHandoffPushTargetPrefix) {
+			remote = strings.TrimSpace(strings.TrimPrefix(line, strictSyncHandoffPushTargetPrefix))
+			continue
+		}
+		if len(line) < 2 || line[1] != '\t' {
+			continue
+		}
+		switch line[0] {
+		case ' ', '+', '-', '*':
+		default:
+			continue
+		}
+		fields := strings.Split(line[2:], "\t")
+		_, destination, found := strings.Cut(fields[0], strictSyncHandoffPushRefSeparator)
+		if !found {
+			destination = fields[0]
+		}
+		publication := strictSyncHandoffPublication{Remote: remote, Reference: strings.TrimSpace(destination)}
+		if len(fields) > 1 {
+			publication.Summary = strings.TrimSpace(fields[1])
+		}
+		publications = append(publications, publication)
+	}
+	return publications
+}
+
+func recordStrictSyncPublishedReferences(ctx context.Context, output string) {
+	transaction, ok := strictSyncTransactionFromContext(ctx)
+	if !ok {
+		return
+	}
+	transaction.publishedReferences = append(transaction.publishedReferences, strictSyncPushedReferences(output)...)
+}
+
+// expectStrictSyncPullRequest remembers the pull request sync is about to open so a handoff can offer to open it.
+func expectStrictSyncPullRequest(ctx context.Context, options strictPullRequestCreateOptions) {
+	transaction, ok := strictSyncTransactionFromContext(ctx)
+	if !ok {
+		return
+	}
+	transaction.pendingPullRequest = &strictSyncHandoffPullRequest{
+		Repository: options.RepositoryIdentifier,
+		BaseBranch: options.BaseBranch,
+		BranchName: options.BranchName,
+		Head:       strictSyncPullRequestHead(ctx, options.BranchName),
+		Title:      options.Title,
+		Body:       options.Body,
+		Labels:     options.Settings.Labels,
+		Assignees:  options.Settings.Assignees,
+		Reviewers:  options.Settings.Reviewers,
+		Milestone:  options.Settings.Milestone,
+		Draft:      options.Settings.draft(),
+		AutoMerge:  string(options.Settings.autoMergeMethod()),
+	}
+}
+
+func clearStrictSyncPullRequestExpectation(ctx context.Context) {
+	transaction, ok := strictSyncTransactionFromContext(ctx)
+	if !ok {
+		return
+	}
+	transaction.pendingPullRequest = nil
+}
@@ -0,0 +1,63 @@
+package syncflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/workflow"
+)
+
+func TestStrictSyncPushedReferencesListsOnlyUpdatedRefs(t *testing.T) {
+	output := "To github.com:owner/project.git\n" +
+		"*\trefs/heads/feature/new:refs/heads/feature/new\t[new branch]\n" +
+		"=\trefs/heads/master:refs/heads/master\t[up to date]\n" +
+		" \trefs/heads/feature/old:refs/heads/feature/old\tabc1234..def5678\n" +
+		"Done\n"
+
+	require.Equal(t, []strictSyncHandoffPublication{
+		{Remote: "github.com:owner/project.git", Reference: "refs/heads/feature/new", Summary: "[new branch]"},
+		{Remote: "github.com:owner/project.git", Reference: "refs/heads/feature/old", Summary: "abc1234..def5678"},
+	}, strictSyncPushedReferences(output))
+}
+
+func TestStrictSyncHandoffRecordCapturesRecoveryState(t *testing.T) {
+	transaction := &strictSyncTransaction{
+		repository:       &workflow.RepositoryState{Path: "/repo"},
+		startingWorktree: listedWorktree{Path: "/repo", BranchName: "master", Commit: "aaa"},
+		touchedWorktrees: []strictSyncWorktreeSnapshot{
+			{Worktree: listedWorktree{Path: "/repo", BranchName: "master"}, Backup: &strictSyncStash{CommitID: "dropped", Path: "/repo"}, BackupDropped: true},
+			{Worktree: listedWorktree{Path: "/sibling", BranchName: "feature/work"}, Backup: &strictSyncStash{CommitID: "snap", Path: "/sibling"}},
+		},
+		ownedStashes: []strictSyncStash{{CommitID: "owned", Path: "/repo"}},
+		branchMutations: map[string]strictSyncBranchMutation{
+			"refs/heads/feature/work": {InitialCommit: "bbb", InitialExists: true, ExpectedCommit: "ccc", ExpectedExists: true},
+		},
+		targetBranch: "feature/work",
+	}
+	ctx := withStrictSyncTransaction(context.Background(), transaction)
+	recordStrictSyncPublishedReferences(ctx, "To origin\n*\trefs/heads/feature/work:refs/heads/feature/work\t[new branch]\n")
+	expectStrictSyncPullRequest(ctx, strictPullRequestCreateOptions{
+		RepositoryIdentifier: "owner/project",
+		BaseBranch:           "master",
+		BranchName:           "feature/work",
+		Title:                "Work",
+		Settings:             strictSyncPullRequestSettings{Labels: []string{"sync"}, Reviewers: []string{"owner/core"}, AutoMerge: "squash"},
+	})
+	recordedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	record := transaction.handoffRecord(strictSyncHandoffKindPublished, "create pull request failed", recordedAt)
+
+	require.Equal(t, strictSyncHandoffSchema, record.Schema)
+	require.Equal(t, strictSyncHandoffWorktree{Path: "/repo", BranchName: "master", Commit: "aaa"}, record.StartingWorktree)
+	require.Equal(t, []strictSyncHandoffStash{{Path: "/sibling", BranchName: "feature/work", CommitID: "snap"}}, record.Snapshots)
+	require.Equal(t, []strictSyncHandoffStash{{Path: "/repo", CommitID: "owned"}}, record.OwnedStashes)
+	require.Equal(t, []strictSyncHandoffReference{{Reference: "refs/heads/feature/work", InitialCommit: "bbb", ExpectedCommit: "ccc"}}, record.BranchMutations)
+	require.Equal(t, []strictSyncHandoffPublication{{Remote: "origin", Reference: "refs/heads/feature/work", Summary: "[new branch]"}}, record.PublishedReferences)
+	require.Equal(t, &strictSyncHandoffPullRequest{Repository: "owner/project", BaseBranch: "master", BranchName: "feature/work", Head: "feature/work", Title: "Work", Labels: []string{"sync"}, Reviewers: []string{"owner/core"}, AutoMerge: "squash"}, record.PullRequest)
+
+	clearStrictSyncPullRequestExpectation(ctx)
+	require.Nil(t, transaction.handoffRecord(strictSyncHandoffKindPublished, "", recordedAt).PullRequest)
+}
@@ -0,0 +1,313 @@
+package syncflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/gitrepo"
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/repos/worktree"
+	"github.com/tyemirov/gix/internal/workflow"
+)
+
+const (
+	// StrictSyncTargetExplicit marks a target branch named by the request.
+	StrictSyncTargetExplicit = branchResolutionSourceExplicit
+	// StrictSyncTargetCurrent marks the checked-out branch used when the request names none.
+	StrictSyncTargetCurrent = syncCurrentBranchSource
+	// StrictSyncTargetConfigured marks the configured sync branch used from a detached checkout.
+	StrictSyncTargetConfigured = branchResolutionSourceConfigured
+
+	// StrictSyncReviewBaseDefault marks a review base taken from the remote default branch.
+	StrictSyncReviewBaseDefault = "default_branch"
+	// StrictSyncReviewBaseRecorded marks a stacked review base recorded under branch.<name>.gix-review-base.
+	StrictSyncReviewBaseRecorded = "recorded"
+
+	// StrictSyncDirtyActionCommit means dirty work is committed in clusters before the sync.
+	StrictSyncDirtyActionCommit = "commit"
+	// StrictSyncDirtyActionStash means dirty work is stashed for the sync and restored afterwards.
+	StrictSyncDirtyActionStash = "stash"
+
+	strictSyncPreviewNameTemplate         = "Sync %s"
+	strictSyncPreviewCurrentName          = "Sync current branch"
+	strictSyncPreviewRepositoryMessage    = "repository path is required to preview sync"
+	strictSyncPreviewManagerMessage       = "repository manager is required to preview sync"
+	strictSyncPreviewDefaultBranchMessage = "could not determine the default branch of remote %q: %w"
+	strictSyncPreviewRemoteHeadTemplate   = "refs/remotes/%s/HEAD"
+	gitSymbolicRefSubcommandConstant      = "symbolic-ref"
+	gitSymbolicRefShortFlagConstant       = "--short"
+)
+
+// StrictSyncRequest chooses the target branch and dirty-worktree policy for one strict sync started outside the sync command.
+type StrictSyncRequest struct {
+	RepositoryPath string
+	BranchName     string
+	StashChanges   bool
+	CommitChanges  bool
+	RequireClean   bool
+}
+
+// StrictSyncCluster is one top-level path group that sync commits on its own when it saves dirty work.
+type StrictSyncCluster struct {
+	Root           string
+	TrackedPaths   []string
+	UntrackedPaths []string
+}
+
+// StrictSyncPreview describes what strict sync would do for a request; building it changes nothing in the repository.
+type StrictSyncPreview struct {
+	CurrentBranch    string
+	TargetBranch     string
+	TargetSource     string
+	PushRemote       string
+	BaseRemote       string
+	DefaultBranch    string
+	ReviewBase       string
+	ReviewBaseSource string
+	GeneratedBranch  bool
+	DirtyAction      string
+	Clusters         []StrictSyncCluster
+	Blocker          string
+	Handoff          *StrictSyncHandoff
+}
+
+// StrictSyncHandoff is the pending recovery state a SYNC_SWITCH_HANDOFF left for gix sync recover.
+type StrictSyncHandoff struct {
+	RecordPath    string
+	Kind          string
+	Reason        string
+	TargetBranch  string
+	RecordedAt    time.Time
+	RecoverySteps []string
+}
+
+// StrictSyncTaskDefinition builds the branch.sync task gix sync runs for request. The sync configuration
+// supplies everything the request does not choose, exactly as it supplies the command-line defaults.
+func StrictSyncTaskDefinition(configuration CommandConfiguration, request StrictSyncRequest) (workflow.TaskDefinition, error) {
+	sanitized := configuration.Sanitize()
+	if request.StashChanges && request.CommitChanges {
+		return workflow.TaskDefinition{}, errors.New(conflictingRecoveryFlagsMessageConstant)
+	}
+	if forcePushModeErr := validateStrictSyncForcePushMode(sanitized.OnForcePush); forcePushModeErr != nil {
+		return workflow.TaskDefinition{}, forcePushModeErr
+	}
+	pullRequestPolicy, pullRequestPolicyErr := newStrictSyncPullRequestPolicy(sanitized.PullRequest, strictSyncPullRequestSettings{})
+	if pullRequestPolicyErr != nil {
+		return workflow.TaskDefinition{}, pullRequestPolicyErr
+	}
+
+	actionOptions := map[string]any{
+		taskOptionBranchRemote:          sanitized.RemoteName,
+		taskOptionBranchCreate:          sanitized.CreateIfMissing,
+		taskOptionRequirePullRequest:    true,
+		taskOptionRequireClean:          request.RequireClean,
+		taskOptionPullRequestPolicy:     pullRequestPolicy,
+		taskOptionWorktreeCommitMessage: worktreeAdoptionCommitMessageOptionsFromConfiguration(sanitized.CommitMessage),
+	}
+	branchName := strings.TrimSpace(request.BranchName)
+	if len(branchName) > 0 {
+		actionOptions[taskOptionBranchName] = branchName
+	}
+	if len(sanitized.DefaultBranch) > 0 {
+		actionOptions[taskOptionConfiguredDefaultBranch] = sanitized.DefaultBranch
+	}
+	if request.RequireClean || request.StashChanges || request.CommitChanges {
+		actionOptions[taskOptionRefreshEnabled] = true
+	}
+	if request.StashChanges {
+		actionOptions[taskOptionStashChanges] = true
+	}
+	if request.CommitChanges {
+		actionOptions[taskOptionCommitChanges] = true
+	}
+	if len(sanitized.PullRequest.Title) > 0 {
+		actionOptions[taskOptionPullRequestTitle] = sanitized.PullRequest.Title
+	}
+	if len(sanitized.PullRequest.Body) > 0 {
+		actionOptions[taskOptionPullRequestBody] = sanitized.PullRequest.Body
+	}
+	if sanitized.ConflictReport.Print {
+		actionOptions[taskOptionConflictReport] = true
+	}
+	if sanitized.ConflictReport.PullRequest {
+		actionOptions[taskOptionConflictReportInBody] = true
+	}
+	if upstreamRemote := strings.TrimSpace(sanitized.UpstreamRemote); len(upstreamRemote) > 0 {
+		actionOptions[taskOptionUpstreamRemote] = upstreamRemote
+	}
+	if len(sanitized.OnForcePush) > 0 {
+		actionOptions[taskOptionForcePushMode] = sanitized.OnForcePush
+	}
+	if len(sanitized.Verify) > 0 {
+		actionOptions[taskOptionVerifyCommands] = append([]string(nil), sanitized.Verify...)
+	}
+
+	taskName := strictSyncPreviewCurrentName
+	if len(branchName) > 0 {
+		taskName = fmt.Sprintf(strictSyncPreviewNameTemplate, branchName)
+	}
+	return workflow.TaskDefinition{
+		Name:        taskName,
+		EnsureClean: false,
+		Actions: []workflow.TaskActionDefinition{
+			{Type: taskTypeBranchSync, Options: actionOptions},
+		},
+	}, nil
+}
+
+// PreviewStrictSync resolves the target branch, remotes, review base, and dirty-work clusters a strict sync
+// would use for request. It reads local state and the remote default branch only; stacked parents that
+// depend on open or merged pull requests are settled by the sync itself. pullRequests is only consulted for a
+// pending handoff's pull request and may be nil.
+func PreviewStrictSync(ctx context.Context, executor shared.GitExecutor, manager shared.GitRepositoryManager, pullRequests StrictSyncPullRequestLister, configuration CommandConfiguration, request StrictSyncRequest) (StrictSyncPreview, error) {
+	repositoryPath := strings.TrimSpace(request.RepositoryPath)
+	if len(repositoryPath) == 0 {
+		return StrictSyncPreview{}, errors.New(strictSyncPreviewRepositoryMessage)
+	}
+	if executor == nil {
+		return StrictSyncPreview{}, ErrGitExecutorNotConfigured
+	}
+	if manager == nil {
+		return StrictSyncPreview{}, errors.New(strictSyncPreviewManagerMessage)
+	}
+	sanitized := configuration.Sanitize()
+
+	preview := StrictSyncPreview{}
+	currentBranch, currentBranchErr := manager.GetCurrentBranch(ctx, repositoryPath)
+	if currentBranchErr == nil {
+		preview.CurrentBranch = strings.TrimSpace(currentBranch)
+	}
+	preview.TargetBranch = strings.TrimSpace(request.BranchName)
+	preview.TargetSource = StrictSyncTargetExplicit
+	if len(preview.TargetBranch) == 0 && len(preview.CurrentBranch) > 0 {
+		preview.TargetBranch = preview.CurrentBranch
+		preview.TargetSource = StrictSyncTargetCurrent
+	}
+	if len(preview.TargetBranch) == 0 && len(sanitized.DefaultBranch) > 0 {
+		preview.TargetBranch = sanitized.DefaultBranch
+		preview.TargetSource = StrictSyncTargetConfigured
+	}
+	if len(preview.TargetBranch) == 0 {
+		return StrictSyncPreview{}, errors.New(missingBranchMessageConstant)
+	}
+
+	remotes, remotesErr := resolveStrictSyncRemotes(ctx, executor, repositoryPath, sanitized.RemoteName, sanitized.UpstreamRemote)
+	if remotesErr != nil {
+		return StrictSyncPreview{}, remotesErr
+	}
+	preview.PushRemote = remotes.PushRemote
+	preview.BaseRemote = remotes.PushRemote
+	if remotes.Fork != nil {
+		preview.BaseRemote = remotes.Fork.UpstreamRemote
+	}
+	defaultBranch, defaultBranchErr := previewStrictSyncDefaultBranch(ctx, executor, repositoryPath, preview.BaseRemote)
+	if defaultBranchErr != nil {
+		return StrictSyncPreview{}, defaultBranchErr
+	}
+	preview.DefaultBranch = defaultBranch
+
+	preview.ReviewBase = defaultBranch
+	preview.ReviewBaseSource = StrictSyncReviewBaseDefault
+	if preview.TargetBranch != defaultBranch && remotes.Fork == nil {
+		recordedBase, recorded, recordedErr := gitrepo.BranchReviewBase(ctx, executor, repositoryPath, preview.TargetBranch)
+		if recordedErr != nil {
+			return StrictSyncPreview{}, recordedErr
+		}
+		if recorded && len(recordedBase) > 0 {
+			preview.ReviewBase = recordedBase
+			preview.ReviewBaseSource = StrictSyncReviewBaseRecorded
+		}
+	}
+
+	statusEntries, statusErr := manager.WorktreeStatus(ctx, repositoryPath)
+	if statusErr != nil {
+		return StrictSyncPreview{}, statusErr
+	}
+	statusEntries = filterIgnoredUntrackedSyncStatusEntries(statusEntries)
+	trackedStatus, untrackedStatus := worktree.SplitStatusEntries(statusEntries, nil)
+	dirty := len(trackedStatus) > 0 || len(untrackedStatus) > 0
+	if dirty {
+		for _, cluster := range buildSyncCommitClusters(statusEntries) {
+			preview.Clusters = append(preview.Clusters, StrictSyncCluster{
+				Root:           cluster.Root,
+				TrackedPaths:   cluster.TrackedPaths,
+				UntrackedPaths: cluster.UntrackedPaths,
+			})
+		}
+	}
+
+	switch {
+	case request.StashChanges && request.CommitChanges:
+		preview.Blocker = conflictingRecoveryFlagsMessageConstant
+	case dirty && syncStatusEntriesHaveConflicts(statusEntries):
+		preview.Blocker = strictSyncConflictWorktreeMessage
+	case dirty && request.StashChanges:
+		preview.DirtyAction = StrictSyncDirtyActionStash
+	case dirty && request.RequireClean && !request.CommitChanges:
+		preview.Blocker = strictSyncDirtyWorktreeTemplate
+	case dirty:
+		preview.DirtyAction = StrictSyncDirtyActionCommit
+		preview.GeneratedBranch = preview.TargetBranch == defaultBranch && preview.TargetSource != StrictSyncTargetExplicit
+	}
+
+	handoff, handoffErr := ReadStrictSyncHandoff(ctx, executor, pullRequests, repositoryPath)
+	if handoffErr != nil {
+		return StrictSyncPreview{}, handoffErr
+	}
+	preview.Handoff = handoff
+	return preview, nil
+}
+
+// ReadStrictSyncHandoff returns the pending handoff recorded for repositoryPath with the completion steps
+// gix sync recover would still offer, or nil when no handoff is pending. pullRequests, when set, drops the
+// pull request step once that pull request is open.
+func ReadStrictSyncHandoff(ctx context.Context, executor shared.GitExecutor, pullRequests StrictSyncPullRequestLister, repositoryPath string) (*StrictSyncHandoff, error) {
+	record, recordPath, found, readErr := readStrictSyncHandoffRecord(ctx, executor, repositoryPath)
+	if readErr != nil {
+		return nil, readErr
+	}
+	if !found {
+		return nil, nil
+	}
+	steps, stepsErr := strictSyncRecovery{executor: executor}.pendingSteps(ctx, pullRequests, repositoryPath, record)
+	if stepsErr != nil {
+		return nil, stepsErr
+	}
+	return &StrictSyncHandoff{
+		RecordPath:    recordPath,
+		Kind:          record.Kind,
+		Reason:        record.Reason,
+		TargetBranch:  record.TargetBranch,
+		RecordedAt:    record.RecordedAt,
+		RecoverySteps: steps,
+	}, nil
+}
+
+// previewStrictSyncDefaultBranch asks the remote like sync does and falls back to the last fetched remote HEAD when it is unreachable.
+func previewStrictSyncDefaultBranch(ctx context.Context, executor shared.GitExecutor, repositoryPath string, remoteName string) (string, error) {
+	defaultBranch, resolveErr := resolveStrictSyncRemoteDefaultBranch(ctx, executor, repositoryPath, remoteName)
+	if resolveErr == nil {
+		return defaultBranch, nil
+	}
+	result, symbolicRefErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments: []string{
+			gitSymbolicRefSubcommandConstant,
+			gitRevParseQuietFlagConstant,
+			gitSymbolicRefShortFlagConstant,
+			fmt.Sprintf(strictSyncPreviewRemoteHeadTemplate, remoteName),
+		},
+		WorkingDirectory: repositoryPath,
+	})
+	if symbolicRefErr != nil {
+		return "", fmt.Errorf(strictSyncPreviewDefaultBranchMessage, remoteName, resolveErr)
+	}
+	fetchedHead := strings.TrimPrefix(strings.TrimSpace(result.StandardOutput), remoteName+"/")
+	if len(fetchedHead) == 0 {
+		return "", fmt.Errorf(strictSyncPreviewDefaultBranchMessage, remoteName, resolveErr)
+	}
+	return fetchedHead, nil
+}
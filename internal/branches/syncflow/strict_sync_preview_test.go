@@ -0,0 +1,227 @@
+package syncflow
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/githubcli"
+	"github.com/tyemirov/gix/internal/gitrepo"
+	"github.com/tyemirov/gix/internal/workflow"
+)
+
+func runStrictSyncPreviewGit(t *testing.T, workingDirectory string, arguments ...string) string {
+	t.Helper()
+	command := exec.Command("git", append([]string{"-c", "user.name=Preview", "-c", "user.email=preview@example.com", "-c", "commit.gpgsign=false"}, arguments...)...)
+	command.Dir = workingDirectory
+	output, commandErr := command.CombinedOutput()
+	require.NoError(t, commandErr, string(output))
+	return string(output)
+}
+
+func newStrictSyncPreviewRepository(t *testing.T) string {
+	t.Helper()
+	rootPath := t.TempDir()
+	remotePath := filepath.Join(rootPath, "remote.git")
+	repositoryPath := filepath.Join(rootPath, "clone")
+	runStrictSyncPreviewGit(t, rootPath, "init", "--bare", "--initial-branch=main", remotePath)
+	runStrictSyncPreviewGit(t, rootPath, "clone", remotePath, repositoryPath)
+	runStrictSyncPreviewGit(t, repositoryPath, "checkout", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("readme\n"), 0o644))
+	runStrictSyncPreviewGit(t, repositoryPath, "add", "README.md")
+	runStrictSyncPreviewGit(t, repositoryPath, "commit", "-m", "initial")
+	runStrictSyncPreviewGit(t, repositoryPath, "push", "-u", "origin", "main")
+	return repositoryPath
+}
+
+func newStrictSyncPreviewCollaborators(t *testing.T) (*execshell.ShellExecutor, *gitrepo.RepositoryManager) {
+	t.Helper()
+	executor, executorErr := execshell.NewShellExecutor(zap.NewNop(), execshell.NewOSCommandRunner(), false)
+	require.NoError(t, executorErr)
+	manager, managerErr := gitrepo.NewRepositoryManager(executor)
+	require.NoError(t, managerErr)
+	return executor, manager
+}
+
+func TestStrictSyncTaskDefinitionAppliesRequestOverConfiguration(t *testing.T) {
+	configuration := DefaultCommandConfiguration()
+	configuration.RemoteName = " origin "
+	configuration.DefaultBranch = "main"
+	configuration.Verify = []string{"go test ./..."}
+	configuration.PullRequest.Title = "Sync work"
+
+	definition, definitionErr := StrictSyncTaskDefinition(configuration, StrictSyncRequest{BranchName: " feature/panel ", StashChanges: true})
+	require.NoError(t, definitionErr)
+	require.Equal(t, "Sync feature/panel", definition.Name)
+	require.Len(t, definition.Actions, 1)
+	require.Equal(t, taskTypeBranchSync, definition.Actions[0].Type)
+
+	options := definition.Actions[0].Options
+	require.Equal(t, "feature/panel", options[taskOptionBranchName])
+	require.Equal(t, "origin", options[taskOptionBranchRemote])
+	require.Equal(t, "main", options[taskOptionConfiguredDefaultBranch])
+	require.Equal(t, true, options[taskOptionRequirePullRequest])
+	require.Equal(t, true, options[taskOptionStashChanges])
+	require.Equal(t, true, options[taskOptionRefreshEnabled])
+	require.Equal(t, false, options[taskOptionRequireClean])
+	require.NotContains(t, options, taskOptionCommitChanges)
+	require.Equal(t, "Sync work", options[taskOptionPullRequestTitle])
+	require.Equal(t, []string{"go test ./..."}, options[taskOptionVerifyCommands])
+
+	currentDefinition, currentErr := StrictSyncTaskDefinition(configuration, StrictSyncRequest{})
+	require.NoError(t, currentErr)
+	require.Equal(t, strictSyncPreviewCurrentName, currentDefinition.Name)
+	require.NotContains(t, currentDefinition.Actions[0].Options, taskOptionRefreshEnabled)
+
+	_, conflictErr := StrictSyncTaskDefinition(configuration, StrictSyncRequest{StashChanges: true, CommitChanges: true})
+	require.EqualError(t, conflictErr, conflictingRecoveryFlagsMessageConstant)
+}
+
+func TestPreviewStrictSyncReportsClustersAndRecordedReviewBase(t *testing.T) {
+	repositoryPath := newStrictSyncPreviewRepository(t)
+	runStrictSyncPreviewGit(t, repositoryPath, "checkout", "-b", "feature/child")
+	runStrictSyncPreviewGit(t, repositoryPath, "config", "--local", gitrepo.BranchReviewBaseKey("feature/child"), "feature/parent")
+	require.NoError(t, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("changed\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(repositoryPath, "docs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repositoryPath, "docs", "guide.md"), []byte("guide\n"), 0o644))
+	executor, manager := newStrictSyncPreviewCollaborators(t)
+
+	preview, previewErr := PreviewStrictSync(context.Background(), executor, manager, nil, DefaultCommandConfiguration(), StrictSyncRequest{RepositoryPath: repositoryPath, CommitChanges: true})
+	require.NoError(t, previewErr)
+	require.Equal(t, "feature/child", preview.CurrentBranch)
+	require.Equal(t, "feature/child", preview.TargetBranch)
+	require.Equal(t, StrictSyncTargetCurrent, preview.TargetSource)
+	require.Equal(t, "origin", preview.PushRemote)
+	require.Equal(t, "main", preview.DefaultBranch)
+	require.Equal(t, "feature/parent", preview.ReviewBase)
+	require.Equal(t, StrictSyncReviewBaseRecorded, preview.ReviewBaseSource)
+	require.Equal(t, StrictSyncDirtyActionCommit, preview.DirtyAction)
+	require.False(t, preview.GeneratedBranch)
+	require.Empty(t, preview.Blocker)
+	require.Nil(t, preview.Handoff)
+
+	clusterRoots := make([]string, 0, len(preview.Clusters))
+	for _, cluster := range preview.Clusters {
+		clusterRoots = append(clusterRoots, cluster.Root)
+	}
+	require.ElementsMatch(t, []string{"README.md", "docs"}, clusterRoots)
+
+	cleanRequired, cleanErr := PreviewStrictSync(context.Background(), executor, manager, nil, DefaultCommandConfiguration(), StrictSyncRequest{RepositoryPath: repositoryPath, BranchName: "main", RequireClean: true})
+	require.NoError(t, cleanErr)
+	require.Equal(t, StrictSyncTargetExplicit, cleanRequired.TargetSource)
+	require.Equal(t, "main", cleanRequired.ReviewBase)
+	require.Equal(t, StrictSyncReviewBaseDefault, cleanRequired.ReviewBaseSource)
+	require.Equal(t, strictSyncDirtyWorktreeTemplate, cleanRequired.Blocker)
+	require.Empty(t, cleanRequired.DirtyAction)
+}
+
+func TestReadStrictSyncHandoffListsRemainingRecoverySteps(t *testing.T) {
+	repositoryPath := newStrictSyncPreviewRepository(t)
+	executor, _ := newStrictSyncPreviewCollaborators(t)
+
+	missing, missingErr := ReadStrictSyncHandoff(context.Background(), executor, nil, repositoryPath)
+	require.NoError(t, missingErr)
+	require.Nil(t, missing)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("stashed\n"), 0o644))
+	runStrictSyncPreviewGit(t, repositoryPath, "stash", "push")
+	stashCommit := strings.TrimSpace(runStrictSyncPreviewGit(t, repositoryPath, "rev-parse", "stash@{0}"))
+	recordedAt := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	_, writeErr := writeStrictSyncHandoffRecord(context.Background(), executor, repositoryPath, strictSyncHandoffRecord{
+		Schema:       strictSyncHandoffSchema,
+		Repository:   repositoryPath,
+		TargetBranch: "feature/panel",
+		Kind:         strictSyncHandoffKindPublished,
+		Reason:       "pull request creation failed",
+		RecordedAt:   recordedAt,
+		OwnedStashes: []strictSyncHandoffStash{
+			{Path: repositoryPath, CommitID: stashCommit},
+			{Path: repositoryPath, CommitID: "0000000000000000000000000000000000000000"},
+		},
+		PullRequest: &strictSyncHandoffPullRequest{Repository: "owner/project", BaseBranch: "main", BranchName: "feature/panel", Head: "feature/panel"},
+	})
+	require.NoError(t, writeErr)
+
+	handoff, handoffErr := ReadStrictSyncHandoff(context.Background(), executor, nil, repositoryPath)
+	require.NoError(t, handoffErr)
+	require.NotNil(t, handoff)
+	require.Equal(t, strictSyncHandoffKindPublished, handoff.Kind)
+	require.Equal(t, "feature/panel", handoff.TargetBranch)
+	require.True(t, recordedAt.Equal(handoff.RecordedAt))
+	require.Equal(t, []string{
+		"reapply stash " + stashCommit + " in " + repositoryPath,
+		"open pull request feature/panel into main on owner/project",
+	}, handoff.RecoverySteps)
+
+	closedOnly := &stubStrictSyncPullRequestLister{}
+	unopened, unopenedErr := ReadStrictSyncHandoff(context.Background(), executor, closedOnly, repositoryPath)
+	require.NoError(t, unopenedErr)
+	require.Len(t, unopened.RecoverySteps, 2)
+	require.Equal(t, githubcli.PullRequestListOptions{
+		State:       githubcli.PullRequestStateOpen,
+		BaseBranch:  "main",
+		HeadBranch:  "feature/panel",
+		ResultLimit: recoverPullRequestListResultLimit,
+	}, closedOnly.options)
+
+	opened, openedErr := ReadStrictSyncHandoff(context.Background(), executor, &stubStrictSyncPullRequestLister{
+		pullRequests: []githubcli.PullRequest{{HeadRefName: "feature/panel"}},
+	}, repositoryPath)
+	require.NoError(t, openedErr)
+	require.Equal(t, []string{"reapply stash " + stashCommit + " in " + repositoryPath}, opened.RecoverySteps)
+}
+
+type stubStrictSyncPullRequestLister struct {
+	pullRequests []githubcli.PullRequest
+	options      githubcli.PullRequestListOptions
+}
+
+func (lister *stubStrictSyncPullRequestLister) ListPullRequests(_ context.Context, _ string, options githubcli.PullRequestListOptions) ([]githubcli.PullRequest, error) {
+	lister.options = options
+	return lister.pullRequests, nil
+}
+
+func TestStrictSyncSupersedesHandoffOnceNothingRemainsToRecover(t *testing.T) {
+	repositoryPath := newStrictSyncPreviewRepository(t)
+	executor, _ := newStrictSyncPreviewCollaborators(t)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("stashed\n"), 0o644))
+	runStrictSyncPreviewGit(t, repositoryPath, "stash", "push")
+	stashCommit := strings.TrimSpace(runStrictSyncPreviewGit(t, repositoryPath, "rev-parse", "stash@{0}"))
+	recordPath, writeErr := writeStrictSyncHandoffRecord(context.Background(), executor, repositoryPath, strictSyncHandoffRecord{
+		Schema:       strictSyncHandoffSchema,
+		Repository:   repositoryPath,
+		TargetBranch: "main",
+		Kind:         strictSyncHandoffKindRollback,
+		OwnedStashes: []strictSyncHandoffStash{{Path: repositoryPath, CommitID: stashCommit}},
+	})
+	require.NoError(t, writeErr)
+
+	newTransaction := func(targetBranch string) *strictSyncTransaction {
+		return &strictSyncTransaction{
+			environment:  &workflow.Environment{GitExecutor: executor},
+			repository:   &workflow.RepositoryState{Path: repositoryPath},
+			targetBranch: targetBranch,
+		}
+	}
+
+	require.NoError(t, newTransaction("feature/other").removeSupersededHandoff(context.Background()))
+	require.FileExists(t, recordPath)
+
+	require.NoError(t, newTransaction("main").removeSupersededHandoff(context.Background()))
+	require.FileExists(t, recordPath, "a preserved stash still needs gix sync recover")
+
+	runStrictSyncPreviewGit(t, repositoryPath, "stash", "pop")
+	require.NoError(t, newTransaction("main").removeSupersededHandoff(context.Background()))
+	require.NoFileExists(t, recordPath)
+
+	require.NoError(t, newTransaction("main").removeSupersededHandoff(context.Background()))
+}
@@ -0,0 +1,458 @@
+package syncflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/githubcli"
+	"github.com/tyemirov/gix/internal/gitrepo"
+	"github.com/tyemirov/gix/internal/repos/shared"
+	flagutils "github.com/tyemirov/gix/internal/utils/flags"
+	rootutils "github.com/tyemirov/gix/internal/utils/roots"
+	"github.com/tyemirov/gix/pkg/taskrunner"
+)
+
+const (
+	recoverCommandUseNameConstant          = "recover"
+	recoverCommandShortDescriptionConstant = "Inspect and complete a sync that ended in SYNC_SWITCH_HANDOFF"
+	recoverCommandLongDescriptionConstant  = "recover reads the handoff record that sync writes under .git/gix/sync-handoff.json when it reports SYNC_SWITCH_HANDOFF. " +
+		"It prints the handoff reason, the starting checkout, the preserved transaction snapshots and invocation-owned stashes, the journaled branch refs, and the remote refs that were already published. " +
+		"It then offers each remaining completion step in order: finish an in-progress merge, reapply each preserved stash with its index, and open the pull request that sync pushed but did not open. " +
+		"Each step asks for confirmation unless --yes is set. The record is removed once every step is complete; a declined step keeps it for the next run. " +
+		"A later successful sync of the same branch also removes the record once none of its preserved stashes remain."
+	recoverNoHandoffTemplate          = "NO HANDOFF: %s\n"
+	recoverRecoveredTemplate          = "RECOVERED: %s (%s)\n"
+	recoverPendingTemplate            = "PENDING: %s (%s); rerun gix sync recover to finish the remaining steps\n"
+	recoverHeaderTemplate             = "HANDOFF: %s (%s) %s handoff recorded %s\n"
+	recoverReasonTemplate             = "  reason: %s\n"
+	recoverRecordTemplate             = "  record: %s\n"
+	recoverStartingTemplate           = "  starting checkout: %s %s at %s\n"
+	recoverSnapshotTemplate           = "  transaction snapshot: %s stash %s%s\n"
+	recoverOwnedStashTemplate         = "  invocation-owned stash: %s stash %s%s\n"
+	recoverBranchMutationTemplate     = "  journaled ref: %s %s -> %s\n"
+	recoverPublishedTemplate          = "  published: %s %s (%s)\n"
+	recoverPullRequestTemplate        = "  pull request: %s into %s on %s\n"
+	recoverStashGoneSuffix            = " (no longer in the stash list)"
+	recoverStepSkippedTemplate        = "SKIPPED: %s\n"
+	recoverStepDoneTemplate           = "DONE: %s\n"
+	recoverMergePromptTemplate        = "Commit the in-progress merge in %s? [a/N/y] "
+	recoverMergeStepTemplate          = "commit the in-progress merge in %s"
+	recoverUnmergedPathsTemplate      = "%s still has unresolved paths: %s; resolve them, drop any stash whose conflicted apply they came from, and rerun gix sync recover"
+	recoverSnapshotPromptTemplate     = "Restore transaction snapshot %s in %s? [a/N/y] "
+	recoverOwnedStashPromptTemplate   = "Reapply invocation-owned stash %s in %s? [a/N/y] "
+	recoverStashStepTemplate          = "reapply stash %s in %s"
+	recoverPullRequestPromptTemplate  = "Open pull request %s into %s on %s? [a/N/y] "
+	recoverPullRequestStepTemplate    = "open pull request %s into %s on %s"
+	recoverPullRequestExistsTemplate  = "pull request for %s into %s already open on %s"
+	recoverAutoMergeStepTemplate      = "enable %s auto-merge for %s"
+	recoverMissingGitHubClientMessage = "GitHub client is required to open the missing pull request"
+	recoverUnknownBranchLabel         = "(detached)"
+	recoverStepFailureTemplate        = "%s: %w"
+	recoverPullRequestListResultLimit = 100
+	recoverMergeHeadMissingReturnCode = 1
+)
+
+// StrictSyncPullRequestLister is the GitHub surface that tells whether a handoff's pull request is already open.
+type StrictSyncPullRequestLister interface {
+	ListPullRequests(ctx context.Context, repository string, options githubcli.PullRequestListOptions) ([]githubcli.PullRequest, error)
+}
+
+// strictSyncRecoveryPullRequests is the GitHub surface recover needs to open a missing pull request.
+type strictSyncRecoveryPullRequests interface {
+	StrictSyncPullRequestLister
+	CreatePullRequest(ctx context.Context, options githubcli.PullRequestCreateOptions) error
+	EnablePullRequestAutoMerge(ctx context.Context, repository string, head string, method githubcli.PullRequestMergeMethod) error
+}
+
+// strictSyncRecovery walks the operator through completing one repository's sync handoff.
+type strictSyncRecovery struct {
+	executor     shared.GitExecutor
+	pullRequests strictSyncRecoveryPullRequests
+	prompter     shared.ConfirmationPrompter
+	assumeYes    bool
+	output       io.Writer
+}
+
+func (builder *CommandBuilder) buildRecoverCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   recoverCommandUseNameConstant,
+		Short: recoverCommandShortDescriptionConstant,
+		Long:  recoverCommandLongDescriptionConstant,
+		Args:  cobra.NoArgs,
+		RunE:  builder.runRecover,
+	}
+}
+
+func (builder *CommandBuilder) runRecover(command *cobra.Command, arguments []string) error {
+	configuration := builder.resolveConfiguration()
+	executionFlags, executionFlagsAvailable := flagutils.ResolveExecutionFlags(command)
+
+	dependencyResult, dependencyError := taskrunner.BuildDependencies(
+		taskrunner.DependenciesConfig{
+			LoggerProvider:               builder.LoggerProvider,
+			HumanReadableLoggingProvider: builder.HumanReadableLoggingProvider,
+			RepositoryDiscoverer:         builder.Discoverer,
+			GitExecutor:                  builder.GitExecutor,
+			GitRepositoryManager:         builder.GitManager,
+			GitHubResolver:               builder.GitHubResolver,
+			FileSystem:                   builder.FileSystem,
+			PrompterFactory:              builder.PrompterFactory,
+		},
+		taskrunner.DependenciesOptions{
+			Command: command,
+			Output:  command.OutOrStdout(),
+			Errors:  command.ErrOrStderr(),
+		},
+	)
+	if dependencyError != nil {
+		return dependencyError
+	}
+
+	repositoryRoots, rootsError := rootutils.Resolve(command, arguments, configuration.RepositoryRoots)
+	if rootsError != nil {
+		return rootsError
+	}
+	repositories, discoveryError := dependencyResult.RepositoryDiscoverer.DiscoverRepositories(repositoryRoots)
+	if discoveryError != nil {
+		return discoveryError
+	}
+
+	recovery := strictSyncRecovery{
+		executor:  dependencyResult.GitExecutor,
+		prompter:  dependencyResult.Workflow.Prompter,
+		assumeYes: executionFlagsAvailable && executionFlags.AssumeYes,
+		output:    command.OutOrStdout(),
+	}
+	if dependencyResult.Workflow.GitHubClient != nil {
+		recovery.pullRequests = dependencyResult.Workflow.GitHubClient
+	}
+
+	var recoveryErrors []error
+	for _, repositoryPath := range repositories {
+		if recoverErr := recovery.recover(command.Context(), repositoryPath); recoverErr != nil {
+			recoveryErrors = append(recoveryErrors, recoverErr)
+		}
+	}
+	return errors.Join(recoveryErrors...)
+}
+
+// recover shows the persisted handoff state and offers each completion step; the record is removed only when none remain.
+func (recovery strictSyncRecovery) recover(ctx context.Context, repositoryPath string) error {
+	record, recordPath, found, readErr := readStrictSyncHandoffRecord(ctx, recovery.executor, repositoryPath)
+	if readErr != nil {
+		return readErr
+	}
+	if !found {
+		fmt.Fprintf(recovery.output, recoverNoHandoffTemplate, repositoryPath)
+		return nil
+	}
+	recovery.describe(ctx, record, recordPath)
+
+	complete, mergeErr := recovery.finishMerge(ctx, repositoryPath)
+	if mergeErr != nil {
+		return mergeErr
+	}
+
+	stashes := make([]strictSyncHandoffStash, 0, len(record.OwnedStashes)+len(record.Snapshots))
+	stashes = append(stashes, record.OwnedStashes...)
+	stashes = append(stashes, record.Snapshots...)
+	for stashIndex := range stashes {
+		prompt := recoverOwnedStashPromptTemplate
+		if stashIndex >= len(record.OwnedStashes) {
+			prompt = recoverSnapshotPromptTemplate
+		}
+		stashComplete, stashErr := recovery.reapplyStash(ctx, stashes[stashIndex], prompt)
+		if stashErr != nil {
+			return stashErr
+		}
+		complete = complete && stashComplete
+	}
+
+	if record.Kind == strictSyncHandoffKindPublished && record.PullRequest != nil {
+		pullRequestComplete, pullRequestErr := recovery.openPullRequest(ctx, *record.PullRequest)
+		if pullRequestErr != nil {
+			return pullRequestErr
+		}
+		complete = complete && pullRequestComplete
+	}
+
+	if !complete {
+		fmt.Fprintf(recovery.output, recoverPendingTemplate, repositoryPath, record.TargetBranch)
+		return nil
+	}
+	if removeErr := removeStrictSyncHandoffRecord(recordPath); removeErr != nil {
+		return removeErr
+	}
+	fmt.Fprintf(recovery.output, recoverRecoveredTemplate, repositoryPath, record.TargetBranch)
+	return nil
+}
+
+func (recovery strictSyncRecovery) describe(ctx context.Context, record strictSyncHandoffRecord, recordPath string) {
+	fmt.Fprintf(recovery.output, recoverHeaderTemplate, record.Repository, record.TargetBranch, record.Kind, record.RecordedAt.Format(time.RFC3339))
+	fmt.Fprintf(recovery.output, recoverReasonTemplate, record.Reason)
+	fmt.Fprintf(recovery.output, recoverRecordTemplate, recordPath)
+	startingBranch := record.StartingWorktree.BranchName
+	if startingBranch == "" {
+		startingBranch = recoverUnknownBranchLabel
+	}
+	fmt.Fprintf(recovery.output, recoverStartingTemplate, record.StartingWorktree.Path, startingBranch, record.StartingWorktree.Commit)
+	for _, snapshot := range record.Snapshots {
+		fmt.Fprintf(recovery.output, recoverSnapshotTemplate, snapshot.Path, snapshot.CommitID, recovery.stashSuffix(ctx, snapshot))
+	}
+	for _, stash := range record.OwnedStashes {
+		fmt.Fprintf(recovery.output, recoverOwnedStashTemplate, stash.Path, stash.CommitID, recovery.stashSuffix(ctx, stash))
+	}
+	for _, mutation := range record.BranchMutations {
+		fmt.Fprintf(recovery.output, recoverBranchMutationTemplate, mutation.Reference, strictSyncReferenceState(mutation.InitialCommit, mutation.InitialCommit != ""), strictSyncReferenceState(mutation.ExpectedCommit, mutation.ExpectedCommit != ""))
+	}
+	for _, publication := range record.PublishedReferences {
+		fmt.Fprintf(recovery.output, recoverPublishedTemplate, publication.Remote, publication.Reference, publication.Summary)
+	}
+	if record.Kind == strictSyncHandoffKindPublished && record.PullRequest != nil {
+		fmt.Fprintf(recovery.output, recoverPullRequestTemplate, record.PullRequest.Head, record.PullRequest.BaseBranch, record.PullRequest.Repository)
+	}
+}
+
+func (recovery strictSyncRecovery) stashSuffix(ctx context.Context, stash strictSyncHandoffStash) string {
+	present, presentErr := recovery.stashPresent(ctx, stash)
+	if presentErr != nil || present {
+		return ""
+	}
+	return recoverStashGoneSuffix
+}
+
+func (recovery strictSyncRecovery) finishMerge(ctx context.Context, repositoryPath string) (bool, error) {
+	unmergedPaths, unmergedErr := mergeConflictResolutionService{executor: recovery.executor, repositoryPath: repositoryPath}.unmergedPaths(ctx)
+	if unmergedErr != nil {
+		return false, unmergedErr
+	}
+	if len(unmergedPaths) > 0 {
+		return false, fmt.Errorf(recoverUnmergedPathsTemplate, repositoryPath, strings.Join(unmergedPaths, ", "))
+	}
+	inProgress, mergeHeadErr := recovery.mergeInProgress(ctx, repositoryPath)
+	if mergeHeadErr != nil {
+		return false, mergeHeadErr
+	}
+	if !inProgress {
+		return true, nil
+	}
+	step := fmt.Sprintf(recoverMergeStepTemplate, repositoryPath)
+	confirmed, confirmErr := recovery.confirm(fmt.Sprintf(recoverMergePromptTemplate, repositoryPath))
+	if confirmErr != nil {
+		return false, confirmErr
+	}
+	if !confirmed {
+		fmt.Fprintf(recovery.output, recoverStepSkippedTemplate, step)
+		return false, nil
+	}
+	if signingErr := gitrepo.VerifyCommitSigning(ctx, recovery.executor, repositoryPath); signingErr != nil {
+		return false, fmt.Errorf(recoverStepFailureTemplate, step, signingErr)
+	}
+	if commitErr := executeGit(ctx, recovery.executor, repositoryPath, []string{gitCommitSubcommandConstant, gitCommitNoEditFlagConstant}); commitErr != nil {
+		return false, fmt.Errorf(recoverStepFailureTemplate, step, commitErr)
+	}
+	fmt.Fprintf(recovery.output, recoverStepDoneTemplate, step)
+	return true, nil
+}
+
+// mergeInProgress reports whether repositoryPath holds a MERGE_HEAD still waiting for its merge commit.
+func (recovery strictSyncRecovery) mergeInProgress(ctx context.Context, repositoryPath string) (bool, error) {
+	_, mergeHeadErr := recovery.executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments:        []string{gitRevParseSubcommandConstant, gitVerifyFlagConstant, gitRevParseQuietFlagConstant, gitMergeHeadReferenceConstant},
+		WorkingDirectory: repositoryPath,
+	})
+	if mergeHeadErr == nil {
+		return true, nil
+	}
+	var commandFailure execshell.CommandFailedError
+	if errors.As(mergeHeadErr, &commandFailure) && commandFailure.Result.ExitCode == recoverMergeHeadMissingReturnCode {
+		return false, nil
+	}
+	return false, mergeHeadErr
+}
+
+// pendingSteps lists, without prompting or changing anything, the completion steps recover would still offer for record.
+// Without pullRequests the recorded pull request cannot be checked and is listed as pending.
+func (recovery strictSyncRecovery) pendingSteps(ctx context.Context, pullRequests StrictSyncPullRequestLister, repositoryPath string, record strictSyncHandoffRecord) ([]string, error) {
+	steps := make([]string, 0)
+	unmergedPaths, unmergedErr := mergeConflictResolutionService{executor: recovery.executor, repositoryPath: repositoryPath}.unmergedPaths(ctx)
+	if unmergedErr != nil {
+		return nil, unmergedErr
+	}
+	if len(unmergedPaths) > 0 {
+		steps = append(steps, fmt.Sprintf(recoverUnmergedPathsTemplate, repositoryPath, strings.Join(unmergedPaths, ", ")))
+	} else {
+		inProgress, mergeHeadErr := recovery.mergeInProgress(ctx, repositoryPath)
+		if mergeHeadErr != nil {
+			return nil, mergeHeadErr
+		}
+		if inProgress {
+			steps = append(steps, fmt.Sprintf(recoverMergeStepTemplate, repositoryPath))
+		}
+	}
+
+	stashes := make([]strictSyncHandoffStash, 0, len(record.OwnedStashes)+len(record.Snapshots))
+	stashes = append(stashes, record.OwnedStashes...)
+	stashes = append(stashes, record.Snapshots...)
+	for _, stash := range stashes {
+		present, presentErr := recovery.stashPresent(ctx, stash)
+		if presentErr != nil {
+			return nil, presentErr
+		}
+		if present {
+			steps = append(steps, fmt.Sprintf(recoverStashStepTemplate, stash.CommitID, stash.Path))
+		}
+	}
+
+	if record.Kind == strictSyncHandoffKindPublished && record.PullRequest != nil {
+		open := false
+		if pullRequests != nil {
+			var openErr error
+			open, openErr = strictSyncHandoffPullRequestOpen(ctx, pullRequests, *record.PullRequest)
+			if openErr != nil {
+				return nil, openErr
+			}
+		}
+		if !open {
+			steps = append(steps, fmt.Sprintf(recoverPullRequestStepTemplate, record.PullRequest.Head, record.PullRequest.BaseBranch, record.PullRequest.Repository))
+		}
+	}
+	return steps, nil
+}
+
+func (recovery strictSyncRecovery) reapplyStash(ctx context.Context, stash strictSyncHandoffStash, promptTemplate string) (bool, error) {
+	present, presentErr := recovery.stashPresent(ctx, stash)
+	if presentErr != nil {
+		return false, presentErr
+	}
+	if !present {
+		return true, nil
+	}
+	step := fmt.Sprintf(recoverStashStepTemplate, stash.CommitID, stash.Path)
+	confirmed, confirmErr := recovery.confirm(fmt.Sprintf(promptTemplate, stash.CommitID, stash.Path))
+	if confirmErr != nil {
+		return false, confirmErr
+	}
+	if !confirmed {
+		fmt.Fprintf(recovery.output, recoverStepSkippedTemplate, step)
+		return false, nil
+	}
+	preserved := strictSyncStash{CommitID: stash.CommitID, Path: stash.Path}
+	if applyErr := applyStrictSyncStash(ctx, recovery.executor, preserved); applyErr != nil {
+		return false, applyErr
+	}
+	if dropErr := dropStrictSyncStash(ctx, recovery.executor, preserved); dropErr != nil {
+		return false, dropErr
+	}
+	fmt.Fprintf(recovery.output, recoverStepDoneTemplate, step)
+	return true, nil
+}
+
+func (recovery strictSyncRecovery) stashPresent(ctx context.Context, stash strictSyncHandoffStash) (bool, error) {
+	result, listErr := recovery.executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments:        []string{gitStashSubcommandConstant, gitStashListSubcommandConstant, gitStashFormatFlagConstant},
+		WorkingDirectory: stash.Path,
+	})
+	if listErr != nil {
+		return false, fmt.Errorf(strictSyncStashListFailureTemplate, stash.Path, listErr)
+	}
+	for _, commitID := range strings.Fields(result.StandardOutput) {
+		if commitID == stash.CommitID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (recovery strictSyncRecovery) openPullRequest(ctx context.Context, pullRequest strictSyncHandoffPullRequest) (bool, error) {
+	if recovery.pullRequests == nil {
+		return false, errors.New(recoverMissingGitHubClientMessage)
+	}
+	open, openErr := strictSyncHandoffPullRequestOpen(ctx, recovery.pullRequests, pullRequest)
+	if openErr != nil {
+		return false, openErr
+	}
+	if open {
+		fmt.Fprintf(recovery.output, recoverStepDoneTemplate, fmt.Sprintf(recoverPullRequestExistsTemplate, pullRequest.Head, pullRequest.BaseBranch, pullRequest.Repository))
+		return true, nil
+	}
+	step := fmt.Sprintf(recoverPullRequestStepTemplate, pullRequest.Head, pullRequest.BaseBranch, pullRequest.Repository)
+	confirmed, confirmErr := recovery.confirm(fmt.Sprintf(recoverPullRequestPromptTemplate, pullRequest.Head, pullRequest.BaseBranch, pullRequest.Repository))
+	if confirmErr != nil {
+		return false, confirmErr
+	}
+	if !confirmed {
+		fmt.Fprintf(recovery.output, recoverStepSkippedTemplate, step)
+		return false, nil
+	}
+	if createErr := recovery.pullRequests.CreatePullRequest(ctx, githubcli.PullRequestCreateOptions{
+		Repository: pullRequest.Repository,
+		Title:      pullRequest.Title,
+		Body:       pullRequest.Body,
+		Base:       pullRequest.BaseBranch,
+		Head:       pullRequest.Head,
+		Draft:      pullRequest.Draft,
+		Labels:     pullRequest.Labels,
+		Assignees:  pullRequest.Assignees,
+		Reviewers:  pullRequest.Reviewers,
+		Milestone:  pullRequest.Milestone,
+	}); createErr != nil {
+		return false, fmt.Errorf(recoverStepFailureTemplate, step, createErr)
+	}
+	fmt.Fprintf(recovery.output, recoverStepDoneTemplate, step)
+	if pullRequest.AutoMerge == "" {
+		return true, nil
+	}
+	autoMergeStep := fmt.Sprintf(recoverAutoMergeStepTemplate, pullRequest.AutoMerge, pullRequest.Head)
+	if autoMergeErr := recovery.pullRequests.EnablePullRequestAutoMerge(ctx, pullRequest.Repository, pullRequest.Head, githubcli.PullRequestMergeMethod(pullRequest.AutoMerge)); autoMergeErr != nil {
+		fmt.Fprintf(recovery.output, recoverStepSkippedTemplate, fmt.Errorf(recoverStepFailureTemplate, autoMergeStep, autoMergeErr).Error())
+		return true, nil
+	}
+	fmt.Fprintf(recovery.output, recoverStepDoneTemplate, autoMergeStep)
+	return true, nil
+}
+
+// strictSyncHandoffPullRequestOpen reports whether an open pull request already carries the handoff's head into its base.
+func strictSyncHandoffPullRequestOpen(ctx context.Context, pullRequests StrictSyncPullRequestLister, pullRequest strictSyncHandoffPullRequest) (bool, error) {
+	openPullRequests, listErr := pullRequests.ListPullRequests(ctx, pullRequest.Repository, githubcli.PullRequestListOptions{
+		State:       githubcli.PullRequestStateOpen,
+		BaseBranch:  pullRequest.BaseBranch,
+		HeadBranch:  pullRequest.BranchName,
+		ResultLimit: recoverPullRequestListResultLimit,
+	})
+	if listErr != nil {
+		return false, listErr
+	}
+	headOwner, _, crossRepository := strings.Cut(pullRequest.Head, ":")
+	for _, openPullRequest := range openPullRequests {
+		openHeadOwner, _, _ := strings.Cut(strings.TrimSpace(openPullRequest.HeadRepositoryNameWithOwner), "/")
+		if crossRepository && !strings.EqualFold(openHeadOwner, headOwner) {
+			continue
+		}
+		if strings.TrimSpace(openPullRequest.HeadRefName) == pullRequest.BranchName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (recovery strictSyncRecovery) confirm(prompt string) (bool, error) {
+	if recovery.assumeYes {
+		return true, nil
+	}
+	if recovery.prompter == nil {
+		return false, nil
+	}
+	confirmation, confirmErr := recovery.prompter.Confirm(prompt)
+	if confirmErr != nil {
+		return false, confirmErr
+	}
+	return confirmation.Confirmed, nil
+}
@@ -0,0 +1,241 @@
+package syncflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/gitrepo"
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/workflow"
+)
+
+const (
+	strictSyncForcePushAdoptRemote           = "adopt-remote"
+	strictSyncForcePushKeepLocal             = "keep-local"
+	strictSyncForcePushBackupReferenceFormat = "refs/gix/backup/%s/%s"
+	strictSyncForcePushKeptBranchTemplate    = "%s-local-%s"
+	strictSyncForcePushShortCommitLength     = 12
+	strictSyncForcePushModeTemplate          = "unsupported force-push mode %q; use adopt-remote or keep-local"
+	strictSyncForcePushDetectedTemplate      = "%s was force-pushed since the last sync: recorded %s is no longer in its history"
+	strictSyncForcePushNoLocalWorkTemplate   = "%s was force-pushed since the last sync; %s has no local-only commits, so sync takes the remote branch"
+	strictSyncForcePushStopTemplate          = "%s was force-pushed since the last sync and %s has %d local-only commits; rerun with --on-force-push adopt-remote to save them to %s and take the remote branch, or --on-force-push keep-local to publish them on %s with a pull request into %s"
+	strictSyncForcePushAdoptedTemplate       = "saved %d local-only commits of %s to %s and adopted %s"
+	strictSyncForcePushKeptTemplate          = "publishing %d local-only commits of %s on %s with a pull request into the rewritten %s"
+	strictSyncForcePushRealignedTemplate     = "moved %s to the rewritten %s; its local history stays on %s"
+	strictSyncForcePushForkKeepLocalTemplate = "keep-local cannot open a pull request into the rewritten %s in a fork workflow; use --on-force-push adopt-remote"
+	strictSyncForcePushTitleTemplate         = "Keep local history of %s"
+	strictSyncForcePushBodyTemplate          = "`%s` was force-pushed on `%s` after it was last synced at `%s`. This branch carries the local history built on the previous remote history, including %d commits that are not on the rewritten branch, so they can be reviewed and reconciled instead of merged implicitly."
+	strictSyncSyncedRemoteWarningTemplate    = "could not record the last-synced remote commit for %s: %v"
+	strictSyncForcePushModeOptionTemplate    = "%s option must be adopt-remote or keep-local"
+)
+
+// strictSyncSyncedBranch is a branch whose remote tip matched the local branch during this sync.
+type strictSyncSyncedBranch struct {
+	RemoteName string
+	BranchName string
+}
+
+// strictSyncForcePushBackup is a backup ref adopt-remote created; rollback deletes it because the branch keeps its commits.
+type strictSyncForcePushBackup struct {
+	Reference string
+	Commit    string
+}
+
+func validateStrictSyncForcePushMode(mode string) error {
+	switch mode {
+	case "", strictSyncForcePushAdoptRemote, strictSyncForcePushKeepLocal:
+		return nil
+	default:
+		return fmt.Errorf(strictSyncForcePushModeTemplate, mode)
+	}
+}
+
+func strictSyncForcePushModeFromParameters(parameters map[string]any) (string, error) {
+	mode, modeErr := optionalStringOption(parameters, taskOptionForcePushMode)
+	if modeErr != nil {
+		return "", modeErr
+	}
+	if validateErr := validateStrictSyncForcePushMode(mode); validateErr != nil {
+		return "", fmt.Errorf(strictSyncForcePushModeOptionTemplate, taskOptionForcePushMode)
+	}
+	return mode, nil
+}
+
+// reconcileStrictSyncRemoteRewrite runs after the target branch is checked out and before it is reconciled with its remote branch.
+// It compares the remote branch with the remote commit recorded at the last successful sync. When that commit is no longer
+// in the remote history and the local branch still carries commits the remote lacks, sync stops unless a force-push mode was chosen.
+// The returned branch is non-empty when keep-local moved the local history to a new review branch.
+func reconcileStrictSyncRemoteRewrite(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, repositoryIdentifier string, options strictPullRequestBranchOptions) (string, error) {
+	executor := environment.GitExecutor
+	recordedCommit, recorded, recordedErr := gitrepo.BranchSyncedRemote(ctx, executor, repository.Path, options.BranchName)
+	if recordedErr != nil {
+		return "", recordedErr
+	}
+	if !recorded || recordedCommit == "" {
+		return "", nil
+	}
+	if _, present, presentErr := strictSyncReferenceCommit(ctx, executor, repository.Path, recordedCommit+"^{commit}"); presentErr != nil {
+		return "", presentErr
+	} else if !present {
+		return "", nil
+	}
+	remoteReference := fmt.Sprintf("%s/%s", options.RemoteName, options.BranchName)
+	remoteCommit, remoteExists, remoteErr := strictSyncReferenceCommit(ctx, executor, repository.Path, remoteReference)
+	if remoteErr != nil || !remoteExists {
+		return "", remoteErr
+	}
+	descends, ancestryErr := strictSyncCommitIsAncestor(ctx, executor, repository.Path, recordedCommit, remoteCommit)
+	if ancestryErr != nil {
+		return "", ancestryErr
+	}
+	if descends {
+		return "", nil
+	}
+
+	localCommit, _, localErr := strictSyncReferenceCommit(ctx, executor, repository.Path, gitLocalHeadsPrefixConstant+options.BranchName)
+	if localErr != nil {
+		return "", localErr
+	}
+	localOnly, localOnlyErr := commitCount(ctx, executor, repository.Path, fmt.Sprintf("%s..%s", remoteReference, options.BranchName))
+	if localOnlyErr != nil {
+		return "", localOnlyErr
+	}
+	details := map[string]string{
+		"branch":          options.BranchName,
+		"remote":          remoteReference,
+		"recorded_commit": recordedCommit,
+		"remote_commit":   remoteCommit,
+		"local_commit":    localCommit,
+		"local_only":      strconv.Itoa(localOnly),
+	}
+	if localOnly == 0 {
+		environment.ReportRepositoryEvent(repository, shared.EventLevelInfo, shared.EventCodeSyncForcePush, fmt.Sprintf(strictSyncForcePushNoLocalWorkTemplate, remoteReference, options.BranchName), details)
+		return "", nil
+	}
+
+	backupReference := fmt.Sprintf(strictSyncForcePushBackupReferenceFormat, options.BranchName, strictSyncShortCommit(localCommit))
+	keptBranch := fmt.Sprintf(strictSyncForcePushKeptBranchTemplate, options.BranchName, strictSyncShortCommit(localCommit))
+	switch strictSyncForcePushModeFromContext(ctx) {
+	case strictSyncForcePushAdoptRemote:
+		details["backup"] = backupReference
+		_, backupExists, backupLookupErr := strictSyncReferenceCommit(ctx, executor, repository.Path, backupReference)
+		if backupLookupErr != nil {
+			return "", backupLookupErr
+		}
+		if _, backupErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+			Arguments:        []string{gitUpdateRefSubcommandConstant, backupReference, localCommit},
+			WorkingDirectory: repository.Path,
+		}); backupErr != nil {
+			return "", backupErr
+		}
+		if !backupExists {
+			noteStrictSyncForcePushBackup(ctx, strictSyncForcePushBackup{Reference: backupReference, Commit: localCommit})
+		}
+		if resetErr := executeGit(ctx, executor, repository.Path, []string{gitResetSubcommandConstant, gitResetHardFlagConstant, remoteReference}); resetErr != nil {
+			return "", resetErr
+		}
+		environment.ReportRepositoryEvent(repository, shared.EventLevelInfo, shared.EventCodeSyncForcePush, fmt.Sprintf(strictSyncForcePushAdoptedTemplate, localOnly, options.BranchName, backupReference, remoteReference), details)
+		return "", nil
+	case strictSyncForcePushKeepLocal:
+		if strictSyncForkFromContext(ctx) != nil {
+			return "", fmt.Errorf(strictSyncForcePushForkKeepLocalTemplate, options.BranchName)
+		}
+		details["kept_branch"] = keptBranch
+		environment.ReportRepositoryEvent(repository, shared.EventLevelInfo, shared.EventCodeSyncForcePush, fmt.Sprintf(strictSyncForcePushKeptTemplate, localOnly, options.BranchName, keptBranch, options.BranchName), details)
+		if switchErr := executeGit(ctx, executor, repository.Path, []string{gitSwitchSubcommandConstant, gitCreateBranchFlagConstant, keptBranch}); switchErr != nil {
+			return "", switchErr
+		}
+		if pullRequestErr := pushAndCreatePullRequest(ctx, environment, repository, repositoryIdentifier, strictPullRequestBranchOptions{
+			BranchName:     keptBranch,
+			RemoteName:     options.RemoteName,
+			BaseBranch:     options.BranchName,
+			CommitMessages: options.CommitMessages,
+			PullRequest: strictSyncPullRequestMetadata{
+				Title: fmt.Sprintf(strictSyncForcePushTitleTemplate, options.BranchName),
+				Body:  fmt.Sprintf(strictSyncForcePushBodyTemplate, options.BranchName, options.RemoteName, recordedCommit, localOnly),
+			},
+		}); pullRequestErr != nil {
+			return "", pullRequestErr
+		}
+		if realignErr := executeGit(ctx, executor, repository.Path, []string{gitUpdateRefSubcommandConstant, gitLocalHeadsPrefixConstant + options.BranchName, remoteCommit, localCommit}); realignErr != nil {
+			return "", realignErr
+		}
+		noteStrictSyncSyncedBranch(ctx, options.RemoteName, options.BranchName)
+		environment.ReportRepositoryEvent(repository, shared.EventLevelInfo, shared.EventCodeSyncForcePush, fmt.Sprintf(strictSyncForcePushRealignedTemplate, options.BranchName, remoteReference, keptBranch), details)
+		return keptBranch, nil
+	default:
+		stopMessage := fmt.Sprintf(strictSyncForcePushStopTemplate, remoteReference, options.BranchName, localOnly, backupReference, keptBranch, options.BranchName)
+		environment.ReportRepositoryEvent(repository, shared.EventLevelError, shared.EventCodeSyncForcePush, fmt.Sprintf(strictSyncForcePushDetectedTemplate, remoteReference, recordedCommit), details)
+		return "", errors.New(stopMessage)
+	}
+}
+
+func strictSyncShortCommit(commit string) string {
+	if len(commit) > strictSyncForcePushShortCommitLength {
+		return commit[:strictSyncForcePushShortCommitLength]
+	}
+	return commit
+}
+
+func strictSyncForcePushModeFromContext(ctx context.Context) string {
+	transaction, ok := strictSyncTransactionFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return transaction.forcePushMode
+}
+
+// noteStrictSyncSyncedBranch remembers that the local and remote branch agree after a push.
+// The remote commit is recorded only once the transaction succeeds, so a rolled-back sync keeps the previous record.
+func noteStrictSyncSyncedBranch(ctx context.Context, remoteName string, branchName string) {
+	transaction, ok := strictSyncTransactionFromContext(ctx)
+	if !ok {
+		return
+	}
+	transaction.syncedBranches = append(transaction.syncedBranches, strictSyncSyncedBranch{RemoteName: remoteName, BranchName: branchName})
+}
+
+func noteStrictSyncForcePushBackup(ctx context.Context, backup strictSyncForcePushBackup) {
+	transaction, ok := strictSyncTransactionFromContext(ctx)
+	if !ok {
+		return
+	}
+	transaction.forcePushBackups = append(transaction.forcePushBackups, backup)
+}
+
+// removeForcePushBackups deletes the backup refs this transaction created while it rolls back.
+func (transaction *strictSyncTransaction) removeForcePushBackups(ctx context.Context) error {
+	for backupIndex := len(transaction.forcePushBackups) - 1; backupIndex >= 0; backupIndex-- {
+		backup := transaction.forcePushBackups[backupIndex]
+		if deleteErr := executeGit(ctx, transaction.environment.GitExecutor, transaction.repository.Path, []string{
+			gitUpdateRefSubcommandConstant,
+			gitDeleteRefFlagConstant,
+			backup.Reference,
+			backup.Commit,
+		}); deleteErr != nil {
+			return deleteErr
+		}
+	}
+	transaction.forcePushBackups = nil
+	return nil
+}
+
+// recordSyncedRemotes stores each synced branch's remote commit in branch.<name>.gix-synced-remote.
+// Failures are warnings: the sync itself already succeeded, and a missing record only disables detection for that branch.
+func (transaction *strictSyncTransaction) recordSyncedRemotes(ctx context.Context) {
+	executor := transaction.environment.GitExecutor
+	for _, synced := range transaction.syncedBranches {
+		remoteReference := fmt.Sprintf("%s/%s", synced.RemoteName, synced.BranchName)
+		remoteCommit, remoteExists, remoteErr := strictSyncReferenceCommit(ctx, executor, transaction.repository.Path, remoteReference)
+		if remoteErr == nil && remoteExists {
+			remoteErr = gitrepo.RecordBranchSyncedRemote(ctx, executor, transaction.repository.Path, synced.BranchName, remoteCommit)
+		}
+		if remoteErr != nil {
+			transaction.environment.ReportRepositoryEvent(transaction.repository, shared.EventLevelWarn, shared.EventCodeSyncForcePush, fmt.Sprintf(strictSyncSyncedRemoteWarningTemplate, synced.BranchName, remoteErr), map[string]string{"branch": synced.BranchName})
+		}
+	}
+	transaction.syncedBranches = nil
+}
@@ -0,0 +1,174 @@
+package syncflow
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/gitrepo"
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/workflow"
+)
+
+const strictSyncRemoteRewriteTestBranch = "feature/shared"
+
+// strictSyncRemoteRewriteFixture is a clone whose feature branch was synced, then force-pushed by someone else.
+type strictSyncRemoteRewriteFixture struct {
+	repositoryPath  string
+	recordedCommit  string
+	rewrittenCommit string
+	localCommit     string
+}
+
+func newStrictSyncRemoteRewriteFixture(t *testing.T, localOnlyCommit bool) strictSyncRemoteRewriteFixture {
+	t.Helper()
+	repositoryPath := newStrictSyncPreviewRepository(t)
+	commitFile := func(name string, contents string) string {
+		require.NoError(t, os.WriteFile(filepath.Join(repositoryPath, name), []byte(contents), 0o644))
+		runStrictSyncPreviewGit(t, repositoryPath, "add", name)
+		runStrictSyncPreviewGit(t, repositoryPath, "commit", "-m", name)
+		return strings.TrimSpace(runStrictSyncPreviewGit(t, repositoryPath, "rev-parse", "HEAD"))
+	}
+
+	runStrictSyncPreviewGit(t, repositoryPath, "switch", "-c", strictSyncRemoteRewriteTestBranch)
+	recordedCommit := commitFile("shared.txt", "shared\n")
+	runStrictSyncPreviewGit(t, repositoryPath, "push", "-u", "origin", strictSyncRemoteRewriteTestBranch)
+	runStrictSyncPreviewGit(t, repositoryPath, "config", "branch."+strictSyncRemoteRewriteTestBranch+".gix-synced-remote", recordedCommit)
+
+	runStrictSyncPreviewGit(t, repositoryPath, "switch", "-c", "rewrite", "main")
+	rewrittenCommit := commitFile("rewritten.txt", "rewritten\n")
+	runStrictSyncPreviewGit(t, repositoryPath, "push", "--force", "origin", "rewrite:"+strictSyncRemoteRewriteTestBranch)
+	runStrictSyncPreviewGit(t, repositoryPath, "switch", strictSyncRemoteRewriteTestBranch)
+	runStrictSyncPreviewGit(t, repositoryPath, "branch", "-D", "rewrite")
+
+	localCommit := rewrittenCommit
+	if localOnlyCommit {
+		localCommit = commitFile("local.txt", "local\n")
+	} else {
+		runStrictSyncPreviewGit(t, repositoryPath, "reset", "--hard", "origin/"+strictSyncRemoteRewriteTestBranch)
+	}
+	return strictSyncRemoteRewriteFixture{
+		repositoryPath:  repositoryPath,
+		recordedCommit:  recordedCommit,
+		rewrittenCommit: rewrittenCommit,
+		localCommit:     localCommit,
+	}
+}
+
+func (fixture strictSyncRemoteRewriteFixture) reference(t *testing.T, reference string) string {
+	t.Helper()
+	return strings.TrimSpace(runStrictSyncPreviewGit(t, fixture.repositoryPath, "rev-parse", reference))
+}
+
+func (fixture strictSyncRemoteRewriteFixture) branchOptions() strictPullRequestBranchOptions {
+	return strictPullRequestBranchOptions{BranchName: strictSyncRemoteRewriteTestBranch, RemoteName: "origin", BaseBranch: "main"}
+}
+
+// failingStrictSyncGitExecutor fails the first git command line that starts with failCommandPrefix, before running it.
+type failingStrictSyncGitExecutor struct {
+	shared.GitExecutor
+	failCommandPrefix string
+	failed            bool
+}
+
+func (executor *failingStrictSyncGitExecutor) ExecuteGit(ctx context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	if !executor.failed && strings.HasPrefix(strings.Join(details.Arguments, " "), executor.failCommandPrefix) {
+		executor.failed = true
+		return execshell.ExecutionResult{}, errors.New("injected git failure")
+	}
+	return executor.GitExecutor.ExecuteGit(ctx, details)
+}
+
+func TestReconcileStrictSyncRemoteRewriteIgnoresMissingRecordedCommit(t *testing.T) {
+	fixture := newStrictSyncRemoteRewriteFixture(t, true)
+	executor, _ := newStrictSyncPreviewCollaborators(t)
+	runStrictSyncPreviewGit(t, fixture.repositoryPath, "config", "branch."+strictSyncRemoteRewriteTestBranch+".gix-synced-remote", "0123456789abcdef0123456789abcdef01234567")
+	reporter := &recordingReporter{}
+	environment := &workflow.Environment{GitExecutor: executor, Reporter: reporter}
+
+	keptBranch, reconcileErr := reconcileStrictSyncRemoteRewrite(context.Background(), environment, &workflow.RepositoryState{Path: fixture.repositoryPath}, "owner/project", fixture.branchOptions())
+	require.NoError(t, reconcileErr)
+	require.Empty(t, keptBranch)
+	require.Empty(t, reporter.events)
+	require.Equal(t, fixture.localCommit, fixture.reference(t, strictSyncRemoteRewriteTestBranch))
+}
+
+func TestReconcileStrictSyncRemoteRewriteTakesRemoteWithoutLocalOnlyCommits(t *testing.T) {
+	fixture := newStrictSyncRemoteRewriteFixture(t, false)
+	executor, _ := newStrictSyncPreviewCollaborators(t)
+	reporter := &recordingReporter{}
+	environment := &workflow.Environment{GitExecutor: executor, Reporter: reporter}
+
+	keptBranch, reconcileErr := reconcileStrictSyncRemoteRewrite(context.Background(), environment, &workflow.RepositoryState{Path: fixture.repositoryPath}, "owner/project", fixture.branchOptions())
+	require.NoError(t, reconcileErr)
+	require.Empty(t, keptBranch)
+	require.Len(t, reporter.events, 1)
+	require.Equal(t, shared.EventCodeSyncForcePush, reporter.events[0].Code)
+	require.Equal(t, shared.EventLevelInfo, reporter.events[0].Level)
+	require.Equal(t, "0", reporter.events[0].Details["local_only"])
+	require.Equal(t, fixture.recordedCommit, reporter.events[0].Details["recorded_commit"])
+	require.Equal(t, fixture.rewrittenCommit, fixture.reference(t, strictSyncRemoteRewriteTestBranch))
+}
+
+func TestReconcileStrictSyncRemoteRewriteRefusesKeepLocalInForkWorkflow(t *testing.T) {
+	fixture := newStrictSyncRemoteRewriteFixture(t, true)
+	executor, _ := newStrictSyncPreviewCollaborators(t)
+	environment := &workflow.Environment{GitExecutor: executor, Reporter: &recordingReporter{}}
+	forkContext := withStrictSyncTransaction(context.Background(), &strictSyncTransaction{
+		forcePushMode: strictSyncForcePushKeepLocal,
+		fork:          &strictSyncFork{UpstreamRemote: "upstream", OriginRemote: "origin", OriginRepository: "fork/project"},
+	})
+
+	keptBranch, reconcileErr := reconcileStrictSyncRemoteRewrite(forkContext, environment, &workflow.RepositoryState{Path: fixture.repositoryPath}, "owner/project", fixture.branchOptions())
+	require.EqualError(t, reconcileErr, "keep-local cannot open a pull request into the rewritten "+strictSyncRemoteRewriteTestBranch+" in a fork workflow; use --on-force-push adopt-remote")
+	require.Empty(t, keptBranch)
+	require.Equal(t, strictSyncRemoteRewriteTestBranch, strings.TrimSpace(runStrictSyncPreviewGit(t, fixture.repositoryPath, "branch", "--show-current")))
+	require.Equal(t, fixture.localCommit, fixture.reference(t, strictSyncRemoteRewriteTestBranch))
+}
+
+func TestReconcileStrictSyncRemoteRewriteRollsBackFailedAdopt(t *testing.T) {
+	testCases := []struct {
+		name              string
+		failCommandPrefix string
+	}{
+		{name: "backup update-ref fails", failCommandPrefix: "update-ref refs/gix/backup/"},
+		{name: "reset fails after the backup", failCommandPrefix: "reset --hard"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			fixture := newStrictSyncRemoteRewriteFixture(t, true)
+			shellExecutor, _ := newStrictSyncPreviewCollaborators(t)
+			executor := &failingStrictSyncGitExecutor{GitExecutor: shellExecutor, failCommandPrefix: testCase.failCommandPrefix}
+			environment := &workflow.Environment{GitExecutor: executor, Reporter: &recordingReporter{}}
+			repository := &workflow.RepositoryState{Path: fixture.repositoryPath}
+
+			transaction, beginErr := beginStrictSyncTransaction(context.Background(), environment, repository, strictSyncPlan{
+				targetBranch:     strictSyncRemoteRewriteTestBranch,
+				startingWorktree: listedWorktree{Path: fixture.repositoryPath, Commit: fixture.localCommit, BranchName: strictSyncRemoteRewriteTestBranch},
+			})
+			require.NoError(t, beginErr)
+			transaction.forcePushMode = strictSyncForcePushAdoptRemote
+
+			_, reconcileErr := reconcileStrictSyncRemoteRewrite(withStrictSyncTransaction(context.Background(), transaction), environment, repository, "owner/project", fixture.branchOptions())
+			require.ErrorContains(t, reconcileErr, "injected git failure")
+			require.True(t, executor.failed)
+
+			_, rollbackErr := transaction.rollback(context.Background())
+			require.NoError(t, rollbackErr)
+			require.Equal(t, fixture.localCommit, fixture.reference(t, strictSyncRemoteRewriteTestBranch))
+			require.Equal(t, strictSyncRemoteRewriteTestBranch, strings.TrimSpace(runStrictSyncPreviewGit(t, fixture.repositoryPath, "branch", "--show-current")))
+			require.Empty(t, strings.TrimSpace(runStrictSyncPreviewGit(t, fixture.repositoryPath, "for-each-ref", "refs/gix/backup/")))
+			require.Empty(t, strings.TrimSpace(runStrictSyncPreviewGit(t, fixture.repositoryPath, "status", "--porcelain")))
+			recordedCommit, recorded, recordedErr := gitrepo.BranchSyncedRemote(context.Background(), shellExecutor, fixture.repositoryPath, strictSyncRemoteRewriteTestBranch)
+			require.NoError(t, recordedErr)
+			require.True(t, recorded)
+			require.Equal(t, fixture.recordedCommit, recordedCommit)
+		})
+	}
+}
@@ -58,6 +58,19 @@ type strictSyncTransaction struct {
 	published         bool
 	restoring         bool
 	ownershipLoss     error
+	conflictReport    *mergeConflictReportRecorder
+	conflictReviewer  mergeConflictRegionReviewer
+	fork              *strictSyncFork
+	// publishedReferences and pendingPullRequest feed the handoff record read by gix sync recover.
+	publishedReferences []strictSyncHandoffPublication
+	pendingPullRequest  *strictSyncHandoffPullRequest
+	verifyCommands      []string
+	verifyRunner        strictSyncVerificationRunner
+	pullRequestPolicy   strictSyncPullRequestPolicy
+	// forcePushMode chooses how a rewritten remote branch is handled; syncedBranches feed branch.<name>.gix-synced-remote.
+	forcePushMode    string
+	syncedBranches   []strictSyncSyncedBranch
+	forcePushBackups []strictSyncForcePushBackup
 }
 
 type strictSyncTransactionContextKey struct{}
@@ -299,6 +312,9 @@ func (transaction *strictSyncTransaction) rollback(ctx context.Context) (strictS
 	if restoreErr := transaction.restoreLocalBranches(ctx); restoreErr != nil {
 		return result, restoreErr
 	}
+	if backupErr := transaction.removeForcePushBackups(ctx); backupErr != nil {
+		return result, backupErr
+	}
 
 	refreshedWorktrees, refreshedListErr := listRepositoryWorktrees(ctx, transaction.environment.GitExecutor, transaction.repository.Path, transaction.targetBranch)
 	if refreshedListErr != nil {
@@ -378,42 +394,34 @@ func (transaction *strictSyncTransaction) reportRollback(result strictSyncRollba
 	)
 }
 
-func (transaction *strictSyncTransaction) reportHandoff(rollbackErr error) {
+func (transaction *strictSyncTransaction) reportHandoff(ctx context.Context, rollbackErr error) {
+	reason := strings.TrimSpace(rollbackErr.Error())
 	transaction.environment.ReportRepositoryEvent(
 		transaction.repository,
 		shared.EventLevelError,
 		shared.EventCodeSyncSwitchHandoff,
-		fmt.Sprintf("%s: %s", strictSyncHandoffMessage, strings.TrimSpace(rollbackErr.Error())),
-		map[string]string{
-			"target_branch": transaction.targetBranch,
-			"reason":        strings.TrimSpace(rollbackErr.Error()),
-		},
+		fmt.Sprintf("%s: %s", strictSyncHandoffMessage, reason),
+		transaction.persistHandoff(ctx, strictSyncHandoffKindRollback, reason),
 	)
 }
 
-func (transaction *strictSyncTransaction) reportOwnershipHandoff() {
+func (transaction *strictSyncTransaction) reportOwnershipHandoff(ctx context.Context) {
 	transaction.environment.ReportRepositoryEvent(
 		transaction.repository,
 		shared.EventLevelError,
 		shared.EventCodeSyncSwitchHandoff,
 		strictSyncOwnershipHandoffMessage,
-		map[string]string{
-			"target_branch": transaction.targetBranch,
-			"reason":        strings.TrimSpace(transaction.ownershipLoss.Error()),
-		},
+		transaction.persistHandoff(ctx, strictSyncHandoffKindOwnership, strings.TrimSpace(transaction.ownershipLoss.Error())),
 	)
 }
 
-func (transaction *strictSyncTransaction) reportPublishedHandoff(reason error) {
+func (transaction *strictSyncTransaction) reportPublishedHandoff(ctx context.Context, reason error) {
 	transaction.environment.ReportRepositoryEvent(
 		transaction.repository,
 		shared.EventLevelError,
 		shared.EventCodeSyncSwitchHandoff,
 		strictSyncPublishedHandoffMessage,
-		map[string]string{
-			"target_branch": transaction.targetBranch,
-			"reason":        strings.TrimSpace(reason.Error()),
-		},
+		transaction.persistHandoff(ctx, strictSyncHandoffKindPublished, strings.TrimSpace(reason.Error())),
 	)
 }
 
@@ -585,6 +593,18 @@ func strictSyncMutatedBranchReferences(ctx context.Context, executor shared.GitE
 		}
 		return nil, nil
 	}
+	if arguments[0] == gitUpdateRefSubcommandConstant {
+		for argumentIndex := 1; argumentIndex < len(arguments); argumentIndex++ {
+			if strings.HasPrefix(arguments[argumentIndex], "-") {
+				continue
+			}
+			if strings.HasPrefix(arguments[argumentIndex], gitLocalHeadsPrefixConstant) {
+				return []string{arguments[argumentIndex]}, nil
+			}
+			return nil, nil
+		}
+		return nil, nil
+	}
 	mutatesCurrentBranch := arguments[0] == gitCommitSubcommandConstant || arguments[0] == gitMergeSubcommandConstant
 	if arguments[0] == gitResetSubcommandConstant {
 		mutatesCurrentBranch = strictSyncArgumentsContain(arguments, gitResetHardFlagConstant)
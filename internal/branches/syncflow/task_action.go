@@ -30,6 +30,13 @@ const (
 	taskOptionRequirePullRequest      = "require_pull_request"
 	taskOptionPullRequestTitle        = "title"
 	taskOptionPullRequestBody         = "body"
+	taskOptionConflictReport          = "conflict_report"
+	taskOptionConflictReportInBody    = "conflict_report_pull_request"
+	taskOptionConflictReviewer        = "conflict_reviewer"
+	taskOptionUpstreamRemote          = "upstream"
+	taskOptionVerifyCommands          = "verify"
+	taskOptionPullRequestPolicy       = "pull_request_settings"
+	taskOptionForcePushMode           = "on_force_push"
 
 	branchResolutionSourceExplicit      = "explicit"
 	branchResolutionSourceRemoteDefault = "remote_default"
@@ -166,6 +173,10 @@ func handleBranchSyncAction(ctx context.Context, environment *workflow.Environme
 	if requirePullRequestErr != nil {
 		return requirePullRequestErr
 	}
+	printConflictReport, printConflictReportErr := boolOptionDefault(parameters, taskOptionConflictReport, false)
+	if printConflictReportErr != nil {
+		return printConflictReportErr
+	}
 	if stashChanges && commitChanges {
 		return errors.New(conflictingRecoveryFlagsMessageConstant)
 	}
@@ -226,15 +237,41 @@ func handleBranchSyncAction(ctx context.Context, environment *workflow.Environme
 		if pullRequestMetadataErr != nil {
 			return pullRequestMetadataErr
 		}
+		conflictReviewer, conflictReviewerErr := mergeConflictRegionReviewerFromParameters(parameters)
+		if conflictReviewerErr != nil {
+			return conflictReviewerErr
+		}
+		upstreamRemote, upstreamRemoteErr := optionalStringOption(parameters, taskOptionUpstreamRemote)
+		if upstreamRemoteErr != nil {
+			return upstreamRemoteErr
+		}
+		verifyCommands, verifyCommandsErr := strictSyncVerifyCommandsFromParameters(parameters)
+		if verifyCommandsErr != nil {
+			return verifyCommandsErr
+		}
+		pullRequestPolicy, pullRequestPolicyErr := strictSyncPullRequestPolicyFromParameters(parameters)
+		if pullRequestPolicyErr != nil {
+			return pullRequestPolicyErr
+		}
+		forcePushMode, forcePushModeErr := strictSyncForcePushModeFromParameters(parameters)
+		if forcePushModeErr != nil {
+			return forcePushModeErr
+		}
 		return handleStrictSyncAction(ctx, environment, repository, strictSyncOptions{
-			BranchName:       resolvedBranchName,
-			RemoteName:       remoteName,
-			RequireClean:     requireClean,
-			StashChanges:     stashChanges,
-			CommitChanges:    commitChanges,
-			CommitMessages:   commitMessageOptions,
-			PullRequest:      pullRequestMetadata,
-			ResolutionSource: resolutionSource,
+			BranchName:          resolvedBranchName,
+			RemoteName:          remoteName,
+			UpstreamRemote:      upstreamRemote,
+			RequireClean:        requireClean,
+			StashChanges:        stashChanges,
+			CommitChanges:       commitChanges,
+			CommitMessages:      commitMessageOptions,
+			PullRequest:         pullRequestMetadata,
+			ResolutionSource:    resolutionSource,
+			PrintConflictReport: printConflictReport,
+			ConflictReviewer:    conflictReviewer,
+			VerifyCommands:      verifyCommands,
+			PullRequestPolicy:   pullRequestPolicy,
+			ForcePushMode:       forcePushMode,
 		})
 	}
 
@@ -447,14 +484,20 @@ func handleBranchSyncAction(ctx context.Context, environment *workflow.Environme
 }
 
 type strictSyncOptions struct {
-	BranchName       string
-	RemoteName       string
-	RequireClean     bool
-	StashChanges     bool
-	CommitChanges    bool
-	CommitMessages   worktreeAdoptionCommitMessageOptions
-	PullRequest      strictSyncPullRequestMetadata
-	ResolutionSource string
+	BranchName          string
+	RemoteName          string
+	UpstreamRemote      string
+	RequireClean        bool
+	StashChanges        bool
+	CommitChanges       bool
+	CommitMessages      worktreeAdoptionCommitMessageOptions
+	PullRequest         strictSyncPullRequestMetadata
+	ResolutionSource    string
+	PrintConflictReport bool
+	ConflictReviewer    mergeConflictRegionReviewer
+	VerifyCommands      []string
+	PullRequestPolicy   strictSyncPullRequestPolicy
+	ForcePushMode       string
 }
 
 func resolveStrictSyncRemoteDefaultBranch(ctx context.Context, executor shared.GitExecutor, repositoryPath string, remoteName string) (string, error) {
@@ -513,12 +556,28 @@ func handleStrictSyncAction(ctx context.Context, environment *workflow.Environme
 	if environment.RepositoryManager == nil {
 		return errors.New(refreshMissingRepositoryManagerMessage)
 	}
+	if signingErr := gitrepo.VerifyCommitSigning(ctx, environment.GitExecutor, repository.Path); signingErr != nil {
+		return signingErr
+	}
 
 	branchName := strings.TrimSpace(options.BranchName)
-	remoteName := strings.TrimSpace(options.RemoteName)
-	if remoteName == "" {
-		remoteName = defaultRemoteNameConstant
+	remotes, remotesErr := resolveStrictSyncRemotes(ctx, environment.GitExecutor, repository.Path, options.RemoteName, options.UpstreamRemote)
+	if remotesErr != nil {
+		return remotesErr
+	}
+	remoteName := remotes.PushRemote
+	if remotes.DetectionSkipped != "" {
+		environment.ReportRepositoryEvent(repository, shared.EventLevelInfo, shared.EventCodeSyncFork, remotes.DetectionSkipped, nil)
 	}
+	// Registered before the transaction so it runs after the rollback handler: roles are recorded only once the sync succeeded.
+	defer func() {
+		if err != nil {
+			return
+		}
+		if recordErr := recordStrictSyncRemoteRoles(context.WithoutCancel(ctx), environment.GitExecutor, repository.Path, remotes); recordErr != nil {
+			environment.ReportRepositoryEvent(repository, shared.EventLevelWarn, shared.EventCodeSyncFork, fmt.Sprintf(strictSyncForkRoleRecordTemplate, recordErr), nil)
+		}
+	}()
 
 	plan, planErr := buildStrictSyncPlan(ctx, environment.GitExecutor, repository.Path, branchName)
 	if planErr != nil {
@@ -529,7 +588,18 @@ func handleStrictSyncAction(ctx context.Context, environment *workflow.Environme
 	if transactionErr != nil {
 		return transactionErr
 	}
+	transaction.conflictReviewer = options.ConflictReviewer
+	transaction.fork = remotes.Fork
+	transaction.verifyCommands = options.VerifyCommands
+	transaction.verifyRunner = runStrictSyncVerificationCommand
+	transaction.pullRequestPolicy = options.PullRequestPolicy
+	transaction.forcePushMode = options.ForcePushMode
 	ctx = withStrictSyncTransaction(ctx, transaction)
+	defer func() {
+		reportContext, cancelReport := context.WithTimeout(context.WithoutCancel(ctx), mergeConflictResolutionRollbackTimeout)
+		defer cancelReport()
+		publishMergeConflictReport(reportContext, environment, repository, transaction, options.PrintConflictReport)
+	}()
 	var invocationStash *strictSyncStash
 	defer func() {
 		if err == nil {
@@ -538,18 +608,18 @@ func handleStrictSyncAction(ctx context.Context, environment *workflow.Environme
 		cleanupContext, cancelCleanup := context.WithTimeout(context.WithoutCancel(ctx), mergeConflictResolutionRollbackTimeout)
 		defer cancelCleanup()
 		if transaction.published {
-			transaction.reportPublishedHandoff(err)
 			if finalizeErr := transaction.finalizeSnapshots(cleanupContext); finalizeErr != nil {
 				err = errors.Join(err, finalizeErr)
 			}
+			transaction.reportPublishedHandoff(cleanupContext, err)
 			return
 		}
 		if transaction.ownershipLoss != nil {
-			transaction.reportOwnershipHandoff()
+			transaction.reportOwnershipHandoff(cleanupContext)
 			return
 		}
 		if _, rollbackErr := transaction.rollback(cleanupContext); rollbackErr != nil {
-			transaction.reportHandoff(rollbackErr)
+			transaction.reportHandoff(cleanupContext, rollbackErr)
 			err = errors.Join(err, rollbackErr)
 		}
 	}()
@@ -566,14 +636,22 @@ func handleStrictSyncAction(ctx context.Context, environment *workflow.Environme
 		return errors.New(strictSyncDirtyWorktreeTemplate)
 	}
 
-	if fetchErr := executeGit(ctx, environment.GitExecutor, repository.Path, []string{gitFetchSubcommandConstant, gitFetchPruneFlagConstant, remoteName}); fetchErr != nil {
-		return fmt.Errorf(gitFetchFailureTemplateConstant, fetchErr)
+	baseRemoteName := strictSyncBaseRemote(ctx, remoteName)
+	fetchRemotes := []string{remoteName}
+	if baseRemoteName != remoteName {
+		reportStrictSyncFork(environment, repository, remotes.Fork)
+		fetchRemotes = append(fetchRemotes, baseRemoteName)
 	}
-	defaultBranch, defaultBranchErr := resolveStrictSyncRemoteDefaultBranch(ctx, environment.GitExecutor, repository.Path, remoteName)
+	for _, fetchRemote := range fetchRemotes {
+		if fetchErr := executeGit(ctx, environment.GitExecutor, repository.Path, []string{gitFetchSubcommandConstant, gitFetchPruneFlagConstant, fetchRemote}); fetchErr != nil {
+			return fmt.Errorf(gitFetchFailureTemplateConstant, fetchErr)
+		}
+	}
+	defaultBranch, defaultBranchErr := resolveStrictSyncRemoteDefaultBranch(ctx, environment.GitExecutor, repository.Path, baseRemoteName)
 	if defaultBranchErr != nil {
 		return defaultBranchErr
 	}
-	if defaultBranchFetchErr := fetchStrictSyncRemoteDefaultBranch(ctx, environment.GitExecutor, repository.Path, remoteName, defaultBranch); defaultBranchFetchErr != nil {
+	if defaultBranchFetchErr := fetchStrictSyncRemoteDefaultBranch(ctx, environment.GitExecutor, repository.Path, baseRemoteName, defaultBranch); defaultBranchFetchErr != nil {
 		return defaultBranchFetchErr
 	}
 	if dirty && syncStatusEntriesHaveConflicts(statusEntries) {
@@ -585,16 +663,22 @@ func handleStrictSyncAction(ctx context.Context, environment *workflow.Environme
 		}
 	}
 	reviewBaseBranch := defaultBranch
-	stackPlan, stackPlanErr := planStrictSyncStack(ctx, environment, repository, strictSyncStackPlanningOptions{
-		RemoteName:       remoteName,
-		ChildBranch:      branchName,
-		DefaultBranch:    defaultBranch,
-		ResolutionSource: options.ResolutionSource,
-		Dirty:            dirty,
-		StashChanges:     options.StashChanges,
-	})
-	if stackPlanErr != nil {
-		return stackPlanErr
+	var stackPlan *strictSyncStackPlan
+	if remotes.Fork == nil {
+		plannedStack, stackPlanErr := planStrictSyncStack(ctx, environment, repository, strictSyncStackPlanningOptions{
+			RemoteName:       remoteName,
+			ChildBranch:      branchName,
+			DefaultBranch:    defaultBranch,
+			ResolutionSource: options.ResolutionSource,
+			Dirty:            dirty,
+			StashChanges:     options.StashChanges,
+		})
+		if stackPlanErr != nil {
+			return stackPlanErr
+		}
+		stackPlan = plannedStack
+	} else if forkStackErr := refuseStrictSyncForkStack(ctx, environment, repository, branchName); forkStackErr != nil {
+		return forkStackErr
 	}
 	if stackPlan != nil && stackPlan.ChildPullRequestMerged && dirty && !options.StashChanges {
 		return fmt.Errorf(strictSyncStackedMergedDirtyTemplate, branchName)
@@ -636,7 +720,7 @@ func handleStrictSyncAction(ctx context.Context, environment *workflow.Environme
 		commitBranchName := branchName
 		commitToExplicitBaseBranch := commitBranchName == defaultBranch && strings.TrimSpace(options.ResolutionSource) == branchResolutionSourceExplicit
 		if commitToExplicitBaseBranch {
-			remoteReference := fmt.Sprintf("%s/%s", remoteName, defaultBranch)
+			remoteReference := fmt.Sprintf("%s/%s", baseRemoteName, defaultBranch)
 			remoteExists, remoteExistsErr := remoteReferenceExists(ctx, environment.GitExecutor, repository.Path, remoteReference)
 			if remoteExistsErr != nil {
 				return remoteExistsErr
@@ -693,9 +777,12 @@ func handleStrictSyncAction(ctx context.Context, environment *workflow.Environme
 		branchName = commitBranchName
 		dirty = false
 		if commitToExplicitBaseBranch {
-			if mergeErr := mergeRemoteBranchIntoLocal(ctx, environment, repository, environment.GitExecutor, repository.Path, remoteName, branchName, options.CommitMessages); mergeErr != nil {
+			if mergeErr := mergeBaseIntoBranch(ctx, environment, repository, environment.GitExecutor, repository.Path, remoteName, branchName, branchName, options.CommitMessages); mergeErr != nil {
 				return mergeErr
 			}
+			if verifyErr := verifyStrictSyncBranch(ctx, environment, repository, branchName); verifyErr != nil {
+				return verifyErr
+			}
 			if pushErr := executeGit(ctx, environment.GitExecutor, repository.Path, []string{gitPushSubcommandConstant, remoteName, branchName}); pushErr != nil {
 				return pushErr
 			}
@@ -749,6 +836,8 @@ func completeStrictSync(ctx context.Context, environment *workflow.Environment,
 	if finalizeErr := transaction.finalize(ctx); finalizeErr != nil {
 		return finalizeErr
 	}
+	transaction.recordSyncedRemotes(ctx)
+	transaction.supersedeHandoff(ctx)
 	reportStrictSync(repository, environment, completion.BranchName, options.ResolutionSource, completion.Created, completion.Stashed)
 	return nil
 }
@@ -761,6 +850,8 @@ func restoreOwnedStrictSyncStash(ctx context.Context, environment *workflow.Envi
 		reporter: func(level shared.EventLevel, code string, message string, details map[string]string) {
 			environment.ReportRepositoryEvent(repository, level, code, message, details)
 		},
+		recorder: mergeConflictReportRecorderFromContext(ctx),
+		reviewer: mergeConflictRegionReviewerFromContext(ctx),
 	}
 	if restoreErr := restoreStrictSyncStash(ctx, environment.GitExecutor, stash, service, mergeConflictResolutionOptions{
 		SourceReference: stash.CommitID,
@@ -805,9 +896,11 @@ type strictPullRequestCreateOptions struct {
 	BranchName           string
 	Title                string
 	Body                 string
+	Settings             strictSyncPullRequestSettings
 }
 
 func syncBaseBranch(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, remoteName string, baseBranch string, commitMessages worktreeAdoptionCommitMessageOptions) error {
+	remoteName = strictSyncBaseRemote(ctx, remoteName)
 	remoteReference := fmt.Sprintf("%s/%s", remoteName, baseBranch)
 	remoteExists, remoteExistsErr := remoteReferenceExists(ctx, environment.GitExecutor, repository.Path, remoteReference)
 	if remoteExistsErr != nil {
@@ -845,7 +938,7 @@ func syncPullRequestBranch(ctx context.Context, environment *workflow.Environmen
 		return strictPullRequestBranchResult{}, remoteExistsErr
 	}
 
-	repositoryIdentifier := strictSyncRepositoryIdentifier(repository)
+	repositoryIdentifier := strictSyncRepositoryIdentifier(ctx, repository)
 	if repositoryIdentifier == "" {
 		return strictPullRequestBranchResult{}, errors.New(strictSyncMissingRepositoryMessage)
 	}
@@ -870,8 +963,8 @@ func syncPullRequestBranch(ctx context.Context, environment *workflow.Environmen
 			if createPullRequestErr != nil {
 				return strictPullRequestBranchResult{}, createPullRequestErr
 			}
-			if createdPullRequest {
-				return strictPullRequestBranchResult{Created: true}, nil
+			if createdPullRequest.Created {
+				return createdPullRequest, nil
 			}
 			return strictPullRequestBranchResult{}, fmt.Errorf(strictSyncMissingPullRequestTemplate, options.BranchName)
 		}
@@ -882,6 +975,13 @@ func syncPullRequestBranch(ctx context.Context, environment *workflow.Environmen
 		if switchErr := switchToLocalOrRemoteBranchWithAdoption(ctx, environment, repository, options.RemoteName, options.BranchName, options.CommitMessages); switchErr != nil {
 			return strictPullRequestBranchResult{}, switchErr
 		}
+		keptBranch, rewriteErr := reconcileStrictSyncRemoteRewrite(ctx, environment, repository, repositoryIdentifier, options)
+		if rewriteErr != nil {
+			return strictPullRequestBranchResult{}, rewriteErr
+		}
+		if keptBranch != "" {
+			return strictPullRequestBranchResult{Created: true, SyncedBranch: keptBranch}, nil
+		}
 		aheadCount, aheadErr := commitCount(ctx, environment.GitExecutor, repository.Path, fmt.Sprintf("%s..%s", remoteReference, options.BranchName))
 		if aheadErr != nil {
 			return strictPullRequestBranchResult{}, aheadErr
@@ -900,7 +1000,14 @@ func syncPullRequestBranch(ctx context.Context, environment *workflow.Environmen
 		if mergeErr := mergeBaseIntoBranch(ctx, environment, repository, environment.GitExecutor, repository.Path, options.RemoteName, pullRequestBaseBranch, options.BranchName, options.CommitMessages); mergeErr != nil {
 			return strictPullRequestBranchResult{}, mergeErr
 		}
-		return strictPullRequestBranchResult{}, executeGit(ctx, environment.GitExecutor, repository.Path, []string{gitPushSubcommandConstant, options.RemoteName, options.BranchName})
+		if verifyErr := verifyStrictSyncBranch(ctx, environment, repository, options.BranchName); verifyErr != nil {
+			return strictPullRequestBranchResult{}, verifyErr
+		}
+		if pushErr := executeGit(ctx, environment.GitExecutor, repository.Path, []string{gitPushSubcommandConstant, options.RemoteName, options.BranchName}); pushErr != nil {
+			return strictPullRequestBranchResult{}, pushErr
+		}
+		noteStrictSyncSyncedBranch(ctx, options.RemoteName, options.BranchName)
+		return strictPullRequestBranchResult{}, nil
 	}
 
 	localExists, localExistsErr := localBranchExists(ctx, environment.GitExecutor, repository.Path, options.BranchName)
@@ -934,7 +1041,7 @@ func syncPullRequestBranch(ctx context.Context, environment *workflow.Environmen
 		return strictPullRequestBranchResult{Created: true}, nil
 	}
 
-	baseReference := fmt.Sprintf("%s/%s", options.RemoteName, options.BaseBranch)
+	baseReference := fmt.Sprintf("%s/%s", strictSyncBaseRemote(ctx, options.RemoteName), options.BaseBranch)
 	baseExists, baseExistsErr := remoteReferenceExists(ctx, environment.GitExecutor, repository.Path, baseReference)
 	if baseExistsErr != nil {
 		return strictPullRequestBranchResult{}, baseExistsErr
@@ -1013,7 +1120,7 @@ func resolveMergedPullRequestBaseTarget(ctx context.Context, environment *workfl
 		return resolveMergedPullRequestBaseTarget(ctx, environment, repository, repositoryIdentifier, remoteName, mergedBaseBranch, defaultBranch, visitedBranches)
 	}
 
-	baseReference := fmt.Sprintf("%s/%s", remoteName, branchName)
+	baseReference := fmt.Sprintf("%s/%s", strictSyncBaseRemote(ctx, remoteName), branchName)
 	remoteBaseExists, remoteBaseExistsErr := remoteReferenceExists(ctx, environment.GitExecutor, repository.Path, baseReference)
 	if remoteBaseExistsErr != nil {
 		return "", remoteBaseExistsErr
@@ -1024,51 +1131,58 @@ func resolveMergedPullRequestBaseTarget(ctx context.Context, environment *workfl
 	return branchName, nil
 }
 
-func syncExistingRemoteBranchWithoutPullRequest(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, repositoryIdentifier string, options strictPullRequestBranchOptions) (bool, error) {
+func syncExistingRemoteBranchWithoutPullRequest(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, repositoryIdentifier string, options strictPullRequestBranchOptions) (strictPullRequestBranchResult, error) {
 	remoteReference := fmt.Sprintf("%s/%s", options.RemoteName, options.BranchName)
 	remoteAheadOfBase, remoteAheadOfBaseErr := referenceHasCommitsBeyondBase(ctx, environment.GitExecutor, repository.Path, options.RemoteName, options.BaseBranch, remoteReference)
 	if remoteAheadOfBaseErr != nil {
-		return false, remoteAheadOfBaseErr
+		return strictPullRequestBranchResult{}, remoteAheadOfBaseErr
 	}
 
 	localExists, localExistsErr := localBranchExists(ctx, environment.GitExecutor, repository.Path, options.BranchName)
 	if localExistsErr != nil {
-		return false, localExistsErr
+		return strictPullRequestBranchResult{}, localExistsErr
 	}
 	localAheadOfBase := false
 	if localExists {
 		var localAheadOfBaseErr error
 		localAheadOfBase, localAheadOfBaseErr = branchHasCommitsBeyondBase(ctx, environment.GitExecutor, repository.Path, options.RemoteName, options.BaseBranch, options.BranchName)
 		if localAheadOfBaseErr != nil {
-			return false, localAheadOfBaseErr
+			return strictPullRequestBranchResult{}, localAheadOfBaseErr
 		}
 	}
 
 	if !remoteAheadOfBase && !localAheadOfBase {
-		return false, nil
+		return strictPullRequestBranchResult{}, nil
 	}
 
 	if switchErr := switchToLocalOrRemoteBranchWithAdoption(ctx, environment, repository, options.RemoteName, options.BranchName, options.CommitMessages); switchErr != nil {
-		return false, switchErr
+		return strictPullRequestBranchResult{}, switchErr
+	}
+	keptBranch, rewriteErr := reconcileStrictSyncRemoteRewrite(ctx, environment, repository, repositoryIdentifier, options)
+	if rewriteErr != nil {
+		return strictPullRequestBranchResult{}, rewriteErr
+	}
+	if keptBranch != "" {
+		return strictPullRequestBranchResult{Created: true, SyncedBranch: keptBranch}, nil
 	}
 	aheadCount, aheadErr := commitCount(ctx, environment.GitExecutor, repository.Path, fmt.Sprintf("%s..%s", remoteReference, options.BranchName))
 	if aheadErr != nil {
-		return false, aheadErr
+		return strictPullRequestBranchResult{}, aheadErr
 	}
 	if aheadCount > 0 {
 		if mergeErr := mergeRemoteBranchIntoLocal(ctx, environment, repository, environment.GitExecutor, repository.Path, options.RemoteName, options.BranchName, options.CommitMessages); mergeErr != nil {
-			return false, mergeErr
+			return strictPullRequestBranchResult{}, mergeErr
 		}
 	} else if resetErr := executeGit(ctx, environment.GitExecutor, repository.Path, []string{gitResetSubcommandConstant, gitResetHardFlagConstant, remoteReference}); resetErr != nil {
-		return false, resetErr
+		return strictPullRequestBranchResult{}, resetErr
 	}
 	if mergeErr := mergeBaseIntoBranch(ctx, environment, repository, environment.GitExecutor, repository.Path, options.RemoteName, options.BaseBranch, options.BranchName, options.CommitMessages); mergeErr != nil {
-		return false, mergeErr
+		return strictPullRequestBranchResult{}, mergeErr
 	}
 	if pullRequestErr := pushAndCreatePullRequest(ctx, environment, repository, repositoryIdentifier, options); pullRequestErr != nil {
-		return false, pullRequestErr
+		return strictPullRequestBranchResult{}, pullRequestErr
 	}
-	return true, nil
+	return strictPullRequestBranchResult{Created: true}, nil
 }
 
 func branchHasCommitsBeyondBase(ctx context.Context, executor shared.GitExecutor, repositoryPath string, remoteName string, baseBranch string, branchName string) (bool, error) {
@@ -1076,7 +1190,7 @@ func branchHasCommitsBeyondBase(ctx context.Context, executor shared.GitExecutor
 }
 
 func referenceHasCommitsBeyondBase(ctx context.Context, executor shared.GitExecutor, repositoryPath string, remoteName string, baseBranch string, reference string) (bool, error) {
-	baseReference := fmt.Sprintf("%s/%s", remoteName, baseBranch)
+	baseReference := fmt.Sprintf("%s/%s", strictSyncBaseRemote(ctx, remoteName), baseBranch)
 	aheadCount, aheadErr := commitCount(ctx, executor, repositoryPath, fmt.Sprintf("%s..%s", baseReference, reference))
 	if aheadErr != nil {
 		return false, aheadErr
@@ -1116,16 +1230,32 @@ func pushAndCreatePullRequest(ctx context.Context, environment *workflow.Environ
 	if pullRequestMetadataErr != nil {
 		return pullRequestMetadataErr
 	}
-	if pushErr := executeGit(ctx, environment.GitExecutor, repository.Path, []string{gitPushSubcommandConstant, gitPushSetUpstreamFlagConstant, options.RemoteName, options.BranchName}); pushErr != nil {
-		return pushErr
+	if options.PullRequest.IncludeConflictReport {
+		if conflictReport := mergeConflictReportPullRequestSection(ctx, repository.Path); conflictReport != "" {
+			pullRequestMetadata.Body = strings.TrimSpace(pullRequestMetadata.Body) + "\n\n" + conflictReport
+		}
 	}
-	return createPullRequest(ctx, environment, strictPullRequestCreateOptions{
+	createOptions := strictPullRequestCreateOptions{
 		RepositoryIdentifier: repositoryIdentifier,
 		BaseBranch:           options.BaseBranch,
 		BranchName:           options.BranchName,
 		Title:                pullRequestMetadata.Title,
 		Body:                 pullRequestMetadata.Body,
-	})
+		Settings:             strictSyncPullRequestSettingsForBranch(ctx, options.BranchName),
+	}
+	if verifyErr := verifyStrictSyncBranch(ctx, environment, repository, options.BranchName); verifyErr != nil {
+		return verifyErr
+	}
+	expectStrictSyncPullRequest(ctx, createOptions)
+	if pushErr := executeGit(ctx, environment.GitExecutor, repository.Path, []string{gitPushSubcommandConstant, gitPushSetUpstreamFlagConstant, options.RemoteName, options.BranchName}); pushErr != nil {
+		return pushErr
+	}
+	noteStrictSyncSyncedBranch(ctx, options.RemoteName, options.BranchName)
+	if createErr := createPullRequest(ctx, environment, createOptions); createErr != nil {
+		return createErr
+	}
+	enableStrictSyncAutoMerge(ctx, environment, repository, createOptions)
+	return nil
 }
 
 func switchToLocalOrRemoteBranch(ctx context.Context, executor shared.GitExecutor, repositoryPath string, remoteName string, branchName string) error {
@@ -1151,6 +1281,7 @@ func switchToLocalOrRemoteBranchWithAdoption(ctx context.Context, environment *w
 }
 
 func mergeBaseIntoBranch(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, executor shared.GitExecutor, repositoryPath string, remoteName string, baseBranch string, branchName string, commitMessages worktreeAdoptionCommitMessageOptions) error {
+	remoteName = strictSyncBaseRemote(ctx, remoteName)
 	baseReference := fmt.Sprintf("%s/%s", remoteName, baseBranch)
 	if mergeErr := executeGit(ctx, executor, repositoryPath, []string{gitMergeSubcommandConstant, gitMergeNoEditFlagConstant, baseReference}); mergeErr != nil {
 		return resolveMergeConflictOrError(ctx, environment, repository, executor, repositoryPath, baseReference, branchName, fmt.Sprintf(strictSyncConflictTemplate, remoteName, baseBranch, branchName), commitMessages, mergeErr)
@@ -1205,7 +1336,7 @@ func pullRequestsForBranchWithState(ctx context.Context, environment *workflow.E
 	if pullRequestErr != nil {
 		return nil, pullRequestErr
 	}
-	return pullRequests, nil
+	return strictSyncForkPullRequests(ctx, pullRequests), nil
 }
 
 func mergedPullRequestForCurrentBranchTip(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, repositoryIdentifier string, remoteName string, branchName string) (*githubcli.PullRequest, error) {
@@ -1265,6 +1396,7 @@ func ensureStrictSyncPullRequestHeadCommit(ctx context.Context, executor shared.
 		return headCommit, nil
 	}
 	pullRequestHeadReference := fmt.Sprintf(gitPullRequestHeadReferenceTemplateConstant, pullRequest.Number)
+	remoteName = strictSyncBaseRemote(ctx, remoteName)
 	if fetchErr := executeGit(ctx, executor, repositoryPath, []string{
 		gitFetchSubcommandConstant,
 		gitFetchNoTagsFlagConstant,
@@ -1387,15 +1519,24 @@ func createPullRequest(ctx context.Context, environment *workflow.Environment, o
 		Title:      options.Title,
 		Body:       options.Body,
 		Base:       options.BaseBranch,
-		Head:       options.BranchName,
+		Head:       strictSyncPullRequestHead(ctx, options.BranchName),
+		Draft:      options.Settings.draft(),
+		Labels:     options.Settings.Labels,
+		Assignees:  options.Settings.Assignees,
+		Reviewers:  options.Settings.Reviewers,
+		Milestone:  options.Settings.Milestone,
 	}); createErr != nil {
 		return createErr
 	}
+	clearStrictSyncPullRequestExpectation(ctx)
 	markStrictSyncPublished(ctx)
 	return nil
 }
 
-func strictSyncRepositoryIdentifier(repository *workflow.RepositoryState) string {
+func strictSyncRepositoryIdentifier(ctx context.Context, repository *workflow.RepositoryState) string {
+	if fork := strictSyncForkFromContext(ctx); fork != nil {
+		return fork.UpstreamRepository
+	}
 	if repository == nil {
 		return ""
 	}
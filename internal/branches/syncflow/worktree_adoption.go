@@ -631,6 +631,7 @@ func executeGitDetails(ctx context.Context, executor shared.GitExecutor, details
 			return publicationErr
 		}
 		if published {
+			recordStrictSyncPublishedReferences(ctx, result.StandardOutput)
 			markStrictSyncPublished(ctx)
 		}
 	}
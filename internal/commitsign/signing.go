@@ -0,0 +1,143 @@
+package commitsign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Mode selects how Git signs the commits gix creates.
+type Mode string
+
+// Supported signing modes.
+const (
+	ModeNone Mode = "none"
+	ModeSSH  Mode = "ssh"
+	ModeGPG  Mode = "gpg"
+)
+
+// Environment variable names Git reads for command-scoped configuration.
+const (
+	EnvGitConfigCount       = "GIT_CONFIG_COUNT"
+	envGitConfigKeyPrefix   = "GIT_CONFIG_KEY_"
+	envGitConfigValuePrefix = "GIT_CONFIG_VALUE_"
+)
+
+const (
+	gitConfigCommitSign     = "commit.gpgsign"
+	gitConfigSigningFormat  = "gpg.format"
+	gitConfigSigningKey     = "user.signingkey"
+	gitSigningFormatSSH     = "ssh"
+	gitSigningFormatOpenPGP = "openpgp"
+	gitConfigTrue           = "true"
+	unsupportedModeTemplate = "unsupported signing mode %q; use ssh, gpg, or none"
+	sshKeyRequiredMessage   = "signing.key is required when signing.mode is ssh"
+)
+
+// Configuration describes commit signing for every commit gix creates.
+type Configuration struct {
+	Mode              Mode
+	Key               string
+	RequireSignatures bool
+}
+
+// ParseMode normalizes a configured signing mode; an empty value means none.
+func ParseMode(raw string) (Mode, error) {
+	switch Mode(strings.ToLower(strings.TrimSpace(raw))) {
+	case "", ModeNone:
+		return ModeNone, nil
+	case ModeSSH:
+		return ModeSSH, nil
+	case ModeGPG:
+		return ModeGPG, nil
+	default:
+		return "", fmt.Errorf(unsupportedModeTemplate, strings.TrimSpace(raw))
+	}
+}
+
+// Validate reports configuration that cannot produce signatures.
+func (configuration Configuration) Validate() error {
+	if _, modeErr := ParseMode(string(configuration.Mode)); modeErr != nil {
+		return modeErr
+	}
+	if configuration.Mode == ModeSSH && len(strings.TrimSpace(configuration.Key)) == 0 {
+		return errors.New(sshKeyRequiredMessage)
+	}
+	return nil
+}
+
+// Signs reports whether gix configures Git to sign commits.
+func (configuration Configuration) Signs() bool {
+	return configuration.Mode == ModeSSH || configuration.Mode == ModeGPG
+}
+
+// RequiresPreflight reports whether commit-creating operations must verify signing before they mutate a repository.
+func (configuration Configuration) RequiresPreflight() bool {
+	return configuration.Signs() || configuration.RequireSignatures
+}
+
+// GitConfigEntries returns the Git configuration that makes commit, merge, and commit-tree sign with the configured key.
+func (configuration Configuration) GitConfigEntries() [][2]string {
+	if !configuration.Signs() {
+		return nil
+	}
+	format := gitSigningFormatOpenPGP
+	if configuration.Mode == ModeSSH {
+		format = gitSigningFormatSSH
+	}
+	entries := [][2]string{
+		{gitConfigCommitSign, gitConfigTrue},
+		{gitConfigSigningFormat, format},
+	}
+	if key := strings.TrimSpace(configuration.Key); len(key) > 0 {
+		entries = append(entries, [2]string{gitConfigSigningKey, key})
+	}
+	return entries
+}
+
+// ApplyEnvironment returns a copy of environment extended with GIT_CONFIG_* entries for the signing configuration.
+// Entries already present in environment are kept and the signing entries are numbered after them.
+func (configuration Configuration) ApplyEnvironment(environment map[string]string) map[string]string {
+	entries := configuration.GitConfigEntries()
+	if len(entries) == 0 {
+		return environment
+	}
+	applied := make(map[string]string, len(environment)+2*len(entries)+1)
+	for key, value := range environment {
+		applied[key] = value
+	}
+	offset, _ := strconv.Atoi(strings.TrimSpace(applied[EnvGitConfigCount]))
+	if offset < 0 {
+		offset = 0
+	}
+	for index, entry := range entries {
+		applied[envGitConfigKeyPrefix+strconv.Itoa(offset+index)] = entry[0]
+		applied[envGitConfigValuePrefix+strconv.Itoa(offset+index)] = entry[1]
+	}
+	applied[EnvGitConfigCount] = strconv.Itoa(offset + len(entries))
+	return applied
+}
+
+type configurationContextKey struct{}
+
+// WithConfiguration attaches the commit signing configuration to an execution context.
+func WithConfiguration(parentContext context.Context, configuration Configuration) context.Context {
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	return context.WithValue(parentContext, configurationContextKey{}, configuration)
+}
+
+// FromContext returns the commit signing configuration attached to the execution context.
+func FromContext(executionContext context.Context) Configuration {
+	if executionContext == nil {
+		return Configuration{Mode: ModeNone}
+	}
+	configuration, available := executionContext.Value(configurationContextKey{}).(Configuration)
+	if !available {
+		return Configuration{Mode: ModeNone}
+	}
+	return configuration
+}
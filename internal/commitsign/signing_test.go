@@ -0,0 +1,175 @@
+package commitsign_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/commitsign"
+)
+
+func TestParseMode(t *testing.T) {
+	testCases := []struct {
+		name          string
+		raw           string
+		expectedMode  commitsign.Mode
+		expectedError string
+	}{
+		{name: "empty means none", raw: "", expectedMode: commitsign.ModeNone},
+		{name: "none", raw: "none", expectedMode: commitsign.ModeNone},
+		{name: "ssh with whitespace and case", raw: " SSH ", expectedMode: commitsign.ModeSSH},
+		{name: "gpg", raw: "gpg", expectedMode: commitsign.ModeGPG},
+		{name: "unsupported", raw: " x509 ", expectedError: `unsupported signing mode "x509"; use ssh, gpg, or none`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mode, parseErr := commitsign.ParseMode(testCase.raw)
+			if testCase.expectedError != "" {
+				require.EqualError(t, parseErr, testCase.expectedError)
+				return
+			}
+			require.NoError(t, parseErr)
+			require.Equal(t, testCase.expectedMode, mode)
+		})
+	}
+}
+
+func TestConfigurationValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configuration commitsign.Configuration
+		expectedError string
+	}{
+		{name: "none", configuration: commitsign.Configuration{Mode: commitsign.ModeNone}},
+		{name: "gpg without key uses the default key", configuration: commitsign.Configuration{Mode: commitsign.ModeGPG}},
+		{name: "ssh with key", configuration: commitsign.Configuration{Mode: commitsign.ModeSSH, Key: "~/.ssh/id_ed25519.pub"}},
+		{
+			name:          "ssh without key",
+			configuration: commitsign.Configuration{Mode: commitsign.ModeSSH, Key: "  "},
+			expectedError: "signing.key is required when signing.mode is ssh",
+		},
+		{
+			name:          "unsupported mode",
+			configuration: commitsign.Configuration{Mode: commitsign.Mode("x509")},
+			expectedError: `unsupported signing mode "x509"; use ssh, gpg, or none`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			validationErr := testCase.configuration.Validate()
+			if testCase.expectedError != "" {
+				require.EqualError(t, validationErr, testCase.expectedError)
+				return
+			}
+			require.NoError(t, validationErr)
+		})
+	}
+}
+
+func TestConfigurationApplyEnvironment(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configuration commitsign.Configuration
+		environment   map[string]string
+		expected      map[string]string
+	}{
+		{
+			name:          "none leaves the environment untouched",
+			configuration: commitsign.Configuration{Mode: commitsign.ModeNone, RequireSignatures: true},
+			environment:   map[string]string{"GIT_TERMINAL_PROMPT": "0"},
+			expected:      map[string]string{"GIT_TERMINAL_PROMPT": "0"},
+		},
+		{
+			name:          "ssh into an empty environment",
+			configuration: commitsign.Configuration{Mode: commitsign.ModeSSH, Key: " ~/.ssh/id_ed25519.pub "},
+			expected: map[string]string{
+				"GIT_CONFIG_COUNT":   "3",
+				"GIT_CONFIG_KEY_0":   "commit.gpgsign",
+				"GIT_CONFIG_VALUE_0": "true",
+				"GIT_CONFIG_KEY_1":   "gpg.format",
+				"GIT_CONFIG_VALUE_1": "ssh",
+				"GIT_CONFIG_KEY_2":   "user.signingkey",
+				"GIT_CONFIG_VALUE_2": "~/.ssh/id_ed25519.pub",
+			},
+		},
+		{
+			name:          "gpg without key omits the signing key",
+			configuration: commitsign.Configuration{Mode: commitsign.ModeGPG},
+			expected: map[string]string{
+				"GIT_CONFIG_COUNT":   "2",
+				"GIT_CONFIG_KEY_0":   "commit.gpgsign",
+				"GIT_CONFIG_VALUE_0": "true",
+				"GIT_CONFIG_KEY_1":   "gpg.format",
+				"GIT_CONFIG_VALUE_1": "openpgp",
+			},
+		},
+		{
+			name:          "existing count numbers signing entries after it",
+			configuration: commitsign.Configuration{Mode: commitsign.ModeGPG, Key: "ABCDEF"},
+			environment: map[string]string{
+				"GIT_CONFIG_COUNT":   " 1 ",
+				"GIT_CONFIG_KEY_0":   "core.hooksPath",
+				"GIT_CONFIG_VALUE_0": "/dev/null",
+			},
+			expected: map[string]string{
+				"GIT_CONFIG_COUNT":   "4",
+				"GIT_CONFIG_KEY_0":   "core.hooksPath",
+				"GIT_CONFIG_VALUE_0": "/dev/null",
+				"GIT_CONFIG_KEY_1":   "commit.gpgsign",
+				"GIT_CONFIG_VALUE_1": "true",
+				"GIT_CONFIG_KEY_2":   "gpg.format",
+				"GIT_CONFIG_VALUE_2": "openpgp",
+				"GIT_CONFIG_KEY_3":   "user.signingkey",
+				"GIT_CONFIG_VALUE_3": "ABCDEF",
+			},
+		},
+		{
+			name:          "invalid count starts at zero",
+			configuration: commitsign.Configuration{Mode: commitsign.ModeGPG},
+			environment:   map[string]string{"GIT_CONFIG_COUNT": "many"},
+			expected: map[string]string{
+				"GIT_CONFIG_COUNT":   "2",
+				"GIT_CONFIG_KEY_0":   "commit.gpgsign",
+				"GIT_CONFIG_VALUE_0": "true",
+				"GIT_CONFIG_KEY_1":   "gpg.format",
+				"GIT_CONFIG_VALUE_1": "openpgp",
+			},
+		},
+		{
+			name:          "negative count starts at zero",
+			configuration: commitsign.Configuration{Mode: commitsign.ModeGPG},
+			environment:   map[string]string{"GIT_CONFIG_COUNT": "-3"},
+			expected: map[string]string{
+				"GIT_CONFIG_COUNT":   "2",
+				"GIT_CONFIG_KEY_0":   "commit.gpgsign",
+				"GIT_CONFIG_VALUE_0": "true",
+				"GIT_CONFIG_KEY_1":   "gpg.format",
+				"GIT_CONFIG_VALUE_1": "openpgp",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var original map[string]string
+			if testCase.environment != nil {
+				original = make(map[string]string, len(testCase.environment))
+				for key, value := range testCase.environment {
+					original[key] = value
+				}
+			}
+
+			applied := testCase.configuration.ApplyEnvironment(testCase.environment)
+
+			require.Equal(t, testCase.expected, applied)
+			require.Equal(t, original, testCase.environment)
+		})
+	}
+}
+
+func TestConfigurationContextRoundTrip(t *testing.T) {
+	require.Equal(t, commitsign.Configuration{Mode: commitsign.ModeNone}, commitsign.FromContext(context.Background()))
+
+	configuration := commitsign.Configuration{Mode: commitsign.ModeSSH, Key: "key.pub", RequireSignatures: true}
+	require.Equal(t, configuration, commitsign.FromContext(commitsign.WithConfiguration(context.Background(), configuration)))
+}
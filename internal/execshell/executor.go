@@ -8,6 +8,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/tyemirov/gix/internal/commitsign"
 	"github.com/tyemirov/gix/internal/githubauth"
 )
 
@@ -264,6 +265,10 @@ func (executor *ShellExecutor) ExecuteCurl(executionContext context.Context, det
 }
 
 func (executor *ShellExecutor) prepareCommand(executionContext context.Context, command ShellCommand) (ShellCommand, error) {
+	if command.Name == CommandGit {
+		command.Details.EnvironmentVariables = commitsign.FromContext(executionContext).ApplyEnvironment(command.Details.EnvironmentVariables)
+		return command, nil
+	}
 	if command.Name != CommandGitHub {
 		return command, nil
 	}
@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 
+	"github.com/tyemirov/gix/internal/commitsign"
 	"github.com/tyemirov/gix/internal/execshell"
 	"github.com/tyemirov/gix/internal/githubauth"
 )
@@ -411,6 +412,42 @@ func TestShellExecutorInjectsGitHubTokenFromConfigurationContext(testInstance *t
 	require.Equal(testInstance, "config-token", environment[githubauth.EnvGitHubToken])
 }
 
+func TestShellExecutorAppliesCommitSigningToGitCommands(testInstance *testing.T) {
+	recordingRunner := &recordingCommandRunner{
+		executionResult: execshell.ExecutionResult{ExitCode: 0},
+	}
+
+	shellExecutor, creationError := execshell.NewShellExecutor(zap.NewNop(), recordingRunner, false)
+	require.NoError(testInstance, creationError)
+
+	executionContext := commitsign.WithConfiguration(context.Background(), commitsign.Configuration{Mode: commitsign.ModeSSH, Key: "/keys/id_ed25519.pub"})
+	_, executionError := shellExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments: []string{"commit", "-m", "signed"},
+		EnvironmentVariables: map[string]string{
+			"GIT_INDEX_FILE":   "/tmp/private-index",
+			"GIT_CONFIG_COUNT": "1",
+			"GIT_CONFIG_KEY_0": "core.hooksPath",
+		},
+	})
+	require.NoError(testInstance, executionError)
+	_, executionError = shellExecutor.ExecuteGitHubCLI(githubauth.WithCredential(executionContext, "config-token"), execshell.CommandDetails{Arguments: []string{"status"}})
+	require.NoError(testInstance, executionError)
+
+	require.Len(testInstance, recordingRunner.recordedCommands, 2)
+	require.Equal(testInstance, map[string]string{
+		"GIT_INDEX_FILE":     "/tmp/private-index",
+		"GIT_CONFIG_COUNT":   "4",
+		"GIT_CONFIG_KEY_0":   "core.hooksPath",
+		"GIT_CONFIG_KEY_1":   "commit.gpgsign",
+		"GIT_CONFIG_VALUE_1": "true",
+		"GIT_CONFIG_KEY_2":   "gpg.format",
+		"GIT_CONFIG_VALUE_2": "ssh",
+		"GIT_CONFIG_KEY_3":   "user.signingkey",
+		"GIT_CONFIG_VALUE_3": "/keys/id_ed25519.pub",
+	}, recordingRunner.recordedCommands[0].Details.EnvironmentVariables)
+	require.NotContains(testInstance, recordingRunner.recordedCommands[1].Details.EnvironmentVariables, commitsign.EnvGitConfigCount)
+}
+
 func TestShellExecutorPreservesExplicitGitHubToken(testInstance *testing.T) {
 	observerCore, _ := observer.New(zap.DebugLevel)
 	logger := zap.New(observerCore)
@@ -1,7 +1,8 @@
 // Package ghcr provides typed clients for the GitHub Container Registry APIs.
 //
-// It defines OwnerType helpers, RetentionRequest and RetentionResult models, and the
+// It defines OwnerType helpers, RetentionRequest and RetentionResult models, the
 // PackageVersionService which performs paginated listing and deletion of
-// container versions. The package powers the packages CLI commands and can be
+// container versions, and PlanRetention, which previews the versions a
+// retention run deletes. The package powers the packages CLI commands and can be
 // reused for GitHub Enterprise endpoints.
 package ghcr
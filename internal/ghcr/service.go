@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -69,6 +70,14 @@ type ServiceConfiguration struct {
 	PageSize int
 }
 
+// PackageRequest identifies one container package and the token used to read it.
+type PackageRequest struct {
+	Owner       string
+	PackageName string
+	OwnerType   OwnerType
+	Token       string
+}
+
 // RetentionRequest captures the information required to apply package retention.
 type RetentionRequest struct {
 	Owner       string
@@ -76,6 +85,19 @@ type RetentionRequest struct {
 	OwnerType   OwnerType
 	Token       string
 	Keep        KeepCount
+	// PinnedVersionIDs are never deleted and do not count toward Keep.
+	PinnedVersionIDs []int64
+}
+
+// PackageVersion describes one container package version.
+type PackageVersion struct {
+	ID int64
+	// Name is the manifest digest of the version.
+	Name      string
+	Tags      []string
+	CreatedAt time.Time
+	// Size is the size in bytes when the API reports one; GitHub omits it for container versions.
+	Size *int64
 }
 
 // RetentionResult contains summary statistics from a retention operation.
@@ -123,74 +145,58 @@ func NewPackageVersionService(logger *zap.Logger, httpClient HTTPClient, configu
 	}, nil
 }
 
+// ListVersions returns every version of a package, newest first, fetched page by page like ApplyRetention.
+func (service *PackageVersionService) ListVersions(executionContext context.Context, request PackageRequest) ([]PackageVersion, error) {
+	normalizedRequest, requestError := normalizePackageRequest(request)
+	if requestError != nil {
+		return nil, requestError
+	}
+
+	return service.listVersions(executionContext, normalizedRequest)
+}
+
 // ApplyRetention preserves the newest requested package versions and deletes the rest.
 func (service *PackageVersionService) ApplyRetention(executionContext context.Context, request RetentionRequest) (RetentionResult, error) {
-	trimmedToken := strings.TrimSpace(request.Token)
-	if len(trimmedToken) == 0 {
-		return RetentionResult{}, errors.New(tokenMissingErrorMessageConstant)
-	}
-	trimmedOwner := strings.TrimSpace(request.Owner)
-	if len(trimmedOwner) == 0 {
-		return RetentionResult{}, errors.New(ownerMissingErrorMessageConstant)
-	}
-	trimmedPackageName := strings.TrimSpace(request.PackageName)
-	if len(trimmedPackageName) == 0 {
-		return RetentionResult{}, errors.New(packageMissingErrorMessageConstant)
-	}
-	ownerType, ownerTypeError := ParseOwnerType(string(request.OwnerType))
-	if ownerTypeError != nil {
-		return RetentionResult{}, ownerTypeError
+	packageRequest, requestError := normalizePackageRequest(PackageRequest{
+		Owner:       request.Owner,
+		PackageName: request.PackageName,
+		OwnerType:   request.OwnerType,
+		Token:       request.Token,
+	})
+	if requestError != nil {
+		return RetentionResult{}, requestError
 	}
 	keepCount, keepCountError := NewKeepCount(request.Keep.Value())
 	if keepCountError != nil {
 		return RetentionResult{}, keepCountError
 	}
 
-	request.Token = trimmedToken
-	request.Owner = trimmedOwner
-	request.PackageName = trimmedPackageName
-	request.OwnerType = ownerType
-	request.Keep = keepCount
-
 	service.logger.Info(
 		retentionStartMessageConstant,
-		zap.String(ownerLogFieldNameConstant, trimmedOwner),
-		zap.String(packageLogFieldNameConstant, trimmedPackageName),
-		zap.String(ownerTypeLogFieldNameConstant, string(request.OwnerType)),
+		zap.String(ownerLogFieldNameConstant, packageRequest.Owner),
+		zap.String(packageLogFieldNameConstant, packageRequest.PackageName),
+		zap.String(ownerTypeLogFieldNameConstant, string(packageRequest.OwnerType)),
 		zap.Int(pageSizeLogFieldNameConstant, service.pageSize),
 	)
 
-	versions, fetchError := service.fetchAllVersions(executionContext, request)
-	if fetchError != nil {
-		return RetentionResult{}, fetchError
-	}
-	if validationError := validatePackageVersions(versions); validationError != nil {
-		return RetentionResult{}, validationError
+	versions, listError := service.listVersions(executionContext, packageRequest)
+	if listError != nil {
+		return RetentionResult{}, listError
 	}
 
-	sort.Slice(versions, func(leftIndex int, rightIndex int) bool {
-		leftVersion := versions[leftIndex]
-		rightVersion := versions[rightIndex]
-		if leftVersion.CreatedAt.Equal(rightVersion.CreatedAt) {
-			return leftVersion.ID > rightVersion.ID
-		}
-		return leftVersion.CreatedAt.After(rightVersion.CreatedAt)
-	})
-
-	retainedVersions := min(keepCount.Value(), len(versions))
+	deletedVersions := PlanRetention(versions, keepCount, request.PinnedVersionIDs)
 	result := RetentionResult{
 		TotalVersions:    len(versions),
-		RetainedVersions: retainedVersions,
+		RetainedVersions: len(versions) - len(deletedVersions),
 	}
 
-	for versionIndex := len(versions) - 1; versionIndex >= retainedVersions; versionIndex-- {
-		version := versions[versionIndex]
+	for _, version := range deletedVersions {
 		service.logger.Info(
 			retentionDeleteMessageConstant,
 			zap.Int64(versionIdentifierLogFieldNameConstant, version.ID),
 		)
 
-		deleteError := service.deleteVersion(executionContext, request, version.ID)
+		deleteError := service.deleteVersion(executionContext, packageRequest, version.ID)
 		if deleteError != nil {
 			return result, deleteError
 		}
@@ -199,8 +205,8 @@ func (service *PackageVersionService) ApplyRetention(executionContext context.Co
 
 	service.logger.Info(
 		retentionCompleteMessageConstant,
-		zap.String(ownerLogFieldNameConstant, trimmedOwner),
-		zap.String(packageLogFieldNameConstant, trimmedPackageName),
+		zap.String(ownerLogFieldNameConstant, packageRequest.Owner),
+		zap.String(packageLogFieldNameConstant, packageRequest.PackageName),
 		zap.Int(totalVersionsLogFieldNameConstant, result.TotalVersions),
 		zap.Int(retainedVersionsLogFieldNameConstant, result.RetainedVersions),
 		zap.Int(deletedVersionsLogFieldNameConstant, result.DeletedVersions),
@@ -209,7 +215,90 @@ func (service *PackageVersionService) ApplyRetention(executionContext context.Co
 	return result, nil
 }
 
-func (service *PackageVersionService) fetchAllVersions(executionContext context.Context, request RetentionRequest) ([]packageVersion, error) {
+// PlanRetention returns the versions a retention run deletes, oldest first. versions must be ordered
+// newest first, as ListVersions returns them: pinned versions are skipped, the newest keep of the
+// remaining versions are retained, and everything older is deleted.
+func PlanRetention(versions []PackageVersion, keep KeepCount, pinnedVersionIDs []int64) []PackageVersion {
+	if keep.Value() <= 0 {
+		return nil
+	}
+
+	retainedVersions := 0
+	deletedVersions := make([]PackageVersion, 0)
+	for _, version := range versions {
+		if slices.Contains(pinnedVersionIDs, version.ID) {
+			continue
+		}
+		if retainedVersions < keep.Value() {
+			retainedVersions++
+			continue
+		}
+		deletedVersions = append(deletedVersions, version)
+	}
+	slices.Reverse(deletedVersions)
+	return deletedVersions
+}
+
+func normalizePackageRequest(request PackageRequest) (PackageRequest, error) {
+	trimmedToken := strings.TrimSpace(request.Token)
+	if len(trimmedToken) == 0 {
+		return PackageRequest{}, errors.New(tokenMissingErrorMessageConstant)
+	}
+	trimmedOwner := strings.TrimSpace(request.Owner)
+	if len(trimmedOwner) == 0 {
+		return PackageRequest{}, errors.New(ownerMissingErrorMessageConstant)
+	}
+	trimmedPackageName := strings.TrimSpace(request.PackageName)
+	if len(trimmedPackageName) == 0 {
+		return PackageRequest{}, errors.New(packageMissingErrorMessageConstant)
+	}
+	ownerType, ownerTypeError := ParseOwnerType(string(request.OwnerType))
+	if ownerTypeError != nil {
+		return PackageRequest{}, ownerTypeError
+	}
+
+	return PackageRequest{
+		Owner:       trimmedOwner,
+		PackageName: trimmedPackageName,
+		OwnerType:   ownerType,
+		Token:       trimmedToken,
+	}, nil
+}
+
+// listVersions fetches every page before returning, so a failed page or an invalid version never
+// leaves a partial snapshot for retention to act on.
+func (service *PackageVersionService) listVersions(executionContext context.Context, request PackageRequest) ([]PackageVersion, error) {
+	versions, fetchError := service.fetchAllVersions(executionContext, request)
+	if fetchError != nil {
+		return nil, fetchError
+	}
+	if validationError := validatePackageVersions(versions); validationError != nil {
+		return nil, validationError
+	}
+
+	sort.Slice(versions, func(leftIndex int, rightIndex int) bool {
+		leftVersion := versions[leftIndex]
+		rightVersion := versions[rightIndex]
+		if leftVersion.CreatedAt.Equal(rightVersion.CreatedAt) {
+			return leftVersion.ID > rightVersion.ID
+		}
+		return leftVersion.CreatedAt.After(rightVersion.CreatedAt)
+	})
+
+	listedVersions := make([]PackageVersion, 0, len(versions))
+	for _, version := range versions {
+		listedVersions = append(listedVersions, PackageVersion{
+			ID:        version.ID,
+			Name:      version.Name,
+			Tags:      slices.Clone(version.Metadata.Container.Tags),
+			CreatedAt: version.CreatedAt,
+			Size:      version.Size,
+		})
+	}
+	return listedVersions, nil
+}
+
+func (service *PackageVersionService) fetchAllVersions(executionContext context.Context, request PackageRequest) ([]packageVersion, error) {
 	allVersions := make([]packageVersion, 0)
 	for pageNumber := 1; ; pageNumber++ {
 		versions, fetchError := service.fetchPage(executionContext, request, pageNumber)
@@ -232,7 +321,7 @@ func (service *PackageVersionService) fetchAllVersions(executionContext context.
 	}
 }
 
-func (service *PackageVersionService) fetchPage(executionContext context.Context, request RetentionRequest, pageNumber int) ([]packageVersion, error) {
+func (service *PackageVersionService) fetchPage(executionContext context.Context, request PackageRequest, pageNumber int) ([]packageVersion, error) {
 	versionsURL, urlBuildError := service.buildVersionsURL(request.OwnerType, request.Owner, request.PackageName, pageNumber)
 	if urlBuildError != nil {
 		return nil, urlBuildError
@@ -272,7 +361,7 @@ func (service *PackageVersionService) fetchPage(executionContext context.Context
 	return versions, nil
 }
 
-func (service *PackageVersionService) deleteVersion(executionContext context.Context, request RetentionRequest, versionID int64) error {
+func (service *PackageVersionService) deleteVersion(executionContext context.Context, request PackageRequest, versionID int64) error {
 	deleteURL, urlBuildError := service.buildVersionURL(request.OwnerType, request.Owner, request.PackageName, versionID)
 	if urlBuildError != nil {
 		return urlBuildError
@@ -359,7 +448,14 @@ func (service *PackageVersionService) buildVersionURL(ownerType OwnerType, owner
 
 type packageVersion struct {
 	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
 	CreatedAt time.Time `json:"created_at"`
+	Size      *int64    `json:"size"`
+	Metadata  struct {
+		Container struct {
+			Tags []string `json:"tags"`
+		} `json:"container"`
+	} `json:"metadata"`
 }
 
 func validatePackageVersions(versions []packageVersion) error {
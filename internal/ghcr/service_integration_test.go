@@ -82,7 +82,7 @@ func handleIntegrationGet(testingInstance *testing.T, responseWriter http.Respon
 		]`
 	case "2":
 		payload = `[
-			{"id":303,"created_at":"2026-01-03T00:00:00Z","metadata":{"container":{"tags":["v3"]}}},
+			{"id":303,"name":"sha256:303","created_at":"2026-01-03T00:00:00Z","size":2048,"metadata":{"container":{"tags":["v3"]}}},
 			{"id":202,"created_at":"2026-01-02T00:00:00Z","metadata":{"container":{"tags":["v2"]}}}
 		]`
 	case "3":
@@ -107,3 +107,52 @@ func parseVersionIdentifierFromPath(requestPath string) (int64, error) {
 	identifierSegment := segments[len(segments)-1]
 	return strconv.ParseInt(identifierSegment, 10, 64)
 }
+
+func TestPackageVersionServiceListsVersionsAndKeepsPinnedVersions(testingInstance *testing.T) {
+	recordedDeleteIdentifiers := make([]int64, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		switch httpRequest.Method {
+		case http.MethodGet:
+			handleIntegrationGet(testingInstance, responseWriter, httpRequest)
+		case http.MethodDelete:
+			versionIdentifier, parseError := parseVersionIdentifierFromPath(httpRequest.URL.Path)
+			require.NoError(testingInstance, parseError)
+			recordedDeleteIdentifiers = append(recordedDeleteIdentifiers, versionIdentifier)
+			responseWriter.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), server.Client(), ghcr.ServiceConfiguration{
+		BaseURL:  server.URL,
+		PageSize: 2,
+	})
+	require.NoError(testingInstance, serviceError)
+
+	versions, listError := service.ListVersions(context.Background(), ghcr.PackageRequest{
+		Owner:       integrationOwnerNameConstant,
+		PackageName: integrationPackageNameConstant,
+		OwnerType:   ghcr.UserOwnerType,
+		Token:       integrationTokenConstant,
+	})
+	require.NoError(testingInstance, listError)
+	require.Len(testingInstance, versions, 5)
+	require.Equal(testingInstance, int64(505), versions[0].ID)
+	require.Equal(testingInstance, []string{"latest", "v5"}, versions[0].Tags)
+	require.Nil(testingInstance, versions[0].Size)
+	require.Equal(testingInstance, "sha256:303", versions[2].Name)
+	require.Equal(testingInstance, int64(2048), *versions[2].Size)
+	require.Equal(testingInstance, int64(101), versions[4].ID)
+
+	result, retentionError := service.ApplyRetention(context.Background(), ghcr.RetentionRequest{
+		Owner:            integrationOwnerNameConstant,
+		PackageName:      integrationPackageNameConstant,
+		OwnerType:        ghcr.UserOwnerType,
+		Token:            integrationTokenConstant,
+		Keep:             mustKeepCount(testingInstance, 2),
+		PinnedVersionIDs: []int64{202},
+	})
+	require.NoError(testingInstance, retentionError)
+	require.Equal(testingInstance, ghcr.RetentionResult{TotalVersions: 5, RetainedVersions: 3, DeletedVersions: 2}, result)
+	require.Equal(testingInstance, []int64{101, 303}, recordedDeleteIdentifiers)
+}
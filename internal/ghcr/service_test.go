@@ -345,6 +345,34 @@ func TestPackageVersionServiceReturnsPartialDeletionResult(testingInstance *test
 	require.Equal(testingInstance, ghcr.RetentionResult{TotalVersions: 3, RetainedVersions: 1, DeletedVersions: 1}, result)
 }
 
+func TestPlanRetentionSkipsPinnedVersions(testingInstance *testing.T) {
+	versions := []ghcr.PackageVersion{{ID: 5}, {ID: 4}, {ID: 3}, {ID: 2}, {ID: 1}}
+
+	testCases := []struct {
+		name            string
+		keep            ghcr.KeepCount
+		pinned          []int64
+		expectedDeleted []int64
+	}{
+		{name: "no_pins", keep: mustKeepCount(testingInstance, 2), expectedDeleted: []int64{1, 2, 3}},
+		{name: "pinned_newest", keep: mustKeepCount(testingInstance, 2), pinned: []int64{5}, expectedDeleted: []int64{1, 2}},
+		{name: "pinned_oldest", keep: mustKeepCount(testingInstance, 2), pinned: []int64{1}, expectedDeleted: []int64{2, 3}},
+		{name: "keep_exceeds_total", keep: mustKeepCount(testingInstance, 9), expectedDeleted: []int64{}},
+		{name: "invalid_keep", keep: ghcr.KeepCount{}, expectedDeleted: []int64{}},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+		testingInstance.Run(testCase.name, func(testingSubInstance *testing.T) {
+			deletedIdentifiers := make([]int64, 0)
+			for _, version := range ghcr.PlanRetention(versions, testCase.keep, testCase.pinned) {
+				deletedIdentifiers = append(deletedIdentifiers, version.ID)
+			}
+			require.Equal(testingSubInstance, testCase.expectedDeleted, deletedIdentifiers)
+		})
+	}
+}
+
 func mustKeepCount(testingInstance *testing.T, value int) ghcr.KeepCount {
 	testingInstance.Helper()
 	keepCount, keepCountError := ghcr.NewKeepCount(value)
@@ -34,6 +34,12 @@ const (
 	titleFlagConstant                          = "--title"
 	bodyFlagConstant                           = "--body"
 	draftFlagConstant                          = "--draft"
+	labelFlagConstant                          = "--label"
+	assigneeFlagConstant                       = "--assignee"
+	reviewerFlagConstant                       = "--reviewer"
+	milestoneFlagConstant                      = "--milestone"
+	autoFlagConstant                           = "--auto"
+	mergeSubcommandConstant                    = "merge"
 	acceptHeaderFlagConstant                   = "-H"
 	acceptHeaderValueConstant                  = "Accept: application/vnd.github+json"
 	repositoryFieldNameConstant                = "repository"
@@ -67,6 +73,10 @@ const (
 	closePullRequestOperationNameConstant      = OperationName("ClosePullRequest")
 	checkBranchProtectionOperationNameConstant = OperationName("CheckBranchProtection")
 	createPullRequestOperationNameConstant     = OperationName("CreatePullRequest")
+	enableAutoMergeOperationNameConstant       = OperationName("EnablePullRequestAutoMerge")
+	repositoryStatusOperationNameConstant      = OperationName("GetRepositoryStatus")
+	mergeMethodFieldNameConstant               = "merge_method"
+	unsupportedMergeMethodTemplateConstant     = "unsupported merge method %q"
 	httpNotFoundIndicatorConstant              = "http 404"
 	statusNotFoundIndicatorConstant            = "status 404"
 	pagesResponseEmptyMessageConstant          = "pages response is empty"
@@ -91,6 +101,16 @@ const (
 	PullRequestStateMerged PullRequestState = PullRequestState("merged")
 )
 
+// PullRequestMergeMethod enumerates the merge strategies gh pr merge accepts.
+type PullRequestMergeMethod string
+
+// Pull request merge method enumerations.
+const (
+	PullRequestMergeMethodSquash PullRequestMergeMethod = PullRequestMergeMethod("squash")
+	PullRequestMergeMethodMerge  PullRequestMergeMethod = PullRequestMergeMethod("merge")
+	PullRequestMergeMethodRebase PullRequestMergeMethod = PullRequestMergeMethod("rebase")
+)
+
 // RepositoryMetadata contains key details resolved from GitHub.
 type RepositoryMetadata struct {
 	NameWithOwner    string
@@ -99,6 +119,14 @@ type RepositoryMetadata struct {
 	IsInOrganization bool
 }
 
+// RepositoryStatus captures the repository state reported by the GitHub REST API.
+type RepositoryStatus struct {
+	Archived      bool
+	Disabled      bool
+	Visibility    string
+	DefaultBranch string
+}
+
 // PullRequest represents minimal PR details returned by GitHub CLI.
 type PullRequest struct {
 	Number                      int
@@ -125,6 +153,11 @@ type PullRequestCreateOptions struct {
 	Base       string
 	Head       string
 	Draft      bool
+	Labels     []string
+	Assignees  []string
+	// Reviewers accepts GitHub logins and organization/team slugs.
+	Reviewers []string
+	Milestone string
 }
 
 // PagesConfiguration describes the desired GitHub Pages configuration.
@@ -404,6 +437,12 @@ func (client *Client) CreatePullRequest(executionContext context.Context, option
 	if options.Draft {
 		arguments = append(arguments, draftFlagConstant)
 	}
+	arguments = appendRepeatedFlag(arguments, labelFlagConstant, options.Labels)
+	arguments = appendRepeatedFlag(arguments, assigneeFlagConstant, options.Assignees)
+	arguments = appendRepeatedFlag(arguments, reviewerFlagConstant, options.Reviewers)
+	if milestone := strings.TrimSpace(options.Milestone); len(milestone) > 0 {
+		arguments = append(arguments, milestoneFlagConstant, milestone)
+	}
 
 	commandDetails := execshell.CommandDetails{
 		Arguments:              arguments,
@@ -417,6 +456,57 @@ func (client *Client) CreatePullRequest(executionContext context.Context, option
 	return nil
 }
 
+// EnablePullRequestAutoMerge turns on auto-merge for the pull request selected by head using gh pr merge --auto.
+func (client *Client) EnablePullRequestAutoMerge(executionContext context.Context, repository string, head string, method PullRequestMergeMethod) error {
+	if client.executor == nil {
+		return ErrExecutorNotConfigured
+	}
+
+	repositoryIdentifier := strings.TrimSpace(repository)
+	if len(repositoryIdentifier) == 0 {
+		return InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	trimmedHead := strings.TrimSpace(head)
+	if len(trimmedHead) == 0 {
+		return InvalidInputError{FieldName: sourceBranchFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	switch method {
+	case PullRequestMergeMethodSquash, PullRequestMergeMethodMerge, PullRequestMergeMethodRebase:
+	default:
+		return InvalidInputError{FieldName: mergeMethodFieldNameConstant, Message: fmt.Sprintf(unsupportedMergeMethodTemplateConstant, method)}
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments: []string{
+			pullRequestSubcommandConstant,
+			mergeSubcommandConstant,
+			trimmedHead,
+			repoFlagConstant,
+			repositoryIdentifier,
+			autoFlagConstant,
+			"--" + string(method),
+		},
+		GitHubTokenRequirement: githubauth.TokenRequired,
+	}
+	_, executionError := client.executor.ExecuteGitHubCLI(executionContext, commandDetails)
+	if executionError != nil {
+		return OperationError{Operation: enableAutoMergeOperationNameConstant, Cause: executionError}
+	}
+
+	return nil
+}
+
+func appendRepeatedFlag(arguments []string, flagName string, values []string) []string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); len(trimmed) > 0 {
+			arguments = append(arguments, flagName, trimmed)
+		}
+	}
+	return arguments
+}
+
 // UpdatePagesConfig updates the GitHub Pages configuration using gh api.
 func (client *Client) UpdatePagesConfig(executionContext context.Context, repository string, configuration PagesConfiguration) error {
 	repositoryIdentifier := strings.TrimSpace(repository)
@@ -558,6 +648,49 @@ func decodePagesStatus(output string) (PagesStatus, error) {
 	}
 }
 
+// GetRepositoryStatus retrieves archive, visibility, and default branch details for the repository.
+func (client *Client) GetRepositoryStatus(executionContext context.Context, repository string) (RepositoryStatus, error) {
+	repositoryIdentifier := strings.TrimSpace(repository)
+	if len(repositoryIdentifier) == 0 {
+		return RepositoryStatus{}, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments: []string{
+			apiSubcommandConstant,
+			fmt.Sprintf(repositoryEndpointTemplateConstant, repositoryIdentifier),
+			methodFlagConstant,
+			httpMethodGetConstant,
+			acceptHeaderFlagConstant,
+			acceptHeaderValueConstant,
+		},
+		GitHubTokenRequirement: githubauth.TokenOptional,
+	}
+
+	executionResult, executionError := client.executor.ExecuteGitHubCLI(executionContext, commandDetails)
+	if executionError != nil {
+		return RepositoryStatus{}, OperationError{Operation: repositoryStatusOperationNameConstant, Cause: executionError}
+	}
+
+	var response struct {
+		Archived      bool   `json:"archived"`
+		Disabled      bool   `json:"disabled"`
+		Visibility    string `json:"visibility"`
+		DefaultBranch string `json:"default_branch"`
+	}
+	decodingError := json.Unmarshal([]byte(executionResult.StandardOutput), &response)
+	if decodingError != nil {
+		return RepositoryStatus{}, ResponseDecodingError{Operation: repositoryStatusOperationNameConstant, Cause: decodingError}
+	}
+
+	return RepositoryStatus{
+		Archived:      response.Archived,
+		Disabled:      response.Disabled,
+		Visibility:    strings.TrimSpace(response.Visibility),
+		DefaultBranch: strings.TrimSpace(response.DefaultBranch),
+	}, nil
+}
+
 // SetDefaultBranch updates the default branch for the repository.
 func (client *Client) SetDefaultBranch(executionContext context.Context, repository string, branchName string) error {
 	repositoryIdentifier := strings.TrimSpace(repository)
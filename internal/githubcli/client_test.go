@@ -58,11 +58,20 @@ const (
 	testCreatePullRequestSuccessCaseNameConstant         = "create_pull_request_success"
 	testCreatePullRequestCommandFailureCaseNameConstant  = "create_pull_request_command_failure"
 	testCreatePullRequestValidationCaseNameConstant      = "create_pull_request_validation"
+	testCreatePullRequestMetadataCaseNameConstant        = "create_pull_request_metadata"
+	testAutoMergeSuccessCaseNameConstant                 = "auto_merge_success"
+	testAutoMergeCommandFailureCaseNameConstant          = "auto_merge_command_failure"
+	testAutoMergeMethodValidationCaseNameConstant        = "auto_merge_method_validation"
+	testAutoMergeValidationCaseNameConstant              = "auto_merge_validation"
 	testBranchProtectionProtectedCaseNameConstant        = "branch_protection_protected"
 	testBranchProtectionUnprotectedCaseNameConstant      = "branch_protection_unprotected"
 	testBranchProtectionUnexpectedStatusCaseNameConstant = "branch_protection_unexpected_status"
 	testBranchProtectionCommandFailureCaseNameConstant   = "branch_protection_command_failure"
 	testBranchProtectionValidationCaseNameConstant       = "branch_protection_validation"
+	testRepositoryStatusSuccessCaseNameConstant          = "repository_status_success"
+	testRepositoryStatusDecodeFailureCaseNameConstant    = "repository_status_decode_failure"
+	testRepositoryStatusCommandFailureCaseNameConstant   = "repository_status_command_failure"
+	testRepositoryStatusValidationCaseNameConstant       = "repository_status_validation"
 	testHTTPNotFoundStandardErrorMessageConstant         = "gh: Not Found (HTTP 404)"
 	testHTTPForbiddenStandardErrorMessageConstant        = "gh: Forbidden (HTTP 403)"
 )
@@ -709,6 +718,50 @@ func TestCreatePullRequest(testInstance *testing.T) {
 				require.Equal(testInstance, expectedArguments, executor.recordedDetails[0].Arguments)
 			},
 		},
+		{
+			name:     testCreatePullRequestMetadataCaseNameConstant,
+			executor: &stubGitHubExecutor{},
+			options: githubcli.PullRequestCreateOptions{
+				Repository: testRepositoryIdentifierConstant,
+				Title:      testPullRequestTitleConstant,
+				Body:       "Automated update",
+				Base:       testBaseBranchConstant,
+				Head:       testPullRequestHeadConstant,
+				Labels:     []string{"sync", " ", "needs-review"},
+				Assignees:  []string{"octocat"},
+				Reviewers:  []string{"hubot", "owner/platform"},
+				Milestone:  " v2.0 ",
+			},
+			verify: func(testInstance *testing.T, executor *stubGitHubExecutor) {
+				require.Len(testInstance, executor.recordedDetails, 1)
+				require.Equal(testInstance, []string{
+					"pr",
+					"create",
+					"--repo",
+					testRepositoryIdentifierConstant,
+					"--base",
+					testBaseBranchConstant,
+					"--head",
+					testPullRequestHeadConstant,
+					"--title",
+					testPullRequestTitleConstant,
+					"--body",
+					"Automated update",
+					"--label",
+					"sync",
+					"--label",
+					"needs-review",
+					"--assignee",
+					"octocat",
+					"--reviewer",
+					"hubot",
+					"--reviewer",
+					"owner/platform",
+					"--milestone",
+					"v2.0",
+				}, executor.recordedDetails[0].Arguments)
+			},
+		},
 		{
 			name: testCreatePullRequestCommandFailureCaseNameConstant,
 			executor: &stubGitHubExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
@@ -752,6 +805,83 @@ func TestCreatePullRequest(testInstance *testing.T) {
 	}
 }
 
+func TestEnablePullRequestAutoMerge(testInstance *testing.T) {
+	testCases := []struct {
+		name        string
+		repository  string
+		head        string
+		method      githubcli.PullRequestMergeMethod
+		executor    *stubGitHubExecutor
+		expectError bool
+		errorType   any
+		verify      func(testInstance *testing.T, executor *stubGitHubExecutor)
+	}{
+		{
+			name:       testAutoMergeSuccessCaseNameConstant,
+			repository: testRepositoryIdentifierConstant,
+			head:       testPullRequestHeadConstant,
+			method:     githubcli.PullRequestMergeMethodSquash,
+			executor:   &stubGitHubExecutor{},
+			verify: func(testInstance *testing.T, executor *stubGitHubExecutor) {
+				require.Len(testInstance, executor.recordedDetails, 1)
+				require.Equal(testInstance, []string{
+					"pr",
+					"merge",
+					testPullRequestHeadConstant,
+					"--repo",
+					testRepositoryIdentifierConstant,
+					"--auto",
+					"--squash",
+				}, executor.recordedDetails[0].Arguments)
+			},
+		},
+		{
+			name:       testAutoMergeCommandFailureCaseNameConstant,
+			repository: testRepositoryIdentifierConstant,
+			head:       testPullRequestHeadConstant,
+			method:     githubcli.PullRequestMergeMethodMerge,
+			executor: &stubGitHubExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, execshell.CommandFailedError{Command: execshell.ShellCommand{Name: execshell.CommandGitHub}, Result: execshell.ExecutionResult{ExitCode: 1}}
+			}},
+			expectError: true,
+			errorType:   githubcli.OperationError{},
+		},
+		{
+			name:        testAutoMergeMethodValidationCaseNameConstant,
+			repository:  testRepositoryIdentifierConstant,
+			head:        testPullRequestHeadConstant,
+			method:      githubcli.PullRequestMergeMethod("fast-forward"),
+			executor:    &stubGitHubExecutor{},
+			expectError: true,
+			errorType:   githubcli.InvalidInputError{},
+		},
+		{
+			name:        testAutoMergeValidationCaseNameConstant,
+			method:      githubcli.PullRequestMergeMethodRebase,
+			executor:    &stubGitHubExecutor{},
+			expectError: true,
+			errorType:   githubcli.InvalidInputError{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			client, creationError := githubcli.NewClient(testCase.executor)
+			require.NoError(testInstance, creationError)
+
+			executionError := client.EnablePullRequestAutoMerge(context.Background(), testCase.repository, testCase.head, testCase.method)
+			if testCase.expectError {
+				require.Error(testInstance, executionError)
+				require.IsType(testInstance, testCase.errorType, executionError)
+			} else {
+				require.NoError(testInstance, executionError)
+				require.NotNil(testInstance, testCase.verify)
+				testCase.verify(testInstance, testCase.executor)
+			}
+		})
+	}
+}
+
 func TestCheckBranchProtection(testInstance *testing.T) {
 	testCases := []struct {
 		name              string
@@ -826,3 +956,67 @@ func TestCheckBranchProtection(testInstance *testing.T) {
 		})
 	}
 }
+
+func TestGetRepositoryStatus(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		repository     string
+		executor       *stubGitHubExecutor
+		expectedStatus githubcli.RepositoryStatus
+		expectError    bool
+		errorType      any
+	}{
+		{
+			name:       testRepositoryStatusSuccessCaseNameConstant,
+			repository: testRepositoryIdentifierConstant,
+			executor: &stubGitHubExecutor{executeFunc: func(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				if details.Arguments[1] != "repos/"+testRepositoryIdentifierConstant {
+					return execshell.ExecutionResult{}, fmt.Errorf("unexpected endpoint %s", details.Arguments[1])
+				}
+				return execshell.ExecutionResult{StandardOutput: `{"archived":true,"disabled":false,"visibility":"private","default_branch":"main"}`}, nil
+			}},
+			expectedStatus: githubcli.RepositoryStatus{Archived: true, Visibility: "private", DefaultBranch: testBaseBranchConstant},
+		},
+		{
+			name:       testRepositoryStatusDecodeFailureCaseNameConstant,
+			repository: testRepositoryIdentifierConstant,
+			executor: &stubGitHubExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: "not-json"}, nil
+			}},
+			expectError: true,
+			errorType:   githubcli.ResponseDecodingError{},
+		},
+		{
+			name:       testRepositoryStatusCommandFailureCaseNameConstant,
+			repository: testRepositoryIdentifierConstant,
+			executor: &stubGitHubExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, execshell.CommandFailedError{Command: execshell.ShellCommand{Name: execshell.CommandGitHub}, Result: execshell.ExecutionResult{ExitCode: 1, StandardError: testHTTPNotFoundStandardErrorMessageConstant}}
+			}},
+			expectError: true,
+			errorType:   githubcli.OperationError{},
+		},
+		{
+			name:        testRepositoryStatusValidationCaseNameConstant,
+			repository:  " ",
+			executor:    &stubGitHubExecutor{},
+			expectError: true,
+			errorType:   githubcli.InvalidInputError{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			client, creationError := githubcli.NewClient(testCase.executor)
+			require.NoError(testInstance, creationError)
+
+			status, statusError := client.GetRepositoryStatus(context.Background(), testCase.repository)
+			if testCase.expectError {
+				require.Error(testInstance, statusError)
+				require.IsType(testInstance, testCase.errorType, statusError)
+			} else {
+				require.NoError(testInstance, statusError)
+				require.Equal(testInstance, testCase.expectedStatus, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyemirov/gix/internal/commitsign"
+	"github.com/tyemirov/gix/internal/execshell"
+)
+
+const (
+	commitSigningMakeTreeSubcommand       = "mktree"
+	commitSigningCommitTreeSubcommand     = "commit-tree"
+	commitSigningCatFileSubcommand        = "cat-file"
+	commitSigningCommitObjectType         = "commit"
+	commitSigningSignFlag                 = "-S"
+	commitSigningMessageFlag              = "-m"
+	commitSigningBoolFlag                 = "--bool"
+	commitSigningCommitSignKey            = "commit.gpgsign"
+	commitSigningPreflightMessage         = "gix commit signing preflight"
+	commitSigningSignatureHeaderPrefix    = "gpgsig"
+	commitSigningPreflightErrorTemplate   = "commit signing preflight failed in %s: %w"
+	commitSigningUnsignedMessage          = "git produced an unsigned commit"
+	commitSigningGitConfigDisabledMessage = "signing.require_signatures is set but signing.mode is none and Git's commit.gpgsign is not enabled"
+)
+
+// ErrCommitSigningUnavailable reports that a repository cannot produce signed commits with the configured signing.
+var ErrCommitSigningUnavailable = errors.New("commit signing unavailable")
+
+// VerifyCommitSigning checks that Git can sign commits in the repository before a command mutates it.
+// It signs a throwaway commit object for the empty tree with the signing configuration attached to ctx;
+// the object is never referenced and is removed by the next garbage collection.
+// Nothing is checked when the context neither configures signing nor requires signatures.
+func VerifyCommitSigning(ctx context.Context, executor GitCommandExecutor, repositoryPath string) error {
+	configuration := commitsign.FromContext(ctx)
+	if !configuration.RequiresPreflight() {
+		return nil
+	}
+	if verifyErr := verifyCommitSigning(ctx, executor, repositoryPath, configuration); verifyErr != nil {
+		return fmt.Errorf(commitSigningPreflightErrorTemplate, repositoryPath, verifyErr)
+	}
+	return nil
+}
+
+func verifyCommitSigning(ctx context.Context, executor GitCommandExecutor, repositoryPath string, configuration commitsign.Configuration) error {
+	if !configuration.Signs() {
+		enabled, enabledErr := gitConfigEnabled(ctx, executor, repositoryPath, commitSigningCommitSignKey)
+		if enabledErr != nil {
+			return enabledErr
+		}
+		if !enabled {
+			return fmt.Errorf("%w: %s", ErrCommitSigningUnavailable, commitSigningGitConfigDisabledMessage)
+		}
+	}
+
+	treeResult, treeErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments:        []string{commitSigningMakeTreeSubcommand},
+		WorkingDirectory: repositoryPath,
+	})
+	if treeErr != nil {
+		return treeErr
+	}
+	commitResult, commitErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments: []string{
+			commitSigningCommitTreeSubcommand,
+			commitSigningSignFlag,
+			commitSigningMessageFlag,
+			commitSigningPreflightMessage,
+			strings.TrimSpace(treeResult.StandardOutput),
+		},
+		WorkingDirectory: repositoryPath,
+	})
+	if commitErr != nil {
+		return errors.Join(ErrCommitSigningUnavailable, commitErr)
+	}
+	objectResult, objectErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments: []string{
+			commitSigningCatFileSubcommand,
+			commitSigningCommitObjectType,
+			strings.TrimSpace(commitResult.StandardOutput),
+		},
+		WorkingDirectory: repositoryPath,
+	})
+	if objectErr != nil {
+		return objectErr
+	}
+	for _, line := range strings.Split(objectResult.StandardOutput, "\n") {
+		if len(line) == 0 {
+			break
+		}
+		if strings.HasPrefix(line, commitSigningSignatureHeaderPrefix) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrCommitSigningUnavailable, commitSigningUnsignedMessage)
+}
+
+func gitConfigEnabled(ctx context.Context, executor GitCommandExecutor, repositoryPath string, key string) (bool, error) {
+	result, configErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments:        []string{branchReviewBaseConfigSubcommand, commitSigningBoolFlag, key},
+		WorkingDirectory: repositoryPath,
+	})
+	if configErr == nil {
+		return strings.TrimSpace(result.StandardOutput) == "true", nil
+	}
+	var commandFailure execshell.CommandFailedError
+	if errors.As(configErr, &commandFailure) && commandFailure.Result.ExitCode == 1 {
+		return false, nil
+	}
+	return false, configErr
+}
@@ -0,0 +1,100 @@
+package gitrepo_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/commitsign"
+	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/gitrepo"
+)
+
+func TestVerifyCommitSigning(testInstance *testing.T) {
+	const (
+		emptyTree       = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+		preflightCommit = "1111111111111111111111111111111111111111"
+	)
+	signedObject := "tree " + emptyTree + "\nauthor A <a@example.com> 0 +0000\ncommitter A <a@example.com> 0 +0000\ngpgsig -----BEGIN SSH SIGNATURE-----\n -----END SSH SIGNATURE-----\n\ngix commit signing preflight\n"
+	unsignedObject := "tree " + emptyTree + "\nauthor A <a@example.com> 0 +0000\ncommitter A <a@example.com> 0 +0000\n\ngpgsig mentioned only in the message\n"
+
+	testCases := []struct {
+		name          string
+		configuration commitsign.Configuration
+		commitSign    string
+		object        string
+		expectedCalls []string
+		expectError   bool
+	}{
+		{
+			name:          "nothing configured",
+			configuration: commitsign.Configuration{Mode: commitsign.ModeNone},
+		},
+		{
+			name:          "ssh signature present",
+			configuration: commitsign.Configuration{Mode: commitsign.ModeSSH, Key: "/keys/id_ed25519.pub"},
+			object:        signedObject,
+			expectedCalls: []string{"mktree", "commit-tree -S -m gix commit signing preflight " + emptyTree, "cat-file commit " + preflightCommit},
+		},
+		{
+			name:          "unsigned object",
+			configuration: commitsign.Configuration{Mode: commitsign.ModeGPG},
+			object:        unsignedObject,
+			expectedCalls: []string{"mktree", "commit-tree -S -m gix commit signing preflight " + emptyTree, "cat-file commit " + preflightCommit},
+			expectError:   true,
+		},
+		{
+			name:          "required with git signing enabled",
+			configuration: commitsign.Configuration{Mode: commitsign.ModeNone, RequireSignatures: true},
+			commitSign:    "true",
+			object:        signedObject,
+			expectedCalls: []string{"config --bool commit.gpgsign", "mktree", "commit-tree -S -m gix commit signing preflight " + emptyTree, "cat-file commit " + preflightCommit},
+		},
+		{
+			name:          "required with git signing disabled",
+			configuration: commitsign.Configuration{Mode: commitsign.ModeNone, RequireSignatures: true},
+			expectedCalls: []string{"config --bool commit.gpgsign"},
+			expectError:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			executor := &stubGitExecutor{executeFunc: func(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				switch details.Arguments[0] {
+				case "config":
+					if testCase.commitSign == "" {
+						return execshell.ExecutionResult{}, execshell.CommandFailedError{Result: execshell.ExecutionResult{ExitCode: 1}}
+					}
+					return execshell.ExecutionResult{StandardOutput: testCase.commitSign + "\n"}, nil
+				case "mktree":
+					return execshell.ExecutionResult{StandardOutput: emptyTree + "\n"}, nil
+				case "commit-tree":
+					return execshell.ExecutionResult{StandardOutput: preflightCommit + "\n"}, nil
+				default:
+					return execshell.ExecutionResult{StandardOutput: testCase.object}, nil
+				}
+			}}
+
+			verifyErr := gitrepo.VerifyCommitSigning(commitsign.WithConfiguration(context.Background(), testCase.configuration), executor, testRepositoryPathConstant)
+
+			if testCase.expectError {
+				require.ErrorIs(testInstance, verifyErr, gitrepo.ErrCommitSigningUnavailable)
+				require.Contains(testInstance, verifyErr.Error(), testRepositoryPathConstant)
+			} else {
+				require.NoError(testInstance, verifyErr)
+			}
+			calls := make([]string, 0, len(executor.recordedDetails))
+			for _, details := range executor.recordedDetails {
+				calls = append(calls, strings.Join(details.Arguments, " "))
+			}
+			if len(testCase.expectedCalls) == 0 {
+				require.Empty(testInstance, calls)
+				return
+			}
+			require.Equal(testInstance, testCase.expectedCalls, calls)
+		})
+	}
+}
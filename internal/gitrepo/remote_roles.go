@@ -0,0 +1,108 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyemirov/gix/internal/execshell"
+)
+
+const (
+	// RemoteRoleUpstream marks the remote whose default branch sync merges from in a fork workflow.
+	RemoteRoleUpstream = "upstream"
+	// RemoteRoleOrigin marks the remote that receives pushed work branches in a fork workflow.
+	RemoteRoleOrigin = "origin"
+
+	remoteRoleKeyPrefix               = "remote."
+	remoteRoleKeySuffix               = ".gix-role"
+	remoteRoleKeyPattern              = `^remote\..*\.gix-role$`
+	remoteRoleConfigSubcommand        = "config"
+	remoteRoleLocalFlag               = "--local"
+	remoteRoleNoIncludesFlag          = "--no-includes"
+	remoteRoleGetRegexpFlag           = "--get-regexp"
+	remoteRoleUnsetAllFlag            = "--unset-all"
+	remoteRoleReadErrorTemplate       = "read remote roles: %w"
+	remoteRoleRecordErrorTemplate     = "record %s role for remote %q: %w"
+	remoteRoleUnsupportedRoleTemplate = "unsupported remote role %q"
+)
+
+// RemoteRoleKey returns the Git config key for a remote role.
+func RemoteRoleKey(remoteName string) string {
+	return remoteRoleKeyPrefix + strings.TrimSpace(remoteName) + remoteRoleKeySuffix
+}
+
+// RemoteRoles returns recorded roles keyed by role.
+func RemoteRoles(ctx context.Context, executor GitCommandExecutor, repositoryPath string) (map[string]string, error) {
+	result, configErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments: []string{
+			remoteRoleConfigSubcommand,
+			remoteRoleLocalFlag,
+			remoteRoleNoIncludesFlag,
+			remoteRoleGetRegexpFlag,
+			remoteRoleKeyPattern,
+		},
+		WorkingDirectory: repositoryPath,
+	})
+	roles := map[string]string{}
+	if configErr != nil {
+		var commandFailure execshell.CommandFailedError
+		if errors.As(configErr, &commandFailure) && commandFailure.Result.ExitCode == 1 {
+			return roles, nil
+		}
+		return nil, fmt.Errorf(remoteRoleReadErrorTemplate, configErr)
+	}
+	for _, line := range strings.Split(result.StandardOutput, "\n") {
+		key, role, found := strings.Cut(strings.TrimSpace(line), " ")
+		if !found {
+			continue
+		}
+		remoteName := strings.TrimSuffix(strings.TrimPrefix(key, remoteRoleKeyPrefix), remoteRoleKeySuffix)
+		role = strings.TrimSpace(role)
+		if remoteName == "" || role == "" {
+			continue
+		}
+		roles[role] = remoteName
+	}
+	return roles, nil
+}
+
+// RecordRemoteRole assigns a role to one remote and clears it from any other remote.
+func RecordRemoteRole(ctx context.Context, executor GitCommandExecutor, repositoryPath string, remoteName string, role string) error {
+	if role != RemoteRoleUpstream && role != RemoteRoleOrigin {
+		return fmt.Errorf(remoteRoleUnsupportedRoleTemplate, role)
+	}
+	roles, rolesErr := RemoteRoles(ctx, executor, repositoryPath)
+	if rolesErr != nil {
+		return rolesErr
+	}
+	if previousRemote, exists := roles[role]; exists && previousRemote != remoteName {
+		if _, unsetErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+			Arguments: []string{
+				remoteRoleConfigSubcommand,
+				remoteRoleLocalFlag,
+				remoteRoleNoIncludesFlag,
+				remoteRoleUnsetAllFlag,
+				RemoteRoleKey(previousRemote),
+			},
+			WorkingDirectory: repositoryPath,
+		}); unsetErr != nil {
+			return fmt.Errorf(remoteRoleRecordErrorTemplate, role, remoteName, unsetErr)
+		}
+	}
+	_, configErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments: []string{
+			remoteRoleConfigSubcommand,
+			remoteRoleLocalFlag,
+			remoteRoleNoIncludesFlag,
+			RemoteRoleKey(remoteName),
+			role,
+		},
+		WorkingDirectory: repositoryPath,
+	})
+	if configErr != nil {
+		return fmt.Errorf(remoteRoleRecordErrorTemplate, role, remoteName, configErr)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyemirov/gix/internal/execshell"
+)
+
+const (
+	branchSyncedRemoteKeyTemplate         = "branch.%s.gix-synced-remote"
+	branchSyncedRemoteReadErrorTemplate   = "read last-synced remote commit for %q: %w"
+	branchSyncedRemoteRecordErrorTemplate = "record last-synced remote commit for %q: %w"
+)
+
+// BranchSyncedRemoteKey returns the Git config key holding the remote commit a branch was last synced with.
+func BranchSyncedRemoteKey(branchName string) string {
+	return fmt.Sprintf(branchSyncedRemoteKeyTemplate, strings.TrimSpace(branchName))
+}
+
+// BranchSyncedRemote returns the recorded last-synced remote commit and whether the key exists.
+func BranchSyncedRemote(ctx context.Context, executor GitCommandExecutor, repositoryPath string, branchName string) (string, bool, error) {
+	result, configErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments: []string{
+			branchReviewBaseConfigSubcommand,
+			branchReviewBaseLocalFlag,
+			branchReviewBaseNoIncludesFlag,
+			branchReviewBaseGetFlag,
+			BranchSyncedRemoteKey(branchName),
+		},
+		WorkingDirectory: repositoryPath,
+	})
+	if configErr == nil {
+		return strings.TrimSpace(result.StandardOutput), true, nil
+	}
+	var commandFailure execshell.CommandFailedError
+	if errors.As(configErr, &commandFailure) && commandFailure.Result.ExitCode == 1 {
+		return "", false, nil
+	}
+	return "", false, fmt.Errorf(branchSyncedRemoteReadErrorTemplate, branchName, configErr)
+}
+
+// RecordBranchSyncedRemote records the remote commit a branch was synced with.
+func RecordBranchSyncedRemote(ctx context.Context, executor GitCommandExecutor, repositoryPath string, branchName string, commit string) error {
+	_, configErr := executor.ExecuteGit(ctx, execshell.CommandDetails{
+		Arguments: []string{
+			branchReviewBaseConfigSubcommand,
+			branchReviewBaseLocalFlag,
+			branchReviewBaseNoIncludesFlag,
+			BranchSyncedRemoteKey(branchName),
+			commit,
+		},
+		WorkingDirectory: repositoryPath,
+	})
+	if configErr != nil {
+		return fmt.Errorf(branchSyncedRemoteRecordErrorTemplate, branchName, configErr)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package llmclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tyemirov/utils/llm"
+)
+
+const (
+	cassetteSchemaVersion            = "gix.llm.cassette/v1"
+	cassetteFileExtension            = ".json"
+	cassetteDirectoryPermissions     = 0o755
+	cassetteFilePermissions          = 0o600
+	cassetteDirectoryRequiredMessage = "llm cassette_directory is required for %s transport"
+	cassetteMissingTemplate          = "llm replay cassette %s not found in %s; record it with llm.transport: record"
+	cassetteReadTemplate             = "read llm replay cassette %s: %w"
+	cassetteDecodeTemplate           = "decode llm replay cassette %s: %w"
+	cassetteSchemaTemplate           = "llm replay cassette %s has unsupported schema %q"
+	cassetteWriteTemplate            = "record llm cassette %s: %w"
+)
+
+// CassetteMode selects whether chat requests reach live connections or a recorded cassette.
+type CassetteMode string
+
+const (
+	// CassetteModeLive sends every request to the prioritized connections.
+	CassetteModeLive CassetteMode = "live"
+	// CassetteModeRecord sends requests to the prioritized connections and stores each successful response.
+	CassetteModeRecord CassetteMode = "record"
+	// CassetteModeReplay answers requests from stored responses without contacting any connection.
+	CassetteModeReplay CassetteMode = "replay"
+)
+
+// CassetteConfig selects the recorded model stand-in shared by every prioritized client.
+type CassetteConfig struct {
+	Mode      CassetteMode
+	Directory string
+}
+
+// CassetteEntry stores one recorded request envelope and the response returned for it.
+type CassetteEntry struct {
+	Schema         string              `json:"schema"`
+	Key            string              `json:"key"`
+	Messages       []llm.Message       `json:"messages"`
+	ResponseFormat *llm.ResponseFormat `json:"response_format,omitempty"`
+	Response       string              `json:"response"`
+}
+
+type cassetteRequestEnvelope struct {
+	Messages       []llm.Message       `json:"messages"`
+	ResponseFormat *llm.ResponseFormat `json:"response_format,omitempty"`
+}
+
+type cassetteRecordingClient struct {
+	client    llm.ChatClient
+	directory string
+}
+
+type cassetteReplayClient struct {
+	directory string
+}
+
+// ParseCassetteMode validates the public llm.transport value. An empty value selects live requests.
+func ParseCassetteMode(rawValue string) (CassetteMode, error) {
+	trimmedValue := strings.ToLower(strings.TrimSpace(rawValue))
+	if trimmedValue == "" {
+		return CassetteModeLive, nil
+	}
+	mode := CassetteMode(trimmedValue)
+	switch mode {
+	case CassetteModeLive, CassetteModeRecord, CassetteModeReplay:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported llm transport %q; expected live, record, or replay", strings.TrimSpace(rawValue))
+	}
+}
+
+// CassetteRequestKey hashes the request envelope that identifies one recorded response.
+// Model names and completion budgets are excluded so a cassette survives connection changes.
+func CassetteRequestKey(request llm.ChatRequest) string {
+	envelope, _ := json.Marshal(cassetteRequestEnvelope{
+		Messages:       request.Messages,
+		ResponseFormat: request.ResponseFormat,
+	})
+	digest := sha256.Sum256(envelope)
+	return hex.EncodeToString(digest[:])
+}
+
+func (configuration CassetteConfig) normalized() CassetteConfig {
+	if configuration.Mode == "" {
+		configuration.Mode = CassetteModeLive
+	}
+	configuration.Directory = strings.TrimSpace(configuration.Directory)
+	return configuration
+}
+
+func (configuration CassetteConfig) validate() error {
+	normalizedConfiguration := configuration.normalized()
+	if _, modeError := ParseCassetteMode(string(normalizedConfiguration.Mode)); modeError != nil {
+		return modeError
+	}
+	if normalizedConfiguration.Mode != CassetteModeLive && normalizedConfiguration.Directory == "" {
+		return fmt.Errorf(cassetteDirectoryRequiredMessage, normalizedConfiguration.Mode)
+	}
+	return nil
+}
+
+func (configuration CassetteConfig) entryPath(key string) string {
+	return filepath.Join(configuration.Directory, key+cassetteFileExtension)
+}
+
+// NewCassetteReplayClient answers chat requests only from entries stored in directory.
+func NewCassetteReplayClient(directory string) llm.ChatClient {
+	return cassetteReplayClient{directory: strings.TrimSpace(directory)}
+}
+
+// NewCassetteRecordingClient forwards chat requests to client and stores every successful response in directory.
+func NewCassetteRecordingClient(client llm.ChatClient, directory string) llm.ChatClient {
+	return cassetteRecordingClient{client: client, directory: strings.TrimSpace(directory)}
+}
+
+func (client cassetteReplayClient) Chat(ctx context.Context, request llm.ChatRequest) (string, error) {
+	if contextError := ctx.Err(); contextError != nil {
+		return "", contextError
+	}
+	key := CassetteRequestKey(request)
+	entryPath := CassetteConfig{Directory: client.directory}.entryPath(key)
+	contents, readError := os.ReadFile(entryPath)
+	if readError != nil {
+		if errors.Is(readError, fs.ErrNotExist) {
+			return "", fmt.Errorf(cassetteMissingTemplate, key, client.directory)
+		}
+		return "", fmt.Errorf(cassetteReadTemplate, key, readError)
+	}
+	var entry CassetteEntry
+	if decodeError := json.Unmarshal(contents, &entry); decodeError != nil {
+		return "", fmt.Errorf(cassetteDecodeTemplate, key, decodeError)
+	}
+	if entry.Schema != cassetteSchemaVersion {
+		return "", fmt.Errorf(cassetteSchemaTemplate, key, entry.Schema)
+	}
+	return entry.Response, nil
+}
+
+func (client cassetteRecordingClient) Chat(ctx context.Context, request llm.ChatRequest) (string, error) {
+	response, responseError := client.client.Chat(ctx, request)
+	if responseError != nil {
+		return "", responseError
+	}
+	key := CassetteRequestKey(request)
+	entry := CassetteEntry{
+		Schema:         cassetteSchemaVersion,
+		Key:            key,
+		Messages:       request.Messages,
+		ResponseFormat: request.ResponseFormat,
+		Response:       response,
+	}
+	if writeError := writeCassetteEntry(CassetteConfig{Directory: client.directory}.entryPath(key), entry); writeError != nil {
+		return "", fmt.Errorf(cassetteWriteTemplate, key, writeError)
+	}
+	return response, nil
+}
+
+func writeCassetteEntry(entryPath string, entry CassetteEntry) error {
+	contents, encodeError := json.MarshalIndent(entry, "", "  ")
+	if encodeError != nil {
+		return encodeError
+	}
+	if directoryError := os.MkdirAll(filepath.Dir(entryPath), cassetteDirectoryPermissions); directoryError != nil {
+		return directoryError
+	}
+	temporaryFile, createError := os.CreateTemp(filepath.Dir(entryPath), ".cassette-*")
+	if createError != nil {
+		return createError
+	}
+	temporaryPath := temporaryFile.Name()
+	_, writeError := temporaryFile.Write(append(contents, '\n'))
+	closeError := temporaryFile.Close()
+	if writeError == nil {
+		writeError = closeError
+	}
+	if writeError == nil {
+		writeError = os.Chmod(temporaryPath, cassetteFilePermissions)
+	}
+	if writeError == nil {
+		writeError = os.Rename(temporaryPath, entryPath)
+	}
+	if writeError != nil {
+		_ = os.Remove(temporaryPath)
+	}
+	return writeError
+}
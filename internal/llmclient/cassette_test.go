@@ -0,0 +1,141 @@
+package llmclient
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tyemirov/utils/llm"
+)
+
+func cassetteTestProfiles(mode CassetteMode, directory string) ConnectionProfiles {
+	return ConnectionProfiles{
+		OpenAI: OpenAIConnectionProfile{
+			Priority:   2,
+			BaseURL:    "https://api.openai.com/v1",
+			Credential: "openai-secret",
+			Model:      "gpt-5.6-terra",
+		},
+		LLMProxy: LLMProxyConnectionProfile{
+			Priority:   1,
+			BaseURL:    "https://llm-proxy.example",
+			Credential: "proxy-secret",
+			Provider:   "meta",
+		},
+		Cassette: CassetteConfig{Mode: mode, Directory: directory},
+	}
+}
+
+func cassetteTestRequest() llm.ChatRequest {
+	return llm.ChatRequest{
+		Messages: []llm.Message{
+			{Role: "system", Content: "Resolve the conflict region."},
+			{Role: "user", Content: "BASE:\nalpha\nOURS:\nbeta\nTHEIRS:\ngamma\nCANDIDATE:\nbeta\ngamma"},
+		},
+		MaxTokens: 512,
+	}
+}
+
+func TestCassetteRecordThenReplayReturnsRecordedResponse(t *testing.T) {
+	cassetteDirectory := filepath.Join(t.TempDir(), "cassettes")
+	liveClient := &scriptedChatClient{
+		responses:      []string{"APPROVE"},
+		responseErrors: []error{nil},
+	}
+	recordingClient, recordingError := NewPrioritizedFactory(
+		cassetteTestProfiles(CassetteModeRecord, cassetteDirectory),
+		LLMProxySelection{},
+		RuntimeConfig{},
+		func(Config) (llm.ChatClient, error) { return liveClient, nil },
+	)
+	require.NoError(t, recordingError)
+
+	recordedResponse, recordedError := recordingClient.Chat(context.Background(), cassetteTestRequest())
+	require.NoError(t, recordedError)
+	require.Equal(t, "APPROVE", recordedResponse)
+	require.Len(t, liveClient.requests, 1)
+
+	entryContents, readError := os.ReadFile(filepath.Join(cassetteDirectory, CassetteRequestKey(cassetteTestRequest())+cassetteFileExtension))
+	require.NoError(t, readError)
+	var entry CassetteEntry
+	require.NoError(t, json.Unmarshal(entryContents, &entry))
+	require.Equal(t, cassetteSchemaVersion, entry.Schema)
+	require.Equal(t, cassetteTestRequest().Messages, entry.Messages)
+
+	replayProfiles := cassetteTestProfiles(CassetteModeReplay, cassetteDirectory)
+	replayProfiles.OpenAI.Credential = ""
+	replayProfiles.LLMProxy.Credential = ""
+	require.NoError(t, replayProfiles.Validate())
+	replayClient, replayError := NewPrioritizedFactory(
+		replayProfiles,
+		LLMProxySelection{},
+		RuntimeConfig{},
+		func(Config) (llm.ChatClient, error) {
+			t.Fatal("replay must not construct live connections")
+			return nil, nil
+		},
+	)
+	require.NoError(t, replayError)
+
+	replayRequest := cassetteTestRequest()
+	replayRequest.MaxTokens = 4_800
+	replayRequest.Model = "another-model"
+	replayedResponse, replayedError := replayClient.Chat(context.Background(), replayRequest)
+	require.NoError(t, replayedError)
+	require.Equal(t, "APPROVE", replayedResponse)
+}
+
+func TestCassetteReplayReportsMissingEntry(t *testing.T) {
+	cassetteDirectory := t.TempDir()
+	client := NewCassetteReplayClient(cassetteDirectory)
+
+	response, responseError := client.Chat(context.Background(), cassetteTestRequest())
+
+	require.Empty(t, response)
+	require.ErrorContains(t, responseError, CassetteRequestKey(cassetteTestRequest()))
+	require.ErrorContains(t, responseError, "record it with llm.transport: record")
+}
+
+func TestCassetteRecordingDoesNotStoreFailedResponses(t *testing.T) {
+	cassetteDirectory := t.TempDir()
+	client := NewCassetteRecordingClient(failingChatClient{err: os.ErrDeadlineExceeded}, cassetteDirectory)
+
+	_, responseError := client.Chat(context.Background(), cassetteTestRequest())
+
+	require.ErrorIs(t, responseError, os.ErrDeadlineExceeded)
+	entries, readError := os.ReadDir(cassetteDirectory)
+	require.NoError(t, readError)
+	require.Empty(t, entries)
+}
+
+func TestCassetteConfigValidation(t *testing.T) {
+	mode, modeError := ParseCassetteMode("")
+	require.NoError(t, modeError)
+	require.Equal(t, CassetteModeLive, mode)
+
+	_, unsupportedError := ParseCassetteMode("llm_proxy")
+	require.EqualError(t, unsupportedError, `unsupported llm transport "llm_proxy"; expected live, record, or replay`)
+
+	missingDirectoryProfiles := cassetteTestProfiles(CassetteModeReplay, " ")
+	require.EqualError(t, missingDirectoryProfiles.Validate(), "llm cassette_directory is required for replay transport")
+
+	liveProfiles := cassetteTestProfiles(CassetteModeLive, "")
+	liveProfiles.OpenAI.Credential = ""
+	liveProfiles.LLMProxy.Credential = ""
+	require.EqualError(t, liveProfiles.Validate(), connectionRequiredMessage)
+}
+
+func TestCassetteRequestKeyIgnoresModelAndBudget(t *testing.T) {
+	request := cassetteTestRequest()
+	changedBudget := cassetteTestRequest()
+	changedBudget.MaxTokens = 1
+	changedBudget.Model = "different"
+	changedContent := cassetteTestRequest()
+	changedContent.Messages[1].Content += "\n"
+
+	require.Equal(t, CassetteRequestKey(request), CassetteRequestKey(changedBudget))
+	require.NotEqual(t, CassetteRequestKey(request), CassetteRequestKey(changedContent))
+}
@@ -65,6 +65,7 @@ type LLMProxyConnectionProfile struct {
 type ConnectionProfiles struct {
 	OpenAI   OpenAIConnectionProfile   `yaml:"openai"`
 	LLMProxy LLMProxyConnectionProfile `yaml:"llm_proxy"`
+	Cassette CassetteConfig            `yaml:"-"`
 }
 
 // LLMProxySelection overrides the configured llm-proxy upstream for one operation.
@@ -156,7 +157,14 @@ func NormalizeProvider(rawProvider string) (string, error) {
 }
 
 // Validate checks the complete connection schema before command execution.
+// Replay mode never contacts a connection, so it requires only the cassette directory.
 func (profiles ConnectionProfiles) Validate() error {
+	if cassetteError := profiles.Cassette.validate(); cassetteError != nil {
+		return cassetteError
+	}
+	if profiles.Cassette.normalized().Mode == CassetteModeReplay {
+		return nil
+	}
 	_, configurationError := profiles.orderedConfigurations(LLMProxySelection{}, RuntimeConfig{})
 	return configurationError
 }
@@ -168,6 +176,13 @@ func NewPrioritizedFactory(
 	runtimeConfiguration RuntimeConfig,
 	clientFactory ClientFactory,
 ) (llm.ChatClient, error) {
+	if cassetteError := profiles.Cassette.validate(); cassetteError != nil {
+		return nil, cassetteError
+	}
+	cassette := profiles.Cassette.normalized()
+	if cassette.Mode == CassetteModeReplay {
+		return NewCassetteReplayClient(cassette.Directory), nil
+	}
 	candidates, configurationError := profiles.orderedConfigurations(selection, runtimeConfiguration)
 	if configurationError != nil {
 		return nil, configurationError
@@ -184,6 +199,9 @@ func NewPrioritizedFactory(
 		}
 		clients = append(clients, prioritizedClientCandidate{name: candidate.name, client: client})
 	}
+	if cassette.Mode == CassetteModeRecord {
+		return NewCassetteRecordingClient(&prioritizedChatClient{candidates: clients}, cassette.Directory), nil
+	}
 	return &prioritizedChatClient{candidates: clients}, nil
 }
 
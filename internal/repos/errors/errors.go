@@ -190,6 +190,8 @@ var (
 	ErrHistoryGitIgnoreUpdateFailed Sentinel = "history_gitignore_update_failed"
 	// ErrHistoryInspectionFailed indicates repository history inspection failed prior to rewrite.
 	ErrHistoryInspectionFailed Sentinel = "history_inspection_failed"
+	// ErrCommitSigningFailed indicates the commit signing preflight failed or signed commits cannot be produced.
+	ErrCommitSigningFailed Sentinel = "commit_signing_failed"
 	// ErrGitRepositoryMissing indicates required git metadata was not found.
 	ErrGitRepositoryMissing Sentinel = "git_repository_missing"
 	// ErrNamespaceRewriteFailed indicates namespace rewrite failed.
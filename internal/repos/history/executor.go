@@ -9,7 +9,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/tyemirov/gix/internal/commitsign"
 	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/gitrepo"
 	repoerrors "github.com/tyemirov/gix/internal/repos/errors"
 	"github.com/tyemirov/gix/internal/repos/shared"
 )
@@ -20,6 +22,7 @@ const (
 	skipMessageTemplate            = "HISTORY-SKIP: %s (no matching history for %s)\n"
 	successMessageTemplate         = "HISTORY-PURGE: %s removed=%s remote=%s push=%t restore=%t push_missing=%t\n"
 	pathsRequiredErrorMessage      = "history purge requires at least one path"
+	signaturesRequiredMessage      = "git-filter-repo rewrites commits without their signatures; history purge is refused while signing.require_signatures is set"
 	gitFilterRepoSubcommand        = "filter-repo"
 	gitRemoteSubcommand            = "remote"
 	gitRemoteAddSubcommand         = "add"
@@ -86,6 +89,12 @@ func (executor Executor) Execute(ctx context.Context, options Options) error {
 	}
 
 	repositoryPath := options.repositoryPathString()
+	if commitsign.FromContext(ctx).RequireSignatures {
+		return repoerrors.WrapMessage(repoerrors.OperationHistoryPurge, repositoryPath, repoerrors.ErrCommitSigningFailed, signaturesRequiredMessage)
+	}
+	if err := gitrepo.VerifyCommitSigning(ctx, executor.dependencies.GitExecutor, repositoryPath); err != nil {
+		return repoerrors.Wrap(repoerrors.OperationHistoryPurge, repositoryPath, repoerrors.ErrCommitSigningFailed, err)
+	}
 	paths := options.pathStrings()
 	requestedRemote := ""
 	if options.remoteName != nil {
@@ -7,7 +7,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/tyemirov/gix/internal/commitsign"
 	"github.com/tyemirov/gix/internal/execshell"
+	repoerrors "github.com/tyemirov/gix/internal/repos/errors"
 	"github.com/tyemirov/gix/internal/repos/filesystem"
 	"github.com/tyemirov/gix/internal/repos/history"
 	"github.com/tyemirov/gix/internal/repos/shared"
@@ -202,3 +204,23 @@ func TestExecutorFailsWhenFetchingRemoteRefsFails(testInstance *testing.T) {
 	executionError := service.Execute(context.Background(), options)
 	require.Error(testInstance, executionError)
 }
+
+func TestExecutorRefusesWhenSignaturesRequired(testInstance *testing.T) {
+	executor := newScriptedGitExecutor()
+	service := history.NewExecutor(history.Dependencies{
+		GitExecutor:       executor,
+		RepositoryManager: stubRepositoryManager{remoteURL: "https://github.com/example/repo.git"},
+		FileSystem:        filesystem.OSFileSystem{},
+		Output:            &strings.Builder{},
+	})
+
+	repositoryPath, repositoryPathError := shared.NewRepositoryPath(testInstance.TempDir())
+	require.NoError(testInstance, repositoryPathError)
+	options := buildHistoryOptions(testInstance, repositoryPath, []string{"secrets.txt"}, nil, true, false, false)
+
+	executionContext := commitsign.WithConfiguration(context.Background(), commitsign.Configuration{Mode: commitsign.ModeSSH, Key: "/keys/id_ed25519.pub", RequireSignatures: true})
+	executionError := service.Execute(executionContext, options)
+	require.ErrorIs(testInstance, executionError, repoerrors.ErrCommitSigningFailed)
+	require.Contains(testInstance, executionError.Error(), "signing.require_signatures")
+	require.Empty(testInstance, executor.commands)
+}
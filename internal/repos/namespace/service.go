@@ -17,6 +17,7 @@ import (
 	"golang.org/x/tools/imports"
 
 	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/gitrepo"
 	repoerrors "github.com/tyemirov/gix/internal/repos/errors"
 	"github.com/tyemirov/gix/internal/repos/shared"
 )
@@ -294,6 +295,10 @@ func (service *Service) applyRewrite(ctx context.Context, repositoryPath string,
 		return Result{}, repoerrors.WrapMessage(repoerrors.OperationNamespaceRewrite, repositoryPath, repoerrors.ErrDirtyWorktree, "working tree must be clean before namespace rewrite")
 	}
 
+	if err := gitrepo.VerifyCommitSigning(ctx, service.gitExecutor, repositoryPath); err != nil {
+		return Result{}, repoerrors.Wrap(repoerrors.OperationNamespaceRewrite, repositoryPath, repoerrors.ErrCommitSigningFailed, err)
+	}
+
 	branchName := service.buildBranchName(options.BranchPrefix)
 	if err := service.createBranch(ctx, repositoryPath, branchName); err != nil {
 		return Result{}, err
@@ -7,11 +7,16 @@ const (
 	EventCodeWorktreeAdopt            = "WORKTREE_ADOPT"
 	EventCodeSyncSwitchRollback       = "SYNC_SWITCH_ROLLBACK"
 	EventCodeSyncSwitchHandoff        = "SYNC_SWITCH_HANDOFF"
+	EventCodeSyncFork                 = "SYNC_FORK"
+	EventCodeSyncVerify               = "SYNC_VERIFY"
+	EventCodeSyncAutoMerge            = "SYNC_AUTO_MERGE"
+	EventCodeSyncForcePush            = "SYNC_FORCE_PUSH"
 	EventCodeMergeConflict            = "MERGE_CONFLICT"
 	EventCodeAIMergeResolution        = "AI_MERGE_RESOLUTION"
 	EventCodeAIMergeValidation        = "AI_MERGE_VALIDATION"
 	EventCodeAIMergeRollback          = "AI_MERGE_ROLLBACK"
 	EventCodeAIMergeHandoff           = "AI_MERGE_HANDOFF"
+	EventCodeAIMergeReport            = "AI_MERGE_REPORT"
 	EventCodeNamespacePlan            = "NAMESPACE_PLAN"
 	EventCodeNamespaceApply           = "NAMESPACE_APPLY"
 	EventCodeNamespaceSkip            = "NAMESPACE_SKIP"
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomically replaces path with contents by writing a temporary file in the same directory and renaming it
+// into place, so readers never observe a partially written file. The directory must already exist.
+func WriteFileAtomically(path string, contents []byte, permissions os.FileMode) error {
+	temporaryFile, temporaryError := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if temporaryError != nil {
+		return temporaryError
+	}
+	temporaryPath := temporaryFile.Name()
+	_, writeError := temporaryFile.Write(contents)
+	closeError := temporaryFile.Close()
+	if writeError == nil {
+		writeError = closeError
+	}
+	if writeError == nil {
+		writeError = os.Chmod(temporaryPath, permissions)
+	}
+	if writeError == nil {
+		writeError = os.Rename(temporaryPath, path)
+	}
+	if writeError != nil {
+		_ = os.Remove(temporaryPath)
+	}
+	return writeError
+}
@@ -0,0 +1,51 @@
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tyemirov/gix/internal/utils"
+)
+
+func TestWriteFileAtomically(t *testing.T) {
+	testCases := []struct {
+		name             string
+		existingContents []byte
+		permissions      os.FileMode
+	}{
+		{name: "creates_file", permissions: 0o600},
+		{name: "replaces_file", existingContents: []byte("old"), permissions: 0o644},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			directory := t.TempDir()
+			path := filepath.Join(directory, "state.json")
+			if testCase.existingContents != nil {
+				require.NoError(t, os.WriteFile(path, testCase.existingContents, 0o600))
+			}
+
+			require.NoError(t, utils.WriteFileAtomically(path, []byte("new"), testCase.permissions))
+
+			contents, readError := os.ReadFile(path)
+			require.NoError(t, readError)
+			require.Equal(t, "new", string(contents))
+			fileInfo, statError := os.Stat(path)
+			require.NoError(t, statError)
+			require.Equal(t, testCase.permissions, fileInfo.Mode().Perm())
+			entries, listError := os.ReadDir(directory)
+			require.NoError(t, listError)
+			require.Len(t, entries, 1)
+		})
+	}
+}
+
+func TestWriteFileAtomicallyFailsWithoutDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "state.json")
+	require.Error(t, utils.WriteFileAtomically(path, []byte("new"), 0o600))
+	_, statError := os.Stat(path)
+	require.ErrorIs(t, statError, os.ErrNotExist)
+}
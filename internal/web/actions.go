@@ -0,0 +1,338 @@
+package web
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tyemirov/gix/internal/utils"
+)
+
+// ActionQueueSchemaVersion identifies the persisted queue layout; files with another schema are ignored.
+const ActionQueueSchemaVersion = "gix.web-queue/v1"
+
+const (
+	actionQueueFileNameConstant             = "queue.json"
+	actionHistoryFileNameConstant           = "history.jsonl"
+	actionStateFilePermissionsConstant      = 0o600
+	actionStateDirectoryPermissionsConstant = 0o700
+	actionHistoryOutputLimitConstant        = 2048
+	actionHistoryOutputTruncatedConstant    = "…"
+	actionHistoryLineLimitConstant          = 1 << 20
+	actionQueueSaveErrorTemplateConstant    = "save audit queue %s: %w"
+	actionQueueLoadErrorTemplateConstant    = "load audit queue %s: %w"
+	actionHistoryWriteErrorTemplateConstant = "record action history %s: %w"
+	actionHistoryReadErrorTemplateConstant  = "read action history %s: %w"
+	missingActionStoreErrorConstant         = "missing action store"
+	missingActionStoreDirectoryConstant     = "action store directory is required"
+	invalidHistoryLimitErrorConstant        = "limit must be a non-negative integer"
+	invalidHistorySinceErrorConstant        = "since must be an RFC 3339 timestamp"
+	historyKindQueryParameterConstant       = "kind"
+	historyStatusQueryParameterConstant     = "status"
+	historyPathQueryParameterConstant       = "path"
+	historySinceQueryParameterConstant      = "since"
+	historyLimitQueryParameterConstant      = "limit"
+)
+
+// ActionStoreOptions configures an ActionStore.
+type ActionStoreOptions struct {
+	// Directory holds the queue file and the history log; it is created on first write.
+	Directory string
+	// Actor is recorded on every history entry, typically the operating system user.
+	Actor string
+	Clock func() time.Time
+}
+
+// ActionStore persists the review-before-apply queue and appends an action history log
+// so a browser refresh keeps pending changes and applied changes leave a record.
+type ActionStore struct {
+	queuePath   string
+	historyPath string
+	actor       string
+	clock       func() time.Time
+	mutex       sync.Mutex
+}
+
+type actionQueueDocument struct {
+	Schema    string              `json:"schema"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	Changes   []AuditQueuedChange `json:"changes"`
+}
+
+// NewActionStore constructs a store rooted at options.Directory. Nothing is written until the queue
+// is saved or an action is recorded.
+func NewActionStore(options ActionStoreOptions) (*ActionStore, error) {
+	directory := strings.TrimSpace(options.Directory)
+	if len(directory) == 0 {
+		return nil, errors.New(missingActionStoreDirectoryConstant)
+	}
+	clock := options.Clock
+	if clock == nil {
+		clock = time.Now
+	}
+	return &ActionStore{
+		queuePath:   filepath.Join(directory, actionQueueFileNameConstant),
+		historyPath: filepath.Join(directory, actionHistoryFileNameConstant),
+		actor:       strings.TrimSpace(options.Actor),
+		clock:       clock,
+	}, nil
+}
+
+// LoadQueue returns the persisted queue; a missing or foreign file yields an empty queue.
+func (store *ActionStore) LoadQueue() (AuditQueueState, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	contents, readError := os.ReadFile(store.queuePath)
+	if errors.Is(readError, fs.ErrNotExist) {
+		return AuditQueueState{Changes: []AuditQueuedChange{}}, nil
+	}
+	if readError != nil {
+		return AuditQueueState{Changes: []AuditQueuedChange{}}, fmt.Errorf(actionQueueLoadErrorTemplateConstant, store.queuePath, readError)
+	}
+	var document actionQueueDocument
+	if decodeError := json.Unmarshal(contents, &document); decodeError != nil || document.Schema != ActionQueueSchemaVersion {
+		return AuditQueueState{Changes: []AuditQueuedChange{}}, nil
+	}
+	changes := document.Changes
+	if changes == nil {
+		changes = []AuditQueuedChange{}
+	}
+	updatedAt := document.UpdatedAt
+	return AuditQueueState{Changes: changes, UpdatedAt: &updatedAt}, nil
+}
+
+// SaveQueue replaces the persisted queue. Folder deletion confirmations are dropped so a restored
+// delete must be confirmed again in the browser that applies it.
+func (store *ActionStore) SaveQueue(changes []AuditQueuedChange) (AuditQueueState, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	persisted := make([]AuditQueuedChange, 0, len(changes))
+	for _, change := range changes {
+		change.ConfirmDelete = false
+		persisted = append(persisted, change)
+	}
+	document := actionQueueDocument{Schema: ActionQueueSchemaVersion, UpdatedAt: store.clock().UTC(), Changes: persisted}
+	contents, encodeError := json.MarshalIndent(document, "", "  ")
+	if encodeError != nil {
+		return AuditQueueState{}, fmt.Errorf(actionQueueSaveErrorTemplateConstant, store.queuePath, encodeError)
+	}
+	if writeError := writeActionStateFile(store.queuePath, contents); writeError != nil {
+		return AuditQueueState{}, fmt.Errorf(actionQueueSaveErrorTemplateConstant, store.queuePath, writeError)
+	}
+	return AuditQueueState{Changes: persisted, UpdatedAt: &document.UpdatedAt}, nil
+}
+
+// RecordAuditResults appends one history entry per applied audit change.
+func (store *ActionStore) RecordAuditResults(results []AuditChangeApplyResult) error {
+	entries := make([]ActionHistoryEntry, 0, len(results))
+	for _, result := range results {
+		entries = append(entries, store.newHistoryEntry(string(result.Kind), result.Path, result.Status, result.Message, result.Error, result.Stdout, result.Stderr))
+	}
+	return store.appendHistory(entries)
+}
+
+// History returns the entries matching filter, newest first. Lines that fail to decode are skipped
+// so one damaged record does not hide the rest of the log.
+func (store *ActionStore) History(filter ActionHistoryFilter) (ActionHistory, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	historyFile, openError := os.Open(store.historyPath)
+	if errors.Is(openError, fs.ErrNotExist) {
+		return ActionHistory{Entries: []ActionHistoryEntry{}}, nil
+	}
+	if openError != nil {
+		return ActionHistory{Entries: []ActionHistoryEntry{}}, fmt.Errorf(actionHistoryReadErrorTemplateConstant, store.historyPath, openError)
+	}
+	defer historyFile.Close()
+
+	matches := []ActionHistoryEntry{}
+	scanner := bufio.NewScanner(historyFile)
+	scanner.Buffer(make([]byte, 0, 64*1024), actionHistoryLineLimitConstant)
+	for scanner.Scan() {
+		var entry ActionHistoryEntry
+		if decodeError := json.Unmarshal(scanner.Bytes(), &entry); decodeError != nil {
+			continue
+		}
+		if filter.matches(entry) {
+			matches = append(matches, entry)
+		}
+	}
+	if scanError := scanner.Err(); scanError != nil {
+		return ActionHistory{Entries: []ActionHistoryEntry{}}, fmt.Errorf(actionHistoryReadErrorTemplateConstant, store.historyPath, scanError)
+	}
+
+	// The log is append-only, so reversing file order lists the newest entries first.
+	slices.Reverse(matches)
+	total := len(matches)
+	if filter.Limit > 0 && total > filter.Limit {
+		matches = matches[:filter.Limit]
+	}
+	return ActionHistory{Entries: matches, Total: total}, nil
+}
+
+func (store *ActionStore) newHistoryEntry(kind string, path string, status string, message string, errorText string, stdout string, stderr string) ActionHistoryEntry {
+	return ActionHistoryEntry{
+		RecordedAt: store.clock().UTC(),
+		Actor:      store.actor,
+		Kind:       kind,
+		Path:       path,
+		Status:     status,
+		Message:    message,
+		Error:      errorText,
+		Output:     actionHistoryOutputExcerpt(stdout, stderr),
+	}
+}
+
+func (store *ActionStore) appendHistory(entries []ActionHistoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	var payload strings.Builder
+	for _, entry := range entries {
+		encoded, encodeError := json.Marshal(entry)
+		if encodeError != nil {
+			return fmt.Errorf(actionHistoryWriteErrorTemplateConstant, store.historyPath, encodeError)
+		}
+		payload.Write(encoded)
+		payload.WriteByte('\n')
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if directoryError := os.MkdirAll(filepath.Dir(store.historyPath), actionStateDirectoryPermissionsConstant); directoryError != nil {
+		return fmt.Errorf(actionHistoryWriteErrorTemplateConstant, store.historyPath, directoryError)
+	}
+	historyFile, openError := os.OpenFile(store.historyPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, actionStateFilePermissionsConstant)
+	if openError != nil {
+		return fmt.Errorf(actionHistoryWriteErrorTemplateConstant, store.historyPath, openError)
+	}
+	_, writeError := historyFile.WriteString(payload.String())
+	closeError := historyFile.Close()
+	if writeError == nil {
+		writeError = closeError
+	}
+	if writeError != nil {
+		return fmt.Errorf(actionHistoryWriteErrorTemplateConstant, store.historyPath, writeError)
+	}
+	return nil
+}
+
+func (filter ActionHistoryFilter) matches(entry ActionHistoryEntry) bool {
+	if len(filter.Kind) > 0 && entry.Kind != filter.Kind {
+		return false
+	}
+	if len(filter.Status) > 0 && entry.Status != filter.Status {
+		return false
+	}
+	if len(filter.Path) > 0 && !strings.Contains(strings.ToLower(entry.Path), strings.ToLower(filter.Path)) {
+		return false
+	}
+	if !filter.Since.IsZero() && entry.RecordedAt.Before(filter.Since) {
+		return false
+	}
+	return true
+}
+
+// actionHistoryOutputExcerpt keeps the tail of the combined output, where failures are usually reported.
+func actionHistoryOutputExcerpt(stdout string, stderr string) string {
+	parts := make([]string, 0, 2)
+	for _, part := range []string{stdout, stderr} {
+		if trimmed := strings.TrimSpace(part); len(trimmed) > 0 {
+			parts = append(parts, trimmed)
+		}
+	}
+	output := strings.Join(parts, "\n")
+	if len(output) <= actionHistoryOutputLimitConstant {
+		return output
+	}
+	tail := output[len(output)-actionHistoryOutputLimitConstant:]
+	for len(tail) > 0 && !utf8.RuneStart(tail[0]) {
+		tail = tail[1:]
+	}
+	return actionHistoryOutputTruncatedConstant + tail
+}
+
+func writeActionStateFile(path string, contents []byte) error {
+	if directoryError := os.MkdirAll(filepath.Dir(path), actionStateDirectoryPermissionsConstant); directoryError != nil {
+		return directoryError
+	}
+	return utils.WriteFileAtomically(path, contents, actionStateFilePermissionsConstant)
+}
+
+func (server *Server) handleAuditQueue(requestContext *gin.Context) {
+	queue, loadError := server.options.actions.LoadQueue()
+	if loadError != nil {
+		queue.Error = loadError.Error()
+	}
+	requestContext.JSON(http.StatusOK, queue)
+}
+
+func (server *Server) handleSaveAuditQueue(requestContext *gin.Context) {
+	var request AuditQueueState
+	if bindError := requestContext.ShouldBindJSON(&request); bindError != nil {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: bindError.Error()})
+		return
+	}
+
+	queue, saveError := server.options.actions.SaveQueue(request.Changes)
+	if saveError != nil {
+		requestContext.JSON(http.StatusInternalServerError, errorResponse{Error: saveError.Error()})
+		return
+	}
+	requestContext.JSON(http.StatusOK, queue)
+}
+
+func (server *Server) handleActionHistory(requestContext *gin.Context) {
+	filter := ActionHistoryFilter{
+		Kind:   strings.TrimSpace(requestContext.Query(historyKindQueryParameterConstant)),
+		Status: strings.TrimSpace(requestContext.Query(historyStatusQueryParameterConstant)),
+		Path:   strings.TrimSpace(requestContext.Query(historyPathQueryParameterConstant)),
+	}
+	if rawSince := strings.TrimSpace(requestContext.Query(historySinceQueryParameterConstant)); len(rawSince) > 0 {
+		since, parseError := time.Parse(time.RFC3339, rawSince)
+		if parseError != nil {
+			requestContext.JSON(http.StatusBadRequest, errorResponse{Error: invalidHistorySinceErrorConstant})
+			return
+		}
+		filter.Since = since
+	}
+	if rawLimit := strings.TrimSpace(requestContext.Query(historyLimitQueryParameterConstant)); len(rawLimit) > 0 {
+		limit, parseError := strconv.Atoi(rawLimit)
+		if parseError != nil || limit < 0 {
+			requestContext.JSON(http.StatusBadRequest, errorResponse{Error: invalidHistoryLimitErrorConstant})
+			return
+		}
+		filter.Limit = limit
+	}
+
+	history, historyError := server.options.actions.History(filter)
+	if historyError != nil {
+		history.Error = historyError.Error()
+	}
+	requestContext.JSON(http.StatusOK, history)
+}
+
+// recordAuditResults appends applied changes to the history. A history write failure is reported
+// separately so it never hides the per-change results the browser dequeues by.
+func (server *Server) recordAuditResults(response *AuditChangeApplyResponse) {
+	if recordError := server.options.actions.RecordAuditResults(response.Results); recordError != nil {
+		response.HistoryError = recordError.Error()
+	}
+}
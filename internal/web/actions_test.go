@@ -0,0 +1,178 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestActionStore(testInstance *testing.T, directory string, clock func() time.Time) *ActionStore {
+	testInstance.Helper()
+
+	store, storeError := NewActionStore(ActionStoreOptions{Directory: directory, Actor: "tester", Clock: clock})
+	require.NoError(testInstance, storeError)
+	return store
+}
+
+func TestActionStorePersistsQueueWithoutDeleteConfirmations(testInstance *testing.T) {
+	directory := filepath.Join(testInstance.TempDir(), "web")
+	savedAt := time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC)
+	store := newTestActionStore(testInstance, directory, func() time.Time { return savedAt })
+
+	emptyQueue, emptyError := store.LoadQueue()
+	require.NoError(testInstance, emptyError)
+	require.Empty(testInstance, emptyQueue.Changes)
+	require.Nil(testInstance, emptyQueue.UpdatedAt)
+
+	_, saveError := store.SaveQueue([]AuditQueuedChange{
+		{ID: "audit-change-1", Kind: AuditChangeKindSyncWithRemote, Path: "/tmp/alpha", SyncStrategy: AuditChangeSyncStrategyStashChanges, Title: "Sync alpha"},
+		{ID: "audit-change-2", Kind: AuditChangeKindDeleteFolder, Path: "/tmp/beta", ConfirmDelete: true},
+	})
+	require.NoError(testInstance, saveError)
+
+	fileInfo, statError := os.Stat(filepath.Join(directory, actionQueueFileNameConstant))
+	require.NoError(testInstance, statError)
+	require.Equal(testInstance, os.FileMode(actionStateFilePermissionsConstant), fileInfo.Mode().Perm())
+
+	restoredQueue, restoreError := newTestActionStore(testInstance, directory, nil).LoadQueue()
+	require.NoError(testInstance, restoreError)
+	require.Len(testInstance, restoredQueue.Changes, 2)
+	require.Equal(testInstance, "Sync alpha", restoredQueue.Changes[0].Title)
+	require.Equal(testInstance, AuditChangeSyncStrategyStashChanges, restoredQueue.Changes[0].SyncStrategy)
+	require.False(testInstance, restoredQueue.Changes[1].ConfirmDelete)
+	require.NotNil(testInstance, restoredQueue.UpdatedAt)
+	require.True(testInstance, savedAt.Equal(*restoredQueue.UpdatedAt))
+}
+
+func TestActionStoreIgnoresForeignQueueFiles(testInstance *testing.T) {
+	directory := testInstance.TempDir()
+	require.NoError(testInstance, os.WriteFile(filepath.Join(directory, actionQueueFileNameConstant), []byte(`{"schema":"other","changes":[{"id":"x"}]}`), 0o600))
+
+	queue, loadError := newTestActionStore(testInstance, directory, nil).LoadQueue()
+	require.NoError(testInstance, loadError)
+	require.Empty(testInstance, queue.Changes)
+}
+
+func TestActionStoreFiltersHistoryNewestFirst(testInstance *testing.T) {
+	directory := testInstance.TempDir()
+	currentTime := time.Date(2026, time.March, 4, 10, 0, 0, 0, time.UTC)
+	store := newTestActionStore(testInstance, directory, func() time.Time { return currentTime })
+
+	require.NoError(testInstance, store.RecordAuditResults([]AuditChangeApplyResult{
+		{ID: "audit-change-1", Kind: AuditChangeKindSyncWithRemote, Path: "/tmp/Alpha", Status: "succeeded", Stdout: "synced\n"},
+		{ID: "audit-change-2", Kind: AuditChangeKindDeleteFolder, Path: "/tmp/beta", Status: "failed", Error: "boom", Stderr: "permission denied\n"},
+	}))
+	currentTime = currentTime.Add(time.Hour)
+	require.NoError(testInstance, store.RecordAuditResults([]AuditChangeApplyResult{
+		{ID: "workflow-change-1", Kind: AuditChangeKindWorkflow, Path: "/tmp/alpha", Status: "succeeded", Message: "Workflow completed: preset:license"},
+		{ID: "workflow-change-2", Kind: AuditChangeKindWorkflow, Path: "/tmp/gamma", Status: "canceled", Error: "canceled"},
+	}))
+
+	historyFile, openError := os.OpenFile(filepath.Join(directory, actionHistoryFileNameConstant), os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(testInstance, openError)
+	_, writeError := historyFile.WriteString("not json\n")
+	require.NoError(testInstance, writeError)
+	require.NoError(testInstance, historyFile.Close())
+
+	allHistory, historyError := store.History(ActionHistoryFilter{})
+	require.NoError(testInstance, historyError)
+	require.Equal(testInstance, 4, allHistory.Total)
+	require.Equal(testInstance, ActionHistoryKindWorkflow, allHistory.Entries[0].Kind)
+	require.Equal(testInstance, "/tmp/gamma", allHistory.Entries[0].Path)
+	require.Equal(testInstance, "/tmp/alpha", allHistory.Entries[1].Path)
+	require.Equal(testInstance, "Workflow completed: preset:license", allHistory.Entries[1].Message)
+	require.Equal(testInstance, "tester", allHistory.Entries[2].Actor)
+	require.Equal(testInstance, "permission denied", allHistory.Entries[2].Output)
+
+	pathHistory, _ := store.History(ActionHistoryFilter{Path: "alpha"})
+	require.Equal(testInstance, 2, pathHistory.Total)
+
+	failedHistory, _ := store.History(ActionHistoryFilter{Status: "failed"})
+	require.Len(testInstance, failedHistory.Entries, 1)
+	require.Equal(testInstance, "boom", failedHistory.Entries[0].Error)
+
+	recentHistory, _ := store.History(ActionHistoryFilter{Since: currentTime})
+	require.Len(testInstance, recentHistory.Entries, 2)
+
+	limitedHistory, _ := store.History(ActionHistoryFilter{Kind: string(AuditChangeKindSyncWithRemote), Limit: 1})
+	require.Equal(testInstance, 1, limitedHistory.Total)
+	require.Equal(testInstance, "synced", limitedHistory.Entries[0].Output)
+}
+
+func TestActionHistoryOutputExcerptKeepsTail(testInstance *testing.T) {
+	output := actionHistoryOutputExcerpt(strings.Repeat("é", actionHistoryOutputLimitConstant), "last line")
+	require.True(testInstance, strings.HasPrefix(output, actionHistoryOutputTruncatedConstant))
+	require.True(testInstance, strings.HasSuffix(output, "\nlast line"))
+	require.LessOrEqual(testInstance, len(output), actionHistoryOutputLimitConstant+len(actionHistoryOutputTruncatedConstant))
+	require.True(testInstance, utf8.ValidString(output))
+}
+
+func TestAuditQueueAndHistoryRoutes(testInstance *testing.T) {
+	options := newTestServerOptions(testInstance, "127.0.0.1:8080")
+	options.ApplyAuditChanges = func(_ context.Context, request AuditChangeApplyRequest) AuditChangeApplyResponse {
+		results := make([]AuditChangeApplyResult, 0, len(request.Changes))
+		for _, change := range request.Changes {
+			results = append(results, AuditChangeApplyResult{ID: change.ID, Kind: change.Kind, Path: change.Path, Status: "succeeded"})
+		}
+		return AuditChangeApplyResponse{Results: results}
+	}
+	server, serverError := NewServer(options)
+	require.NoError(testInstance, serverError)
+
+	serve := func(method string, target string, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, "http://127.0.0.1:8080"+target, strings.NewReader(body))
+		request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+		request.Header.Set("Content-Type", jsonContentTypeConstant)
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	emptyQueue := serve(http.MethodGet, "/api/audit/queue", "")
+	require.Equal(testInstance, http.StatusOK, emptyQueue.Code)
+	require.JSONEq(testInstance, `{"changes":[]}`, emptyQueue.Body.String())
+
+	savedQueue := serve(http.MethodPut, "/api/audit/queue", `{"changes":[{"id":"audit-change-1","kind":"delete_folder","path":"/tmp/alpha","confirm_delete":true}]}`)
+	require.Equal(testInstance, http.StatusOK, savedQueue.Code)
+
+	var restoredQueue AuditQueueState
+	require.NoError(testInstance, json.Unmarshal(serve(http.MethodGet, "/api/audit/queue", "").Body.Bytes(), &restoredQueue))
+	require.Len(testInstance, restoredQueue.Changes, 1)
+	require.Equal(testInstance, "/tmp/alpha", restoredQueue.Changes[0].Path)
+	require.False(testInstance, restoredQueue.Changes[0].ConfirmDelete)
+
+	applied := serve(http.MethodPost, "/api/audit/apply", `{"changes":[{"id":"audit-change-1","kind":"sync_with_remote","path":"/tmp/alpha"},{"id":"audit-change-2","kind":"rename_folder","path":"/tmp/beta"}]}`)
+	require.Equal(testInstance, http.StatusOK, applied.Code)
+	require.NotContains(testInstance, applied.Body.String(), "history_error")
+
+	ran := serve(http.MethodPost, "/api/audit/apply", `{"changes":[{"id":"workflow-change-1","kind":"workflow","path":"/tmp/alpha","workflow_id":"preset:license"},{"id":"workflow-change-2","kind":"workflow","path":"/tmp/gamma","workflow_id":"preset:license"}]}`)
+	require.Equal(testInstance, http.StatusOK, ran.Code)
+
+	var history ActionHistory
+	require.NoError(testInstance, json.Unmarshal(serve(http.MethodGet, "/api/audit/history", "").Body.Bytes(), &history))
+	require.Equal(testInstance, 4, history.Total)
+	require.Equal(testInstance, ActionHistoryKindWorkflow, history.Entries[0].Kind)
+	require.Equal(testInstance, "/tmp/gamma", history.Entries[0].Path)
+	require.Equal(testInstance, "/tmp/alpha", history.Entries[1].Path)
+
+	var filteredHistory ActionHistory
+	require.NoError(testInstance, json.Unmarshal(serve(http.MethodGet, "/api/audit/history?kind=rename_folder&path=BETA", "").Body.Bytes(), &filteredHistory))
+	require.Len(testInstance, filteredHistory.Entries, 1)
+	require.Equal(testInstance, "tester", filteredHistory.Entries[0].Actor)
+
+	invalidLimit := serve(http.MethodGet, "/api/audit/history?limit=-1", "")
+	require.Equal(testInstance, http.StatusBadRequest, invalidLimit.Code)
+	require.Contains(testInstance, invalidLimit.Body.String(), invalidHistoryLimitErrorConstant)
+
+	invalidSince := serve(http.MethodGet, "/api/audit/history?since=yesterday", "")
+	require.Equal(testInstance, http.StatusBadRequest, invalidSince.Code)
+}
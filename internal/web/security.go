@@ -0,0 +1,185 @@
+package web
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	sessionTokenQueryParameterConstant   = "token"
+	sessionCookieNameConstant            = "gix_session"
+	authorizationHeaderConstant          = "Authorization"
+	bearerAuthorizationPrefixConstant    = "Bearer "
+	originHeaderConstant                 = "Origin"
+	jsonContentTypeConstant              = "application/json"
+	sessionTokenByteLengthConstant       = 32
+	missingSessionTokenErrorConstant     = "missing session token"
+	sessionRequiredErrorConstant         = "session token required; open the URL printed by gix --web"
+	untrustedHostErrorConstant           = "request host does not match the web interface bind address"
+	crossOriginRequestErrorConstant      = "cross-origin requests are not allowed"
+	jsonContentTypeRequiredErrorConstant = "requests must use Content-Type: application/json"
+	sessionRequiredDocumentConstant      = "<!doctype html>\n<html lang=\"en\">\n<head><meta charset=\"utf-8\"><title>gix</title></head>\n<body><p>This gix web interface requires its session token. Open the URL printed by <code>gix --web</code>.</p></body>\n</html>\n"
+	localhostNameConstant                = "localhost"
+	wildcardIPv4BindConstant             = "0.0.0.0"
+	wildcardIPv6BindConstant             = "::"
+	ipv6HostOpeningBracketConstant       = "["
+	ipv6HostClosingBracketConstant       = "]"
+	sessionCookiePathConstant            = "/"
+)
+
+// NewSessionToken returns a random URL-safe token for one web interface launch.
+func NewSessionToken() (string, error) {
+	tokenBytes := make([]byte, sessionTokenByteLengthConstant)
+	if _, readError := rand.Read(tokenBytes); readError != nil {
+		return "", readError
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+// hostAllowList accepts request Host names that reach the configured bind address.
+// Wildcard binds accept any name; loopback binds accept every loopback spelling so
+// DNS-rebinding names such as attacker.example resolving to 127.0.0.1 are refused.
+type hostAllowList struct {
+	anyHost   bool
+	loopback  bool
+	hostnames map[string]struct{}
+}
+
+func newHostAllowList(address string) hostAllowList {
+	bindHost := hostnameOnly(address)
+	switch {
+	case len(bindHost) == 0, bindHost == wildcardIPv4BindConstant, bindHost == wildcardIPv6BindConstant:
+		return hostAllowList{anyHost: true}
+	case isLoopbackHost(bindHost):
+		return hostAllowList{loopback: true}
+	default:
+		return hostAllowList{hostnames: map[string]struct{}{strings.ToLower(bindHost): {}}}
+	}
+}
+
+func (allowList hostAllowList) allows(requestHost string) bool {
+	if allowList.anyHost {
+		return true
+	}
+	hostname := strings.ToLower(hostnameOnly(requestHost))
+	if len(hostname) == 0 {
+		return false
+	}
+	if allowList.loopback {
+		return isLoopbackHost(hostname)
+	}
+	_, allowed := allowList.hostnames[hostname]
+	return allowed
+}
+
+func hostnameOnly(hostPort string) string {
+	trimmed := strings.TrimSpace(hostPort)
+	if host, _, splitError := net.SplitHostPort(trimmed); splitError == nil {
+		return host
+	}
+	if strings.HasPrefix(trimmed, ipv6HostOpeningBracketConstant) && strings.HasSuffix(trimmed, ipv6HostClosingBracketConstant) {
+		return strings.TrimSuffix(strings.TrimPrefix(trimmed, ipv6HostOpeningBracketConstant), ipv6HostClosingBracketConstant)
+	}
+	return trimmed
+}
+
+func isLoopbackHost(hostname string) bool {
+	if strings.EqualFold(hostname, localhostNameConstant) {
+		return true
+	}
+	parsedAddress := net.ParseIP(hostname)
+	return parsedAddress != nil && parsedAddress.IsLoopback()
+}
+
+// requireTrustedOrigin rejects requests addressed to a foreign Host and browser requests
+// whose Origin differs from the Host they were sent to.
+func (server *Server) requireTrustedOrigin(requestContext *gin.Context) {
+	request := requestContext.Request
+	if !server.options.allowedHosts.allows(request.Host) {
+		requestContext.AbortWithStatusJSON(http.StatusForbidden, errorResponse{Error: untrustedHostErrorConstant})
+		return
+	}
+	origin := strings.TrimSpace(request.Header.Get(originHeaderConstant))
+	if len(origin) == 0 {
+		requestContext.Next()
+		return
+	}
+	originURL, parseError := url.Parse(origin)
+	if parseError != nil || !strings.EqualFold(originURL.Host, request.Host) {
+		requestContext.AbortWithStatusJSON(http.StatusForbidden, errorResponse{Error: crossOriginRequestErrorConstant})
+		return
+	}
+	requestContext.Next()
+}
+
+// requireSession accepts the session cookie set by the launch URL or an Authorization bearer token.
+func (server *Server) requireSession(requestContext *gin.Context) {
+	if !server.validSession(requestContext.Request) {
+		requestContext.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse{Error: sessionRequiredErrorConstant})
+		return
+	}
+	requestContext.Next()
+}
+
+// requireJSONRequest refuses state-changing requests that are not JSON, so HTML forms and
+// other simple cross-site requests cannot reach the API without a CORS preflight.
+func (server *Server) requireJSONRequest(requestContext *gin.Context) {
+	switch requestContext.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		requestContext.Next()
+		return
+	}
+	if requestContext.ContentType() != jsonContentTypeConstant {
+		requestContext.AbortWithStatusJSON(http.StatusUnsupportedMediaType, errorResponse{Error: jsonContentTypeRequiredErrorConstant})
+		return
+	}
+	requestContext.Next()
+}
+
+func (server *Server) validSession(request *http.Request) bool {
+	if bearerToken, found := strings.CutPrefix(request.Header.Get(authorizationHeaderConstant), bearerAuthorizationPrefixConstant); found {
+		return server.matchesSessionToken(strings.TrimSpace(bearerToken))
+	}
+	sessionCookie, cookieError := request.Cookie(sessionCookieNameConstant)
+	if cookieError != nil {
+		return false
+	}
+	return server.matchesSessionToken(sessionCookie.Value)
+}
+
+func (server *Server) matchesSessionToken(candidate string) bool {
+	return len(candidate) > 0 && subtle.ConstantTimeCompare([]byte(candidate), []byte(server.options.sessionToken)) == 1
+}
+
+// handleIndex exchanges the launch URL token for a session cookie, then redirects to the
+// bare path so the token does not linger in the address bar or browser history.
+func (server *Server) handleIndex(requestContext *gin.Context) {
+	if queryToken := requestContext.Query(sessionTokenQueryParameterConstant); len(queryToken) > 0 {
+		if !server.matchesSessionToken(queryToken) {
+			requestContext.Data(http.StatusUnauthorized, htmlContentTypeConstant, []byte(sessionRequiredDocumentConstant))
+			return
+		}
+		http.SetCookie(requestContext.Writer, &http.Cookie{
+			Name:     sessionCookieNameConstant,
+			Value:    server.options.sessionToken,
+			Path:     sessionCookiePathConstant,
+			HttpOnly: true,
+			Secure:   server.options.certificate != nil,
+			SameSite: http.SameSiteStrictMode,
+		})
+		requestContext.Redirect(http.StatusSeeOther, indexRoutePathConstant)
+		return
+	}
+	if !server.validSession(requestContext.Request) {
+		requestContext.Data(http.StatusUnauthorized, htmlContentTypeConstant, []byte(sessionRequiredDocumentConstant))
+		return
+	}
+	requestContext.Data(http.StatusOK, htmlContentTypeConstant, server.indexHTML)
+}
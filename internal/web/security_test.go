@@ -0,0 +1,140 @@
+package web
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newSecurityTestServer(testInstance *testing.T, address string) *Server {
+	testInstance.Helper()
+
+	server, serverError := NewServer(newTestServerOptions(testInstance, address))
+	require.NoError(testInstance, serverError)
+	return server
+}
+
+func serveSecurityTestRequest(server *Server, request *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestNewServerRequiresSessionToken(testInstance *testing.T) {
+	options := newTestServerOptions(testInstance, "127.0.0.1:0")
+	options.SessionToken = ""
+	_, serverError := NewServer(options)
+	require.EqualError(testInstance, serverError, missingSessionTokenErrorConstant)
+}
+
+func TestLaunchTokenExchangesForSessionCookie(testInstance *testing.T) {
+	server := newSecurityTestServer(testInstance, "127.0.0.1:8080")
+
+	anonymousIndex := serveSecurityTestRequest(server, httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/", nil))
+	require.Equal(testInstance, http.StatusUnauthorized, anonymousIndex.Code)
+
+	wrongToken := serveSecurityTestRequest(server, httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/?token=guess", nil))
+	require.Equal(testInstance, http.StatusUnauthorized, wrongToken.Code)
+
+	launch := serveSecurityTestRequest(server, httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/?token="+testSessionTokenConstant, nil))
+	require.Equal(testInstance, http.StatusSeeOther, launch.Code)
+	require.Equal(testInstance, indexRoutePathConstant, launch.Header().Get("Location"))
+	cookies := launch.Result().Cookies()
+	require.Len(testInstance, cookies, 1)
+	require.Equal(testInstance, sessionCookieNameConstant, cookies[0].Name)
+	require.True(testInstance, cookies[0].HttpOnly)
+	require.Equal(testInstance, http.SameSiteStrictMode, cookies[0].SameSite)
+
+	indexRequest := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/", nil)
+	indexRequest.AddCookie(cookies[0])
+	require.Equal(testInstance, http.StatusOK, serveSecurityTestRequest(server, indexRequest).Code)
+
+	anonymousAPI := serveSecurityTestRequest(server, httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/api/folders?path=/tmp", nil))
+	require.Equal(testInstance, http.StatusUnauthorized, anonymousAPI.Code)
+
+	cookieAPIRequest := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/api/folders?path=/tmp", nil)
+	cookieAPIRequest.AddCookie(cookies[0])
+	require.Equal(testInstance, http.StatusOK, serveSecurityTestRequest(server, cookieAPIRequest).Code)
+}
+
+func TestAPIRejectsForeignHostsOriginsAndNonJSONBodies(testInstance *testing.T) {
+	server := newSecurityTestServer(testInstance, "127.0.0.1:8080")
+	authorize := func(request *http.Request) *http.Request {
+		request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+		return request
+	}
+
+	rebound := authorize(httptest.NewRequest(http.MethodGet, "http://attacker.example:8080/api/folders?path=/tmp", nil))
+	require.Equal(testInstance, http.StatusForbidden, serveSecurityTestRequest(server, rebound).Code)
+
+	loopbackAlias := authorize(httptest.NewRequest(http.MethodGet, "http://localhost:9999/api/folders?path=/tmp", nil))
+	require.Equal(testInstance, http.StatusOK, serveSecurityTestRequest(server, loopbackAlias).Code)
+
+	crossOrigin := authorize(httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8080/api/audit/inspect", strings.NewReader(`{}`)))
+	crossOrigin.Header.Set("Content-Type", jsonContentTypeConstant)
+	crossOrigin.Header.Set(originHeaderConstant, "https://attacker.example")
+	require.Equal(testInstance, http.StatusForbidden, serveSecurityTestRequest(server, crossOrigin).Code)
+
+	formPost := authorize(httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8080/api/audit/apply", strings.NewReader(`changes=[]`)))
+	formPost.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	require.Equal(testInstance, http.StatusUnsupportedMediaType, serveSecurityTestRequest(server, formPost).Code)
+
+	sameOrigin := authorize(httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8080/api/audit/inspect", strings.NewReader(`{}`)))
+	sameOrigin.Header.Set("Content-Type", jsonContentTypeConstant+"; charset=utf-8")
+	sameOrigin.Header.Set(originHeaderConstant, "http://127.0.0.1:8080")
+	require.Equal(testInstance, http.StatusOK, serveSecurityTestRequest(server, sameOrigin).Code)
+
+	namedServer := newSecurityTestServer(testInstance, "jump.example.net:8443")
+	namedRequest := authorize(httptest.NewRequest(http.MethodGet, "http://jump.example.net:8443/api/folders?path=/tmp", nil))
+	require.Equal(testInstance, http.StatusOK, serveSecurityTestRequest(namedServer, namedRequest).Code)
+	loopbackRequest := authorize(httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8443/api/folders?path=/tmp", nil))
+	require.Equal(testInstance, http.StatusForbidden, serveSecurityTestRequest(namedServer, loopbackRequest).Code)
+}
+
+func TestServeUsesSelfSignedCertificate(testInstance *testing.T) {
+	certificate, certificateError := NewSelfSignedCertificate([]string{"127.0.0.1", "localhost"}, time.Now())
+	require.NoError(testInstance, certificateError)
+	require.Equal(testInstance, []string{"localhost"}, certificate.Leaf.DNSNames)
+	require.Len(testInstance, strings.Split(CertificateFingerprint(certificate), ":"), 32)
+
+	_, emptyHostsError := NewSelfSignedCertificate([]string{" "}, time.Now())
+	require.EqualError(testInstance, emptyHostsError, missingCertificateHostsErrorConstant)
+
+	options := newTestServerOptions(testInstance, "127.0.0.1:0")
+	options.TLSCertificate = &certificate
+	server, serverError := NewServer(options)
+	require.NoError(testInstance, serverError)
+
+	listener, listenError := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(testInstance, listenError)
+	executionContext, cancelExecution := context.WithCancel(context.Background())
+	serverErrors := make(chan error, 1)
+	go func() {
+		serverErrors <- server.serveContext(executionContext, listener)
+	}()
+
+	trustedRoots := x509.NewCertPool()
+	trustedRoots.AddCert(certificate.Leaf)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: trustedRoots}}}
+	request, requestBuildError := http.NewRequest(http.MethodGet, "https://"+listener.Addr().String()+"/api/folders?path=/tmp", nil)
+	require.NoError(testInstance, requestBuildError)
+	request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+	response, requestError := client.Do(request)
+	require.NoError(testInstance, requestError)
+	_, readError := io.Copy(io.Discard, response.Body)
+	require.NoError(testInstance, readError)
+	require.NoError(testInstance, response.Body.Close())
+	require.Equal(testInstance, http.StatusOK, response.StatusCode)
+
+	cancelExecution()
+	require.NoError(testInstance, <-serverErrors)
+}
@@ -2,6 +2,7 @@ package web
 
 import (
 	"context"
+	"crypto/tls"
 	"embed"
 	"errors"
 	"io/fs"
@@ -14,20 +15,48 @@ import (
 )
 
 const (
-	indexRoutePathConstant               = "/"
-	assetsRoutePathConstant              = "/assets"
-	apiRepositoriesRoutePathConstant     = "/api/repos"
-	apiFoldersRoutePathConstant          = "/api/folders"
-	apiAuditInspectRoutePathConstant     = "/api/audit/inspect"
-	apiAuditApplyRoutePathConstant       = "/api/audit/apply"
-	indexDocumentFilePathConstant        = "ui/index.html"
-	htmlContentTypeConstant              = "text/html; charset=utf-8"
-	serverShutdownTimeoutConstant        = 5 * time.Second
-	missingServerAddressErrorConstant    = "missing server address"
-	missingDirectoryBrowserErrorConstant = "missing directory browser"
-	missingAuditInspectorErrorConstant   = "missing audit inspector"
-	missingAuditChangeExecutorConstant   = "missing audit change executor"
-	missingFolderPathErrorConstant       = "missing folder path"
+	indexRoutePathConstant                = "/"
+	assetsRoutePathConstant               = "/assets"
+	apiRoutePrefixConstant                = "/api"
+	apiRepositoriesRoutePathConstant      = "/repos"
+	apiFoldersRoutePathConstant           = "/folders"
+	apiAuditInspectRoutePathConstant      = "/audit/inspect"
+	apiAuditExportRoutePathConstant       = "/audit/export"
+	apiAuditApplyRoutePathConstant        = "/audit/apply"
+	apiAuditApplyStreamRoutePathConstant  = "/audit/apply/stream"
+	apiAuditApplyCancelRoutePathConstant  = "/audit/apply/cancel"
+	apiRepositoryRoutePathConstant        = "/repository"
+	apiRepositoryDiffRoutePathConstant    = "/repository/diff"
+	apiWorkflowsRoutePathConstant         = "/workflows"
+	apiWorkflowPlanRoutePathConstant      = "/workflows/plan"
+	apiAuditQueueRoutePathConstant        = "/audit/queue"
+	apiAuditHistoryRoutePathConstant      = "/audit/history"
+	apiSyncPreviewRoutePathConstant       = "/sync/preview"
+	apiPullRequestsRoutePathConstant      = "/pull-requests"
+	apiPackagesRoutePathConstant          = "/packages"
+	apiWorkspacesRoutePathConstant        = "/workspaces"
+	apiWorkspaceRoutePathConstant         = "/workspaces/:name"
+	indexDocumentFilePathConstant         = "ui/index.html"
+	htmlContentTypeConstant               = "text/html; charset=utf-8"
+	serverShutdownTimeoutConstant         = 5 * time.Second
+	missingServerAddressErrorConstant     = "missing server address"
+	missingDirectoryBrowserErrorConstant  = "missing directory browser"
+	missingAuditInspectorErrorConstant    = "missing audit inspector"
+	missingAuditChangeExecutorConstant    = "missing audit change executor"
+	missingAuditExporterErrorConstant     = "missing audit exporter"
+	missingFolderPathErrorConstant        = "missing folder path"
+	missingRepositoryLoaderErrorConstant  = "missing repository detail loader"
+	missingFileDiffLoaderErrorConstant    = "missing repository file diff loader"
+	missingRepositoryPathErrorConstant    = "missing repository path"
+	missingDiffFileErrorConstant          = "missing file"
+	missingWorkflowLoaderErrorConstant    = "missing workflow catalog loader"
+	missingWorkflowPlannerErrorConstant   = "missing workflow planner"
+	missingWorkflowIDErrorConstant        = "missing workflow_id"
+	missingSyncPreviewerErrorConstant     = "missing sync previewer"
+	missingPullRequestLoaderErrorConstant = "missing pull request dashboard loader"
+	missingPackageLoaderErrorConstant     = "missing package dashboard loader"
+	missingRootsErrorConstant             = "missing roots"
+	missingAuditSnapshotErrorConstant     = "missing snapshot_id"
 )
 
 //go:embed ui
@@ -42,7 +71,21 @@ type serverRuntimeOptions struct {
 	repositories RepositoryCatalog
 	browseDirs   DirectoryBrowser
 	inspectAudit AuditInspector
+	exportAudit  AuditExporter
 	applyAudit   AuditChangeExecutor
+	loadRepo     RepositoryDetailLoader
+	loadFileDiff RepositoryFileDiffLoader
+	loadFlows    WorkflowCatalogLoader
+	planFlow     WorkflowPlanner
+	previewSync  SyncPreviewer
+	loadPulls    PullRequestDashboardLoader
+	loadPackages PackageDashboardLoader
+	workspaces   WorkspaceStore
+	loadSpace    WorkspaceRepositoryLoader
+	actions      *ActionStore
+	sessionToken string
+	certificate  *tls.Certificate
+	allowedHosts hostAllowList
 }
 
 // Server hosts the embedded gix browser interface and JSON API.
@@ -51,6 +94,7 @@ type Server struct {
 	httpServer *http.Server
 	options    serverRuntimeOptions
 	indexHTML  []byte
+	auditRuns  auditApplyRuns
 }
 
 // Run starts the configured server and blocks until the context is canceled or the server exits.
@@ -102,8 +146,14 @@ func (server *Server) Handler() http.Handler {
 	return server.engine
 }
 
-// Serve accepts an existing listener.
+// Serve accepts an existing listener, wrapping it in TLS when a certificate is configured.
 func (server *Server) Serve(listener net.Listener) error {
+	if server.options.certificate != nil {
+		listener = tls.NewListener(listener, &tls.Config{
+			Certificates: []tls.Certificate{*server.options.certificate},
+			MinVersion:   tls.VersionTLS12,
+		})
+	}
 	return server.httpServer.Serve(listener)
 }
 
@@ -164,16 +214,67 @@ func newServerRuntimeOptions(options ServerOptions) (serverRuntimeOptions, error
 	if options.InspectAudit == nil {
 		return serverRuntimeOptions{}, errors.New(missingAuditInspectorErrorConstant)
 	}
+	if options.ExportAudit == nil {
+		return serverRuntimeOptions{}, errors.New(missingAuditExporterErrorConstant)
+	}
 	if options.ApplyAuditChanges == nil {
 		return serverRuntimeOptions{}, errors.New(missingAuditChangeExecutorConstant)
 	}
+	if options.LoadRepository == nil {
+		return serverRuntimeOptions{}, errors.New(missingRepositoryLoaderErrorConstant)
+	}
+	if options.LoadFileDiff == nil {
+		return serverRuntimeOptions{}, errors.New(missingFileDiffLoaderErrorConstant)
+	}
+	if options.LoadWorkflows == nil {
+		return serverRuntimeOptions{}, errors.New(missingWorkflowLoaderErrorConstant)
+	}
+	if options.PlanWorkflow == nil {
+		return serverRuntimeOptions{}, errors.New(missingWorkflowPlannerErrorConstant)
+	}
+	if options.PreviewSync == nil {
+		return serverRuntimeOptions{}, errors.New(missingSyncPreviewerErrorConstant)
+	}
+	if options.LoadPullRequests == nil {
+		return serverRuntimeOptions{}, errors.New(missingPullRequestLoaderErrorConstant)
+	}
+	if options.LoadPackages == nil {
+		return serverRuntimeOptions{}, errors.New(missingPackageLoaderErrorConstant)
+	}
+	if options.Workspaces == nil {
+		return serverRuntimeOptions{}, errors.New(missingWorkspaceStoreErrorConstant)
+	}
+	if options.LoadWorkspace == nil {
+		return serverRuntimeOptions{}, errors.New(missingWorkspaceRepositoryLoaderConstant)
+	}
+	if options.Actions == nil {
+		return serverRuntimeOptions{}, errors.New(missingActionStoreErrorConstant)
+	}
+	trimmedToken := strings.TrimSpace(options.SessionToken)
+	if len(trimmedToken) == 0 {
+		return serverRuntimeOptions{}, errors.New(missingSessionTokenErrorConstant)
+	}
 
 	return serverRuntimeOptions{
 		address:      trimmedAddress,
 		repositories: options.Repositories,
 		browseDirs:   options.BrowseDirectories,
 		inspectAudit: options.InspectAudit,
+		exportAudit:  options.ExportAudit,
 		applyAudit:   options.ApplyAuditChanges,
+		loadRepo:     options.LoadRepository,
+		loadFileDiff: options.LoadFileDiff,
+		loadFlows:    options.LoadWorkflows,
+		planFlow:     options.PlanWorkflow,
+		previewSync:  options.PreviewSync,
+		loadPulls:    options.LoadPullRequests,
+		loadPackages: options.LoadPackages,
+		workspaces:   options.Workspaces,
+		loadSpace:    options.LoadWorkspace,
+		actions:      options.Actions,
+		sessionToken: trimmedToken,
+		certificate:  options.TLSCertificate,
+		allowedHosts: newHostAllowList(trimmedAddress),
 	}, nil
 }
 
@@ -190,17 +291,39 @@ func embeddedFile(path string) ([]byte, error) {
 }
 
 func (server *Server) registerRoutes(assetsFileSystem http.FileSystem) {
-	server.engine.GET(indexRoutePathConstant, func(requestContext *gin.Context) {
-		requestContext.Data(http.StatusOK, htmlContentTypeConstant, server.indexHTML)
-	})
+	server.engine.Use(server.requireTrustedOrigin)
+	server.engine.GET(indexRoutePathConstant, server.handleIndex)
 	server.engine.StaticFS(assetsRoutePathConstant, assetsFileSystem)
-	server.engine.GET(apiRepositoriesRoutePathConstant, server.handleRepositories)
-	server.engine.GET(apiFoldersRoutePathConstant, server.handleBrowseDirectories)
-	server.engine.POST(apiAuditInspectRoutePathConstant, server.handleInspectAudit)
-	server.engine.POST(apiAuditApplyRoutePathConstant, server.handleApplyAuditChanges)
+
+	apiRoutes := server.engine.Group(apiRoutePrefixConstant, server.requireSession, server.requireJSONRequest)
+	apiRoutes.GET(apiRepositoriesRoutePathConstant, server.handleRepositories)
+	apiRoutes.GET(apiFoldersRoutePathConstant, server.handleBrowseDirectories)
+	apiRoutes.POST(apiAuditInspectRoutePathConstant, server.handleInspectAudit)
+	apiRoutes.POST(apiAuditExportRoutePathConstant, server.handleExportAudit)
+	apiRoutes.POST(apiAuditApplyRoutePathConstant, server.handleApplyAuditChanges)
+	apiRoutes.POST(apiAuditApplyStreamRoutePathConstant, server.handleStreamAuditChanges)
+	apiRoutes.POST(apiAuditApplyCancelRoutePathConstant, server.handleCancelAuditChanges)
+	apiRoutes.GET(apiRepositoryRoutePathConstant, server.handleRepositoryDetail)
+	apiRoutes.GET(apiRepositoryDiffRoutePathConstant, server.handleRepositoryFileDiff)
+	apiRoutes.GET(apiWorkflowsRoutePathConstant, server.handleWorkflows)
+	apiRoutes.POST(apiWorkflowPlanRoutePathConstant, server.handlePlanWorkflow)
+	apiRoutes.GET(apiAuditQueueRoutePathConstant, server.handleAuditQueue)
+	apiRoutes.PUT(apiAuditQueueRoutePathConstant, server.handleSaveAuditQueue)
+	apiRoutes.GET(apiAuditHistoryRoutePathConstant, server.handleActionHistory)
+	apiRoutes.POST(apiSyncPreviewRoutePathConstant, server.handlePreviewSync)
+	apiRoutes.POST(apiPullRequestsRoutePathConstant, server.handlePullRequests)
+	apiRoutes.POST(apiPackagesRoutePathConstant, server.handlePackages)
+	apiRoutes.GET(apiWorkspacesRoutePathConstant, server.handleWorkspaces)
+	apiRoutes.POST(apiWorkspacesRoutePathConstant, server.handleCreateWorkspace)
+	apiRoutes.PUT(apiWorkspaceRoutePathConstant, server.handleUpdateWorkspace)
+	apiRoutes.DELETE(apiWorkspaceRoutePathConstant, server.handleDeleteWorkspace)
 }
 
 func (server *Server) handleRepositories(requestContext *gin.Context) {
+	if workspaceName := strings.TrimSpace(requestContext.Query(workspaceQueryParameterConstant)); len(workspaceName) > 0 {
+		server.workspaceRepositories(requestContext, workspaceName)
+		return
+	}
 	requestContext.JSON(http.StatusOK, server.options.repositories)
 }
 
@@ -224,6 +347,21 @@ func (server *Server) handleInspectAudit(requestContext *gin.Context) {
 	requestContext.JSON(http.StatusOK, server.options.inspectAudit(requestContext.Request.Context(), request))
 }
 
+func (server *Server) handleExportAudit(requestContext *gin.Context) {
+	var request AuditExportRequest
+	if bindError := requestContext.ShouldBindJSON(&request); bindError != nil {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: bindError.Error()})
+		return
+	}
+	request.SnapshotID = strings.TrimSpace(request.SnapshotID)
+	if len(request.SnapshotID) == 0 {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: missingAuditSnapshotErrorConstant})
+		return
+	}
+
+	requestContext.JSON(http.StatusOK, server.options.exportAudit(requestContext.Request.Context(), request))
+}
+
 func (server *Server) handleApplyAuditChanges(requestContext *gin.Context) {
 	var request AuditChangeApplyRequest
 	if bindError := requestContext.ShouldBindJSON(&request); bindError != nil {
@@ -231,5 +369,121 @@ func (server *Server) handleApplyAuditChanges(requestContext *gin.Context) {
 		return
 	}
 
-	requestContext.JSON(http.StatusOK, server.options.applyAudit(requestContext.Request.Context(), request))
+	response := server.options.applyAudit(requestContext.Request.Context(), request)
+	server.recordAuditResults(&response)
+	requestContext.JSON(http.StatusOK, response)
+}
+
+func (server *Server) handleRepositoryDetail(requestContext *gin.Context) {
+	repositoryPath := strings.TrimSpace(requestContext.Query("path"))
+	if len(repositoryPath) == 0 {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: missingRepositoryPathErrorConstant})
+		return
+	}
+
+	requestContext.JSON(http.StatusOK, server.options.loadRepo(requestContext.Request.Context(), repositoryPath))
+}
+
+func (server *Server) handleRepositoryFileDiff(requestContext *gin.Context) {
+	request := RepositoryFileDiffRequest{
+		Path: strings.TrimSpace(requestContext.Query("path")),
+		File: strings.TrimSpace(requestContext.Query("file")),
+	}
+	if len(request.Path) == 0 {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: missingRepositoryPathErrorConstant})
+		return
+	}
+	if len(request.File) == 0 {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: missingDiffFileErrorConstant})
+		return
+	}
+
+	requestContext.JSON(http.StatusOK, server.options.loadFileDiff(requestContext.Request.Context(), request))
+}
+
+func (server *Server) handleWorkflows(requestContext *gin.Context) {
+	requestContext.JSON(http.StatusOK, server.options.loadFlows(requestContext.Request.Context()))
+}
+
+func (server *Server) handlePlanWorkflow(requestContext *gin.Context) {
+	request, requestValid := bindWorkflowRunRequest(requestContext)
+	if !requestValid {
+		return
+	}
+
+	requestContext.JSON(http.StatusOK, server.options.planFlow(requestContext.Request.Context(), request))
+}
+
+func (server *Server) handlePreviewSync(requestContext *gin.Context) {
+	var request SyncPreviewRequest
+	if bindError := requestContext.ShouldBindJSON(&request); bindError != nil {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: bindError.Error()})
+		return
+	}
+	request.Path = strings.TrimSpace(request.Path)
+	if len(request.Path) == 0 {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: missingRepositoryPathErrorConstant})
+		return
+	}
+
+	requestContext.JSON(http.StatusOK, server.options.previewSync(requestContext.Request.Context(), request))
+}
+
+func (server *Server) handlePullRequests(requestContext *gin.Context) {
+	var request PullRequestDashboardRequest
+	if bindError := requestContext.ShouldBindJSON(&request); bindError != nil {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: bindError.Error()})
+		return
+	}
+	roots, rootsValid := requiredRoots(requestContext, request.Roots)
+	if !rootsValid {
+		return
+	}
+	request.Roots = roots
+
+	requestContext.JSON(http.StatusOK, server.options.loadPulls(requestContext.Request.Context(), request))
+}
+
+func (server *Server) handlePackages(requestContext *gin.Context) {
+	var request PackageDashboardRequest
+	if bindError := requestContext.ShouldBindJSON(&request); bindError != nil {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: bindError.Error()})
+		return
+	}
+	roots, rootsValid := requiredRoots(requestContext, request.Roots)
+	if !rootsValid {
+		return
+	}
+	request.Roots = roots
+
+	requestContext.JSON(http.StatusOK, server.options.loadPackages(requestContext.Request.Context(), request))
+}
+
+// requiredRoots trims the requested roots and answers 400 when none remain.
+func requiredRoots(requestContext *gin.Context, requestedRoots []string) ([]string, bool) {
+	roots := make([]string, 0, len(requestedRoots))
+	for _, root := range requestedRoots {
+		if trimmedRoot := strings.TrimSpace(root); len(trimmedRoot) > 0 {
+			roots = append(roots, trimmedRoot)
+		}
+	}
+	if len(roots) == 0 {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: missingRootsErrorConstant})
+		return nil, false
+	}
+	return roots, true
+}
+
+func bindWorkflowRunRequest(requestContext *gin.Context) (WorkflowRunRequest, bool) {
+	var request WorkflowRunRequest
+	if bindError := requestContext.ShouldBindJSON(&request); bindError != nil {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: bindError.Error()})
+		return WorkflowRunRequest{}, false
+	}
+	request.WorkflowID = strings.TrimSpace(request.WorkflowID)
+	if len(request.WorkflowID) == 0 {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: missingWorkflowIDErrorConstant})
+		return WorkflowRunRequest{}, false
+	}
+	return request, true
 }
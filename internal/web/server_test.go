@@ -5,7 +5,9 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -13,28 +15,73 @@ import (
 	"github.com/tyemirov/gix/internal/githubauth"
 )
 
-func TestServeContextPropagatesLaunchContextToRequests(testInstance *testing.T) {
-	contextValue := "configured-token"
-	executionContext, cancelExecution := context.WithCancel(
-		githubauth.WithCredential(context.Background(), contextValue),
-	)
-	defer cancelExecution()
+const testSessionTokenConstant = "test-session-token"
 
-	requestContextValues := make(chan string, 1)
-	server, serverError := NewServer(ServerOptions{
-		Address: "127.0.0.1:0",
-		BrowseDirectories: func(requestContext context.Context, folderPath string) DirectoryListing {
-			resolvedToken, _ := githubauth.ResolveToken(requestContext, nil)
-			requestContextValues <- resolvedToken
+func newTestServerOptions(testInstance *testing.T, address string) ServerOptions {
+	testInstance.Helper()
+
+	actions, actionsError := NewActionStore(ActionStoreOptions{Directory: testInstance.TempDir(), Actor: "tester"})
+	require.NoError(testInstance, actionsError)
+
+	return ServerOptions{
+		Address:      address,
+		SessionToken: testSessionTokenConstant,
+		BrowseDirectories: func(_ context.Context, folderPath string) DirectoryListing {
 			return DirectoryListing{Path: folderPath}
 		},
 		InspectAudit: func(context.Context, AuditInspectionRequest) AuditInspectionResponse {
 			return AuditInspectionResponse{}
 		},
+		ExportAudit: func(_ context.Context, request AuditExportRequest) AuditExport {
+			return AuditExport{Format: request.Format, FileName: "gix-audit.csv", Content: strings.Join(request.Paths, ",")}
+		},
 		ApplyAuditChanges: func(context.Context, AuditChangeApplyRequest) AuditChangeApplyResponse {
 			return AuditChangeApplyResponse{}
 		},
-	})
+		LoadRepository: func(_ context.Context, repositoryPath string) RepositoryDetail {
+			return RepositoryDetail{Path: repositoryPath}
+		},
+		LoadFileDiff: func(_ context.Context, request RepositoryFileDiffRequest) RepositoryFileDiff {
+			return RepositoryFileDiff{Path: request.Path, File: request.File}
+		},
+		LoadWorkflows: func(context.Context) WorkflowCatalog {
+			return WorkflowCatalog{}
+		},
+		PlanWorkflow: func(_ context.Context, request WorkflowRunRequest) WorkflowPlan {
+			return WorkflowPlan{WorkflowID: request.WorkflowID, RepositoryPaths: request.RepositoryPaths}
+		},
+		PreviewSync: func(_ context.Context, request SyncPreviewRequest) SyncPreview {
+			return SyncPreview{Path: request.Path, TargetBranch: request.Branch}
+		},
+		LoadPullRequests: func(_ context.Context, request PullRequestDashboardRequest) PullRequestDashboard {
+			return PullRequestDashboard{Roots: request.Roots, Repositories: []PullRequestRepository{}}
+		},
+		LoadPackages: func(_ context.Context, request PackageDashboardRequest) PackageDashboard {
+			return PackageDashboard{Roots: request.Roots, Packages: []PackageDescriptor{}}
+		},
+		Workspaces: &memoryWorkspaceStore{},
+		LoadWorkspace: func(_ context.Context, roots []string) RepositoryCatalog {
+			return RepositoryCatalog{LaunchRoots: roots}
+		},
+		Actions: actions,
+	}
+}
+
+func TestServeContextPropagatesLaunchContextToRequests(testInstance *testing.T) {
+	contextValue := "configured-token"
+	executionContext, cancelExecution := context.WithCancel(
+		githubauth.WithCredential(context.Background(), contextValue),
+	)
+	defer cancelExecution()
+
+	requestContextValues := make(chan string, 1)
+	options := newTestServerOptions(testInstance, "127.0.0.1:0")
+	options.BrowseDirectories = func(requestContext context.Context, folderPath string) DirectoryListing {
+		resolvedToken, _ := githubauth.ResolveToken(requestContext, nil)
+		requestContextValues <- resolvedToken
+		return DirectoryListing{Path: folderPath}
+	}
+	server, serverError := NewServer(options)
 	require.NoError(testInstance, serverError)
 
 	listener, listenError := net.Listen("tcp", "127.0.0.1:0")
@@ -45,8 +92,11 @@ func TestServeContextPropagatesLaunchContextToRequests(testInstance *testing.T)
 		serverErrors <- server.serveContext(executionContext, listener)
 	}()
 
-	requestURL := "http://" + listener.Addr().String() + apiFoldersRoutePathConstant + "?path=" + url.QueryEscape("/tmp")
-	response, requestError := http.Get(requestURL)
+	requestURL := "http://" + listener.Addr().String() + apiRoutePrefixConstant + apiFoldersRoutePathConstant + "?path=" + url.QueryEscape("/tmp")
+	request, requestBuildError := http.NewRequest(http.MethodGet, requestURL, nil)
+	require.NoError(testInstance, requestBuildError)
+	request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+	response, requestError := http.DefaultClient.Do(request)
 	require.NoError(testInstance, requestError)
 	_, readError := io.Copy(io.Discard, response.Body)
 	require.NoError(testInstance, readError)
@@ -57,3 +107,192 @@ func TestServeContextPropagatesLaunchContextToRequests(testInstance *testing.T)
 	cancelExecution()
 	require.NoError(testInstance, <-serverErrors)
 }
+
+func TestRepositoryDetailRoutesRequireRepositoryAndFile(testInstance *testing.T) {
+	server, serverError := NewServer(newTestServerOptions(testInstance, "127.0.0.1:8080"))
+	require.NoError(testInstance, serverError)
+
+	testCases := []struct {
+		name           string
+		target         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "detail without path", target: "/api/repository", expectedStatus: http.StatusBadRequest, expectedBody: missingRepositoryPathErrorConstant},
+		{name: "detail", target: "/api/repository?path=/tmp/alpha", expectedStatus: http.StatusOK, expectedBody: `"path":"/tmp/alpha"`},
+		{name: "diff without file", target: "/api/repository/diff?path=/tmp/alpha", expectedStatus: http.StatusBadRequest, expectedBody: missingDiffFileErrorConstant},
+		{name: "diff", target: "/api/repository/diff?path=/tmp/alpha&file=README.md", expectedStatus: http.StatusOK, expectedBody: `"file":"README.md"`},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080"+testCase.target, nil)
+			request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+			require.Equal(testInstance, testCase.expectedStatus, recorder.Code)
+			require.Contains(testInstance, recorder.Body.String(), testCase.expectedBody)
+		})
+	}
+}
+
+func TestWorkflowRoutesRequireWorkflowIdentifier(testInstance *testing.T) {
+	server, serverError := NewServer(newTestServerOptions(testInstance, "127.0.0.1:8080"))
+	require.NoError(testInstance, serverError)
+
+	testCases := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "catalog", method: http.MethodGet, target: "/api/workflows", expectedStatus: http.StatusOK, expectedBody: "{}"},
+		{name: "plan without workflow", method: http.MethodPost, target: "/api/workflows/plan", body: `{"repository_paths":["/tmp/alpha"]}`, expectedStatus: http.StatusBadRequest, expectedBody: missingWorkflowIDErrorConstant},
+		{name: "plan", method: http.MethodPost, target: "/api/workflows/plan", body: `{"workflow_id":"preset:license","repository_paths":["/tmp/alpha"]}`, expectedStatus: http.StatusOK, expectedBody: `"repository_paths":["/tmp/alpha"]`},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			request := httptest.NewRequest(testCase.method, "http://127.0.0.1:8080"+testCase.target, strings.NewReader(testCase.body))
+			request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+			request.Header.Set("Content-Type", jsonContentTypeConstant)
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+			require.Equal(testInstance, testCase.expectedStatus, recorder.Code)
+			require.Contains(testInstance, recorder.Body.String(), testCase.expectedBody)
+		})
+	}
+}
+
+func TestSyncPreviewRouteRequiresRepository(testInstance *testing.T) {
+	server, serverError := NewServer(newTestServerOptions(testInstance, "127.0.0.1:8080"))
+	require.NoError(testInstance, serverError)
+
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "without path", body: `{"branch":"feature/panel"}`, expectedStatus: http.StatusBadRequest, expectedBody: missingRepositoryPathErrorConstant},
+		{name: "preview", body: `{"path":" /tmp/alpha ","branch":"feature/panel","sync_strategy":"stash_changes"}`, expectedStatus: http.StatusOK, expectedBody: `"path":"/tmp/alpha","target_branch":"feature/panel"`},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8080/api/sync/preview", strings.NewReader(testCase.body))
+			request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+			request.Header.Set("Content-Type", jsonContentTypeConstant)
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+			require.Equal(testInstance, testCase.expectedStatus, recorder.Code)
+			require.Contains(testInstance, recorder.Body.String(), testCase.expectedBody)
+		})
+	}
+
+	options := newTestServerOptions(testInstance, "127.0.0.1:8080")
+	options.PreviewSync = nil
+	_, missingError := NewServer(options)
+	require.EqualError(testInstance, missingError, missingSyncPreviewerErrorConstant)
+}
+
+func TestPullRequestsRouteRequiresRoots(testInstance *testing.T) {
+	server, serverError := NewServer(newTestServerOptions(testInstance, "127.0.0.1:8080"))
+	require.NoError(testInstance, serverError)
+
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "without roots", body: `{"roots":[" "]}`, expectedStatus: http.StatusBadRequest, expectedBody: missingRootsErrorConstant},
+		{name: "dashboard", body: `{"roots":[" /tmp/alpha ",""]}`, expectedStatus: http.StatusOK, expectedBody: `"roots":["/tmp/alpha"]`},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8080/api/pull-requests", strings.NewReader(testCase.body))
+			request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+			request.Header.Set("Content-Type", jsonContentTypeConstant)
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+			require.Equal(testInstance, testCase.expectedStatus, recorder.Code)
+			require.Contains(testInstance, recorder.Body.String(), testCase.expectedBody)
+		})
+	}
+
+	options := newTestServerOptions(testInstance, "127.0.0.1:8080")
+	options.LoadPullRequests = nil
+	_, missingError := NewServer(options)
+	require.EqualError(testInstance, missingError, missingPullRequestLoaderErrorConstant)
+}
+
+func TestPackagesRouteRequiresRoots(testInstance *testing.T) {
+	server, serverError := NewServer(newTestServerOptions(testInstance, "127.0.0.1:8080"))
+	require.NoError(testInstance, serverError)
+
+	for _, body := range []string{`{"roots":[]}`, `{"roots":[" /tmp/alpha "]}`} {
+		request := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8080/api/packages", strings.NewReader(body))
+		request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+		request.Header.Set("Content-Type", jsonContentTypeConstant)
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, request)
+		if body == `{"roots":[]}` {
+			require.Equal(testInstance, http.StatusBadRequest, recorder.Code)
+			require.Contains(testInstance, recorder.Body.String(), missingRootsErrorConstant)
+			continue
+		}
+		require.Equal(testInstance, http.StatusOK, recorder.Code)
+		require.JSONEq(testInstance, `{"roots":["/tmp/alpha"],"packages":[]}`, recorder.Body.String())
+	}
+
+	options := newTestServerOptions(testInstance, "127.0.0.1:8080")
+	options.LoadPackages = nil
+	_, missingError := NewServer(options)
+	require.EqualError(testInstance, missingError, missingPackageLoaderErrorConstant)
+}
+
+func TestAuditExportRoute(testInstance *testing.T) {
+	server, serverError := NewServer(newTestServerOptions(testInstance, "127.0.0.1:8080"))
+	require.NoError(testInstance, serverError)
+
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "without snapshot",
+			body:           `{"snapshot_id":" ","format":"csv","paths":["/tmp/alpha"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"` + missingAuditSnapshotErrorConstant + `"}`,
+		},
+		{
+			name:           "export",
+			body:           `{"snapshot_id":"snapshot-1","format":"csv","paths":["/tmp/beta","/tmp/alpha"]}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"format":"csv","file_name":"gix-audit.csv","content":"/tmp/beta,/tmp/alpha"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8080/api/audit/export", strings.NewReader(testCase.body))
+			request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+			request.Header.Set("Content-Type", jsonContentTypeConstant)
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+			require.Equal(testInstance, testCase.expectedStatus, recorder.Code)
+			require.JSONEq(testInstance, testCase.expectedBody, recorder.Body.String())
+		})
+	}
+
+	options := newTestServerOptions(testInstance, "127.0.0.1:8080")
+	options.ExportAudit = nil
+	_, missingError := NewServer(options)
+	require.EqualError(testInstance, missingError, missingAuditExporterErrorConstant)
+}
@@ -0,0 +1,239 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	eventStreamContentTypeConstant      = "text/event-stream"
+	cacheControlHeaderConstant          = "Cache-Control"
+	cacheControlNoCacheConstant         = "no-cache"
+	proxyBufferingHeaderConstant        = "X-Accel-Buffering"
+	proxyBufferingDisabledConstant      = "no"
+	serverSentEventTemplateConstant     = "event: %s\ndata: %s\n\n"
+	auditApplyRunIDTemplateConstant     = "run-%06d"
+	auditApplyRunNotFoundErrorConstant  = "audit apply run %q is not active"
+	missingAuditApplyRunIDErrorConstant = "run_id is required"
+)
+
+type auditApplyRunContextKey struct{}
+
+// auditApplyRun tracks one streamed apply so queued changes can be canceled while it runs.
+type auditApplyRun struct {
+	id        string
+	cancelRun context.CancelFunc
+	events    chan AuditChangeEvent
+
+	mutex    sync.Mutex
+	active   map[string]context.CancelFunc
+	canceled map[string]struct{}
+}
+
+func (run *auditApplyRun) cancel(changeID string) {
+	if len(changeID) == 0 {
+		run.cancelRun()
+		return
+	}
+
+	run.mutex.Lock()
+	defer run.mutex.Unlock()
+	run.canceled[changeID] = struct{}{}
+	if cancelChange, running := run.active[changeID]; running {
+		cancelChange()
+	}
+}
+
+func (run *auditApplyRun) begin(executionContext context.Context, changeID string) context.Context {
+	changeContext, cancelChange := context.WithCancel(executionContext)
+
+	run.mutex.Lock()
+	defer run.mutex.Unlock()
+	if _, canceled := run.canceled[changeID]; canceled {
+		cancelChange()
+	}
+	run.active[changeID] = cancelChange
+	return changeContext
+}
+
+func (run *auditApplyRun) end(changeID string) {
+	run.mutex.Lock()
+	defer run.mutex.Unlock()
+	if cancelChange, running := run.active[changeID]; running {
+		cancelChange()
+		delete(run.active, changeID)
+	}
+}
+
+// auditApplyRuns indexes the streamed applies that are currently running.
+type auditApplyRuns struct {
+	mutex    sync.Mutex
+	sequence int
+	runs     map[string]*auditApplyRun
+}
+
+func (registry *auditApplyRuns) start(cancelRun context.CancelFunc) *auditApplyRun {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if registry.runs == nil {
+		registry.runs = map[string]*auditApplyRun{}
+	}
+	registry.sequence++
+	run := &auditApplyRun{
+		id:        fmt.Sprintf(auditApplyRunIDTemplateConstant, registry.sequence),
+		cancelRun: cancelRun,
+		events:    make(chan AuditChangeEvent),
+		active:    map[string]context.CancelFunc{},
+		canceled:  map[string]struct{}{},
+	}
+	registry.runs[run.id] = run
+	return run
+}
+
+func (registry *auditApplyRuns) lookup(runID string) (*auditApplyRun, bool) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	run, found := registry.runs[runID]
+	return run, found
+}
+
+func (registry *auditApplyRuns) finish(runID string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	delete(registry.runs, runID)
+}
+
+// AuditChangeProgress streams one queued change while an AuditChangeExecutor applies it.
+// The zero value, returned when the executor is not serving a streamed apply, does nothing.
+type AuditChangeProgress struct {
+	run      *auditApplyRun
+	changeID string
+}
+
+// StartAuditChange announces a queued change to the streaming client and returns the context the
+// change must run under; the context is canceled when the operator cancels the change or the run.
+func StartAuditChange(executionContext context.Context, changeID string) (context.Context, AuditChangeProgress) {
+	run, streaming := executionContext.Value(auditApplyRunContextKey{}).(*auditApplyRun)
+	if !streaming {
+		return executionContext, AuditChangeProgress{}
+	}
+
+	changeContext := run.begin(executionContext, changeID)
+	run.events <- AuditChangeEvent{Type: AuditChangeEventStarted, RunID: run.id, ID: changeID}
+	return changeContext, AuditChangeProgress{run: run, changeID: changeID}
+}
+
+// Output returns a writer that records into buffer and streams every write as an output event.
+func (progress AuditChangeProgress) Output(stream AuditChangeOutputStream, buffer io.Writer) io.Writer {
+	if progress.run == nil {
+		return buffer
+	}
+	return io.MultiWriter(buffer, auditChangeOutputWriter{progress: progress, stream: stream})
+}
+
+// Event streams one structured sync event reported while the change runs.
+func (progress AuditChangeProgress) Event(event SyncEvent) {
+	if progress.run == nil {
+		return
+	}
+	progress.run.events <- AuditChangeEvent{Type: AuditChangeEventSync, RunID: progress.run.id, ID: progress.changeID, Event: &event}
+}
+
+// Finish streams the change result and releases its context.
+func (progress AuditChangeProgress) Finish(result AuditChangeApplyResult) {
+	if progress.run == nil {
+		return
+	}
+	progress.run.end(progress.changeID)
+	progress.run.events <- AuditChangeEvent{Type: AuditChangeEventResult, RunID: progress.run.id, ID: progress.changeID, Result: &result}
+}
+
+type auditChangeOutputWriter struct {
+	progress AuditChangeProgress
+	stream   AuditChangeOutputStream
+}
+
+func (writer auditChangeOutputWriter) Write(payload []byte) (int, error) {
+	writer.progress.run.events <- AuditChangeEvent{
+		Type:   AuditChangeEventOutput,
+		RunID:  writer.progress.run.id,
+		ID:     writer.progress.changeID,
+		Stream: writer.stream,
+		Text:   string(payload),
+	}
+	return len(payload), nil
+}
+
+// handleStreamAuditChanges applies the queue like handleApplyAuditChanges but answers with
+// Server-Sent Events: a run event carrying the cancel handle, then started, output, and result
+// events per change, and a done event with the complete response.
+func (server *Server) handleStreamAuditChanges(requestContext *gin.Context) {
+	var request AuditChangeApplyRequest
+	if bindError := requestContext.ShouldBindJSON(&request); bindError != nil {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: bindError.Error()})
+		return
+	}
+
+	runContext, cancelRun := context.WithCancel(requestContext.Request.Context())
+	defer cancelRun()
+	run := server.auditRuns.start(cancelRun)
+	defer server.auditRuns.finish(run.id)
+
+	go func() {
+		response := server.options.applyAudit(context.WithValue(runContext, auditApplyRunContextKey{}, run), request)
+		server.recordAuditResults(&response)
+		run.events <- AuditChangeEvent{Type: AuditChangeEventDone, RunID: run.id, Response: &response}
+		close(run.events)
+	}()
+
+	header := requestContext.Writer.Header()
+	header.Set("Content-Type", eventStreamContentTypeConstant)
+	header.Set(cacheControlHeaderConstant, cacheControlNoCacheConstant)
+	header.Set(proxyBufferingHeaderConstant, proxyBufferingDisabledConstant)
+	requestContext.Status(http.StatusOK)
+
+	writeServerSentEvent(requestContext.Writer, AuditChangeEvent{Type: AuditChangeEventRun, RunID: run.id})
+	// Drain every event even after the client disconnects so the executor never blocks on a send.
+	for event := range run.events {
+		writeServerSentEvent(requestContext.Writer, event)
+	}
+}
+
+func (server *Server) handleCancelAuditChanges(requestContext *gin.Context) {
+	var request AuditChangeCancelRequest
+	if bindError := requestContext.ShouldBindJSON(&request); bindError != nil {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: bindError.Error()})
+		return
+	}
+	runID := strings.TrimSpace(request.RunID)
+	if len(runID) == 0 {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: missingAuditApplyRunIDErrorConstant})
+		return
+	}
+
+	run, found := server.auditRuns.lookup(runID)
+	if !found {
+		requestContext.JSON(http.StatusNotFound, errorResponse{Error: fmt.Sprintf(auditApplyRunNotFoundErrorConstant, runID)})
+		return
+	}
+	run.cancel(strings.TrimSpace(request.ChangeID))
+	requestContext.Status(http.StatusNoContent)
+}
+
+func writeServerSentEvent(writer gin.ResponseWriter, event AuditChangeEvent) {
+	payload, encodeError := json.Marshal(event)
+	if encodeError != nil {
+		return
+	}
+	if _, writeError := fmt.Fprintf(writer, serverSentEventTemplateConstant, event.Type, payload); writeError != nil {
+		return
+	}
+	writer.Flush()
+}
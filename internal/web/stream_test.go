@@ -0,0 +1,190 @@
+package web
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newStreamTestServer(testInstance *testing.T, applyAudit AuditChangeExecutor) *httptest.Server {
+	testInstance.Helper()
+
+	options := newTestServerOptions(testInstance, "127.0.0.1:0")
+	options.ApplyAuditChanges = applyAudit
+	server, serverError := NewServer(options)
+	require.NoError(testInstance, serverError)
+
+	httpServer := httptest.NewServer(server.Handler())
+	testInstance.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func postStreamTestJSON(testInstance *testing.T, endpoint string, payload string) *http.Response {
+	testInstance.Helper()
+
+	request, requestError := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(payload))
+	require.NoError(testInstance, requestError)
+	request.Header.Set("Content-Type", jsonContentTypeConstant)
+	request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+	response, responseError := http.DefaultClient.Do(request)
+	require.NoError(testInstance, responseError)
+	return response
+}
+
+func readServerSentEvent(testInstance *testing.T, reader *bufio.Reader) AuditChangeEvent {
+	testInstance.Helper()
+
+	var frame bytes.Buffer
+	for {
+		line, readError := reader.ReadString('\n')
+		require.NoError(testInstance, readError)
+		if line == "\n" {
+			break
+		}
+		frame.WriteString(line)
+	}
+
+	var eventName string
+	var event AuditChangeEvent
+	for _, line := range strings.Split(strings.TrimSpace(frame.String()), "\n") {
+		if name, found := strings.CutPrefix(line, "event: "); found {
+			eventName = name
+		}
+		if data, found := strings.CutPrefix(line, "data: "); found {
+			require.NoError(testInstance, json.Unmarshal([]byte(data), &event))
+		}
+	}
+	require.Equal(testInstance, string(event.Type), eventName)
+	return event
+}
+
+func TestStreamAuditChangesEmitsProgressPerChange(testInstance *testing.T) {
+	httpServer := newStreamTestServer(testInstance, func(executionContext context.Context, request AuditChangeApplyRequest) AuditChangeApplyResponse {
+		results := make([]AuditChangeApplyResult, 0, len(request.Changes))
+		for _, change := range request.Changes {
+			_, progress := StartAuditChange(executionContext, change.ID)
+			var stdout bytes.Buffer
+			_, _ = fmt.Fprintf(progress.Output(AuditChangeOutputStdout, &stdout), "applying %s\n", change.Path)
+			result := AuditChangeApplyResult{ID: change.ID, Kind: change.Kind, Path: change.Path, Status: "succeeded", Stdout: stdout.String()}
+			progress.Finish(result)
+			results = append(results, result)
+		}
+		return AuditChangeApplyResponse{Results: results}
+	})
+
+	response := postStreamTestJSON(testInstance, httpServer.URL+"/api/audit/apply/stream", `{"changes":[{"id":"chg-001","kind":"convert_protocol","path":"/tmp/alpha"}]}`)
+	defer response.Body.Close()
+	require.Equal(testInstance, http.StatusOK, response.StatusCode)
+	require.Equal(testInstance, eventStreamContentTypeConstant, response.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(response.Body)
+	runEvent := readServerSentEvent(testInstance, reader)
+	require.Equal(testInstance, AuditChangeEventRun, runEvent.Type)
+	require.NotEmpty(testInstance, runEvent.RunID)
+
+	startedEvent := readServerSentEvent(testInstance, reader)
+	require.Equal(testInstance, AuditChangeEvent{Type: AuditChangeEventStarted, RunID: runEvent.RunID, ID: "chg-001"}, startedEvent)
+
+	outputEvent := readServerSentEvent(testInstance, reader)
+	require.Equal(testInstance, AuditChangeEventOutput, outputEvent.Type)
+	require.Equal(testInstance, AuditChangeOutputStdout, outputEvent.Stream)
+	require.Equal(testInstance, "applying /tmp/alpha\n", outputEvent.Text)
+
+	resultEvent := readServerSentEvent(testInstance, reader)
+	require.Equal(testInstance, AuditChangeEventResult, resultEvent.Type)
+	require.NotNil(testInstance, resultEvent.Result)
+	require.Equal(testInstance, "succeeded", resultEvent.Result.Status)
+
+	doneEvent := readServerSentEvent(testInstance, reader)
+	require.Equal(testInstance, AuditChangeEventDone, doneEvent.Type)
+	require.NotNil(testInstance, doneEvent.Response)
+	require.Len(testInstance, doneEvent.Response.Results, 1)
+	require.Equal(testInstance, "applying /tmp/alpha\n", doneEvent.Response.Results[0].Stdout)
+}
+
+func TestCancelAuditChangesCancelsRunningChange(testInstance *testing.T) {
+	httpServer := newStreamTestServer(testInstance, func(executionContext context.Context, request AuditChangeApplyRequest) AuditChangeApplyResponse {
+		results := make([]AuditChangeApplyResult, 0, len(request.Changes))
+		for _, change := range request.Changes {
+			changeContext, progress := StartAuditChange(executionContext, change.ID)
+			result := AuditChangeApplyResult{ID: change.ID, Kind: change.Kind, Path: change.Path, Status: "succeeded"}
+			if change.ID == "chg-001" {
+				<-changeContext.Done()
+			}
+			if changeContext.Err() != nil {
+				result.Status = "canceled"
+			}
+			progress.Finish(result)
+			results = append(results, result)
+		}
+		return AuditChangeApplyResponse{Results: results}
+	})
+
+	missingRun := postStreamTestJSON(testInstance, httpServer.URL+"/api/audit/apply/cancel", `{"run_id":"run-999999","change_id":"chg-001"}`)
+	require.NoError(testInstance, missingRun.Body.Close())
+	require.Equal(testInstance, http.StatusNotFound, missingRun.StatusCode)
+
+	response := postStreamTestJSON(testInstance, httpServer.URL+"/api/audit/apply/stream", `{"changes":[{"id":"chg-001","kind":"sync_with_remote","path":"/tmp/alpha"},{"id":"chg-002","kind":"sync_with_remote","path":"/tmp/beta"}]}`)
+	defer response.Body.Close()
+	reader := bufio.NewReader(response.Body)
+	runEvent := readServerSentEvent(testInstance, reader)
+	require.Equal(testInstance, "chg-001", readServerSentEvent(testInstance, reader).ID)
+
+	cancelResponse := postStreamTestJSON(testInstance, httpServer.URL+"/api/audit/apply/cancel", fmt.Sprintf(`{"run_id":%q,"change_id":"chg-001"}`, runEvent.RunID))
+	require.NoError(testInstance, cancelResponse.Body.Close())
+	require.Equal(testInstance, http.StatusNoContent, cancelResponse.StatusCode)
+
+	canceledResult := readServerSentEvent(testInstance, reader)
+	require.Equal(testInstance, "chg-001", canceledResult.ID)
+	require.Equal(testInstance, "canceled", canceledResult.Result.Status)
+
+	require.Equal(testInstance, AuditChangeEventStarted, readServerSentEvent(testInstance, reader).Type)
+	nextResult := readServerSentEvent(testInstance, reader)
+	require.Equal(testInstance, "chg-002", nextResult.ID)
+	require.Equal(testInstance, "succeeded", nextResult.Result.Status)
+	require.Equal(testInstance, AuditChangeEventDone, readServerSentEvent(testInstance, reader).Type)
+}
+
+func TestStreamAuditChangesEmitsSyncEventsAndHandoff(testInstance *testing.T) {
+	handoffEvent := SyncEvent{Level: "WARN", Code: "SYNC_SWITCH_HANDOFF", Message: "sync stopped", Details: map[string]string{"target_branch": "feature/panel"}}
+	httpServer := newStreamTestServer(testInstance, func(executionContext context.Context, request AuditChangeApplyRequest) AuditChangeApplyResponse {
+		change := request.Changes[0]
+		_, progress := StartAuditChange(executionContext, change.ID)
+		progress.Event(handoffEvent)
+		result := AuditChangeApplyResult{
+			ID:      change.ID,
+			Kind:    change.Kind,
+			Path:    change.Path,
+			Status:  "handoff",
+			Events:  []SyncEvent{handoffEvent},
+			Handoff: &SyncHandoff{Code: handoffEvent.Code, TargetBranch: "feature/panel", RecoverySteps: []string{"reapply stash abc"}},
+		}
+		progress.Finish(result)
+		return AuditChangeApplyResponse{Results: []AuditChangeApplyResult{result}}
+	})
+
+	response := postStreamTestJSON(testInstance, httpServer.URL+"/api/audit/apply/stream", `{"changes":[{"id":"chg-001","kind":"strict_sync","path":"/tmp/alpha","branch":"feature/panel"}]}`)
+	defer response.Body.Close()
+	reader := bufio.NewReader(response.Body)
+	require.Equal(testInstance, AuditChangeEventRun, readServerSentEvent(testInstance, reader).Type)
+	require.Equal(testInstance, AuditChangeEventStarted, readServerSentEvent(testInstance, reader).Type)
+
+	syncEvent := readServerSentEvent(testInstance, reader)
+	require.Equal(testInstance, AuditChangeEventSync, syncEvent.Type)
+	require.Equal(testInstance, "chg-001", syncEvent.ID)
+	require.Equal(testInstance, &handoffEvent, syncEvent.Event)
+
+	resultEvent := readServerSentEvent(testInstance, reader)
+	require.Equal(testInstance, "handoff", resultEvent.Result.Status)
+	require.NotNil(testInstance, resultEvent.Result.Handoff)
+	require.Equal(testInstance, []string{"reapply stash abc"}, resultEvent.Result.Handoff.RecoverySteps)
+	require.Equal(testInstance, AuditChangeEventDone, readServerSentEvent(testInstance, reader).Type)
+}
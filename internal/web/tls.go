@@ -0,0 +1,95 @@
+package web
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	selfSignedCertificateOrganizationConstant = "gix web interface"
+	selfSignedCertificateLifetimeConstant     = 30 * 24 * time.Hour
+	selfSignedCertificateClockSkewConstant    = time.Hour
+	selfSignedSerialNumberBitsConstant        = 128
+	missingCertificateHostsErrorConstant      = "self-signed certificate requires at least one host"
+	fingerprintByteSeparatorConstant          = ":"
+)
+
+// NewSelfSignedCertificate generates an in-memory ECDSA certificate valid for the provided
+// host names and IP addresses. The key never touches disk and is replaced on every launch.
+func NewSelfSignedCertificate(hosts []string, now time.Time) (tls.Certificate, error) {
+	var dnsNames []string
+	var ipAddresses []net.IP
+	for _, host := range hosts {
+		trimmedHost := strings.TrimSpace(host)
+		if len(trimmedHost) == 0 {
+			continue
+		}
+		if parsedAddress := net.ParseIP(trimmedHost); parsedAddress != nil {
+			ipAddresses = append(ipAddresses, parsedAddress)
+			continue
+		}
+		dnsNames = append(dnsNames, trimmedHost)
+	}
+	if len(dnsNames) == 0 && len(ipAddresses) == 0 {
+		return tls.Certificate{}, errors.New(missingCertificateHostsErrorConstant)
+	}
+
+	privateKey, keyError := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if keyError != nil {
+		return tls.Certificate{}, keyError
+	}
+	serialNumber, serialError := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), selfSignedSerialNumberBitsConstant))
+	if serialError != nil {
+		return tls.Certificate{}, serialError
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{Organization: []string{selfSignedCertificateOrganizationConstant}},
+		NotBefore:             now.Add(-selfSignedCertificateClockSkewConstant),
+		NotAfter:              now.Add(selfSignedCertificateLifetimeConstant),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              dnsNames,
+		IPAddresses:           ipAddresses,
+	}
+	certificateDER, certificateError := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if certificateError != nil {
+		return tls.Certificate{}, certificateError
+	}
+	leaf, parseError := x509.ParseCertificate(certificateDER)
+	if parseError != nil {
+		return tls.Certificate{}, parseError
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{certificateDER},
+		PrivateKey:  privateKey,
+		Leaf:        leaf,
+	}, nil
+}
+
+// CertificateFingerprint returns the colon-separated SHA-256 fingerprint browsers display for a certificate.
+func CertificateFingerprint(certificate tls.Certificate) string {
+	if len(certificate.Certificate) == 0 {
+		return ""
+	}
+	digest := sha256.Sum256(certificate.Certificate[0])
+	segments := make([]string, len(digest))
+	for byteIndex, digestByte := range digest {
+		segments[byteIndex] = fmt.Sprintf("%02X", digestByte)
+	}
+	return strings.Join(segments, fingerprintByteSeparatorConstant)
+}
@@ -2,7 +2,9 @@ package web
 
 import (
 	"context"
+	"crypto/tls"
 	"io"
+	"time"
 )
 
 // CommandExecutor executes one gix command with explicit arguments and I/O streams.
@@ -11,12 +13,21 @@ type CommandExecutor func(context.Context, []string, io.Reader, io.Writer, io.Wr
 // BranchCatalogLoader resolves branch metadata for one repository descriptor.
 type BranchCatalogLoader func(context.Context, RepositoryDescriptor) BranchCatalog
 
+// RepositoryDetailLoader resolves the read-only detail view for one repository path.
+type RepositoryDetailLoader func(context.Context, string) RepositoryDetail
+
+// RepositoryFileDiffLoader resolves the working-tree diff for one dirty file.
+type RepositoryFileDiffLoader func(context.Context, RepositoryFileDiffRequest) RepositoryFileDiff
+
 // DirectoryBrowser resolves immediate child folders for one absolute path.
 type DirectoryBrowser func(context.Context, string) DirectoryListing
 
 // AuditInspector resolves typed audit rows for explicit roots.
 type AuditInspector func(context.Context, AuditInspectionRequest) AuditInspectionResponse
 
+// AuditExporter renders selected audit rows with the audit report renderers.
+type AuditExporter func(context.Context, AuditExportRequest) AuditExport
+
 // AuditChangeExecutor applies queued audit changes.
 type AuditChangeExecutor func(context.Context, AuditChangeApplyRequest) AuditChangeApplyResponse
 
@@ -26,13 +37,45 @@ type WorkflowPrimitiveCatalogLoader func(context.Context) WorkflowPrimitiveCatal
 // WorkflowPrimitiveExecutor applies queued workflow primitive actions.
 type WorkflowPrimitiveExecutor func(context.Context, WorkflowPrimitiveApplyRequest) WorkflowPrimitiveApplyResponse
 
+// WorkflowCatalogLoader resolves the primitives, embedded presets, and workflow files the web interface can run.
+type WorkflowCatalogLoader func(context.Context) WorkflowCatalog
+
+// WorkflowPlanner previews the steps one workflow run would execute without touching repositories.
+type WorkflowPlanner func(context.Context, WorkflowRunRequest) WorkflowPlan
+
+// SyncPreviewer resolves what a strict sync would do for one repository without changing it.
+type SyncPreviewer func(context.Context, SyncPreviewRequest) SyncPreview
+
+// PullRequestDashboardLoader lists the pull requests of the repositories under the selected roots.
+type PullRequestDashboardLoader func(context.Context, PullRequestDashboardRequest) PullRequestDashboard
+
+// PackageDashboardLoader lists the container package versions of the repositories under the selected roots.
+type PackageDashboardLoader func(context.Context, PackageDashboardRequest) PackageDashboard
+
 // ServerOptions configures the local web server.
 type ServerOptions struct {
 	Address           string
 	Repositories      RepositoryCatalog
 	BrowseDirectories DirectoryBrowser
 	InspectAudit      AuditInspector
+	ExportAudit       AuditExporter
 	ApplyAuditChanges AuditChangeExecutor
+	LoadRepository    RepositoryDetailLoader
+	LoadFileDiff      RepositoryFileDiffLoader
+	LoadWorkflows     WorkflowCatalogLoader
+	PlanWorkflow      WorkflowPlanner
+	PreviewSync       SyncPreviewer
+	LoadPullRequests  PullRequestDashboardLoader
+	LoadPackages      PackageDashboardLoader
+	// Workspaces lists and edits the saved workspaces; LoadWorkspace builds the catalog of their roots.
+	Workspaces    WorkspaceStore
+	LoadWorkspace WorkspaceRepositoryLoader
+	// Actions persists the audit queue and records the action history; see NewActionStore.
+	Actions *ActionStore
+	// SessionToken authorizes the launch URL and every /api request; see NewSessionToken.
+	SessionToken string
+	// TLSCertificate serves HTTPS instead of HTTP when set; see NewSelfSignedCertificate.
+	TLSCertificate *tls.Certificate
 }
 
 // RepositoryCatalog describes the repositories visible to the web interface at launch time.
@@ -42,6 +85,7 @@ type RepositoryCatalog struct {
 	ExplorerRoot         string                 `json:"explorer_root,omitempty"`
 	LaunchMode           string                 `json:"launch_mode,omitempty"`
 	SelectedRepositoryID string                 `json:"selected_repository_id,omitempty"`
+	Workspace            string                 `json:"workspace,omitempty"`
 	Repositories         []RepositoryDescriptor `json:"repositories,omitempty"`
 	Error                string                 `json:"error,omitempty"`
 }
@@ -66,11 +110,71 @@ type BranchCatalog struct {
 	Error          string             `json:"error,omitempty"`
 }
 
-// BranchDescriptor captures one local Git branch.
+// BranchDescriptor captures one Git branch. The divergence fields are filled by the repository
+// detail view: Ahead/Behind compare a local branch with its upstream, and DefaultAhead/DefaultBehind
+// compare any branch with the remote default branch.
 type BranchDescriptor struct {
-	Name     string `json:"name"`
-	Current  bool   `json:"current"`
-	Upstream string `json:"upstream,omitempty"`
+	Name          string `json:"name"`
+	Current       bool   `json:"current"`
+	Upstream      string `json:"upstream,omitempty"`
+	Remote        bool   `json:"remote,omitempty"`
+	UpstreamGone  bool   `json:"upstream_gone,omitempty"`
+	Ahead         *int   `json:"ahead,omitempty"`
+	Behind        *int   `json:"behind,omitempty"`
+	DefaultAhead  *int   `json:"default_ahead,omitempty"`
+	DefaultBehind *int   `json:"default_behind,omitempty"`
+}
+
+// RepositoryDetail describes one repository for the read-only detail view.
+type RepositoryDetail struct {
+	Path          string                `json:"path"`
+	Name          string                `json:"name"`
+	CurrentBranch string                `json:"current_branch,omitempty"`
+	DefaultBranch string                `json:"default_branch,omitempty"`
+	Branches      []BranchDescriptor    `json:"branches"`
+	Graph         []RepositoryGraphLine `json:"graph"`
+	DirtyFiles    []AuditDirtyFileEntry `json:"dirty_files"`
+	Stashes       []RepositoryStash     `json:"stashes"`
+	Error         string                `json:"error,omitempty"`
+}
+
+// RepositoryGraphLine is one line of `git log --graph` output; connector-only lines carry no commit.
+type RepositoryGraphLine struct {
+	Graph  string            `json:"graph"`
+	Commit *RepositoryCommit `json:"commit,omitempty"`
+}
+
+// RepositoryCommit captures one commit in the recent history graph.
+type RepositoryCommit struct {
+	Hash      string   `json:"hash"`
+	ShortHash string   `json:"short_hash"`
+	Parents   []string `json:"parents,omitempty"`
+	Author    string   `json:"author"`
+	Date      string   `json:"date"`
+	Refs      []string `json:"refs,omitempty"`
+	Subject   string   `json:"subject"`
+}
+
+// RepositoryStash captures one stash entry.
+type RepositoryStash struct {
+	Reference string `json:"reference"`
+	Date      string `json:"date"`
+	Subject   string `json:"subject"`
+}
+
+// RepositoryFileDiffRequest selects one dirty file of a repository.
+type RepositoryFileDiffRequest struct {
+	Path string `json:"path"`
+	File string `json:"file"`
+}
+
+// RepositoryFileDiff carries the unified diff for one dirty file against HEAD.
+type RepositoryFileDiff struct {
+	Path   string `json:"path"`
+	File   string `json:"file"`
+	Status string `json:"status,omitempty"`
+	Diff   string `json:"diff"`
+	Error  string `json:"error,omitempty"`
 }
 
 // DirectoryListing describes the immediate child folders for one browsable path.
@@ -143,13 +247,39 @@ type FlagDescriptor struct {
 type AuditInspectionRequest struct {
 	Roots      []string `json:"roots"`
 	IncludeAll bool     `json:"include_all"`
+	// Refresh bypasses the inspection cache for this request.
+	Refresh bool `json:"refresh,omitempty"`
+	// Depth selects the inspection depth (minimal, full, or github); empty means full.
+	Depth string `json:"depth,omitempty"`
 }
 
 // AuditInspectionResponse returns typed audit rows to the browser.
 type AuditInspectionResponse struct {
 	Roots []string             `json:"roots,omitempty"`
 	Rows  []AuditInspectionRow `json:"rows,omitempty"`
-	Error string               `json:"error,omitempty"`
+	// SnapshotID names the inspection behind Rows so an export writes exactly what the table rendered.
+	SnapshotID string `json:"snapshot_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// AuditExportRequest renders the rows at Paths from the audit snapshot SnapshotID, in that order.
+// The repositories are not inspected again.
+type AuditExportRequest struct {
+	SnapshotID string `json:"snapshot_id"`
+	// Format is csv, html, or json.
+	Format string   `json:"format"`
+	Paths  []string `json:"paths"`
+	// Columns lists the audit column names to render; empty renders every column. JSON ignores it.
+	Columns []string `json:"columns,omitempty"`
+}
+
+// AuditExport carries a rendered audit report for the browser to download.
+type AuditExport struct {
+	Format      string `json:"format,omitempty"`
+	FileName    string `json:"file_name,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Content     string `json:"content,omitempty"`
+	Error       string `json:"error,omitempty"`
 }
 
 // AuditDirtyFileEntry captures one dirty worktree file status line.
@@ -175,6 +305,14 @@ type AuditInspectionRow struct {
 	WorktreeDirty          string                `json:"worktree_dirty"`
 	DirtyFiles             string                `json:"dirty_files"`
 	DirtyFileEntries       []AuditDirtyFileEntry `json:"dirty_file_entries,omitempty"`
+	UpstreamAhead          string                `json:"upstream_ahead"`
+	UpstreamBehind         string                `json:"upstream_behind"`
+	DefaultAhead           string                `json:"default_ahead"`
+	DefaultBehind          string                `json:"default_behind"`
+	NoUpstreamBranches     string                `json:"no_upstream_branches"`
+	UpstreamGoneBranches   string                `json:"upstream_gone_branches"`
+	MergedBranches         string                `json:"merged_branches"`
+	BranchListing          string                `json:"branch_listing,omitempty"`
 }
 
 // AuditChangeKind identifies one queued audit remediation.
@@ -188,6 +326,12 @@ const (
 	AuditChangeKindUpdateChangelog       AuditChangeKind = "update_changelog"
 	AuditChangeKindCommitChanges         AuditChangeKind = "commit_changes"
 	AuditChangeKindDeleteFolder          AuditChangeKind = "delete_folder"
+	AuditChangeKindStrictSync            AuditChangeKind = "strict_sync"
+	AuditChangeKindClosePullRequest      AuditChangeKind = "close_pull_request"
+	AuditChangeKindRetargetPullRequest   AuditChangeKind = "retarget_pull_request"
+	AuditChangeKindDeleteMergedBranch    AuditChangeKind = "delete_merged_branch"
+	AuditChangeKindPackageRetention      AuditChangeKind = "package_retention"
+	AuditChangeKindWorkflow              AuditChangeKind = "workflow"
 	AuditChangeSyncStrategyRequireClean  string          = "require_clean"
 	AuditChangeSyncStrategyStashChanges  string          = "stash_changes"
 	AuditChangeSyncStrategyCommitChanges string          = "commit_changes"
@@ -203,7 +347,29 @@ type AuditQueuedChange struct {
 	SourceProtocol string          `json:"source_protocol,omitempty"`
 	TargetProtocol string          `json:"target_protocol,omitempty"`
 	SyncStrategy   string          `json:"sync_strategy,omitempty"`
-	ConfirmDelete  bool            `json:"confirm_delete,omitempty"`
+	// Branch is the target of a strict_sync change, where empty syncs the current branch, and the
+	// head branch of a pull request change.
+	Branch string `json:"branch,omitempty"`
+	// Repository and PullRequest name the GitHub pull request a pull request change acts on.
+	Repository  string `json:"repository,omitempty"`
+	PullRequest int    `json:"pull_request,omitempty"`
+	// BaseBranch is the new base of a retarget_pull_request change.
+	BaseBranch string `json:"base_branch,omitempty"`
+	// Package, Keep, and PinnedVersions describe the retention a package_retention change applies to
+	// the container package of the repository at Path. DeleteVersions lists the version IDs the
+	// previewed plan deletes; the change fails when the plan at apply time differs.
+	Package        string  `json:"package,omitempty"`
+	Keep           int     `json:"keep,omitempty"`
+	PinnedVersions []int64 `json:"pinned_versions,omitempty"`
+	DeleteVersions []int64 `json:"delete_versions,omitempty"`
+	ConfirmDelete  bool    `json:"confirm_delete,omitempty"`
+	// WorkflowID and Parameters select the workflow a workflow change runs against the repository at Path,
+	// with the values of WorkflowRunRequest.
+	WorkflowID string         `json:"workflow_id,omitempty"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+	// Title and Description label the change in the queue; the executor ignores them.
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 // AuditChangeApplyRequest captures one queued apply request.
@@ -221,12 +387,97 @@ type AuditChangeApplyResult struct {
 	Stdout  string          `json:"stdout,omitempty"`
 	Stderr  string          `json:"stderr,omitempty"`
 	Error   string          `json:"error,omitempty"`
+	// Events lists the sync events a strict_sync change reported, in order.
+	Events []SyncEvent `json:"events,omitempty"`
+	// Handoff is set when a strict_sync change stopped for manual recovery instead of failing outright.
+	Handoff *SyncHandoff `json:"handoff,omitempty"`
 }
 
 // AuditChangeApplyResponse returns per-change execution results.
 type AuditChangeApplyResponse struct {
 	Results []AuditChangeApplyResult `json:"results,omitempty"`
 	Error   string                   `json:"error,omitempty"`
+	// HistoryError reports a failure to record the results in the action history.
+	HistoryError string `json:"history_error,omitempty"`
+}
+
+// AuditChangeEventType identifies one Server-Sent Event emitted while a queue is applied.
+type AuditChangeEventType string
+
+const (
+	AuditChangeEventRun     AuditChangeEventType = "run"
+	AuditChangeEventStarted AuditChangeEventType = "started"
+	AuditChangeEventOutput  AuditChangeEventType = "output"
+	AuditChangeEventSync    AuditChangeEventType = "sync_event"
+	AuditChangeEventResult  AuditChangeEventType = "result"
+	AuditChangeEventDone    AuditChangeEventType = "done"
+)
+
+// AuditChangeOutputStream names the stream an output event was written to.
+type AuditChangeOutputStream string
+
+const (
+	AuditChangeOutputStdout AuditChangeOutputStream = "stdout"
+	AuditChangeOutputStderr AuditChangeOutputStream = "stderr"
+)
+
+// AuditChangeEvent is one streamed apply event; ID names the queued change it belongs to.
+type AuditChangeEvent struct {
+	Type     AuditChangeEventType      `json:"type"`
+	RunID    string                    `json:"run_id"`
+	ID       string                    `json:"id,omitempty"`
+	Stream   AuditChangeOutputStream   `json:"stream,omitempty"`
+	Text     string                    `json:"text,omitempty"`
+	Event    *SyncEvent                `json:"event,omitempty"`
+	Result   *AuditChangeApplyResult   `json:"result,omitempty"`
+	Response *AuditChangeApplyResponse `json:"response,omitempty"`
+}
+
+// AuditQueueState is the review-before-apply queue persisted between browser sessions.
+type AuditQueueState struct {
+	Changes   []AuditQueuedChange `json:"changes"`
+	UpdatedAt *time.Time          `json:"updated_at,omitempty"`
+	Error     string              `json:"error,omitempty"`
+}
+
+// ActionHistoryKindWorkflow marks the history entries of workflow runs, one per repository.
+const ActionHistoryKindWorkflow = string(AuditChangeKindWorkflow)
+
+// ActionHistoryEntry records one applied audit change or workflow run.
+type ActionHistoryEntry struct {
+	RecordedAt time.Time `json:"recorded_at"`
+	Actor      string    `json:"actor"`
+	Kind       string    `json:"kind"`
+	Path       string    `json:"path"`
+	Status     string    `json:"status"`
+	Message    string    `json:"message,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	// Output is the tail of the combined stdout and stderr, bounded so the history stays small.
+	Output string `json:"output,omitempty"`
+}
+
+// ActionHistoryFilter narrows the action history; empty fields match every entry.
+type ActionHistoryFilter struct {
+	Kind   string
+	Status string
+	// Path matches entries whose path contains it, ignoring case.
+	Path  string
+	Since time.Time
+	// Limit caps the number of returned entries; zero returns every match.
+	Limit int
+}
+
+// ActionHistory lists history entries newest first.
+type ActionHistory struct {
+	Entries []ActionHistoryEntry `json:"entries"`
+	Total   int                  `json:"total"`
+	Error   string               `json:"error,omitempty"`
+}
+
+// AuditChangeCancelRequest cancels one queued change of a streamed apply, or the whole run when ChangeID is empty.
+type AuditChangeCancelRequest struct {
+	RunID    string `json:"run_id"`
+	ChangeID string `json:"change_id,omitempty"`
 }
 
 // WorkflowPrimitiveCatalog describes the workflow primitives exposed through the web interface.
@@ -303,3 +554,213 @@ type WorkflowPrimitiveApplyResponse struct {
 	Results []WorkflowPrimitiveApplyResult `json:"results,omitempty"`
 	Error   string                         `json:"error,omitempty"`
 }
+
+// WorkflowSource identifies where a runnable workflow is defined.
+type WorkflowSource string
+
+const (
+	WorkflowSourcePrimitive WorkflowSource = "primitive"
+	WorkflowSourcePreset    WorkflowSource = "preset"
+	WorkflowSourceFile      WorkflowSource = "file"
+)
+
+// WorkflowCatalog lists the workflows the web interface can plan and run.
+type WorkflowCatalog struct {
+	Workflows []WorkflowDescriptor `json:"workflows,omitempty"`
+	Directory string               `json:"directory,omitempty"`
+	Error     string               `json:"error,omitempty"`
+}
+
+// WorkflowDescriptor captures one runnable workflow. Preset and workflow file parameters are generated
+// from the workflow variables the configuration reads.
+type WorkflowDescriptor struct {
+	ID          string                                 `json:"id"`
+	Source      WorkflowSource                         `json:"source"`
+	Label       string                                 `json:"label"`
+	Description string                                 `json:"description,omitempty"`
+	Path        string                                 `json:"path,omitempty"`
+	Parameters  []WorkflowPrimitiveParameterDescriptor `json:"parameters,omitempty"`
+	Error       string                                 `json:"error,omitempty"`
+}
+
+// WorkflowRunRequest selects one workflow, its parameter values, and the repositories a plan previews.
+// The browser runs the plan as one workflow change per repository through the streamed apply.
+type WorkflowRunRequest struct {
+	WorkflowID      string         `json:"workflow_id"`
+	Parameters      map[string]any `json:"parameters,omitempty"`
+	RepositoryPaths []string       `json:"repository_paths"`
+}
+
+// WorkflowPlan previews the resolved steps of one workflow run.
+type WorkflowPlan struct {
+	WorkflowID      string             `json:"workflow_id"`
+	Label           string             `json:"label,omitempty"`
+	RepositoryPaths []string           `json:"repository_paths,omitempty"`
+	Variables       map[string]string  `json:"variables,omitempty"`
+	Steps           []WorkflowPlanStep `json:"steps,omitempty"`
+	Error           string             `json:"error,omitempty"`
+}
+
+// WorkflowPlanStep describes one resolved workflow step; Options holds its `with` block as YAML.
+type WorkflowPlanStep struct {
+	Name    string   `json:"name"`
+	Command string   `json:"command"`
+	After   []string `json:"after,omitempty"`
+	Options string   `json:"options,omitempty"`
+}
+
+// SyncPreviewRequest selects the repository, target branch, and dirty-worktree policy of one strict sync.
+// SyncStrategy takes the AuditChangeSyncStrategy values.
+type SyncPreviewRequest struct {
+	Path         string `json:"path"`
+	Branch       string `json:"branch,omitempty"`
+	SyncStrategy string `json:"sync_strategy,omitempty"`
+}
+
+// SyncPreview describes the branch, remotes, review base, and dirty-work clusters a strict sync would use.
+type SyncPreview struct {
+	Path             string        `json:"path"`
+	CurrentBranch    string        `json:"current_branch,omitempty"`
+	TargetBranch     string        `json:"target_branch,omitempty"`
+	TargetSource     string        `json:"target_source,omitempty"`
+	PushRemote       string        `json:"push_remote,omitempty"`
+	BaseRemote       string        `json:"base_remote,omitempty"`
+	DefaultBranch    string        `json:"default_branch,omitempty"`
+	ReviewBase       string        `json:"review_base,omitempty"`
+	ReviewBaseSource string        `json:"review_base_source,omitempty"`
+	GeneratedBranch  bool          `json:"generated_branch,omitempty"`
+	DirtyAction      string        `json:"dirty_action,omitempty"`
+	Clusters         []SyncCluster `json:"clusters,omitempty"`
+	// Blocker explains why the sync would stop before changing anything.
+	Blocker string `json:"blocker,omitempty"`
+	// Handoff is the recovery still pending from an earlier sync.
+	Handoff *SyncHandoff `json:"handoff,omitempty"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// SyncCluster is one top-level path group that sync commits on its own.
+type SyncCluster struct {
+	Root           string   `json:"root"`
+	TrackedPaths   []string `json:"tracked_paths,omitempty"`
+	UntrackedPaths []string `json:"untracked_paths,omitempty"`
+}
+
+// SyncEvent is one structured event reported while a strict sync runs.
+type SyncEvent struct {
+	Level   string            `json:"level"`
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// SyncHandoff explains a sync that stopped in SYNC_SWITCH_HANDOFF or AI_MERGE_HANDOFF and how to finish it.
+type SyncHandoff struct {
+	Code          string   `json:"code"`
+	Kind          string   `json:"kind,omitempty"`
+	Message       string   `json:"message,omitempty"`
+	Reason        string   `json:"reason,omitempty"`
+	TargetBranch  string   `json:"target_branch,omitempty"`
+	RecordPath    string   `json:"record_path,omitempty"`
+	RecoverySteps []string `json:"recovery_steps,omitempty"`
+	// RecoverCommand completes the remaining steps from a terminal.
+	RecoverCommand string `json:"recover_command,omitempty"`
+}
+
+// PullRequestDashboardRequest selects the roots whose repositories the pull request dashboard lists.
+type PullRequestDashboardRequest struct {
+	Roots []string `json:"roots"`
+}
+
+// PullRequestDashboard lists pull requests per repository under the requested roots.
+type PullRequestDashboard struct {
+	Roots        []string                `json:"roots,omitempty"`
+	Repositories []PullRequestRepository `json:"repositories"`
+	Error        string                  `json:"error,omitempty"`
+}
+
+// PullRequestRepository groups the open pull requests of one repository into stacks. Merged lists
+// merged pull requests whose head branch is still checked out locally, so it can be deleted.
+type PullRequestRepository struct {
+	Path          string                  `json:"path"`
+	Name          string                  `json:"name"`
+	Repository    string                  `json:"repository,omitempty"`
+	CurrentBranch string                  `json:"current_branch,omitempty"`
+	Stacks        []PullRequestStack      `json:"stacks"`
+	Merged        []PullRequestDescriptor `json:"merged"`
+	Error         string                  `json:"error,omitempty"`
+}
+
+// PullRequestStack is a chain of open pull requests, each based on the head of the one before it.
+// Base is the branch the bottom pull request merges into; a pull request outside any chain forms a
+// stack of its own.
+type PullRequestStack struct {
+	Base         string                  `json:"base"`
+	PullRequests []PullRequestDescriptor `json:"pull_requests"`
+}
+
+// Pull request states and local sync states reported by the dashboard.
+const (
+	PullRequestStateOpen         = "open"
+	PullRequestStateMerged       = "merged"
+	PullRequestSyncStateInSync   = "in_sync"
+	PullRequestSyncStateAhead    = "ahead"
+	PullRequestSyncStateBehind   = "behind"
+	PullRequestSyncStateDiverged = "diverged"
+	PullRequestSyncStateMissing  = "missing"
+	PullRequestSyncStateUnknown  = "unknown"
+)
+
+// PullRequestDescriptor captures one pull request with its local branch. ReviewBase is the parent
+// recorded in the branch's gix-review-base config, and Depth is its position within its stack.
+// SyncState compares the local branch with the pull request head; unknown means the head commit has
+// not been fetched.
+type PullRequestDescriptor struct {
+	Number          int    `json:"number"`
+	Title           string `json:"title"`
+	State           string `json:"state"`
+	HeadBranch      string `json:"head_branch"`
+	BaseBranch      string `json:"base_branch"`
+	HeadCommit      string `json:"head_commit,omitempty"`
+	HeadRepository  string `json:"head_repository,omitempty"`
+	CrossRepository bool   `json:"cross_repository,omitempty"`
+	ReviewBase      string `json:"review_base,omitempty"`
+	Depth           int    `json:"depth"`
+	LocalBranch     bool   `json:"local_branch"`
+	Current         bool   `json:"current,omitempty"`
+	SyncState       string `json:"sync_state"`
+	Ahead           int    `json:"ahead,omitempty"`
+	Behind          int    `json:"behind,omitempty"`
+}
+
+// PackageDashboardRequest selects the roots whose repositories the package dashboard lists.
+type PackageDashboardRequest struct {
+	Roots []string `json:"roots"`
+}
+
+// PackageDashboard lists the container package of each repository under the requested roots.
+type PackageDashboard struct {
+	Roots    []string            `json:"roots,omitempty"`
+	Packages []PackageDescriptor `json:"packages"`
+	Error    string              `json:"error,omitempty"`
+}
+
+// PackageDescriptor lists every version of the GHCR container package published for the repository
+// at Path, newest first.
+type PackageDescriptor struct {
+	Path      string                     `json:"path"`
+	Owner     string                     `json:"owner,omitempty"`
+	OwnerType string                     `json:"owner_type,omitempty"`
+	Package   string                     `json:"package,omitempty"`
+	Versions  []PackageVersionDescriptor `json:"versions"`
+	Error     string                     `json:"error,omitempty"`
+}
+
+// PackageVersionDescriptor describes one package version. Name is its manifest digest; Size is set
+// only when the packages API reports one.
+type PackageVersionDescriptor struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name,omitempty"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      *int64    `json:"size,omitempty"`
+}
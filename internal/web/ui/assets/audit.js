@@ -2,40 +2,73 @@
 
 import {
   auditChangeKindCommitChangesValue,
-  auditApplyEndpoint,
+  auditApplyCancelEndpoint,
+  auditApplyStreamEndpoint,
+  auditChangeKindClosePullRequestValue,
+  auditChangeKindPackageRetentionValue,
   auditChangeKindConvertProtocolValue,
   auditChangeKindDeleteFolderValue,
+  auditChangeKindDeleteMergedBranchValue,
   auditChangeKindRenameFolderValue,
+  auditChangeKindRetargetPullRequestValue,
   auditChangeKindSyncWithRemoteValue,
   auditChangeKindUpdateChangelogValue,
   auditChangeKindUpdateCanonicalValue,
+  auditChangeStatusCanceledValue,
+  auditChangeStatusFailedValue,
+  auditChangeStatusHandoffValue,
+  auditChangeStatusRunningValue,
   auditChangeStatusSkippedValue,
   auditChangeStatusSucceededValue,
   auditColumnLabels,
+  auditDepthFullValue,
   auditDirtyFilesPreviewLimit,
+  auditExportEndpoint,
   auditInspectEndpoint,
+  auditQueueEndpoint,
   auditQueueSummary,
+  auditApplyStatusTokenClass,
+  auditSortAscendingValue,
+  auditSortDescendingValue,
   auditSyncStrategyCommitChangesValue,
   auditSyncStrategyRequireCleanValue,
   auditSyncStrategyStashChangesValue,
   elements,
   state,
+  activeWorkspace,
   appendEmptyState,
+  applyAuditView,
   appendToken,
   checkedRepositories,
   clearRunnerOutput,
+  formatAuditChangeKind,
+  readAuditChangeEvents,
   renderRunError,
   setStatus,
   summarizeAuditSelectionValues,
   typedAuditHeaderColumns,
+  workspaceAuditView,
+  writeAuditViewLocation,
 } from "./shared.js";
 import {
   activeRepositoryTreeFolderPath,
   workingFolderRoots,
 } from "./repo_tree.js";
+import {
+  loadActionHistory,
+} from "./history.js";
 
 const remoteProtocolSSHValue = "ssh";
 const remoteProtocolHTTPSValue = "https";
+const auditExportDefaultFormatValue = "csv";
+
+// The audit table shows the full repository path where the audit reports name the folder.
+const auditExportColumnNames = Object.freeze({
+  path: "folder_name",
+});
+
+/** @type {() => void} */
+let auditQueueAppliedHandler = () => {};
 
 export function renderAuditTaskState() {
   const auditScopeRoots = workingFolderRoots();
@@ -89,6 +122,8 @@ function currentAuditInspectionRequest() {
   return {
     roots: resolveAuditRoots(),
     include_all: Boolean(elements.auditIncludeAll?.checked),
+    refresh: Boolean(elements.auditRefresh?.checked),
+    depth: elements.auditDepth?.value || auditDepthFullValue,
   };
 }
 
@@ -113,9 +148,13 @@ async function fetchAuditInspection(inspectionRequest) {
 function replaceAuditInspectionSnapshot(inspectionRequest, inspection, resetFilters) {
   state.auditInspectionRoots = (inspectionRequest.roots || []).slice();
   state.auditInspectionIncludeAll = Boolean(inspectionRequest.include_all);
+  state.auditInspectionDepth = inspectionRequest.depth || auditDepthFullValue;
   state.auditInspectionRows = (inspection.rows || []).slice();
+  state.auditInspectionSnapshotID = inspection.snapshot_id || "";
   if (resetFilters) {
-    state.auditColumnFilters = {};
+    // A fresh audit starts from the view a deep link carried, else from the one saved with the active workspace.
+    applyAuditView(state.auditLinkedView || workspaceAuditView(activeWorkspace()));
+    state.auditLinkedView = null;
     state.expandedAuditDirtyFilePaths = [];
   } else {
     state.expandedAuditDirtyFilePaths = state.expandedAuditDirtyFilePaths.filter((rowPath) => (
@@ -129,6 +168,12 @@ function reconcileAuditQueueToInspection() {
   let removedQueuedActions = 0;
 
   state.auditQueue.forEach((change) => {
+    // Pull request and package changes come from their dashboards, not from audit findings.
+    if (pullRequestChangeKind(change.kind) || change.kind === auditChangeKindPackageRetentionValue) {
+      nextQueue.push(change);
+      return;
+    }
+
     const row = state.auditInspectionRows.find((candidate) => candidate.path === change.path);
     if (!row) {
       removedQueuedActions += 1;
@@ -166,6 +211,7 @@ async function refreshCurrentAuditInspection(resetFilters) {
   const inspectionRequest = {
     roots: state.auditInspectionRoots.slice(),
     include_all: state.auditInspectionIncludeAll,
+    depth: state.auditInspectionDepth || auditDepthFullValue,
   };
   if (inspectionRequest.roots.length === 0) {
     return { removedQueuedActions: 0 };
@@ -219,21 +265,27 @@ function renderTypedAuditTable(rows) {
   elements.auditResultsHead.innerHTML = "";
   elements.auditResultsBody.innerHTML = "";
 
+  const visibleColumns = visibleAuditColumns();
   const headerRow = document.createElement("tr");
-  typedAuditHeaderColumns.forEach((headerName) => {
+  visibleColumns.forEach((headerName) => {
     const headerCell = document.createElement("th");
     headerCell.scope = "col";
     const columnClassName = auditTableColumnClass(headerName);
     if (columnClassName) {
       headerCell.classList.add(columnClassName);
     }
+    const sortDirection = state.auditSort.column === headerName ? state.auditSort.direction : "";
+    headerCell.ariaSort = sortDirection === auditSortAscendingValue ? "ascending" : sortDirection === auditSortDescendingValue ? "descending" : "none";
 
     const headerStack = document.createElement("div");
     headerStack.className = "audit-header-stack";
 
-    const headerLabel = document.createElement("span");
-    headerLabel.className = "audit-header-label";
-    headerLabel.textContent = auditColumnLabels[headerName] || headerName;
+    const headerLabel = document.createElement("button");
+    headerLabel.type = "button";
+    headerLabel.className = "audit-header-label audit-sort-button";
+    headerLabel.dataset.auditSort = headerName;
+    headerLabel.title = "Sort by this column";
+    headerLabel.textContent = `${auditColumnLabels[headerName] || headerName}${sortDirection === auditSortAscendingValue ? " ↑" : sortDirection === auditSortDescendingValue ? " ↓" : ""}`;
     headerStack.append(headerLabel);
 
     const filterControl = renderAuditColumnFilterControl(headerName, rows);
@@ -252,11 +304,11 @@ function renderTypedAuditTable(rows) {
   headerRow.append(actionsHeaderCell);
   elements.auditResultsHead.append(headerRow);
 
-  const filteredRows = filterTypedAuditRows(rows);
+  const filteredRows = visibleAuditRows(rows);
   if (filteredRows.length === 0) {
     const emptyRow = document.createElement("tr");
     const emptyCell = document.createElement("td");
-    emptyCell.colSpan = typedAuditHeaderColumns.length + 1;
+    emptyCell.colSpan = visibleColumns.length + 1;
     emptyCell.textContent = rows.length === 0
       ? "No repositories or folders matched the inspected roots."
       : "No audit rows match the current column filters.";
@@ -265,17 +317,16 @@ function renderTypedAuditTable(rows) {
   } else {
     filteredRows.forEach((row) => {
       const rowElement = document.createElement("tr");
-      typedAuditRecord(row).forEach((value, valueIndex) => {
-        const cell = document.createElement(valueIndex === 0 ? "th" : "td");
-        const headerName = typedAuditHeaderColumns[valueIndex] || "";
+      visibleColumns.forEach((headerName, columnIndex) => {
+        const cell = document.createElement(columnIndex === 0 ? "th" : "td");
         const columnClassName = auditTableColumnClass(headerName);
-        if (valueIndex === 0) {
+        if (columnIndex === 0) {
           cell.scope = "row";
         }
         if (columnClassName) {
           cell.classList.add(columnClassName);
         }
-        renderAuditTableCell(cell, row, headerName, value);
+        renderAuditTableCell(cell, row, headerName, typedAuditColumnValue(row, headerName));
         rowElement.append(cell);
       });
 
@@ -312,9 +363,132 @@ function renderTypedAuditTable(rows) {
 
   elements.auditResultsSummary.textContent = formatAuditResultsSummary(filteredRows.length, rows.length);
   elements.auditTableShell?.classList.toggle("audit-table-shell-dirty-expanded", state.expandedAuditDirtyFilePaths.length > 0);
+  renderAuditColumnToggles();
+  writeAuditViewLocation({
+    filters: state.auditColumnFilters,
+    sort: state.auditSort,
+    hiddenColumns: state.auditHiddenColumns,
+  });
   elements.auditResultsPanel.hidden = false;
 }
 
+/** The path column always shows; it names the repository every row acts on. */
+function visibleAuditColumns() {
+  return typedAuditHeaderColumns.filter((headerName, columnIndex) => columnIndex === 0 || !state.auditHiddenColumns.includes(headerName));
+}
+
+/** The rows the table shows, filtered and sorted; exports write the same rows in the same order. */
+function visibleAuditRows(rows) {
+  const filteredRows = filterTypedAuditRows(rows);
+  const { column, direction } = state.auditSort;
+  if (!column || !typedAuditHeaderColumns.includes(column)) {
+    return filteredRows;
+  }
+  const directionFactor = direction === auditSortDescendingValue ? -1 : 1;
+  return filteredRows.slice().sort((left, right) => directionFactor * String(typedAuditColumnValue(left, column) || "")
+    .localeCompare(String(typedAuditColumnValue(right, column) || ""), undefined, { numeric: true, sensitivity: "base" }));
+}
+
+function renderAuditColumnToggles() {
+  if (!elements.auditColumnToggles) {
+    return;
+  }
+  elements.auditColumnToggles.innerHTML = "";
+  typedAuditHeaderColumns.slice(1).forEach((headerName) => {
+    const toggleLabel = document.createElement("label");
+    toggleLabel.className = "audit-column-toggle";
+    const toggle = document.createElement("input");
+    toggle.type = "checkbox";
+    toggle.checked = !state.auditHiddenColumns.includes(headerName);
+    toggle.dataset.auditColumnToggle = headerName;
+    toggleLabel.append(toggle, auditColumnLabels[headerName] || headerName);
+    elements.auditColumnToggles.append(toggleLabel);
+  });
+}
+
+/** Cycles the clicked column through ascending, descending, and the inspection order. */
+export function handleAuditResultsHeadClick(event) {
+  const eventTarget = event.target;
+  if (!(eventTarget instanceof HTMLElement)) {
+    return;
+  }
+  const sortButton = eventTarget.closest("[data-audit-sort]");
+  if (!(sortButton instanceof HTMLButtonElement)) {
+    return;
+  }
+
+  const headerName = sortButton.dataset.auditSort || "";
+  if (state.auditSort.column !== headerName) {
+    state.auditSort = { column: headerName, direction: auditSortAscendingValue };
+  } else if (state.auditSort.direction === auditSortAscendingValue) {
+    state.auditSort = { column: headerName, direction: auditSortDescendingValue };
+  } else {
+    state.auditSort = { column: "", direction: auditSortAscendingValue };
+  }
+  renderTypedAuditTable(state.auditInspectionRows);
+}
+
+/** Hides or shows a column; hiding a column also drops its filter so no row disappears unexplained. */
+export function handleAuditColumnTogglesChange(event) {
+  const eventTarget = event.target;
+  if (!(eventTarget instanceof HTMLInputElement)) {
+    return;
+  }
+  const headerName = eventTarget.dataset.auditColumnToggle || "";
+  if (!headerName) {
+    return;
+  }
+
+  if (eventTarget.checked) {
+    state.auditHiddenColumns = state.auditHiddenColumns.filter((hiddenColumn) => hiddenColumn !== headerName);
+  } else if (!state.auditHiddenColumns.includes(headerName)) {
+    state.auditHiddenColumns = state.auditHiddenColumns.concat(headerName);
+    const nextFilters = { ...state.auditColumnFilters };
+    delete nextFilters[headerName];
+    state.auditColumnFilters = nextFilters;
+  }
+  renderTypedAuditTable(state.auditInspectionRows);
+}
+
+/**
+ * Downloads the filtered and sorted rows with their visible columns, rendered by the `gix audit` reports
+ * from the inspection snapshot the table shows, so the file matches the table.
+ */
+export async function exportAuditTable() {
+  const exportFormat = elements.auditExportFormat?.value || auditExportDefaultFormatValue;
+  elements.auditExport.disabled = true;
+  try {
+    const response = await fetch(auditExportEndpoint, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({
+        snapshot_id: state.auditInspectionSnapshotID,
+        format: exportFormat,
+        paths: visibleAuditRows(state.auditInspectionRows).map((row) => row.path),
+        columns: visibleAuditColumns().map((headerName) => auditExportColumnNames[headerName] || headerName),
+      }),
+    });
+    /** @type {import("./shared.js").AuditExport} */
+    const auditExport = await response.json();
+    if (!response.ok || auditExport.error) {
+      throw new Error(auditExport.error || `Failed to export the audit: ${response.status}`);
+    }
+
+    const payload = new Blob([auditExport.content || ""], { type: auditExport.content_type || "text/plain" });
+    const downloadURL = URL.createObjectURL(payload);
+    const link = document.createElement("a");
+    link.href = downloadURL;
+    link.download = auditExport.file_name || `gix-audit.${exportFormat}`;
+    link.click();
+    URL.revokeObjectURL(downloadURL);
+    renderRunError("");
+  } catch (error) {
+    renderRunError(String(error instanceof Error ? error.message : error));
+  } finally {
+    elements.auditExport.disabled = false;
+  }
+}
+
 function hideAuditResults() {
   elements.auditResultsPanel.hidden = true;
   elements.auditResultsSummary.textContent = "";
@@ -332,6 +506,23 @@ function renderAuditTableCell(cell, row, headerName, value) {
   }
 
   cell.textContent = value || " ";
+  if (auditBranchInventoryColumn(headerName) && row.branch_listing) {
+    cell.title = row.branch_listing;
+  }
+}
+
+function auditBranchCountColumn(headerName) {
+  return headerName === "upstream_ahead"
+    || headerName === "upstream_behind"
+    || headerName === "default_ahead"
+    || headerName === "default_behind"
+    || auditBranchInventoryColumn(headerName);
+}
+
+function auditBranchInventoryColumn(headerName) {
+  return headerName === "no_upstream_branches"
+    || headerName === "upstream_gone_branches"
+    || headerName === "merged_branches";
 }
 
 function renderAuditDirtyFilesCell(cell, row) {
@@ -629,17 +820,51 @@ function toggleAuditDirtyFilesExpanded(rowPath) {
 }
 
 function queueAuditChange(row, action) {
-  const nextChangeID = `audit-change-${state.nextAuditChangeSequence}`;
-  const candidate = {
-    id: nextChangeID,
+  enqueueAuditChange({
     kind: action.kind,
     path: row.path,
     title: action.title,
     description: action.description,
     ...action.buildChange(row),
+  });
+}
+
+/**
+ * Adds a pull request change from the pull request dashboard to the review-before-apply queue.
+ * A change of the same kind for the same pull request replaces the queued one.
+ * @param {Omit<import("./shared.js").AuditQueueEntry, "id">} change
+ */
+export function queuePullRequestChange(change) {
+  enqueueAuditChange(change);
+}
+
+/**
+ * Adds a retention change from the package panel to the review-before-apply queue. A queued retention
+ * for the same repository is replaced and has to be confirmed again.
+ * @param {Omit<import("./shared.js").AuditQueueEntry, "id">} change
+ */
+export function queuePackageRetentionChange(change) {
+  enqueueAuditChange(change);
+}
+
+/** Registers the callback that runs after an apply of the queue finishes. */
+export function setAuditQueueAppliedHandler(handler) {
+  auditQueueAppliedHandler = handler;
+}
+
+/** @param {Omit<import("./shared.js").AuditQueueEntry, "id">} change */
+function enqueueAuditChange(change) {
+  const nextChangeID = `audit-change-${state.nextAuditChangeSequence}`;
+  const candidate = {
+    id: nextChangeID,
+    ...change,
   };
 
-  const existingIndex = state.auditQueue.findIndex((change) => change.path === candidate.path && change.kind === candidate.kind);
+  const existingIndex = state.auditQueue.findIndex((queuedChange) => (
+    queuedChange.path === candidate.path
+    && queuedChange.kind === candidate.kind
+    && queuedChange.pull_request === candidate.pull_request
+  ));
   if (existingIndex >= 0) {
     const existingChange = state.auditQueue[existingIndex];
     state.auditQueue[existingIndex] = {
@@ -665,6 +890,7 @@ function renderAuditQueueState() {
 }
 
 export function renderAuditQueue() {
+  void persistAuditQueue();
   const shouldShowQueue = state.auditQueueVisible || state.auditQueue.length > 0;
   if (!shouldShowQueue) {
     elements.auditQueuePanel.hidden = true;
@@ -691,12 +917,23 @@ export function renderAuditQueue() {
       title.textContent = change.title;
       heading.append(title);
 
-      const removeButton = document.createElement("button");
-      removeButton.type = "button";
-      removeButton.className = "secondary-button audit-queue-remove";
-      removeButton.dataset.queueRemoveId = change.id;
-      removeButton.textContent = "Remove";
-      heading.append(removeButton);
+      const applyStatus = state.auditApplyStatuses[change.id] || "";
+      if (state.auditQueueApplying && !auditApplyStatusFinal(applyStatus)) {
+        const cancelButton = document.createElement("button");
+        cancelButton.type = "button";
+        cancelButton.className = "secondary-button audit-queue-cancel";
+        cancelButton.dataset.queueCancelId = change.id;
+        cancelButton.textContent = "Cancel";
+        heading.append(cancelButton);
+      } else {
+        const removeButton = document.createElement("button");
+        removeButton.type = "button";
+        removeButton.className = "secondary-button audit-queue-remove";
+        removeButton.dataset.queueRemoveId = change.id;
+        removeButton.textContent = "Remove";
+        removeButton.disabled = state.auditQueueApplying;
+        heading.append(removeButton);
+      }
 
       const description = document.createElement("p");
       description.className = "audit-queue-description";
@@ -706,8 +943,21 @@ export function renderAuditQueue() {
       meta.className = "audit-queue-meta";
       appendToken(meta, formatAuditChangeKind(change.kind), "token-default");
       appendToken(meta, change.path, "token-context");
+      if (change.repository && change.pull_request) {
+        appendToken(meta, `${change.repository}#${change.pull_request}`, "token-context");
+      }
+      if (applyStatus) {
+        appendToken(meta, applyStatus, auditApplyStatusTokenClass(applyStatus));
+      }
 
       container.append(heading, description, meta);
+      if (Object.hasOwn(state.auditApplyLogs, change.id)) {
+        const log = document.createElement("pre");
+        log.className = "terminal-window audit-queue-log";
+        log.dataset.queueLogId = change.id;
+        log.textContent = state.auditApplyLogs[change.id];
+        container.append(log);
+      }
       const options = renderAuditQueueOptions(change);
       if (options) {
         container.append(options);
@@ -720,6 +970,66 @@ export function renderAuditQueue() {
   elements.auditQueueApply.disabled = state.auditQueue.length === 0 || state.auditQueueApplying || !auditQueueCanApply();
 }
 
+/** Restores the queue the server persisted, so a refresh keeps pending changes. */
+export async function restoreAuditQueue() {
+  /** @type {import("./shared.js").AuditQueueState} */
+  let queue;
+  try {
+    const response = await fetch(auditQueueEndpoint);
+    queue = await response.json();
+    if (!response.ok) {
+      throw new Error(queue.error || `Failed to load the saved queue: ${response.status}`);
+    }
+  } catch (error) {
+    queue = { changes: [], error: String(error instanceof Error ? error.message : error) };
+  }
+
+  const changes = (queue.changes || []).map((change) => ({
+    ...change,
+    title: change.title || formatAuditChangeKind(change.kind),
+    description: change.description || "",
+  }));
+  state.auditQueue = changes;
+  state.auditQueueSavedJSON = JSON.stringify(changes);
+  state.auditQueueRestored = true;
+  state.auditQueueVisible = changes.length > 0;
+  state.nextAuditChangeSequence = changes.reduce((nextSequence, change) => {
+    const sequence = Number.parseInt(String(change.id).replace(/^audit-change-/, ""), 10);
+    return Number.isFinite(sequence) && sequence >= nextSequence ? sequence + 1 : nextSequence;
+  }, state.nextAuditChangeSequence);
+  if (queue.error) {
+    renderRunError(queue.error);
+  }
+  renderAuditQueue();
+}
+
+/** Saves the queue whenever it differs from the last saved copy. */
+async function persistAuditQueue() {
+  if (!state.auditQueueRestored) {
+    return;
+  }
+  const queueJSON = JSON.stringify(state.auditQueue);
+  if (queueJSON === state.auditQueueSavedJSON) {
+    return;
+  }
+  state.auditQueueSavedJSON = queueJSON;
+
+  try {
+    const response = await fetch(auditQueueEndpoint, {
+      method: "PUT",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ changes: state.auditQueue }),
+    });
+    if (!response.ok) {
+      const payload = await response.json().catch(() => ({ error: `HTTP ${response.status}` }));
+      throw new Error(payload.error || `Failed to save the queue: ${response.status}`);
+    }
+  } catch (error) {
+    state.auditQueueSavedJSON = "";
+    renderRunError(`The queue was not saved: ${String(error instanceof Error ? error.message : error)}`);
+  }
+}
+
 export function clearAuditQueue() {
   state.auditQueue = [];
   renderRunError("");
@@ -732,6 +1042,13 @@ export function handleAuditQueueListClick(event) {
     return;
   }
 
+  const cancelButton = eventTarget.closest("[data-queue-cancel-id]");
+  if (cancelButton instanceof HTMLButtonElement) {
+    cancelButton.disabled = true;
+    void cancelQueuedAuditChange(cancelButton.dataset.queueCancelId || "");
+    return;
+  }
+
   const removeButton = eventTarget.closest("[data-queue-remove-id]");
   if (!(removeButton instanceof HTMLButtonElement)) {
     return;
@@ -772,6 +1089,12 @@ export function handleAuditQueueListChange(event) {
   const syncStrategySelect = eventTarget.closest("[data-queue-sync-strategy]");
   if (syncStrategySelect instanceof HTMLSelectElement) {
     updateAuditQueueText(syncStrategySelect.dataset.queueSyncStrategy || "", "sync_strategy", syncStrategySelect.value);
+    return;
+  }
+
+  const baseBranchInput = eventTarget.closest("[data-queue-base-branch]");
+  if (baseBranchInput instanceof HTMLInputElement) {
+    updateAuditQueueText(baseBranchInput.dataset.queueBaseBranch || "", "base_branch", baseBranchInput.value.trim());
   }
 }
 
@@ -831,13 +1154,16 @@ export async function applyAuditQueue() {
   }
 
   state.auditQueueApplying = true;
+  state.auditApplyRunID = "";
+  state.auditApplyLogs = {};
+  state.auditApplyStatuses = {};
   renderAuditQueue();
   clearRunnerOutput();
   renderRunError("");
-  setStatus("loading");
+  setStatus("running");
 
   try {
-    const response = await fetch(auditApplyEndpoint, {
+    const response = await fetch(auditApplyStreamEndpoint, {
       method: "POST",
       headers: { "Content-Type": "application/json" },
       body: JSON.stringify({
@@ -851,15 +1177,23 @@ export async function applyAuditQueue() {
           source_protocol: change.source_protocol || "",
           target_protocol: change.target_protocol || "",
           sync_strategy: change.sync_strategy || "",
+          branch: change.branch || "",
+          repository: change.repository || "",
+          pull_request: change.pull_request || 0,
+          base_branch: change.base_branch || "",
+          package: change.package || "",
+          keep: change.keep || 0,
+          pinned_versions: change.pinned_versions || [],
+          delete_versions: change.delete_versions || [],
         })),
       }),
     });
-    if (!response.ok) {
+    if (!response.ok || !response.body) {
       const payload = await response.json().catch(() => ({ error: `HTTP ${response.status}` }));
       throw new Error(payload.error || `Failed to apply queued audit changes: ${response.status}`);
     }
 
-    const applyResponse = await response.json();
+    const applyResponse = await readAuditApplyStream(response.body);
     if (applyResponse.error) {
       throw new Error(applyResponse.error);
     }
@@ -893,6 +1227,9 @@ export async function applyAuditQueue() {
     if (failedResults.length > 0) {
       messages.unshift(failedResults.map(formatAuditApplyIssue).join("\n"));
     }
+    if (applyResponse.history_error) {
+      messages.push(`Action history not recorded: ${applyResponse.history_error}`);
+    }
 
     renderRunError(messages.join("\n"));
     if (failedResults.length > 0 || messages.some((message) => message.startsWith("Audit refresh failed:"))) {
@@ -905,10 +1242,95 @@ export async function applyAuditQueue() {
     setStatus("failed");
   } finally {
     state.auditQueueApplying = false;
+    state.auditApplyRunID = "";
     renderAuditQueueState();
+    void loadActionHistory();
+    auditQueueAppliedHandler();
+  }
+}
+
+/**
+ * Reads the Server-Sent Events of a streamed apply, updating each queued item's live log and
+ * status as events arrive, and resolves with the final response from the done event.
+ * @param {ReadableStream<Uint8Array>} body
+ * @returns {Promise<import("./shared.js").AuditChangeApplyResponse>}
+ */
+async function readAuditApplyStream(body) {
+  /** @type {import("./shared.js").AuditChangeApplyResult[]} */
+  const streamedResults = [];
+  /** @type {import("./shared.js").AuditChangeApplyResponse | null} */
+  let finalResponse = null;
+
+  await readAuditChangeEvents(body, (event) => {
+    switch (event.type) {
+      case "run":
+        state.auditApplyRunID = event.run_id;
+        break;
+      case "started":
+        state.auditApplyStatuses[event.id || ""] = auditChangeStatusRunningValue;
+        state.auditApplyLogs[event.id || ""] = "";
+        renderAuditQueue();
+        break;
+      case "output":
+        appendAuditApplyLog(event.id || "", event.text || "");
+        break;
+      case "result":
+        if (event.result) {
+          state.auditApplyStatuses[event.result.id] = event.result.status;
+          streamedResults.push(event.result);
+          renderAuditApplyResults(streamedResults);
+          renderAuditQueue();
+        }
+        break;
+      case "done":
+        finalResponse = event.response || {};
+        break;
+    }
+  });
+
+  if (!finalResponse) {
+    throw new Error("The apply stream ended before reporting its results.");
+  }
+  return finalResponse;
+}
+
+function appendAuditApplyLog(changeID, text) {
+  state.auditApplyLogs[changeID] = (state.auditApplyLogs[changeID] || "") + text;
+  const log = elements.auditQueueList.querySelector(`[data-queue-log-id="${CSS.escape(changeID)}"]`);
+  if (log instanceof HTMLElement) {
+    log.textContent = state.auditApplyLogs[changeID];
+    log.scrollTop = log.scrollHeight;
+  }
+}
+
+async function cancelQueuedAuditChange(changeID) {
+  if (!changeID || !state.auditApplyRunID) {
+    return;
+  }
+
+  try {
+    const response = await fetch(auditApplyCancelEndpoint, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ run_id: state.auditApplyRunID, change_id: changeID }),
+    });
+    if (!response.ok) {
+      const payload = await response.json().catch(() => ({ error: `HTTP ${response.status}` }));
+      throw new Error(payload.error || `Failed to cancel queued audit change: ${response.status}`);
+    }
+  } catch (error) {
+    renderRunError(String(error));
   }
 }
 
+function auditApplyStatusFinal(status) {
+  return status === auditChangeStatusSucceededValue
+    || status === auditChangeStatusSkippedValue
+    || status === auditChangeStatusCanceledValue
+    || status === auditChangeStatusHandoffValue
+    || status === auditChangeStatusFailedValue;
+}
+
 function renderAuditApplyResults(results) {
   const stdoutSections = [];
   const stderrSections = [];
@@ -950,28 +1372,10 @@ function formatAuditApplyIssue(result) {
   if (result.status === auditChangeStatusSkippedValue) {
     return `${formatAuditChangeKind(result.kind)} skipped for ${result.path}`;
   }
-  return `${formatAuditChangeKind(result.kind)} failed for ${result.path}`;
-}
-
-function formatAuditChangeKind(kind) {
-  switch (kind) {
-    case auditChangeKindCommitChangesValue:
-      return "Commit changes";
-    case auditChangeKindConvertProtocolValue:
-      return "Switch remote protocol";
-    case auditChangeKindDeleteFolderValue:
-      return "Delete folder";
-    case auditChangeKindUpdateChangelogValue:
-      return "Update changelog";
-    case auditChangeKindRenameFolderValue:
-      return "Rename folder";
-    case auditChangeKindUpdateCanonicalValue:
-      return "Fix canonical remote";
-    case auditChangeKindSyncWithRemoteValue:
-      return "Sync with remote";
-    default:
-      return kind;
+  if (result.status === auditChangeStatusCanceledValue) {
+    return `${formatAuditChangeKind(result.kind)} canceled for ${result.path}`;
   }
+  return `${formatAuditChangeKind(result.kind)} failed for ${result.path}`;
 }
 
 function dequeueAuditActionLabel(label) {
@@ -992,6 +1396,12 @@ function renderAuditQueueOptions(change) {
       return renderProtocolQueueOptions(change);
     case auditChangeKindSyncWithRemoteValue:
       return renderSyncQueueOptions(change);
+    case auditChangeKindRetargetPullRequestValue:
+      return renderRetargetQueueOptions(change);
+    case auditChangeKindDeleteMergedBranchValue:
+      return renderDeleteMergedBranchQueueOptions(change);
+    case auditChangeKindPackageRetentionValue:
+      return renderPackageRetentionQueueOptions(change);
     default:
       return null;
   }
@@ -1105,11 +1515,88 @@ function renderSyncQueueOptions(change) {
   return container;
 }
 
+function renderRetargetQueueOptions(change) {
+  const container = document.createElement("div");
+  container.className = "audit-queue-options";
+
+  const heading = document.createElement("div");
+  heading.className = "audit-queue-options-heading";
+  heading.textContent = "Retarget options";
+
+  const label = document.createElement("label");
+  label.className = "audit-queue-option-row";
+  label.textContent = `New base for ${change.branch || "the pull request"}`;
+
+  const input = document.createElement("input");
+  input.type = "text";
+  input.className = "text-input audit-queue-select";
+  input.placeholder = "Base branch";
+  input.value = change.base_branch || "";
+  input.dataset.queueBaseBranch = change.id;
+
+  container.append(heading, label, input);
+  return container;
+}
+
+function renderDeleteMergedBranchQueueOptions(change) {
+  const container = document.createElement("div");
+  container.className = "audit-queue-options";
+
+  const warning = document.createElement("p");
+  warning.className = "audit-queue-warning";
+  warning.textContent = `This deletes ${change.branch} from origin and from the local repository. The merge is checked again on GitHub before anything is deleted.`;
+
+  const label = document.createElement("label");
+  label.className = "checkbox-row audit-queue-confirm";
+
+  const checkbox = document.createElement("input");
+  checkbox.type = "checkbox";
+  checkbox.checked = Boolean(change.confirm_delete);
+  checkbox.dataset.queueConfirmDelete = change.id;
+
+  const copy = document.createElement("span");
+  copy.textContent = "I understand this deletes the branch on both sides";
+
+  label.append(checkbox, copy);
+  container.append(warning, label);
+  return container;
+}
+
+function renderPackageRetentionQueueOptions(change) {
+  const container = document.createElement("div");
+  container.className = "audit-queue-options";
+
+  const warning = document.createElement("p");
+  warning.className = "audit-queue-warning";
+  const deleteCount = (change.delete_versions || []).length;
+  warning.textContent = `This permanently deletes ${deleteCount} ${deleteCount === 1 ? "version" : "versions"} of ${change.package}. The versions are listed again before anything is deleted, and nothing is deleted when the plan changed.`;
+
+  const label = document.createElement("label");
+  label.className = "checkbox-row audit-queue-confirm";
+
+  const checkbox = document.createElement("input");
+  checkbox.type = "checkbox";
+  checkbox.checked = Boolean(change.confirm_delete);
+  checkbox.dataset.queueConfirmDelete = change.id;
+
+  const copy = document.createElement("span");
+  copy.textContent = "I understand deleted package versions cannot be restored";
+
+  label.append(checkbox, copy);
+  container.append(warning, label);
+  return container;
+}
+
 function auditQueueCanApply() {
   return state.auditQueue.every((change) => {
-    if (change.kind === auditChangeKindDeleteFolderValue) {
+    if (change.kind === auditChangeKindDeleteFolderValue
+      || change.kind === auditChangeKindDeleteMergedBranchValue
+      || change.kind === auditChangeKindPackageRetentionValue) {
       return Boolean(change.confirm_delete);
     }
+    if (change.kind === auditChangeKindRetargetPullRequestValue) {
+      return Boolean(String(change.base_branch || "").trim());
+    }
     if (change.kind === auditChangeKindSyncWithRemoteValue) {
       return syncStrategyAllowed(String(change.sync_strategy || ""));
     }
@@ -1135,6 +1622,14 @@ function auditApplyPriority(kind) {
       return 35;
     case auditChangeKindCommitChangesValue:
       return 36;
+    case auditChangeKindRetargetPullRequestValue:
+      return 40;
+    case auditChangeKindClosePullRequestValue:
+      return 42;
+    case auditChangeKindDeleteMergedBranchValue:
+      return 44;
+    case auditChangeKindPackageRetentionValue:
+      return 46;
     case auditChangeKindDeleteFolderValue:
       return 50;
     case auditChangeKindRenameFolderValue:
@@ -1144,6 +1639,12 @@ function auditApplyPriority(kind) {
   }
 }
 
+function pullRequestChangeKind(kind) {
+  return kind === auditChangeKindClosePullRequestValue
+    || kind === auditChangeKindRetargetPullRequestValue
+    || kind === auditChangeKindDeleteMergedBranchValue;
+}
+
 function syncStrategyOptions() {
   return [
     { value: auditSyncStrategyRequireCleanValue, label: "Require clean worktree" },
@@ -1156,10 +1657,6 @@ function syncStrategyAllowed(syncStrategy) {
   return syncStrategyOptions().some((optionValue) => optionValue.value === syncStrategy);
 }
 
-function typedAuditRecord(row) {
-  return typedAuditHeaderColumns.map((headerName) => typedAuditColumnValue(row, headerName));
-}
-
 function auditDirtyFileEntries(row) {
   const entries = Array.isArray(row.dirty_file_entries) ? row.dirty_file_entries : [];
   if (entries.length > 0) {
@@ -1241,6 +1738,9 @@ function auditTableColumnClass(headerName) {
   if (headerName === "dirty_files") {
     return "audit-column-dirty-files";
   }
+  if (auditBranchCountColumn(headerName)) {
+    return "audit-column-count";
+  }
   return "";
 }
 
@@ -1328,6 +1828,20 @@ function typedAuditColumnValue(row, headerName) {
       return row.worktree_dirty;
     case "dirty_files":
       return auditDirtyFilesText(row);
+    case "upstream_ahead":
+      return row.upstream_ahead;
+    case "upstream_behind":
+      return row.upstream_behind;
+    case "default_ahead":
+      return row.default_ahead;
+    case "default_behind":
+      return row.default_behind;
+    case "no_upstream_branches":
+      return row.no_upstream_branches;
+    case "upstream_gone_branches":
+      return row.upstream_gone_branches;
+    case "merged_branches":
+      return row.merged_branches;
     default:
       return "";
   }
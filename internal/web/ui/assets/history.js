@@ -0,0 +1,152 @@
+// @ts-check
+
+import {
+  actionHistoryKindWorkflowValue,
+  auditApplyStatusTokenClass,
+  auditHistoryEndpoint,
+  elements,
+  formatAuditChangeKind,
+  state,
+  appendEmptyState,
+  appendToken,
+} from "./shared.js";
+
+const actionHistoryDisplayLimit = 200;
+const actionHistoryExportFileName = "gix-action-history.json";
+
+/** Reloads the history table with the current filters. */
+export async function loadActionHistory() {
+  /** @type {import("./shared.js").ActionHistory} */
+  let history;
+  try {
+    history = await fetchActionHistory(actionHistoryDisplayLimit);
+  } catch (error) {
+    history = { entries: [], total: 0, error: String(error instanceof Error ? error.message : error) };
+  }
+
+  state.actionHistory = history;
+  renderActionHistory();
+}
+
+/** Downloads every entry matching the current filters as a JSON document. */
+export async function exportActionHistory() {
+  elements.actionHistoryExport.disabled = true;
+  try {
+    const history = await fetchActionHistory(0);
+    const payload = new Blob([JSON.stringify(history.entries || [], null, 2)], { type: "application/json" });
+    const downloadURL = URL.createObjectURL(payload);
+    const link = document.createElement("a");
+    link.href = downloadURL;
+    link.download = actionHistoryExportFileName;
+    link.click();
+    URL.revokeObjectURL(downloadURL);
+  } catch (error) {
+    elements.actionHistorySummary.textContent = String(error instanceof Error ? error.message : error);
+  } finally {
+    elements.actionHistoryExport.disabled = false;
+  }
+}
+
+/**
+ * @param {number} limit zero requests every matching entry
+ * @returns {Promise<import("./shared.js").ActionHistory>}
+ */
+async function fetchActionHistory(limit) {
+  const query = new URLSearchParams();
+  const filters = {
+    kind: String(elements.actionHistoryKind.value || ""),
+    status: String(elements.actionHistoryStatus.value || ""),
+    path: String(elements.actionHistoryPath.value || "").trim(),
+  };
+  Object.entries(filters).forEach(([name, value]) => {
+    if (value) {
+      query.set(name, value);
+    }
+  });
+  if (limit > 0) {
+    query.set("limit", String(limit));
+  }
+
+  const response = await fetch(`${auditHistoryEndpoint}?${query.toString()}`);
+  /** @type {import("./shared.js").ActionHistory} */
+  const history = await response.json();
+  if (!response.ok) {
+    throw new Error(history.error || `Failed to load the action history: ${response.status}`);
+  }
+  return history;
+}
+
+function renderActionHistory() {
+  const history = state.actionHistory || { entries: [], total: 0 };
+  const entries = history.entries || [];
+  elements.actionHistoryBody.replaceChildren();
+
+  if (history.error) {
+    elements.actionHistorySummary.textContent = history.error;
+  } else if (entries.length < history.total) {
+    elements.actionHistorySummary.textContent = `Showing the latest ${entries.length} of ${history.total} actions`;
+  } else {
+    elements.actionHistorySummary.textContent = `${history.total} ${history.total === 1 ? "action" : "actions"}`;
+  }
+
+  if (entries.length === 0) {
+    const row = document.createElement("tr");
+    const cell = document.createElement("td");
+    cell.colSpan = 5;
+    appendEmptyState(cell, "No recorded actions match these filters.");
+    row.append(cell);
+    elements.actionHistoryBody.append(row);
+    return;
+  }
+
+  entries.forEach((entry) => {
+    elements.actionHistoryBody.append(renderActionHistoryRow(entry));
+  });
+}
+
+/** @param {import("./shared.js").ActionHistoryEntry} entry */
+function renderActionHistoryRow(entry) {
+  const row = document.createElement("tr");
+
+  const recordedCell = document.createElement("td");
+  recordedCell.textContent = new Date(entry.recorded_at).toLocaleString();
+  if (entry.actor) {
+    const actor = document.createElement("div");
+    actor.className = "panel-note";
+    actor.textContent = entry.actor;
+    recordedCell.append(actor);
+  }
+
+  const kindCell = document.createElement("td");
+  kindCell.textContent = entry.kind === actionHistoryKindWorkflowValue
+    ? `Workflow ${entry.message || ""}`.trim()
+    : formatAuditChangeKind(entry.kind);
+
+  const pathCell = document.createElement("td");
+  pathCell.className = "action-history-path";
+  pathCell.textContent = entry.path;
+
+  const statusCell = document.createElement("td");
+  appendToken(statusCell, entry.status || "unknown", auditApplyStatusTokenClass(entry.status));
+
+  const detailCell = document.createElement("td");
+  const summary = entry.error || (entry.kind === actionHistoryKindWorkflowValue ? "" : entry.message) || "";
+  if (summary) {
+    const summaryText = document.createElement("div");
+    summaryText.textContent = summary;
+    detailCell.append(summaryText);
+  }
+  if (entry.output) {
+    const outputDetails = document.createElement("details");
+    const outputSummary = document.createElement("summary");
+    outputSummary.textContent = "Output";
+    const output = document.createElement("pre");
+    output.className = "terminal-window action-history-output";
+    output.textContent = entry.output;
+    outputDetails.append(outputSummary, output);
+    detailCell.append(outputDetails);
+  }
+
+  row.append(recordedCell, kindCell, pathCell, statusCell, detailCell);
+  return row;
+}
@@ -2,6 +2,8 @@
 
 import {
   elements,
+  state,
+  readAuditViewLocation,
   setStatus,
 } from "./shared.js";
 import {
@@ -13,14 +15,66 @@ import {
 import {
   applyAuditQueue,
   clearAuditQueue,
+  exportAuditTable,
+  handleAuditColumnTogglesChange,
   handleAuditQueueListChange,
   handleAuditQueueListClick,
   handleAuditResultsClick,
   handleAuditResultsHeadChange,
+  handleAuditResultsHeadClick,
   inspectAuditRoots,
-  renderAuditQueue,
   renderAuditTaskState,
+  restoreAuditQueue,
+  setAuditQueueAppliedHandler,
 } from "./audit.js";
+import {
+  exportActionHistory,
+  loadActionHistory,
+} from "./history.js";
+import {
+  closeRepositoryDetail,
+  handleRepositoryDetailDirtyFilesClick,
+  openRepositoryDetail,
+  renderRepositoryDetailTrigger,
+} from "./repository_detail.js";
+import {
+  cancelWorkflow,
+  handleWorkflowSelectChange,
+  loadWorkflowCatalog,
+  previewWorkflowPlan,
+  renderWorkflowState,
+  runWorkflow,
+} from "./workflows.js";
+import {
+  cancelSync,
+  handleSyncRequestChange,
+  previewSync,
+  renderSyncState,
+  runSync,
+} from "./sync.js";
+import {
+  handlePullRequestListClick,
+  loadPullRequests,
+  refreshPullRequests,
+  renderPullRequestState,
+} from "./pull_requests.js";
+import {
+  handlePackageKeepChange,
+  handlePackageListClick,
+  loadPackages,
+  refreshPackages,
+  renderPackageState,
+} from "./packages.js";
+import {
+  applyActiveWorkspaceDefaults,
+  deleteWorkspace,
+  handleWorkspaceSelectChange,
+  loadWorkspaces,
+  renderWorkspaceState,
+  requestedWorkspaceName,
+  saveWorkspace,
+  setWorkspaceOpenedHandler,
+} from "./workspaces.js";
 
 export function reportBootstrapFailure(message) {
   const failureMessage = String(message || "").trim();
@@ -40,17 +94,55 @@ export function reportBootstrapFailure(message) {
 
 export async function initializeApp() {
   bindEvents();
-  setRepositoryTreeScopeChangeHandler(renderAuditTaskState);
-  await loadInitialState();
-  renderAuditTaskState();
-  await renderRepositoryTree("");
-  renderAuditQueue();
+  setRepositoryTreeScopeChangeHandler(renderScopeState);
+  setAuditQueueAppliedHandler(() => {
+    refreshPullRequests();
+    refreshPackages();
+  });
+  setWorkspaceOpenedHandler(renderScopeState);
+  await loadWorkspaces();
+  await loadInitialState(requestedWorkspaceName());
+  applyActiveWorkspaceDefaults();
+  renderScopeState();
+  await renderRepositoryTree(currentRepositoryFilterQuery());
+  // A deep link opens the audit it describes; it runs before the saved queue returns so nothing is cleared.
+  state.auditLinkedView = readAuditViewLocation();
+  if (state.auditLinkedView && !elements.taskInspectLoad.disabled) {
+    await inspectAuditRoots();
+  }
+  await restoreAuditQueue();
+  await loadWorkflowCatalog();
+  await loadActionHistory();
   setStatus("idle");
 }
 
+function renderScopeState() {
+  renderAuditTaskState();
+  renderRepositoryDetailTrigger();
+  renderWorkflowState();
+  renderSyncState();
+  renderPullRequestState();
+  renderPackageState();
+  renderWorkspaceState();
+}
+
+function currentRepositoryFilterQuery() {
+  return (elements.repoFilter?.value || "").trim().toLowerCase();
+}
+
 function bindEvents() {
   elements.repoFilter?.addEventListener("input", () => {
-    void renderRepositoryTree((elements.repoFilter?.value || "").trim().toLowerCase());
+    void renderRepositoryTree(currentRepositoryFilterQuery());
+  });
+  elements.workspaceSelect?.addEventListener("change", () => {
+    void handleWorkspaceSelectChange();
+  });
+  elements.workspaceName?.addEventListener("input", renderWorkspaceState);
+  elements.workspaceSave?.addEventListener("click", () => {
+    void saveWorkspace();
+  });
+  elements.workspaceDelete?.addEventListener("click", () => {
+    void deleteWorkspace();
   });
   elements.repoTree?.addEventListener("click", handleRepositoryTreeCheckboxClick);
   elements.auditIncludeAll?.addEventListener("change", renderAuditTaskState);
@@ -58,6 +150,11 @@ function bindEvents() {
     void inspectAuditRoots();
   });
   elements.auditResultsHead?.addEventListener("change", handleAuditResultsHeadChange);
+  elements.auditResultsHead?.addEventListener("click", handleAuditResultsHeadClick);
+  elements.auditColumnToggles?.addEventListener("change", handleAuditColumnTogglesChange);
+  elements.auditExport?.addEventListener("click", () => {
+    void exportAuditTable();
+  });
   elements.auditResultsBody?.addEventListener("click", handleAuditResultsClick);
   elements.auditQueueList?.addEventListener("click", handleAuditQueueListClick);
   elements.auditQueueList?.addEventListener("change", handleAuditQueueListChange);
@@ -65,4 +162,53 @@ function bindEvents() {
   elements.auditQueueApply?.addEventListener("click", () => {
     void applyAuditQueue();
   });
+  elements.repositoryDetailOpen?.addEventListener("click", () => {
+    void openRepositoryDetail();
+  });
+  elements.repositoryDetailClose?.addEventListener("click", closeRepositoryDetail);
+  elements.repositoryDetailDirtyFiles?.addEventListener("click", handleRepositoryDetailDirtyFilesClick);
+  elements.workflowSelect?.addEventListener("change", handleWorkflowSelectChange);
+  elements.workflowParameters?.addEventListener("input", renderWorkflowState);
+  elements.workflowParameters?.addEventListener("change", renderWorkflowState);
+  elements.workflowPlan?.addEventListener("click", () => {
+    void previewWorkflowPlan();
+  });
+  elements.workflowRun?.addEventListener("click", () => {
+    void runWorkflow();
+  });
+  elements.workflowCancel?.addEventListener("click", () => {
+    void cancelWorkflow();
+  });
+  elements.syncRepository?.addEventListener("change", handleSyncRequestChange);
+  elements.syncBranch?.addEventListener("input", renderSyncState);
+  elements.syncStrategy?.addEventListener("change", renderSyncState);
+  elements.syncPreviewButton?.addEventListener("click", () => {
+    void previewSync();
+  });
+  elements.syncRun?.addEventListener("click", () => {
+    void runSync();
+  });
+  elements.syncCancel?.addEventListener("click", () => {
+    void cancelSync();
+  });
+  elements.pullRequestLoad?.addEventListener("click", () => {
+    void loadPullRequests();
+  });
+  elements.pullRequestList?.addEventListener("click", handlePullRequestListClick);
+  elements.packageLoad?.addEventListener("click", () => {
+    void loadPackages();
+  });
+  elements.packageKeep?.addEventListener("input", handlePackageKeepChange);
+  elements.packageList?.addEventListener("click", handlePackageListClick);
+  [elements.actionHistoryKind, elements.actionHistoryStatus, elements.actionHistoryPath].forEach((filter) => {
+    filter?.addEventListener("change", () => {
+      void loadActionHistory();
+    });
+  });
+  elements.actionHistoryRefresh?.addEventListener("click", () => {
+    void loadActionHistory();
+  });
+  elements.actionHistoryExport?.addEventListener("click", () => {
+    void exportActionHistory();
+  });
 }
@@ -0,0 +1,335 @@
+// @ts-check
+
+import {
+  auditChangeKindPackageRetentionValue,
+  elements,
+  packagesEndpoint,
+  state,
+  appendEmptyState,
+  appendToken,
+  renderRunError,
+} from "./shared.js";
+import {
+  workingFolderRoots,
+} from "./repo_tree.js";
+import {
+  queuePackageRetentionChange,
+} from "./audit.js";
+
+const packageSizeUnits = ["B", "KiB", "MiB", "GiB", "TiB"];
+
+/** Re-evaluates the package panel after the tree scope changes. */
+export function renderPackageState() {
+  const roots = workingFolderRoots();
+  elements.packageScopeSummary.textContent = roots.length > 0
+    ? `Lists ${roots.length === 1 ? roots[0] : `${roots.length} roots`}`
+    : "Select a folder or check repositories to list their packages.";
+  elements.packageLoad.disabled = roots.length === 0 || state.packagesLoading;
+}
+
+export async function loadPackages() {
+  const roots = workingFolderRoots();
+  if (roots.length === 0) {
+    return;
+  }
+  state.packagesLoading = true;
+  renderPackageState();
+  elements.packageList.replaceChildren();
+  appendEmptyState(elements.packageList, "Loading package versions...");
+
+  /** @type {import("./shared.js").PackageDashboard} */
+  let dashboard;
+  try {
+    const response = await fetch(packagesEndpoint, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ roots }),
+    });
+    dashboard = await response.json();
+    if (!response.ok) {
+      throw new Error(dashboard.error || `Failed to load packages: ${response.status}`);
+    }
+  } catch (error) {
+    dashboard = { roots, packages: [], error: String(error instanceof Error ? error.message : error) };
+  }
+
+  state.packageDashboard = dashboard;
+  state.packagesLoading = false;
+  renderPackageDashboard();
+  renderPackageState();
+}
+
+/** Reloads the versions after the queue applied, so deleted versions disappear. */
+export function refreshPackages() {
+  if (state.packageDashboard && !state.packagesLoading) {
+    void loadPackages();
+  }
+}
+
+/** Re-plans every package after the keep count changes. */
+export function handlePackageKeepChange() {
+  renderPackageDashboard();
+}
+
+export function handlePackageListClick(event) {
+  const eventTarget = event.target;
+  if (!(eventTarget instanceof HTMLElement)) {
+    return;
+  }
+  const button = eventTarget.closest("[data-package-action]");
+  if (!(button instanceof HTMLButtonElement)) {
+    return;
+  }
+  const packageDescriptor = (state.packageDashboard?.packages || [])
+    .find((candidate) => candidate.path === button.dataset.packagePath);
+  if (!packageDescriptor) {
+    return;
+  }
+
+  switch (button.dataset.packageAction) {
+    case "pin":
+      togglePackageVersionPin(packageDescriptor.path, Number.parseInt(button.dataset.packageVersion || "", 10));
+      renderPackageDashboard();
+      break;
+    case auditChangeKindPackageRetentionValue:
+      queuePackageRetention(packageDescriptor);
+      break;
+    default:
+      break;
+  }
+}
+
+/** The keep count typed in the panel, or 0 when it is not a positive whole number. */
+function packageKeepCount() {
+  const keep = Number(elements.packageKeep?.value || "");
+  return Number.isInteger(keep) && keep > 0 ? keep : 0;
+}
+
+/** @param {string} packagePath */
+function pinnedPackageVersions(packagePath) {
+  return state.packagePinnedVersions[packagePath] || [];
+}
+
+/**
+ * @param {string} packagePath
+ * @param {number} versionID
+ */
+function togglePackageVersionPin(packagePath, versionID) {
+  if (!Number.isInteger(versionID)) {
+    return;
+  }
+  const pinnedVersions = pinnedPackageVersions(packagePath);
+  state.packagePinnedVersions[packagePath] = pinnedVersions.includes(versionID)
+    ? pinnedVersions.filter((pinnedVersion) => pinnedVersion !== versionID)
+    : pinnedVersions.concat(versionID);
+}
+
+/**
+ * Mirrors ghcr.PlanRetention: versions arrive newest first, pinned versions are skipped, the newest
+ * keep of the rest are retained, and every older version is deleted.
+ * @param {import("./shared.js").PackageDescriptor} packageDescriptor
+ * @param {number} keep
+ * @returns {Set<number>}
+ */
+function plannedPackageDeletions(packageDescriptor, keep) {
+  const deletions = new Set();
+  if (keep <= 0) {
+    return deletions;
+  }
+  const pinnedVersions = pinnedPackageVersions(packageDescriptor.path);
+  let retainedVersions = 0;
+  packageDescriptor.versions.forEach((version) => {
+    if (pinnedVersions.includes(version.id)) {
+      return;
+    }
+    if (retainedVersions < keep) {
+      retainedVersions += 1;
+      return;
+    }
+    deletions.add(version.id);
+  });
+  return deletions;
+}
+
+/** @param {import("./shared.js").PackageDescriptor} packageDescriptor */
+function queuePackageRetention(packageDescriptor) {
+  const keep = packageKeepCount();
+  const deletions = plannedPackageDeletions(packageDescriptor, keep);
+  if (deletions.size === 0) {
+    return;
+  }
+  const pinnedVersions = pinnedPackageVersions(packageDescriptor.path)
+    .filter((versionID) => packageDescriptor.versions.some((version) => version.id === versionID));
+  const pinnedSummary = pinnedVersions.length > 0 ? ` plus ${pinnedVersions.length} pinned` : "";
+  queuePackageRetentionChange({
+    kind: auditChangeKindPackageRetentionValue,
+    path: packageDescriptor.path,
+    package: packageDescriptor.package || "",
+    keep,
+    pinned_versions: pinnedVersions,
+    delete_versions: Array.from(deletions),
+    confirm_delete: false,
+    title: `Retain ${keep} of ${packageDescriptor.package}`,
+    description: `Keep the newest ${keep}${pinnedSummary} of ${packageDescriptor.versions.length} versions of ${packageDescriptor.owner}/${packageDescriptor.package} and delete ${deletions.size}.`,
+  });
+  renderRunError("");
+}
+
+function renderPackageDashboard() {
+  const dashboard = state.packageDashboard;
+  elements.packageList.replaceChildren();
+  if (!dashboard) {
+    return;
+  }
+  if (dashboard.error) {
+    appendEmptyState(elements.packageList, dashboard.error);
+    return;
+  }
+  if (dashboard.packages.length === 0) {
+    appendEmptyState(elements.packageList, "No repositories found under the current scope.");
+    return;
+  }
+
+  const keep = packageKeepCount();
+  dashboard.packages.forEach((packageDescriptor) => {
+    elements.packageList.append(renderPackage(packageDescriptor, keep));
+  });
+}
+
+/**
+ * @param {import("./shared.js").PackageDescriptor} packageDescriptor
+ * @param {number} keep
+ */
+function renderPackage(packageDescriptor, keep) {
+  const container = document.createElement("article");
+  container.className = "package-repository";
+
+  const heading = document.createElement("div");
+  heading.className = "package-repository-heading";
+  const name = document.createElement("strong");
+  name.textContent = packageDescriptor.package || packageDescriptor.path;
+  heading.append(name);
+  if (packageDescriptor.owner) {
+    appendToken(heading, `${packageDescriptor.owner_type === "org" ? "org" : "user"} ${packageDescriptor.owner}`, "token-context");
+  }
+  appendToken(heading, packageDescriptor.path, "token-muted");
+  container.append(heading);
+
+  if (packageDescriptor.error) {
+    appendEmptyState(container, packageDescriptor.error);
+    return container;
+  }
+  if (packageDescriptor.versions.length === 0) {
+    appendEmptyState(container, "No package versions.");
+    return container;
+  }
+
+  const deletions = plannedPackageDeletions(packageDescriptor, keep);
+  const pinnedVersions = pinnedPackageVersions(packageDescriptor.path);
+  const summary = document.createElement("div");
+  summary.className = "package-repository-heading";
+  appendToken(summary, `${packageDescriptor.versions.length} versions`, "token-default");
+  appendToken(summary, keep > 0 ? `${deletions.size} to delete` : "enter a keep count", deletions.size > 0 ? "token-danger" : "token-muted");
+  const queueButton = document.createElement("button");
+  queueButton.type = "button";
+  queueButton.className = "secondary-button";
+  queueButton.textContent = "Queue retention";
+  queueButton.disabled = deletions.size === 0;
+  queueButton.dataset.packageAction = auditChangeKindPackageRetentionValue;
+  queueButton.dataset.packagePath = packageDescriptor.path;
+  summary.append(queueButton);
+  container.append(summary);
+
+  const table = document.createElement("table");
+  table.className = "audit-table package-version-table";
+  const headerRow = document.createElement("tr");
+  ["Version", "Tags", "Created", "Size", ""].forEach((label) => {
+    const headerCell = document.createElement("th");
+    headerCell.scope = "col";
+    headerCell.textContent = label;
+    headerRow.append(headerCell);
+  });
+  const tableHead = document.createElement("thead");
+  tableHead.append(headerRow);
+  const tableBody = document.createElement("tbody");
+  packageDescriptor.versions.forEach((version) => {
+    tableBody.append(renderPackageVersionRow(packageDescriptor, version, deletions.has(version.id), pinnedVersions.includes(version.id)));
+  });
+  table.append(tableHead, tableBody);
+
+  const tableShell = document.createElement("div");
+  tableShell.className = "audit-table-shell";
+  tableShell.append(table);
+  container.append(tableShell);
+  return container;
+}
+
+/**
+ * @param {import("./shared.js").PackageDescriptor} packageDescriptor
+ * @param {import("./shared.js").PackageVersionDescriptor} version
+ * @param {boolean} deleted
+ * @param {boolean} pinned
+ */
+function renderPackageVersionRow(packageDescriptor, version, deleted, pinned) {
+  const row = document.createElement("tr");
+  row.className = deleted ? "package-version-deleted" : "";
+
+  const versionCell = document.createElement("td");
+  versionCell.textContent = version.name ? `${version.id} ${shortPackageDigest(version.name)}` : String(version.id);
+  versionCell.title = version.name || "";
+
+  const tagsCell = document.createElement("td");
+  if (version.tags.length === 0) {
+    appendToken(tagsCell, "untagged", "token-muted");
+  }
+  version.tags.forEach((tag) => {
+    appendToken(tagsCell, tag, "token-branch");
+  });
+
+  const createdCell = document.createElement("td");
+  createdCell.textContent = new Date(version.created_at).toLocaleString();
+
+  const sizeCell = document.createElement("td");
+  sizeCell.textContent = formatPackageSize(version.size);
+
+  const actionCell = document.createElement("td");
+  if (pinned) {
+    appendToken(actionCell, "pinned", "token-success");
+  } else if (deleted) {
+    appendToken(actionCell, "delete", "token-danger");
+  }
+  const pinButton = document.createElement("button");
+  pinButton.type = "button";
+  pinButton.className = "secondary-button";
+  pinButton.textContent = pinned ? "Unpin" : "Pin";
+  pinButton.dataset.packageAction = "pin";
+  pinButton.dataset.packagePath = packageDescriptor.path;
+  pinButton.dataset.packageVersion = String(version.id);
+  actionCell.append(pinButton);
+
+  row.append(versionCell, tagsCell, createdCell, sizeCell, actionCell);
+  return row;
+}
+
+/** @param {string} digest */
+function shortPackageDigest(digest) {
+  const [algorithm, value] = digest.split(":");
+  return value ? `${algorithm}:${value.slice(0, 12)}` : digest;
+}
+
+/**
+ * GitHub does not report sizes for container versions; a size shows only when the API returns one.
+ * @param {number | undefined} size
+ */
+function formatPackageSize(size) {
+  if (typeof size !== "number") {
+    return "n/a";
+  }
+  let value = size;
+  let unitIndex = 0;
+  while (value >= 1024 && unitIndex < packageSizeUnits.length - 1) {
+    value /= 1024;
+    unitIndex += 1;
+  }
+  return unitIndex === 0 ? `${value} ${packageSizeUnits[unitIndex]}` : `${value.toFixed(1)} ${packageSizeUnits[unitIndex]}`;
+}
@@ -0,0 +1,298 @@
+// @ts-check
+
+import {
+  auditChangeKindClosePullRequestValue,
+  auditChangeKindDeleteMergedBranchValue,
+  auditChangeKindRetargetPullRequestValue,
+  elements,
+  pullRequestsEndpoint,
+  state,
+  appendEmptyState,
+  appendToken,
+  renderRunError,
+} from "./shared.js";
+import {
+  workingFolderRoots,
+} from "./repo_tree.js";
+import {
+  queuePullRequestChange,
+} from "./audit.js";
+
+const pullRequestSyncStateLabels = Object.freeze({
+  in_sync: "in sync",
+  ahead: "local ahead",
+  behind: "local behind",
+  diverged: "diverged",
+  missing: "no local branch",
+  unknown: "head not fetched",
+});
+
+/** Re-evaluates the pull request panel after the tree scope changes. */
+export function renderPullRequestState() {
+  const roots = workingFolderRoots();
+  elements.pullRequestScopeSummary.textContent = roots.length > 0
+    ? `Lists ${roots.length === 1 ? roots[0] : `${roots.length} roots`}`
+    : "Select a folder or check repositories to list their pull requests.";
+  elements.pullRequestLoad.disabled = roots.length === 0 || state.pullRequestsLoading;
+}
+
+export async function loadPullRequests() {
+  const roots = workingFolderRoots();
+  if (roots.length === 0) {
+    return;
+  }
+  state.pullRequestsLoading = true;
+  renderPullRequestState();
+  elements.pullRequestList.replaceChildren();
+  appendEmptyState(elements.pullRequestList, "Loading pull requests...");
+
+  /** @type {import("./shared.js").PullRequestDashboard} */
+  let dashboard;
+  try {
+    const response = await fetch(pullRequestsEndpoint, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ roots }),
+    });
+    dashboard = await response.json();
+    if (!response.ok) {
+      throw new Error(dashboard.error || `Failed to load pull requests: ${response.status}`);
+    }
+  } catch (error) {
+    dashboard = { roots, repositories: [], error: String(error instanceof Error ? error.message : error) };
+  }
+
+  state.pullRequestDashboard = dashboard;
+  state.pullRequestsLoading = false;
+  renderPullRequestDashboard();
+  renderPullRequestState();
+}
+
+/** Reloads the dashboard after the queue applied, so closed and retargeted pull requests move. */
+export function refreshPullRequests() {
+  if (state.pullRequestDashboard && !state.pullRequestsLoading) {
+    void loadPullRequests();
+  }
+}
+
+export function handlePullRequestListClick(event) {
+  const eventTarget = event.target;
+  if (!(eventTarget instanceof HTMLElement)) {
+    return;
+  }
+  const button = eventTarget.closest("[data-pull-request-action]");
+  if (!(button instanceof HTMLButtonElement)) {
+    return;
+  }
+
+  const repository = (state.pullRequestDashboard?.repositories || [])
+    .find((candidate) => candidate.path === button.dataset.pullRequestPath);
+  const number = Number.parseInt(button.dataset.pullRequestNumber || "", 10);
+  const pullRequest = repository && pullRequestsOf(repository).find((candidate) => candidate.number === number);
+  if (!repository || !pullRequest) {
+    return;
+  }
+
+  const target = `${repository.repository}#${pullRequest.number}`;
+  const change = {
+    path: repository.path,
+    repository: repository.repository || "",
+    pull_request: pullRequest.number,
+    branch: pullRequest.head_branch,
+  };
+  switch (button.dataset.pullRequestAction) {
+    case auditChangeKindClosePullRequestValue:
+      queuePullRequestChange({
+        ...change,
+        kind: auditChangeKindClosePullRequestValue,
+        title: `Close ${target}`,
+        description: `Close "${pullRequest.title}" without merging. The ${pullRequest.head_branch} branch is kept.`,
+      });
+      break;
+    case auditChangeKindRetargetPullRequestValue:
+      queuePullRequestChange({
+        ...change,
+        kind: auditChangeKindRetargetPullRequestValue,
+        base_branch: suggestedRetargetBase(pullRequest),
+        title: `Retarget ${target}`,
+        description: `Change the base of "${pullRequest.title}" from ${pullRequest.base_branch}. A recorded review base for ${pullRequest.head_branch} follows the new base.`,
+      });
+      break;
+    case auditChangeKindDeleteMergedBranchValue:
+      queuePullRequestChange({
+        ...change,
+        kind: auditChangeKindDeleteMergedBranchValue,
+        confirm_delete: false,
+        title: `Delete ${pullRequest.head_branch}`,
+        description: `${target} is merged; delete its head branch from origin and ${repository.name}.`,
+      });
+      break;
+    default:
+      return;
+  }
+  renderRunError("");
+}
+
+function renderPullRequestDashboard() {
+  const dashboard = state.pullRequestDashboard;
+  elements.pullRequestList.replaceChildren();
+  if (!dashboard) {
+    return;
+  }
+  if (dashboard.error) {
+    appendEmptyState(elements.pullRequestList, dashboard.error);
+    return;
+  }
+  if (dashboard.repositories.length === 0) {
+    appendEmptyState(elements.pullRequestList, "No repositories found under the current scope.");
+    return;
+  }
+
+  dashboard.repositories.forEach((repository) => {
+    const container = document.createElement("article");
+    container.className = "pull-request-repository";
+
+    const heading = document.createElement("div");
+    heading.className = "pull-request-repository-heading";
+    const name = document.createElement("strong");
+    name.textContent = repository.name;
+    heading.append(name);
+    if (repository.repository) {
+      appendToken(heading, repository.repository, "token-context");
+    }
+    if (repository.current_branch) {
+      appendToken(heading, `on ${repository.current_branch}`, "token-muted");
+    }
+    container.append(heading);
+
+    if (repository.error) {
+      appendEmptyState(container, repository.error);
+      elements.pullRequestList.append(container);
+      return;
+    }
+    if (repository.stacks.length === 0) {
+      appendEmptyState(container, "No open pull requests.");
+    }
+    repository.stacks.forEach((stack) => {
+      const title = stack.pull_requests.length > 1
+        ? `Stack of ${stack.pull_requests.length} on ${stack.base}`
+        : `On ${stack.base}`;
+      container.append(renderPullRequestGroup(repository, title, stack.pull_requests));
+    });
+    if (repository.merged.length > 0) {
+      container.append(renderPullRequestGroup(repository, "Merged with a local branch left", repository.merged));
+    }
+    elements.pullRequestList.append(container);
+  });
+}
+
+/**
+ * @param {import("./shared.js").PullRequestRepository} repository
+ * @param {string} title
+ * @param {import("./shared.js").PullRequestDescriptor[]} pullRequests
+ */
+function renderPullRequestGroup(repository, title, pullRequests) {
+  const group = document.createElement("section");
+  group.className = "pull-request-stack";
+  const heading = document.createElement("h4");
+  heading.textContent = title;
+  const rows = document.createElement("ul");
+  rows.className = "pull-request-rows";
+  pullRequests.forEach((pullRequest) => {
+    rows.append(renderPullRequestRow(repository, pullRequest));
+  });
+  group.append(heading, rows);
+  return group;
+}
+
+/**
+ * @param {import("./shared.js").PullRequestRepository} repository
+ * @param {import("./shared.js").PullRequestDescriptor} pullRequest
+ */
+function renderPullRequestRow(repository, pullRequest) {
+  const row = document.createElement("li");
+  row.className = "pull-request-row";
+  row.style.paddingLeft = `${pullRequest.depth * 1.2}rem`;
+
+  appendToken(row, `#${pullRequest.number}`, "token-default");
+  const title = document.createElement("span");
+  title.className = "pull-request-row-title";
+  title.textContent = pullRequest.title;
+  row.append(title);
+  appendToken(row, `${pullRequest.head_branch} -> ${pullRequest.base_branch}`, "token-context");
+  if (pullRequest.cross_repository) {
+    appendToken(row, `from ${pullRequest.head_repository}`, "token-muted");
+  }
+  if (pullRequest.review_base && pullRequest.review_base !== pullRequest.base_branch) {
+    appendToken(row, `review base ${pullRequest.review_base}`, "token-warning");
+  }
+  if (pullRequest.current) {
+    appendToken(row, "checked out", "token-muted");
+  }
+  appendToken(row, formatPullRequestSyncState(pullRequest), pullRequestSyncStateTokenClass(pullRequest.sync_state));
+
+  if (pullRequest.state === "merged") {
+    appendPullRequestButton(row, repository, pullRequest, auditChangeKindDeleteMergedBranchValue, "Delete branch");
+  } else {
+    appendPullRequestButton(row, repository, pullRequest, auditChangeKindRetargetPullRequestValue, "Retarget");
+    appendPullRequestButton(row, repository, pullRequest, auditChangeKindClosePullRequestValue, "Close");
+  }
+  return row;
+}
+
+function appendPullRequestButton(row, repository, pullRequest, action, label) {
+  const button = document.createElement("button");
+  button.type = "button";
+  button.className = "secondary-button";
+  button.textContent = label;
+  button.dataset.pullRequestAction = action;
+  button.dataset.pullRequestPath = repository.path;
+  button.dataset.pullRequestNumber = String(pullRequest.number);
+  row.append(button);
+}
+
+/** @param {import("./shared.js").PullRequestRepository} repository */
+function pullRequestsOf(repository) {
+  return repository.stacks.flatMap((stack) => stack.pull_requests).concat(repository.merged);
+}
+
+/**
+ * Proposes the recorded review base when GitHub disagrees with it; otherwise the operator types the base.
+ * @param {import("./shared.js").PullRequestDescriptor} pullRequest
+ */
+function suggestedRetargetBase(pullRequest) {
+  if (pullRequest.review_base && pullRequest.review_base !== pullRequest.base_branch) {
+    return pullRequest.review_base;
+  }
+  return "";
+}
+
+/** @param {import("./shared.js").PullRequestDescriptor} pullRequest */
+function formatPullRequestSyncState(pullRequest) {
+  const label = pullRequestSyncStateLabels[pullRequest.sync_state] || pullRequest.sync_state;
+  if (pullRequest.sync_state === "diverged") {
+    return `${label} +${pullRequest.ahead || 0}/-${pullRequest.behind || 0}`;
+  }
+  if (pullRequest.sync_state === "ahead") {
+    return `${label} ${pullRequest.ahead || 0}`;
+  }
+  if (pullRequest.sync_state === "behind") {
+    return `${label} ${pullRequest.behind || 0}`;
+  }
+  return label;
+}
+
+/** @param {string} syncState */
+function pullRequestSyncStateTokenClass(syncState) {
+  switch (syncState) {
+    case "in_sync":
+      return "token-success";
+    case "ahead":
+    case "behind":
+      return "token-warning";
+    case "diverged":
+      return "token-danger";
+    default:
+      return "token-muted";
+  }
+}
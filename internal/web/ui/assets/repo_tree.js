@@ -9,6 +9,7 @@ import {
   repositoryTreeIconMap,
   repositoriesEndpoint,
   state,
+  workspaceQueryParameter,
   appendEmptyState,
   checkedRepositories,
   compareRepositories,
@@ -33,10 +34,14 @@ export function setRepositoryTreeScopeChangeHandler(handler) {
   repositoryTreeScopeChangeHandler = typeof handler === "function" ? handler : () => {};
 }
 
-export async function loadInitialState() {
-  const response = await fetch(repositoriesEndpoint);
+export async function loadInitialState(workspaceName = "") {
+  const repositoriesURL = workspaceName
+    ? `${repositoriesEndpoint}?${workspaceQueryParameter}=${encodeURIComponent(workspaceName)}`
+    : repositoriesEndpoint;
+  const response = await fetch(repositoriesURL);
   if (!response.ok) {
-    throw new Error(`Failed to load repositories: ${response.status}`);
+    const payload = await response.json().catch(() => ({ error: "" }));
+    throw new Error(payload.error || `Failed to load repositories: ${response.status}`);
   }
 
   const repositoryCatalog = await response.json();
@@ -44,7 +49,13 @@ export async function loadInitialState() {
     throw new Error(repositoryCatalog.error);
   }
 
+  // A workspace switch replaces the whole scope, so drop the tree state of the previous roots.
+  state.directoryFolders = {};
+  state.checkedRepositoryIDs = [];
+  state.collapsedFolderPaths = [];
+  state.repositoryTreeRootPathsOverride = [];
   state.repositoryCatalog = repositoryCatalog;
+  state.activeWorkspaceName = String(repositoryCatalog.workspace || "");
   state.repositories = (repositoryCatalog.repositories || [])
     .map((repository) => normalizeDiscoveredRepository(repository))
     .filter(Boolean)
@@ -0,0 +1,228 @@
+// @ts-check
+
+import {
+  elements,
+  repositoryDetailEndpoint,
+  repositoryDiffEndpoint,
+  state,
+  appendEmptyState,
+  appendToken,
+  repositoryForFolderPath,
+} from "./shared.js";
+import {
+  activeRepositoryTreeFolderPath,
+} from "./repo_tree.js";
+
+let repositoryDetailSequence = 0;
+
+/** Enables the details button only while the tree selection is a known repository. */
+export function renderRepositoryDetailTrigger() {
+  elements.repositoryDetailOpen.disabled = !selectedDetailRepositoryPath();
+}
+
+export async function openRepositoryDetail() {
+  const repositoryPath = selectedDetailRepositoryPath();
+  if (!repositoryPath) {
+    return;
+  }
+
+  const detailSequence = ++repositoryDetailSequence;
+  state.repositoryDetail = null;
+  state.repositoryDetailDiffFile = "";
+  elements.repositoryDetailPanel.hidden = false;
+  elements.repositoryDetailTitle.textContent = repositoryPath;
+  elements.repositoryDetailSummary.textContent = "Loading repository details...";
+  clearRepositoryDetailSections();
+
+  /** @type {import("./shared.js").RepositoryDetail} */
+  let detail;
+  try {
+    const response = await fetch(`${repositoryDetailEndpoint}?path=${encodeURIComponent(repositoryPath)}`);
+    detail = await response.json();
+    if (!response.ok) {
+      throw new Error(detail.error || `Failed to load ${repositoryPath}: ${response.status}`);
+    }
+  } catch (error) {
+    if (detailSequence === repositoryDetailSequence) {
+      elements.repositoryDetailSummary.textContent = String(error instanceof Error ? error.message : error);
+    }
+    return;
+  }
+  if (detailSequence !== repositoryDetailSequence) {
+    return;
+  }
+
+  state.repositoryDetail = detail;
+  renderRepositoryDetail();
+}
+
+export function closeRepositoryDetail() {
+  repositoryDetailSequence++;
+  state.repositoryDetail = null;
+  state.repositoryDetailDiffFile = "";
+  elements.repositoryDetailPanel.hidden = true;
+}
+
+export function handleRepositoryDetailDirtyFilesClick(event) {
+  const target = event.target instanceof Element ? event.target.closest("[data-repository-diff-file]") : null;
+  if (!(target instanceof HTMLElement)) {
+    return;
+  }
+  void showRepositoryFileDiff(String(target.dataset.repositoryDiffFile || ""));
+}
+
+function selectedDetailRepositoryPath() {
+  const repository = repositoryForFolderPath(activeRepositoryTreeFolderPath());
+  return repository ? repository.path : "";
+}
+
+function clearRepositoryDetailSections() {
+  elements.repositoryDetailBranches.replaceChildren();
+  elements.repositoryDetailGraph.textContent = "";
+  elements.repositoryDetailDirtyFiles.replaceChildren();
+  elements.repositoryDetailDiff.textContent = "";
+  elements.repositoryDetailDiff.hidden = true;
+  elements.repositoryDetailStashes.replaceChildren();
+}
+
+function renderRepositoryDetail() {
+  const detail = state.repositoryDetail;
+  if (!detail) {
+    return;
+  }
+
+  elements.repositoryDetailTitle.textContent = detail.name || detail.path;
+  elements.repositoryDetailSummary.textContent = detail.error
+    ? detail.error
+    : [
+      detail.path,
+      detail.current_branch ? `on ${detail.current_branch}` : "detached HEAD",
+      detail.default_branch ? `default ${detail.default_branch}` : "no remote default branch",
+    ].join(" · ");
+
+  renderRepositoryBranches(detail.branches || []);
+  elements.repositoryDetailGraph.textContent = (detail.graph || []).map(formatRepositoryGraphLine).join("\n");
+  renderRepositoryDirtyFiles(detail.dirty_files || []);
+  renderRepositoryStashes(detail.stashes || []);
+}
+
+function renderRepositoryBranches(branches) {
+  if (branches.length === 0) {
+    const row = document.createElement("tr");
+    const cell = document.createElement("td");
+    cell.colSpan = 4;
+    appendEmptyState(cell, "No branches.");
+    row.append(cell);
+    elements.repositoryDetailBranches.append(row);
+    return;
+  }
+
+  branches.forEach((branch) => {
+    const row = document.createElement("tr");
+    row.dataset.repositoryBranch = branch.name;
+
+    const nameCell = document.createElement("td");
+    nameCell.textContent = branch.name;
+    if (branch.current) {
+      nameCell.append(" ");
+      appendToken(nameCell, "current", "token-success");
+    }
+    if (branch.remote) {
+      nameCell.append(" ");
+      appendToken(nameCell, "remote", "token-muted");
+    }
+
+    const upstreamCell = document.createElement("td");
+    upstreamCell.textContent = branch.upstream || "";
+    if (branch.upstream_gone) {
+      upstreamCell.append(" ");
+      appendToken(upstreamCell, "gone", "token-danger");
+    }
+
+    const upstreamDivergenceCell = document.createElement("td");
+    upstreamDivergenceCell.textContent = formatDivergence(branch.ahead, branch.behind);
+    const defaultDivergenceCell = document.createElement("td");
+    defaultDivergenceCell.textContent = formatDivergence(branch.default_ahead, branch.default_behind);
+
+    row.append(nameCell, upstreamCell, upstreamDivergenceCell, defaultDivergenceCell);
+    elements.repositoryDetailBranches.append(row);
+  });
+}
+
+function renderRepositoryDirtyFiles(dirtyFiles) {
+  if (dirtyFiles.length === 0) {
+    appendEmptyState(elements.repositoryDetailDirtyFiles, "Working tree is clean.");
+    return;
+  }
+
+  dirtyFiles.forEach((entry) => {
+    const button = document.createElement("button");
+    button.type = "button";
+    button.className = "secondary-button repository-dirty-file";
+    button.dataset.repositoryDiffFile = entry.file;
+    const statusToken = document.createElement("span");
+    statusToken.className = "context-token token-warning";
+    statusToken.textContent = entry.status;
+    button.append(statusToken, ` ${entry.file}`);
+    elements.repositoryDetailDirtyFiles.append(button);
+  });
+}
+
+function renderRepositoryStashes(stashes) {
+  if (stashes.length === 0) {
+    appendEmptyState(elements.repositoryDetailStashes, "No stashes.");
+    return;
+  }
+
+  stashes.forEach((stash) => {
+    const entry = document.createElement("div");
+    entry.className = "repository-stash";
+    appendToken(entry, stash.reference, "token-muted");
+    entry.append(` ${stash.subject} `);
+    const date = document.createElement("span");
+    date.className = "panel-note";
+    date.textContent = stash.date;
+    entry.append(date);
+    elements.repositoryDetailStashes.append(entry);
+  });
+}
+
+async function showRepositoryFileDiff(file) {
+  const detail = state.repositoryDetail;
+  if (!detail || !file) {
+    return;
+  }
+
+  state.repositoryDetailDiffFile = file;
+  elements.repositoryDetailDiff.hidden = false;
+  elements.repositoryDetailDiff.textContent = `Loading diff for ${file}...`;
+
+  const query = `path=${encodeURIComponent(detail.path)}&file=${encodeURIComponent(file)}`;
+  let diffText;
+  try {
+    const response = await fetch(`${repositoryDiffEndpoint}?${query}`);
+    /** @type {import("./shared.js").RepositoryFileDiff} */
+    const fileDiff = await response.json();
+    diffText = fileDiff.error || fileDiff.diff || "No textual changes.";
+  } catch (error) {
+    diffText = String(error instanceof Error ? error.message : error);
+  }
+  if (state.repositoryDetailDiffFile === file) {
+    elements.repositoryDetailDiff.textContent = diffText;
+  }
+}
+
+function formatRepositoryGraphLine(line) {
+  if (!line.commit) {
+    return line.graph;
+  }
+  const refs = line.commit.refs && line.commit.refs.length > 0 ? ` (${line.commit.refs.join(", ")})` : "";
+  return `${line.graph} ${line.commit.short_hash}${refs} ${line.commit.subject} — ${line.commit.author}, ${line.commit.date}`;
+}
+
+function formatDivergence(ahead, behind) {
+  if (typeof ahead !== "number" || typeof behind !== "number") {
+    return "";
+  }
+  return `+${ahead} / -${behind}`;
+}
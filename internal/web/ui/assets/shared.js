@@ -7,11 +7,53 @@
  *   explorer_root?: string,
  *   launch_mode?: string,
  *   selected_repository_id?: string,
+ *   workspace?: string,
  *   repositories?: RepositoryDescriptor[],
  *   error?: string,
  * }} RepositoryCatalog
  */
 
+/**
+ * @typedef {{
+ *   name: string,
+ *   roots: string[],
+ *   filters?: { repository?: string, columns?: Record<string, string> },
+ *   audit?: { depth?: string, format?: string, hidden_columns?: string[], sort?: AuditSort },
+ * }} Workspace
+ */
+
+/**
+ * @typedef {{
+ *   column: string,
+ *   direction: string,
+ * }} AuditSort
+ */
+
+/**
+ * @typedef {{
+ *   filters: Record<string, string>,
+ *   sort: AuditSort,
+ *   hiddenColumns: string[],
+ * }} AuditView
+ */
+
+/**
+ * @typedef {{
+ *   format?: string,
+ *   file_name?: string,
+ *   content_type?: string,
+ *   content?: string,
+ *   error?: string,
+ * }} AuditExport
+ */
+
+/**
+ * @typedef {{
+ *   workspaces?: Workspace[],
+ *   error?: string,
+ * }} WorkspaceCatalog
+ */
+
 /**
  * @typedef {{
  *   id: string,
@@ -52,6 +94,7 @@
  * @typedef {{
  *   roots?: string[],
  *   rows?: AuditInspectionRow[],
+ *   snapshot_id?: string,
  *   error?: string,
  * }} AuditInspectionResponse
  */
@@ -80,6 +123,14 @@
  *   worktree_dirty: string,
  *   dirty_files: string,
  *   dirty_file_entries?: AuditDirtyFileEntry[],
+ *   upstream_ahead: string,
+ *   upstream_behind: string,
+ *   default_ahead: string,
+ *   default_behind: string,
+ *   no_upstream_branches: string,
+ *   upstream_gone_branches: string,
+ *   merged_branches: string,
+ *   branch_listing?: string,
  * }} AuditInspectionRow
  */
 
@@ -93,7 +144,17 @@
  *   source_protocol?: string,
  *   target_protocol?: string,
  *   sync_strategy?: string,
+ *   branch?: string,
+ *   repository?: string,
+ *   pull_request?: number,
+ *   base_branch?: string,
+ *   package?: string,
+ *   keep?: number,
+ *   pinned_versions?: number[],
+ *   delete_versions?: number[],
  *   confirm_delete?: boolean,
+ *   workflow_id?: string,
+ *   parameters?: Record<string, string | boolean>,
  * }} AuditQueuedChange
  */
 
@@ -108,9 +169,39 @@
  * @typedef {{
  *   results?: AuditChangeApplyResult[],
  *   error?: string,
+ *   history_error?: string,
  * }} AuditChangeApplyResponse
  */
 
+/**
+ * @typedef {{
+ *   changes: AuditQueueEntry[],
+ *   updated_at?: string,
+ *   error?: string,
+ * }} AuditQueueState
+ */
+
+/**
+ * @typedef {{
+ *   recorded_at: string,
+ *   actor: string,
+ *   kind: string,
+ *   path: string,
+ *   status: string,
+ *   message?: string,
+ *   error?: string,
+ *   output?: string,
+ * }} ActionHistoryEntry
+ */
+
+/**
+ * @typedef {{
+ *   entries: ActionHistoryEntry[],
+ *   total: number,
+ *   error?: string,
+ * }} ActionHistory
+ */
+
 /**
  * @typedef {{
  *   id: string,
@@ -121,9 +212,245 @@
  *   stdout?: string,
  *   stderr?: string,
  *   error?: string,
+ *   events?: SyncEvent[],
+ *   handoff?: SyncHandoff,
  * }} AuditChangeApplyResult
  */
 
+/**
+ * @typedef {{
+ *   type: string,
+ *   run_id: string,
+ *   id?: string,
+ *   stream?: string,
+ *   text?: string,
+ *   event?: SyncEvent,
+ *   result?: AuditChangeApplyResult,
+ *   response?: AuditChangeApplyResponse,
+ * }} AuditChangeEvent
+ */
+
+/**
+ * @typedef {{
+ *   level: string,
+ *   code: string,
+ *   message: string,
+ *   details?: Record<string, string>,
+ * }} SyncEvent
+ */
+
+/**
+ * @typedef {{
+ *   code: string,
+ *   kind?: string,
+ *   message?: string,
+ *   reason?: string,
+ *   target_branch?: string,
+ *   record_path?: string,
+ *   recovery_steps?: string[],
+ *   recover_command?: string,
+ * }} SyncHandoff
+ */
+
+/**
+ * @typedef {{
+ *   root: string,
+ *   tracked_paths?: string[],
+ *   untracked_paths?: string[],
+ * }} SyncCluster
+ */
+
+/**
+ * @typedef {{
+ *   path: string,
+ *   current_branch?: string,
+ *   target_branch?: string,
+ *   target_source?: string,
+ *   push_remote?: string,
+ *   base_remote?: string,
+ *   default_branch?: string,
+ *   review_base?: string,
+ *   review_base_source?: string,
+ *   generated_branch?: boolean,
+ *   dirty_action?: string,
+ *   clusters?: SyncCluster[],
+ *   blocker?: string,
+ *   handoff?: SyncHandoff,
+ *   error?: string,
+ * }} SyncPreview
+ */
+
+/**
+ * @typedef {{
+ *   number: number,
+ *   title: string,
+ *   state: string,
+ *   head_branch: string,
+ *   base_branch: string,
+ *   head_commit?: string,
+ *   head_repository?: string,
+ *   cross_repository?: boolean,
+ *   review_base?: string,
+ *   depth: number,
+ *   local_branch: boolean,
+ *   current?: boolean,
+ *   sync_state: string,
+ *   ahead?: number,
+ *   behind?: number,
+ * }} PullRequestDescriptor
+ */
+
+/**
+ * @typedef {{
+ *   base: string,
+ *   pull_requests: PullRequestDescriptor[],
+ * }} PullRequestStack
+ */
+
+/**
+ * @typedef {{
+ *   path: string,
+ *   name: string,
+ *   repository?: string,
+ *   current_branch?: string,
+ *   stacks: PullRequestStack[],
+ *   merged: PullRequestDescriptor[],
+ *   error?: string,
+ * }} PullRequestRepository
+ */
+
+/**
+ * @typedef {{
+ *   roots?: string[],
+ *   repositories: PullRequestRepository[],
+ *   error?: string,
+ * }} PullRequestDashboard
+ */
+
+/**
+ * @typedef {{
+ *   id: number,
+ *   name?: string,
+ *   tags: string[],
+ *   created_at: string,
+ *   size?: number,
+ * }} PackageVersionDescriptor
+ */
+
+/**
+ * @typedef {{
+ *   path: string,
+ *   owner?: string,
+ *   owner_type?: string,
+ *   package?: string,
+ *   versions: PackageVersionDescriptor[],
+ *   error?: string,
+ * }} PackageDescriptor
+ */
+
+/**
+ * @typedef {{
+ *   roots?: string[],
+ *   packages: PackageDescriptor[],
+ *   error?: string,
+ * }} PackageDashboard
+ */
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   current: boolean,
+ *   upstream?: string,
+ *   remote?: boolean,
+ *   upstream_gone?: boolean,
+ *   ahead?: number,
+ *   behind?: number,
+ *   default_ahead?: number,
+ *   default_behind?: number,
+ * }} BranchDescriptor
+ */
+
+/**
+ * @typedef {{
+ *   hash: string,
+ *   short_hash: string,
+ *   parents?: string[],
+ *   author: string,
+ *   date: string,
+ *   refs?: string[],
+ *   subject: string,
+ * }} RepositoryCommit
+ */
+
+/**
+ * @typedef {{
+ *   path: string,
+ *   name: string,
+ *   current_branch?: string,
+ *   default_branch?: string,
+ *   branches: BranchDescriptor[],
+ *   graph: { graph: string, commit?: RepositoryCommit }[],
+ *   dirty_files: AuditDirtyFileEntry[],
+ *   stashes: { reference: string, date: string, subject: string }[],
+ *   error?: string,
+ * }} RepositoryDetail
+ */
+
+/**
+ * @typedef {{
+ *   path: string,
+ *   file: string,
+ *   status?: string,
+ *   diff: string,
+ *   error?: string,
+ * }} RepositoryFileDiff
+ */
+
+/**
+ * @typedef {{
+ *   key: string,
+ *   label: string,
+ *   description?: string,
+ *   control: "text" | "textarea" | "checkbox" | "select",
+ *   required: boolean,
+ *   placeholder?: string,
+ *   default_value?: string,
+ *   default_bool?: boolean,
+ *   options?: { value: string, label: string }[],
+ * }} WorkflowParameterDescriptor
+ */
+
+/**
+ * @typedef {{
+ *   id: string,
+ *   source: "primitive" | "preset" | "file",
+ *   label: string,
+ *   description?: string,
+ *   path?: string,
+ *   parameters?: WorkflowParameterDescriptor[],
+ *   error?: string,
+ * }} WorkflowDescriptor
+ */
+
+/**
+ * @typedef {{
+ *   workflows?: WorkflowDescriptor[],
+ *   directory?: string,
+ *   error?: string,
+ * }} WorkflowCatalog
+ */
+
+/**
+ * @typedef {{
+ *   workflow_id: string,
+ *   label?: string,
+ *   repository_paths?: string[],
+ *   variables?: Record<string, string>,
+ *   steps?: { name: string, command: string, after?: string[], options?: string }[],
+ *   error?: string,
+ * }} WorkflowPlan
+ */
+
 /**
  * @typedef {{
  *   kind: string,
@@ -153,7 +480,31 @@
 export const repositoriesEndpoint = "/api/repos";
 export const foldersEndpoint = "/api/folders";
 export const auditInspectEndpoint = "/api/audit/inspect";
+export const auditExportEndpoint = "/api/audit/export";
 export const auditApplyEndpoint = "/api/audit/apply";
+export const auditApplyStreamEndpoint = "/api/audit/apply/stream";
+export const auditApplyCancelEndpoint = "/api/audit/apply/cancel";
+export const repositoryDetailEndpoint = "/api/repository";
+export const repositoryDiffEndpoint = "/api/repository/diff";
+export const workflowsEndpoint = "/api/workflows";
+export const workflowPlanEndpoint = "/api/workflows/plan";
+export const auditQueueEndpoint = "/api/audit/queue";
+export const auditHistoryEndpoint = "/api/audit/history";
+export const syncPreviewEndpoint = "/api/sync/preview";
+export const pullRequestsEndpoint = "/api/pull-requests";
+export const packagesEndpoint = "/api/packages";
+export const workspacesEndpoint = "/api/workspaces";
+export const workspaceQueryParameter = "workspace";
+export const auditSortQueryParameter = "sort";
+export const auditSortDirectionQueryParameter = "dir";
+export const auditHiddenColumnsQueryParameter = "hide";
+export const auditFilterQueryParameterPrefix = "filter.";
+export const auditDepthFullValue = "full";
+export const auditFormatTableValue = "table";
+export const auditSortAscendingValue = "asc";
+export const auditSortDescendingValue = "desc";
+export const actionHistoryKindWorkflowValue = "workflow";
+export const auditChangeKindWorkflowValue = actionHistoryKindWorkflowValue;
 export const currentRepositoryLaunchMode = "current_repo";
 export const configuredRootsLaunchMode = "configured_roots";
 export const auditChangeKindRenameFolderValue = "rename_folder";
@@ -163,11 +514,20 @@ export const auditChangeKindSyncWithRemoteValue = "sync_with_remote";
 export const auditChangeKindUpdateChangelogValue = "update_changelog";
 export const auditChangeKindCommitChangesValue = "commit_changes";
 export const auditChangeKindDeleteFolderValue = "delete_folder";
+export const auditChangeKindStrictSyncValue = "strict_sync";
+export const auditChangeKindClosePullRequestValue = "close_pull_request";
+export const auditChangeKindRetargetPullRequestValue = "retarget_pull_request";
+export const auditChangeKindDeleteMergedBranchValue = "delete_merged_branch";
+export const auditChangeKindPackageRetentionValue = "package_retention";
 export const auditSyncStrategyRequireCleanValue = "require_clean";
 export const auditSyncStrategyStashChangesValue = "stash_changes";
 export const auditSyncStrategyCommitChangesValue = "commit_changes";
 export const auditChangeStatusSucceededValue = "succeeded";
 export const auditChangeStatusSkippedValue = "skipped";
+export const auditChangeStatusFailedValue = "failed";
+export const auditChangeStatusCanceledValue = "canceled";
+export const auditChangeStatusRunningValue = "running";
+export const auditChangeStatusHandoffValue = "handoff";
 export const auditDirtyFilesPreviewLimit = 3;
 export const typedAuditHeaderColumns = [
   "path",
@@ -181,6 +541,13 @@ export const typedAuditHeaderColumns = [
   "origin_matches_canonical",
   "worktree_dirty",
   "dirty_files",
+  "upstream_ahead",
+  "upstream_behind",
+  "default_ahead",
+  "default_behind",
+  "no_upstream_branches",
+  "upstream_gone_branches",
+  "merged_branches",
 ];
 
 export const auditColumnLabels = Object.freeze({
@@ -195,6 +562,13 @@ export const auditColumnLabels = Object.freeze({
   origin_matches_canonical: "Canonical",
   worktree_dirty: "Dirty",
   dirty_files: "Dirty Files",
+  upstream_ahead: "Upstream Ahead",
+  upstream_behind: "Upstream Behind",
+  default_ahead: "Default Ahead",
+  default_behind: "Default Behind",
+  no_upstream_branches: "No Upstream",
+  upstream_gone_branches: "Upstream Gone",
+  merged_branches: "Merged",
 });
 
 export const repositoryTreeIconMap = Object.freeze({
@@ -231,8 +605,18 @@ export const state = {
   auditInspectionRoots: [],
   /** @type {boolean} */
   auditInspectionIncludeAll: false,
+  /** @type {string} */
+  auditInspectionDepth: "",
+  /** @type {string} */
+  auditInspectionSnapshotID: "",
   /** @type {Record<string, string>} */
   auditColumnFilters: {},
+  /** @type {AuditSort} */
+  auditSort: { column: "", direction: auditSortAscendingValue },
+  /** @type {string[]} */
+  auditHiddenColumns: [],
+  /** @type {AuditView | null} */
+  auditLinkedView: null,
   /** @type {string[]} */
   expandedAuditDirtyFilePaths: [],
   /** @type {AuditQueueEntry[]} */
@@ -241,22 +625,88 @@ export const state = {
   auditQueueVisible: false,
   /** @type {boolean} */
   auditQueueApplying: false,
+  /** @type {string} */
+  auditApplyRunID: "",
+  /** @type {Record<string, string>} */
+  auditApplyLogs: {},
+  /** @type {Record<string, string>} */
+  auditApplyStatuses: {},
   /** @type {number} */
   nextAuditChangeSequence: 1,
   /** @type {string[]} */
   collapsedFolderPaths: [],
   /** @type {string[]} */
   repositoryTreeRootPathsOverride: [],
+  /** @type {RepositoryDetail | null} */
+  repositoryDetail: null,
+  /** @type {string} */
+  repositoryDetailDiffFile: "",
+  /** @type {WorkflowDescriptor[]} */
+  workflows: [],
+  /** @type {string} */
+  selectedWorkflowID: "",
+  /** @type {WorkflowPlan | null} */
+  workflowPlan: null,
+  /** @type {string} */
+  workflowPlanKey: "",
+  /** @type {boolean} */
+  workflowRunning: false,
+  /** @type {string} */
+  workflowRunID: "",
+  /** @type {number} */
+  nextWorkflowChangeSequence: 1,
+  /** @type {boolean} */
+  auditQueueRestored: false,
+  /** @type {string} */
+  auditQueueSavedJSON: "",
+  /** @type {ActionHistory | null} */
+  actionHistory: null,
+  /** @type {string} */
+  syncRepositoryPath: "",
+  /** @type {SyncPreview | null} */
+  syncPreview: null,
+  /** @type {string} */
+  syncPreviewKey: "",
+  /** @type {boolean} */
+  syncRunning: false,
+  /** @type {string} */
+  syncRunID: "",
+  /** @type {string} */
+  syncChangeID: "",
+  /** @type {number} */
+  nextSyncChangeSequence: 1,
+  /** @type {PullRequestDashboard | null} */
+  pullRequestDashboard: null,
+  /** @type {boolean} */
+  pullRequestsLoading: false,
+  /** @type {PackageDashboard | null} */
+  packageDashboard: null,
+  /** @type {boolean} */
+  packagesLoading: false,
+  /** @type {Record<string, number[]>} */
+  packagePinnedVersions: {},
+  /** @type {Workspace[]} */
+  workspaces: [],
+  /** @type {string} */
+  activeWorkspaceName: "",
 };
 
 export const elements = {
   repoCount: document.querySelector("#repo-count"),
   repoFilter: document.querySelector("#repo-filter"),
   repoTree: document.querySelector("#repo-tree"),
+  workspaceSelect: document.querySelector("#workspace-select"),
+  workspaceName: document.querySelector("#workspace-name"),
+  workspaceFormat: document.querySelector("#workspace-format"),
+  workspaceSave: document.querySelector("#workspace-save"),
+  workspaceDelete: document.querySelector("#workspace-delete"),
+  workspaceSummary: document.querySelector("#workspace-summary"),
   auditSelectionBadge: document.querySelector("#audit-selection-badge"),
   auditSelectionSummary: document.querySelector("#audit-selection-summary"),
   auditRootsInput: document.querySelector("#audit-roots-input"),
   auditIncludeAll: document.querySelector("#audit-include-all"),
+  auditRefresh: document.querySelector("#audit-refresh"),
+  auditDepth: document.querySelector("#audit-depth"),
   taskInspectLoad: document.querySelector("#task-inspect-load"),
   runError: document.querySelector("#run-error"),
   auditResultsPanel: document.querySelector("#audit-results-panel"),
@@ -264,6 +714,9 @@ export const elements = {
   auditTableShell: document.querySelector(".audit-table-shell"),
   auditResultsHead: document.querySelector("#audit-results-head"),
   auditResultsBody: document.querySelector("#audit-results-body"),
+  auditColumnToggles: document.querySelector("#audit-column-toggles"),
+  auditExportFormat: document.querySelector("#audit-export-format"),
+  auditExport: document.querySelector("#audit-export"),
   auditQueuePanel: document.querySelector("#audit-queue-panel"),
   auditQueueSummary: document.querySelector("#audit-queue-summary"),
   auditQueueList: document.querySelector("#audit-queue-list"),
@@ -272,6 +725,48 @@ export const elements = {
   runStatus: document.querySelector("#run-status"),
   stdoutOutput: document.querySelector("#stdout-output"),
   stderrOutput: document.querySelector("#stderr-output"),
+  repositoryDetailOpen: document.querySelector("#repository-detail-open"),
+  repositoryDetailPanel: document.querySelector("#repository-detail-panel"),
+  repositoryDetailTitle: document.querySelector("#repository-detail-title"),
+  repositoryDetailClose: document.querySelector("#repository-detail-close"),
+  repositoryDetailSummary: document.querySelector("#repository-detail-summary"),
+  repositoryDetailBranches: document.querySelector("#repository-detail-branches"),
+  repositoryDetailGraph: document.querySelector("#repository-detail-graph"),
+  repositoryDetailDirtyFiles: document.querySelector("#repository-detail-dirty-files"),
+  repositoryDetailDiff: document.querySelector("#repository-detail-diff"),
+  repositoryDetailStashes: document.querySelector("#repository-detail-stashes"),
+  workflowScopeSummary: document.querySelector("#workflow-scope-summary"),
+  workflowSelect: document.querySelector("#workflow-select"),
+  workflowDescription: document.querySelector("#workflow-description"),
+  workflowParameters: document.querySelector("#workflow-parameters"),
+  workflowPlan: document.querySelector("#workflow-plan"),
+  workflowRun: document.querySelector("#workflow-run"),
+  workflowCancel: document.querySelector("#workflow-cancel"),
+  workflowPlanOutput: document.querySelector("#workflow-plan-output"),
+  actionHistorySummary: document.querySelector("#action-history-summary"),
+  actionHistoryKind: document.querySelector("#action-history-kind"),
+  actionHistoryStatus: document.querySelector("#action-history-status"),
+  actionHistoryPath: document.querySelector("#action-history-path"),
+  actionHistoryRefresh: document.querySelector("#action-history-refresh"),
+  actionHistoryExport: document.querySelector("#action-history-export"),
+  actionHistoryBody: document.querySelector("#action-history-body"),
+  syncScopeSummary: document.querySelector("#sync-scope-summary"),
+  syncRepository: document.querySelector("#sync-repository"),
+  syncBranch: document.querySelector("#sync-branch"),
+  syncStrategy: document.querySelector("#sync-strategy"),
+  syncPreviewButton: document.querySelector("#sync-preview"),
+  syncRun: document.querySelector("#sync-run"),
+  syncCancel: document.querySelector("#sync-cancel"),
+  syncPreviewOutput: document.querySelector("#sync-preview-output"),
+  syncEvents: document.querySelector("#sync-events"),
+  syncHandoff: document.querySelector("#sync-handoff"),
+  pullRequestScopeSummary: document.querySelector("#pull-request-scope-summary"),
+  pullRequestLoad: document.querySelector("#pull-request-load"),
+  pullRequestList: document.querySelector("#pull-request-list"),
+  packageScopeSummary: document.querySelector("#package-scope-summary"),
+  packageKeep: document.querySelector("#package-keep"),
+  packageLoad: document.querySelector("#package-load"),
+  packageList: document.querySelector("#package-list"),
 };
 
 export function normalizeDiscoveredRepository(repository) {
@@ -337,6 +832,90 @@ export function mergeKnownRepositories(repositories) {
   elements.repoCount.textContent = String(state.repositories.length);
 }
 
+export function activeWorkspace() {
+  return state.workspaces.find((workspace) => workspace.name === state.activeWorkspaceName) || null;
+}
+
+/**
+ * The column filters, sort, and hidden columns saved with a workspace; no workspace means the full,
+ * unsorted table.
+ * @param {Workspace | null} workspace
+ * @returns {AuditView}
+ */
+export function workspaceAuditView(workspace) {
+  return {
+    filters: { ...(workspace?.filters?.columns || {}) },
+    sort: {
+      column: workspace?.audit?.sort?.column || "",
+      direction: workspace?.audit?.sort?.direction || auditSortAscendingValue,
+    },
+    hiddenColumns: (workspace?.audit?.hidden_columns || []).slice(),
+  };
+}
+
+/** @param {AuditView} view */
+export function applyAuditView(view) {
+  state.auditColumnFilters = { ...view.filters };
+  state.auditSort = { ...view.sort };
+  state.auditHiddenColumns = view.hiddenColumns.slice();
+}
+
+/**
+ * Reads the audit view a deep link carries, or null when the address names none.
+ * @returns {AuditView | null}
+ */
+export function readAuditViewLocation() {
+  const query = new URLSearchParams(window.location.search);
+  /** @type {Record<string, string>} */
+  const filters = {};
+  query.forEach((value, key) => {
+    if (key.startsWith(auditFilterQueryParameterPrefix) && value) {
+      filters[key.slice(auditFilterQueryParameterPrefix.length)] = value;
+    }
+  });
+  const sortColumn = query.get(auditSortQueryParameter) || "";
+  const hiddenColumns = (query.get(auditHiddenColumnsQueryParameter) || "").split(",").filter(Boolean);
+  if (Object.keys(filters).length === 0 && !sortColumn && hiddenColumns.length === 0) {
+    return null;
+  }
+
+  return {
+    filters,
+    sort: {
+      column: sortColumn,
+      direction: query.get(auditSortDirectionQueryParameter) === auditSortDescendingValue ? auditSortDescendingValue : auditSortAscendingValue,
+    },
+    hiddenColumns,
+  };
+}
+
+/**
+ * Mirrors the audit view into the address so it can be shared; null drops the view parameters.
+ * @param {AuditView | null} view
+ */
+export function writeAuditViewLocation(view) {
+  const location = new URL(window.location.href);
+  Array.from(location.searchParams.keys())
+    .filter((key) => key.startsWith(auditFilterQueryParameterPrefix)
+      || key === auditSortQueryParameter
+      || key === auditSortDirectionQueryParameter
+      || key === auditHiddenColumnsQueryParameter)
+    .forEach((key) => location.searchParams.delete(key));
+  if (view) {
+    Object.entries(view.filters).filter(([, value]) => Boolean(value)).forEach(([column, value]) => {
+      location.searchParams.set(`${auditFilterQueryParameterPrefix}${column}`, value);
+    });
+    if (view.sort.column) {
+      location.searchParams.set(auditSortQueryParameter, view.sort.column);
+      location.searchParams.set(auditSortDirectionQueryParameter, view.sort.direction);
+    }
+    if (view.hiddenColumns.length > 0) {
+      location.searchParams.set(auditHiddenColumnsQueryParameter, view.hiddenColumns.join(","));
+    }
+  }
+  window.history.replaceState(null, "", location);
+}
+
 export function checkedRepositories() {
   return state.repositories.filter((repository) => state.checkedRepositoryIDs.includes(repository.id));
 }
@@ -456,5 +1035,86 @@ export function setStatus(status) {
     elements.runStatus.classList.add("status-failed");
     return;
   }
+  if (status === auditChangeStatusHandoffValue) {
+    elements.runStatus.classList.add("status-handoff");
+    return;
+  }
   elements.runStatus.classList.add("status-idle");
 }
+
+/**
+ * Reads the Server-Sent Events of a streamed apply and hands each decoded event to handleEvent.
+ * @param {ReadableStream<Uint8Array>} body
+ * @param {(event: AuditChangeEvent) => void} handleEvent
+ */
+export async function readAuditChangeEvents(body, handleEvent) {
+  const reader = body.pipeThrough(new TextDecoderStream()).getReader();
+  let buffered = "";
+
+  for (;;) {
+    const { value, done } = await reader.read();
+    if (done) {
+      return;
+    }
+    buffered += value;
+
+    let frameEnd = buffered.indexOf("\n\n");
+    while (frameEnd >= 0) {
+      const frame = buffered.slice(0, frameEnd);
+      buffered = buffered.slice(frameEnd + 2);
+      frameEnd = buffered.indexOf("\n\n");
+
+      const dataLine = frame.split("\n").find((line) => line.startsWith("data: "));
+      if (dataLine) {
+        handleEvent(JSON.parse(dataLine.slice("data: ".length)));
+      }
+    }
+  }
+}
+
+export function auditApplyStatusTokenClass(status) {
+  switch (status) {
+    case auditChangeStatusSucceededValue:
+      return "token-success";
+    case auditChangeStatusRunningValue:
+    case auditChangeStatusSkippedValue:
+    case auditChangeStatusCanceledValue:
+    case auditChangeStatusHandoffValue:
+      return "token-warning";
+    default:
+      return "token-danger";
+  }
+}
+
+export function formatAuditChangeKind(kind) {
+  switch (kind) {
+    case auditChangeKindCommitChangesValue:
+      return "Commit changes";
+    case auditChangeKindConvertProtocolValue:
+      return "Switch remote protocol";
+    case auditChangeKindDeleteFolderValue:
+      return "Delete folder";
+    case auditChangeKindUpdateChangelogValue:
+      return "Update changelog";
+    case auditChangeKindRenameFolderValue:
+      return "Rename folder";
+    case auditChangeKindUpdateCanonicalValue:
+      return "Fix canonical remote";
+    case auditChangeKindSyncWithRemoteValue:
+      return "Sync with remote";
+    case auditChangeKindStrictSyncValue:
+      return "Strict sync";
+    case auditChangeKindClosePullRequestValue:
+      return "Close pull request";
+    case auditChangeKindRetargetPullRequestValue:
+      return "Retarget pull request";
+    case auditChangeKindDeleteMergedBranchValue:
+      return "Delete merged branch";
+    case auditChangeKindPackageRetentionValue:
+      return "Package retention";
+    case auditChangeKindWorkflowValue:
+      return "Workflow run";
+    default:
+      return kind;
+  }
+}
@@ -0,0 +1,402 @@
+// @ts-check
+
+import {
+  auditApplyCancelEndpoint,
+  auditApplyStreamEndpoint,
+  auditChangeKindStrictSyncValue,
+  auditChangeStatusHandoffValue,
+  auditChangeStatusSucceededValue,
+  elements,
+  state,
+  syncPreviewEndpoint,
+  appendEmptyState,
+  appendToken,
+  clearRunnerOutput,
+  compareRepositories,
+  readAuditChangeEvents,
+  renderRunError,
+  repositoryTreePathWithin,
+  setStatus,
+} from "./shared.js";
+import {
+  workingFolderRoots,
+} from "./repo_tree.js";
+import {
+  loadActionHistory,
+} from "./history.js";
+
+const syncTargetSourceLabels = Object.freeze({
+  explicit: "chosen in this panel",
+  current: "checked-out branch",
+  configured_default: "configured sync branch",
+});
+
+const syncReviewBaseSourceLabels = Object.freeze({
+  default_branch: "remote default branch",
+  recorded: "recorded stacked parent",
+});
+
+/** Re-evaluates the sync panel after the tree scope, the chosen repository, or the options change. */
+export function renderSyncState() {
+  renderSyncRepositoryOptions();
+
+  const repositoryPath = state.syncRepositoryPath;
+  elements.syncScopeSummary.textContent = repositoryPath
+    ? `Syncs ${repositoryPath}`
+    : "Select a folder or check repositories that contain a Git repository.";
+
+  elements.syncPreviewButton.disabled = !repositoryPath || state.syncRunning;
+  const preview = state.syncPreview;
+  const previewCurrent = Boolean(preview && !preview.error && !preview.blocker) && state.syncPreviewKey === syncRequestKey();
+  elements.syncRun.disabled = !previewCurrent || state.syncRunning;
+  elements.syncCancel.hidden = !state.syncRunning;
+  elements.syncCancel.disabled = !state.syncRunID;
+  if (preview && state.syncPreviewKey !== syncRequestKey() && !elements.syncPreviewOutput.hidden) {
+    elements.syncPreviewOutput.classList.add("workflow-plan-stale");
+  } else {
+    elements.syncPreviewOutput.classList.remove("workflow-plan-stale");
+  }
+}
+
+export function handleSyncRequestChange() {
+  state.syncRepositoryPath = String(elements.syncRepository.value || "");
+  renderSyncState();
+}
+
+export async function previewSync() {
+  const request = currentSyncRequest();
+  if (!request.path) {
+    return;
+  }
+  const requestKey = JSON.stringify(request);
+  elements.syncPreviewOutput.hidden = false;
+  elements.syncPreviewOutput.textContent = "Resolving the sync plan...";
+
+  /** @type {import("./shared.js").SyncPreview} */
+  let preview;
+  try {
+    const response = await fetch(syncPreviewEndpoint, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify(request),
+    });
+    preview = await response.json();
+    if (!response.ok) {
+      throw new Error(preview.error || `Failed to preview sync: ${response.status}`);
+    }
+  } catch (error) {
+    preview = { path: request.path, error: String(error instanceof Error ? error.message : error) };
+  }
+
+  state.syncPreview = preview;
+  state.syncPreviewKey = requestKey;
+  elements.syncPreviewOutput.textContent = formatSyncPreview(preview);
+  renderSyncHandoff(preview.handoff || null, "A previous sync is waiting for recovery.");
+  renderSyncState();
+}
+
+export async function runSync() {
+  if (!state.syncPreview || state.syncPreviewKey !== syncRequestKey()) {
+    return;
+  }
+
+  const request = currentSyncRequest();
+  const changeID = `sync-change-${state.nextSyncChangeSequence++}`;
+  state.syncRunning = true;
+  state.syncRunID = "";
+  state.syncChangeID = changeID;
+  elements.syncEvents.replaceChildren();
+  renderSyncHandoff(null, "");
+  renderSyncState();
+  clearRunnerOutput();
+  renderRunError("");
+  setStatus("running");
+
+  try {
+    const response = await fetch(auditApplyStreamEndpoint, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({
+        changes: [{
+          id: changeID,
+          kind: auditChangeKindStrictSyncValue,
+          path: request.path,
+          branch: request.branch,
+          sync_strategy: request.sync_strategy,
+        }],
+      }),
+    });
+    if (!response.ok || !response.body) {
+      const payload = await response.json().catch(() => ({ error: `HTTP ${response.status}` }));
+      throw new Error(payload.error || `Failed to start sync: ${response.status}`);
+    }
+
+    /** @type {import("./shared.js").AuditChangeApplyResponse | null} */
+    let finalResponse = null;
+    await readAuditChangeEvents(response.body, (event) => {
+      switch (event.type) {
+        case "run":
+          state.syncRunID = event.run_id;
+          renderSyncState();
+          break;
+        case "output":
+          appendSyncOutput(event.stream || "", event.text || "");
+          break;
+        case "sync_event":
+          if (event.event) {
+            appendSyncEvent(event.event);
+          }
+          break;
+        case "done":
+          finalResponse = event.response || {};
+          break;
+      }
+    });
+    if (!finalResponse) {
+      throw new Error("The sync stream ended before reporting its result.");
+    }
+
+    /** @type {import("./shared.js").AuditChangeApplyResponse} */
+    const syncResponse = finalResponse;
+    if (syncResponse.error) {
+      throw new Error(syncResponse.error);
+    }
+    const result = (syncResponse.results || [])[0];
+    if (!result) {
+      throw new Error("The sync finished without a result.");
+    }
+
+    const messages = [];
+    if (result.status === auditChangeStatusHandoffValue) {
+      renderSyncHandoff(result.handoff || null, "The sync stopped and handed the repository back for recovery.");
+      setStatus(auditChangeStatusHandoffValue);
+    } else if (result.status === auditChangeStatusSucceededValue) {
+      setStatus("succeeded");
+    } else {
+      messages.push(result.error || `Strict sync ${result.status} for ${result.path}`);
+      setStatus("failed");
+    }
+    if (syncResponse.history_error) {
+      messages.push(`Action history not recorded: ${syncResponse.history_error}`);
+    }
+    renderRunError(messages.join("\n"));
+  } catch (error) {
+    renderRunError(String(error));
+    setStatus("failed");
+  } finally {
+    state.syncRunning = false;
+    state.syncRunID = "";
+    state.syncChangeID = "";
+    state.syncPreview = null;
+    state.syncPreviewKey = "";
+    renderSyncState();
+    void loadActionHistory();
+  }
+}
+
+export async function cancelSync() {
+  if (!state.syncRunID || !state.syncChangeID) {
+    return;
+  }
+
+  try {
+    const response = await fetch(auditApplyCancelEndpoint, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ run_id: state.syncRunID, change_id: state.syncChangeID }),
+    });
+    if (!response.ok) {
+      const payload = await response.json().catch(() => ({ error: `HTTP ${response.status}` }));
+      throw new Error(payload.error || `Failed to cancel sync: ${response.status}`);
+    }
+  } catch (error) {
+    renderRunError(String(error));
+  }
+}
+
+function syncRepositoryCandidates() {
+  const scopeRoots = workingFolderRoots();
+  return state.repositories
+    .filter((repository) => scopeRoots.some((rootPath) => repositoryTreePathWithin(rootPath, repository.path)))
+    .sort(compareRepositories);
+}
+
+function renderSyncRepositoryOptions() {
+  const candidates = syncRepositoryCandidates();
+  if (!candidates.some((repository) => repository.path === state.syncRepositoryPath)) {
+    state.syncRepositoryPath = candidates.length > 0 ? candidates[0].path : "";
+  }
+
+  const optionPaths = Array.from(elements.syncRepository.options).map((option) => option.value);
+  if (optionPaths.join("\n") !== candidates.map((repository) => repository.path).join("\n")) {
+    elements.syncRepository.replaceChildren(...candidates.map((repository) => {
+      const branch = repository.current_branch ? ` (${repository.current_branch})` : "";
+      return new Option(`${repository.name}${branch}`, repository.path);
+    }));
+  }
+  elements.syncRepository.value = state.syncRepositoryPath;
+  elements.syncRepository.disabled = candidates.length === 0 || state.syncRunning;
+}
+
+function currentSyncRequest() {
+  return {
+    path: state.syncRepositoryPath,
+    branch: String(elements.syncBranch.value || "").trim(),
+    sync_strategy: String(elements.syncStrategy.value || ""),
+  };
+}
+
+function syncRequestKey() {
+  return JSON.stringify(currentSyncRequest());
+}
+
+/**
+ * @param {string} stream
+ * @param {string} text
+ */
+function appendSyncOutput(stream, text) {
+  const output = stream === "stderr" ? elements.stderrOutput : elements.stdoutOutput;
+  output.textContent += text;
+  output.scrollTop = output.scrollHeight;
+}
+
+/** @param {import("./shared.js").SyncEvent} event */
+function appendSyncEvent(event) {
+  const row = document.createElement("li");
+  row.className = "sync-event";
+  appendToken(row, event.code, syncEventTokenClass(event.level));
+  const message = document.createElement("span");
+  message.textContent = event.message;
+  row.append(message);
+  elements.syncEvents.append(row);
+  row.scrollIntoView({ block: "nearest" });
+}
+
+/** @param {string} level */
+function syncEventTokenClass(level) {
+  switch (level) {
+    case "ERROR":
+      return "token-danger";
+    case "WARN":
+      return "token-warning";
+    default:
+      return "token-muted";
+  }
+}
+
+/**
+ * Shows a SYNC_SWITCH_HANDOFF or AI_MERGE_HANDOFF with the steps that remain, or hides the card.
+ * @param {import("./shared.js").SyncHandoff | null} handoff
+ * @param {string} summary
+ */
+function renderSyncHandoff(handoff, summary) {
+  elements.syncHandoff.replaceChildren();
+  elements.syncHandoff.hidden = !handoff;
+  if (!handoff) {
+    return;
+  }
+
+  const heading = document.createElement("div");
+  heading.className = "sync-handoff-heading";
+  appendToken(heading, handoff.code, "token-warning");
+  const headingText = document.createElement("strong");
+  headingText.textContent = summary;
+  heading.append(headingText);
+  elements.syncHandoff.append(heading);
+
+  const facts = [
+    ["What happened", handoff.message || ""],
+    ["Reason", handoff.reason || ""],
+    ["Target branch", handoff.target_branch || ""],
+    ["Handoff record", handoff.record_path || ""],
+  ].filter(([, value]) => value);
+  if (facts.length > 0) {
+    const factList = document.createElement("dl");
+    factList.className = "sync-handoff-facts";
+    facts.forEach(([label, value]) => {
+      const term = document.createElement("dt");
+      term.textContent = label;
+      const description = document.createElement("dd");
+      description.textContent = value;
+      factList.append(term, description);
+    });
+    elements.syncHandoff.append(factList);
+  }
+
+  const stepsHeading = document.createElement("h4");
+  stepsHeading.textContent = "Recovery";
+  elements.syncHandoff.append(stepsHeading);
+  const steps = handoff.recovery_steps || [];
+  if (steps.length > 0) {
+    const stepList = document.createElement("ol");
+    stepList.className = "sync-handoff-steps";
+    steps.forEach((step) => {
+      const item = document.createElement("li");
+      item.textContent = step;
+      stepList.append(item);
+    });
+    elements.syncHandoff.append(stepList);
+  } else {
+    appendEmptyState(elements.syncHandoff, "No recovery steps remain in the handoff record.");
+  }
+
+  if (handoff.recover_command) {
+    const commandNote = document.createElement("p");
+    commandNote.className = "panel-note";
+    commandNote.textContent = "Finish the remaining steps from a terminal; each one asks for confirmation:";
+    const command = document.createElement("pre");
+    command.className = "terminal-window sync-recover-command";
+    command.textContent = handoff.recover_command;
+    elements.syncHandoff.append(commandNote, command);
+  }
+}
+
+/** @param {import("./shared.js").SyncPreview} preview */
+function formatSyncPreview(preview) {
+  if (preview.error) {
+    return preview.error;
+  }
+
+  const lines = [`Sync plan for ${preview.path}`];
+  const targetSource = syncTargetSourceLabels[preview.target_source || ""] || preview.target_source || "";
+  const generated = preview.generated_branch ? " (the dirty work moves to a generated branch)" : "";
+  lines.push(`Target branch: ${preview.target_branch || "(none)"}${targetSource ? ` — ${targetSource}` : ""}${generated}`);
+  if (preview.current_branch && preview.current_branch !== preview.target_branch) {
+    lines.push(`Checked out: ${preview.current_branch}`);
+  }
+  const remotes = preview.base_remote && preview.base_remote !== preview.push_remote
+    ? `push ${preview.push_remote}, base ${preview.base_remote}`
+    : preview.push_remote || "";
+  if (remotes) {
+    lines.push(`Remote: ${remotes}`);
+  }
+  if (preview.default_branch) {
+    lines.push(`Default branch: ${preview.default_branch}`);
+  }
+  const reviewBaseSource = syncReviewBaseSourceLabels[preview.review_base_source || ""] || preview.review_base_source || "";
+  lines.push(`Review base: ${preview.review_base || "(unknown)"}${reviewBaseSource ? ` — ${reviewBaseSource}` : ""}`);
+
+  const clusters = preview.clusters || [];
+  if (clusters.length === 0) {
+    lines.push("Working tree: clean");
+  } else {
+    const action = preview.dirty_action === "commit"
+      ? "committed one cluster per commit"
+      : preview.dirty_action === "stash"
+        ? "stashed for the sync and restored afterwards"
+        : "left in place";
+    lines.push(`Dirty work (${action}):`);
+    clusters.forEach((cluster) => {
+      const paths = [
+        ...(cluster.tracked_paths || []),
+        ...(cluster.untracked_paths || []).map((path) => `${path} (untracked)`),
+      ];
+      lines.push(`  ${cluster.root}: ${paths.join(", ")}`);
+    });
+  }
+  if (preview.blocker) {
+    lines.push("");
+    lines.push(`Blocked: ${preview.blocker}`);
+  }
+  return lines.join("\n");
+}
@@ -0,0 +1,397 @@
+// @ts-check
+
+import {
+  auditApplyCancelEndpoint,
+  auditApplyStreamEndpoint,
+  auditChangeKindWorkflowValue,
+  auditChangeStatusSucceededValue,
+  elements,
+  state,
+  workflowPlanEndpoint,
+  workflowsEndpoint,
+  clearRunnerOutput,
+  readAuditChangeEvents,
+  renderRunError,
+  setStatus,
+  summarizeAuditSelectionValues,
+} from "./shared.js";
+import {
+  workingFolderRoots,
+} from "./repo_tree.js";
+import {
+  loadActionHistory,
+} from "./history.js";
+
+const workflowSourceLabels = Object.freeze({
+  primitive: "Primitives",
+  preset: "Embedded presets",
+  file: "Workflow files",
+});
+
+export async function loadWorkflowCatalog() {
+  /** @type {import("./shared.js").WorkflowCatalog} */
+  let catalog;
+  try {
+    const response = await fetch(workflowsEndpoint);
+    catalog = await response.json();
+    if (!response.ok) {
+      throw new Error(catalog.error || `Failed to load workflows: ${response.status}`);
+    }
+  } catch (error) {
+    elements.workflowDescription.textContent = String(error instanceof Error ? error.message : error);
+    return;
+  }
+
+  state.workflows = catalog.workflows || [];
+  renderWorkflowOptions();
+  if (catalog.error) {
+    elements.workflowDescription.textContent = catalog.error;
+  }
+}
+
+/** Re-evaluates the workflow controls after the tree scope, selection, or parameters change. */
+export function renderWorkflowState() {
+  const repositoryPaths = workflowRepositoryPaths();
+  elements.workflowScopeSummary.textContent = repositoryPaths.length > 0
+    ? `Runs against ${summarizeAuditSelectionValues(repositoryPaths)}`
+    : "Select a folder or check repositories to choose a target.";
+
+  const workflow = selectedWorkflow();
+  const runnable = Boolean(workflow && !workflow.error) && repositoryPaths.length > 0 && !state.workflowRunning;
+  elements.workflowPlan.disabled = !runnable;
+
+  const planCurrent = Boolean(state.workflowPlan && !state.workflowPlan.error) && state.workflowPlanKey === workflowRequestKey();
+  elements.workflowRun.disabled = !runnable || !planCurrent;
+  elements.workflowCancel.hidden = !state.workflowRunning;
+  elements.workflowCancel.disabled = !state.workflowRunID;
+  if (state.workflowPlan && !planCurrent && !elements.workflowPlanOutput.hidden) {
+    elements.workflowPlanOutput.classList.add("workflow-plan-stale");
+  } else {
+    elements.workflowPlanOutput.classList.remove("workflow-plan-stale");
+  }
+}
+
+export function handleWorkflowSelectChange() {
+  state.selectedWorkflowID = String(elements.workflowSelect.value || "");
+  state.workflowPlan = null;
+  state.workflowPlanKey = "";
+  elements.workflowPlanOutput.hidden = true;
+  renderWorkflowParameters();
+  renderWorkflowState();
+}
+
+export async function previewWorkflowPlan() {
+  const request = currentWorkflowRequest();
+  const requestKey = JSON.stringify(request);
+  elements.workflowPlanOutput.hidden = false;
+  elements.workflowPlanOutput.textContent = "Planning workflow...";
+
+  /** @type {import("./shared.js").WorkflowPlan} */
+  let plan;
+  try {
+    const response = await fetch(workflowPlanEndpoint, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify(request),
+    });
+    plan = await response.json();
+    if (!response.ok) {
+      throw new Error(plan.error || `Failed to plan workflow: ${response.status}`);
+    }
+  } catch (error) {
+    plan = { workflow_id: request.workflow_id, error: String(error instanceof Error ? error.message : error) };
+  }
+
+  state.workflowPlan = plan;
+  state.workflowPlanKey = requestKey;
+  elements.workflowPlanOutput.textContent = formatWorkflowPlan(plan);
+  renderWorkflowState();
+}
+
+export async function runWorkflow() {
+  if (!state.workflowPlan || state.workflowPlanKey !== workflowRequestKey()) {
+    return;
+  }
+
+  const request = currentWorkflowRequest();
+  /** @type {Map<string, string>} */
+  const changePaths = new Map();
+  const changes = request.repository_paths.map((repositoryPath) => {
+    const changeID = `workflow-change-${state.nextWorkflowChangeSequence++}`;
+    changePaths.set(changeID, repositoryPath);
+    return {
+      id: changeID,
+      kind: auditChangeKindWorkflowValue,
+      path: repositoryPath,
+      workflow_id: request.workflow_id,
+      parameters: request.parameters,
+    };
+  });
+
+  state.workflowRunning = true;
+  state.workflowRunID = "";
+  renderWorkflowState();
+  clearRunnerOutput();
+  renderRunError("");
+  setStatus("running");
+
+  try {
+    const response = await fetch(auditApplyStreamEndpoint, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ changes }),
+    });
+    if (!response.ok || !response.body) {
+      const payload = await response.json().catch(() => ({ error: `HTTP ${response.status}` }));
+      throw new Error(payload.error || `Failed to start workflow: ${response.status}`);
+    }
+
+    /** @type {import("./shared.js").AuditChangeApplyResponse | null} */
+    let finalResponse = null;
+    await readAuditChangeEvents(response.body, (event) => {
+      switch (event.type) {
+        case "run":
+          state.workflowRunID = event.run_id;
+          renderWorkflowState();
+          break;
+        case "started":
+          appendWorkflowOutput("stdout", `==> ${changePaths.get(event.id || "") || event.id}\n`);
+          break;
+        case "output":
+          appendWorkflowOutput(event.stream || "", event.text || "");
+          break;
+        case "done":
+          finalResponse = event.response || {};
+          break;
+      }
+    });
+    if (!finalResponse) {
+      throw new Error("The workflow stream ended before reporting its result.");
+    }
+
+    /** @type {import("./shared.js").AuditChangeApplyResponse} */
+    const workflowResponse = finalResponse;
+    if (workflowResponse.error) {
+      throw new Error(workflowResponse.error);
+    }
+    const results = workflowResponse.results || [];
+    const messages = results
+      .filter((result) => result.status !== auditChangeStatusSucceededValue)
+      .map((result) => `${result.path}: ${result.error || result.status}`);
+    if (workflowResponse.history_error) {
+      messages.push(`Action history not recorded: ${workflowResponse.history_error}`);
+    }
+    renderRunError(messages.join("\n"));
+    const succeeded = results.length === changes.length
+      && results.every((result) => result.status === auditChangeStatusSucceededValue);
+    setStatus(succeeded ? "succeeded" : "failed");
+  } catch (error) {
+    renderRunError(String(error));
+    setStatus("failed");
+  } finally {
+    state.workflowRunning = false;
+    state.workflowRunID = "";
+    state.workflowPlan = null;
+    state.workflowPlanKey = "";
+    renderWorkflowState();
+    void loadActionHistory();
+  }
+}
+
+/** Cancels every repository of the running workflow; an empty change ID targets the whole run. */
+export async function cancelWorkflow() {
+  if (!state.workflowRunID) {
+    return;
+  }
+
+  try {
+    const response = await fetch(auditApplyCancelEndpoint, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ run_id: state.workflowRunID, change_id: "" }),
+    });
+    if (!response.ok) {
+      const payload = await response.json().catch(() => ({ error: `HTTP ${response.status}` }));
+      throw new Error(payload.error || `Failed to cancel workflow: ${response.status}`);
+    }
+  } catch (error) {
+    renderRunError(String(error));
+  }
+}
+
+/**
+ * @param {string} stream
+ * @param {string} text
+ */
+function appendWorkflowOutput(stream, text) {
+  const output = stream === "stderr" ? elements.stderrOutput : elements.stdoutOutput;
+  output.textContent += text;
+  output.scrollTop = output.scrollHeight;
+}
+
+function renderWorkflowOptions() {
+  elements.workflowSelect.replaceChildren();
+  Object.entries(workflowSourceLabels).forEach(([source, label]) => {
+    const workflows = state.workflows.filter((workflow) => workflow.source === source);
+    if (workflows.length === 0) {
+      return;
+    }
+    const group = document.createElement("optgroup");
+    group.label = label;
+    workflows.forEach((workflow) => {
+      const option = document.createElement("option");
+      option.value = workflow.id;
+      option.textContent = workflow.error ? `${workflow.label} (invalid)` : workflow.label;
+      group.append(option);
+    });
+    elements.workflowSelect.append(group);
+  });
+
+  if (!selectedWorkflow()) {
+    state.selectedWorkflowID = state.workflows.length > 0 ? state.workflows[0].id : "";
+  }
+  elements.workflowSelect.value = state.selectedWorkflowID;
+  renderWorkflowParameters();
+  renderWorkflowState();
+}
+
+function renderWorkflowParameters() {
+  const workflow = selectedWorkflow();
+  elements.workflowParameters.replaceChildren();
+  if (!workflow) {
+    elements.workflowDescription.textContent = "No workflows available.";
+    return;
+  }
+
+  elements.workflowDescription.textContent = workflow.error
+    ? workflow.error
+    : [workflow.description, workflow.path].filter(Boolean).join(" · ");
+
+  (workflow.parameters || []).forEach((parameter) => {
+    elements.workflowParameters.append(renderWorkflowParameter(parameter));
+  });
+}
+
+/** @param {import("./shared.js").WorkflowParameterDescriptor} parameter */
+function renderWorkflowParameter(parameter) {
+  const inputID = `workflow-parameter-${parameter.key}`;
+
+  if (parameter.control === "checkbox") {
+    const row = document.createElement("label");
+    row.className = "checkbox-row";
+    row.htmlFor = inputID;
+    const checkbox = document.createElement("input");
+    checkbox.id = inputID;
+    checkbox.type = "checkbox";
+    checkbox.checked = Boolean(parameter.default_bool);
+    checkbox.dataset.workflowParameter = parameter.key;
+    const text = document.createElement("span");
+    text.textContent = parameter.label;
+    row.append(checkbox, text);
+    return row;
+  }
+
+  const field = document.createElement("div");
+  field.className = "workflow-parameter";
+  const label = document.createElement("label");
+  label.className = "field-label";
+  label.htmlFor = inputID;
+  label.textContent = parameter.required ? `${parameter.label} *` : parameter.label;
+
+  /** @type {HTMLInputElement | HTMLTextAreaElement | HTMLSelectElement} */
+  let input;
+  if (parameter.control === "select") {
+    input = document.createElement("select");
+    input.className = "select-input";
+    if (!parameter.required) {
+      input.append(new Option("", ""));
+    }
+    (parameter.options || []).forEach((option) => {
+      input.append(new Option(option.label, option.value));
+    });
+  } else if (parameter.control === "textarea") {
+    input = document.createElement("textarea");
+    input.className = "code-input workflow-parameter-textarea";
+    input.spellcheck = false;
+    input.placeholder = parameter.placeholder || "";
+  } else {
+    input = document.createElement("input");
+    input.type = "text";
+    input.className = "text-input";
+    input.placeholder = parameter.placeholder || "";
+  }
+  input.id = inputID;
+  input.value = parameter.default_value || "";
+  input.dataset.workflowParameter = parameter.key;
+
+  field.append(label, input);
+  if (parameter.description) {
+    const description = document.createElement("p");
+    description.className = "panel-note";
+    description.textContent = parameter.description;
+    field.append(description);
+  }
+  return field;
+}
+
+function selectedWorkflow() {
+  return state.workflows.find((workflow) => workflow.id === state.selectedWorkflowID) || null;
+}
+
+function workflowRepositoryPaths() {
+  return Array.from(new Set(workingFolderRoots()));
+}
+
+function currentWorkflowRequest() {
+  /** @type {Record<string, string | boolean>} */
+  const parameters = {};
+  elements.workflowParameters.querySelectorAll("[data-workflow-parameter]").forEach((input) => {
+    const key = String(input instanceof HTMLElement ? input.dataset.workflowParameter || "" : "");
+    if (!key) {
+      return;
+    }
+    if (input instanceof HTMLInputElement && input.type === "checkbox") {
+      parameters[key] = input.checked;
+      return;
+    }
+    if (input instanceof HTMLInputElement || input instanceof HTMLTextAreaElement || input instanceof HTMLSelectElement) {
+      parameters[key] = input.value;
+    }
+  });
+
+  return {
+    workflow_id: state.selectedWorkflowID,
+    parameters,
+    repository_paths: workflowRepositoryPaths(),
+  };
+}
+
+function workflowRequestKey() {
+  return JSON.stringify(currentWorkflowRequest());
+}
+
+/** @param {import("./shared.js").WorkflowPlan} plan */
+function formatWorkflowPlan(plan) {
+  if (plan.error) {
+    return plan.error;
+  }
+
+  const lines = [`Plan for ${plan.label || plan.workflow_id}`];
+  lines.push(`Repositories: ${(plan.repository_paths || []).join(", ")}`);
+  const variableNames = Object.keys(plan.variables || {}).sort();
+  if (variableNames.length > 0) {
+    lines.push("Variables:");
+    variableNames.forEach((name) => {
+      lines.push(`  ${name} = ${(plan.variables || {})[name]}`);
+    });
+  }
+  (plan.steps || []).forEach((step, stepIndex) => {
+    lines.push("");
+    const after = step.after && step.after.length > 0 ? ` (after ${step.after.join(", ")})` : "";
+    lines.push(`${stepIndex + 1}. ${step.name}: ${step.command}${after}`);
+    if (step.options) {
+      lines.push(step.options.replace(/\n$/, "").split("\n").map((line) => `   ${line}`).join("\n"));
+    }
+  });
+  return lines.join("\n");
+}
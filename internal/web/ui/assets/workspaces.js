@@ -0,0 +1,205 @@
+// @ts-check
+
+import {
+  auditDepthFullValue,
+  auditFormatTableValue,
+  elements,
+  state,
+  workspaceQueryParameter,
+  workspacesEndpoint,
+  activeWorkspace,
+  applyAuditView,
+  renderRunError,
+  summarizeAuditSelectionValues,
+  workspaceAuditView,
+  writeAuditViewLocation,
+} from "./shared.js";
+import {
+  loadInitialState,
+  renderRepositoryTree,
+  workingFolderRoots,
+} from "./repo_tree.js";
+
+/** @type {() => void} */
+let workspaceOpenedHandler = () => {};
+
+export function setWorkspaceOpenedHandler(handler) {
+  workspaceOpenedHandler = typeof handler === "function" ? handler : () => {};
+}
+
+export async function loadWorkspaces() {
+  /** @type {import("./shared.js").WorkspaceCatalog} */
+  let catalog;
+  try {
+    const response = await fetch(workspacesEndpoint);
+    catalog = await response.json();
+    if (!response.ok) {
+      throw new Error(catalog.error || `Failed to load workspaces: ${response.status}`);
+    }
+  } catch (error) {
+    elements.workspaceSummary.textContent = String(error instanceof Error ? error.message : error);
+    return;
+  }
+
+  state.workspaces = catalog.workspaces || [];
+  renderWorkspaceState();
+  if (catalog.error) {
+    elements.workspaceSummary.textContent = catalog.error;
+  }
+}
+
+/** Returns the saved workspace named by the ?workspace= query parameter, or "" when none matches. */
+export function requestedWorkspaceName() {
+  const requestedName = new URLSearchParams(window.location.search).get(workspaceQueryParameter) || "";
+  return state.workspaces.some((workspace) => workspace.name === requestedName) ? requestedName : "";
+}
+
+/** Restores the repository filter, audit depth, and audit view saved with the workspace the catalog was loaded for. */
+export function applyActiveWorkspaceDefaults() {
+  const workspace = activeWorkspace();
+  if (elements.repoFilter) {
+    elements.repoFilter.value = workspace?.filters?.repository || "";
+  }
+  if (elements.auditDepth) {
+    elements.auditDepth.value = workspace?.audit?.depth || auditDepthFullValue;
+  }
+  applyAuditView(workspaceAuditView(workspace));
+  syncWorkspaceLocation();
+  renderWorkspaceState();
+}
+
+export async function handleWorkspaceSelectChange() {
+  const workspaceName = String(elements.workspaceSelect.value || "");
+  try {
+    await loadInitialState(workspaceName);
+  } catch (error) {
+    elements.workspaceSelect.value = state.activeWorkspaceName;
+    renderRunError(String(error instanceof Error ? error.message : error));
+    return;
+  }
+
+  renderRunError("");
+  // Another workspace brings its own view, so a deep-linked view no longer applies.
+  state.auditLinkedView = null;
+  writeAuditViewLocation(null);
+  applyActiveWorkspaceDefaults();
+  await renderRepositoryTree((elements.repoFilter?.value || "").trim().toLowerCase());
+  workspaceOpenedHandler();
+}
+
+/** Saves the current scope, filters, audit defaults, and audit view, updating the active workspace when the name is unchanged. */
+export async function saveWorkspace() {
+  const workspace = currentWorkspaceDraft();
+  const updating = Boolean(state.activeWorkspaceName) && workspace.name === state.activeWorkspaceName;
+  const endpoint = updating ? `${workspacesEndpoint}/${encodeURIComponent(state.activeWorkspaceName)}` : workspacesEndpoint;
+  const saved = await sendWorkspaceRequest(endpoint, updating ? "PUT" : "POST", workspace);
+  if (!saved) {
+    return;
+  }
+
+  state.activeWorkspaceName = workspace.name;
+  applyActiveWorkspaceDefaults();
+  elements.workspaceSummary.textContent = `Saved workspace ${workspace.name}.`;
+}
+
+export async function deleteWorkspace() {
+  const workspaceName = state.activeWorkspaceName;
+  if (!workspaceName || !window.confirm(`Delete workspace ${workspaceName}? Its roots stay on disk.`)) {
+    return;
+  }
+
+  const deleted = await sendWorkspaceRequest(`${workspacesEndpoint}/${encodeURIComponent(workspaceName)}`, "DELETE");
+  if (!deleted) {
+    return;
+  }
+
+  state.activeWorkspaceName = "";
+  syncWorkspaceLocation();
+  renderWorkspaceState();
+  elements.workspaceSummary.textContent = `Deleted workspace ${workspaceName}. The current scope stays open until you pick another workspace.`;
+}
+
+/** Re-evaluates the workspace controls after the workspace list or the tree scope changes. */
+export function renderWorkspaceState() {
+  renderWorkspaceOptions();
+  const workspace = activeWorkspace();
+  if (document.activeElement !== elements.workspaceName) {
+    elements.workspaceName.value = workspace?.name || "";
+  }
+  if (elements.workspaceFormat) {
+    elements.workspaceFormat.value = workspace?.audit?.format || auditFormatTableValue;
+  }
+
+  const draftRoots = workingFolderRoots();
+  const draftName = String(elements.workspaceName.value || "").trim();
+  elements.workspaceSave.disabled = !draftName || draftRoots.length === 0;
+  elements.workspaceSave.textContent = workspace && draftName === workspace.name ? "Update" : "Save as new";
+  elements.workspaceDelete.disabled = !workspace;
+  elements.workspaceSummary.textContent = workspace
+    ? `Roots: ${summarizeAuditSelectionValues(workspace.roots)}`
+    : "Save the current scope, filters, audit depth, and table view as a named workspace.";
+}
+
+function renderWorkspaceOptions() {
+  elements.workspaceSelect.innerHTML = "";
+  const launchOption = document.createElement("option");
+  launchOption.value = "";
+  launchOption.textContent = "Launch scope";
+  elements.workspaceSelect.append(launchOption);
+
+  state.workspaces.forEach((workspace) => {
+    const option = document.createElement("option");
+    option.value = workspace.name;
+    option.textContent = workspace.name;
+    elements.workspaceSelect.append(option);
+  });
+  elements.workspaceSelect.value = activeWorkspace() ? state.activeWorkspaceName : "";
+}
+
+/** @returns {import("./shared.js").Workspace} */
+function currentWorkspaceDraft() {
+  return {
+    name: String(elements.workspaceName.value || "").trim(),
+    roots: Array.from(new Set(workingFolderRoots())),
+    filters: {
+      repository: (elements.repoFilter?.value || "").trim(),
+      columns: { ...state.auditColumnFilters },
+    },
+    audit: {
+      depth: elements.auditDepth?.value || auditDepthFullValue,
+      format: elements.workspaceFormat?.value || auditFormatTableValue,
+      hidden_columns: state.auditHiddenColumns.slice(),
+      sort: state.auditSort.column ? { ...state.auditSort } : undefined,
+    },
+  };
+}
+
+async function sendWorkspaceRequest(endpoint, method, workspace = undefined) {
+  try {
+    const response = await fetch(endpoint, {
+      method,
+      headers: { "Content-Type": "application/json" },
+      body: workspace ? JSON.stringify(workspace) : undefined,
+    });
+    /** @type {import("./shared.js").WorkspaceCatalog} */
+    const catalog = await response.json();
+    if (!response.ok) {
+      throw new Error(catalog.error || `Failed to save workspace: ${response.status}`);
+    }
+    state.workspaces = catalog.workspaces || [];
+    return true;
+  } catch (error) {
+    elements.workspaceSummary.textContent = String(error instanceof Error ? error.message : error);
+    return false;
+  }
+}
+
+function syncWorkspaceLocation() {
+  const location = new URL(window.location.href);
+  if (state.activeWorkspaceName) {
+    location.searchParams.set(workspaceQueryParameter, state.activeWorkspaceName);
+  } else {
+    location.searchParams.delete(workspaceQueryParameter);
+  }
+  window.history.replaceState(null, "", location);
+}
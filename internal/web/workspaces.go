@@ -0,0 +1,296 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Workspace audit depths mirror the audit inspection depths.
+const (
+	WorkspaceAuditDepthMinimal = "minimal"
+	WorkspaceAuditDepthFull    = "full"
+	WorkspaceAuditDepthGitHub  = "github"
+)
+
+// Workspace audit formats mirror the audit report formats.
+const (
+	WorkspaceAuditFormatTable  = "table"
+	WorkspaceAuditFormatCSV    = "csv"
+	WorkspaceAuditFormatHTML   = "html"
+	WorkspaceAuditFormatJSON   = "json"
+	WorkspaceAuditFormatNDJSON = "ndjson"
+)
+
+// Workspace audit sort directions order the audit table rows.
+const (
+	WorkspaceAuditSortAscending  = "asc"
+	WorkspaceAuditSortDescending = "desc"
+)
+
+const (
+	workspaceNameParameterConstant           = "name"
+	workspaceQueryParameterConstant          = "workspace"
+	missingWorkspaceStoreErrorConstant       = "missing workspace store"
+	missingWorkspaceRepositoryLoaderConstant = "missing workspace repository loader"
+	missingWorkspaceNameErrorConstant        = "workspace name is required"
+	invalidWorkspaceNameErrorTemplate        = "workspace name %q must start with a letter or digit and contain only letters, digits, '.', '_' or '-'"
+	missingWorkspaceRootsErrorTemplate       = "workspace %q requires at least one root"
+	invalidWorkspaceDepthErrorTemplate       = "workspace %q has unsupported audit depth %q; expected minimal, full, or github"
+	invalidWorkspaceFormatErrorTemplate      = "workspace %q has unsupported audit format %q; expected table, csv, html, json, or ndjson"
+	invalidWorkspaceSortErrorTemplate        = "workspace %q has unsupported audit sort direction %q; expected asc or desc"
+	workspaceNotFoundErrorTemplate           = "%w: %s"
+)
+
+var (
+	// ErrWorkspaceNotFound reports a workspace name that is not saved.
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+	// ErrWorkspaceExists reports a create or rename that collides with a saved workspace.
+	ErrWorkspaceExists = errors.New("workspace already exists")
+)
+
+var workspaceNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// WorkspaceStore lists and edits the saved workspaces. Implementations report ErrWorkspaceNotFound
+// and ErrWorkspaceExists (possibly wrapped) so the API can answer 404 and 409.
+type WorkspaceStore interface {
+	ListWorkspaces() ([]Workspace, error)
+	CreateWorkspace(Workspace) error
+	// UpdateWorkspace replaces the workspace saved as name; workspace.Name may rename it.
+	UpdateWorkspace(name string, workspace Workspace) error
+	DeleteWorkspace(name string) error
+}
+
+// WorkspaceRepositoryLoader builds the repository catalog for the roots of a saved workspace.
+type WorkspaceRepositoryLoader func(context.Context, []string) RepositoryCatalog
+
+// Workspace is a named set of roots with the browser filters and audit defaults restored with it.
+type Workspace struct {
+	Name    string           `json:"name" yaml:"name"`
+	Roots   []string         `json:"roots" yaml:"roots"`
+	Filters WorkspaceFilters `json:"filters" yaml:"filters,omitempty"`
+	Audit   WorkspaceAudit   `json:"audit" yaml:"audit"`
+}
+
+// WorkspaceFilters holds the selector filters applied when a workspace opens.
+type WorkspaceFilters struct {
+	// Repository narrows the repository tree like the sidebar search box.
+	Repository string `json:"repository,omitempty" yaml:"repository,omitempty"`
+	// Columns holds audit column filters keyed by audit column name.
+	Columns map[string]string `json:"columns,omitempty" yaml:"columns,omitempty"`
+}
+
+// WorkspaceAudit holds the audit defaults of a workspace.
+type WorkspaceAudit struct {
+	Depth  string `json:"depth" yaml:"depth"`
+	Format string `json:"format" yaml:"format"`
+	// HiddenColumns lists the audit columns the table hides and exports leave out.
+	HiddenColumns []string `json:"hidden_columns,omitempty" yaml:"hidden_columns,omitempty"`
+	// Sort orders the audit table; an empty column keeps the inspection order.
+	Sort WorkspaceAuditSort `json:"sort,omitzero" yaml:"sort,omitempty"`
+}
+
+// WorkspaceAuditSort names the audit column the table is sorted by and the direction.
+type WorkspaceAuditSort struct {
+	Column    string `json:"column,omitempty" yaml:"column,omitempty"`
+	Direction string `json:"direction,omitempty" yaml:"direction,omitempty"`
+}
+
+// WorkspaceCatalog lists the saved workspaces for the browser.
+type WorkspaceCatalog struct {
+	Workspaces []Workspace `json:"workspaces"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// NormalizeWorkspace trims the workspace fields, drops empty roots, filters, and hidden columns, fills
+// the default audit depth (full), format (table), and sort direction (asc), and rejects names, depths,
+// formats, and sort directions gix cannot use.
+func NormalizeWorkspace(workspace Workspace) (Workspace, error) {
+	name := strings.TrimSpace(workspace.Name)
+	if len(name) == 0 {
+		return Workspace{}, errors.New(missingWorkspaceNameErrorConstant)
+	}
+	if !workspaceNamePattern.MatchString(name) {
+		return Workspace{}, fmt.Errorf(invalidWorkspaceNameErrorTemplate, name)
+	}
+
+	roots := make([]string, 0, len(workspace.Roots))
+	for _, root := range workspace.Roots {
+		if trimmedRoot := strings.TrimSpace(root); len(trimmedRoot) > 0 && !slices.Contains(roots, trimmedRoot) {
+			roots = append(roots, trimmedRoot)
+		}
+	}
+	if len(roots) == 0 {
+		return Workspace{}, fmt.Errorf(missingWorkspaceRootsErrorTemplate, name)
+	}
+
+	depth := strings.ToLower(strings.TrimSpace(workspace.Audit.Depth))
+	switch depth {
+	case "":
+		depth = WorkspaceAuditDepthFull
+	case WorkspaceAuditDepthMinimal, WorkspaceAuditDepthFull, WorkspaceAuditDepthGitHub:
+	default:
+		return Workspace{}, fmt.Errorf(invalidWorkspaceDepthErrorTemplate, name, workspace.Audit.Depth)
+	}
+
+	format := strings.ToLower(strings.TrimSpace(workspace.Audit.Format))
+	switch format {
+	case "":
+		format = WorkspaceAuditFormatTable
+	case WorkspaceAuditFormatTable, WorkspaceAuditFormatCSV, WorkspaceAuditFormatHTML, WorkspaceAuditFormatJSON, WorkspaceAuditFormatNDJSON:
+	default:
+		return Workspace{}, fmt.Errorf(invalidWorkspaceFormatErrorTemplate, name, workspace.Audit.Format)
+	}
+
+	var hiddenColumns []string
+	for _, column := range workspace.Audit.HiddenColumns {
+		if trimmedColumn := strings.TrimSpace(column); len(trimmedColumn) > 0 && !slices.Contains(hiddenColumns, trimmedColumn) {
+			hiddenColumns = append(hiddenColumns, trimmedColumn)
+		}
+	}
+
+	sort := WorkspaceAuditSort{Column: strings.TrimSpace(workspace.Audit.Sort.Column)}
+	if len(sort.Column) > 0 {
+		sort.Direction = strings.ToLower(strings.TrimSpace(workspace.Audit.Sort.Direction))
+		switch sort.Direction {
+		case "":
+			sort.Direction = WorkspaceAuditSortAscending
+		case WorkspaceAuditSortAscending, WorkspaceAuditSortDescending:
+		default:
+			return Workspace{}, fmt.Errorf(invalidWorkspaceSortErrorTemplate, name, workspace.Audit.Sort.Direction)
+		}
+	}
+
+	var columns map[string]string
+	for column, value := range workspace.Filters.Columns {
+		trimmedColumn := strings.TrimSpace(column)
+		trimmedValue := strings.TrimSpace(value)
+		if len(trimmedColumn) == 0 || len(trimmedValue) == 0 {
+			continue
+		}
+		if columns == nil {
+			columns = map[string]string{}
+		}
+		columns[trimmedColumn] = trimmedValue
+	}
+
+	return Workspace{
+		Name:  name,
+		Roots: roots,
+		Filters: WorkspaceFilters{
+			Repository: strings.TrimSpace(workspace.Filters.Repository),
+			Columns:    columns,
+		},
+		Audit: WorkspaceAudit{Depth: depth, Format: format, HiddenColumns: hiddenColumns, Sort: sort},
+	}, nil
+}
+
+// FindWorkspace returns the workspace saved under name.
+func FindWorkspace(workspaces []Workspace, name string) (Workspace, error) {
+	trimmedName := strings.TrimSpace(name)
+	for _, workspace := range workspaces {
+		if workspace.Name == trimmedName {
+			return workspace, nil
+		}
+	}
+	return Workspace{}, fmt.Errorf(workspaceNotFoundErrorTemplate, ErrWorkspaceNotFound, trimmedName)
+}
+
+func (server *Server) workspaceCatalog() WorkspaceCatalog {
+	workspaces, listError := server.options.workspaces.ListWorkspaces()
+	if workspaces == nil {
+		workspaces = []Workspace{}
+	}
+	catalog := WorkspaceCatalog{Workspaces: workspaces}
+	if listError != nil {
+		catalog.Error = listError.Error()
+	}
+	return catalog
+}
+
+func (server *Server) handleWorkspaces(requestContext *gin.Context) {
+	requestContext.JSON(http.StatusOK, server.workspaceCatalog())
+}
+
+func (server *Server) handleCreateWorkspace(requestContext *gin.Context) {
+	workspace, workspaceValid := bindWorkspace(requestContext)
+	if !workspaceValid {
+		return
+	}
+	if createError := server.options.workspaces.CreateWorkspace(workspace); createError != nil {
+		respondWorkspaceStoreError(requestContext, createError)
+		return
+	}
+	requestContext.JSON(http.StatusCreated, server.workspaceCatalog())
+}
+
+func (server *Server) handleUpdateWorkspace(requestContext *gin.Context) {
+	workspace, workspaceValid := bindWorkspace(requestContext)
+	if !workspaceValid {
+		return
+	}
+	name := strings.TrimSpace(requestContext.Param(workspaceNameParameterConstant))
+	if updateError := server.options.workspaces.UpdateWorkspace(name, workspace); updateError != nil {
+		respondWorkspaceStoreError(requestContext, updateError)
+		return
+	}
+	requestContext.JSON(http.StatusOK, server.workspaceCatalog())
+}
+
+func (server *Server) handleDeleteWorkspace(requestContext *gin.Context) {
+	name := strings.TrimSpace(requestContext.Param(workspaceNameParameterConstant))
+	if deleteError := server.options.workspaces.DeleteWorkspace(name); deleteError != nil {
+		respondWorkspaceStoreError(requestContext, deleteError)
+		return
+	}
+	requestContext.JSON(http.StatusOK, server.workspaceCatalog())
+}
+
+// workspaceRepositories answers /api/repos?workspace=<name> with the catalog of that workspace's roots.
+func (server *Server) workspaceRepositories(requestContext *gin.Context, name string) {
+	workspaces, listError := server.options.workspaces.ListWorkspaces()
+	if listError != nil {
+		requestContext.JSON(http.StatusInternalServerError, errorResponse{Error: listError.Error()})
+		return
+	}
+	workspace, findError := FindWorkspace(workspaces, name)
+	if findError != nil {
+		requestContext.JSON(http.StatusNotFound, errorResponse{Error: findError.Error()})
+		return
+	}
+
+	catalog := server.options.loadSpace(requestContext.Request.Context(), workspace.Roots)
+	catalog.Workspace = workspace.Name
+	requestContext.JSON(http.StatusOK, catalog)
+}
+
+func bindWorkspace(requestContext *gin.Context) (Workspace, bool) {
+	var request Workspace
+	if bindError := requestContext.ShouldBindJSON(&request); bindError != nil {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: bindError.Error()})
+		return Workspace{}, false
+	}
+	workspace, normalizeError := NormalizeWorkspace(request)
+	if normalizeError != nil {
+		requestContext.JSON(http.StatusBadRequest, errorResponse{Error: normalizeError.Error()})
+		return Workspace{}, false
+	}
+	return workspace, true
+}
+
+func respondWorkspaceStoreError(requestContext *gin.Context, storeError error) {
+	switch {
+	case errors.Is(storeError, ErrWorkspaceNotFound):
+		requestContext.JSON(http.StatusNotFound, errorResponse{Error: storeError.Error()})
+	case errors.Is(storeError, ErrWorkspaceExists):
+		requestContext.JSON(http.StatusConflict, errorResponse{Error: storeError.Error()})
+	default:
+		requestContext.JSON(http.StatusInternalServerError, errorResponse{Error: storeError.Error()})
+	}
+}
@@ -0,0 +1,147 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memoryWorkspaceStore keeps workspaces in memory with the error semantics of the configuration store.
+type memoryWorkspaceStore struct {
+	workspaces []Workspace
+}
+
+func (store *memoryWorkspaceStore) ListWorkspaces() ([]Workspace, error) {
+	return append([]Workspace(nil), store.workspaces...), nil
+}
+
+func (store *memoryWorkspaceStore) CreateWorkspace(workspace Workspace) error {
+	if _, findError := FindWorkspace(store.workspaces, workspace.Name); findError == nil {
+		return ErrWorkspaceExists
+	}
+	store.workspaces = append(store.workspaces, workspace)
+	return nil
+}
+
+func (store *memoryWorkspaceStore) UpdateWorkspace(name string, workspace Workspace) error {
+	for index := range store.workspaces {
+		if store.workspaces[index].Name != name {
+			continue
+		}
+		if workspace.Name != name {
+			if _, findError := FindWorkspace(store.workspaces, workspace.Name); findError == nil {
+				return ErrWorkspaceExists
+			}
+		}
+		store.workspaces[index] = workspace
+		return nil
+	}
+	return ErrWorkspaceNotFound
+}
+
+func (store *memoryWorkspaceStore) DeleteWorkspace(name string) error {
+	for index := range store.workspaces {
+		if store.workspaces[index].Name == name {
+			store.workspaces = append(store.workspaces[:index], store.workspaces[index+1:]...)
+			return nil
+		}
+	}
+	return ErrWorkspaceNotFound
+}
+
+func TestNormalizeWorkspaceFillsDefaultsAndRejectsInvalidValues(testInstance *testing.T) {
+	workspace, normalizeError := NormalizeWorkspace(Workspace{
+		Name:    " fleet ",
+		Roots:   []string{" ~/Development ", "", "~/Development"},
+		Filters: WorkspaceFilters{Repository: " api ", Columns: map[string]string{"in_sync": " no ", "dirty": " "}},
+		Audit:   WorkspaceAudit{HiddenColumns: []string{" dirty_files ", "", "dirty_files"}, Sort: WorkspaceAuditSort{Column: " in_sync "}},
+	})
+	require.NoError(testInstance, normalizeError)
+	require.Equal(testInstance, Workspace{
+		Name:    "fleet",
+		Roots:   []string{"~/Development"},
+		Filters: WorkspaceFilters{Repository: "api", Columns: map[string]string{"in_sync": "no"}},
+		Audit: WorkspaceAudit{
+			Depth:         WorkspaceAuditDepthFull,
+			Format:        WorkspaceAuditFormatTable,
+			HiddenColumns: []string{"dirty_files"},
+			Sort:          WorkspaceAuditSort{Column: "in_sync", Direction: WorkspaceAuditSortAscending},
+		},
+	}, workspace)
+
+	testCases := []struct {
+		name          string
+		workspace     Workspace
+		expectedError string
+	}{
+		{name: "missing name", workspace: Workspace{Roots: []string{"/tmp"}}, expectedError: missingWorkspaceNameErrorConstant},
+		{name: "unsafe name", workspace: Workspace{Name: "../fleet", Roots: []string{"/tmp"}}, expectedError: `workspace name "../fleet" must start`},
+		{name: "missing roots", workspace: Workspace{Name: "fleet", Roots: []string{" "}}, expectedError: `workspace "fleet" requires at least one root`},
+		{name: "depth", workspace: Workspace{Name: "fleet", Roots: []string{"/tmp"}, Audit: WorkspaceAudit{Depth: "deep"}}, expectedError: `unsupported audit depth "deep"`},
+		{name: "format", workspace: Workspace{Name: "fleet", Roots: []string{"/tmp"}, Audit: WorkspaceAudit{Format: "xml"}}, expectedError: `unsupported audit format "xml"`},
+		{name: "sort", workspace: Workspace{Name: "fleet", Roots: []string{"/tmp"}, Audit: WorkspaceAudit{Sort: WorkspaceAuditSort{Column: "path", Direction: "up"}}}, expectedError: `unsupported audit sort direction "up"`},
+	}
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			_, caseError := NormalizeWorkspace(testCase.workspace)
+			require.ErrorContains(testInstance, caseError, testCase.expectedError)
+		})
+	}
+}
+
+func TestWorkspaceRoutesCreateUpdateAndDeleteWorkspaces(testInstance *testing.T) {
+	server, serverError := NewServer(newTestServerOptions(testInstance, "127.0.0.1:8080"))
+	require.NoError(testInstance, serverError)
+
+	steps := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "empty list", method: http.MethodGet, target: "/api/workspaces", expectedStatus: http.StatusOK, expectedBody: `{"workspaces":[]}`},
+		{name: "invalid create", method: http.MethodPost, target: "/api/workspaces", body: `{"name":"fleet","roots":[]}`, expectedStatus: http.StatusBadRequest, expectedBody: "requires at least one root"},
+		{name: "create", method: http.MethodPost, target: "/api/workspaces", body: `{"name":"fleet","roots":["/tmp/fleet"],"audit":{"depth":"minimal"}}`, expectedStatus: http.StatusCreated, expectedBody: `"audit":{"depth":"minimal","format":"table"}`},
+		{name: "duplicate create", method: http.MethodPost, target: "/api/workspaces", body: `{"name":"fleet","roots":["/tmp/other"]}`, expectedStatus: http.StatusConflict, expectedBody: ErrWorkspaceExists.Error()},
+		{name: "workspace catalog", method: http.MethodGet, target: "/api/repos?workspace=fleet", expectedStatus: http.StatusOK, expectedBody: `"launch_roots":["/tmp/fleet"],"workspace":"fleet"`},
+		{name: "unknown workspace catalog", method: http.MethodGet, target: "/api/repos?workspace=missing", expectedStatus: http.StatusNotFound, expectedBody: "workspace not found: missing"},
+		{name: "rename", method: http.MethodPut, target: "/api/workspaces/fleet", body: `{"name":"services","roots":["/tmp/services"],"filters":{"repository":"api"}}`, expectedStatus: http.StatusOK, expectedBody: `"name":"services"`},
+		{name: "update missing", method: http.MethodPut, target: "/api/workspaces/fleet", body: `{"name":"fleet","roots":["/tmp/fleet"]}`, expectedStatus: http.StatusNotFound, expectedBody: ErrWorkspaceNotFound.Error()},
+		{name: "delete", method: http.MethodDelete, target: "/api/workspaces/services", expectedStatus: http.StatusOK, expectedBody: `{"workspaces":[]}`},
+		{name: "delete missing", method: http.MethodDelete, target: "/api/workspaces/services", expectedStatus: http.StatusNotFound, expectedBody: ErrWorkspaceNotFound.Error()},
+	}
+
+	for _, step := range steps {
+		request := httptest.NewRequest(step.method, "http://127.0.0.1:8080"+step.target, strings.NewReader(step.body))
+		request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+		request.Header.Set("Content-Type", jsonContentTypeConstant)
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, request)
+		require.Equal(testInstance, step.expectedStatus, recorder.Code, step.name)
+		require.Contains(testInstance, recorder.Body.String(), step.expectedBody, step.name)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/api/repos", nil)
+	request.Header.Set(authorizationHeaderConstant, bearerAuthorizationPrefixConstant+testSessionTokenConstant)
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	var catalog RepositoryCatalog
+	require.NoError(testInstance, json.Unmarshal(recorder.Body.Bytes(), &catalog))
+	require.Empty(testInstance, catalog.Workspace)
+
+	options := newTestServerOptions(testInstance, "127.0.0.1:8080")
+	options.Workspaces = nil
+	_, missingStoreError := NewServer(options)
+	require.EqualError(testInstance, missingStoreError, missingWorkspaceStoreErrorConstant)
+
+	options = newTestServerOptions(testInstance, "127.0.0.1:8080")
+	options.LoadWorkspace = nil
+	_, missingLoaderError := NewServer(options)
+	require.EqualError(testInstance, missingLoaderError, missingWorkspaceRepositoryLoaderConstant)
+}
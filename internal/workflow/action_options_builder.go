@@ -17,6 +17,7 @@ const (
 	TaskActionReleaseTagType         = taskActionReleaseTag
 	TaskActionReleaseRetagType       = taskActionReleaseRetag
 	TaskActionAuditReportType        = taskActionAuditReport
+	TaskActionAuditPolicyType        = taskActionAuditPolicy
 	TaskActionHistoryPurgeType       = taskActionHistoryPurge
 	TaskActionFileReplaceType        = taskActionFileReplace
 	TaskActionNamespaceRewriteType   = taskActionNamespaceRewrite
@@ -34,6 +35,8 @@ const (
 	actionOptionIncludeAllKeyConstant         = "include_all"
 	actionOptionDebugKeyConstant              = "debug"
 	actionOptionDepthKeyConstant              = "depth"
+	actionOptionBranchListingKeyConstant      = "branch_listing"
+	actionOptionSaveKeyConstant               = "save"
 	actionOptionRestoreKeyConstant            = "restore"
 	actionOptionPushMissingKeyConstant        = "push_missing"
 	safeguardHardStopKeyConstant              = "hard_stop"
@@ -154,11 +157,14 @@ func (options ReleaseRetagActionOptions) Options() map[string]any {
 
 // AuditReportActionOptions serializes audit.report options.
 type AuditReportActionOptions struct {
-	IncludeAll bool
-	Debug      bool
-	Depth      audit.InspectionDepth
-	OutputPath string
-	Format     audit.ReportFormat
+	IncludeAll    bool
+	Debug         bool
+	Depth         audit.InspectionDepth
+	OutputPath    string
+	Format        audit.ReportFormat
+	BranchListing bool
+	// SnapshotPath additionally saves the inspections as a JSON snapshot for audit diff.
+	SnapshotPath string
 }
 
 // Options returns workflow action options for audit report generation.
@@ -171,9 +177,28 @@ func (options AuditReportActionOptions) Options() map[string]any {
 		actionOptionDepthKeyConstant: string(options.Depth),
 		optionOutputPathKeyConstant:  options.OutputPath,
 		optionFormatKeyConstant:      string(reportFormat),
+		actionOptionSaveKeyConstant:  options.SnapshotPath,
 	})
 	serialized[actionOptionIncludeAllKeyConstant] = options.IncludeAll
 	serialized[actionOptionDebugKeyConstant] = options.Debug
+	if options.BranchListing {
+		serialized[actionOptionBranchListingKeyConstant] = true
+	}
+	return serialized
+}
+
+// AuditPolicyActionOptions serializes audit.policy options.
+type AuditPolicyActionOptions struct {
+	PolicyPath string
+	Fix        bool
+}
+
+// Options returns workflow action options for audit policy evaluation.
+func (options AuditPolicyActionOptions) Options() map[string]any {
+	serialized := compactStringOptions(map[string]string{
+		auditPolicyPathOptionKey: options.PolicyPath,
+	})
+	serialized[auditPolicyFixOptionKey] = options.Fix
 	return serialized
 }
 
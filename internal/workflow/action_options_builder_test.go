@@ -61,18 +61,30 @@ func TestActionOptionBuildersSerializeWorkflowContracts(testingInstance *testing
 		{
 			name: "audit report options",
 			options: AuditReportActionOptions{
-				IncludeAll: true,
-				Debug:      true,
-				Depth:      audit.InspectionDepthMinimal,
-				OutputPath: "audit.csv",
-				Format:     audit.ReportFormatCSV,
+				IncludeAll:    true,
+				Debug:         true,
+				Depth:         audit.InspectionDepthMinimal,
+				OutputPath:    "audit.csv",
+				Format:        audit.ReportFormatCSV,
+				BranchListing: true,
+				SnapshotPath:  "snapshot.json",
 			}.Options(),
 			expected: map[string]any{
-				actionOptionIncludeAllKeyConstant: true,
-				actionOptionDebugKeyConstant:      true,
-				actionOptionDepthKeyConstant:      string(audit.InspectionDepthMinimal),
-				optionOutputPathKeyConstant:       "audit.csv",
-				optionFormatKeyConstant:           string(audit.ReportFormatCSV),
+				actionOptionIncludeAllKeyConstant:    true,
+				actionOptionDebugKeyConstant:         true,
+				actionOptionDepthKeyConstant:         string(audit.InspectionDepthMinimal),
+				optionOutputPathKeyConstant:          "audit.csv",
+				optionFormatKeyConstant:              string(audit.ReportFormatCSV),
+				actionOptionBranchListingKeyConstant: true,
+				actionOptionSaveKeyConstant:          "snapshot.json",
+			},
+		},
+		{
+			name:    "audit policy options",
+			options: AuditPolicyActionOptions{PolicyPath: "policy.yml", Fix: true}.Options(),
+			expected: map[string]any{
+				auditPolicyPathOptionKey: "policy.yml",
+				auditPolicyFixOptionKey:  true,
 			},
 		},
 	}
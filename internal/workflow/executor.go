@@ -49,6 +49,8 @@ type Dependencies struct {
 	DisableHeaderDecoration bool
 	// SuppressOperationFailureOutput leaves operation failures in returned errors without echoing them to Errors.
 	SuppressOperationFailureOutput bool
+	// InspectionCache lets repository inspections skip repositories unchanged since a previous run.
+	InspectionCache *audit.InspectionCache
 }
 
 // RuntimeOptions captures user-provided execution modifiers.
@@ -194,6 +196,7 @@ func (executor *Executor) Execute(executionContext context.Context, roots []stri
 		executor.dependencies.Output,
 		executor.dependencies.Errors,
 	)
+	auditService.SetInspectionCache(executor.dependencies.InspectionCache)
 
 	inspections, inspectionError := auditService.DiscoverInspections(executionContext, sanitizedRoots, false, false, audit.InspectionDepthFull)
 	if inspectionError != nil {
@@ -80,7 +80,7 @@ func phaseForActionType(actionType string) LogPhase {
 	switch normalized {
 	case "branch.sync", "branch.default":
 		return LogPhaseBranch
-	case taskActionCanonicalRemote, taskActionProtocolConversion, taskActionRenameDirectories:
+	case taskActionCanonicalRemote, taskActionProtocolConversion, taskActionRenameDirectories, taskActionAuditPolicy:
 		return LogPhaseRemoteFolder
 	case taskActionNamespaceRewrite, taskActionFileReplace, taskActionAuditReport:
 		return LogPhaseFiles
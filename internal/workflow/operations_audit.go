@@ -20,9 +20,10 @@ const (
 
 // AuditReportOperation emits an audit report summarizing repository state.
 type AuditReportOperation struct {
-	OutputPath  string
-	WriteToFile bool
-	Format      audit.ReportFormat
+	OutputPath    string
+	WriteToFile   bool
+	Format        audit.ReportFormat
+	BranchListing bool
 }
 
 // Name identifies the workflow command handled by this operation.
@@ -80,7 +81,7 @@ func (operation *AuditReportOperation) Execute(executionContext context.Context,
 		repository := state.Repositories[repositoryIndex]
 		inspections = append(inspections, repository.Inspection)
 	}
-	if writeError := audit.WriteReport(writer, operation.Format, inspections); writeError != nil {
+	if writeError := audit.WriteReport(writer, audit.ReportOptions{Format: operation.Format, BranchListing: operation.BranchListing}, inspections); writeError != nil {
 		return writeError
 	}
 
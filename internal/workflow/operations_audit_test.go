@@ -17,7 +17,7 @@ import (
 const (
 	auditReportTestFileNameConstant       = "audit_report.csv"
 	auditReportWhitespacePaddingConstant  = " "
-	auditReportExpectedHeaderLineConstant = "folder_name,final_github_repo,origin_remote_status,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,worktree_dirty,dirty_files"
+	auditReportExpectedHeaderLineConstant = "folder_name,final_github_repo,origin_remote_status,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,worktree_dirty,dirty_files,upstream_ahead,upstream_behind,default_ahead,default_behind,no_upstream_branches,upstream_gone_branches,merged_branches"
 )
 
 func TestAuditReportOperationCreatesNestedOutput(testInstance *testing.T) {
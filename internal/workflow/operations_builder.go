@@ -306,8 +306,12 @@ func buildAuditReportOperation(options map[string]any) (Operation, error) {
 		}
 		reportFormat = parsedFormat
 	}
+	branchListing, _, branchListingError := reader.boolValue(actionOptionBranchListingKeyConstant)
+	if branchListingError != nil {
+		return nil, branchListingError
+	}
 
-	return &AuditReportOperation{OutputPath: strings.TrimSpace(outputPath), WriteToFile: outputExists && len(strings.TrimSpace(outputPath)) > 0, Format: reportFormat}, nil
+	return &AuditReportOperation{OutputPath: strings.TrimSpace(outputPath), WriteToFile: outputExists && len(strings.TrimSpace(outputPath)) > 0, Format: reportFormat, BranchListing: branchListing}, nil
 }
 
 func parseProtocolValue(raw string) (shared.RemoteProtocol, error) {
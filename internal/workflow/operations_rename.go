@@ -72,7 +72,7 @@ func (operation *RenameOperation) ExecuteForRepository(
 	ownerRepository, _ := shared.ParseOwnerRepositoryOptional(repository.Inspection.FinalOwnerRepo)
 	plan := directoryPlanner.Plan(operation.IncludeOwner, ownerRepository, repository.Inspection.DesiredFolderName)
 	desiredFolderName := plan.FolderName
-	if plan.IsNoop(repository.Path, repository.Inspection.FolderName) {
+	if plan.IsNoop(repository.Path, filepath.Base(repository.Inspection.FolderName)) {
 		desiredFolderName = filepath.Base(repository.Path)
 	}
 	trimmedFolderName := strings.TrimSpace(desiredFolderName)
@@ -22,6 +22,7 @@ const (
 	taskActionReleaseTag         = "repo.release.tag"
 	taskActionReleaseRetag       = "repo.release.retag"
 	taskActionAuditReport        = "audit.report"
+	taskActionAuditPolicy        = "audit.policy"
 	taskActionHistoryPurge       = "repo.history.purge"
 	taskActionFileReplace        = "repo.files.replace"
 	taskActionNamespaceRewrite   = "repo.namespace.rewrite"
@@ -38,6 +39,7 @@ var taskActionHandlers = map[string]taskActionHandlerFunc{
 	taskActionReleaseTag:         handleReleaseTagAction,
 	taskActionReleaseRetag:       handleReleaseRetagAction,
 	taskActionAuditReport:        handleAuditReportAction,
+	taskActionAuditPolicy:        handleAuditPolicyAction,
 	taskActionHistoryPurge:       handleHistoryPurgeAction,
 	taskActionFileReplace:        handleFileReplaceAction,
 	taskActionNamespaceRewrite:   handleNamespaceRewriteAction,
@@ -350,8 +352,11 @@ func handleAuditReportAction(ctx context.Context, environment *Environment, repo
 		return depthError
 	}
 	depth := audit.InspectionDepthFull
-	if strings.EqualFold(strings.TrimSpace(depthValue), string(audit.InspectionDepthMinimal)) {
+	switch strings.ToLower(strings.TrimSpace(depthValue)) {
+	case string(audit.InspectionDepthMinimal):
 		depth = audit.InspectionDepthMinimal
+	case string(audit.InspectionDepthGitHub):
+		depth = audit.InspectionDepthGitHub
 	}
 
 	formatValue, formatExists, formatError := reader.stringValue(optionFormatKeyConstant)
@@ -366,6 +371,11 @@ func handleAuditReportAction(ctx context.Context, environment *Environment, repo
 		}
 		reportFormat = parsedFormat
 	}
+	branchListing, _, branchListingError := reader.boolValue(actionOptionBranchListingKeyConstant)
+	if branchListingError != nil {
+		return branchListingError
+	}
+	reportOptions := audit.ReportOptions{Format: reportFormat, BranchListing: branchListing, GitHubHealth: depth == audit.InspectionDepthGitHub}
 
 	roots := collectAuditRoots(environment.State, repository)
 	if len(roots) == 0 {
@@ -380,14 +390,35 @@ func handleAuditReportAction(ctx context.Context, environment *Environment, repo
 	sanitizedOutput := strings.TrimSpace(outputValue)
 	writeToFile := outputExists && len(sanitizedOutput) > 0
 
-	if writeToFile {
+	snapshotValue, _, snapshotError := reader.stringValue(actionOptionSaveKeyConstant)
+	if snapshotError != nil {
+		return snapshotError
+	}
+	snapshotPath := strings.TrimSpace(snapshotValue)
+
+	if writeToFile || len(snapshotPath) > 0 {
 		inspections, discoveryError := environment.AuditService.DiscoverInspections(ctx, roots, includeAll, debugOutput, depth)
 		if discoveryError != nil {
 			environment.markAuditReportExecuted()
 			return discoveryError
 		}
 
-		if writeError := writeAuditReportFile(sanitizedOutput, reportFormat, inspections); writeError != nil {
+		if len(snapshotPath) > 0 {
+			if writeError := writeAuditReportFile(snapshotPath, audit.ReportOptions{Format: audit.ReportFormatJSON}, inspections); writeError != nil {
+				environment.markAuditReportExecuted()
+				return writeError
+			}
+		}
+
+		if !writeToFile {
+			environment.markAuditReportExecuted()
+			if environment.Output == nil {
+				return nil
+			}
+			return audit.WriteReport(environment.Output, reportOptions, inspections)
+		}
+
+		if writeError := writeAuditReportFile(sanitizedOutput, reportOptions, inspections); writeError != nil {
 			environment.markAuditReportExecuted()
 			return writeError
 		}
@@ -405,6 +436,7 @@ func handleAuditReportAction(ctx context.Context, environment *Environment, repo
 		IncludeAllFolders: includeAll,
 		InspectionDepth:   depth,
 		ReportFormat:      reportFormat,
+		BranchListing:     branchListing,
 	}
 
 	if runError := environment.AuditService.Run(ctx, commandOptions); runError != nil {
@@ -589,7 +621,7 @@ func readHistoryPaths(raw any) ([]string, error) {
 	}
 }
 
-func writeAuditReportFile(destination string, reportFormat audit.ReportFormat, inspections []audit.RepositoryInspection) error {
+func writeAuditReportFile(destination string, reportOptions audit.ReportOptions, inspections []audit.RepositoryInspection) error {
 	if len(strings.TrimSpace(destination)) == 0 {
 		return errors.New("audit report destination missing")
 	}
@@ -605,7 +637,7 @@ func writeAuditReportFile(destination string, reportFormat audit.ReportFormat, i
 	if createError != nil {
 		return createError
 	}
-	writeError := audit.WriteReport(fileHandle, reportFormat, inspections)
+	writeError := audit.WriteReport(fileHandle, reportOptions, inspections)
 	closeError := fileHandle.Close()
 	if writeError != nil {
 		return writeError
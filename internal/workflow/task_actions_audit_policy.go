@@ -0,0 +1,105 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tyemirov/gix/internal/audit"
+)
+
+const (
+	auditPolicyPathOptionKey = "policy"
+	auditPolicyFixOptionKey  = "fix"
+
+	auditPolicyViolationMessageTemplate = "POLICY-VIOLATION: %s rule=%s %s\n"
+	auditPolicyFixedMessageTemplate     = "POLICY-FIXED: %s rule=%s %s\n"
+	auditPolicyFailureTemplate          = "audit policy: %d violation(s) in %s"
+	auditPolicyMissingPathMessage       = "audit policy action requires 'policy'"
+)
+
+// auditPolicyRemediationOrder applies remote rewrites before the folder rename that changes the repository path.
+var auditPolicyRemediationOrder = []audit.PolicyRemediation{
+	audit.PolicyRemediationCanonicalRemote,
+	audit.PolicyRemediationConvertProtocol,
+	audit.PolicyRemediationRenameFolder,
+}
+
+func handleAuditPolicyAction(ctx context.Context, environment *Environment, repository *RepositoryState, parameters map[string]any) error {
+	if environment == nil || repository == nil {
+		return nil
+	}
+
+	reader := newOptionReader(parameters)
+	policyPath, policyExists, policyPathError := reader.stringValue(auditPolicyPathOptionKey)
+	if policyPathError != nil {
+		return policyPathError
+	}
+	if !policyExists || len(policyPath) == 0 {
+		return errors.New(auditPolicyMissingPathMessage)
+	}
+	fix, _, fixError := reader.boolValue(auditPolicyFixOptionKey)
+	if fixError != nil {
+		return fixError
+	}
+
+	policy, policyError := audit.LoadPolicy(policyPath)
+	if policyError != nil {
+		return policyError
+	}
+
+	violations := policy.Evaluate(repository.Inspection, environment.FileSystem)
+	if fix && len(violations) > 0 {
+		for _, remediation := range auditPolicyRemediationOrder {
+			if remediationError := applyAuditPolicyRemediation(ctx, environment, repository, remediation, violations); remediationError != nil {
+				return remediationError
+			}
+		}
+		remaining := policy.Evaluate(repository.Inspection, environment.FileSystem)
+		if environment.Output != nil {
+			for _, violation := range violations {
+				if !containsPolicyViolation(remaining, violation) {
+					fmt.Fprintf(environment.Output, auditPolicyFixedMessageTemplate, violation.RepositoryPath, violation.Rule.Type, violation.Message)
+				}
+			}
+		}
+		violations = remaining
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	if environment.Output != nil {
+		for _, violation := range violations {
+			fmt.Fprintf(environment.Output, auditPolicyViolationMessageTemplate, violation.RepositoryPath, violation.Rule.Type, violation.Message)
+		}
+	}
+	return fmt.Errorf(auditPolicyFailureTemplate, len(violations), repository.Path)
+}
+
+// applyAuditPolicyRemediation runs the reconciliation action mapped to remediation when any violation requests it.
+func applyAuditPolicyRemediation(ctx context.Context, environment *Environment, repository *RepositoryState, remediation audit.PolicyRemediation, violations []audit.PolicyViolation) error {
+	for _, violation := range violations {
+		if violation.Remediation != remediation {
+			continue
+		}
+		switch remediation {
+		case audit.PolicyRemediationCanonicalRemote:
+			return handleCanonicalRemoteAction(ctx, environment, repository, CanonicalRemoteActionOptions{}.Options())
+		case audit.PolicyRemediationConvertProtocol:
+			return handleProtocolConversionAction(ctx, environment, repository, ProtocolConversionActionOptions{To: violation.Rule.Protocol}.Options())
+		case audit.PolicyRemediationRenameFolder:
+			return handleRenameDirectoriesAction(ctx, environment, repository, RenameDirectoriesActionOptions{RequireClean: true}.Options())
+		}
+	}
+	return nil
+}
+
+func containsPolicyViolation(violations []audit.PolicyViolation, candidate audit.PolicyViolation) bool {
+	for _, violation := range violations {
+		if violation.Rule.Type == candidate.Rule.Type && violation.Message == candidate.Message {
+			return true
+		}
+	}
+	return false
+}
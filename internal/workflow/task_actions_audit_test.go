@@ -3,6 +3,7 @@ package workflow
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
@@ -156,3 +157,107 @@ func TestCollectAuditRootsPreservesContainingRelativeRoot(testInstance *testing.
 
 	require.Equal(testInstance, []string{"."}, collectAuditRoots(state, nil))
 }
+
+func TestHandleAuditReportActionWritesJSONReportFile(testInstance *testing.T) {
+	testInstance.Parallel()
+
+	temporaryDirectory := testInstance.TempDir()
+	repositoryPath := filepath.Join(temporaryDirectory, "repository")
+	outputPath := filepath.Join(temporaryDirectory, "reports", "audit.ndjson")
+
+	discoverer := &stubRepositoryDiscoverer{repositories: []string{repositoryPath}}
+	gitRepositoryManager := &stubGitRepositoryManager{remoteURL: "https://github.com/example/repo.git"}
+	metadataResolver := &stubGitHubMetadataResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "example/repo", DefaultBranch: "main"}}
+	auditService := audit.NewService(discoverer, gitRepositoryManager, &stubGitExecutor{}, metadataResolver, &bytes.Buffer{}, &bytes.Buffer{})
+	output := &bytes.Buffer{}
+	environment := &Environment{
+		AuditService: auditService,
+		Output:       output,
+		State:        &State{Roots: []string{repositoryPath}},
+	}
+
+	parameters := AuditReportActionOptions{
+		Depth:      audit.InspectionDepthMinimal,
+		OutputPath: outputPath,
+		Format:     audit.ReportFormatNDJSON,
+	}.Options()
+
+	require.NoError(testInstance, handleAuditReportAction(context.Background(), environment, &RepositoryState{Path: repositoryPath}, parameters))
+	content, readError := os.ReadFile(outputPath)
+	require.NoError(testInstance, readError)
+
+	var record audit.InspectionRecord
+	require.NoError(testInstance, json.Unmarshal(bytes.TrimSpace(content), &record))
+	require.Equal(testInstance, audit.ReportSchemaVersion, record.Schema)
+	require.Equal(testInstance, repositoryPath, record.Path)
+	require.Equal(testInstance, "example/repo", record.FinalOwnerRepo)
+	require.Equal(testInstance, audit.OriginRemoteStatusConfigured, record.OriginRemoteStatus)
+	require.Equal(testInstance, []string{}, record.WorktreeDirtyFiles)
+	require.Contains(testInstance, output.String(), outputPath)
+}
+
+func TestHandleAuditReportActionSavesSnapshotAlongsideReport(testInstance *testing.T) {
+	testInstance.Parallel()
+
+	temporaryDirectory := testInstance.TempDir()
+	repositoryPath := filepath.Join(temporaryDirectory, "repository")
+	snapshotPath := filepath.Join(temporaryDirectory, "snapshots", "fleet.json")
+
+	discoverer := &stubRepositoryDiscoverer{repositories: []string{repositoryPath}}
+	gitRepositoryManager := &stubGitRepositoryManager{remoteURL: "https://github.com/example/repo.git"}
+	metadataResolver := &stubGitHubMetadataResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "example/repo", DefaultBranch: "main"}}
+	auditService := audit.NewService(discoverer, gitRepositoryManager, &stubGitExecutor{}, metadataResolver, &bytes.Buffer{}, &bytes.Buffer{})
+	output := &bytes.Buffer{}
+	environment := &Environment{
+		AuditService: auditService,
+		Output:       output,
+		State:        &State{Roots: []string{repositoryPath}},
+	}
+
+	parameters := AuditReportActionOptions{
+		Depth:        audit.InspectionDepthMinimal,
+		Format:       audit.ReportFormatCSV,
+		SnapshotPath: snapshotPath,
+	}.Options()
+
+	require.NoError(testInstance, handleAuditReportAction(context.Background(), environment, &RepositoryState{Path: repositoryPath}, parameters))
+	require.Contains(testInstance, output.String(), "folder_name,final_github_repo")
+	require.Contains(testInstance, output.String(), "repository,example/repo")
+
+	snapshot, loadError := audit.LoadSnapshot(snapshotPath)
+	require.NoError(testInstance, loadError)
+	require.Len(testInstance, snapshot.Repositories, 1)
+	require.Equal(testInstance, repositoryPath, snapshot.Repositories[0].Path)
+}
+
+func TestHandleAuditPolicyActionReportsViolations(testInstance *testing.T) {
+	testInstance.Parallel()
+
+	policyPath := filepath.Join(testInstance.TempDir(), "policy.yml")
+	require.NoError(testInstance, os.WriteFile(policyPath, []byte("rules:\n  - type: remote_protocol\n    protocol: ssh\n  - type: clean_default_branch\n"), 0o644))
+
+	repository := &RepositoryState{
+		Path: "/repos/example",
+		Inspection: audit.RepositoryInspection{
+			Path:                "/repos/example",
+			IsGitRepository:     true,
+			OriginRemoteStatus:  audit.OriginRemoteStatusConfigured,
+			RemoteProtocol:      audit.RemoteProtocolHTTPS,
+			RemoteDefaultBranch: "main",
+			LocalBranch:         "feature",
+		},
+	}
+	output := &bytes.Buffer{}
+	environment := &Environment{Output: output}
+
+	policyError := handleAuditPolicyAction(context.Background(), environment, repository, AuditPolicyActionOptions{PolicyPath: policyPath}.Options())
+	require.EqualError(testInstance, policyError, "audit policy: 1 violation(s) in /repos/example")
+	require.Equal(testInstance, "POLICY-VIOLATION: /repos/example rule=remote_protocol remote protocol is https; policy requires ssh\n", output.String())
+
+	repository.Inspection.RemoteProtocol = audit.RemoteProtocolSSH
+	output.Reset()
+	require.NoError(testInstance, handleAuditPolicyAction(context.Background(), environment, repository, AuditPolicyActionOptions{PolicyPath: policyPath}.Options()))
+	require.Empty(testInstance, output.String())
+
+	require.EqualError(testInstance, handleAuditPolicyAction(context.Background(), environment, repository, AuditPolicyActionOptions{}.Options()), auditPolicyMissingPathMessage)
+}
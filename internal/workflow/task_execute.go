@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/tyemirov/gix/internal/execshell"
+	"github.com/tyemirov/gix/internal/gitrepo"
 	"github.com/tyemirov/gix/internal/repos/shared"
 	"github.com/tyemirov/gix/internal/repos/worktree"
 )
@@ -40,6 +41,12 @@ func (executor taskExecutor) Execute(executionContext context.Context) error {
 		ignoredDirtyPatterns: collectIgnoredDirtyPatterns(executor.plan.task),
 	}
 
+	if executor.plan.createsCommits() && executor.environment.GitExecutor != nil {
+		if signingErr := gitrepo.VerifyCommitSigning(executionContext, executor.environment.GitExecutor, executor.repository.Path); signingErr != nil {
+			return signingErr
+		}
+	}
+
 	hasFileChanges := hasApplicableChanges(executor.plan.fileChanges)
 	if hasFileChanges && executor.environment.RepositoryManager != nil {
 		originalBranch, branchError := executor.environment.RepositoryManager.GetCurrentBranch(executionContext, executor.repository.Path)
@@ -84,6 +91,17 @@ func (executor taskExecutor) Execute(executionContext context.Context) error {
 	return nil
 }
 
+// createsCommits reports whether the plan commits, so commit signing is verified before its first mutation.
+func (plan taskPlan) createsCommits() bool {
+	for _, action := range plan.workflowSteps {
+		switch action.(type) {
+		case gitCommitAction, gitStageCommitAction:
+			return true
+		}
+	}
+	return false
+}
+
 func (executor taskExecutor) resolveEnsureClean() bool {
 	defaultValue := executor.plan.task.EnsureClean
 	variableName := strings.TrimSpace(executor.plan.task.EnsureCleanVariable)
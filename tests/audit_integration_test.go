@@ -36,12 +36,13 @@ const (
 	auditIntegrationStubScript                    = "#!/bin/sh\nif [ \"$1\" = \"repo\" ] && [ \"$2\" = \"view\" ]; then\n  cat <<'EOF'\n{\"nameWithOwner\":\"canonical/example\",\"defaultBranchRef\":{\"name\":\"main\"},\"description\":\"\"}\nEOF\n  exit 0\nfi\nexit 0\n"
 	auditIntegrationRepositoryPrefixConstant      = "audit-integration-repository-"
 	auditIntegrationHomeShortcutPrefixConstant    = "~/"
-	auditIntegrationCSVHeaderConstant             = "folder_name,final_github_repo,origin_remote_status,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,worktree_dirty,dirty_files\n"
-	auditIntegrationCSVRowTemplate                = "%[1]s,canonical/example,configured,no,main,,n/a,ssh,no,no,\n"
+	auditIntegrationCSVHeaderConstant             = "folder_name,final_github_repo,origin_remote_status,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,worktree_dirty,dirty_files,upstream_ahead,upstream_behind,default_ahead,default_behind,no_upstream_branches,upstream_gone_branches,merged_branches\n"
+	auditIntegrationCSVRowTemplate                = "%[1]s,canonical/example,configured,no,main,,n/a,ssh,no,no,,n/a,n/a,n/a,n/a,0,0,n/a\n"
 	auditIntegrationCSVTemplate                   = auditIntegrationCSVHeaderConstant + auditIntegrationCSVRowTemplate
 	auditIntegrationTableCaseNameConstant         = "audit_table_default"
 	auditIntegrationCSVCaseNameConstant           = "audit_csv_export"
 	auditIntegrationHTMLCaseNameConstant          = "audit_html_export"
+	auditIntegrationNDJSONCaseNameConstant        = "audit_ndjson_export"
 	auditIntegrationTableEscapingCaseNameConstant = "audit_table_escapes_delimiters_and_aligns_wide_characters"
 	auditIntegrationWorkflowTableCaseNameConstant = "workflow_audit_table_respects_terminal_width"
 	auditIntegrationDebugCaseNameConstant         = "audit_debug"
@@ -54,13 +55,30 @@ const (
 	auditIntegrationColumnsEnvironmentVariable    = "COLUMNS"
 	auditIntegrationDisabledColumnsWidth          = "0"
 	auditIntegrationNarrowTerminalWidth           = 40
-	auditIntegrationWideTerminalWidth             = 190
+	auditIntegrationWideTerminalWidth             = 292
 	auditIntegrationTableEllipsis                 = "…"
 	auditIntegrationWorkflowFileName              = "audit-workflow.yaml"
 	auditIntegrationWorkflowConfiguration         = "workflow:\n  - step:\n      command: ['audit', 'report']\n      with:\n        format: table\n"
+	auditIntegrationCacheHomeVariable             = "XDG_CACHE_HOME"
+	auditIntegrationGoCacheVariable               = "GOCACHE"
 )
 
+// isolateAuditInspectionCache points the audit inspection cache of every command the test runs at a
+// temporary directory, so the suite never writes the user's cache. The Go build cache stays where it was
+// so `go run` does not rebuild from scratch.
+func isolateAuditInspectionCache(testInstance *testing.T) {
+	testInstance.Helper()
+	if len(os.Getenv(auditIntegrationGoCacheVariable)) == 0 {
+		goCacheOutput, goCacheError := exec.Command("go", "env", auditIntegrationGoCacheVariable).Output()
+		require.NoError(testInstance, goCacheError)
+		testInstance.Setenv(auditIntegrationGoCacheVariable, strings.TrimSpace(string(goCacheOutput)))
+	}
+	testInstance.Setenv(auditIntegrationCacheHomeVariable, testInstance.TempDir())
+}
+
 func TestAuditRunCommandIntegration(testInstance *testing.T) {
+	isolateAuditInspectionCache(testInstance)
+
 	workingDirectory, workingDirectoryError := os.Getwd()
 	require.NoError(testInstance, workingDirectoryError)
 	repositoryRoot := filepath.Dir(workingDirectory)
@@ -152,6 +170,7 @@ func TestAuditRunCommandIntegration(testInstance *testing.T) {
 	rootFlagArguments := buildArguments(auditIntegrationErrorLevel, repositoryPath)
 	csvArguments := withFormat(rootFlagArguments, "csv")
 	htmlArguments := withFormat(rootFlagArguments, "html")
+	ndjsonArguments := withFormat(rootFlagArguments, "ndjson")
 	debugLogLevelArguments := buildArguments(auditIntegrationDebugLevel, repositoryPath)
 	tildeRootArguments := buildArguments(auditIntegrationErrorLevel, tildeRootArgument)
 	includeAllArguments := append(buildArguments(auditIntegrationErrorLevel, includeAllRoot), auditIntegrationIncludeAllFlag)
@@ -317,6 +336,27 @@ func TestAuditRunCommandIntegration(testInstance *testing.T) {
 		requireAuditTableFitsTerminal(subtest, constrainedTable, auditIntegrationWideTerminalWidth)
 	})
 
+	testInstance.Run(auditIntegrationNDJSONCaseNameConstant, func(subtest *testing.T) {
+		ndjsonOutput := runIntegrationCommand(subtest, repositoryRoot, integrationCommandOptions{PathVariable: extendedPath}, auditIntegrationTimeout, ndjsonArguments)
+		records := []string{}
+		for _, line := range strings.Split(ndjsonOutput, "\n") {
+			if strings.HasPrefix(line, `{"schema":`) {
+				records = append(records, line)
+			}
+		}
+		require.Len(subtest, records, 1, ndjsonOutput)
+		require.True(subtest, strings.HasPrefix(records[0], fmt.Sprintf(
+			`{"schema":"gix.audit-report/v1","path":%q,"folder_name":%q,"is_git_repository":true,"origin_url":%q,`,
+			repositoryPath,
+			repositoryFolderName,
+			auditIntegrationOriginURL,
+		)), records[0])
+		require.Contains(subtest, records[0], `"final_owner_repo":"canonical/example"`)
+		require.Contains(subtest, records[0], `"origin_remote_status":"configured"`)
+		require.Contains(subtest, records[0], `"worktree_dirty_files":[]`)
+		require.NotContains(subtest, ndjsonOutput, auditIntegrationCSVHeaderConstant)
+	})
+
 	testInstance.Run(auditIntegrationWorkflowTableCaseNameConstant, func(subtest *testing.T) {
 		workflowPath := filepath.Join(tempDirectory, auditIntegrationWorkflowFileName)
 		require.NoError(subtest, os.WriteFile(workflowPath, []byte(auditIntegrationWorkflowConfiguration), 0o644))
@@ -354,13 +394,15 @@ func TestAuditRunCommandIntegration(testInstance *testing.T) {
 		repositoryRoot,
 		integrationCommandOptions{PathVariable: extendedPath},
 		auditIntegrationTimeout,
-		withFormat(rootFlagArguments, "json"),
+		withFormat(rootFlagArguments, "xml"),
 	)
 	require.Error(testInstance, invalidFormatError)
-	require.Contains(testInstance, filterStructuredOutput(invalidFormatOutput), "unsupported audit report format \"json\"")
+	require.Contains(testInstance, filterStructuredOutput(invalidFormatOutput), "unsupported audit report format \"xml\"")
 }
 
 func TestAuditDistinguishesDetachedNestedWorktreeFromPrimaryCheckout(testInstance *testing.T) {
+	isolateAuditInspectionCache(testInstance)
+
 	workingDirectory, workingDirectoryError := os.Getwd()
 	require.NoError(testInstance, workingDirectoryError)
 	repositoryRoot := filepath.Dir(workingDirectory)
@@ -416,6 +458,317 @@ func TestAuditDistinguishesDetachedNestedWorktreeFromPrimaryCheckout(testInstanc
 	)
 }
 
+func TestAuditReportsBranchDivergenceAndInventory(testInstance *testing.T) {
+	isolateAuditInspectionCache(testInstance)
+
+	workingDirectory, workingDirectoryError := os.Getwd()
+	require.NoError(testInstance, workingDirectoryError)
+	repositoryRoot := filepath.Dir(workingDirectory)
+
+	auditRoot := testInstance.TempDir()
+	repositoryPath := createGitRepository(testInstance, gitRepositoryOptions{
+		Path:      filepath.Join(auditRoot, "branch-inventory"),
+		RemoteURL: auditIntegrationOriginURL,
+	})
+	configureGitIdentity(testInstance, repositoryPath)
+	commitFile := func(name string) string {
+		require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, name), []byte(name+"\n"), 0o644))
+		runGit(testInstance, repositoryPath, "add", name)
+		runGit(testInstance, repositoryPath, "commit", "-m", "add "+name)
+		return strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", "HEAD"))
+	}
+
+	firstCommit := commitFile("first.txt")
+	runGit(testInstance, repositoryPath, "branch", "done")
+	commitFile("second.txt")
+	runGit(testInstance, repositoryPath, "branch", "local-only")
+	runGit(testInstance, repositoryPath, "update-ref", "refs/remotes/origin/main", "HEAD")
+	runGit(testInstance, repositoryPath, "update-ref", "refs/remotes/origin/done", firstCommit)
+	runGit(testInstance, repositoryPath, "branch", "--set-upstream-to=origin/main", "main")
+	runGit(testInstance, repositoryPath, "branch", "--set-upstream-to=origin/done", "done")
+
+	runGit(testInstance, repositoryPath, "switch", "-c", "old", firstCommit)
+	commitFile("old.txt")
+	runGit(testInstance, repositoryPath, "update-ref", "refs/remotes/origin/old", "HEAD")
+	runGit(testInstance, repositoryPath, "branch", "--set-upstream-to=origin/old", "old")
+	runGit(testInstance, repositoryPath, "update-ref", "-d", "refs/remotes/origin/old")
+
+	runGit(testInstance, repositoryPath, "switch", "-c", "feature/work", "main")
+	sharedCommit := commitFile("work.txt")
+	commitFile("local.txt")
+	remoteTree := strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", sharedCommit+"^{tree}"))
+	remoteCommit := strings.TrimSpace(runGit(testInstance, repositoryPath, "commit-tree", remoteTree, "-p", sharedCommit, "-m", "remote follow-up"))
+	runGit(testInstance, repositoryPath, "update-ref", "refs/remotes/origin/feature/work", remoteCommit)
+	runGit(testInstance, repositoryPath, "branch", "--set-upstream-to=origin/feature/work")
+
+	pathWithStub := buildStubbedExecutablePath(testInstance, auditIntegrationStubExecutableName, auditIntegrationStubScript)
+	runAudit := func(arguments ...string) string {
+		return filterStructuredOutput(runIntegrationCommand(
+			testInstance,
+			repositoryRoot,
+			integrationCommandOptions{
+				PathVariable: pathWithStub,
+				EnvironmentOverrides: map[string]string{
+					auditIntegrationColumnsEnvironmentVariable: auditIntegrationDisabledColumnsWidth,
+				},
+			},
+			auditIntegrationTimeout,
+			append([]string{
+				auditIntegrationRunSubcommand,
+				auditIntegrationModulePathConstant,
+				auditIntegrationLogLevelFlag,
+				auditIntegrationErrorLevel,
+				auditIntegrationAuditCommandName,
+				auditIntegrationRootFlag,
+				auditRoot,
+			}, arguments...),
+		))
+	}
+
+	const expectedCounts = "branch-inventory,canonical/example,configured,no,main,feature/work,n/a,ssh,no,no,,1,1,2,0,1,1,2"
+	csvOutput := runAudit(auditIntegrationFormatFlag, "csv")
+	require.Contains(testInstance, csvOutput, expectedCounts+"\n")
+	require.NotContains(testInstance, csvOutput, "branch_listing")
+
+	listingOutput := runAudit(auditIntegrationFormatFlag, "csv", "--branches")
+	require.Contains(testInstance, listingOutput, ",merged_branches,branch_listing\n")
+	require.Contains(testInstance, listingOutput, expectedCounts+`,"merged: done, local-only; no upstream: local-only; upstream gone: old"`)
+
+	tableOutput := runAudit("--branches")
+	for _, header := range []string{"Upstream Ahead", "Default Behind", "No Upstream", "Upstream Gone", "Merged", "Branches"} {
+		require.Contains(testInstance, tableOutput, header)
+	}
+}
+
+func TestAuditPolicyReportsAndFixesViolations(testInstance *testing.T) {
+	isolateAuditInspectionCache(testInstance)
+
+	workingDirectory, workingDirectoryError := os.Getwd()
+	require.NoError(testInstance, workingDirectoryError)
+	repositoryRoot := filepath.Dir(workingDirectory)
+
+	auditRoot := testInstance.TempDir()
+	repositoryPath := createGitRepository(testInstance, gitRepositoryOptions{
+		Path:      filepath.Join(auditRoot, "org", "legacy"),
+		RemoteURL: "https://github.com/origin/example.git",
+	})
+	policyPath := filepath.Join(testInstance.TempDir(), "policy.yml")
+	policyContents := "rules:\n" +
+		"  - type: remote_protocol\n    protocol: ssh\n" +
+		"  - type: origin_matches_canonical\n" +
+		"  - type: folder_matches_canonical\n" +
+		"  - type: required_files\n    files: [LICENSE]\n"
+	require.NoError(testInstance, os.WriteFile(policyPath, []byte(policyContents), 0o644))
+
+	pathWithStub := buildStubbedExecutablePath(testInstance, auditIntegrationStubExecutableName, auditIntegrationStubScript)
+	runPolicy := func(arguments ...string) (string, error) {
+		return executeIntegrationCommand(
+			testInstance,
+			repositoryRoot,
+			integrationCommandOptions{PathVariable: pathWithStub},
+			auditIntegrationTimeout,
+			append([]string{
+				auditIntegrationRunSubcommand,
+				auditIntegrationModulePathConstant,
+				auditIntegrationLogLevelFlag,
+				auditIntegrationErrorLevel,
+				auditIntegrationAuditCommandName,
+				auditIntegrationRootFlag,
+				auditRoot,
+				"--policy",
+				policyPath,
+			}, arguments...),
+		)
+	}
+
+	evaluateOutput, evaluateError := runPolicy()
+	require.Error(testInstance, evaluateError)
+	require.Contains(testInstance, evaluateOutput, "POLICY-VIOLATION: "+repositoryPath+" rule=remote_protocol remote protocol is https; policy requires ssh")
+	require.Contains(testInstance, evaluateOutput, "POLICY-VIOLATION: "+repositoryPath+" rule=origin_matches_canonical origin origin/example does not match canonical canonical/example")
+	require.Contains(testInstance, evaluateOutput, "POLICY-VIOLATION: "+repositoryPath+" rule=folder_matches_canonical folder name legacy does not match canonical name example")
+	require.Contains(testInstance, evaluateOutput, "POLICY-VIOLATION: "+repositoryPath+" rule=required_files required file LICENSE is missing")
+	require.Contains(testInstance, evaluateOutput, "audit policy: 4 violation(s) in "+repositoryPath)
+	require.DirExists(testInstance, repositoryPath)
+
+	fixOutput, fixError := runPolicy("--fix", "--yes")
+	require.Error(testInstance, fixError)
+	require.Contains(testInstance, fixOutput, "POLICY-FIXED: "+repositoryPath+" rule=remote_protocol")
+	require.Contains(testInstance, fixOutput, "POLICY-FIXED: "+repositoryPath+" rule=origin_matches_canonical")
+	require.Contains(testInstance, fixOutput, "POLICY-FIXED: "+repositoryPath+" rule=folder_matches_canonical")
+
+	renamedPath := filepath.Join(auditRoot, "org", "example")
+	require.NoDirExists(testInstance, repositoryPath)
+	require.Equal(testInstance, "git@github.com:canonical/example.git", strings.TrimSpace(runGit(testInstance, renamedPath, "remote", "get-url", "origin")))
+	require.Contains(testInstance, fixOutput, "POLICY-VIOLATION: "+renamedPath+" rule=required_files required file LICENSE is missing")
+	require.Contains(testInstance, fixOutput, "audit policy: 1 violation(s) in "+renamedPath)
+
+	require.NoError(testInstance, os.WriteFile(filepath.Join(renamedPath, "LICENSE"), []byte("MIT\n"), 0o644))
+	passingOutput, passingError := runPolicy()
+	require.NoError(testInstance, passingError, passingOutput)
+	require.NotContains(testInstance, passingOutput, "POLICY-VIOLATION")
+}
+
+func TestAuditReusesCachedInspectionsUntilRefresh(testInstance *testing.T) {
+	isolateAuditInspectionCache(testInstance)
+
+	workingDirectory, workingDirectoryError := os.Getwd()
+	require.NoError(testInstance, workingDirectoryError)
+	repositoryRoot := filepath.Dir(workingDirectory)
+
+	auditRoot := testInstance.TempDir()
+	uniqueRepositoryName := fmt.Sprintf("cache-%d", time.Now().UnixNano())
+	createGitRepository(testInstance, gitRepositoryOptions{
+		Path:      filepath.Join(auditRoot, uniqueRepositoryName),
+		RemoteURL: "git@github.com:origin/" + uniqueRepositoryName + ".git",
+	})
+
+	ghLogPath := filepath.Join(testInstance.TempDir(), "gh.log")
+	loggingStubScript := strings.Replace(auditIntegrationStubScript, "#!/bin/sh\n", "#!/bin/sh\necho \"$*\" >> \"$GIX_AUDIT_TEST_GH_LOG\"\n", 1)
+	commandOptions := integrationCommandOptions{
+		PathVariable:         buildStubbedExecutablePath(testInstance, auditIntegrationStubExecutableName, loggingStubScript),
+		EnvironmentOverrides: map[string]string{"GIX_AUDIT_TEST_GH_LOG": ghLogPath},
+	}
+	runAudit := func(arguments ...string) string {
+		return runIntegrationCommand(testInstance, repositoryRoot, commandOptions, auditIntegrationTimeout, append([]string{
+			auditIntegrationRunSubcommand,
+			auditIntegrationModulePathConstant,
+			auditIntegrationLogLevelFlag,
+			auditIntegrationErrorLevel,
+			auditIntegrationAuditCommandName,
+			auditIntegrationRootFlag,
+			auditRoot,
+			auditIntegrationFormatFlag,
+			"csv",
+		}, arguments...))
+	}
+	ghCalls := func() int {
+		contents, readError := os.ReadFile(ghLogPath)
+		if os.IsNotExist(readError) {
+			return 0
+		}
+		require.NoError(testInstance, readError)
+		return strings.Count(string(contents), "\n")
+	}
+
+	firstOutput := filterStructuredOutput(runAudit())
+	require.Contains(testInstance, firstOutput, uniqueRepositoryName+",canonical/example,configured")
+	require.Equal(testInstance, 1, ghCalls())
+
+	require.Equal(testInstance, firstOutput, filterStructuredOutput(runAudit()))
+	require.Equal(testInstance, 1, ghCalls())
+
+	require.Equal(testInstance, firstOutput, filterStructuredOutput(runAudit("--refresh")))
+	require.Equal(testInstance, 2, ghCalls())
+}
+
+func TestAuditDiffComparesSavedSnapshots(testInstance *testing.T) {
+	isolateAuditInspectionCache(testInstance)
+
+	workingDirectory, workingDirectoryError := os.Getwd()
+	require.NoError(testInstance, workingDirectoryError)
+	repositoryRoot := filepath.Dir(workingDirectory)
+
+	auditRoot := testInstance.TempDir()
+	legacyPath := createGitRepository(testInstance, gitRepositoryOptions{
+		Path:      filepath.Join(auditRoot, "legacy"),
+		RemoteURL: auditIntegrationOriginURL,
+	})
+	snapshotDirectory := testInstance.TempDir()
+	previousSnapshot := filepath.Join(snapshotDirectory, "previous.json")
+	currentSnapshot := filepath.Join(snapshotDirectory, "current.json")
+
+	commandOptions := integrationCommandOptions{
+		PathVariable: buildStubbedExecutablePath(testInstance, auditIntegrationStubExecutableName, auditIntegrationStubScript),
+	}
+	runGix := func(arguments ...string) string {
+		return runIntegrationCommand(testInstance, repositoryRoot, commandOptions, auditIntegrationTimeout, append([]string{
+			auditIntegrationRunSubcommand,
+			auditIntegrationModulePathConstant,
+			auditIntegrationLogLevelFlag,
+			auditIntegrationErrorLevel,
+			auditIntegrationAuditCommandName,
+		}, arguments...))
+	}
+
+	previousOutput := runGix(auditIntegrationRootFlag, auditRoot, auditIntegrationFormatFlag, "csv", "--save", previousSnapshot)
+	require.Contains(testInstance, previousOutput, auditIntegrationCSVHeaderConstant)
+	require.FileExists(testInstance, previousSnapshot)
+
+	renamedPath := filepath.Join(auditRoot, "example")
+	require.NoError(testInstance, os.Rename(legacyPath, renamedPath))
+	runGit(testInstance, renamedPath, "remote", "set-url", auditIntegrationOriginRemoteName, "https://github.com/origin/example.git")
+	require.NoError(testInstance, os.WriteFile(filepath.Join(renamedPath, "notes.md"), []byte("draft\n"), 0o644))
+	runGix(auditIntegrationRootFlag, auditRoot, "--save", currentSnapshot)
+
+	diffOutput := runGix("diff", previousSnapshot, currentSnapshot, auditIntegrationFormatFlag, "json")
+	require.JSONEq(testInstance, `{"schema":"gix.audit-diff/v1","changes":[
+		{"kind":"renamed","path":"`+renamedPath+`","previous":"`+legacyPath+`","current":"`+renamedPath+`"},
+		{"kind":"protocol_changed","path":"`+renamedPath+`","previous":"ssh","current":"https"},
+		{"kind":"became_dirty","path":"`+renamedPath+`","previous":"clean","current":"?? notes.md"}
+	]}`, diffOutput)
+
+	tableOutput := runGix("diff", previousSnapshot, currentSnapshot)
+	require.Contains(testInstance, tableOutput, "renamed")
+	require.Contains(testInstance, runGix("diff", previousSnapshot, previousSnapshot), "No changes between snapshots.")
+}
+
+func TestAuditGitHubDepthReportsRepositoryHealth(testInstance *testing.T) {
+	isolateAuditInspectionCache(testInstance)
+
+	workingDirectory, workingDirectoryError := os.Getwd()
+	require.NoError(testInstance, workingDirectoryError)
+	repositoryRoot := filepath.Dir(workingDirectory)
+
+	auditRoot := testInstance.TempDir()
+	repositoryName := fmt.Sprintf("health-%d", time.Now().UnixNano())
+	repositoryPath := createGitRepository(testInstance, gitRepositoryOptions{
+		Path:      filepath.Join(auditRoot, repositoryName),
+		RemoteURL: "git@github.com:origin/" + repositoryName + ".git",
+	})
+	runGit(testInstance, repositoryPath, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/master")
+
+	healthStubScript := `#!/bin/sh
+case "$1 $2" in
+  "repo view")
+    echo '{"nameWithOwner":"canonical/example","defaultBranchRef":{"name":"main"},"description":""}'
+    ;;
+  "api repos/canonical/example")
+    echo '{"archived":true,"disabled":false,"visibility":"public","default_branch":"main"}'
+    ;;
+  "api repos/canonical/example/branches/main/protection")
+    echo 'gh: Not Found (HTTP 404)' >&2
+    exit 1
+    ;;
+  "api repos/canonical/example/pages")
+    echo '{"build_type":"workflow"}'
+    ;;
+  "pr list")
+    echo '[{"number":7,"title":"Add docs","headRefName":"docs","baseRefName":"main"}]'
+    ;;
+esac
+exit 0
+`
+	commandOptions := integrationCommandOptions{
+		PathVariable: buildStubbedExecutablePath(testInstance, auditIntegrationStubExecutableName, healthStubScript),
+	}
+	output := filterStructuredOutput(runIntegrationCommand(testInstance, repositoryRoot, commandOptions, auditIntegrationTimeout, []string{
+		auditIntegrationRunSubcommand,
+		auditIntegrationModulePathConstant,
+		auditIntegrationLogLevelFlag,
+		auditIntegrationErrorLevel,
+		auditIntegrationAuditCommandName,
+		auditIntegrationRootFlag,
+		auditRoot,
+		auditIntegrationFormatFlag,
+		"csv",
+		"--github",
+	}))
+
+	require.Contains(testInstance, output, strings.TrimSuffix(auditIntegrationCSVHeaderConstant, "\n")+",github_archived,github_disabled,github_visibility,default_branch_protected,github_pages,open_pull_requests,local_default_branch,default_branch_matches_github\n")
+	require.Contains(testInstance, output, repositoryName+",canonical/example,configured,")
+	require.Contains(testInstance, output, ",yes,no,public,no,workflow,1,master,no\n")
+}
+
 func requireAuditTableFitsTerminal(testInstance *testing.T, table string, terminalWidth int) {
 	testInstance.Helper()
 	for _, line := range strings.Split(strings.TrimSpace(table), "\n") {
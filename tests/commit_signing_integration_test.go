@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncSignsDirtyAndMergeCommits(testInstance *testing.T) {
+	if _, lookupError := exec.LookPath("ssh-keygen"); lookupError != nil {
+		testInstance.Skip("ssh-keygen is required for SSH commit signing")
+	}
+	const branchName = "feature/signed-work"
+
+	testCases := []struct {
+		name          string
+		missingKey    bool
+		expectSuccess bool
+	}{
+		{name: "signs every commit sync creates", expectSuccess: true},
+		{name: "preflight stops before mutation", missingKey: true},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			repositoryRoot := integrationRepositoryRoot(testInstance)
+			workspacePath := syncHomeWorkspace(testInstance)
+			remotePath := filepath.Join(workspacePath, "remote.git")
+			repositoryPath := filepath.Join(workspacePath, "project")
+			createSyncGitHubBackedRepository(testInstance, remotePath, repositoryPath)
+
+			keyPath := filepath.Join(testInstance.TempDir(), "signing_key")
+			keyGeneration := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "signer@example.com", "-f", keyPath)
+			keyOutput, keyError := keyGeneration.CombinedOutput()
+			require.NoError(testInstance, keyError, string(keyOutput))
+			configuredKeyPath := keyPath
+			if testCase.missingKey {
+				configuredKeyPath = filepath.Join(testInstance.TempDir(), "missing_key")
+			}
+
+			require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("initial\n"), 0o644))
+			runGit(testInstance, repositoryPath, "add", "README.md")
+			runGit(testInstance, repositoryPath, "commit", "-m", "initial commit")
+			runGit(testInstance, repositoryPath, "push", "-u", "origin", "master")
+			runGit(testInstance, repositoryPath, "switch", "-c", branchName)
+			require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "work.txt"), []byte("prepared work\n"), 0o644))
+			runGit(testInstance, repositoryPath, "add", "work.txt")
+			runGit(testInstance, repositoryPath, "commit", "-m", "prepare work")
+			runGit(testInstance, repositoryPath, "push", "-u", "origin", branchName)
+			runGit(testInstance, repositoryPath, "switch", "master")
+			require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "base.txt"), []byte("base change\n"), 0o644))
+			runGit(testInstance, repositoryPath, "add", "base.txt")
+			runGit(testInstance, repositoryPath, "commit", "-m", "advance master")
+			runGit(testInstance, repositoryPath, "push", "origin", "master")
+			runGit(testInstance, repositoryPath, "switch", branchName)
+			require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "work.txt"), []byte("prepared work\nsigned follow-up\n"), 0o644))
+			startingHead := strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", "HEAD"))
+
+			llmServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+				if request.URL.Path != "/chat/completions" {
+					http.NotFound(responseWriter, request)
+					return
+				}
+				responseWriter.Header().Set("Content-Type", "application/json")
+				_, _ = responseWriter.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"fix: sign follow-up work"}}]}`))
+			}))
+			testInstance.Cleanup(llmServer.Close)
+
+			configurationPath := writeDirtySyncMergedBranchConfiguration(testInstance, llmServer.URL)
+			configurationContent := readTextFile(testInstance, configurationPath) + fmt.Sprintf("signing:\n  mode: ssh\n  key: %q\n  require_signatures: true\n", configuredKeyPath)
+			require.NoError(testInstance, os.WriteFile(configurationPath, []byte(configurationContent), 0o600))
+
+			output, runError := runIntegrationCommandWithInput(
+				testInstance,
+				repositoryRoot,
+				integrationCommandOptions{
+					PathVariable: buildSyncMergedBranchExecutablePath(testInstance),
+					EnvironmentOverrides: map[string]string{
+						syncMergedBranchAPIKeyVariable:    "test-key",
+						syncMergedBranchGitHubLogVariable: filepath.Join(testInstance.TempDir(), "gh.log"),
+						syncMergedBranchNameVariable:      branchName,
+						syncMergedBranchMergedVariable:    "false",
+					},
+				},
+				syncMergedBranchIntegrationTimeout,
+				"",
+				[]string{"run", ".", "--config", configurationPath, "--log-level", "error", "sync", "--body", "Signed work.", "--roots", repositoryPath},
+			)
+
+			if !testCase.expectSuccess {
+				require.Error(testInstance, runError, output)
+				require.Contains(testInstance, output, "commit signing preflight failed")
+				require.Equal(testInstance, startingHead, strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", "HEAD")))
+				require.Equal(testInstance, "M work.txt", strings.TrimSpace(runGit(testInstance, repositoryPath, "status", "--porcelain")))
+				return
+			}
+
+			require.NoError(testInstance, runError, output)
+			require.Contains(testInstance, output, fmt.Sprintf("SYNCED: %s (%s)", repositoryPath, branchName))
+			allowedSignersPath := filepath.Join(testInstance.TempDir(), "allowed_signers")
+			publicKey, publicKeyError := os.ReadFile(keyPath + ".pub")
+			require.NoError(testInstance, publicKeyError)
+			require.NoError(testInstance, os.WriteFile(allowedSignersPath, []byte("signer@example.com "+string(publicKey)), 0o600))
+
+			createdCommits := strings.Fields(runGit(testInstance, repositoryPath, "rev-list", startingHead+".."+branchName, "^origin/master"))
+			subjects := make([]string, 0, len(createdCommits))
+			for _, commit := range createdCommits {
+				subjects = append(subjects, strings.TrimSpace(runGit(testInstance, repositoryPath, "log", "-1", "--format=%s", commit)))
+				runGit(testInstance, repositoryPath, "-c", "gpg.ssh.allowedSignersFile="+allowedSignersPath, "verify-commit", commit)
+			}
+			require.Len(testInstance, createdCommits, 2, strings.Join(subjects, "\n"))
+			require.Contains(testInstance, subjects, "fix: sign follow-up work")
+			require.Empty(testInstance, strings.TrimSpace(runGit(testInstance, repositoryPath, "status", "--porcelain")))
+		})
+	}
+}
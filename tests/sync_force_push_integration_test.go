@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncDetectsUpstreamForcePush(testInstance *testing.T) {
+	const (
+		defaultBranch = "master"
+		targetBranch  = "feature/shared-work"
+	)
+
+	testCases := []struct {
+		name string
+		mode string
+	}{
+		{name: "stops without a mode"},
+		{name: "adopt remote saves local-only commits", mode: "adopt-remote"},
+		{name: "keep local opens a pull request", mode: "keep-local"},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			repositoryRoot := integrationRepositoryRoot(testInstance)
+			workspacePath := syncHomeWorkspace(testInstance)
+			remotePath := filepath.Join(workspacePath, "remote.git")
+			repositoryPath := filepath.Join(workspacePath, "project")
+			teammatePath := filepath.Join(workspacePath, "teammate")
+
+			runGitWithDir(testInstance, "", "init", "--bare", "--initial-branch="+defaultBranch, remotePath)
+			runGitWithDir(testInstance, "", "init", "--initial-branch="+defaultBranch, repositoryPath)
+			configureGitIdentity(testInstance, repositoryPath)
+			runGit(testInstance, repositoryPath, "remote", "add", "origin", localFileURL(remotePath))
+			require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("initial\n"), 0o644))
+			runGit(testInstance, repositoryPath, "add", "README.md")
+			runGit(testInstance, repositoryPath, "commit", "-m", "initial commit")
+			runGit(testInstance, repositoryPath, "push", "-u", "origin", defaultBranch)
+			runGit(testInstance, repositoryPath, "switch", "-c", targetBranch)
+			require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "shared.txt"), []byte("shared work\n"), 0o644))
+			runGit(testInstance, repositoryPath, "add", "shared.txt")
+			runGit(testInstance, repositoryPath, "commit", "-m", "shared work")
+
+			llmServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+				http.Error(responseWriter, "unexpected LLM request", http.StatusBadRequest)
+			}))
+			testInstance.Cleanup(llmServer.Close)
+
+			configurationPath := writeDirtySyncMergedBranchConfiguration(testInstance, llmServer.URL)
+			executablePath := buildSyncMergedBranchExecutablePath(testInstance)
+			githubLogPath := filepath.Join(testInstance.TempDir(), "gh.log")
+			runSync := func(arguments ...string) (string, error) {
+				return runIntegrationCommandWithInput(
+					testInstance,
+					repositoryRoot,
+					integrationCommandOptions{
+						PathVariable: executablePath,
+						EnvironmentOverrides: map[string]string{
+							syncMergedBranchAPIKeyVariable:    "test-key",
+							syncMergedBranchGitLogVariable:    filepath.Join(testInstance.TempDir(), "git.log"),
+							syncMergedBranchGitHubLogVariable: githubLogPath,
+							syncMergedBranchMergedVariable:    "false",
+							syncMergedBranchNameVariable:      targetBranch,
+						},
+					},
+					syncMergedBranchIntegrationTimeout,
+					"",
+					append([]string{
+						syncRefreshIntegrationRunCommand,
+						syncRefreshIntegrationModulePath,
+						"--config",
+						configurationPath,
+						syncRefreshIntegrationLogLevelFlag,
+						syncRefreshIntegrationErrorLogLevel,
+						"sync",
+						targetBranch,
+						"--title",
+						"Shared work",
+						"--body",
+						"Adds shared work.",
+						"--roots",
+						repositoryPath,
+					}, arguments...),
+				)
+			}
+
+			output, runError := runSync()
+			require.NoError(testInstance, runError, output)
+			syncedCommit := strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", "HEAD"))
+			require.Equal(testInstance, syncedCommit, strings.TrimSpace(runGit(testInstance, repositoryPath, "config", "--get", "branch."+targetBranch+".gix-synced-remote")))
+
+			runGitWithDir(testInstance, "", "clone", "--branch", targetBranch, localFileURL(remotePath), teammatePath)
+			configureGitIdentity(testInstance, teammatePath)
+			runGit(testInstance, teammatePath, "reset", "--hard", "origin/"+defaultBranch)
+			require.NoError(testInstance, os.WriteFile(filepath.Join(teammatePath, "rewritten.txt"), []byte("rewritten\n"), 0o644))
+			runGit(testInstance, teammatePath, "add", "rewritten.txt")
+			runGit(testInstance, teammatePath, "commit", "-m", "rewritten shared work")
+			runGit(testInstance, teammatePath, "push", "--force", "origin", targetBranch)
+			rewrittenCommit := strings.TrimSpace(runGit(testInstance, teammatePath, "rev-parse", "HEAD"))
+
+			require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "local.txt"), []byte("local follow-up\n"), 0o644))
+			runGit(testInstance, repositoryPath, "add", "local.txt")
+			runGit(testInstance, repositoryPath, "commit", "-m", "local follow-up")
+			localCommit := strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", "HEAD"))
+			shortLocalCommit := localCommit[:12]
+
+			var arguments []string
+			if testCase.mode != "" {
+				arguments = []string{"--on-force-push", testCase.mode}
+			}
+			output, runError = runSync(arguments...)
+			remoteTarget := strings.TrimSpace(runGit(testInstance, remotePath, "rev-parse", "refs/heads/"+targetBranch))
+			require.Equal(testInstance, rewrittenCommit, remoteTarget)
+
+			switch testCase.mode {
+			case "":
+				require.Error(testInstance, runError, output)
+				require.Contains(testInstance, output, "SYNC_FORCE_PUSH")
+				require.Contains(testInstance, output, "--on-force-push adopt-remote")
+				require.NotContains(testInstance, output, "SYNCED:")
+				require.Equal(testInstance, localCommit, strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", targetBranch)))
+				require.Equal(testInstance, targetBranch, strings.TrimSpace(runGit(testInstance, repositoryPath, "branch", "--show-current")))
+			case "adopt-remote":
+				require.NoError(testInstance, runError, output)
+				require.Contains(testInstance, output, fmt.Sprintf("SYNCED: %s (%s)", repositoryPath, targetBranch))
+				require.Equal(testInstance, rewrittenCommit, strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", targetBranch)))
+				require.Equal(testInstance, localCommit, strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", "refs/gix/backup/"+targetBranch+"/"+shortLocalCommit)))
+				require.Equal(testInstance, rewrittenCommit, strings.TrimSpace(runGit(testInstance, repositoryPath, "config", "--get", "branch."+targetBranch+".gix-synced-remote")))
+			case "keep-local":
+				keptBranch := targetBranch + "-local-" + shortLocalCommit
+				require.NoError(testInstance, runError, output)
+				require.Contains(testInstance, output, fmt.Sprintf("SYNCED: %s (%s)", repositoryPath, keptBranch))
+				require.Equal(testInstance, keptBranch, strings.TrimSpace(runGit(testInstance, repositoryPath, "branch", "--show-current")))
+				require.Equal(testInstance, localCommit, strings.TrimSpace(runGit(testInstance, remotePath, "rev-parse", "refs/heads/"+keptBranch)))
+				require.Contains(testInstance, readTextFile(testInstance, githubLogPath), "pr create --repo owner/project --base "+targetBranch+" --head "+keptBranch+" --title Keep local history of "+targetBranch)
+				require.Equal(testInstance, localCommit, strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", keptBranch)))
+				require.Equal(testInstance, rewrittenCommit, strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", targetBranch)))
+				require.Equal(testInstance, rewrittenCommit, strings.TrimSpace(runGit(testInstance, repositoryPath, "config", "--get", "branch."+targetBranch+".gix-synced-remote")))
+
+				runGit(testInstance, repositoryPath, "switch", targetBranch)
+				output, runError = runSync()
+				require.NoError(testInstance, runError, output)
+				require.NotContains(testInstance, output, "SYNC_FORCE_PUSH")
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncForkWorkflowMergesUpstreamPushesOriginAndOpensCrossRepositoryPullRequest(testInstance *testing.T) {
+	const (
+		defaultBranch      = "master"
+		targetBranch       = "feature/fork-work"
+		upstreamRemoteURL  = "https://github.com/owner/project.git"
+		originRemoteURL    = "https://github.com/contributor/project.git"
+		pullRequestTitle   = "Fork work"
+		pullRequestBody    = "Adds fork work."
+		upstreamUpdateText = "initial\nupstream update\n"
+	)
+
+	repositoryRoot := integrationRepositoryRoot(testInstance)
+	workspacePath := syncHomeWorkspace(testInstance)
+	upstreamPath := filepath.Join(workspacePath, "upstream.git")
+	forkPath := filepath.Join(workspacePath, "fork.git")
+	maintainerPath := filepath.Join(workspacePath, "maintainer")
+	repositoryPath := filepath.Join(workspacePath, "project")
+
+	runGitWithDir(testInstance, "", "init", "--bare", "--initial-branch="+defaultBranch, upstreamPath)
+	runGitWithDir(testInstance, "", "init", "--initial-branch="+defaultBranch, maintainerPath)
+	configureGitIdentity(testInstance, maintainerPath)
+	runGit(testInstance, maintainerPath, "remote", "add", "origin", localFileURL(upstreamPath))
+	readmePath := filepath.Join(maintainerPath, "README.md")
+	require.NoError(testInstance, os.WriteFile(readmePath, []byte("initial\n"), 0o644))
+	runGit(testInstance, maintainerPath, "add", "README.md")
+	runGit(testInstance, maintainerPath, "commit", "-m", "initial commit")
+	runGit(testInstance, maintainerPath, "push", "-u", "origin", defaultBranch)
+	runGitWithDir(testInstance, "", "clone", "--bare", upstreamPath, forkPath)
+
+	runGitWithDir(testInstance, "", "clone", localFileURL(forkPath), repositoryPath)
+	configureGitIdentity(testInstance, repositoryPath)
+	runGit(testInstance, repositoryPath, "config", "url."+localFileURL(upstreamPath)+".insteadOf", upstreamRemoteURL)
+	runGit(testInstance, repositoryPath, "config", "url."+localFileURL(forkPath)+".insteadOf", originRemoteURL)
+	runGit(testInstance, repositoryPath, "remote", "set-url", "origin", originRemoteURL)
+	runGit(testInstance, repositoryPath, "remote", "add", "upstream", upstreamRemoteURL)
+	runGit(testInstance, repositoryPath, "switch", "-c", targetBranch)
+	workPath := filepath.Join(repositoryPath, "work.txt")
+	require.NoError(testInstance, os.WriteFile(workPath, []byte("fork work\n"), 0o644))
+	runGit(testInstance, repositoryPath, "add", "work.txt")
+	runGit(testInstance, repositoryPath, "commit", "-m", "fork work")
+
+	require.NoError(testInstance, os.WriteFile(readmePath, []byte(upstreamUpdateText), 0o644))
+	runGit(testInstance, maintainerPath, "commit", "-am", "upstream update")
+	runGit(testInstance, maintainerPath, "push", "origin", defaultBranch)
+
+	llmServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		http.Error(responseWriter, "unexpected LLM request", http.StatusBadRequest)
+	}))
+	testInstance.Cleanup(llmServer.Close)
+
+	configurationPath := writeDirtySyncMergedBranchConfiguration(testInstance, llmServer.URL)
+	gitLogPath := filepath.Join(testInstance.TempDir(), "git.log")
+	githubLogPath := filepath.Join(testInstance.TempDir(), "gh.log")
+	output, runError := runIntegrationCommandWithInput(
+		testInstance,
+		repositoryRoot,
+		integrationCommandOptions{
+			PathVariable: buildSyncMergedBranchExecutablePath(testInstance),
+			EnvironmentOverrides: map[string]string{
+				syncMergedBranchAPIKeyVariable:    "test-key",
+				syncMergedBranchGitLogVariable:    gitLogPath,
+				syncMergedBranchGitHubLogVariable: githubLogPath,
+				syncMergedBranchMergedVariable:    "false",
+				syncMergedBranchNameVariable:      targetBranch,
+			},
+		},
+		syncMergedBranchIntegrationTimeout,
+		"",
+		[]string{
+			syncRefreshIntegrationRunCommand,
+			syncRefreshIntegrationModulePath,
+			"--config",
+			configurationPath,
+			syncRefreshIntegrationLogLevelFlag,
+			syncRefreshIntegrationErrorLogLevel,
+			"sync",
+			targetBranch,
+			"--title",
+			pullRequestTitle,
+			"--body",
+			pullRequestBody,
+			"--roots",
+			repositoryPath,
+		},
+	)
+	require.NoError(testInstance, runError, output)
+
+	require.Contains(testInstance, output, fmt.Sprintf("SYNCED: %s (%s)", repositoryPath, targetBranch))
+	require.Contains(testInstance, output, "SYNC_FORK")
+	require.Equal(testInstance, "upstream", strings.TrimSpace(runGit(testInstance, repositoryPath, "config", "--get", "remote.upstream.gix-role")))
+	require.Equal(testInstance, "origin", strings.TrimSpace(runGit(testInstance, repositoryPath, "config", "--get", "remote.origin.gix-role")))
+	require.Equal(testInstance, upstreamUpdateText, readTextFile(testInstance, filepath.Join(repositoryPath, "README.md")))
+
+	localHead := strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", targetBranch))
+	require.Equal(testInstance, localHead, strings.TrimSpace(runGit(testInstance, forkPath, "rev-parse", "refs/heads/"+targetBranch)))
+	require.Empty(testInstance, strings.TrimSpace(runGit(testInstance, upstreamPath, "for-each-ref", "refs/heads/"+targetBranch)))
+
+	gitLog := readTextFile(testInstance, gitLogPath)
+	require.Contains(testInstance, gitLog, "fetch --prune upstream")
+	require.Contains(testInstance, gitLog, "ls-remote --symref upstream HEAD")
+	require.Contains(testInstance, gitLog, "merge --no-edit upstream/"+defaultBranch)
+	require.Contains(testInstance, gitLog, "push -u origin "+targetBranch)
+
+	githubLog := readTextFile(testInstance, githubLogPath)
+	require.Contains(testInstance, githubLog, "pr create --repo owner/project --base "+defaultBranch+" --head contributor:"+targetBranch+" --title "+pullRequestTitle)
+}
@@ -24,6 +24,7 @@ const (
 	syncMergedBranchGitHubLogVariable           = "GIX_SYNC_TEST_GH_LOG"
 	syncMergedBranchOperationLogVariable        = "GIX_SYNC_TEST_OPERATION_LOG"
 	syncMergedBranchFailPullRequestHeadVariable = "GIX_SYNC_TEST_FAIL_PR_HEAD"
+	syncMergedBranchFailAutoMergeVariable       = "GIX_SYNC_TEST_FAIL_AUTO_MERGE"
 	syncMergedBranchFailGitMatchVariable        = "GIX_SYNC_TEST_FAIL_GIT_MATCH"
 	syncMergedBranchFailGitOccurrenceVariable   = "GIX_SYNC_TEST_FAIL_GIT_OCCURRENCE"
 	syncMergedBranchFailGitStateVariable        = "GIX_SYNC_TEST_FAIL_GIT_STATE"
@@ -1008,6 +1009,14 @@ if [ "$1" = "pr" ] && [ "$2" = "create" ]; then
   exit 0
 fi
 
+if [ "$1" = "pr" ] && [ "$2" = "merge" ]; then
+  if [ "$GIX_SYNC_TEST_FAIL_AUTO_MERGE" = "true" ]; then
+    printf 'simulated auto-merge failure: auto-merge is not allowed for this repository\n' >&2
+    exit 1
+  fi
+  exit 0
+fi
+
 printf 'unexpected gh invocation: %s\n' "$*" >&2
 exit 1
 `
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const syncPullRequestSettingsConfiguration = `      remote: origin
+      pull_request:
+        labels: [sync]
+        reviewers: [owner/core]
+        rules:
+          - branch_prefix: feature/
+            labels: [feature, sync]
+            auto_merge: squash
+          - branch_prefix: hotfix/
+            labels: [urgent]
+            draft: true
+`
+
+func TestSyncAppliesPullRequestSettingsFromRulesAndFlags(testInstance *testing.T) {
+	const (
+		defaultBranch = "master"
+		targetBranch  = "feature/labelled-work"
+	)
+
+	testCases := []struct {
+		name            string
+		failAutoMerge   string
+		expectAutoMerge bool
+	}{
+		{name: "auto-merge enabled after creation", failAutoMerge: "false", expectAutoMerge: true},
+		{name: "auto-merge refusal keeps the sync", failAutoMerge: "true"},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			repositoryRoot := integrationRepositoryRoot(testInstance)
+			workspacePath := syncHomeWorkspace(testInstance)
+			remotePath := filepath.Join(workspacePath, "remote.git")
+			repositoryPath := filepath.Join(workspacePath, "project")
+
+			runGitWithDir(testInstance, "", "init", "--bare", "--initial-branch="+defaultBranch, remotePath)
+			runGitWithDir(testInstance, "", "init", "--initial-branch="+defaultBranch, repositoryPath)
+			configureGitIdentity(testInstance, repositoryPath)
+			runGit(testInstance, repositoryPath, "remote", "add", "origin", localFileURL(remotePath))
+			require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("initial\n"), 0o644))
+			runGit(testInstance, repositoryPath, "add", "README.md")
+			runGit(testInstance, repositoryPath, "commit", "-m", "initial commit")
+			runGit(testInstance, repositoryPath, "push", "-u", "origin", defaultBranch)
+			runGit(testInstance, repositoryPath, "switch", "-c", targetBranch)
+			require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "work.txt"), []byte("labelled work\n"), 0o644))
+			runGit(testInstance, repositoryPath, "add", "work.txt")
+			runGit(testInstance, repositoryPath, "commit", "-m", "labelled work")
+
+			llmServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+				http.Error(responseWriter, "unexpected LLM request", http.StatusBadRequest)
+			}))
+			testInstance.Cleanup(llmServer.Close)
+
+			configurationPath := writeDirtySyncMergedBranchConfiguration(testInstance, llmServer.URL)
+			configurationContent := readTextFile(testInstance, configurationPath)
+			require.Contains(testInstance, configurationContent, "      remote: origin\n")
+			configurationContent = strings.Replace(configurationContent, "      remote: origin\n", syncPullRequestSettingsConfiguration, 1)
+			require.NoError(testInstance, os.WriteFile(configurationPath, []byte(configurationContent), 0o600))
+
+			githubLogPath := filepath.Join(testInstance.TempDir(), "gh.log")
+			output, runError := runIntegrationCommandWithInput(
+				testInstance,
+				repositoryRoot,
+				integrationCommandOptions{
+					PathVariable: buildSyncMergedBranchExecutablePath(testInstance),
+					EnvironmentOverrides: map[string]string{
+						syncMergedBranchAPIKeyVariable:        "test-key",
+						syncMergedBranchGitLogVariable:        filepath.Join(testInstance.TempDir(), "git.log"),
+						syncMergedBranchGitHubLogVariable:     githubLogPath,
+						syncMergedBranchMergedVariable:        "false",
+						syncMergedBranchNameVariable:          targetBranch,
+						syncMergedBranchFailAutoMergeVariable: testCase.failAutoMerge,
+					},
+				},
+				syncMergedBranchIntegrationTimeout,
+				"",
+				[]string{
+					syncRefreshIntegrationRunCommand,
+					syncRefreshIntegrationModulePath,
+					"--config",
+					configurationPath,
+					syncRefreshIntegrationLogLevelFlag,
+					syncRefreshIntegrationErrorLogLevel,
+					"sync",
+					targetBranch,
+					"--title",
+					"Labelled",
+					"--body",
+					"Adds labelled work.",
+					"--label",
+					"reviewed",
+					"--assignee",
+					"octocat",
+					"--milestone",
+					"v1",
+					"--roots",
+					repositoryPath,
+				},
+			)
+
+			require.NoError(testInstance, runError, output)
+			require.Contains(testInstance, output, fmt.Sprintf("SYNCED: %s (%s)", repositoryPath, targetBranch))
+			githubLog := readTextFile(testInstance, githubLogPath)
+			require.Contains(testInstance, githubLog, "pr create --repo owner/project --base "+defaultBranch+" --head "+targetBranch+" --title Labelled --body Adds labelled work. --label sync --label feature --label reviewed --assignee octocat --reviewer owner/core --milestone v1\n")
+			require.NotContains(testInstance, githubLog, "urgent")
+			require.NotContains(testInstance, githubLog, "--draft")
+			require.Contains(testInstance, githubLog, "pr merge "+targetBranch+" --repo owner/project --auto --squash\n")
+			if !testCase.expectAutoMerge {
+				require.Contains(testInstance, output, "SYNC_AUTO_MERGE")
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncRecoverOpensMissingPullRequestAfterPublishedHandoff(testInstance *testing.T) {
+	const (
+		defaultBranch    = "master"
+		targetBranch     = "feature/recover-work"
+		pullRequestTitle = "Recover work"
+		pullRequestBody  = "Adds recover work."
+	)
+
+	repositoryRoot := integrationRepositoryRoot(testInstance)
+	workspacePath := syncHomeWorkspace(testInstance)
+	remotePath := filepath.Join(workspacePath, "remote.git")
+	repositoryPath := filepath.Join(workspacePath, "project")
+
+	runGitWithDir(testInstance, "", "init", "--bare", "--initial-branch="+defaultBranch, remotePath)
+	runGitWithDir(testInstance, "", "init", "--initial-branch="+defaultBranch, repositoryPath)
+	configureGitIdentity(testInstance, repositoryPath)
+	runGit(testInstance, repositoryPath, "remote", "add", "origin", localFileURL(remotePath))
+	require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("initial\n"), 0o644))
+	runGit(testInstance, repositoryPath, "add", "README.md")
+	runGit(testInstance, repositoryPath, "commit", "-m", "initial commit")
+	runGit(testInstance, repositoryPath, "push", "-u", "origin", defaultBranch)
+	runGit(testInstance, repositoryPath, "switch", "-c", targetBranch)
+	require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "work.txt"), []byte("recover work\n"), 0o644))
+	runGit(testInstance, repositoryPath, "add", "work.txt")
+	runGit(testInstance, repositoryPath, "commit", "-m", "recover work")
+
+	llmServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		http.Error(responseWriter, "unexpected LLM request", http.StatusBadRequest)
+	}))
+	testInstance.Cleanup(llmServer.Close)
+
+	configurationPath := writeDirtySyncMergedBranchConfiguration(testInstance, llmServer.URL)
+	executablePath := buildSyncMergedBranchExecutablePath(testInstance)
+	githubLogPath := filepath.Join(testInstance.TempDir(), "gh.log")
+	environment := map[string]string{
+		syncMergedBranchAPIKeyVariable:    "test-key",
+		syncMergedBranchGitLogVariable:    filepath.Join(testInstance.TempDir(), "git.log"),
+		syncMergedBranchGitHubLogVariable: githubLogPath,
+		syncMergedBranchMergedVariable:    "false",
+		syncMergedBranchNameVariable:      targetBranch,
+	}
+	runGix := func(overrides map[string]string, arguments ...string) (string, error) {
+		commandEnvironment := make(map[string]string, len(environment)+len(overrides))
+		for key, value := range environment {
+			commandEnvironment[key] = value
+		}
+		for key, value := range overrides {
+			commandEnvironment[key] = value
+		}
+		return runIntegrationCommandWithInput(
+			testInstance,
+			repositoryRoot,
+			integrationCommandOptions{PathVariable: executablePath, EnvironmentOverrides: commandEnvironment},
+			syncMergedBranchIntegrationTimeout,
+			"",
+			append([]string{
+				syncRefreshIntegrationRunCommand,
+				syncRefreshIntegrationModulePath,
+				"--config",
+				configurationPath,
+				syncRefreshIntegrationLogLevelFlag,
+				syncRefreshIntegrationErrorLogLevel,
+			}, arguments...),
+		)
+	}
+
+	output, runError := runGix(
+		map[string]string{syncMergedBranchFailPullRequestHeadVariable: targetBranch},
+		"sync", targetBranch, "--title", pullRequestTitle, "--body", pullRequestBody, "--roots", repositoryPath,
+	)
+	require.Error(testInstance, runError, output)
+	require.Contains(testInstance, output, "SYNC_SWITCH_HANDOFF")
+	require.NotContains(testInstance, output, "SYNCED:")
+	recordPath := filepath.Join(repositoryPath, ".git", "gix", "sync-handoff.json")
+	record := readTextFile(testInstance, recordPath)
+	require.Contains(testInstance, record, `"schema": "gix.sync-handoff/v1"`)
+	require.Contains(testInstance, record, `"kind": "published"`)
+	require.Contains(testInstance, record, `"reference": "refs/heads/`+targetBranch+`"`)
+	require.Contains(testInstance, record, `"title": "`+pullRequestTitle+`"`)
+
+	output, runError = runGix(nil, "sync", "recover", "--yes", "--roots", repositoryPath)
+	require.NoError(testInstance, runError, output)
+	require.Contains(testInstance, output, "HANDOFF: "+repositoryPath+" ("+targetBranch+") published handoff")
+	require.Contains(testInstance, output, "published: ")
+	require.Contains(testInstance, output, "DONE: open pull request "+targetBranch+" into "+defaultBranch+" on owner/project")
+	require.Contains(testInstance, output, "RECOVERED: "+repositoryPath+" ("+targetBranch+")")
+	require.NoFileExists(testInstance, recordPath)
+	require.Contains(testInstance, readTextFile(testInstance, githubLogPath), "pr create --repo owner/project --base "+defaultBranch+" --head "+targetBranch+" --title "+pullRequestTitle)
+
+	output, runError = runGix(nil, "sync", "recover", "--roots", repositoryPath)
+	require.NoError(testInstance, runError, output)
+	require.Contains(testInstance, strings.TrimSpace(output), "NO HANDOFF: "+repositoryPath)
+}
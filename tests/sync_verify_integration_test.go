@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncRunsRepositoryVerificationBeforePush(testInstance *testing.T) {
+	const (
+		defaultBranch = "master"
+		targetBranch  = "feature/verified-work"
+	)
+
+	testCases := []struct {
+		name          string
+		verifyCommand string
+		expectPush    bool
+	}{
+		{name: "passing verification pushes", verifyCommand: "test -f work.txt", expectPush: true},
+		{name: "failing verification rolls back before push", verifyCommand: "echo broken merge >&2; exit 3"},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			repositoryRoot := integrationRepositoryRoot(testInstance)
+			workspacePath := syncHomeWorkspace(testInstance)
+			remotePath := filepath.Join(workspacePath, "remote.git")
+			repositoryPath := filepath.Join(workspacePath, "project")
+
+			runGitWithDir(testInstance, "", "init", "--bare", "--initial-branch="+defaultBranch, remotePath)
+			runGitWithDir(testInstance, "", "init", "--initial-branch="+defaultBranch, repositoryPath)
+			configureGitIdentity(testInstance, repositoryPath)
+			runGit(testInstance, repositoryPath, "remote", "add", "origin", localFileURL(remotePath))
+			require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("initial\n"), 0o644))
+			repositoryConfiguration := fmt.Sprintf("sync:\n  verify:\n    - %q\n", testCase.verifyCommand)
+			require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, ".gix.yml"), []byte(repositoryConfiguration), 0o644))
+			runGit(testInstance, repositoryPath, "add", "README.md", ".gix.yml")
+			runGit(testInstance, repositoryPath, "commit", "-m", "initial commit")
+			runGit(testInstance, repositoryPath, "push", "-u", "origin", defaultBranch)
+			runGit(testInstance, repositoryPath, "switch", "-c", targetBranch)
+			require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "work.txt"), []byte("verified work\n"), 0o644))
+			runGit(testInstance, repositoryPath, "add", "work.txt")
+			runGit(testInstance, repositoryPath, "commit", "-m", "verified work")
+			localHead := strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", "HEAD"))
+
+			llmServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+				http.Error(responseWriter, "unexpected LLM request", http.StatusBadRequest)
+			}))
+			testInstance.Cleanup(llmServer.Close)
+
+			githubLogPath := filepath.Join(testInstance.TempDir(), "gh.log")
+			output, runError := runIntegrationCommandWithInput(
+				testInstance,
+				repositoryRoot,
+				integrationCommandOptions{
+					PathVariable: buildSyncMergedBranchExecutablePath(testInstance),
+					EnvironmentOverrides: map[string]string{
+						syncMergedBranchAPIKeyVariable:    "test-key",
+						syncMergedBranchGitLogVariable:    filepath.Join(testInstance.TempDir(), "git.log"),
+						syncMergedBranchGitHubLogVariable: githubLogPath,
+						syncMergedBranchMergedVariable:    "false",
+						syncMergedBranchNameVariable:      targetBranch,
+					},
+				},
+				syncMergedBranchIntegrationTimeout,
+				"",
+				[]string{
+					syncRefreshIntegrationRunCommand,
+					syncRefreshIntegrationModulePath,
+					"--config",
+					writeDirtySyncMergedBranchConfiguration(testInstance, llmServer.URL),
+					syncRefreshIntegrationLogLevelFlag,
+					syncRefreshIntegrationErrorLogLevel,
+					"sync",
+					targetBranch,
+					"--title",
+					"Verified work",
+					"--body",
+					"Adds verified work.",
+					"--roots",
+					repositoryPath,
+				},
+			)
+
+			remoteBranch := strings.TrimSpace(runGit(testInstance, remotePath, "for-each-ref", "--format=%(objectname)", "refs/heads/"+targetBranch))
+			if testCase.expectPush {
+				require.NoError(testInstance, runError, output)
+				require.Contains(testInstance, output, fmt.Sprintf("SYNCED: %s (%s)", repositoryPath, targetBranch))
+				require.Equal(testInstance, localHead, remoteBranch)
+				return
+			}
+			require.Error(testInstance, runError, output)
+			require.Contains(testInstance, output, "SYNC_VERIFY")
+			require.Contains(testInstance, output, "broken merge")
+			require.Contains(testInstance, output, "SYNC_SWITCH_ROLLBACK")
+			require.NotContains(testInstance, output, "SYNCED:")
+			require.Empty(testInstance, remoteBranch)
+			require.Equal(testInstance, localHead, strings.TrimSpace(runGit(testInstance, repositoryPath, "rev-parse", "HEAD")))
+			require.Equal(testInstance, targetBranch, strings.TrimSpace(runGit(testInstance, repositoryPath, "branch", "--show-current")))
+			require.NotContains(testInstance, readTextFile(testInstance, githubLogPath), "pr create")
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -37,6 +38,8 @@ const (
 	webIntegrationWildcardHostConstant         = "0.0.0.0"
 )
 
+var webIntegrationSessionTokenPattern = regexp.MustCompile(`/\?token=([A-Za-z0-9_-]+)`)
+
 func TestWebBinaryEmbedsFirstPartyAssetsAndUsesCDNDependencies(testInstance *testing.T) {
 	repositoryRoot := integrationRepositoryRoot(testInstance)
 	binaryPath := buildIntegrationBinary(testInstance, repositoryRoot)
@@ -52,33 +55,39 @@ func TestWebBinaryEmbedsFirstPartyAssetsAndUsesCDNDependencies(testInstance *tes
 	standardOutput, standardError := startLongRunningCommand(testInstance, command)
 	defer stopLongRunningCommand(testInstance, command)
 
-	waitForWebServerReady(testInstance, baseURL+webIntegrationIndexAssetPathConstant)
+	waitForWebServerReady(testInstance, baseURL+webIntegrationStylesAssetPathConstant)
+	sessionToken := readWebLaunchSessionToken(testInstance, standardOutput)
 
-	indexDocument := readHTTPBody(testInstance, baseURL+webIntegrationIndexAssetPathConstant)
+	indexDocument := readHTTPBody(testInstance, baseURL+webIntegrationIndexAssetPathConstant, sessionToken)
 	require.Contains(testInstance, indexDocument, "https://cdn.jsdelivr.net/npm/wunderbaum@0/dist/wunderbaum.min.css")
 	require.Contains(testInstance, indexDocument, "/assets/styles.css")
 	require.Contains(testInstance, indexDocument, "/assets/app.js")
 	require.Contains(testInstance, indexDocument, webIntegrationExternalCDNHostConstant)
 
-	applicationScript := readHTTPBody(testInstance, baseURL+webIntegrationApplicationAssetPathConstant)
+	applicationScript := readHTTPBody(testInstance, baseURL+webIntegrationApplicationAssetPathConstant, sessionToken)
 	require.Contains(testInstance, applicationScript, "from \"./main.js\"")
 
-	mainScript := readHTTPBody(testInstance, baseURL+webIntegrationMainAssetPathConstant)
+	mainScript := readHTTPBody(testInstance, baseURL+webIntegrationMainAssetPathConstant, sessionToken)
 	require.Contains(testInstance, mainScript, "from \"./repo_tree.js\"")
 	require.Contains(testInstance, mainScript, "from \"./audit.js\"")
 
-	auditScript := readHTTPBody(testInstance, baseURL+webIntegrationAuditAssetPathConstant)
+	auditScript := readHTTPBody(testInstance, baseURL+webIntegrationAuditAssetPathConstant, sessionToken)
 	require.Contains(testInstance, auditScript, "from \"./shared.js\"")
 
-	repoTreeScript := readHTTPBody(testInstance, baseURL+webIntegrationRepoTreeAssetPathConstant)
+	repoTreeScript := readHTTPBody(testInstance, baseURL+webIntegrationRepoTreeAssetPathConstant, sessionToken)
 	require.Contains(testInstance, repoTreeScript, "from \"https://cdn.jsdelivr.net/npm/wunderbaum@0/+esm\"")
 
-	sharedScript := readHTTPBody(testInstance, baseURL+webIntegrationSharedAssetPathConstant)
+	sharedScript := readHTTPBody(testInstance, baseURL+webIntegrationSharedAssetPathConstant, sessionToken)
 	require.Contains(testInstance, sharedScript, "export const state = {")
 
-	stylesSheet := readHTTPBody(testInstance, baseURL+webIntegrationStylesAssetPathConstant)
+	stylesSheet := readHTTPBody(testInstance, baseURL+webIntegrationStylesAssetPathConstant, sessionToken)
 	require.Contains(testInstance, stylesSheet, ".repo-tree")
 
+	unauthenticatedResponse, unauthenticatedError := getAuthenticated(baseURL+webIntegrationRepositoriesAPIPathConstant, "")
+	require.NoError(testInstance, unauthenticatedError)
+	require.NoError(testInstance, unauthenticatedResponse.Body.Close())
+	require.Equal(testInstance, http.StatusUnauthorized, unauthenticatedResponse.StatusCode)
+
 	require.Contains(testInstance, standardOutput.String(), baseURL)
 	require.Empty(testInstance, strings.TrimSpace(standardError.String()))
 }
@@ -104,9 +113,10 @@ func TestWebBinaryHonorsExplicitBindAndPortFlags(testInstance *testing.T) {
 	standardOutput, standardError := startLongRunningCommand(testInstance, command)
 	defer stopLongRunningCommand(testInstance, command)
 
-	waitForWebServerReady(testInstance, baseURL+webIntegrationIndexAssetPathConstant)
+	waitForWebServerReady(testInstance, baseURL+webIntegrationStylesAssetPathConstant)
+	sessionToken := readWebLaunchSessionToken(testInstance, standardOutput)
 
-	indexDocument := readHTTPBody(testInstance, baseURL+webIntegrationIndexAssetPathConstant)
+	indexDocument := readHTTPBody(testInstance, baseURL+webIntegrationIndexAssetPathConstant, sessionToken)
 	require.Contains(testInstance, indexDocument, "<title>gix Audit Workspace</title>")
 	require.Contains(testInstance, standardOutput.String(), fmt.Sprintf("http://%s:%d", webIntegrationWildcardHostConstant, listenPort))
 	require.Empty(testInstance, strings.TrimSpace(standardError.String()))
@@ -137,9 +147,10 @@ func TestWebBinaryScopesInitialRepositoryCatalogToExplicitRoots(testInstance *te
 	standardOutput, standardError := startLongRunningCommand(testInstance, command)
 	defer stopLongRunningCommand(testInstance, command)
 
-	waitForWebServerReady(testInstance, baseURL+webIntegrationIndexAssetPathConstant)
+	waitForWebServerReady(testInstance, baseURL+webIntegrationStylesAssetPathConstant)
+	sessionToken := readWebLaunchSessionToken(testInstance, standardOutput)
 
-	repositoryCatalog := readRepositoryCatalog(testInstance, baseURL+webIntegrationRepositoriesAPIPathConstant)
+	repositoryCatalog := readRepositoryCatalog(testInstance, baseURL+webIntegrationRepositoriesAPIPathConstant, sessionToken)
 	require.Equal(testInstance, "configured_roots", repositoryCatalog.LaunchMode)
 	require.Equal(testInstance, canonicalFilesystemPath(testInstance, launchRoot), canonicalFilesystemPath(testInstance, repositoryCatalog.LaunchPath))
 	require.Len(testInstance, repositoryCatalog.LaunchRoots, 1)
@@ -177,9 +188,10 @@ func TestWebBinaryResolvesRelativeExplicitRootsWithoutUsingCurrentRepositoryCont
 	standardOutput, standardError := startLongRunningCommand(testInstance, command)
 	defer stopLongRunningCommand(testInstance, command)
 
-	waitForWebServerReady(testInstance, baseURL+webIntegrationIndexAssetPathConstant)
+	waitForWebServerReady(testInstance, baseURL+webIntegrationStylesAssetPathConstant)
+	sessionToken := readWebLaunchSessionToken(testInstance, standardOutput)
 
-	repositoryCatalog := readRepositoryCatalog(testInstance, baseURL+webIntegrationRepositoriesAPIPathConstant)
+	repositoryCatalog := readRepositoryCatalog(testInstance, baseURL+webIntegrationRepositoriesAPIPathConstant, sessionToken)
 	require.Equal(testInstance, "configured_roots", repositoryCatalog.LaunchMode)
 	require.Equal(testInstance, canonicalFilesystemPath(testInstance, launchRoot), canonicalFilesystemPath(testInstance, repositoryCatalog.LaunchPath))
 	require.Len(testInstance, repositoryCatalog.LaunchRoots, 1)
@@ -251,11 +263,28 @@ func waitForWebServerReady(testInstance *testing.T, endpoint string) {
 	testInstance.Fatalf("web server did not become ready at %s", endpoint)
 }
 
-func readHTTPBody(testInstance *testing.T, endpoint string) string {
+func readWebLaunchSessionToken(testInstance *testing.T, standardOutput *strings.Builder) string {
 	testInstance.Helper()
 
+	tokenMatch := webIntegrationSessionTokenPattern.FindStringSubmatch(standardOutput.String())
+	require.Len(testInstance, tokenMatch, 2, standardOutput.String())
+	return tokenMatch[1]
+}
+
+func getAuthenticated(endpoint string, sessionToken string) (*http.Response, error) {
+	request, requestError := http.NewRequest(http.MethodGet, endpoint, nil)
+	if requestError != nil {
+		return nil, requestError
+	}
+	request.Header.Set("Authorization", "Bearer "+sessionToken)
 	client := &http.Client{Timeout: webIntegrationRequestTimeoutConstant}
-	response, requestError := client.Get(endpoint)
+	return client.Do(request)
+}
+
+func readHTTPBody(testInstance *testing.T, endpoint string, sessionToken string) string {
+	testInstance.Helper()
+
+	response, requestError := getAuthenticated(endpoint, sessionToken)
 	require.NoError(testInstance, requestError)
 	defer response.Body.Close()
 	require.Equal(testInstance, http.StatusOK, response.StatusCode)
@@ -265,11 +294,10 @@ func readHTTPBody(testInstance *testing.T, endpoint string) string {
 	return string(bodyBytes)
 }
 
-func readRepositoryCatalog(testInstance *testing.T, endpoint string) web.RepositoryCatalog {
+func readRepositoryCatalog(testInstance *testing.T, endpoint string, sessionToken string) web.RepositoryCatalog {
 	testInstance.Helper()
 
-	client := &http.Client{Timeout: webIntegrationRequestTimeoutConstant}
-	response, requestError := client.Get(endpoint)
+	response, requestError := getAuthenticated(endpoint, sessionToken)
 	require.NoError(testInstance, requestError)
 	defer response.Body.Close()
 	require.Equal(testInstance, http.StatusOK, response.StatusCode)